@@ -0,0 +1,328 @@
+package binance
+
+import (
+	"context"
+
+	"github.com/adshao/go-binance/v2/common"
+)
+
+const (
+	WsApiMethodAccountStatus    WsApiMethodType = "account.status"
+	WsApiMethodOpenOrdersStatus WsApiMethodType = "openOrders.status"
+	WsApiMethodAllOrders        WsApiMethodType = "allOrders"
+	WsApiMethodMyTrades         WsApiMethodType = "myTrades"
+)
+
+// AccountStatusWsRequest parameters for 'account.status' websocket API
+type AccountStatusWsRequest struct {
+	omitZeroBalances *bool
+}
+
+// NewAccountStatusWsRequest init AccountStatusWsRequest
+func NewAccountStatusWsRequest() *AccountStatusWsRequest {
+	return &AccountStatusWsRequest{}
+}
+
+// OmitZeroBalances set omitZeroBalances
+func (s *AccountStatusWsRequest) OmitZeroBalances(omitZeroBalances bool) *AccountStatusWsRequest {
+	s.omitZeroBalances = &omitZeroBalances
+	return s
+}
+
+// buildParams builds params
+func (s *AccountStatusWsRequest) buildParams() params {
+	m := params{}
+	if s.omitZeroBalances != nil {
+		m["omitZeroBalances"] = *s.omitZeroBalances
+	}
+	return m
+}
+
+// AccountStatusWsResponse define 'account.status' websocket API response
+type AccountStatusWsResponse struct {
+	Id     string   `json:"id"`
+	Status int      `json:"status"`
+	Result *Account `json:"result"`
+
+	// error response
+	Error *common.APIError `json:"error,omitempty"`
+}
+
+// AccountStatusWsService account information over websocket API
+type AccountStatusWsService struct {
+	c *ClientWs
+}
+
+// NewAccountStatusWsService init AccountStatusWsService sharing the client connection
+func (c *ClientWs) NewAccountStatusWsService() *AccountStatusWsService {
+	return &AccountStatusWsService{c: c}
+}
+
+// Do - sends 'account.status' request
+func (s *AccountStatusWsService) Do(ctx context.Context, req *AccountStatusWsRequest) (*Account, error) {
+	rawResp, err := s.c.doRequest(ctx, WsApiMethodAccountStatus, req.buildParams(), true)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := AccountStatusWsResponse{}
+	if err := json.Unmarshal(rawResp, &resp); err != nil {
+		return nil, err
+	}
+	return resp.Result, nil
+}
+
+// OpenOrdersStatusWsRequest parameters for 'openOrders.status' websocket API
+type OpenOrdersStatusWsRequest struct {
+	symbol *string
+}
+
+// NewOpenOrdersStatusWsRequest init OpenOrdersStatusWsRequest
+func NewOpenOrdersStatusWsRequest() *OpenOrdersStatusWsRequest {
+	return &OpenOrdersStatusWsRequest{}
+}
+
+// Symbol set symbol, open orders of all symbols are returned if not set
+func (s *OpenOrdersStatusWsRequest) Symbol(symbol string) *OpenOrdersStatusWsRequest {
+	s.symbol = &symbol
+	return s
+}
+
+// buildParams builds params
+func (s *OpenOrdersStatusWsRequest) buildParams() params {
+	m := params{}
+	if s.symbol != nil {
+		m["symbol"] = *s.symbol
+	}
+	return m
+}
+
+// OrdersWsResponse define websocket API response with a list of orders
+type OrdersWsResponse struct {
+	Id     string   `json:"id"`
+	Status int      `json:"status"`
+	Result []*Order `json:"result"`
+
+	// error response
+	Error *common.APIError `json:"error,omitempty"`
+}
+
+// OpenOrdersStatusWsService open orders over websocket API
+type OpenOrdersStatusWsService struct {
+	c *ClientWs
+}
+
+// NewOpenOrdersStatusWsService init OpenOrdersStatusWsService sharing the client connection
+func (c *ClientWs) NewOpenOrdersStatusWsService() *OpenOrdersStatusWsService {
+	return &OpenOrdersStatusWsService{c: c}
+}
+
+// Do - sends 'openOrders.status' request
+func (s *OpenOrdersStatusWsService) Do(ctx context.Context, req *OpenOrdersStatusWsRequest) ([]*Order, error) {
+	rawResp, err := s.c.doRequest(ctx, WsApiMethodOpenOrdersStatus, req.buildParams(), true)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := OrdersWsResponse{}
+	if err := json.Unmarshal(rawResp, &resp); err != nil {
+		return nil, err
+	}
+	return resp.Result, nil
+}
+
+// AllOrdersWsRequest parameters for 'allOrders' websocket API
+type AllOrdersWsRequest struct {
+	symbol    string
+	orderID   *int64
+	startTime *int64
+	endTime   *int64
+	limit     *int
+}
+
+// NewAllOrdersWsRequest init AllOrdersWsRequest
+func NewAllOrdersWsRequest() *AllOrdersWsRequest {
+	return &AllOrdersWsRequest{}
+}
+
+// Symbol set symbol
+func (s *AllOrdersWsRequest) Symbol(symbol string) *AllOrdersWsRequest {
+	s.symbol = symbol
+	return s
+}
+
+// OrderID set orderID
+func (s *AllOrdersWsRequest) OrderID(orderID int64) *AllOrdersWsRequest {
+	s.orderID = &orderID
+	return s
+}
+
+// StartTime set startTime
+func (s *AllOrdersWsRequest) StartTime(startTime int64) *AllOrdersWsRequest {
+	s.startTime = &startTime
+	return s
+}
+
+// EndTime set endTime
+func (s *AllOrdersWsRequest) EndTime(endTime int64) *AllOrdersWsRequest {
+	s.endTime = &endTime
+	return s
+}
+
+// Limit set limit
+func (s *AllOrdersWsRequest) Limit(limit int) *AllOrdersWsRequest {
+	s.limit = &limit
+	return s
+}
+
+// buildParams builds params
+func (s *AllOrdersWsRequest) buildParams() params {
+	m := params{
+		"symbol": s.symbol,
+	}
+	if s.orderID != nil {
+		m["orderId"] = *s.orderID
+	}
+	if s.startTime != nil {
+		m["startTime"] = *s.startTime
+	}
+	if s.endTime != nil {
+		m["endTime"] = *s.endTime
+	}
+	if s.limit != nil {
+		m["limit"] = *s.limit
+	}
+	return m
+}
+
+// AllOrdersWsService order history over websocket API
+type AllOrdersWsService struct {
+	c *ClientWs
+}
+
+// NewAllOrdersWsService init AllOrdersWsService sharing the client connection
+func (c *ClientWs) NewAllOrdersWsService() *AllOrdersWsService {
+	return &AllOrdersWsService{c: c}
+}
+
+// Do - sends 'allOrders' request
+func (s *AllOrdersWsService) Do(ctx context.Context, req *AllOrdersWsRequest) ([]*Order, error) {
+	rawResp, err := s.c.doRequest(ctx, WsApiMethodAllOrders, req.buildParams(), true)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := OrdersWsResponse{}
+	if err := json.Unmarshal(rawResp, &resp); err != nil {
+		return nil, err
+	}
+	return resp.Result, nil
+}
+
+// MyTradesWsRequest parameters for 'myTrades' websocket API
+type MyTradesWsRequest struct {
+	symbol    string
+	orderID   *int64
+	startTime *int64
+	endTime   *int64
+	fromID    *int64
+	limit     *int
+}
+
+// NewMyTradesWsRequest init MyTradesWsRequest
+func NewMyTradesWsRequest() *MyTradesWsRequest {
+	return &MyTradesWsRequest{}
+}
+
+// Symbol set symbol
+func (s *MyTradesWsRequest) Symbol(symbol string) *MyTradesWsRequest {
+	s.symbol = symbol
+	return s
+}
+
+// OrderID set orderID
+func (s *MyTradesWsRequest) OrderID(orderID int64) *MyTradesWsRequest {
+	s.orderID = &orderID
+	return s
+}
+
+// StartTime set startTime
+func (s *MyTradesWsRequest) StartTime(startTime int64) *MyTradesWsRequest {
+	s.startTime = &startTime
+	return s
+}
+
+// EndTime set endTime
+func (s *MyTradesWsRequest) EndTime(endTime int64) *MyTradesWsRequest {
+	s.endTime = &endTime
+	return s
+}
+
+// FromID set fromID
+func (s *MyTradesWsRequest) FromID(fromID int64) *MyTradesWsRequest {
+	s.fromID = &fromID
+	return s
+}
+
+// Limit set limit
+func (s *MyTradesWsRequest) Limit(limit int) *MyTradesWsRequest {
+	s.limit = &limit
+	return s
+}
+
+// buildParams builds params
+func (s *MyTradesWsRequest) buildParams() params {
+	m := params{
+		"symbol": s.symbol,
+	}
+	if s.orderID != nil {
+		m["orderId"] = *s.orderID
+	}
+	if s.startTime != nil {
+		m["startTime"] = *s.startTime
+	}
+	if s.endTime != nil {
+		m["endTime"] = *s.endTime
+	}
+	if s.fromID != nil {
+		m["fromId"] = *s.fromID
+	}
+	if s.limit != nil {
+		m["limit"] = *s.limit
+	}
+	return m
+}
+
+// MyTradesWsResponse define 'myTrades' websocket API response
+type MyTradesWsResponse struct {
+	Id     string     `json:"id"`
+	Status int        `json:"status"`
+	Result []*TradeV3 `json:"result"`
+
+	// error response
+	Error *common.APIError `json:"error,omitempty"`
+}
+
+// MyTradesWsService account trade list over websocket API
+type MyTradesWsService struct {
+	c *ClientWs
+}
+
+// NewMyTradesWsService init MyTradesWsService sharing the client connection
+func (c *ClientWs) NewMyTradesWsService() *MyTradesWsService {
+	return &MyTradesWsService{c: c}
+}
+
+// Do - sends 'myTrades' request
+func (s *MyTradesWsService) Do(ctx context.Context, req *MyTradesWsRequest) ([]*TradeV3, error) {
+	rawResp, err := s.c.doRequest(ctx, WsApiMethodMyTrades, req.buildParams(), true)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := MyTradesWsResponse{}
+	if err := json.Unmarshal(rawResp, &resp); err != nil {
+		return nil, err
+	}
+	return resp.Result, nil
+}
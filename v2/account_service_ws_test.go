@@ -0,0 +1,135 @@
+package binance
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type accountWsServiceTestSuite struct {
+	baseWsApiTestSuite
+}
+
+func TestAccountWsService(t *testing.T) {
+	suite.Run(t, new(accountWsServiceTestSuite))
+}
+
+func (s *accountWsServiceTestSuite) TestAccountStatus() {
+	s.respond(WsApiMethodAccountStatus, `{
+		"makerCommission": 15,
+		"takerCommission": 15,
+		"canTrade": true,
+		"canWithdraw": true,
+		"canDeposit": true,
+		"updateTime": 1660801833000,
+		"accountType": "SPOT",
+		"balances": [{"asset": "BNB", "free": "0.00000000", "locked": "0.00000000"}],
+		"permissions": ["SPOT"]
+	}`)
+
+	res, err := s.wsClient.NewAccountStatusWsService().Do(newContext(), NewAccountStatusWsRequest().OmitZeroBalances(true))
+	r := s.r()
+	r.NoError(err)
+	r.Equal(int64(15), res.MakerCommission)
+	r.True(res.CanTrade)
+	r.Equal("SPOT", res.AccountType)
+	r.Equal([]Balance{{Asset: "BNB", Free: "0.00000000", Locked: "0.00000000"}}, res.Balances)
+
+	p := s.lastRequest().Params
+	r.Equal(true, p["omitZeroBalances"])
+	r.Equal(s.apiKey, p[apiKey])
+	r.NotEmpty(p[timestampKey])
+	r.NotEmpty(p[signatureKey])
+}
+
+func (s *accountWsServiceTestSuite) TestOpenOrdersStatus() {
+	s.respond(WsApiMethodOpenOrdersStatus, `[{
+		"symbol": "BNBBTC",
+		"orderId": 12569099453,
+		"orderListId": -1,
+		"clientOrderId": "4d96324ff9d44481926157",
+		"price": "0.02000000",
+		"origQty": "1.00000000",
+		"executedQty": "0.00000000",
+		"cummulativeQuoteQty": "0.00000000",
+		"status": "NEW",
+		"timeInForce": "GTC",
+		"type": "LIMIT",
+		"side": "SELL",
+		"stopPrice": "0.00000000",
+		"icebergQty": "0.00000000",
+		"time": 1660801715639,
+		"updateTime": 1660801715639,
+		"isWorking": true,
+		"origQuoteOrderQty": "0.00000000"
+	}]`)
+
+	res, err := s.wsClient.NewOpenOrdersStatusWsService().Do(newContext(), NewOpenOrdersStatusWsRequest().Symbol("BNBBTC"))
+	r := s.r()
+	r.NoError(err)
+	r.Len(res, 1)
+	r.Equal(int64(12569099453), res[0].OrderID)
+	r.Equal(OrderStatusTypeNew, res[0].Status)
+	r.Equal(SideTypeSell, res[0].Side)
+	r.Equal("BNBBTC", s.lastRequest().Params["symbol"])
+}
+
+func (s *accountWsServiceTestSuite) TestAllOrders() {
+	s.respond(WsApiMethodAllOrders, `[{"symbol":"BNBBTC","orderId":12569099453,"status":"FILLED","side":"BUY"}]`)
+
+	res, err := s.wsClient.NewAllOrdersWsService().Do(newContext(), NewAllOrdersWsRequest().
+		Symbol("BNBBTC").StartTime(1660780800000).Limit(5))
+	r := s.r()
+	r.NoError(err)
+	r.Len(res, 1)
+	r.Equal(OrderStatusTypeFilled, res[0].Status)
+
+	p := s.lastRequest().Params
+	r.Equal("BNBBTC", p["symbol"])
+	r.Equal(float64(1660780800000), p["startTime"])
+	r.Equal(float64(5), p["limit"])
+	r.NotContains(p, "orderId")
+}
+
+func (s *accountWsServiceTestSuite) TestMyTrades() {
+	s.respond(WsApiMethodMyTrades, `[{
+		"symbol": "BNBBTC",
+		"id": 1650422481,
+		"orderId": 12569099453,
+		"orderListId": -1,
+		"price": "0.02000000",
+		"qty": "1.00000000",
+		"quoteQty": "0.02000000",
+		"commission": "0.00000012",
+		"commissionAsset": "BNB",
+		"time": 1660801715793,
+		"isBuyer": false,
+		"isMaker": true,
+		"isBestMatch": true
+	}]`)
+
+	res, err := s.wsClient.NewMyTradesWsService().Do(newContext(), NewMyTradesWsRequest().
+		Symbol("BNBBTC").FromID(1650422481))
+	r := s.r()
+	r.NoError(err)
+	r.Equal([]*TradeV3{{
+		ID:              1650422481,
+		Symbol:          "BNBBTC",
+		OrderID:         12569099453,
+		OrderListId:     -1,
+		Price:           "0.02000000",
+		Quantity:        "1.00000000",
+		QuoteQuantity:   "0.02000000",
+		Commission:      "0.00000012",
+		CommissionAsset: "BNB",
+		Time:            1660801715793,
+		IsMaker:         true,
+		IsBestMatch:     true,
+	}}, res)
+	r.Equal(float64(1650422481), s.lastRequest().Params["fromId"])
+}
+
+func (s *accountWsServiceTestSuite) TestError() {
+	_, err := s.wsClient.NewMyTradesWsService().Do(newContext(), NewMyTradesWsRequest().Symbol("BNBBTC"))
+	s.r().Error(err)
+}
@@ -0,0 +1,236 @@
+package binance
+
+import (
+	"context"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/adshao/go-binance/v2/futures"
+)
+
+// BalanceWallet define wallet watched by a BalanceWatcher
+type BalanceWallet string
+
+// Wallets watched by a BalanceWatcher
+const (
+	BalanceWalletSpot    BalanceWallet = "SPOT"
+	BalanceWalletFutures BalanceWallet = "FUTURES"
+)
+
+// BalanceThreshold define level of free balance of an asset in a wallet
+type BalanceThreshold struct {
+	Wallet BalanceWallet
+	Asset  string
+	Level  float64
+}
+
+// BalanceCrossing define free balance crossing a BalanceThreshold
+type BalanceCrossing struct {
+	BalanceThreshold
+	Previous float64
+	Free     float64
+	// Above is true when the balance rose to or above Level, false when it fell below
+	Above bool
+}
+
+// BalanceCrossingHandler handle BalanceCrossing
+type BalanceCrossingHandler func(crossing BalanceCrossing)
+
+type balanceKey struct {
+	wallet BalanceWallet
+	asset  string
+}
+
+// BalanceWatcher polls free balances of the spot wallet and the available balances of the
+// USDⓈ-M futures wallet, and calls handlers when a balance crosses one of the thresholds, e.g.
+// to trigger treasury rebalancing. The first balance read of an asset only sets its level.
+// Spot balances can also be fed from the user data stream with HandleUserDataEvent.
+type BalanceWatcher struct {
+	c  *Client
+	fc *futures.Client
+
+	mu         sync.Mutex
+	thresholds []BalanceThreshold
+	handlers   []BalanceCrossingHandler
+	balances   map[balanceKey]float64
+	stopC      chan struct{}
+	doneC      chan struct{}
+}
+
+// NewBalanceWatcher init BalanceWatcher reading spot balances with c and futures balances with
+// fc, fc may be nil when only spot thresholds are set
+func NewBalanceWatcher(c *Client, fc *futures.Client) *BalanceWatcher {
+	return &BalanceWatcher{
+		c:        c,
+		fc:       fc,
+		balances: make(map[balanceKey]float64),
+	}
+}
+
+// Threshold adds threshold at level for free balance of asset in wallet
+func (w *BalanceWatcher) Threshold(wallet BalanceWallet, asset string, level float64) *BalanceWatcher {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	w.thresholds = append(w.thresholds, BalanceThreshold{Wallet: wallet, Asset: asset, Level: level})
+	return w
+}
+
+// OnCross registers handler called when a balance crosses a threshold
+func (w *BalanceWatcher) OnCross(handler BalanceCrossingHandler) *BalanceWatcher {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	w.handlers = append(w.handlers, handler)
+	return w
+}
+
+// Free returns last read free balance of asset in wallet
+func (w *BalanceWatcher) Free(wallet BalanceWallet, asset string) (float64, bool) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	v, ok := w.balances[balanceKey{wallet: wallet, asset: asset}]
+	return v, ok
+}
+
+// Update reads the balances of the wallets having thresholds
+func (w *BalanceWatcher) Update(ctx context.Context) error {
+	w.mu.Lock()
+	var spot, perp bool
+	for _, t := range w.thresholds {
+		spot = spot || t.Wallet == BalanceWalletSpot
+		perp = perp || t.Wallet == BalanceWalletFutures
+	}
+	w.mu.Unlock()
+
+	if spot {
+		account, err := w.c.NewGetAccountService().Do(ctx)
+		if err != nil {
+			return err
+		}
+		balances := make(map[string]string, len(account.Balances))
+		for _, b := range account.Balances {
+			balances[b.Asset] = b.Free
+		}
+		if err = w.set(BalanceWalletSpot, balances); err != nil {
+			return err
+		}
+	}
+	if perp {
+		res, err := w.fc.NewGetBalanceService().Do(ctx)
+		if err != nil {
+			return err
+		}
+		balances := make(map[string]string, len(res))
+		for _, b := range res {
+			balances[b.Asset] = b.AvailableBalance
+		}
+		if err = w.set(BalanceWalletFutures, balances); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// HandleUserDataEvent applies spot balances of outboundAccountPosition events
+func (w *BalanceWatcher) HandleUserDataEvent(event *WsUserDataEvent) {
+	if event.Event != UserDataEventTypeOutboundAccountPosition {
+		return
+	}
+	balances := make(map[string]string, len(event.AccountUpdate.WsAccountUpdates))
+	for _, b := range event.AccountUpdate.WsAccountUpdates {
+		balances[b.Asset] = b.Free
+	}
+	_ = w.set(BalanceWalletSpot, balances)
+}
+
+// Start runs Update every interval in background until Stop is called, errors go to errHandler
+func (w *BalanceWatcher) Start(interval time.Duration, errHandler ErrHandler) {
+	w.mu.Lock()
+	if w.stopC != nil {
+		w.mu.Unlock()
+		return
+	}
+	w.stopC = make(chan struct{})
+	w.doneC = make(chan struct{})
+	stopC, doneC := w.stopC, w.doneC
+	w.mu.Unlock()
+
+	go func() {
+		defer close(doneC)
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-stopC:
+				return
+			case <-ticker.C:
+				ctx, cancel := context.WithTimeout(context.Background(), interval)
+				if err := w.Update(ctx); err != nil && errHandler != nil {
+					errHandler(err)
+				}
+				cancel()
+			}
+		}
+	}()
+}
+
+// Stop stops scheduled updates
+func (w *BalanceWatcher) Stop() {
+	w.mu.Lock()
+	stopC, doneC := w.stopC, w.doneC
+	w.stopC, w.doneC = nil, nil
+	w.mu.Unlock()
+
+	if stopC == nil {
+		return
+	}
+	close(stopC)
+	<-doneC
+}
+
+// set records free balances of wallet and calls handlers of the thresholds crossed
+func (w *BalanceWatcher) set(wallet BalanceWallet, balances map[string]string) error {
+	w.mu.Lock()
+	var crossings []BalanceCrossing
+	for _, t := range w.thresholds {
+		if t.Wallet != wallet {
+			continue
+		}
+		v, ok := balances[t.Asset]
+		if !ok {
+			continue
+		}
+		free, err := strconv.ParseFloat(v, 64)
+		if err != nil {
+			w.mu.Unlock()
+			return err
+		}
+		key := balanceKey{wallet: wallet, asset: t.Asset}
+		previous, seen := w.balances[key]
+		if seen && (previous < t.Level) != (free < t.Level) {
+			crossings = append(crossings, BalanceCrossing{
+				BalanceThreshold: t,
+				Previous:         previous,
+				Free:             free,
+				Above:            free >= t.Level,
+			})
+		}
+	}
+	for asset, v := range balances {
+		if free, err := strconv.ParseFloat(v, 64); err == nil {
+			w.balances[balanceKey{wallet: wallet, asset: asset}] = free
+		}
+	}
+	handlers := w.handlers
+	w.mu.Unlock()
+
+	for _, c := range crossings {
+		for _, handler := range handlers {
+			handler(c)
+		}
+	}
+	return nil
+}
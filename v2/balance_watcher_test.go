@@ -0,0 +1,67 @@
+package binance
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/adshao/go-binance/v2/futures"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBalanceWatcher(t *testing.T) {
+	assert := assert.New(t)
+
+	spotFree, futuresAvailable := "1000", "500"
+	do := func(req *http.Request) (*http.Response, error) {
+		switch req.URL.Path {
+		case "/api/v3/account":
+			return newHTTPResponse([]byte(`{"balances":[{"asset":"USDT","free":"`+spotFree+`","locked":"0"}]}`), http.StatusOK), nil
+		case "/fapi/v2/balance":
+			return newHTTPResponse([]byte(`[{"asset":"USDT","balance":"600","availableBalance":"`+futuresAvailable+`"}]`), http.StatusOK), nil
+		}
+		return newHTTPResponse([]byte(`{}`), http.StatusNotFound), nil
+	}
+	c := NewClient("apiKey", "secretKey")
+	c.do = do
+	fc := futures.NewClient("apiKey", "secretKey")
+	fc.HTTPClient = &http.Client{Transport: roundTripFunc(do)}
+
+	var crossings []BalanceCrossing
+	w := NewBalanceWatcher(c, fc).
+		Threshold(BalanceWalletSpot, "USDT", 800).
+		Threshold(BalanceWalletFutures, "USDT", 200).
+		OnCross(func(crossing BalanceCrossing) {
+			crossings = append(crossings, crossing)
+		})
+
+	assert.NoError(w.Update(newContext()))
+	assert.Empty(crossings, "first read only sets the level")
+	free, ok := w.Free(BalanceWalletFutures, "USDT")
+	assert.True(ok)
+	assert.Equal(500.0, free)
+
+	spotFree, futuresAvailable = "700", "300"
+	assert.NoError(w.Update(newContext()))
+	if assert.Len(crossings, 1) {
+		assert.Equal(BalanceCrossing{
+			BalanceThreshold: BalanceThreshold{Wallet: BalanceWalletSpot, Asset: "USDT", Level: 800},
+			Previous:         1000,
+			Free:             700,
+		}, crossings[0])
+	}
+
+	crossings = nil
+	assert.NoError(w.Update(newContext()))
+	assert.Empty(crossings, "no crossing while staying below")
+
+	w.HandleUserDataEvent(&WsUserDataEvent{
+		Event: UserDataEventTypeOutboundAccountPosition,
+		AccountUpdate: WsAccountUpdateList{WsAccountUpdates: []WsAccountUpdate{
+			{Asset: "USDT", Free: "900"},
+		}},
+	})
+	if assert.Len(crossings, 1) {
+		assert.True(crossings[0].Above)
+		assert.Equal(900.0, crossings[0].Free)
+	}
+}
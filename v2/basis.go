@@ -0,0 +1,196 @@
+package binance
+
+import (
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/adshao/go-binance/v2/futures"
+)
+
+const (
+	defaultBasisHistorySize = 1000
+	// defaultBasisPeriod is the funding interval a perp premium is annualized over
+	defaultBasisPeriod = 8 * time.Hour
+	yearDuration       = 365 * 24 * time.Hour
+)
+
+// BasisPoint define spot-perp basis of a symbol at a time
+type BasisPoint struct {
+	Symbol string
+	Time   time.Time
+	// SpotPrice is the spot mid price, PerpPrice the mark price or the futures mid price before
+	// any mark price was received
+	SpotPrice float64
+	PerpPrice float64
+	// Basis is PerpPrice minus SpotPrice, BasisRate the basis relative to SpotPrice
+	Basis     float64
+	BasisRate float64
+	// AnnualizedRate is BasisRate compounded simply over a year of periods
+	AnnualizedRate float64
+}
+
+// BasisHandler handle BasisPoint
+type BasisHandler func(point BasisPoint)
+
+type basisSymbol struct {
+	spot      float64
+	perpMid   float64
+	markPrice float64
+	history   []BasisPoint
+	next      int
+}
+
+// BasisCalculator joins spot book tickers with futures mark prices and book tickers and
+// publishes the basis of each symbol on every update, keeping a history of the last points.
+// Pass it the events of the spot bookTicker and futures markPrice/bookTicker streams.
+type BasisCalculator struct {
+	historySize int
+	period      time.Duration
+
+	mu       sync.Mutex
+	symbols  map[string]*basisSymbol
+	handlers []BasisHandler
+}
+
+// NewBasisCalculator init BasisCalculator keeping historySize points per symbol and annualizing
+// the basis rate over period, zero values use 1000 points and the 8h funding interval
+func NewBasisCalculator(historySize int, period time.Duration) *BasisCalculator {
+	if historySize <= 0 {
+		historySize = defaultBasisHistorySize
+	}
+	if period <= 0 {
+		period = defaultBasisPeriod
+	}
+	return &BasisCalculator{
+		historySize: historySize,
+		period:      period,
+		symbols:     make(map[string]*basisSymbol),
+	}
+}
+
+// OnBasis registers handler called with each new BasisPoint
+func (b *BasisCalculator) OnBasis(handler BasisHandler) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.handlers = append(b.handlers, handler)
+}
+
+// HandleSpotBookTicker applies spot bookTicker event
+func (b *BasisCalculator) HandleSpotBookTicker(event *WsBookTickerEvent) {
+	mid, ok := midPrice(event.BestBidPrice, event.BestAskPrice)
+	if !ok {
+		return
+	}
+	b.update(event.Symbol, func(s *basisSymbol) {
+		s.spot = mid
+	})
+}
+
+// HandleFuturesBookTicker applies futures bookTicker event
+func (b *BasisCalculator) HandleFuturesBookTicker(event *futures.WsBookTickerEvent) {
+	mid, ok := midPrice(event.BestBidPrice, event.BestAskPrice)
+	if !ok {
+		return
+	}
+	b.update(event.Symbol, func(s *basisSymbol) {
+		s.perpMid = mid
+	})
+}
+
+// HandleFuturesMarkPrice applies futures markPrice event
+func (b *BasisCalculator) HandleFuturesMarkPrice(event *futures.WsMarkPriceEvent) {
+	markPrice, err := strconv.ParseFloat(event.MarkPrice, 64)
+	if err != nil || markPrice <= 0 {
+		return
+	}
+	b.update(event.Symbol, func(s *basisSymbol) {
+		s.markPrice = markPrice
+	})
+}
+
+// Basis returns the last BasisPoint of symbol
+func (b *BasisCalculator) Basis(symbol string) (BasisPoint, bool) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	s, ok := b.symbols[symbol]
+	if !ok || len(s.history) == 0 {
+		return BasisPoint{}, false
+	}
+	last := (s.next - 1 + len(s.history)) % len(s.history)
+	return s.history[last], true
+}
+
+// History returns the kept BasisPoints of symbol, oldest first
+func (b *BasisCalculator) History(symbol string) []BasisPoint {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	res := make([]BasisPoint, 0)
+	s, ok := b.symbols[symbol]
+	if !ok {
+		return res
+	}
+	if len(s.history) == b.historySize {
+		res = append(res, s.history[s.next:]...)
+		return append(res, s.history[:s.next]...)
+	}
+	return append(res, s.history...)
+}
+
+// update applies change to symbol and publishes its basis once both prices are known
+func (b *BasisCalculator) update(symbol string, change func(s *basisSymbol)) {
+	b.mu.Lock()
+	s, ok := b.symbols[symbol]
+	if !ok {
+		s = &basisSymbol{}
+		b.symbols[symbol] = s
+	}
+	change(s)
+
+	perp := s.markPrice
+	if perp == 0 {
+		perp = s.perpMid
+	}
+	if s.spot == 0 || perp == 0 {
+		b.mu.Unlock()
+		return
+	}
+	point := BasisPoint{
+		Symbol:    symbol,
+		Time:      time.Now(),
+		SpotPrice: s.spot,
+		PerpPrice: perp,
+		Basis:     perp - s.spot,
+	}
+	point.BasisRate = point.Basis / s.spot
+	point.AnnualizedRate = point.BasisRate * float64(yearDuration) / float64(b.period)
+
+	if len(s.history) < b.historySize {
+		s.history = append(s.history, point)
+		s.next = len(s.history) % b.historySize
+	} else {
+		s.history[s.next] = point
+		s.next = (s.next + 1) % b.historySize
+	}
+	handlers := b.handlers
+	b.mu.Unlock()
+
+	for _, handler := range handlers {
+		handler(point)
+	}
+}
+
+func midPrice(bid, ask string) (float64, bool) {
+	bidPrice, err := strconv.ParseFloat(bid, 64)
+	if err != nil || bidPrice <= 0 {
+		return 0, false
+	}
+	askPrice, err := strconv.ParseFloat(ask, 64)
+	if err != nil || askPrice <= 0 {
+		return 0, false
+	}
+	return (bidPrice + askPrice) / 2, true
+}
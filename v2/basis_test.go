@@ -0,0 +1,52 @@
+package binance
+
+import (
+	"testing"
+	"time"
+
+	"github.com/adshao/go-binance/v2/futures"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBasisCalculator(t *testing.T) {
+	assert := assert.New(t)
+
+	b := NewBasisCalculator(2, 0)
+	var points []BasisPoint
+	b.OnBasis(func(point BasisPoint) {
+		points = append(points, point)
+	})
+
+	b.HandleSpotBookTicker(&WsBookTickerEvent{Symbol: "BTCUSDT", BestBidPrice: "99", BestAskPrice: "101"})
+	_, ok := b.Basis("BTCUSDT")
+	assert.False(ok, "no perp price yet")
+
+	b.HandleFuturesBookTicker(&futures.WsBookTickerEvent{Symbol: "BTCUSDT", BestBidPrice: "100.5", BestAskPrice: "100.7"})
+	point, ok := b.Basis("BTCUSDT")
+	assert.True(ok)
+	assert.Equal(100.6, point.PerpPrice)
+	assert.InDelta(0.6, point.Basis, 1e-9)
+
+	b.HandleFuturesMarkPrice(&futures.WsMarkPriceEvent{Symbol: "BTCUSDT", MarkPrice: "101"})
+	point, _ = b.Basis("BTCUSDT")
+	assert.Equal(101.0, point.PerpPrice, "mark price is preferred")
+	assert.Equal(1.0, point.Basis)
+	assert.Equal(0.01, point.BasisRate)
+	assert.InDelta(0.01*3*365, point.AnnualizedRate, 1e-9)
+
+	b.HandleSpotBookTicker(&WsBookTickerEvent{Symbol: "BTCUSDT", BestBidPrice: "100", BestAskPrice: "102"})
+	history := b.History("BTCUSDT")
+	if assert.Len(history, 2) {
+		assert.Equal(100.0, history[0].SpotPrice)
+		assert.Equal(101.0, history[1].SpotPrice)
+		assert.Equal(0.0, history[1].Basis)
+	}
+	assert.Len(points, 3)
+	assert.Empty(b.History("ETHUSDT"))
+
+	b = NewBasisCalculator(10, 24*time.Hour)
+	b.HandleSpotBookTicker(&WsBookTickerEvent{Symbol: "BTCUSDT", BestBidPrice: "100", BestAskPrice: "100"})
+	b.HandleFuturesMarkPrice(&futures.WsMarkPriceEvent{Symbol: "BTCUSDT", MarkPrice: "99"})
+	point, _ = b.Basis("BTCUSDT")
+	assert.InDelta(-0.01*365, point.AnnualizedRate, 1e-9)
+}
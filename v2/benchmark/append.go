@@ -0,0 +1,131 @@
+package benchmark
+
+import (
+	"context"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"slices"
+	"strconv"
+)
+
+// appendHeader are the columns prepended to the results appended to a results file
+var appendHeader = []string{"run_id", "endpoint", "checkpoint"}
+
+// resultsFile define a CSV file the results of runs are appended to. Rows are tagged with the id
+// of their run, their endpoint and the number of tests of the plan done once they were written,
+// so an interrupted run can resume after its last checkpoint.
+type resultsFile struct {
+	path  string
+	runID string
+	// header is the header of the file, nil if the file has none yet
+	header []string
+	// checkpoints are the tests of the run already done per endpoint
+	checkpoints map[string]int
+}
+
+// openResultsFile reads the checkpoints of run runID from the results file at path, if it exists
+func openResultsFile(path, runID string) (*resultsFile, error) {
+	f := &resultsFile{path: path, runID: runID, checkpoints: make(map[string]int)}
+	file, err := os.Open(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return f, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	r := csv.NewReader(file)
+	header, err := r.Read()
+	if errors.Is(err, io.EOF) {
+		return f, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if len(header) < len(appendHeader) || !slices.Equal(header[:len(appendHeader)], appendHeader) {
+		return nil, fmt.Errorf("%s is not a results file, its columns don't start with %v", path, appendHeader)
+	}
+	f.header = header
+	for {
+		row, err := r.Read()
+		if errors.Is(err, io.EOF) {
+			return f, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		if row[0] != runID {
+			continue
+		}
+		checkpoint, err := strconv.Atoi(row[2])
+		if err != nil {
+			return nil, fmt.Errorf("invalid checkpoint %q in %s", row[2], path)
+		}
+		f.checkpoints[row[1]] = max(f.checkpoints[row[1]], checkpoint)
+	}
+}
+
+// append writes rows of endpoint, done once checkpoint tests were, preceded by the header if the
+// file has none yet
+func (f *resultsFile) append(header []string, endpoint string, checkpoint int, rows [][]string) error {
+	header = append(slices.Clone(appendHeader), header...)
+	if f.header != nil && !slices.Equal(f.header, header) {
+		return fmt.Errorf("columns of %s differ from the ones of the run: %v", f.path, header)
+	}
+	file, err := os.OpenFile(f.path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o644)
+	if err != nil {
+		return err
+	}
+	w := csv.NewWriter(file)
+	if f.header == nil {
+		if err := w.Write(header); err != nil {
+			file.Close()
+			return err
+		}
+	}
+	for _, row := range rows {
+		if err := w.Write(append([]string{f.runID, endpoint, strconv.Itoa(checkpoint)}, row...)); err != nil {
+			file.Close()
+			return err
+		}
+	}
+	w.Flush()
+	if err := errors.Join(w.Error(), file.Close()); err != nil {
+		return err
+	}
+	f.header = header
+	return nil
+}
+
+// runAppend runs the tests of mode after the checkpoint of the endpoint of b in f, checkpoint
+// tests at a time, and appends the results of every batch to f as soon as it's done. Batches
+// without results are run again on resume.
+func (b *benchmark) runAppend(ctx context.Context, f *resultsFile, checkpoint int, mode string, tests []placeOrderParam, apiKey, secretKey string) ([]string, [][]string, error) {
+	var (
+		header []string
+		data   [][]string
+	)
+	done := f.checkpoints[b.endpoint.Name]
+	if done > 0 {
+		b.l.Infow("Resuming run", "runID", f.runID, "done", done, "tests", len(tests))
+	}
+	for start := done; start < len(tests); start += checkpoint {
+		end := min(start+checkpoint, len(tests))
+		h, rows, err := b.run(ctx, mode, tests[start:end], apiKey, secretKey)
+		if err != nil {
+			return nil, nil, err
+		}
+		if err := f.append(h, b.endpoint.Name, end, rows); err != nil {
+			b.l.Errorw("Failed to append results", "file", f.path, "err", err)
+			return nil, nil, err
+		}
+		header = h
+		data = append(data, rows...)
+	}
+	return header, data, nil
+}
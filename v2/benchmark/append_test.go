@@ -0,0 +1,53 @@
+package benchmark
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestResultsFileResume(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "results.csv")
+	header := []string{"symbol", "ws_latency"}
+
+	f, err := openResultsFile(path, "run1")
+	require.NoError(t, err)
+	assert.Empty(t, f.checkpoints)
+	require.NoError(t, f.append(header, "default", 2, [][]string{{"BTCUSDT", "3"}, {"ETHUSDT", "4"}}))
+	require.NoError(t, f.append(header, "default", 4, [][]string{{"BTCUSDT", "5"}, {"ETHUSDT", "6"}}))
+	require.NoError(t, f.append(header, "eth1", 2, [][]string{{"BTCUSDT", "7"}}))
+
+	other, err := openResultsFile(path, "run2")
+	require.NoError(t, err)
+	require.NoError(t, other.append(header, "default", 6, [][]string{{"BTCUSDT", "8"}}))
+
+	// a resumed run continues after the last checkpoint of every endpoint
+	f, err = openResultsFile(path, "run1")
+	require.NoError(t, err)
+	assert.Equal(t, map[string]int{"default": 4, "eth1": 2}, f.checkpoints)
+	assert.Equal(t, append(append([]string{}, appendHeader...), header...), f.header)
+
+	raw, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Equal(t, "run_id,endpoint,checkpoint,symbol,ws_latency\n"+
+		"run1,default,2,BTCUSDT,3\nrun1,default,2,ETHUSDT,4\n"+
+		"run1,default,4,BTCUSDT,5\nrun1,default,4,ETHUSDT,6\n"+
+		"run1,eth1,2,BTCUSDT,7\nrun2,default,6,BTCUSDT,8\n", string(raw))
+
+	// results of another mode don't fit the file
+	assert.Error(t, f.append([]string{"symbol", "rest_latency"}, "default", 6, nil))
+}
+
+func TestOpenResultsFileInvalid(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "results.csv")
+	require.NoError(t, os.WriteFile(path, []byte("symbol,ws_latency\nBTCUSDT,3\n"), 0o644))
+	_, err := openResultsFile(path, "run1")
+	assert.Error(t, err)
+
+	require.NoError(t, os.WriteFile(path, []byte("run_id,endpoint,checkpoint,symbol\nrun1,default,x,BTCUSDT\n"), 0o644))
+	_, err = openResultsFile(path, "run1")
+	assert.Error(t, err)
+}
@@ -0,0 +1,822 @@
+// Package benchmark measures the latency of futures orders placed through the websocket API and
+// the REST API at once, so services can embed latency probes. cmd/benchmark is its command line.
+package benchmark
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/adshao/go-binance/v2"
+	"github.com/adshao/go-binance/v2/futures"
+	"go.uber.org/zap"
+	"golang.org/x/exp/rand"
+)
+
+const (
+	// ModePlace measures order placement latency
+	ModePlace = "place"
+	// ModeCancel measures order cancel latency
+	ModeCancel = "cancel"
+	// ModeRoundTrip measures the round trip of placing an order and canceling it once acknowledged
+	ModeRoundTrip = "roundtrip"
+	// ModeModify measures order modify latency
+	ModeModify = "modify"
+	// ModeCompare measures order placement latency of futures and spot
+	ModeCompare = "compare"
+	// ModeDaemon places probe orders until stopped and exports their latency to Prometheus
+	ModeDaemon = "daemon"
+	// ModeSigning measures order placement latency of HMAC and Ed25519 API keys
+	ModeSigning = "signing"
+	// ModeSweep measures websocket order placement latency over several connections and concurrencies
+	ModeSweep = "sweep"
+)
+
+// orderHeader define the columns of place and cancel results, the error columns are empty if the
+// requests succeeded
+var orderHeader = []string{"symbol", "qty", "price", "side", "tif", "ws_latency", "rest_latency", "ws_error", "rest_error"}
+
+// placeHeader define the columns of place results
+var placeHeader = append(append([]string{}, orderHeader...), streamHeader...)
+
+// respTypeHeader define the columns of place results with AckVsResult
+var respTypeHeader = append([]string{
+	"symbol", "qty", "price", "side", "tif", "resp_type", "ws_latency", "rest_latency", "ws_rtt", "rest_rtt", "ws_error", "rest_error",
+}, streamHeader...)
+
+// Config define a run, DefaultConfig returns the defaults of the benchmark command
+type Config struct {
+	// Logger logs the progress of the run, nothing is logged if nil
+	Logger *zap.SugaredLogger
+	// Mode is the Mode constant of the run
+	Mode      string
+	APIKey    string
+	SecretKey string
+	// Test define the orders of the run, replaced by the ones of PlanFile if it exists
+	Test TestConfig
+	// Endpoints are benchmarked one after another and tag the results, the default endpoint is
+	// benchmarked if empty
+	Endpoints []EndpointConfig
+
+	// OutputFormat is the OutputFormat constant of the results file written to OutputFolder, no
+	// file is written if empty
+	OutputFormat string
+	OutputFolder string
+	// Region is the region of the host recorded with the results
+	Region string
+	// Warmup is the number of orders placed after warming connections and excluded from results
+	Warmup int
+	// Outliers is the Outliers constant of the handling of latencies, trimming or winsorizing
+	// OutlierPercent of the lowest and highest ones
+	Outliers       string
+	OutlierPercent float64
+	// AckVsResult places every test with both ACK and RESULT response types in place mode
+	AckVsResult bool
+	// Ed25519APIKey is the key compared with the HMAC one in signing mode, its private key is in
+	// the PEM file Ed25519KeyFile
+	Ed25519APIKey  string
+	Ed25519KeyFile string
+	// MaxConnections and Concurrencies are swept by sweep mode
+	MaxConnections int
+	Concurrencies  []int
+	// TestTimeout bounds the requests of every test, 0 if unbounded
+	TestTimeout time.Duration
+	Profile     ProfileConfig
+
+	// Seed is the seed of the random pauses between tests, the one of PlanFile or a random one if 0
+	Seed uint64
+	// PlanFile is the file the tests and seed of the run are loaded from if it exists, saved to
+	// otherwise
+	PlanFile string
+	// WebhookURL is the Slack compatible webhook the Report is posted to, flagging latencies above
+	// LatencyThreshold, 0 disables alerts
+	WebhookURL       string
+	LatencyThreshold time.Duration
+	// ProbeInterval is the interval of the probes of daemon mode, whose latencies are served on
+	// MetricsAddr
+	ProbeInterval time.Duration
+	MetricsAddr   string
+	// Append is the CSV file results are appended to as the run goes, Checkpoint tests at a time,
+	// instead of the results file. Rows are tagged with RunID, a new id if empty, and a run given
+	// the id of an interrupted one resumes it.
+	Append     string
+	RunID      string
+	Checkpoint int
+}
+
+// DefaultConfig returns the Config of a run placing 50 IOC BUY orders of USDT symbols at 10%
+// below the last price and writing results to a CSV file
+func DefaultConfig() Config {
+	return Config{
+		Mode: ModePlace,
+		Test: TestConfig{
+			Count:       50,
+			QuoteAsset:  "USDT",
+			Side:        futures.SideTypeBuy,
+			TimeInForce: futures.TimeInForceTypeIOC,
+			PriceOffset: 10,
+		},
+		OutputFormat:   OutputFormatCSV,
+		Outliers:       OutliersNone,
+		OutlierPercent: 5,
+		MaxConnections: 4,
+		Concurrencies:  []int{1},
+		TestTimeout:    10 * time.Second,
+		ProbeInterval:  10 * time.Second,
+		MetricsAddr:    ":9090",
+		Checkpoint:     10,
+	}
+}
+
+// Report define the outcome of a run, posted without its results to the webhook
+type Report struct {
+	Mode      string    `json:"mode"`
+	Region    string    `json:"region,omitempty"`
+	Host      string    `json:"host"`
+	Seed      uint64    `json:"seed"`
+	RunID     string    `json:"runId,omitempty"`
+	StartTime time.Time `json:"startTime"`
+	EndTime   time.Time `json:"endTime"`
+	// Columns summarize the results per endpoint and group
+	Columns    []ColumnSummary `json:"columns"`
+	Reconnects int64           `json:"reconnects"`
+	// Header and Rows are the results, they start with an endpoint column if Endpoints are set
+	Header []string   `json:"-"`
+	Rows   [][]string `json:"-"`
+	// Error is the error the run failed with, empty if it succeeded
+	Error string `json:"error,omitempty"`
+}
+
+// benchmark holds the clients compared by a run, spot clients are only set in compare mode
+type benchmark struct {
+	l              *zap.SugaredLogger
+	cfg            TestConfig
+	endpoint       EndpointConfig
+	restClient     *futures.Client
+	wsClient       *futures.ClientWs
+	serverTimeDiff float64
+	// rng draws the pauses between tests
+	rng *rand.Rand
+	// testTimeout bounds the requests of every test, 0 if unbounded
+	testTimeout time.Duration
+	// notifier alerts slow probes of daemon mode, nil if disabled
+	notifier *webhookNotifier
+
+	// ackVsResult places every test with both ACK and RESULT response types
+	ackVsResult bool
+	// updates watches the user stream for placed orders, results have streamHeader columns if set
+	updates *orderUpdateWatcher
+	// ed25519 is the key compared with the HMAC key of the clients in signing mode
+	ed25519 ed25519Key
+	// ed25519Rest and ed25519Ws are the clients of ed25519, created once by signing mode
+	ed25519Rest *futures.Client
+	ed25519Ws   *futures.ClientWs
+	// maxConnections and concurrencies are swept by sweep mode over sweepClients
+	maxConnections int
+	concurrencies  []int
+	sweepClients   []*futures.ClientWs
+
+	spotRestClient     *binance.Client
+	spotWsClient       *binance.ClientWs
+	spotServerTimeDiff float64
+}
+
+// latency returns the one-way latency of a request sent at sentTime (local, milliseconds) and
+// handled by the server at updateTime
+func (b *benchmark) latency(sentTime, updateTime int64) string {
+	return IntToString(updateTime - sentTime - int64(b.serverTimeDiff))
+}
+
+// resultLatency returns the latency of a request like latency, empty if it failed with err
+func (b *benchmark) resultLatency(sentTime, updateTime int64, err error) string {
+	if err != nil {
+		return ""
+	}
+	return b.latency(sentTime, updateTime)
+}
+
+// roundTrip returns the round-trip time of a request sent at sentTime and answered at doneTime,
+// empty if it failed with err
+func roundTrip(sentTime, doneTime int64, err error) string {
+	if err != nil {
+		return ""
+	}
+	return IntToString(doneTime - sentTime)
+}
+
+// errorString returns the error column of err
+func errorString(err error) string {
+	if err == nil {
+		return ""
+	}
+	return err.Error()
+}
+
+// testContext returns the context of the requests of a test, done with ctx or after testTimeout
+func (b *benchmark) testContext(ctx context.Context) (context.Context, context.CancelFunc) {
+	if b.testTimeout <= 0 {
+		return context.WithCancel(ctx)
+	}
+	return context.WithTimeout(ctx, b.testTimeout)
+}
+
+// Run runs the benchmark of cfg until done or ctx is done and returns its Report, with the error
+// the run failed with if any. Orders and positions left by the run are cleaned up and its
+// connections closed before it returns.
+func Run(ctx context.Context, cfg Config) (report Report, err error) {
+	l := cfg.Logger
+	if l == nil {
+		l = zap.NewNop().Sugar()
+	}
+	l.Infow("Start running benchmark...")
+
+	mode := cfg.Mode
+	switch mode {
+	case ModePlace, ModeCancel, ModeRoundTrip, ModeModify, ModeCompare, ModeDaemon, ModeSigning, ModeSweep:
+	default:
+		return report, fmt.Errorf("unknown mode %q", mode)
+	}
+
+	outputFormat := cfg.OutputFormat
+	switch outputFormat {
+	case "", OutputFormatCSV, OutputFormatJSON, OutputFormatParquet:
+	default:
+		return report, fmt.Errorf("unknown output format %q", outputFormat)
+	}
+
+	outliers, outlierPercent := cfg.Outliers, cfg.OutlierPercent
+	switch outliers {
+	case OutliersNone, OutliersTrim, OutliersWinsorize:
+	default:
+		return report, fmt.Errorf("unknown outlier handling %q", outliers)
+	}
+	if outlierPercent < 0 || outlierPercent >= 50 {
+		return report, fmt.Errorf("invalid outlier percent %v", outlierPercent)
+	}
+
+	testCfg := cfg.Test
+	if err := testCfg.validate(); err != nil {
+		return report, err
+	}
+	planFile := cfg.PlanFile
+	var plan *testPlan
+	if planFile != "" {
+		if plan, err = loadPlan(planFile); err != nil {
+			l.Errorw("Failed to load test plan", "file", planFile, "err", err)
+			return report, err
+		}
+	}
+	seed := uint64(time.Now().UnixNano())
+	if plan != nil {
+		// the orders of the plan were priced with its config
+		testCfg, seed = plan.Config, plan.Seed
+	}
+	if cfg.Seed != 0 {
+		seed = cfg.Seed
+	}
+	var results *resultsFile
+	checkpoint := cfg.Checkpoint
+	if path := cfg.Append; path != "" {
+		switch {
+		case mode == ModeDaemon || mode == ModeSweep:
+			return report, fmt.Errorf("appending results is not supported in %s mode", mode)
+		case outliers != OutliersNone:
+			// rows are written before the run ends
+			return report, errors.New("appending results doesn't support outlier handling")
+		case checkpoint <= 0:
+			return report, fmt.Errorf("invalid checkpoint %d", checkpoint)
+		}
+		runID := cfg.RunID
+		if runID == "" {
+			runID = time.Now().UTC().Format("20060102T150405Z")
+		}
+		if results, err = openResultsFile(path, runID); err != nil {
+			l.Errorw("Failed to open results file", "file", path, "err", err)
+			return report, err
+		}
+		if len(results.checkpoints) > 0 && plan == nil {
+			// the tests of a new plan would differ from the ones done
+			return report, fmt.Errorf("resuming run %s requires the plan file it was run with", runID)
+		}
+		l.Infow("Appending results", "file", path, "runID", runID)
+	}
+	warmup := cfg.Warmup
+	ackVsResult := cfg.AckVsResult
+	if ackVsResult && mode != ModePlace {
+		return report, fmt.Errorf("ack vs result is only supported in %s mode", ModePlace)
+	}
+	maxConnections, concurrencies := cfg.MaxConnections, cfg.Concurrencies
+	if maxConnections <= 0 {
+		return report, fmt.Errorf("invalid max connections %d", maxConnections)
+	}
+	for _, concurrency := range concurrencies {
+		if concurrency <= 0 {
+			return report, fmt.Errorf("invalid concurrency %d", concurrency)
+		}
+	}
+
+	endpoints := []EndpointConfig{{Name: defaultEndpointName}}
+	tagEndpoint := len(cfg.Endpoints) > 0
+	if tagEndpoint {
+		endpoints = cfg.Endpoints
+		if len(endpoints) > 1 && (mode == ModeCompare || mode == ModeDaemon) {
+			return report, fmt.Errorf("%s mode supports a single endpoint", mode)
+		}
+	}
+	host, _ := os.Hostname()
+	notifier := newWebhookNotifier(cfg.WebhookURL, cfg.LatencyThreshold)
+	report = Report{Mode: mode, Region: cfg.Region, Host: host, Seed: seed, StartTime: time.Now()}
+	if results != nil {
+		report.RunID = results.runID
+	}
+	defer func() {
+		report.EndTime = time.Now()
+		if err != nil {
+			report.Error = err.Error()
+		}
+		if err := notifier.notifyRun(context.Background(), report); err != nil {
+			l.Errorw("Failed to post summary to webhook", "err", err)
+		}
+	}()
+
+	stopProfiling, err := startProfiling(l, cfg.Profile)
+	if err != nil {
+		l.Errorw("Failed to start profiling", "err", err)
+		return report, err
+	}
+	defer func() {
+		if err := stopProfiling(); err != nil {
+			l.Errorw("Failed to write profiles", "err", err)
+		}
+	}()
+
+	apiKey, secretKey := cfg.APIKey, cfg.SecretKey
+
+	var edKey ed25519Key
+	if mode == ModeSigning {
+		if edKey, err = newEd25519Key(cfg.Ed25519APIKey, cfg.Ed25519KeyFile); err != nil {
+			return report, err
+		}
+	}
+
+	restClient, wsClient, err := endpoints[0].newClients(apiKey, secretKey)
+	if err != nil {
+		l.Errorw("Cannot init wsClient", "endpoint", endpoints[0].Name, "err", err)
+		return report, err
+	}
+	// b is the benchmark of the current endpoint, which closes its clients
+	var b *benchmark
+	defer func() {
+		if b != nil {
+			b.close()
+		} else {
+			wsClient.Close()
+		}
+	}()
+
+	// Setup test
+	var tests []placeOrderParam
+	if plan != nil {
+		tests = plan.Tests
+		l.Infow("Loaded test plan", "file", planFile)
+	} else {
+		mappedExInfo, err := getFutureExInfo(ctx, restClient, testCfg, l)
+		if err != nil {
+			l.Errorw("Failed to get future exchange info", "err", err)
+			return report, err
+		}
+
+		tickers, err := restClient.NewListPriceChangeStatsService().Do(ctx)
+		if err != nil {
+			l.Errorw("Failed to get binance ticker", "err", err)
+			return report, err
+		}
+
+		tests = setupFutureOrderTest(mappedExInfo, tickers, testCfg)
+		if planFile != "" {
+			if err := savePlan(planFile, &testPlan{Seed: seed, Config: testCfg, Tests: tests}); err != nil {
+				l.Errorw("Failed to save test plan", "file", planFile, "err", err)
+				return report, err
+			}
+			l.Infow("Saved test plan", "file", planFile)
+		}
+	}
+	l.Infow("Future order tests", "mode", mode, "seed", seed, "config", testCfg, "endpoints", endpoints, "data", tests)
+
+	var spot *binance.Client
+	for i, e := range endpoints {
+		if i > 0 {
+			b.close()
+			b = nil
+			if restClient, wsClient, err = e.newClients(apiKey, secretKey); err != nil {
+				l.Errorw("Cannot init wsClient", "endpoint", e.Name, "err", err)
+				return report, err
+			}
+		}
+		serverTimeDiff, err := getFutureServerTimeDiff(ctx, restClient)
+		if err != nil {
+			l.Errorw("Cannot getFutureServerTimeDiff", "endpoint", e.Name, "err", err)
+			return report, err
+		}
+
+		b = &benchmark{
+			l:              l,
+			cfg:            testCfg,
+			endpoint:       e,
+			restClient:     restClient,
+			wsClient:       wsClient,
+			serverTimeDiff: serverTimeDiff,
+			rng:            rand.New(rand.NewSource(seed)),
+			testTimeout:    cfg.TestTimeout,
+			notifier:       notifier,
+			ackVsResult:    ackVsResult,
+			ed25519:        edKey,
+			maxConnections: maxConnections,
+			concurrencies:  concurrencies,
+		}
+		symbols, endpointStartTime := testSymbols(tests), time.Now()
+		positions, err := b.positions(ctx, symbols)
+		if err != nil {
+			l.Errorw("Failed to get positions", "endpoint", e.Name, "err", err)
+			return report, err
+		}
+		if mode == ModeDaemon {
+			err := b.daemon(ctx, tests, cfg.ProbeInterval, cfg.MetricsAddr, warmup)
+			report.Reconnects += b.wsClient.GetReconnectCount()
+			return report, errors.Join(err, b.cleanupResidue(symbols, endpointStartTime, positions))
+		}
+
+		l.Infow("Benchmarking endpoint", "endpoint", e)
+		if err := b.warmup(ctx, mode, tests, warmup, apiKey, secretKey); err != nil {
+			return report, errors.Join(err, b.cleanupResidue(symbols, endpointStartTime, positions))
+		}
+		var (
+			h    []string
+			rows [][]string
+		)
+		if results != nil {
+			h, rows, err = b.runAppend(ctx, results, checkpoint, mode, tests, apiKey, secretKey)
+		} else {
+			h, rows, err = b.run(ctx, mode, tests, apiKey, secretKey)
+		}
+		if err := errors.Join(err, b.cleanupResidue(symbols, endpointStartTime, positions)); err != nil {
+			return report, err
+		}
+		if rows, err = handleOutliers(h, rows, outliers, outlierPercent); err != nil {
+			return report, err
+		}
+		columns := summarize(l.With("endpoint", e.Name), h, rows)
+		if tagEndpoint {
+			for j := range columns {
+				columns[j].Endpoint = e.Name
+			}
+		}
+		report.Columns = append(report.Columns, columns...)
+		report.Reconnects += b.wsClient.GetReconnectCount()
+		spot = b.spotRestClient
+		report.Header = h
+		if tagEndpoint {
+			report.Header = append([]string{"endpoint"}, h...)
+			for j := range rows {
+				rows[j] = append([]string{e.Name}, rows[j]...)
+			}
+		}
+		report.Rows = append(report.Rows, rows...)
+	}
+
+	switch {
+	case results != nil:
+		l.Infow("Results appended successfully", "file", results.path, "runID", results.runID)
+		return report, nil
+	case outputFormat == "":
+		return report, nil
+	case outputFormat == OutputFormatCSV:
+		if err := WriteCSV(cfg.OutputFolder, report.Header, report.Rows); err != nil {
+			l.Errorw("Failed to WriteCSV", "err", err)
+			return report, err
+		}
+		l.Info("CSV file written successfully")
+		return report, nil
+	}
+
+	records, err := newResultRecords(report.Header, report.Rows)
+	if err != nil {
+		l.Errorw("Failed to convert results", "err", err)
+		return report, err
+	}
+	metadata := runMetadata{
+		Mode:          mode,
+		Endpoint:      restClient.BaseURL,
+		ClientVersion: clientVersion(),
+		Region:        cfg.Region,
+		Host:          host,
+		Seed:          seed,
+		StartTime:     report.StartTime,
+		EndTime:       time.Now(),
+		Config:        testCfg,
+	}
+	if tagEndpoint {
+		metadata.Endpoints = endpoints
+	}
+	if spot != nil {
+		metadata.SpotEndpoint = spot.BaseURL
+	}
+	if outputFormat == OutputFormatJSON {
+		err = WriteJSON(cfg.OutputFolder, metadata, records)
+	} else {
+		err = WriteParquet(cfg.OutputFolder, metadata, records)
+	}
+	if err != nil {
+		l.Errorw("Failed to write results", "format", outputFormat, "err", err)
+		return report, err
+	}
+
+	l.Infow("Results file written successfully", "format", outputFormat)
+	return report, nil
+}
+
+// run runs tests in mode and returns the header and rows of the results, or the error of ctx
+// if it is done before all tests are
+func (b *benchmark) run(ctx context.Context, mode string, tests []placeOrderParam, apiKey, secretKey string) ([]string, [][]string, error) {
+	header, data, err := b.runMode(ctx, mode, tests, apiKey, secretKey)
+	if err != nil {
+		return nil, nil, err
+	}
+	if err := ctx.Err(); err != nil {
+		return nil, nil, err
+	}
+	return header, data, nil
+}
+
+// runMode runs tests in mode until done or ctx is done
+func (b *benchmark) runMode(ctx context.Context, mode string, tests []placeOrderParam, apiKey, secretKey string) ([]string, [][]string, error) {
+	switch mode {
+	case ModeCancel:
+		return orderHeader, b.cancel(ctx, tests), nil
+	case ModeRoundTrip:
+		return roundTripHeader, b.placeCancel(ctx, tests), nil
+	case ModeModify:
+		data, err := b.modify(ctx, tests)
+		if err != nil {
+			b.l.Errorw("Failed to run modify benchmark", "err", err)
+			return nil, nil, err
+		}
+		return modifyHeader, data, nil
+	case ModeSigning:
+		data, err := b.signing(ctx, tests)
+		if err != nil {
+			return nil, nil, err
+		}
+		return signingHeader, data, nil
+	case ModeSweep:
+		data, err := b.sweep(ctx, tests, apiKey, secretKey)
+		if err != nil {
+			return nil, nil, err
+		}
+		return sweepHeader, data, nil
+	case ModeCompare:
+		spotTests, err := b.setupSpot(ctx, apiKey, secretKey, tests)
+		if err != nil {
+			return nil, nil, err
+		}
+		b.l.Infow("Spot order tests", "data", spotTests)
+		return compareHeader, b.compare(ctx, tests, spotTests), nil
+	default:
+		b.updates = newOrderUpdateWatcher()
+		stop, err := b.serveUserData(ctx, b.updates.handleUserDataEvent)
+		if err != nil {
+			b.l.Errorw("Failed to serve user data stream", "err", err)
+			return nil, nil, err
+		}
+		defer func() {
+			stop()
+			b.updates = nil
+		}()
+		if b.ackVsResult {
+			return respTypeHeader, b.placeRespTypes(ctx, tests), nil
+		}
+		return placeHeader, b.place(ctx, tests), nil
+	}
+}
+
+// close closes the websocket API clients of b
+func (b *benchmark) close() {
+	clients := append([]*futures.ClientWs{b.wsClient}, b.sweepClients...)
+	if b.ed25519Ws != nil {
+		clients = append(clients, b.ed25519Ws)
+	}
+	for _, client := range clients {
+		if err := client.Close(); err != nil {
+			b.l.Debugw("Failed to close ws connection", "err", err)
+		}
+	}
+	if b.spotWsClient != nil {
+		if err := b.spotWsClient.Close(); err != nil {
+			b.l.Debugw("Failed to close spot ws connection", "err", err)
+		}
+	}
+}
+
+// validate returns an error if cfg can't define tests
+func (cfg TestConfig) validate() error {
+	if cfg.Count <= 0 {
+		return fmt.Errorf("invalid order count %d", cfg.Count)
+	}
+	switch cfg.Side {
+	case futures.SideTypeBuy, futures.SideTypeSell:
+	default:
+		return fmt.Errorf("invalid side %q", cfg.Side)
+	}
+	switch cfg.TimeInForce {
+	case futures.TimeInForceTypeIOC, futures.TimeInForceTypeGTX, futures.TimeInForceTypeGTC:
+	default:
+		return fmt.Errorf("invalid time in force %q", cfg.TimeInForce)
+	}
+	if cfg.PriceOffset < 0 || cfg.PriceOffset >= 100 {
+		return fmt.Errorf("invalid price offset %v", cfg.PriceOffset)
+	}
+	return nil
+}
+
+// placeResult define the outcome of placing a test order through WS and REST at once, times
+// are in milliseconds: SentTime, *DoneTime and *StreamTime are local, *UpdateTime of the server.
+// *StreamTime is when the user stream reported the order, 0 if not watched or timed out.
+type placeResult struct {
+	SentTime                     int64
+	WsUpdateTime, RestUpdateTime int64
+	WsDoneTime, RestDoneTime     int64
+	WsStreamTime, RestStreamTime int64
+	WsErr, RestErr               error
+}
+
+// streamLatencies returns the streamHeader columns of res
+func (res placeResult) streamLatencies() []string {
+	latencies := make([]string, 0, 2)
+	for _, t := range []int64{res.WsStreamTime, res.RestStreamTime} {
+		if t == 0 {
+			latencies = append(latencies, "")
+			continue
+		}
+		latencies = append(latencies, IntToString(t-res.SentTime))
+	}
+	return latencies
+}
+
+// placeBoth places test through WS and REST at once with respType responses, then waits for
+// the user stream to report both orders if watched. Orders left resting are canceled.
+func (b *benchmark) placeBoth(ctx context.Context, wsService *futures.OrderPlaceWsService, test placeOrderParam, respType futures.NewOrderRespType) placeResult {
+	var (
+		wsClientOrderID   = newClientOrderID(transportWs)
+		restClientOrderID = newClientOrderID(transportRest)
+
+		wsStreamC, restStreamC <-chan int64
+	)
+	if b.updates != nil {
+		wsStreamC, restStreamC = b.updates.watch(wsClientOrderID), b.updates.watch(restClientOrderID)
+		defer b.updates.unwatch(wsClientOrderID)
+		defer b.updates.unwatch(restClientOrderID)
+	}
+
+	ctx, cancel := b.testContext(ctx)
+	defer cancel()
+	var (
+		res = placeResult{SentTime: time.Now().UnixMilli()}
+		wg  sync.WaitGroup
+	)
+	wg.Add(2)
+
+	// place WS order
+	go func() {
+		defer wg.Done()
+		req := futures.NewOrderPlaceWsRequest().
+			Symbol(test.Symbol).
+			Side(b.cfg.Side).
+			Type(futures.OrderTypeLimit).
+			Price(FloatToString(test.Price)).
+			Quantity(FloatToString(test.Qty)).
+			TimeInForce(b.cfg.TimeInForce).
+			NewClientOrderID(wsClientOrderID).
+			NewOrderResponseType(respType)
+		order, err := wsService.Do(ctx, req)
+		if err != nil {
+			b.l.Errorw("Failed to place ws order", "err", err)
+			res.WsErr = err
+			return
+		}
+		res.WsDoneTime, res.WsUpdateTime = time.Now().UnixMilli(), order.UpdateTime
+	}()
+
+	// place rest API order
+	go func() {
+		defer wg.Done()
+		order, err := b.restClient.NewCreateOrderService().
+			Symbol(test.Symbol).
+			Side(b.cfg.Side).
+			Type(futures.OrderTypeLimit).
+			TimeInForce(b.cfg.TimeInForce).
+			Price(FloatToString(test.Price)).
+			Quantity(FloatToString(test.Qty)).
+			NewClientOrderID(restClientOrderID).
+			NewOrderResponseType(respType).
+			Do(ctx)
+		if err != nil {
+			b.l.Errorw("Failed to place rest order", "err", err)
+			res.RestErr = err
+			return
+		}
+		res.RestDoneTime, res.RestUpdateTime = time.Now().UnixMilli(), order.UpdateTime
+	}()
+	wg.Wait()
+
+	if res.WsErr != nil {
+		wsStreamC = nil
+	}
+	if res.RestErr != nil {
+		restStreamC = nil
+	}
+	timeout := time.After(orderUpdateTimeout)
+	for wsStreamC != nil || restStreamC != nil {
+		select {
+		case t := <-wsStreamC:
+			res.WsStreamTime, wsStreamC = t, nil
+		case t := <-restStreamC:
+			res.RestStreamTime, restStreamC = t, nil
+		case <-timeout:
+			b.l.Warnw("Placed order not reported on user stream", "symbol", test.Symbol)
+			wsStreamC, restStreamC = nil, nil
+		}
+	}
+
+	if b.cfg.TimeInForce != futures.TimeInForceTypeIOC {
+		b.cleanup(test.Symbol)
+	}
+	return res
+}
+
+// place measures latency of placing orders through WS and REST at once, orders left resting
+// are canceled after every test
+func (b *benchmark) place(ctx context.Context, tests []placeOrderParam) [][]string {
+	wsService := b.wsClient.NewOrderPlaceWsService()
+	data := [][]string{}
+	for _, test := range tests {
+		if ctx.Err() != nil {
+			break
+		}
+		res := b.placeBoth(ctx, wsService, test, futures.NewOrderRespTypeRESULT)
+
+		// "symbol", "qty", "price", "side", "tif", "ws_latency", "rest_latency", "ws_error", "rest_error"
+		row := []string{
+			test.Symbol, FloatToString(test.Qty), FloatToString(test.Price), string(b.cfg.Side), string(b.cfg.TimeInForce),
+			b.resultLatency(res.SentTime, res.WsUpdateTime, res.WsErr),
+			b.resultLatency(res.SentTime, res.RestUpdateTime, res.RestErr),
+			errorString(res.WsErr),
+			errorString(res.RestErr),
+		}
+		if b.updates != nil {
+			// "ws_stream_latency", "rest_stream_latency"
+			row = append(row, res.streamLatencies()...)
+		}
+		data = append(data, row)
+
+		b.pause(ctx)
+	}
+	return data
+}
+
+// placeRespTypes measures latency of placing every test with ACK then RESULT response types, or
+// the other way around for every other test, so their difference shows the time spent waiting
+// for the matching engine. Every response type adds a row.
+func (b *benchmark) placeRespTypes(ctx context.Context, tests []placeOrderParam) [][]string {
+	wsService := b.wsClient.NewOrderPlaceWsService()
+	data := [][]string{}
+	for i, test := range tests {
+		if ctx.Err() != nil {
+			break
+		}
+		respTypes := []futures.NewOrderRespType{futures.NewOrderRespTypeACK, futures.NewOrderRespTypeRESULT}
+		if i%2 == 1 {
+			respTypes[0], respTypes[1] = respTypes[1], respTypes[0]
+		}
+		for _, respType := range respTypes {
+			res := b.placeBoth(ctx, wsService, test, respType)
+
+			// "symbol", "qty", "price", "side", "tif", "resp_type", "ws_latency", "rest_latency", "ws_rtt", "rest_rtt",
+			// "ws_error", "rest_error", "ws_stream_latency", "rest_stream_latency"
+			data = append(data, append([]string{
+				test.Symbol, FloatToString(test.Qty), FloatToString(test.Price), string(b.cfg.Side), string(b.cfg.TimeInForce), string(respType),
+				b.resultLatency(res.SentTime, res.WsUpdateTime, res.WsErr),
+				b.resultLatency(res.SentTime, res.RestUpdateTime, res.RestErr),
+				roundTrip(res.SentTime, res.WsDoneTime, res.WsErr),
+				roundTrip(res.SentTime, res.RestDoneTime, res.RestErr),
+				errorString(res.WsErr),
+				errorString(res.RestErr),
+			}, res.streamLatencies()...))
+
+			b.pause(ctx)
+		}
+	}
+	return data
+}
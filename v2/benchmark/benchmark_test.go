@@ -0,0 +1,64 @@
+package benchmark
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+
+	"github.com/adshao/go-binance/v2/futures"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTestConfigValidate(t *testing.T) {
+	valid := DefaultConfig().Test
+	assert.NoError(t, valid.validate())
+
+	for name, modify := range map[string]func(cfg *TestConfig){
+		"count":         func(cfg *TestConfig) { cfg.Count = 0 },
+		"side":          func(cfg *TestConfig) { cfg.Side = "HOLD" },
+		"time in force": func(cfg *TestConfig) { cfg.TimeInForce = futures.TimeInForceTypeFOK },
+		"price offset":  func(cfg *TestConfig) { cfg.PriceOffset = 100 },
+	} {
+		cfg := valid
+		modify(&cfg)
+		assert.Error(t, cfg.validate(), name)
+	}
+}
+
+func TestRunInvalidConfig(t *testing.T) {
+	for name, modify := range map[string]func(cfg *Config){
+		"mode":            func(cfg *Config) { cfg.Mode = "replay" },
+		"output format":   func(cfg *Config) { cfg.OutputFormat = "xml" },
+		"outliers":        func(cfg *Config) { cfg.Outliers = "clip" },
+		"outlier percent": func(cfg *Config) { cfg.OutlierPercent = 50 },
+		"test":            func(cfg *Config) { cfg.Test.Count = 0 },
+		"append mode": func(cfg *Config) {
+			cfg.Mode = ModeSweep
+			cfg.Append = filepath.Join(t.TempDir(), "results.csv")
+		},
+		"append outliers": func(cfg *Config) {
+			cfg.Outliers = OutliersTrim
+			cfg.Append = filepath.Join(t.TempDir(), "results.csv")
+		},
+		"checkpoint": func(cfg *Config) {
+			cfg.Append = filepath.Join(t.TempDir(), "results.csv")
+			cfg.Checkpoint = 0
+		},
+		"ack vs result": func(cfg *Config) {
+			cfg.Mode = ModeCancel
+			cfg.AckVsResult = true
+		},
+		"max connections": func(cfg *Config) { cfg.MaxConnections = 0 },
+		"concurrency":     func(cfg *Config) { cfg.Concurrencies = []int{1, 0} },
+		"endpoints": func(cfg *Config) {
+			cfg.Mode = ModeDaemon
+			cfg.Endpoints = []EndpointConfig{{Name: "a"}, {Name: "b"}}
+		},
+	} {
+		cfg := DefaultConfig()
+		modify(&cfg)
+		// invalid configs fail before connecting
+		_, err := Run(context.Background(), cfg)
+		assert.Error(t, err, name)
+	}
+}
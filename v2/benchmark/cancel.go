@@ -0,0 +1,115 @@
+package benchmark
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/adshao/go-binance/v2/futures"
+)
+
+// cancel measures latency of canceling resting orders through WS and REST at once. Every test
+// places two resting orders away from the market, then cancels one through each. Tests failing
+// to place the resting orders are skipped, as they measure nothing.
+func (b *benchmark) cancel(ctx context.Context, tests []placeOrderParam) [][]string {
+	wsService := b.wsClient.NewOrderCancelWsService()
+	data := [][]string{}
+	for _, test := range tests {
+		if ctx.Err() != nil {
+			break
+		}
+		wsOrderID, err := b.placeResting(ctx, test)
+		if err != nil {
+			b.l.Errorw("Failed to place resting order", "symbol", test.Symbol, "err", err)
+			continue
+		}
+		restOrderID, err := b.placeResting(ctx, test)
+		if err != nil {
+			b.l.Errorw("Failed to place resting order", "symbol", test.Symbol, "err", err)
+			b.cleanup(test.Symbol)
+			continue
+		}
+
+		testCtx, cancel := b.testContext(ctx)
+		var (
+			now                          = time.Now().UnixMilli()
+			wg                           sync.WaitGroup
+			wsUpdateTime, restUpdateTime int64
+			wsErr, restErr               error
+		)
+		wg.Add(2)
+
+		// cancel WS order
+		go func() {
+			defer wg.Done()
+			req := futures.NewCancelOrderRequest().
+				Symbol(test.Symbol).
+				OrderID(wsOrderID)
+			order, err := wsService.Do(testCtx, req)
+			if err != nil {
+				b.l.Errorw("Failed to cancel ws order", "err", err)
+				wsErr = err
+				return
+			}
+			wsUpdateTime = order.UpdateTime
+		}()
+
+		// cancel rest API order
+		go func() {
+			defer wg.Done()
+			order, err := b.restClient.NewCancelOrderService().
+				Symbol(test.Symbol).
+				OrderID(restOrderID).
+				Do(testCtx)
+			if err != nil {
+				b.l.Errorw("Failed to cancel rest order", "err", err)
+				restErr = err
+				return
+			}
+			restUpdateTime = order.UpdateTime
+		}()
+		wg.Wait()
+		cancel()
+		if wsErr != nil || restErr != nil {
+			b.cleanup(test.Symbol)
+		}
+
+		// "symbol", "qty", "price", "side", "tif", "ws_latency", "rest_latency", "ws_error", "rest_error"
+		data = append(data, []string{
+			test.Symbol, FloatToString(test.Qty), FloatToString(test.Price), string(b.cfg.Side), string(b.cfg.restingTimeInForce()),
+			b.resultLatency(now, wsUpdateTime, wsErr),
+			b.resultLatency(now, restUpdateTime, restErr),
+			errorString(wsErr),
+			errorString(restErr),
+		})
+
+		b.pause(ctx)
+	}
+	return data
+}
+
+// placeResting places a resting order of test and returns its id
+func (b *benchmark) placeResting(ctx context.Context, test placeOrderParam) (int64, error) {
+	ctx, cancel := b.testContext(ctx)
+	defer cancel()
+	order, err := b.restClient.NewCreateOrderService().
+		Symbol(test.Symbol).
+		Side(b.cfg.Side).
+		Type(futures.OrderTypeLimit).
+		TimeInForce(b.cfg.restingTimeInForce()).
+		Price(FloatToString(test.Price)).
+		Quantity(FloatToString(test.Qty)).
+		NewOrderResponseType(futures.NewOrderRespTypeACK).
+		Do(ctx)
+	if err != nil {
+		return 0, err
+	}
+	return order.OrderID, nil
+}
+
+// cleanup cancels the orders of symbol left resting by a test
+func (b *benchmark) cleanup(symbol string) {
+	if err := b.restClient.NewCancelAllOpenOrdersService().Symbol(symbol).Do(context.Background()); err != nil {
+		b.l.Errorw("Failed to cancel open orders", "symbol", symbol, "err", err)
+	}
+}
@@ -0,0 +1,26 @@
+package main
+
+import (
+	"path/filepath"
+	"strings"
+
+	"github.com/urfave/cli/v2"
+	"github.com/urfave/cli/v2/altsrc"
+)
+
+// configSource loads the file of the config flag, whose keys are flag names of the subcommand,
+// e.g. for "benchmark place --config config.yaml"
+//
+//	order-count: 100
+//	symbols: [BTCUSDT, ETHUSDT]
+//	endpoint: ["default=", "eth1=||10.0.0.5"]
+//	output-format: parquet
+//
+// Files with a .json extension are read as JSON, others as YAML.
+func configSource(c *cli.Context) (altsrc.InputSourceContext, error) {
+	path := c.String(configFlag)
+	if strings.EqualFold(filepath.Ext(path), ".json") {
+		return altsrc.NewJSONSourceFromFile(path)
+	}
+	return altsrc.NewYamlSourceFromFlagFunc(configFlag)(c)
+}
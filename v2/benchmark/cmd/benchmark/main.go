@@ -0,0 +1,357 @@
+package main
+
+import (
+	"log"
+	"os"
+	"strings"
+
+	"github.com/adshao/go-binance/v2/benchmark"
+	"github.com/adshao/go-binance/v2/futures"
+	"github.com/urfave/cli/v2"
+	"github.com/urfave/cli/v2/altsrc"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+const (
+	configFlag           = "config"
+	binanceApiKeyFlag    = "binance-api-key"
+	binanceSecretKeyFlag = "binance-secret-key"
+	outputFolderFlag     = "output-folder"
+	orderCountFlag       = "order-count"
+	symbolsFlag          = "symbols"
+	quoteAssetFlag       = "quote-asset"
+	sideFlag             = "side"
+	timeInForceFlag      = "tif"
+	priceOffsetFlag      = "price-offset"
+	probeIntervalFlag    = "probe-interval"
+	metricsAddrFlag      = "metrics-addr"
+	outputFormatFlag     = "output-format"
+	regionFlag           = "region"
+	endpointFlag         = "endpoint"
+	warmupFlag           = "warmup"
+	outliersFlag         = "outliers"
+	outlierPercentFlag   = "outlier-percent"
+	ackVsResultFlag      = "ack-vs-result"
+	ed25519ApiKeyFlag    = "ed25519-api-key"
+	ed25519KeyFileFlag   = "ed25519-private-key-file"
+	maxConnectionsFlag   = "max-connections"
+	concurrencyFlag      = "concurrency"
+	testTimeoutFlag      = "test-timeout"
+	pprofAddrFlag        = "pprof-addr"
+	cpuProfileFlag       = "cpu-profile"
+	allocProfileFlag     = "alloc-profile"
+	traceFileFlag        = "trace-file"
+	seedFlag             = "seed"
+	planFileFlag         = "plan-file"
+	webhookURLFlag       = "webhook-url"
+	latencyThresholdFlag = "latency-threshold"
+	appendFlag           = "append"
+	runIDFlag            = "run-id"
+	checkpointFlag       = "checkpoint"
+)
+
+// modeUsages define the subcommands of the benchmark, one per mode
+var modeUsages = []struct{ mode, usage string }{
+	{benchmark.ModePlace, "measure order placement latency"},
+	{benchmark.ModeCancel, "measure order cancel latency"},
+	{benchmark.ModeRoundTrip, "measure the round trip of placing an order and canceling it once acknowledged"},
+	{benchmark.ModeModify, "measure order modify latency"},
+	{benchmark.ModeCompare, "measure order placement latency of futures and spot"},
+	{benchmark.ModeDaemon, "place probe orders until stopped and export their latency to Prometheus"},
+	{benchmark.ModeSigning, "measure order placement latency of HMAC and Ed25519 API keys"},
+	{benchmark.ModeSweep, "measure websocket order placement latency over several connections and concurrencies"},
+}
+
+func main() {
+	if err := newApp().Run(os.Args); err != nil {
+		log.Panic(err)
+	}
+}
+
+// newApp init the benchmark command with a subcommand per mode, sharing the flags of newFlags
+func newApp() *cli.App {
+	app := cli.NewApp()
+	app.Name = "Future order benchmark"
+	flags := newFlags()
+	for _, m := range modeUsages {
+		mode := m.mode
+		app.Commands = append(app.Commands, &cli.Command{
+			Name:   mode,
+			Usage:  m.usage,
+			Flags:  flags,
+			Before: altsrc.InitInputSourceWithContext(flags, configSource),
+			Action: func(c *cli.Context) error {
+				return run(c, mode)
+			},
+		})
+	}
+	return app
+}
+
+// newFlags returns the flags of the benchmark, defaulting to benchmark.DefaultConfig
+func newFlags() []cli.Flag {
+	defaults := benchmark.DefaultConfig()
+	return []cli.Flag{
+		&cli.StringFlag{
+			Name:    configFlag,
+			Usage:   "YAML or JSON file of flag values, overridden by flags and environment variables",
+			EnvVars: []string{"BENCHMARK_CONFIG"},
+		},
+		altsrc.NewStringFlag(&cli.StringFlag{
+			Name:    binanceApiKeyFlag,
+			EnvVars: []string{"BINANCE_API_KEY"},
+		}),
+		altsrc.NewStringFlag(&cli.StringFlag{
+			Name:    binanceSecretKeyFlag,
+			EnvVars: []string{"BINANCE_SECRET_KEY"},
+		}),
+		altsrc.NewStringFlag(&cli.StringFlag{
+			Name:    outputFolderFlag,
+			EnvVars: []string{"OUTPUT_FOLDER"},
+		}),
+		altsrc.NewStringFlag(&cli.StringFlag{
+			Name:    outputFormatFlag,
+			Usage:   "format of the results file: csv, json or parquet",
+			Value:   defaults.OutputFormat,
+			EnvVars: []string{"OUTPUT_FORMAT"},
+		}),
+		altsrc.NewStringFlag(&cli.StringFlag{
+			Name:    regionFlag,
+			Usage:   "region the benchmark runs from, recorded in json and parquet results",
+			EnvVars: []string{"REGION"},
+		}),
+		altsrc.NewIntFlag(&cli.IntFlag{
+			Name:    warmupFlag,
+			Usage:   "number of orders placed after warming connections and excluded from results",
+			EnvVars: []string{"WARMUP"},
+		}),
+		altsrc.NewStringFlag(&cli.StringFlag{
+			Name:    outliersFlag,
+			Usage:   "outlier handling of latencies: none, trim or winsorize",
+			Value:   defaults.Outliers,
+			EnvVars: []string{"OUTLIERS"},
+		}),
+		altsrc.NewFloat64Flag(&cli.Float64Flag{
+			Name:    outlierPercentFlag,
+			Usage:   "percent of lowest and highest latencies trimmed or winsorized",
+			Value:   defaults.OutlierPercent,
+			EnvVars: []string{"OUTLIER_PERCENT"},
+		}),
+		altsrc.NewStringFlag(&cli.StringFlag{
+			Name:    ed25519ApiKeyFlag,
+			Usage:   "Ed25519 API key compared with the HMAC one in signing mode",
+			EnvVars: []string{"ED25519_API_KEY"},
+		}),
+		altsrc.NewStringFlag(&cli.StringFlag{
+			Name:    ed25519KeyFileFlag,
+			Usage:   "PEM file of the private key of --ed25519-api-key",
+			EnvVars: []string{"ED25519_PRIVATE_KEY_FILE"},
+		}),
+		altsrc.NewStringFlag(&cli.StringFlag{
+			Name:    webhookURLFlag,
+			Usage:   "Slack compatible webhook the summary of the run is posted to",
+			EnvVars: []string{"WEBHOOK_URL"},
+		}),
+		altsrc.NewDurationFlag(&cli.DurationFlag{
+			Name:    latencyThresholdFlag,
+			Usage:   "p99 latency of a run, or latency of a daemon probe, posted as an alert to the webhook above it, 0 disables alerts",
+			EnvVars: []string{"LATENCY_THRESHOLD"},
+		}),
+		altsrc.NewUint64Flag(&cli.Uint64Flag{
+			Name:    seedFlag,
+			Usage:   "seed of the random pauses between tests, the one of --plan-file or a random one if not set",
+			EnvVars: []string{"SEED"},
+		}),
+		altsrc.NewStringFlag(&cli.StringFlag{
+			Name:    planFileFlag,
+			Usage:   "file the orders and seed of the run are loaded from if it exists, saved to otherwise",
+			EnvVars: []string{"PLAN_FILE"},
+		}),
+		altsrc.NewStringFlag(&cli.StringFlag{
+			Name:    appendFlag,
+			Usage:   "CSV file results are appended to as the run goes, instead of a new file in --output-folder",
+			EnvVars: []string{"APPEND"},
+		}),
+		altsrc.NewStringFlag(&cli.StringFlag{
+			Name:    runIDFlag,
+			Usage:   "id of the run in the --append file, an interrupted run resumes from its --plan-file if given its id, a new id if not set",
+			EnvVars: []string{"RUN_ID"},
+		}),
+		altsrc.NewIntFlag(&cli.IntFlag{
+			Name:    checkpointFlag,
+			Usage:   "number of tests whose results are appended at once, at most lost by an interrupted run",
+			Value:   defaults.Checkpoint,
+			EnvVars: []string{"CHECKPOINT"},
+		}),
+		altsrc.NewStringFlag(&cli.StringFlag{
+			Name:    pprofAddrFlag,
+			Usage:   "listen address of the /debug/pprof endpoints, disabled if empty",
+			EnvVars: []string{"PPROF_ADDR"},
+		}),
+		altsrc.NewStringFlag(&cli.StringFlag{
+			Name:    cpuProfileFlag,
+			Usage:   "file the CPU profile of the run is written to",
+			EnvVars: []string{"CPU_PROFILE"},
+		}),
+		altsrc.NewStringFlag(&cli.StringFlag{
+			Name:    allocProfileFlag,
+			Usage:   "file the allocation profile of the run is written to",
+			EnvVars: []string{"ALLOC_PROFILE"},
+		}),
+		altsrc.NewStringFlag(&cli.StringFlag{
+			Name:    traceFileFlag,
+			Usage:   "file the execution trace of the run is written to, showing scheduling delays",
+			EnvVars: []string{"TRACE_FILE"},
+		}),
+		altsrc.NewDurationFlag(&cli.DurationFlag{
+			Name:    testTimeoutFlag,
+			Usage:   "timeout of every request of a test, failed requests are recorded with their error, 0 disables it",
+			Value:   defaults.TestTimeout,
+			EnvVars: []string{"TEST_TIMEOUT"},
+		}),
+		altsrc.NewIntFlag(&cli.IntFlag{
+			Name:    maxConnectionsFlag,
+			Usage:   "sweep mode places orders over 1 to this number of websocket connections",
+			Value:   defaults.MaxConnections,
+			EnvVars: []string{"MAX_CONNECTIONS"},
+		}),
+		altsrc.NewIntSliceFlag(&cli.IntSliceFlag{
+			Name:    concurrencyFlag,
+			Usage:   "numbers of orders in flight swept by sweep mode for every number of connections",
+			Value:   cli.NewIntSlice(defaults.Concurrencies...),
+			EnvVars: []string{"CONCURRENCY"},
+		}),
+		altsrc.NewBoolFlag(&cli.BoolFlag{
+			Name:    ackVsResultFlag,
+			Usage:   "place every test with both ACK and RESULT response types, only in place mode",
+			EnvVars: []string{"ACK_VS_RESULT"},
+		}),
+		altsrc.NewStringSliceFlag(&cli.StringSliceFlag{
+			Name:    endpointFlag,
+			Usage:   "endpoint to benchmark as name=restURL|wsURL|localIP, repeat to compare several, empty parts keep the defaults",
+			EnvVars: []string{"ENDPOINTS"},
+		}),
+		altsrc.NewIntFlag(&cli.IntFlag{
+			Name:    orderCountFlag,
+			Usage:   "number of tests",
+			Value:   defaults.Test.Count,
+			EnvVars: []string{"ORDER_COUNT"},
+		}),
+		altsrc.NewStringSliceFlag(&cli.StringSliceFlag{
+			Name:    symbolsFlag,
+			Usage:   "symbols to test, all symbols quoted in --quote-asset if empty",
+			EnvVars: []string{"SYMBOLS"},
+		}),
+		altsrc.NewStringFlag(&cli.StringFlag{
+			Name:    quoteAssetFlag,
+			Usage:   "quote asset of the symbols to test",
+			Value:   defaults.Test.QuoteAsset,
+			EnvVars: []string{"QUOTE_ASSET"},
+		}),
+		altsrc.NewStringFlag(&cli.StringFlag{
+			Name:    sideFlag,
+			Usage:   "order side: BUY or SELL",
+			Value:   string(defaults.Test.Side),
+			EnvVars: []string{"SIDE"},
+		}),
+		altsrc.NewStringFlag(&cli.StringFlag{
+			Name:    timeInForceFlag,
+			Usage:   "order time in force: IOC, GTX or GTC, cancel, roundtrip and modify modes place GTX orders for IOC",
+			Value:   string(defaults.Test.TimeInForce),
+			EnvVars: []string{"TIME_IN_FORCE"},
+		}),
+		altsrc.NewFloat64Flag(&cli.Float64Flag{
+			Name:    priceOffsetFlag,
+			Usage:   "distance of order prices from the last price in percent, below it for BUY and above it for SELL",
+			Value:   defaults.Test.PriceOffset,
+			EnvVars: []string{"PRICE_OFFSET"},
+		}),
+		altsrc.NewDurationFlag(&cli.DurationFlag{
+			Name:    probeIntervalFlag,
+			Usage:   "interval between probe orders of daemon mode",
+			Value:   defaults.ProbeInterval,
+			EnvVars: []string{"PROBE_INTERVAL"},
+		}),
+		altsrc.NewStringFlag(&cli.StringFlag{
+			Name:    metricsAddrFlag,
+			Usage:   "listen address of the /metrics endpoint of daemon mode",
+			Value:   defaults.MetricsAddr,
+			EnvVars: []string{"METRICS_ADDR"},
+		}),
+	}
+}
+
+func run(c *cli.Context, mode string) error {
+	cfg, err := newConfig(c, mode)
+	if err != nil {
+		return err
+	}
+	_, err = benchmark.Run(c.Context, cfg)
+	return err
+}
+
+// newConfig returns the benchmark.Config of mode set by the flags of c
+func newConfig(c *cli.Context, mode string) (benchmark.Config, error) {
+	cfg := benchmark.Config{
+		Logger:    setupLogger(),
+		Mode:      mode,
+		APIKey:    c.String(binanceApiKeyFlag),
+		SecretKey: c.String(binanceSecretKeyFlag),
+		Test: benchmark.TestConfig{
+			Count:       c.Int(orderCountFlag),
+			QuoteAsset:  strings.ToUpper(c.String(quoteAssetFlag)),
+			Side:        futures.SideType(strings.ToUpper(c.String(sideFlag))),
+			TimeInForce: futures.TimeInForceType(strings.ToUpper(c.String(timeInForceFlag))),
+			PriceOffset: c.Float64(priceOffsetFlag),
+		},
+		OutputFormat:   c.String(outputFormatFlag),
+		OutputFolder:   c.String(outputFolderFlag),
+		Region:         c.String(regionFlag),
+		Warmup:         c.Int(warmupFlag),
+		Outliers:       c.String(outliersFlag),
+		OutlierPercent: c.Float64(outlierPercentFlag),
+		AckVsResult:    c.Bool(ackVsResultFlag),
+		Ed25519APIKey:  c.String(ed25519ApiKeyFlag),
+		Ed25519KeyFile: c.String(ed25519KeyFileFlag),
+		MaxConnections: c.Int(maxConnectionsFlag),
+		Concurrencies:  c.IntSlice(concurrencyFlag),
+		TestTimeout:    c.Duration(testTimeoutFlag),
+		Profile: benchmark.ProfileConfig{
+			PprofAddr:    c.String(pprofAddrFlag),
+			CPUProfile:   c.String(cpuProfileFlag),
+			AllocProfile: c.String(allocProfileFlag),
+			TraceFile:    c.String(traceFileFlag),
+		},
+		Seed:             c.Uint64(seedFlag),
+		PlanFile:         c.String(planFileFlag),
+		WebhookURL:       c.String(webhookURLFlag),
+		LatencyThreshold: c.Duration(latencyThresholdFlag),
+		ProbeInterval:    c.Duration(probeIntervalFlag),
+		MetricsAddr:      c.String(metricsAddrFlag),
+		Append:           c.String(appendFlag),
+		RunID:            c.String(runIDFlag),
+		Checkpoint:       c.Int(checkpointFlag),
+	}
+	for _, symbol := range c.StringSlice(symbolsFlag) {
+		cfg.Test.Symbols = append(cfg.Test.Symbols, strings.ToUpper(strings.TrimSpace(symbol)))
+	}
+	for _, s := range c.StringSlice(endpointFlag) {
+		e, err := benchmark.ParseEndpoint(s)
+		if err != nil {
+			return cfg, err
+		}
+		cfg.Endpoints = append(cfg.Endpoints, e)
+	}
+	return cfg, nil
+}
+
+func setupLogger() *zap.SugaredLogger {
+	pConf := zap.NewProductionEncoderConfig()
+	pConf.EncodeTime = zapcore.ISO8601TimeEncoder
+	encoder := zapcore.NewConsoleEncoder(pConf)
+	level := zap.NewAtomicLevelAt(zap.DebugLevel)
+	l := zap.New(zapcore.NewCore(encoder, zapcore.AddSync(os.Stdout), level), zap.AddCaller())
+	zap.ReplaceGlobals(l)
+	return zap.S()
+}
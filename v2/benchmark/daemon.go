@@ -0,0 +1,140 @@
+package benchmark
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/adshao/go-binance/v2/futures"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+const (
+	transportWs   = "ws"
+	transportRest = "rest"
+)
+
+// latencyBuckets define histogram buckets of order latency in seconds
+var latencyBuckets = []float64{.001, .0025, .005, .0075, .01, .015, .02, .03, .05, .075, .1, .25, .5, 1}
+
+// daemonMetrics define metrics exported by daemon mode
+type daemonMetrics struct {
+	latency   *prometheus.HistogramVec
+	roundTrip *prometheus.HistogramVec
+	errors    *prometheus.CounterVec
+	probes    prometheus.Counter
+}
+
+func newDaemonMetrics(reg prometheus.Registerer, reconnects func() float64) *daemonMetrics {
+	m := &daemonMetrics{
+		latency: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Name:    "binance_order_latency_seconds",
+			Help:    "One-way latency of probe orders, from sending to the update time of the server.",
+			Buckets: latencyBuckets,
+		}, []string{"transport"}),
+		roundTrip: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Name:    "binance_order_round_trip_seconds",
+			Help:    "Round-trip time of probe orders, from sending to receiving the response.",
+			Buckets: latencyBuckets,
+		}, []string{"transport"}),
+		errors: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "binance_order_errors_total",
+			Help: "Number of probe orders failed.",
+		}, []string{"transport"}),
+		probes: prometheus.NewCounter(prometheus.CounterOpts{
+			Name: "binance_order_probes_total",
+			Help: "Number of probes, each placing an order through every transport.",
+		}),
+	}
+	reg.MustRegister(m.latency, m.roundTrip, m.errors, m.probes, prometheus.NewCounterFunc(prometheus.CounterOpts{
+		Name: "binance_ws_reconnects_total",
+		Help: "Number of reconnect attempts of the websocket API connection.",
+	}, reconnects))
+	return m
+}
+
+// latencies returns the one-way latency of every transport of res that succeeded
+func (res placeResult) latencies(serverTimeDiff float64) map[string]time.Duration {
+	latencies := make(map[string]time.Duration, 2)
+	if res.WsErr == nil {
+		latencies[transportWs] = time.Duration(float64(res.WsUpdateTime-res.SentTime)-serverTimeDiff) * time.Millisecond
+	}
+	if res.RestErr == nil {
+		latencies[transportRest] = time.Duration(float64(res.RestUpdateTime-res.SentTime)-serverTimeDiff) * time.Millisecond
+	}
+	return latencies
+}
+
+// observe records the outcome of a probe
+func (m *daemonMetrics) observe(res placeResult, serverTimeDiff float64) {
+	m.probes.Inc()
+	for _, t := range []struct {
+		transport            string
+		updateTime, doneTime int64
+		err                  error
+	}{
+		{transportWs, res.WsUpdateTime, res.WsDoneTime, res.WsErr},
+		{transportRest, res.RestUpdateTime, res.RestDoneTime, res.RestErr},
+	} {
+		if t.err != nil {
+			m.errors.WithLabelValues(t.transport).Inc()
+			continue
+		}
+		m.latency.WithLabelValues(t.transport).Observe((float64(t.updateTime-res.SentTime) - serverTimeDiff) / 1e3)
+		m.roundTrip.WithLabelValues(t.transport).Observe(float64(t.doneTime-res.SentTime) / 1e3)
+	}
+}
+
+// daemon places a probe order through WS and REST every interval, cycling through tests, and
+// serves their metrics on addr until ctx is done or the process is interrupted. The first warmup
+// probes are not recorded, slower ones are alerted to the webhook.
+func (b *benchmark) daemon(ctx context.Context, tests []placeOrderParam, interval time.Duration, addr string, warmup int) error {
+	if len(tests) == 0 {
+		return errors.New("no order test to probe")
+	}
+	ctx, stop := signal.NotifyContext(ctx, syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+
+	reg := prometheus.NewRegistry()
+	metrics := newDaemonMetrics(reg, func() float64 {
+		return float64(b.wsClient.GetReconnectCount())
+	})
+	mux := http.NewServeMux()
+	mux.Handle("/metrics", promhttp.HandlerFor(reg, promhttp.HandlerOpts{}))
+	server := &http.Server{Addr: addr, Handler: mux, ReadHeaderTimeout: 5 * time.Second}
+	serverErrC := make(chan error, 1)
+	go func() {
+		serverErrC <- server.ListenAndServe()
+	}()
+	b.l.Infow("Serving metrics", "addr", addr, "interval", interval)
+
+	wsService := b.wsClient.NewOrderPlaceWsService()
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for i := 0; ; i++ {
+		test := tests[i%len(tests)]
+		res := b.placeBoth(ctx, wsService, test, futures.NewOrderRespTypeRESULT)
+		if i >= warmup {
+			metrics.observe(res, b.serverTimeDiff)
+			if err := b.notifier.notifyProbe(ctx, test.Symbol, res.latencies(b.serverTimeDiff)); err != nil {
+				b.l.Errorw("Failed to post alert to webhook", "err", err)
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			b.l.Info("Stopping daemon")
+			shutdownCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+			defer cancel()
+			return server.Shutdown(shutdownCtx)
+		case err := <-serverErrC:
+			b.l.Errorw("Metrics server stopped", "err", err)
+			return err
+		case <-ticker.C:
+		}
+	}
+}
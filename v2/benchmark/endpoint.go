@@ -0,0 +1,91 @@
+package benchmark
+
+import (
+	"fmt"
+	"net"
+	"net/http"
+	"strings"
+
+	"github.com/adshao/go-binance/v2/futures"
+)
+
+// defaultEndpointName is the name of the endpoint used when none is configured
+const defaultEndpointName = "default"
+
+// EndpointConfig define where a run sends orders, empty fields keep the client defaults
+type EndpointConfig struct {
+	Name    string `json:"name"`
+	RestURL string `json:"restUrl,omitempty"`
+	WsURL   string `json:"wsUrl,omitempty"`
+	// LocalIP is the local address connections are dialed from, to pick the egress IP
+	LocalIP string `json:"localIp,omitempty"`
+}
+
+// ParseEndpoint parses "name=restURL|wsURL|localIP", any part after the name may be empty or
+// omitted, e.g. "alt=|wss://ws-fapi.binance.com/ws-fapi/v1" or "eth1=||10.0.0.5"
+func ParseEndpoint(s string) (EndpointConfig, error) {
+	name, value, _ := strings.Cut(s, "=")
+	e := EndpointConfig{Name: strings.TrimSpace(name)}
+	if e.Name == "" {
+		return e, fmt.Errorf("endpoint %q has no name", s)
+	}
+
+	parts := strings.Split(value, "|")
+	if len(parts) > 3 {
+		return e, fmt.Errorf("endpoint %q has too many parts", s)
+	}
+	parts = append(parts, "", "")
+	e.RestURL, e.WsURL, e.LocalIP = strings.TrimSpace(parts[0]), strings.TrimSpace(parts[1]), strings.TrimSpace(parts[2])
+	if e.LocalIP != "" && net.ParseIP(e.LocalIP) == nil {
+		return e, fmt.Errorf("endpoint %q has invalid local IP", s)
+	}
+	return e, nil
+}
+
+// localAddr returns the address to dial from, nil if LocalIP is not set
+func (e EndpointConfig) localAddr() *net.TCPAddr {
+	if e.LocalIP == "" {
+		return nil
+	}
+	return &net.TCPAddr{IP: net.ParseIP(e.LocalIP)}
+}
+
+// wsOptions returns the options of the websocket API clients of the endpoint
+func (e EndpointConfig) wsOptions() []futures.ClientWsOption {
+	var opts []futures.ClientWsOption
+	if e.WsURL != "" {
+		opts = append(opts, futures.WithWsApiEndpoint(e.WsURL))
+	}
+	if addr := e.localAddr(); addr != nil {
+		opts = append(opts, futures.WithWsSocketConfig(e.socket()))
+	}
+	return opts
+}
+
+// socket returns the socket settings of the websocket connections of the endpoint
+func (e EndpointConfig) socket() futures.WsSocketConfig {
+	socket := futures.WsSocketOptions
+	if addr := e.localAddr(); addr != nil {
+		socket.LocalAddr = addr
+	}
+	return socket
+}
+
+// newClients inits the REST and websocket API clients of the endpoint
+func (e EndpointConfig) newClients(apiKey, secretKey string) (*futures.Client, *futures.ClientWs, error) {
+	restClient := futures.NewClient(apiKey, secretKey)
+	if e.RestURL != "" {
+		restClient.BaseURL = e.RestURL
+	}
+	if addr := e.localAddr(); addr != nil {
+		transport := http.DefaultTransport.(*http.Transport).Clone()
+		transport.DialContext = (&net.Dialer{LocalAddr: addr}).DialContext
+		restClient.HTTPClient = &http.Client{Transport: transport}
+	}
+
+	wsClient, err := futures.NewClientWs(apiKey, secretKey, e.wsOptions()...)
+	if err != nil {
+		return nil, nil, err
+	}
+	return restClient, wsClient, nil
+}
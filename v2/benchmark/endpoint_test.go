@@ -0,0 +1,44 @@
+package benchmark
+
+import (
+	"net"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseEndpoint(t *testing.T) {
+	tests := []struct {
+		in  string
+		out EndpointConfig
+	}{
+		{"default=", EndpointConfig{Name: "default"}},
+		{"default", EndpointConfig{Name: "default"}},
+		{"rest=https://fapi.binance.com", EndpointConfig{Name: "rest", RestURL: "https://fapi.binance.com"}},
+		{"alt=|wss://ws-fapi.binance.com/ws-fapi/v1", EndpointConfig{Name: "alt", WsURL: "wss://ws-fapi.binance.com/ws-fapi/v1"}},
+		{" eth1 = || 10.0.0.5 ", EndpointConfig{Name: "eth1", LocalIP: "10.0.0.5"}},
+		{"all=https://a|wss://b|::1", EndpointConfig{Name: "all", RestURL: "https://a", WsURL: "wss://b", LocalIP: "::1"}},
+	}
+	for _, test := range tests {
+		e, err := ParseEndpoint(test.in)
+		if assert.NoError(t, err, test.in) {
+			assert.Equal(t, test.out, e, test.in)
+		}
+	}
+
+	for _, in := range []string{"", "=https://a", "a=1|2|3|4", "a=||10.0.0"} {
+		_, err := ParseEndpoint(in)
+		assert.Error(t, err, in)
+	}
+}
+
+func TestEndpointSocket(t *testing.T) {
+	e, err := ParseEndpoint("eth1=||10.0.0.5")
+	require.NoError(t, err)
+	assert.Equal(t, &net.TCPAddr{IP: net.ParseIP("10.0.0.5")}, e.socket().LocalAddr)
+	assert.Len(t, e.wsOptions(), 1)
+
+	assert.Nil(t, EndpointConfig{Name: "default"}.socket().LocalAddr)
+	assert.Empty(t, EndpointConfig{Name: "default"}.wsOptions())
+}
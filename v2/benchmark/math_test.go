@@ -0,0 +1,41 @@
+package benchmark
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPercentile(t *testing.T) {
+	sorted := []float64{1, 2, 3, 4, 5, 6, 7, 8, 9, 10}
+	assert.Equal(t, 0.0, Percentile(nil, 50))
+	assert.Equal(t, 1.0, Percentile(sorted, 0))
+	assert.InDelta(t, 5.5, Percentile(sorted, 50), 1e-9)
+	assert.InDelta(t, 1.9, Percentile(sorted, 10), 1e-9)
+	assert.InDelta(t, 9.91, Percentile(sorted, 99), 1e-9)
+	assert.Equal(t, 10.0, Percentile(sorted, 100))
+	assert.Equal(t, 7.0, Percentile([]float64{7}, 99))
+}
+
+func TestMean(t *testing.T) {
+	assert.Equal(t, 0.0, Mean(nil))
+	assert.InDelta(t, 2.5, Mean([]float64{1, 2, 3, 4}), 1e-9)
+}
+
+func TestRoundDown(t *testing.T) {
+	assert.Equal(t, 1.23, RoundDown(1.2399, 2))
+	assert.Equal(t, 100.0, RoundDown(109, -1))
+}
+
+func TestGetPrecision(t *testing.T) {
+	step, precision, err := GetPrecision("0.001")
+	require.NoError(t, err)
+	assert.Equal(t, 0.001, step)
+	assert.Equal(t, 3, precision)
+
+	_, _, err = GetPrecision("0")
+	assert.Error(t, err)
+	_, _, err = GetPrecision("x")
+	assert.Error(t, err)
+}
@@ -0,0 +1,199 @@
+package benchmark
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/adshao/go-binance/v2/futures"
+)
+
+// amendVisibleTimeout is how long to wait for the user stream to report a modified price
+const amendVisibleTimeout = 5 * time.Second
+
+var modifyHeader = []string{
+	"symbol", "qty", "price", "new_price", "side", "tif",
+	"ws_latency", "rest_latency", "ws_rtt", "rest_rtt", "ws_visible", "rest_visible", "ws_error", "rest_error",
+}
+
+// amendWatcher reports when the user stream shows orders at their modified price
+type amendWatcher struct {
+	mu      sync.Mutex
+	waiters map[int64]amendWaiter
+}
+
+type amendWaiter struct {
+	price float64
+	c     chan int64
+}
+
+func newAmendWatcher() *amendWatcher {
+	return &amendWatcher{waiters: make(map[int64]amendWaiter)}
+}
+
+// watch returns a channel receiving the local time in milliseconds when order orderID is
+// reported at price
+func (w *amendWatcher) watch(orderID int64, price float64) <-chan int64 {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	c := make(chan int64, 1)
+	w.waiters[orderID] = amendWaiter{price: price, c: c}
+	return c
+}
+
+func (w *amendWatcher) unwatch(orderID int64) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	delete(w.waiters, orderID)
+}
+
+func (w *amendWatcher) handleUserDataEvent(event *futures.WsUserDataEvent) {
+	now := time.Now().UnixMilli()
+	if event.Event != futures.UserDataEventTypeOrderTradeUpdate {
+		return
+	}
+	u := event.OrderTradeUpdate
+	if u.ExecutionType != futures.OrderExecutionTypeAmendment {
+		return
+	}
+
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	waiter, ok := w.waiters[u.ID]
+	if !ok || StringToFloat(u.OriginalPrice) != waiter.price {
+		return
+	}
+	delete(w.waiters, u.ID)
+	waiter.c <- now
+}
+
+// modify measures latency of modifying the price of resting orders through WS and REST at
+// once. Every test places two resting orders away from the market, then modifies one
+// through each and waits for the user stream to report the new price.
+func (b *benchmark) modify(ctx context.Context, tests []placeOrderParam) ([][]string, error) {
+	watcher := newAmendWatcher()
+	stop, err := b.serveUserData(ctx, watcher.handleUserDataEvent)
+	if err != nil {
+		return nil, err
+	}
+	defer stop()
+
+	wsService := b.wsClient.NewOrderModifyWsService()
+	data := [][]string{}
+	for _, test := range tests {
+		if ctx.Err() != nil {
+			break
+		}
+		if test.NewPrice == 0 || test.NewPrice == test.Price {
+			continue
+		}
+		wsOrderID, err := b.placeResting(ctx, test)
+		if err != nil {
+			b.l.Errorw("Failed to place resting order", "symbol", test.Symbol, "err", err)
+			continue
+		}
+		restOrderID, err := b.placeResting(ctx, test)
+		if err != nil {
+			b.l.Errorw("Failed to place resting order", "symbol", test.Symbol, "err", err)
+			b.cleanup(test.Symbol)
+			continue
+		}
+
+		testCtx, cancel := b.testContext(ctx)
+		var (
+			wsVisibleC   = watcher.watch(wsOrderID, test.NewPrice)
+			restVisibleC = watcher.watch(restOrderID, test.NewPrice)
+			now          = time.Now().UnixMilli()
+			wg           sync.WaitGroup
+
+			wsUpdateTime, restUpdateTime int64
+			wsDoneTime, restDoneTime     int64
+			wsErr, restErr               error
+		)
+		wg.Add(2)
+
+		// modify WS order
+		go func() {
+			defer wg.Done()
+			req := futures.NewModifyOrderRequest().
+				Symbol(test.Symbol).
+				Side(b.cfg.Side).
+				OrderID(wsOrderID).
+				Quantity(FloatToString(test.Qty)).
+				Price(FloatToString(test.NewPrice))
+			order, err := wsService.Do(testCtx, req)
+			if err != nil {
+				b.l.Errorw("Failed to modify ws order", "err", err)
+				wsErr = err
+				return
+			}
+			wsDoneTime = time.Now().UnixMilli()
+			wsUpdateTime = order.UpdateTime
+		}()
+
+		// modify rest API order
+		go func() {
+			defer wg.Done()
+			order, err := b.restClient.NewModifyOrderService().
+				Symbol(test.Symbol).
+				Side(b.cfg.Side).
+				OrderID(restOrderID).
+				Quantity(FloatToString(test.Qty)).
+				Price(FloatToString(test.NewPrice)).
+				Do(testCtx)
+			if err != nil {
+				b.l.Errorw("Failed to modify rest order", "err", err)
+				restErr = err
+				return
+			}
+			restDoneTime = time.Now().UnixMilli()
+			restUpdateTime = order.UpdateTime
+		}()
+		wg.Wait()
+		cancel()
+
+		// failed modifications are not waited for
+		if wsErr != nil {
+			wsVisibleC = nil
+		}
+		if restErr != nil {
+			restVisibleC = nil
+		}
+		var (
+			timeout                = time.After(amendVisibleTimeout)
+			wsVisible, restVisible string
+		)
+		for wsVisibleC != nil || restVisibleC != nil {
+			select {
+			case t := <-wsVisibleC:
+				wsVisible, wsVisibleC = IntToString(t-now), nil
+			case t := <-restVisibleC:
+				restVisible, restVisibleC = IntToString(t-now), nil
+			case <-timeout:
+				b.l.Warnw("Modified price not visible on user stream", "symbol", test.Symbol)
+				wsVisibleC, restVisibleC = nil, nil
+			}
+		}
+		watcher.unwatch(wsOrderID)
+		watcher.unwatch(restOrderID)
+		b.cleanup(test.Symbol)
+
+		// "symbol", "qty", "price", "new_price", "side", "tif", "ws_latency", "rest_latency",
+		// "ws_rtt", "rest_rtt", "ws_visible", "rest_visible", "ws_error", "rest_error"
+		data = append(data, []string{
+			test.Symbol, FloatToString(test.Qty), FloatToString(test.Price), FloatToString(test.NewPrice), string(b.cfg.Side), string(b.cfg.restingTimeInForce()),
+			b.resultLatency(now, wsUpdateTime, wsErr),
+			b.resultLatency(now, restUpdateTime, restErr),
+			roundTrip(now, wsDoneTime, wsErr),
+			roundTrip(now, restDoneTime, restErr),
+			wsVisible,
+			restVisible,
+			errorString(wsErr),
+			errorString(restErr),
+		})
+
+		b.pause(ctx)
+	}
+	return data, nil
+}
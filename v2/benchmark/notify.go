@@ -0,0 +1,133 @@
+package benchmark
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+)
+
+// alertCooldown is the minimum time between two latency alerts of daemon mode
+const alertCooldown = 5 * time.Minute
+
+// webhookNotifier posts run summaries and latency alerts to a Slack compatible webhook, a nil
+// notifier posts nothing
+type webhookNotifier struct {
+	url string
+	// threshold is the latency alerted above, compared with p99 latencies of runs and latencies
+	// of daemon probes, 0 disables alerts
+	threshold time.Duration
+	client    *http.Client
+
+	mu        sync.Mutex
+	lastAlert time.Time
+}
+
+// newWebhookNotifier init webhookNotifier, nil if url is empty
+func newWebhookNotifier(url string, threshold time.Duration) *webhookNotifier {
+	if url == "" {
+		return nil
+	}
+	return &webhookNotifier{url: url, threshold: threshold, client: &http.Client{Timeout: 10 * time.Second}}
+}
+
+// slowColumns returns the latency columns of s whose p99 is above the threshold of n
+func (n *webhookNotifier) slowColumns(s Report) []ColumnSummary {
+	var res []ColumnSummary
+	if n.threshold <= 0 {
+		return res
+	}
+	for _, column := range s.Columns {
+		if isLatencyColumn(column.Column) && column.Count > 0 && column.P99 > float64(n.threshold.Milliseconds()) {
+			res = append(res, column)
+		}
+	}
+	return res
+}
+
+// notifyRun posts the summary of a finished run, flagged as an alert if a p99 latency is above
+// the threshold
+func (n *webhookNotifier) notifyRun(ctx context.Context, s Report) error {
+	if n == nil {
+		return nil
+	}
+	var text strings.Builder
+	if s.Error != "" {
+		fmt.Fprintf(&text, ":x: Benchmark %s failed on %s: %s\n", s.Mode, s.Host, s.Error)
+	} else {
+		fmt.Fprintf(&text, "Benchmark %s finished on %s\n", s.Mode, s.Host)
+	}
+	if slow := n.slowColumns(s); len(slow) > 0 {
+		fmt.Fprintf(&text, ":rotating_light: p99 latency above %s:\n", n.threshold)
+		for _, column := range slow {
+			fmt.Fprintf(&text, "• %s p99=%.0fms\n", column.name(), column.P99)
+		}
+	}
+	for _, column := range s.Columns {
+		if isLatencyColumn(column.Column) {
+			fmt.Fprintf(&text, "%s: n=%d mean=%.1fms p50=%.0fms p99=%.0fms\n",
+				column.name(), column.Count, column.Mean, column.P50, column.P99)
+		} else if column.Count > 0 {
+			fmt.Fprintf(&text, "%s: %d\n", column.name(), column.Count)
+		}
+	}
+	fmt.Fprintf(&text, "ws reconnects: %d", s.Reconnects)
+	return n.post(ctx, text.String(), &s)
+}
+
+// notifyProbe posts an alert if a latency of a daemon probe is above the threshold, at most once
+// per alertCooldown
+func (n *webhookNotifier) notifyProbe(ctx context.Context, symbol string, latencies map[string]time.Duration) error {
+	if n == nil || n.threshold <= 0 {
+		return nil
+	}
+	var slow []string
+	for _, transport := range []string{transportWs, transportRest} {
+		if latency, ok := latencies[transport]; ok && latency > n.threshold {
+			slow = append(slow, fmt.Sprintf("%s=%s", transport, latency))
+		}
+	}
+	if len(slow) == 0 {
+		return nil
+	}
+
+	n.mu.Lock()
+	if time.Since(n.lastAlert) < alertCooldown {
+		n.mu.Unlock()
+		return nil
+	}
+	n.lastAlert = time.Now()
+	n.mu.Unlock()
+
+	text := fmt.Sprintf(":rotating_light: %s probe latency above %s: %s", symbol, n.threshold, strings.Join(slow, " "))
+	return n.post(ctx, text, nil)
+}
+
+// post sends text, shown by Slack, with the structured summary for other consumers
+func (n *webhookNotifier) post(ctx context.Context, text string, summary *Report) error {
+	body, err := json.Marshal(struct {
+		Text    string  `json:"text"`
+		Summary *Report `json:"summary,omitempty"`
+	}{text, summary})
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, n.url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := n.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode >= http.StatusBadRequest {
+		return fmt.Errorf("webhook responded %s", resp.Status)
+	}
+	return nil
+}
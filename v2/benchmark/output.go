@@ -0,0 +1,228 @@
+package benchmark
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"runtime/debug"
+	"strconv"
+	"time"
+
+	"github.com/parquet-go/parquet-go"
+)
+
+const (
+	// OutputFormatCSV, OutputFormatJSON and OutputFormatParquet are the formats of results files,
+	// JSON and Parquet ones hold the metadata of the run
+	OutputFormatCSV     = "csv"
+	OutputFormatJSON    = "json"
+	OutputFormatParquet = "parquet"
+
+	// clientModule is the module of go-binance
+	clientModule = "github.com/adshao/go-binance/v2"
+)
+
+// runMetadata define the context of a run written with JSON and Parquet results
+type runMetadata struct {
+	Mode         string `json:"mode"`
+	Endpoint     string `json:"endpoint"`
+	SpotEndpoint string `json:"spotEndpoint,omitempty"`
+	// Endpoints are the endpoints compared by the run, results are tagged with their name
+	Endpoints     []EndpointConfig `json:"endpoints,omitempty"`
+	ClientVersion string           `json:"clientVersion"`
+	Region        string           `json:"region"`
+	Host          string           `json:"host"`
+	Seed          uint64           `json:"seed"`
+	StartTime     time.Time        `json:"startTime"`
+	EndTime       time.Time        `json:"endTime"`
+	Config        TestConfig       `json:"config"`
+}
+
+// resultRecord define a result row of any mode, latencies are in milliseconds and columns not
+// measured by the mode are nil
+type resultRecord struct {
+	EndpointName  string   `json:"endpointName,omitempty" parquet:"endpoint_name,optional"`
+	Market        string   `json:"market" parquet:"market"`
+	Symbol        string   `json:"symbol" parquet:"symbol"`
+	Qty           float64  `json:"qty" parquet:"qty"`
+	Price         float64  `json:"price" parquet:"price"`
+	NewPrice      *float64 `json:"newPrice,omitempty" parquet:"new_price"`
+	Side          string   `json:"side" parquet:"side"`
+	TimeInForce   string   `json:"tif" parquet:"tif"`
+	RespType      string   `json:"respType,omitempty" parquet:"resp_type,optional"`
+	KeyType       string   `json:"keyType,omitempty" parquet:"key_type,optional"`
+	Connections   *int64   `json:"connections,omitempty" parquet:"connections"`
+	Concurrency   *int64   `json:"concurrency,omitempty" parquet:"concurrency"`
+	WsLatencyMs   *int64   `json:"wsLatencyMs,omitempty" parquet:"ws_latency_ms"`
+	RestLatencyMs *int64   `json:"restLatencyMs,omitempty" parquet:"rest_latency_ms"`
+	WsRttMs       *int64   `json:"wsRttMs,omitempty" parquet:"ws_rtt_ms"`
+	RestRttMs     *int64   `json:"restRttMs,omitempty" parquet:"rest_rtt_ms"`
+	WsVisibleMs   *int64   `json:"wsVisibleMs,omitempty" parquet:"ws_visible_ms"`
+	RestVisibleMs *int64   `json:"restVisibleMs,omitempty" parquet:"rest_visible_ms"`
+	WsStreamMs    *int64   `json:"wsStreamMs,omitempty" parquet:"ws_stream_ms"`
+	RestStreamMs  *int64   `json:"restStreamMs,omitempty" parquet:"rest_stream_ms"`
+	WsError       string   `json:"wsError,omitempty" parquet:"ws_error,optional"`
+	RestError     string   `json:"restError,omitempty" parquet:"rest_error,optional"`
+}
+
+// parquetRecord define a Parquet row: a result with the metadata of its run
+type parquetRecord struct {
+	Mode          string `parquet:"mode"`
+	Endpoint      string `parquet:"endpoint"`
+	ClientVersion string `parquet:"client_version"`
+	Region        string `parquet:"region"`
+	Host          string `parquet:"host"`
+	StartTime     int64  `parquet:"start_time,timestamp(millisecond)"`
+	resultRecord
+}
+
+// clientVersion returns the version of go-binance the benchmark was built with
+func clientVersion() string {
+	info, ok := debug.ReadBuildInfo()
+	if !ok {
+		return ""
+	}
+	// services embedding the benchmark depend on go-binance
+	for _, dep := range info.Deps {
+		if dep.Path == clientModule {
+			return dep.Version
+		}
+	}
+	version := info.Main.Version
+	for _, s := range info.Settings {
+		if s.Key == "vcs.revision" {
+			version += "+" + s.Value
+		}
+	}
+	return version
+}
+
+// newResultRecords converts rows of CSV columns header into records
+func newResultRecords(header []string, data [][]string) ([]resultRecord, error) {
+	records := make([]resultRecord, 0, len(data))
+	for _, row := range data {
+		r := resultRecord{Market: marketFuture}
+		for i, column := range header {
+			var err error
+			v := row[i]
+			switch column {
+			case "endpoint":
+				r.EndpointName = v
+			case "market":
+				r.Market = v
+			case "symbol":
+				r.Symbol = v
+			case "qty":
+				r.Qty, err = strconv.ParseFloat(v, 64)
+			case "price":
+				r.Price, err = strconv.ParseFloat(v, 64)
+			case "new_price":
+				r.NewPrice, err = parseOptionalFloat(v)
+			case "side":
+				r.Side = v
+			case "tif":
+				r.TimeInForce = v
+			case "resp_type":
+				r.RespType = v
+			case "key_type":
+				r.KeyType = v
+			case "connections":
+				r.Connections, err = parseOptionalInt(v)
+			case "concurrency":
+				r.Concurrency, err = parseOptionalInt(v)
+			case "ws_latency":
+				r.WsLatencyMs, err = parseOptionalInt(v)
+			case "rest_latency":
+				r.RestLatencyMs, err = parseOptionalInt(v)
+			case "ws_rtt":
+				r.WsRttMs, err = parseOptionalInt(v)
+			case "rest_rtt":
+				r.RestRttMs, err = parseOptionalInt(v)
+			case "ws_visible":
+				r.WsVisibleMs, err = parseOptionalInt(v)
+			case "rest_visible":
+				r.RestVisibleMs, err = parseOptionalInt(v)
+			case "ws_stream_latency":
+				r.WsStreamMs, err = parseOptionalInt(v)
+			case "rest_stream_latency":
+				r.RestStreamMs, err = parseOptionalInt(v)
+			case "ws_error":
+				r.WsError = v
+			case "rest_error":
+				r.RestError = v
+			default:
+				err = fmt.Errorf("unknown column %q", column)
+			}
+			if err != nil {
+				return nil, err
+			}
+		}
+		records = append(records, r)
+	}
+	return records, nil
+}
+
+func parseOptionalFloat(s string) (*float64, error) {
+	if s == "" {
+		return nil, nil
+	}
+	f, err := strconv.ParseFloat(s, 64)
+	if err != nil {
+		return nil, err
+	}
+	return &f, nil
+}
+
+func parseOptionalInt(s string) (*int64, error) {
+	if s == "" {
+		return nil, nil
+	}
+	i, err := strconv.ParseInt(s, 10, 64)
+	if err != nil {
+		return nil, err
+	}
+	return &i, nil
+}
+
+// WriteJSON writes metadata and records as a JSON object into the folder path
+func WriteJSON(path string, metadata runMetadata, records []resultRecord) error {
+	file, err := os.Create(outputFile(path, OutputFormatJSON))
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	encoder := json.NewEncoder(file)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(struct {
+		Metadata runMetadata    `json:"metadata"`
+		Results  []resultRecord `json:"results"`
+	}{metadata, records})
+}
+
+// WriteParquet writes records with their metadata as Parquet rows into the folder path, the
+// whole metadata is also stored as JSON in the "metadata" key of the file
+func WriteParquet(path string, metadata runMetadata, records []resultRecord) error {
+	rawMetadata, err := json.Marshal(metadata)
+	if err != nil {
+		return err
+	}
+	rows := make([]parquetRecord, 0, len(records))
+	for _, r := range records {
+		rows = append(rows, parquetRecord{
+			Mode:          metadata.Mode,
+			Endpoint:      metadata.Endpoint,
+			ClientVersion: metadata.ClientVersion,
+			Region:        metadata.Region,
+			Host:          metadata.Host,
+			StartTime:     metadata.StartTime.UnixMilli(),
+			resultRecord:  r,
+		})
+	}
+	return parquet.WriteFile(outputFile(path, OutputFormatParquet), rows, parquet.KeyValueMetadata("metadata", string(rawMetadata)))
+}
+
+// outputFile returns the name of the result file of format in the folder path
+func outputFile(path, format string) string {
+	return fmt.Sprintf("%s/benchmark_%d.%s", path, time.Now().Unix(), format)
+}
@@ -0,0 +1,57 @@
+package benchmark
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io/fs"
+	"os"
+	"time"
+)
+
+// testPlan define the exact orders of a run and the seed of its random pauses, so runs before
+// and after a client change can be compared
+type testPlan struct {
+	Seed   uint64            `json:"seed"`
+	Config TestConfig        `json:"config"`
+	Tests  []placeOrderParam `json:"tests"`
+}
+
+// loadPlan reads the plan saved at path, nil if there is no file
+func loadPlan(path string) (*testPlan, error) {
+	raw, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	plan := &testPlan{}
+	if err := json.Unmarshal(raw, plan); err != nil {
+		return nil, err
+	}
+	if len(plan.Tests) == 0 {
+		return nil, errors.New("test plan has no test")
+	}
+	return plan, nil
+}
+
+// savePlan writes plan to path
+func savePlan(path string, plan *testPlan) error {
+	raw, err := json.MarshalIndent(plan, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, raw, 0o644)
+}
+
+// pause sleeps a random time of up to a second between tests, drawn from the seeded rng of b,
+// or until ctx is done
+func (b *benchmark) pause(ctx context.Context) {
+	timer := time.NewTimer(time.Duration(b.rng.Intn(1000)+1) * time.Millisecond)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+	case <-ctx.Done():
+	}
+}
@@ -0,0 +1,43 @@
+package benchmark
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/adshao/go-binance/v2/futures"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSaveLoadPlan(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "plan.json")
+
+	plan, err := loadPlan(path)
+	require.NoError(t, err)
+	assert.Nil(t, plan)
+
+	saved := &testPlan{
+		Seed: 42,
+		Config: TestConfig{
+			Count:       1,
+			QuoteAsset:  "USDT",
+			Side:        futures.SideTypeBuy,
+			TimeInForce: futures.TimeInForceTypeIOC,
+			PriceOffset: 10,
+		},
+		Tests: []placeOrderParam{{Symbol: "BTCUSDT", Price: 54000.1, Qty: 0.002, NewPrice: 53400}},
+	}
+	require.NoError(t, savePlan(path, saved))
+	plan, err = loadPlan(path)
+	require.NoError(t, err)
+	assert.Equal(t, saved, plan)
+}
+
+func TestLoadPlanWithoutTests(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "plan.json")
+	require.NoError(t, os.WriteFile(path, []byte(`{"seed": 1, "tests": []}`), 0o644))
+
+	_, err := loadPlan(path)
+	assert.Error(t, err)
+}
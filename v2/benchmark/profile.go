@@ -0,0 +1,109 @@
+package benchmark
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	httppprof "net/http/pprof"
+	"os"
+	"runtime"
+	"runtime/pprof"
+	"runtime/trace"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// ProfileConfig define the profiles captured around a run, empty fields are disabled
+type ProfileConfig struct {
+	// PprofAddr is the listen address of the /debug/pprof endpoints
+	PprofAddr string
+	// CPUProfile, AllocProfile and TraceFile are the files the profiles are written to
+	CPUProfile   string
+	AllocProfile string
+	TraceFile    string
+}
+
+// startProfiling starts the profiles of cfg, so client-side time spent in signing, JSON and
+// scheduling can be told apart from network and exchange latency. The returned stop writes
+// and closes them.
+func startProfiling(l *zap.SugaredLogger, cfg ProfileConfig) (_ func() error, err error) {
+	var stops []func() error
+	stop := func() error {
+		var errs []error
+		for i := len(stops) - 1; i >= 0; i-- {
+			errs = append(errs, stops[i]())
+		}
+		return errors.Join(errs...)
+	}
+	defer func() {
+		if err != nil {
+			_ = stop()
+		}
+	}()
+
+	if cfg.PprofAddr != "" {
+		mux := http.NewServeMux()
+		mux.HandleFunc("/debug/pprof/", httppprof.Index)
+		mux.HandleFunc("/debug/pprof/cmdline", httppprof.Cmdline)
+		mux.HandleFunc("/debug/pprof/profile", httppprof.Profile)
+		mux.HandleFunc("/debug/pprof/symbol", httppprof.Symbol)
+		mux.HandleFunc("/debug/pprof/trace", httppprof.Trace)
+		server := &http.Server{Addr: cfg.PprofAddr, Handler: mux, ReadHeaderTimeout: 5 * time.Second}
+		go func() {
+			if err := server.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+				l.Errorw("Pprof server stopped", "err", err)
+			}
+		}()
+		l.Infow("Serving pprof", "addr", cfg.PprofAddr)
+		stops = append(stops, func() error {
+			ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+			defer cancel()
+			return server.Shutdown(ctx)
+		})
+	}
+
+	if cfg.CPUProfile != "" {
+		f, err := os.Create(cfg.CPUProfile)
+		if err != nil {
+			return nil, err
+		}
+		if err := pprof.StartCPUProfile(f); err != nil {
+			f.Close()
+			return nil, err
+		}
+		stops = append(stops, func() error {
+			pprof.StopCPUProfile()
+			return f.Close()
+		})
+	}
+
+	if cfg.TraceFile != "" {
+		f, err := os.Create(cfg.TraceFile)
+		if err != nil {
+			return nil, err
+		}
+		if err := trace.Start(f); err != nil {
+			f.Close()
+			return nil, err
+		}
+		stops = append(stops, func() error {
+			trace.Stop()
+			return f.Close()
+		})
+	}
+
+	if cfg.AllocProfile != "" {
+		// create the file upfront so a bad path fails before the run
+		f, err := os.Create(cfg.AllocProfile)
+		if err != nil {
+			return nil, err
+		}
+		stops = append(stops, func() error {
+			runtime.GC()
+			err := pprof.Lookup("allocs").WriteTo(f, 0)
+			return errors.Join(err, f.Close())
+		})
+	}
+	return stop, nil
+}
@@ -0,0 +1,215 @@
+package benchmark
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/adshao/go-binance/v2/futures"
+)
+
+// errResidue is returned when orders or positions created by a run remain after cleanup
+var errResidue = errors.New("orders or positions of the run remain")
+
+// positionKey identify a position of a symbol, positionSide is BOTH in One-way Mode
+type positionKey struct {
+	symbol, positionSide string
+}
+
+// positionSnapshot define position amounts of the tested symbols at a point in time, kept as
+// strings to format flattening orders at their precision
+type positionSnapshot map[positionKey]string
+
+// amount returns the position amount of key, 0 if there is no position
+func (s positionSnapshot) amount(key positionKey) string {
+	if amount, ok := s[key]; ok {
+		return amount
+	}
+	return "0"
+}
+
+// testSymbols returns the distinct symbols of tests
+func testSymbols(tests []placeOrderParam) []string {
+	seen := make(map[string]bool, len(tests))
+	symbols := make([]string, 0, len(tests))
+	for _, test := range tests {
+		if !seen[test.Symbol] {
+			seen[test.Symbol] = true
+			symbols = append(symbols, test.Symbol)
+		}
+	}
+	return symbols
+}
+
+// positions returns the non zero positions of symbols
+func (b *benchmark) positions(ctx context.Context, symbols []string) (positionSnapshot, error) {
+	risks, err := b.restClient.NewGetPositionRiskService().Do(ctx)
+	if err != nil {
+		return nil, err
+	}
+	tested := make(map[string]bool, len(symbols))
+	for _, symbol := range symbols {
+		tested[symbol] = true
+	}
+	snapshot := make(positionSnapshot)
+	for _, p := range risks {
+		if tested[p.Symbol] && StringToFloat(p.PositionAmt) != 0 {
+			snapshot[positionKey{p.Symbol, p.PositionSide}] = p.PositionAmt
+		}
+	}
+	return snapshot, nil
+}
+
+// positionChanges returns the amounts positions changed by since before, formatted at the
+// precision of the amounts
+func positionChanges(before, after positionSnapshot) map[positionKey]string {
+	changes := make(map[positionKey]string)
+	for _, snapshot := range []positionSnapshot{before, after} {
+		for key := range snapshot {
+			if _, ok := changes[key]; ok {
+				continue
+			}
+			prev, cur := before.amount(key), after.amount(key)
+			precision := max(decimals(prev), decimals(cur))
+			change := strconv.FormatFloat(StringToFloat(cur)-StringToFloat(prev), 'f', precision, 64)
+			if StringToFloat(change) != 0 {
+				changes[key] = change
+			}
+		}
+	}
+	return changes
+}
+
+// decimals returns the number of decimals of the number s
+func decimals(s string) int {
+	if _, fraction, ok := strings.Cut(s, "."); ok {
+		return len(fraction)
+	}
+	return 0
+}
+
+// residualOrder define an order placed by the run still open
+type residualOrder struct {
+	market, symbol string
+	orderID        int64
+}
+
+// residualOrders returns the orders of symbols placed since startTime still open, spot ones
+// included if the run used spot
+func (b *benchmark) residualOrders(symbols []string, startTime time.Time) ([]residualOrder, error) {
+	var res []residualOrder
+	for _, symbol := range symbols {
+		orders, err := b.restClient.NewListOpenOrdersService().Symbol(symbol).Do(context.Background())
+		if err != nil {
+			return nil, err
+		}
+		for _, order := range orders {
+			if order.Time >= startTime.UnixMilli() {
+				res = append(res, residualOrder{marketFuture, symbol, order.OrderID})
+			}
+		}
+		if b.spotRestClient == nil {
+			continue
+		}
+		spotOrders, err := b.spotRestClient.NewListOpenOrdersService().Symbol(symbol).Do(context.Background())
+		if err != nil {
+			return nil, err
+		}
+		for _, order := range spotOrders {
+			if order.Time >= startTime.UnixMilli() {
+				res = append(res, residualOrder{marketSpot, symbol, order.OrderID})
+			}
+		}
+	}
+	return res, nil
+}
+
+func (b *benchmark) cancelResidualOrder(o residualOrder) error {
+	if o.market == marketSpot {
+		_, err := b.spotRestClient.NewCancelOrderService().Symbol(o.symbol).OrderID(o.orderID).Do(context.Background())
+		return err
+	}
+	_, err := b.restClient.NewCancelOrderService().Symbol(o.symbol).OrderID(o.orderID).Do(context.Background())
+	return err
+}
+
+// residue returns a description of the orders of symbols placed since startTime still open and
+// the positions changed since before, empty if there is none
+func (b *benchmark) residue(symbols []string, startTime time.Time, before positionSnapshot) ([]string, error) {
+	orders, err := b.residualOrders(symbols, startTime)
+	if err != nil {
+		return nil, err
+	}
+	after, err := b.positions(context.Background(), symbols)
+	if err != nil {
+		return nil, err
+	}
+
+	var res []string
+	for _, o := range orders {
+		res = append(res, fmt.Sprintf("%s %s order %d", o.symbol, o.market, o.orderID))
+	}
+	for key, change := range positionChanges(before, after) {
+		res = append(res, fmt.Sprintf("%s %s position changed by %s", key.symbol, key.positionSide, change))
+	}
+	return res, nil
+}
+
+// cleanupResidue cancels the orders of symbols placed since startTime still open, as network
+// errors may leave orders in an unknown state, and brings the positions changed since before
+// back to their amount with market orders, reduce only for positions opened by the run. It
+// fails with errResidue if anything remains afterwards. It runs after the context of the run is
+// done too, so its requests are not bound to it.
+func (b *benchmark) cleanupResidue(symbols []string, startTime time.Time, before positionSnapshot) error {
+	orders, err := b.residualOrders(symbols, startTime)
+	if err != nil {
+		b.l.Errorw("Failed to list open orders", "err", err)
+		return err
+	}
+	for _, o := range orders {
+		b.l.Warnw("Canceling residual order", "market", o.market, "symbol", o.symbol, "orderID", o.orderID)
+		if err := b.cancelResidualOrder(o); err != nil {
+			b.l.Errorw("Failed to cancel residual order", "market", o.market, "symbol", o.symbol, "orderID", o.orderID, "err", err)
+		}
+	}
+
+	after, err := b.positions(context.Background(), symbols)
+	if err != nil {
+		b.l.Errorw("Failed to get positions", "err", err)
+		return err
+	}
+	for key, change := range positionChanges(before, after) {
+		b.l.Warnw("Flattening residual position", "symbol", key.symbol, "positionSide", key.positionSide, "change", change)
+		side := futures.SideTypeSell
+		if StringToFloat(change) < 0 {
+			side = futures.SideTypeBuy
+		}
+		s := b.restClient.NewCreateOrderService().
+			Symbol(key.symbol).
+			Side(side).
+			Type(futures.OrderTypeMarket).
+			Quantity(strings.TrimPrefix(change, "-"))
+		if positionSide := futures.PositionSideType(key.positionSide); positionSide != futures.PositionSideTypeBoth {
+			s.PositionSide(positionSide)
+		} else if before[key] == "" {
+			s.ReduceOnly(true)
+		}
+		if _, err := s.Do(context.Background()); err != nil {
+			b.l.Errorw("Failed to flatten residual position", "symbol", key.symbol, "err", err)
+		}
+	}
+
+	residue, err := b.residue(symbols, startTime, before)
+	if err != nil {
+		b.l.Errorw("Failed to check residue", "err", err)
+		return err
+	}
+	if len(residue) > 0 {
+		b.l.Errorw("Residue remains after cleanup", "residue", residue)
+		return fmt.Errorf("%w: %s", errResidue, strings.Join(residue, ", "))
+	}
+	return nil
+}
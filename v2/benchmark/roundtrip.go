@@ -0,0 +1,125 @@
+package benchmark
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/adshao/go-binance/v2/futures"
+)
+
+// roundTripHeader define the columns of roundtrip results, round trips are local times
+var roundTripHeader = []string{
+	"symbol", "qty", "price", "side", "tif",
+	"ws_place_rtt", "ws_cancel_rtt", "ws_rtt", "rest_place_rtt", "rest_cancel_rtt", "rest_rtt", "ws_error", "rest_error",
+}
+
+// placeCancelResult define the round trips in milliseconds of placing an order and of canceling
+// it once acknowledged, err is the error of whichever failed
+type placeCancelResult struct {
+	place, cancel int64
+	err           error
+}
+
+// columns returns the place, cancel and total round trip columns of res, empty if it failed
+func (res placeCancelResult) columns() []string {
+	if res.err != nil {
+		return []string{"", "", ""}
+	}
+	return []string{IntToString(res.place), IntToString(res.cancel), IntToString(res.place + res.cancel)}
+}
+
+// placeCancel measures the round trip of placing a resting order and canceling it as soon as
+// it is acknowledged, through WS and REST at once, as quoting strategies do. Orders left by a
+// failed test are canceled.
+func (b *benchmark) placeCancel(ctx context.Context, tests []placeOrderParam) [][]string {
+	placeService, cancelService := b.wsClient.NewOrderPlaceWsService(), b.wsClient.NewOrderCancelWsService()
+	data := [][]string{}
+	for _, test := range tests {
+		if ctx.Err() != nil {
+			break
+		}
+		testCtx, cancel := b.testContext(ctx)
+		var (
+			ws, rest placeCancelResult
+			wg       sync.WaitGroup
+		)
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			ws = b.wsPlaceCancel(testCtx, placeService, cancelService, test)
+		}()
+		go func() {
+			defer wg.Done()
+			rest = b.restPlaceCancel(testCtx, test)
+		}()
+		wg.Wait()
+		cancel()
+		if ws.err != nil || rest.err != nil {
+			b.cleanup(test.Symbol)
+		}
+
+		// "symbol", "qty", "price", "side", "tif", "ws_place_rtt", "ws_cancel_rtt", "ws_rtt",
+		// "rest_place_rtt", "rest_cancel_rtt", "rest_rtt", "ws_error", "rest_error"
+		row := []string{test.Symbol, FloatToString(test.Qty), FloatToString(test.Price), string(b.cfg.Side), string(b.cfg.restingTimeInForce())}
+		row = append(row, ws.columns()...)
+		row = append(row, rest.columns()...)
+		data = append(data, append(row, errorString(ws.err), errorString(rest.err)))
+
+		b.pause(ctx)
+	}
+	return data
+}
+
+// wsPlaceCancel places a resting order of test through WS, then cancels it
+func (b *benchmark) wsPlaceCancel(ctx context.Context, placeService *futures.OrderPlaceWsService, cancelService *futures.OrderCancelWsService, test placeOrderParam) (res placeCancelResult) {
+	start := time.Now()
+	order, err := placeService.Do(ctx, futures.NewOrderPlaceWsRequest().
+		Symbol(test.Symbol).
+		Side(b.cfg.Side).
+		Type(futures.OrderTypeLimit).
+		Price(FloatToString(test.Price)).
+		Quantity(FloatToString(test.Qty)).
+		TimeInForce(b.cfg.restingTimeInForce()).
+		NewOrderResponseType(futures.NewOrderRespTypeACK))
+	if err != nil {
+		b.l.Errorw("Failed to place ws order", "err", err)
+		res.err = err
+		return res
+	}
+	placed := time.Now()
+	if _, err := cancelService.Do(ctx, futures.NewCancelOrderRequest().Symbol(test.Symbol).OrderID(order.OrderID)); err != nil {
+		b.l.Errorw("Failed to cancel ws order", "err", err)
+		res.err = err
+		return res
+	}
+	res.place, res.cancel = placed.Sub(start).Milliseconds(), time.Since(placed).Milliseconds()
+	return res
+}
+
+// restPlaceCancel places a resting order of test through REST, then cancels it
+func (b *benchmark) restPlaceCancel(ctx context.Context, test placeOrderParam) (res placeCancelResult) {
+	start := time.Now()
+	order, err := b.restClient.NewCreateOrderService().
+		Symbol(test.Symbol).
+		Side(b.cfg.Side).
+		Type(futures.OrderTypeLimit).
+		TimeInForce(b.cfg.restingTimeInForce()).
+		Price(FloatToString(test.Price)).
+		Quantity(FloatToString(test.Qty)).
+		NewOrderResponseType(futures.NewOrderRespTypeACK).
+		Do(ctx)
+	if err != nil {
+		b.l.Errorw("Failed to place rest order", "err", err)
+		res.err = err
+		return res
+	}
+	placed := time.Now()
+	if _, err := b.restClient.NewCancelOrderService().Symbol(test.Symbol).OrderID(order.OrderID).Do(ctx); err != nil {
+		b.l.Errorw("Failed to cancel rest order", "err", err)
+		res.err = err
+		return res
+	}
+	res.place, res.cancel = placed.Sub(start).Milliseconds(), time.Since(placed).Milliseconds()
+	return res
+}
@@ -0,0 +1,112 @@
+package benchmark
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/adshao/go-binance/v2/common"
+	"github.com/adshao/go-binance/v2/futures"
+)
+
+const (
+	keyTypeHMAC    = "hmac"
+	keyTypeEd25519 = "ed25519"
+)
+
+// signingHeader define the columns of signing results
+var signingHeader = []string{
+	"key_type", "symbol", "qty", "price", "side", "tif", "ws_latency", "rest_latency", "ws_rtt", "rest_rtt", "ws_error", "rest_error",
+}
+
+// ed25519Key define the Ed25519 API key compared with the HMAC one in signing mode
+type ed25519Key struct {
+	APIKey string
+	Signer common.Signer
+}
+
+// newEd25519Key returns the Ed25519 key of apiKey whose private key is in the PEM file keyFile
+func newEd25519Key(apiKey, keyFile string) (ed25519Key, error) {
+	if apiKey == "" || keyFile == "" {
+		return ed25519Key{}, fmt.Errorf("%s mode requires an Ed25519 API key and its private key file", ModeSigning)
+	}
+	pemKey, err := os.ReadFile(keyFile)
+	if err != nil {
+		return ed25519Key{}, err
+	}
+	signer, err := common.NewEd25519Signer(pemKey)
+	if err != nil {
+		return ed25519Key{}, err
+	}
+	return ed25519Key{APIKey: apiKey, Signer: signer}, nil
+}
+
+// signing measures latency of placing every test with the HMAC key then the Ed25519 key, or the
+// other way around for every other test, so the difference of their latencies is the cost of
+// signing and verifying the signature. Every key type adds a row and mean differences are
+// logged.
+func (b *benchmark) signing(ctx context.Context, tests []placeOrderParam) ([][]string, error) {
+	if b.ed25519Rest == nil {
+		restClient, wsClient, err := b.endpoint.newClients(b.ed25519.APIKey, "")
+		if err != nil {
+			b.l.Errorw("Cannot init ed25519 clients", "err", err)
+			return nil, err
+		}
+		restClient.SetSigner(b.ed25519.Signer)
+		wsClient.SetSigner(b.ed25519.Signer)
+		b.ed25519Rest, b.ed25519Ws = restClient, wsClient
+	}
+	ed := *b
+	ed.restClient, ed.wsClient = b.ed25519Rest, b.ed25519Ws
+
+	var (
+		benchmarks = map[string]*benchmark{keyTypeHMAC: b, keyTypeEd25519: &ed}
+		wsServices = map[string]*futures.OrderPlaceWsService{
+			keyTypeHMAC:    b.wsClient.NewOrderPlaceWsService(),
+			keyTypeEd25519: b.ed25519Ws.NewOrderPlaceWsService(),
+		}
+		wsLatencies   = map[string][]float64{}
+		restLatencies = map[string][]float64{}
+		data          = [][]string{}
+	)
+	for i, test := range tests {
+		if ctx.Err() != nil {
+			break
+		}
+		keyTypes := []string{keyTypeHMAC, keyTypeEd25519}
+		if i%2 == 1 {
+			keyTypes[0], keyTypes[1] = keyTypes[1], keyTypes[0]
+		}
+		for _, keyType := range keyTypes {
+			kb := benchmarks[keyType]
+			res := kb.placeBoth(ctx, wsServices[keyType], test, futures.NewOrderRespTypeRESULT)
+			wsLatency := kb.resultLatency(res.SentTime, res.WsUpdateTime, res.WsErr)
+			restLatency := kb.resultLatency(res.SentTime, res.RestUpdateTime, res.RestErr)
+			if res.WsErr == nil {
+				wsLatencies[keyType] = append(wsLatencies[keyType], StringToFloat(wsLatency))
+			}
+			if res.RestErr == nil {
+				restLatencies[keyType] = append(restLatencies[keyType], StringToFloat(restLatency))
+			}
+
+			// "key_type", "symbol", "qty", "price", "side", "tif",
+			// "ws_latency", "rest_latency", "ws_rtt", "rest_rtt", "ws_error", "rest_error"
+			data = append(data, []string{
+				keyType, test.Symbol, FloatToString(test.Qty), FloatToString(test.Price), string(b.cfg.Side), string(b.cfg.TimeInForce),
+				wsLatency,
+				restLatency,
+				roundTrip(res.SentTime, res.WsDoneTime, res.WsErr),
+				roundTrip(res.SentTime, res.RestDoneTime, res.RestErr),
+				errorString(res.WsErr),
+				errorString(res.RestErr),
+			})
+
+			b.pause(ctx)
+		}
+	}
+
+	b.l.Infow("Signing latency difference, ed25519 minus hmac",
+		"ws_mean", Mean(wsLatencies[keyTypeEd25519])-Mean(wsLatencies[keyTypeHMAC]),
+		"rest_mean", Mean(restLatencies[keyTypeEd25519])-Mean(restLatencies[keyTypeHMAC]))
+	return data, nil
+}
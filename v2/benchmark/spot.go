@@ -0,0 +1,268 @@
+package benchmark
+
+import (
+	"context"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/adshao/go-binance/v2"
+	"github.com/adshao/go-binance/v2/futures"
+	"go.uber.org/zap"
+)
+
+const (
+	marketFuture = "future"
+	marketSpot   = "spot"
+)
+
+// compareHeader define the columns of compare results
+var compareHeader = append([]string{"market"}, orderHeader...)
+
+func getSpotExInfo(ctx context.Context, client *binance.Client, cfg TestConfig, l *zap.SugaredLogger) (map[string]exchangeInfo, error) {
+	exInfo, err := client.NewExchangeInfoService().Do(ctx)
+	if err != nil {
+		l.Errorw("Failed to get spot exchange info", "err", err)
+		return nil, err
+	}
+
+	mappedExInfo := make(map[string]exchangeInfo)
+	for _, s := range exInfo.Symbols {
+		if !cfg.allowed(s.Symbol, s.QuoteAsset) || s.Status != "TRADING" {
+			continue
+		}
+		priceFilter, lotSizeFilter := s.PriceFilter(), s.LotSizeFilter()
+		if priceFilter == nil || lotSizeFilter == nil {
+			continue
+		}
+		_, pricePrecision, err := GetPrecision(priceFilter.TickSize)
+		if err != nil {
+			l.Errorw("Failed to get pricePrecision", "symbol", s.Symbol, "err", err)
+			return nil, err
+		}
+		_, qtyPrecision, err := GetPrecision(lotSizeFilter.StepSize)
+		if err != nil {
+			l.Errorw("Failed to get qtyPrecision", "symbol", s.Symbol, "err", err)
+			return nil, err
+		}
+		var minNotional string
+		if f := s.NotionalFilter(); f != nil {
+			minNotional = f.MinNotional
+		} else if f := s.MinNotionalFilter(); f != nil {
+			minNotional = f.MinNotional
+		}
+		notional, err := strconv.ParseFloat(minNotional, 64)
+		if err != nil {
+			l.Errorw("Failed to get minNotional", "symbol", s.Symbol, "err", err)
+			return nil, err
+		}
+		mappedExInfo[s.Symbol] = exchangeInfo{
+			PricePrecision: pricePrecision,
+			QtyPrecision:   qtyPrecision,
+			MinNotional:    notional,
+		}
+	}
+	return mappedExInfo, nil
+}
+
+// setupSpotOrderTest returns spot tests for the symbols of futureTests listed on spot, priced
+// like setupFutureOrderTest
+func setupSpotOrderTest(
+	mappedExInfo map[string]exchangeInfo,
+	tickers []*binance.PriceChangeStats,
+	futureTests []placeOrderParam,
+	cfg TestConfig,
+) []placeOrderParam {
+	lastPrices := make(map[string]float64, len(tickers))
+	for _, ticker := range tickers {
+		lastPrices[ticker.Symbol] = StringToFloat(ticker.LastPrice)
+	}
+
+	res := make([]placeOrderParam, 0, len(futureTests))
+	for _, test := range futureTests {
+		exInfo, ok := mappedExInfo[test.Symbol]
+		if !ok {
+			continue
+		}
+		price := cfg.price(lastPrices[test.Symbol], cfg.PriceOffset, exInfo.PricePrecision)
+		if price == 0 {
+			continue
+		}
+		qty := RoundDown(3*exInfo.MinNotional/price, exInfo.QtyPrecision)
+		if qty == 0 {
+			continue
+		}
+		res = append(res, placeOrderParam{
+			Symbol: test.Symbol,
+			Price:  price,
+			Qty:    qty,
+		})
+	}
+	return res
+}
+
+func getSpotServerTimeDiff(ctx context.Context, client *binance.Client) (float64, error) {
+	diffs := make([]float64, 0)
+	for i := 0; i < 3; i++ {
+		startTime := time.Now().UnixMilli()
+		serverTime, err := client.NewServerTimeService().Do(ctx)
+		finishTime := time.Now().UnixMilli()
+		if err != nil {
+			return 0, err
+		}
+		diffs = append(diffs, float64(serverTime-(startTime+finishTime)/2))
+	}
+
+	return Mean(diffs), nil
+}
+
+// setupSpot inits the spot clients unless already done, e.g. by warmup, and returns the spot
+// tests matching futureTests
+func (b *benchmark) setupSpot(ctx context.Context, apiKey, secretKey string, futureTests []placeOrderParam) ([]placeOrderParam, error) {
+	if b.spotRestClient == nil {
+		wsClient, err := binance.NewClientWs(apiKey, secretKey)
+		if err != nil {
+			b.l.Errorw("Cannot init spot wsClient", "err", err)
+			return nil, err
+		}
+		b.spotRestClient, b.spotWsClient = binance.NewClient(apiKey, secretKey), wsClient
+	}
+
+	mappedExInfo, err := getSpotExInfo(ctx, b.spotRestClient, b.cfg, b.l)
+	if err != nil {
+		return nil, err
+	}
+	tickers, err := b.spotRestClient.NewListPriceChangeStatsService().Do(ctx)
+	if err != nil {
+		b.l.Errorw("Failed to get binance spot ticker", "err", err)
+		return nil, err
+	}
+	if b.spotServerTimeDiff, err = getSpotServerTimeDiff(ctx, b.spotRestClient); err != nil {
+		b.l.Errorw("Cannot getSpotServerTimeDiff", "err", err)
+		return nil, err
+	}
+	return setupSpotOrderTest(mappedExInfo, tickers, futureTests, b.cfg), nil
+}
+
+// spotResultLatency returns the one-way latency of a spot request sent at sentTime (local,
+// milliseconds) and handled by the server at transactTime, empty if it failed with err
+func (b *benchmark) spotResultLatency(sentTime, transactTime int64, err error) string {
+	if err != nil {
+		return ""
+	}
+	return IntToString(transactTime - sentTime - int64(b.spotServerTimeDiff))
+}
+
+// placeSpot measures latency of placing spot orders through WS and REST at once. GTX orders are
+// placed as LIMIT_MAKER, the spot equivalent, and orders left resting are canceled after every test.
+func (b *benchmark) placeSpot(ctx context.Context, tests []placeOrderParam) [][]string {
+	wsService := b.spotWsClient.NewOrderPlaceWsService()
+	side := binance.SideType(b.cfg.Side)
+	data := [][]string{}
+	for _, test := range tests {
+		if ctx.Err() != nil {
+			break
+		}
+		testCtx, cancel := b.testContext(ctx)
+		var (
+			now                              = time.Now().UnixMilli()
+			wg                               sync.WaitGroup
+			wsTransactTime, restTransactTime int64
+			wsErr, restErr                   error
+		)
+		wg.Add(2)
+
+		// place WS order
+		go func() {
+			defer wg.Done()
+			req := binance.NewOrderPlaceWsRequest().
+				Symbol(test.Symbol).
+				Side(side).
+				Price(FloatToString(test.Price)).
+				Quantity(FloatToString(test.Qty)).
+				NewOrderRespType(binance.NewOrderRespTypeRESULT)
+			if b.cfg.TimeInForce == futures.TimeInForceTypeGTX {
+				req.Type(binance.OrderTypeLimitMaker)
+			} else {
+				req.Type(binance.OrderTypeLimit).TimeInForce(binance.TimeInForceType(b.cfg.TimeInForce))
+			}
+			order, err := wsService.Do(testCtx, req)
+			if err != nil {
+				b.l.Errorw("Failed to place spot ws order", "err", err)
+				wsErr = err
+				return
+			}
+			wsTransactTime = order.TransactTime
+		}()
+
+		// place rest API order
+		go func() {
+			defer wg.Done()
+			s := b.spotRestClient.NewCreateOrderService().
+				Symbol(test.Symbol).
+				Side(side).
+				Price(FloatToString(test.Price)).
+				Quantity(FloatToString(test.Qty)).
+				NewOrderRespType(binance.NewOrderRespTypeRESULT)
+			if b.cfg.TimeInForce == futures.TimeInForceTypeGTX {
+				s.Type(binance.OrderTypeLimitMaker)
+			} else {
+				s.Type(binance.OrderTypeLimit).TimeInForce(binance.TimeInForceType(b.cfg.TimeInForce))
+			}
+			order, err := s.Do(testCtx)
+			if err != nil {
+				b.l.Errorw("Failed to place spot rest order", "err", err)
+				restErr = err
+				return
+			}
+			restTransactTime = order.TransactTime
+		}()
+		wg.Wait()
+		cancel()
+		if b.cfg.TimeInForce != futures.TimeInForceTypeIOC {
+			b.cleanupSpot(test.Symbol)
+		}
+
+		// "symbol", "qty", "price", "side", "tif", "ws_latency", "rest_latency", "ws_error", "rest_error"
+		data = append(data, []string{
+			test.Symbol, FloatToString(test.Qty), FloatToString(test.Price), string(b.cfg.Side), string(b.cfg.TimeInForce),
+			b.spotResultLatency(now, wsTransactTime, wsErr),
+			b.spotResultLatency(now, restTransactTime, restErr),
+			errorString(wsErr),
+			errorString(restErr),
+		})
+
+		b.pause(ctx)
+	}
+	return data
+}
+
+// cleanupSpot cancels the spot orders of symbol left resting by a test
+func (b *benchmark) cleanupSpot(symbol string) {
+	if _, err := b.spotRestClient.NewCancelOpenOrdersService().Symbol(symbol).Do(context.Background()); err != nil {
+		b.l.Errorw("Failed to cancel spot open orders", "symbol", symbol, "err", err)
+	}
+}
+
+// compare runs the place experiment on futures then spot and returns the rows of both,
+// prefixed by their market. Mean latencies of every market and transport are logged.
+func (b *benchmark) compare(ctx context.Context, futureTests, spotTests []placeOrderParam) [][]string {
+	data := [][]string{}
+	for _, market := range []string{marketFuture, marketSpot} {
+		var rows [][]string
+		if market == marketFuture {
+			rows = b.place(ctx, futureTests)
+		} else {
+			rows = b.placeSpot(ctx, spotTests)
+		}
+
+		for _, row := range rows {
+			data = append(data, append([]string{market}, row...))
+		}
+		b.l.Infow("Market latency",
+			"market", market, "orders", len(rows),
+			"ws_mean", Mean(columnValues(rows, columnIndex(orderHeader, "ws_latency"))),
+			"rest_mean", Mean(columnValues(rows, columnIndex(orderHeader, "rest_latency"))))
+	}
+	return data
+}
@@ -0,0 +1,91 @@
+package benchmark
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/adshao/go-binance/v2/futures"
+)
+
+// orderUpdateTimeout is how long to wait for the user stream to report a placed order
+const orderUpdateTimeout = 5 * time.Second
+
+// streamHeader define the columns appended to place results when the user stream is watched
+var streamHeader = []string{"ws_stream_latency", "rest_stream_latency"}
+
+// serveUserData starts a listen key and serves its user data stream to handler until stop is
+// called, which closes the listen key
+func (b *benchmark) serveUserData(ctx context.Context, handler futures.WsUserDataHandler) (stop func(), err error) {
+	listenKey, err := b.restClient.NewStartUserStreamService().Do(ctx)
+	if err != nil {
+		return nil, err
+	}
+	closeListenKey := func() {
+		if err := b.restClient.NewCloseUserStreamService().ListenKey(listenKey).Do(context.Background()); err != nil {
+			b.l.Errorw("Failed to close listen key", "err", err)
+		}
+	}
+	doneC, stopC, err := futures.WsUserDataServeWithSocket(listenKey, b.endpoint.socket(), handler, func(err error) {
+		b.l.Errorw("User data stream error", "err", err)
+	})
+	if err != nil {
+		closeListenKey()
+		return nil, err
+	}
+	return func() {
+		close(stopC)
+		<-doneC
+		closeListenKey()
+	}, nil
+}
+
+// orderUpdateWatcher reports when the user stream shows the first update of orders, which is
+// what strategies react to rather than the response of the request
+type orderUpdateWatcher struct {
+	mu      sync.Mutex
+	waiters map[string]chan int64
+}
+
+func newOrderUpdateWatcher() *orderUpdateWatcher {
+	return &orderUpdateWatcher{waiters: make(map[string]chan int64)}
+}
+
+// watch returns a channel receiving the local time in milliseconds when the first update of
+// order clientOrderID is reported
+func (w *orderUpdateWatcher) watch(clientOrderID string) <-chan int64 {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	c := make(chan int64, 1)
+	w.waiters[clientOrderID] = c
+	return c
+}
+
+func (w *orderUpdateWatcher) unwatch(clientOrderID string) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	delete(w.waiters, clientOrderID)
+}
+
+func (w *orderUpdateWatcher) handleUserDataEvent(event *futures.WsUserDataEvent) {
+	now := time.Now().UnixMilli()
+	if event.Event != futures.UserDataEventTypeOrderTradeUpdate {
+		return
+	}
+
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	c, ok := w.waiters[event.OrderTradeUpdate.ClientOrderID]
+	if !ok {
+		return
+	}
+	delete(w.waiters, event.OrderTradeUpdate.ClientOrderID)
+	c <- now
+}
+
+// newClientOrderID returns a unique client order ID of an order placed through transport
+func newClientOrderID(transport string) string {
+	return fmt.Sprintf("bench_%s_%d", transport, time.Now().UnixNano())
+}
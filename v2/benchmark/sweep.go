@@ -0,0 +1,124 @@
+package benchmark
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/adshao/go-binance/v2/futures"
+)
+
+// sweepHeader define the columns of sweep results
+var sweepHeader = []string{"connections", "concurrency", "symbol", "qty", "price", "side", "tif", "ws_latency", "ws_rtt", "ws_error"}
+
+// sweep measures latency of placing tests through the websocket API over 1 to maxConnections
+// connections, with every in-flight concurrency of concurrencies. Connections are used in turn
+// and connected once, each step using the first ones. Tail latency of every step is logged.
+func (b *benchmark) sweep(ctx context.Context, tests []placeOrderParam, apiKey, secretKey string) ([][]string, error) {
+	if len(b.sweepClients) < b.maxConnections {
+		pool, err := futures.NewWsPool(apiKey, secretKey, b.maxConnections, futures.WsPoolRoundRobin, b.endpoint.wsOptions()...)
+		if err != nil {
+			b.l.Errorw("Cannot init ws connections", "connections", b.maxConnections, "err", err)
+			return nil, err
+		}
+		b.sweepClients = pool.Clients()
+	}
+	services := make([]*futures.OrderPlaceWsService, 0, b.maxConnections)
+	for _, client := range b.sweepClients[:b.maxConnections] {
+		services = append(services, client.NewOrderPlaceWsService())
+	}
+
+	data := [][]string{}
+	for connections := 1; connections <= b.maxConnections; connections++ {
+		for _, concurrency := range b.concurrencies {
+			if ctx.Err() != nil {
+				return data, nil
+			}
+			rows, latencies := b.sweepStep(ctx, services[:connections], tests, concurrency)
+			sort.Float64s(latencies)
+			b.l.Infow("Sweep step",
+				"connections", connections, "concurrency", concurrency, "orders", len(latencies),
+				"p50", Percentile(latencies, 50), "p90", Percentile(latencies, 90), "p99", Percentile(latencies, 99))
+			data = append(data, rows...)
+		}
+	}
+	return data, nil
+}
+
+// sweepStep places tests with concurrency orders in flight over services in turn, and returns
+// the result rows with their WS latencies. Orders left resting are canceled once all are placed.
+func (b *benchmark) sweepStep(ctx context.Context, services []*futures.OrderPlaceWsService, tests []placeOrderParam, concurrency int) ([][]string, []float64) {
+	var (
+		mu        sync.Mutex
+		wg        sync.WaitGroup
+		next      atomic.Uint64
+		testC     = make(chan placeOrderParam)
+		data      = [][]string{}
+		latencies []float64
+	)
+	for i := 0; i < concurrency; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for test := range testC {
+				wsService := services[int(next.Add(1)-1)%len(services)]
+				req := futures.NewOrderPlaceWsRequest().
+					Symbol(test.Symbol).
+					Side(b.cfg.Side).
+					Type(futures.OrderTypeLimit).
+					Price(FloatToString(test.Price)).
+					Quantity(FloatToString(test.Qty)).
+					TimeInForce(b.cfg.TimeInForce).
+					NewOrderResponseType(futures.NewOrderRespTypeRESULT)
+				testCtx, cancel := b.testContext(ctx)
+				sentTime := time.Now().UnixMilli()
+				order, err := wsService.Do(testCtx, req)
+				cancel()
+				var updateTime, doneTime int64
+				if err != nil {
+					b.l.Errorw("Failed to place ws order", "err", err)
+				} else {
+					updateTime, doneTime = order.UpdateTime, time.Now().UnixMilli()
+				}
+				latency := b.resultLatency(sentTime, updateTime, err)
+
+				mu.Lock()
+				// "connections", "concurrency", "symbol", "qty", "price", "side", "tif", "ws_latency", "ws_rtt", "ws_error"
+				data = append(data, []string{
+					IntToString(int64(len(services))), IntToString(int64(concurrency)),
+					test.Symbol, FloatToString(test.Qty), FloatToString(test.Price), string(b.cfg.Side), string(b.cfg.TimeInForce),
+					latency,
+					roundTrip(sentTime, doneTime, err),
+					errorString(err),
+				})
+				if err == nil {
+					latencies = append(latencies, StringToFloat(latency))
+				}
+				mu.Unlock()
+			}
+		}()
+	}
+send:
+	for _, test := range tests {
+		select {
+		case testC <- test:
+		case <-ctx.Done():
+			break send
+		}
+	}
+	close(testC)
+	wg.Wait()
+
+	if b.cfg.TimeInForce != futures.TimeInForceTypeIOC {
+		cleaned := make(map[string]bool)
+		for _, test := range tests {
+			if !cleaned[test.Symbol] {
+				cleaned[test.Symbol] = true
+				b.cleanup(test.Symbol)
+			}
+		}
+	}
+	return data, latencies
+}
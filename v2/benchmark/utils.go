@@ -1,33 +1,66 @@
-package main
+package benchmark
 
 import (
 	"context"
 	"encoding/csv"
-	"fmt"
 	"os"
 	"strconv"
 	"time"
 
 	"go.uber.org/zap"
-	"go.uber.org/zap/zapcore"
 
 	"github.com/adshao/go-binance/v2/futures"
 )
 
-func setupLogger() *zap.SugaredLogger {
-	pConf := zap.NewProductionEncoderConfig()
-	pConf.EncodeTime = zapcore.ISO8601TimeEncoder
-	encoder := zapcore.NewConsoleEncoder(pConf)
-	level := zap.NewAtomicLevelAt(zap.DebugLevel)
-	l := zap.New(zapcore.NewCore(encoder, zapcore.AddSync(os.Stdout), level), zap.AddCaller())
-	zap.ReplaceGlobals(l)
-	return zap.S()
+type placeOrderParam struct {
+	Symbol string  `json:"symbol"`
+	Price  float64 `json:"price"`
+	Qty    float64 `json:"qty"`
+	// NewPrice is the price orders are modified to
+	NewPrice float64 `json:"newPrice,omitempty"`
 }
 
-type placeOrderParam struct {
-	Symbol string
-	Price  float64
-	Qty    float64
+// TestConfig define the orders placed by a run
+type TestConfig struct {
+	Count int `json:"count"`
+	// Symbols restricts tests to the listed symbols, otherwise to symbols quoted in QuoteAsset
+	Symbols     []string                `json:"symbols,omitempty"`
+	QuoteAsset  string                  `json:"quoteAsset"`
+	Side        futures.SideType        `json:"side"`
+	TimeInForce futures.TimeInForceType `json:"tif"`
+	// PriceOffset is the distance of order prices from the last price in percent, below it for
+	// BUY orders and above it for SELL orders
+	PriceOffset float64 `json:"priceOffset"`
+}
+
+// allowed reports whether symbol quoted in quoteAsset may be tested
+func (cfg TestConfig) allowed(symbol, quoteAsset string) bool {
+	if len(cfg.Symbols) == 0 {
+		return quoteAsset == cfg.QuoteAsset
+	}
+	for _, s := range cfg.Symbols {
+		if s == symbol {
+			return true
+		}
+	}
+	return false
+}
+
+// price returns the price offset percent away from lastPrice
+func (cfg TestConfig) price(lastPrice, offset float64, precision int) float64 {
+	if cfg.Side == futures.SideTypeSell {
+		return RoundDown((1+offset/100)*lastPrice, precision)
+	}
+	return RoundDown((1-offset/100)*lastPrice, precision)
+}
+
+// restingTimeInForce returns the time in force of orders which must rest in the book, GTX
+// unless TimeInForce is already not immediate
+func (cfg TestConfig) restingTimeInForce() futures.TimeInForceType {
+	if cfg.TimeInForce == futures.TimeInForceTypeIOC {
+		return futures.TimeInForceTypeGTX
+	}
+	return cfg.TimeInForce
 }
 
 type exchangeInfo struct {
@@ -37,9 +70,9 @@ type exchangeInfo struct {
 }
 
 func getFutureExInfo(
-	client *futures.Client, l *zap.SugaredLogger,
+	ctx context.Context, client *futures.Client, cfg TestConfig, l *zap.SugaredLogger,
 ) (map[string]exchangeInfo, error) {
-	exInfo, err := client.NewExchangeInfoService().Do(context.Background())
+	exInfo, err := client.NewExchangeInfoService().Do(ctx)
 	if err != nil {
 		l.Errorw("Failed to get future exchange info", "err", err)
 		return nil, err
@@ -51,7 +84,7 @@ func getFutureExInfo(
 		minNotional                  float64
 	)
 	for _, s := range exInfo.Symbols {
-		if s.QuoteAsset != "USDT" || s.Status != "TRADING" {
+		if !cfg.allowed(s.Symbol, s.QuoteAsset) || s.Status != "TRADING" {
 			continue
 		}
 		for _, f := range s.Filters {
@@ -88,17 +121,18 @@ func getFutureExInfo(
 func setupFutureOrderTest(
 	mappedExInfo map[string]exchangeInfo,
 	tickers []*futures.PriceChangeStats,
-	testSize int,
+	cfg TestConfig,
 ) []placeOrderParam {
-	res := make([]placeOrderParam, 0, testSize)
+	res := make([]placeOrderParam, 0, cfg.Count)
 	count := 0
 	for _, ticker := range tickers {
-		if count >= testSize {
+		if count >= cfg.Count {
 			break
 		}
-		// place BUY order with price = 0.9 * lastPrice, qty = 3 * minNotional
+		// place order PriceOffset away from lastPrice with qty = 3 * minNotional, modified to 1% further
 		if exInfo, ok := mappedExInfo[ticker.Symbol]; ok {
-			price := RoundDown(0.9*StringToFloat(ticker.LastPrice), exInfo.PricePrecision)
+			lastPrice := StringToFloat(ticker.LastPrice)
+			price := cfg.price(lastPrice, cfg.PriceOffset, exInfo.PricePrecision)
 			if price == 0 {
 				continue
 			}
@@ -107,9 +141,10 @@ func setupFutureOrderTest(
 				continue
 			}
 			res = append(res, placeOrderParam{
-				Symbol: ticker.Symbol,
-				Price:  price,
-				Qty:    qty,
+				Symbol:   ticker.Symbol,
+				Price:    price,
+				Qty:      qty,
+				NewPrice: cfg.price(lastPrice, cfg.PriceOffset+1, exInfo.PricePrecision),
 			})
 			count += 1
 		}
@@ -119,7 +154,7 @@ func setupFutureOrderTest(
 
 func WriteCSV(path string, header []string, data [][]string) error {
 	// Create a new CSV file
-	file, err := os.Create(fmt.Sprintf("%s/benchmark_%d.csv", path, time.Now().Unix()))
+	file, err := os.Create(outputFile(path, OutputFormatCSV))
 	if err != nil {
 		return err
 	}
@@ -142,11 +177,11 @@ func WriteCSV(path string, header []string, data [][]string) error {
 	return nil
 }
 
-func getFutureServerTimeDiff(client *futures.Client) (float64, error) {
+func getFutureServerTimeDiff(ctx context.Context, client *futures.Client) (float64, error) {
 	diffs := make([]float64, 0)
 	for i := 0; i < 3; i++ {
 		startTime := time.Now().UnixMilli()
-		serverTime, err := client.NewServerTimeService().Do(context.Background())
+		serverTime, err := client.NewServerTimeService().Do(ctx)
 		finishTime := time.Now().UnixMilli()
 		if err != nil {
 			return 0, err
@@ -0,0 +1,230 @@
+package benchmark
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+
+	"github.com/adshao/go-binance/v2/futures"
+	"go.uber.org/zap"
+)
+
+const (
+	// warmupPings is the number of requests sent through every connection before warmup orders
+	warmupPings = 3
+
+	// OutliersNone, OutliersTrim and OutliersWinsorize are the outlier handlings of handleOutliers
+	OutliersNone      = "none"
+	OutliersTrim      = "trim"
+	OutliersWinsorize = "winsorize"
+)
+
+// warmup establishes the connections of b, then runs n tests of mode and discards their
+// results, so connection setup, TLS handshakes and cold caches don't skew the measured tests
+func (b *benchmark) warmup(ctx context.Context, mode string, tests []placeOrderParam, n int, apiKey, secretKey string) error {
+	if n <= 0 || len(tests) == 0 {
+		return nil
+	}
+	b.l.Infow("Warming up", "orders", n)
+	for i := 0; i < warmupPings; i++ {
+		if err := b.restClient.NewPingService().Do(ctx); err != nil {
+			b.l.Warnw("Failed to warm rest connection", "err", err)
+		}
+		_, err := b.wsClient.NewTickerPriceWsService().Do(ctx, futures.NewTickerPriceWsRequest().Symbol(tests[0].Symbol))
+		if err != nil {
+			b.l.Warnw("Failed to warm ws connection", "err", err)
+		}
+	}
+
+	warmupTests := make([]placeOrderParam, 0, n)
+	for i := 0; i < n; i++ {
+		warmupTests = append(warmupTests, tests[i%len(tests)])
+	}
+	_, _, err := b.run(ctx, mode, warmupTests, apiKey, secretKey)
+	return err
+}
+
+// isLatencyColumn reports whether column of the results holds a latency
+func isLatencyColumn(column string) bool {
+	return strings.HasSuffix(column, "_latency") || strings.HasSuffix(column, "_rtt") || strings.HasSuffix(column, "_visible")
+}
+
+// groupColumns are the columns splitting results into groups compared separately
+var groupColumns = []string{"market", "resp_type", "key_type", "connections", "concurrency"}
+
+// groupRows splits data by the values of its groupColumns and returns the groups in order of
+// appearance with their keys
+func groupRows(header []string, data [][]string) ([]string, map[string][][]string) {
+	var columns []int
+	for i, column := range header {
+		for _, c := range groupColumns {
+			if column == c {
+				columns = append(columns, i)
+			}
+		}
+	}
+	var (
+		keys   []string
+		groups = make(map[string][][]string)
+	)
+	for _, row := range data {
+		values := make([]string, 0, len(columns))
+		for _, i := range columns {
+			values = append(values, row[i])
+		}
+		key := strings.Join(values, "/")
+		if _, ok := groups[key]; !ok {
+			keys = append(keys, key)
+		}
+		groups[key] = append(groups[key], row)
+	}
+	return keys, groups
+}
+
+// handleOutliers trims or winsorizes data at the percent lowest and highest latencies of every
+// latency column, computed per group of groupColumns if the results hold several. Trimming drops
+// rows with any latency out of bounds, winsorizing clamps the latencies to the bounds instead.
+// Empty cells are ignored.
+func handleOutliers(header []string, data [][]string, method string, percent float64) ([][]string, error) {
+	switch method {
+	case OutliersNone:
+		return data, nil
+	case OutliersTrim, OutliersWinsorize:
+	default:
+		return nil, fmt.Errorf("unknown outlier handling %q", method)
+	}
+	if percent <= 0 {
+		return data, nil
+	}
+
+	keys, groups := groupRows(header, data)
+	res := make([][]string, 0, len(data))
+	for _, key := range keys {
+		res = append(res, handleGroupOutliers(header, groups[key], method, percent)...)
+	}
+	return res, nil
+}
+
+// handleGroupOutliers handles outliers of rows of the same group
+func handleGroupOutliers(header []string, data [][]string, method string, percent float64) [][]string {
+	type bounds struct{ low, high float64 }
+	columnBounds := make(map[int]bounds)
+	for i, column := range header {
+		if !isLatencyColumn(column) {
+			continue
+		}
+		values := columnValues(data, i)
+		sort.Float64s(values)
+		columnBounds[i] = bounds{low: Percentile(values, percent), high: Percentile(values, 100-percent)}
+	}
+
+	res := make([][]string, 0, len(data))
+rows:
+	for _, row := range data {
+		row = append([]string(nil), row...)
+		for i, b := range columnBounds {
+			if row[i] == "" {
+				continue
+			}
+			v := StringToFloat(row[i])
+			if v >= b.low && v <= b.high {
+				continue
+			}
+			if method == OutliersTrim {
+				continue rows
+			}
+			row[i] = IntToString(int64(math.Round(math.Max(b.low, math.Min(v, b.high)))))
+		}
+		res = append(res, row)
+	}
+	return res
+}
+
+// columnIndex returns the index of column in header, -1 if missing
+func columnIndex(header []string, column string) int {
+	for i, c := range header {
+		if c == column {
+			return i
+		}
+	}
+	return -1
+}
+
+// columnValues returns the non empty values of column i of data
+func columnValues(data [][]string, i int) []float64 {
+	values := make([]float64, 0, len(data))
+	for _, row := range data {
+		if row[i] != "" {
+			values = append(values, StringToFloat(row[i]))
+		}
+	}
+	return values
+}
+
+// ColumnSummary define the statistics of a column of results, latencies are in milliseconds
+// and Count is the number of errors for error columns
+type ColumnSummary struct {
+	Endpoint string  `json:"endpoint,omitempty"`
+	Group    string  `json:"group,omitempty"`
+	Column   string  `json:"column"`
+	Count    int     `json:"count"`
+	Mean     float64 `json:"mean,omitempty"`
+	P50      float64 `json:"p50,omitempty"`
+	P99      float64 `json:"p99,omitempty"`
+}
+
+// name returns the column prefixed with its endpoint and group if set
+func (s ColumnSummary) name() string {
+	var parts []string
+	for _, part := range []string{s.Endpoint, s.Group, s.Column} {
+		if part != "" {
+			parts = append(parts, part)
+		}
+	}
+	return strings.Join(parts, " ")
+}
+
+// summarize logs and returns mean, median and 99th percentile of every latency column of data
+// and the number of errors of every error column, per group of groupColumns if the results hold
+// several
+func summarize(l *zap.SugaredLogger, header []string, data [][]string) []ColumnSummary {
+	var res []ColumnSummary
+	keys, groups := groupRows(header, data)
+	for _, key := range keys {
+		gl := l
+		if key != "" {
+			gl = l.With("group", key)
+		}
+		for i, column := range header {
+			if strings.HasSuffix(column, "_error") {
+				errors := 0
+				for _, row := range groups[key] {
+					if row[i] != "" {
+						errors++
+					}
+				}
+				gl.Infow("Error summary", "column", column, "count", errors)
+				res = append(res, ColumnSummary{Group: key, Column: column, Count: errors})
+				continue
+			}
+			if !isLatencyColumn(column) {
+				continue
+			}
+			values := columnValues(groups[key], i)
+			sort.Float64s(values)
+			s := ColumnSummary{
+				Group:  key,
+				Column: column,
+				Count:  len(values),
+				Mean:   Mean(values),
+				P50:    Percentile(values, 50),
+				P99:    Percentile(values, 99),
+			}
+			gl.Infow("Latency summary", "column", column, "count", s.Count, "mean", s.Mean, "p50", s.P50, "p99", s.P99)
+			res = append(res, s)
+		}
+	}
+	return res
+}
@@ -0,0 +1,74 @@
+package benchmark
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// latencyRows returns rows of header "symbol", "ws_latency", "ws_error" of latencies 1 to n
+func latencyRows(group string, n int) [][]string {
+	rows := make([][]string, 0, n)
+	for i := 1; i <= n; i++ {
+		rows = append(rows, []string{group, IntToString(int64(i)), ""})
+	}
+	return rows
+}
+
+func TestHandleOutliers(t *testing.T) {
+	header := []string{"symbol", "ws_latency", "ws_error"}
+	data := latencyRows("BTCUSDT", 10)
+	// failed requests have no latency and are kept
+	data = append(data, []string{"BTCUSDT", "", "timeout"})
+
+	res, err := handleOutliers(header, data, OutliersNone, 10)
+	require.NoError(t, err)
+	assert.Equal(t, data, res)
+
+	res, err = handleOutliers(header, data, OutliersTrim, 10)
+	require.NoError(t, err)
+	assert.Equal(t, append(latencyRows("BTCUSDT", 10)[1:9], data[10]), res)
+
+	res, err = handleOutliers(header, data, OutliersWinsorize, 10)
+	require.NoError(t, err)
+	require.Len(t, res, len(data))
+	assert.Equal(t, "2", res[0][1])
+	assert.Equal(t, "5", res[4][1])
+	assert.Equal(t, "9", res[9][1])
+	assert.Equal(t, "", res[10][1])
+	// data is not modified
+	assert.Equal(t, "1", data[0][1])
+
+	_, err = handleOutliers(header, data, "clip", 10)
+	assert.Error(t, err)
+}
+
+func TestHandleOutliersPerGroup(t *testing.T) {
+	header := []string{"connections", "ws_latency", "ws_error"}
+	var data [][]string
+	for i := 1; i <= 10; i++ {
+		data = append(data, []string{"1", IntToString(int64(i)), ""}, []string{"2", IntToString(int64(100 * i)), ""})
+	}
+
+	res, err := handleOutliers(header, data, OutliersTrim, 10)
+	require.NoError(t, err)
+	// bounds of every group are computed from its own latencies
+	keys, groups := groupRows(header, res)
+	assert.Equal(t, []string{"1", "2"}, keys)
+	assert.Equal(t, []float64{2, 3, 4, 5, 6, 7, 8, 9}, columnValues(groups["1"], 1))
+	assert.Equal(t, []float64{200, 300, 400, 500, 600, 700, 800, 900}, columnValues(groups["2"], 1))
+}
+
+func TestIsLatencyColumn(t *testing.T) {
+	for column, latency := range map[string]bool{
+		"ws_latency":  true,
+		"rest_rtt":    true,
+		"ws_visible":  true,
+		"ws_error":    false,
+		"symbol":      false,
+		"concurrency": false,
+	} {
+		assert.Equal(t, latency, isLatencyColumn(column), column)
+	}
+}
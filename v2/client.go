@@ -12,6 +12,7 @@ import (
 	"net/http"
 	"net/url"
 	"os"
+	"sync"
 	"time"
 
 	"github.com/bitly/go-simplejson"
@@ -20,6 +21,7 @@ import (
 	"github.com/adshao/go-binance/v2/common"
 	"github.com/adshao/go-binance/v2/delivery"
 	"github.com/adshao/go-binance/v2/futures"
+	"github.com/adshao/go-binance/v2/options"
 )
 
 // SideType define side type of order
@@ -309,6 +311,11 @@ func NewDeliveryClient(apiKey, secretKey string) *delivery.Client {
 	return delivery.NewClient(apiKey, secretKey)
 }
 
+// NewOptionsClient initialize client for european options API
+func NewOptionsClient(apiKey, secretKey string) *options.Client {
+	return options.NewClient(apiKey, secretKey)
+}
+
 type doFunc func(req *http.Request) (*http.Response, error)
 
 // Client define API client
@@ -321,7 +328,10 @@ type Client struct {
 	Debug      bool
 	Logger     *log.Logger
 	TimeOffset int64
-	do         doFunc
+	// Interceptors wrap sending of every request, the first one being the outermost
+	Interceptors []common.Interceptor
+	do           doFunc
+	credMu       sync.RWMutex
 }
 
 func (c *Client) debug(format string, v ...interface{}) {
@@ -330,6 +340,31 @@ func (c *Client) debug(format string, v ...interface{}) {
 	}
 }
 
+// SetCredentials replaces API key and secret key at runtime. Requests built afterwards are
+// signed with the new pair, requests already sent are not affected.
+func (c *Client) SetCredentials(apiKey, secretKey string) {
+	c.credMu.Lock()
+	defer c.credMu.Unlock()
+
+	c.APIKey = apiKey
+	c.SecretKey = secretKey
+}
+
+func (c *Client) credentials() (apiKey, secretKey string) {
+	c.credMu.RLock()
+	defer c.credMu.RUnlock()
+
+	return c.APIKey, c.SecretKey
+}
+
+// Healthy pings the API and checks TimeOffset is sane, so it can back readiness probes
+func (c *Client) Healthy(ctx context.Context) error {
+	if err := c.NewPingService().Do(ctx); err != nil {
+		return err
+	}
+	return common.CheckTimeOffset(c.TimeOffset)
+}
+
 func (c *Client) parseRequest(r *request, opts ...RequestOption) (err error) {
 	// set request options from user
 	for _, opt := range opts {
@@ -358,13 +393,14 @@ func (c *Client) parseRequest(r *request, opts ...RequestOption) (err error) {
 		header.Set("Content-Type", "application/x-www-form-urlencoded")
 		body = bytes.NewBufferString(bodyString)
 	}
+	key, secret := c.credentials()
 	if r.secType == secTypeAPIKey || r.secType == secTypeSigned {
-		header.Set("X-MBX-APIKEY", c.APIKey)
+		header.Set("X-MBX-APIKEY", key)
 	}
 
 	if r.secType == secTypeSigned {
 		raw := fmt.Sprintf("%s%s", queryString, bodyString)
-		mac := hmac.New(sha256.New, []byte(c.SecretKey))
+		mac := hmac.New(sha256.New, []byte(secret))
 		_, err = mac.Write([]byte(raw))
 		if err != nil {
 			return err
@@ -404,7 +440,7 @@ func (c *Client) callAPI(ctx context.Context, r *request, opts ...RequestOption)
 	if f == nil {
 		f = c.HTTPClient.Do
 	}
-	res, err := f(req)
+	res, err := common.ChainInterceptors(common.RoundTripFunc(f), c.Interceptors...)(req)
 	if err != nil {
 		return []byte{}, err
 	}
@@ -841,6 +877,21 @@ func (c *Client) NewConvertTradeHistoryService() *ConvertTradeHistoryService {
 	return &ConvertTradeHistoryService{c: c}
 }
 
+// NewConvertGetQuoteService init the convert get quote service
+func (c *Client) NewConvertGetQuoteService() *ConvertGetQuoteService {
+	return &ConvertGetQuoteService{c: c}
+}
+
+// NewConvertAcceptQuoteService init the convert accept quote service
+func (c *Client) NewConvertAcceptQuoteService() *ConvertAcceptQuoteService {
+	return &ConvertAcceptQuoteService{c: c}
+}
+
+// NewConvertOrderStatusService init the convert order status service
+func (c *Client) NewConvertOrderStatusService() *ConvertOrderStatusService {
+	return &ConvertOrderStatusService{c: c}
+}
+
 // NewGetIsolatedMarginAllPairsService init get isolated margin all pairs service
 func (c *Client) NewGetIsolatedMarginAllPairsService() *GetIsolatedMarginAllPairsService {
 	return &GetIsolatedMarginAllPairsService{c: c}
@@ -971,6 +1022,16 @@ func (c *Client) NewSubAccountFuturesAccountService() *SubAccountFuturesAccountS
 	return &SubAccountFuturesAccountService{c: c}
 }
 
+// NewSubAccountFuturesPositionRiskService Get Futures Position-Risk of Sub-account (For Master Account)
+func (c *Client) NewSubAccountFuturesPositionRiskService() *SubAccountFuturesPositionRiskService {
+	return &SubAccountFuturesPositionRiskService{c: c}
+}
+
+// NewSubAccountFuturesTransferService Futures Transfer between Sub-accounts (For Master Account)
+func (c *Client) NewSubAccountFuturesTransferService() *SubAccountFuturesTransferService {
+	return &SubAccountFuturesTransferService{c: c}
+}
+
 // NewMarginCollateralRateService get margin collateral rate
 func (c *Client) NewMarginCollateralRateService() *MarginCollateralRateService {
 	return &MarginCollateralRateService{c: c}
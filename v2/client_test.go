@@ -9,6 +9,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/adshao/go-binance/v2/common"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
@@ -167,3 +168,26 @@ func TestFormatTimestamp(t *testing.T) {
 	tm, _ := time.Parse("2006-01-02 15:04:05", "2018-06-01 01:01:01")
 	assert.Equal(t, int64(1527814861000), FormatTimestamp(tm))
 }
+
+func TestClientInterceptors(t *testing.T) {
+	c := NewClient("apiKey", "secretKey")
+	c.do = func(req *http.Request) (*http.Response, error) {
+		assert.Equal(t, "value", req.Header.Get("X-Custom"))
+		return newHTTPResponse([]byte(`{"serverTime":1499827319559}`), http.StatusOK), nil
+	}
+	var status int
+	c.Interceptors = []common.Interceptor{
+		common.BeforeSend(func(req *http.Request) error {
+			req.Header.Set("X-Custom", "value")
+			return nil
+		}),
+		common.AfterReceive(func(req *http.Request, res *http.Response, err error) {
+			status = res.StatusCode
+		}),
+	}
+
+	serverTime, err := c.NewServerTimeService().Do(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, int64(1499827319559), serverTime)
+	assert.Equal(t, http.StatusOK, status)
+}
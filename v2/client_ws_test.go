@@ -0,0 +1,279 @@
+package binance
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/adshao/go-binance/v2/common"
+)
+
+// baseWsApiTestSuite serves websocket API requests from a local server
+type baseWsApiTestSuite struct {
+	baseTestSuite
+	server      *httptest.Server
+	origGetConn func(cfg *WsConfig) (*websocket.Conn, error)
+	mu          sync.Mutex
+	requests    []WsApiRequest
+	responses   map[WsApiMethodType]string
+	pushes      map[WsApiMethodType][]string
+	wsClient    *ClientWs
+}
+
+func (s *baseWsApiTestSuite) SetupTest() {
+	s.baseTestSuite.SetupTest()
+	s.requests = nil
+	s.responses = make(map[WsApiMethodType]string)
+	s.pushes = make(map[WsApiMethodType][]string)
+
+	upgrader := websocket.Upgrader{}
+	s.server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		conn, err := upgrader.Upgrade(w, r, nil)
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		for {
+			_, message, err := conn.ReadMessage()
+			if err != nil {
+				return
+			}
+			req := WsApiRequest{}
+			if err := json.Unmarshal(message, &req); err != nil {
+				return
+			}
+			s.mu.Lock()
+			s.requests = append(s.requests, req)
+			result, ok := s.responses[req.Method]
+			pushes := s.pushes[req.Method]
+			s.mu.Unlock()
+			if !ok {
+				result = `{"id":"` + req.Id + `","status":400,"error":{"code":-1102,"msg":"unexpected method"}}`
+			} else {
+				result = strings.ReplaceAll(result, "{{id}}", req.Id)
+			}
+			if err := conn.WriteMessage(websocket.TextMessage, []byte(result)); err != nil {
+				return
+			}
+			for _, push := range pushes {
+				if err := conn.WriteMessage(websocket.TextMessage, []byte(push)); err != nil {
+					return
+				}
+			}
+		}
+	}))
+
+	s.origGetConn = WsGetReadWriteConnection
+	endpoint := "ws" + strings.TrimPrefix(s.server.URL, "http")
+	WsGetReadWriteConnection = func(cfg *WsConfig) (*websocket.Conn, error) {
+		c, _, err := websocket.DefaultDialer.Dial(endpoint, nil)
+		return c, err
+	}
+
+	var err error
+	s.wsClient, err = NewClientWs(s.apiKey, s.secretKey)
+	s.r().NoError(err)
+}
+
+func (s *baseWsApiTestSuite) TearDownTest() {
+	WsGetReadWriteConnection = s.origGetConn
+	s.server.Close()
+}
+
+// respond sets result returned for method, '{{id}}' is replaced by the request id
+func (s *baseWsApiTestSuite) respond(method WsApiMethodType, result string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.responses[method] = `{"id":"{{id}}","status":200,"result":` + result + `}`
+}
+
+// pushAfter sets messages pushed by the server after responding to method
+func (s *baseWsApiTestSuite) pushAfter(method WsApiMethodType, messages ...string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.pushes[method] = messages
+}
+
+// lastRequest returns the last request received by the server
+func (s *baseWsApiTestSuite) lastRequest() WsApiRequest {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.r().NotEmpty(s.requests)
+	return s.requests[len(s.requests)-1]
+}
+
+type clientWsTestSuite struct {
+	baseWsApiTestSuite
+}
+
+func TestClientWs(t *testing.T) {
+	suite.Run(t, new(clientWsTestSuite))
+}
+
+func (s *clientWsTestSuite) TestConnectionState() {
+	r := s.r()
+	r.True(s.wsClient.LastMessageAt().IsZero())
+	r.Greater(s.wsClient.ConnectionAge(), time.Duration(0))
+	r.Less(s.wsClient.ConnectionAge(), time.Minute)
+
+	s.respond(WsApiMethodAccountStatus, `{"accountType": "SPOT"}`)
+	before := time.Now()
+	_, err := s.wsClient.NewAccountStatusWsService().Do(newContext(), NewAccountStatusWsRequest())
+	r.NoError(err)
+	r.False(s.wsClient.LastMessageAt().Before(before))
+}
+
+func (s *clientWsTestSuite) TestHealthy() {
+	r := s.r()
+	r.NoError(s.wsClient.Healthy(newContext()))
+
+	s.mu.Lock()
+	s.responses[WsApiMethodAccountStatus] = `{"id":"{{id}}","status":503,"error":{"code":-1008,"msg":"server is busy"}}`
+	s.mu.Unlock()
+	for i := 0; i < 10; i++ {
+		_, err := s.wsClient.NewAccountStatusWsService().Do(newContext(), NewAccountStatusWsRequest())
+		r.Error(err)
+	}
+	r.ErrorIs(s.wsClient.Healthy(newContext()), common.ErrHighErrorRate)
+}
+
+func (s *clientWsTestSuite) TestHealthyTimeOffset() {
+	s.wsClient.TimeOffset = -common.MaxTimeOffset - 1
+	s.r().ErrorIs(s.wsClient.Healthy(newContext()), common.ErrTimeOffsetTooLarge)
+}
+
+func (s *clientWsTestSuite) TestOnUnhandledMessage() {
+	event := `{"event":{"e":"outboundAccountPosition","E":1728972148778,"u":1728972148778}}`
+	s.mu.Lock()
+	s.responses[WsApiMethodAccountStatus] = event
+	s.mu.Unlock()
+
+	messages := make(chan []byte, 1)
+	s.wsClient.OnUnhandledMessage(func(message []byte) {
+		messages <- message
+	})
+
+	ctx, cancel := context.WithTimeout(newContext(), 100*time.Millisecond)
+	defer cancel()
+	_, err := s.wsClient.NewAccountStatusWsService().Do(ctx, NewAccountStatusWsRequest())
+	r := s.r()
+	r.ErrorIs(err, context.DeadlineExceeded)
+
+	select {
+	case message := <-messages:
+		r.JSONEq(event, string(message))
+	case <-time.After(time.Second):
+		r.Fail("unhandled message was not delivered")
+	}
+}
+
+func (s *clientWsTestSuite) TestCredentialsSigner() {
+	r := s.r()
+	_, signer := s.wsClient.credentials()
+	_, again := s.wsClient.credentials()
+	r.Same(signer, again)
+
+	s.wsClient.SetCredentials("rotatedAPIKey", "rotatedSecretKey")
+	key, rotated := s.wsClient.credentials()
+	r.Equal("rotatedAPIKey", key)
+	r.NotSame(signer, rotated)
+	expected, _ := common.NewHMACSigner("rotatedSecretKey").Sign(context.Background(), "a=1")
+	actual, err := rotated.Sign(context.Background(), "a=1")
+	r.NoError(err)
+	r.Equal(expected, actual)
+}
+
+func (s *clientWsTestSuite) TestSetCredentials() {
+	s.respond(WsApiMethodAccountStatus, `{"accountType": "SPOT"}`)
+	s.wsClient.SetCredentials("rotatedAPIKey", "rotatedSecretKey")
+
+	_, err := s.wsClient.NewAccountStatusWsService().Do(newContext(), NewAccountStatusWsRequest())
+	r := s.r()
+	r.NoError(err)
+	r.Equal("rotatedAPIKey", s.lastRequest().Params[apiKey])
+}
+
+func (s *clientWsTestSuite) TestSigner() {
+	s.respond(WsApiMethodAccountStatus, `{"accountType": "SPOT"}`)
+
+	var payload string
+	s.wsClient.SetSigner(common.SignerFunc(func(ctx context.Context, p string) (string, error) {
+		payload = p
+		return "remoteSignature", nil
+	}))
+
+	_, err := s.wsClient.NewAccountStatusWsService().Do(newContext(), NewAccountStatusWsRequest())
+	r := s.r()
+	r.NoError(err)
+	p := s.lastRequest().Params
+	r.Equal("remoteSignature", p[signatureKey])
+
+	signed, err := url.ParseQuery(payload)
+	r.NoError(err)
+	r.Equal(s.apiKey, signed.Get(apiKey))
+	r.NotEmpty(signed.Get(timestampKey))
+	r.NotContains(signed, signatureKey)
+}
+
+func (s *clientWsTestSuite) TestMetricsHandler() {
+	s.respond(WsApiMethodOpenOrdersStatus, `[]`)
+
+	type observation struct {
+		method, symbol string
+		status         int
+		err            error
+	}
+	var observed []observation
+	s.wsClient.MetricsHandler = func(method string, symbol string, latency time.Duration, status int, err error) {
+		observed = append(observed, observation{method, symbol, status, err})
+	}
+
+	_, err := s.wsClient.NewOpenOrdersStatusWsService().Do(newContext(), NewOpenOrdersStatusWsRequest().Symbol("BNBBTC"))
+	r := s.r()
+	r.NoError(err)
+	_, err = s.wsClient.NewMyTradesWsService().Do(newContext(), NewMyTradesWsRequest().Symbol("BNBBTC"))
+	r.Error(err)
+
+	r.Len(observed, 2)
+	r.Equal(observation{string(WsApiMethodOpenOrdersStatus), "BNBBTC", 200, nil}, observed[0])
+	r.Equal(string(WsApiMethodMyTrades), observed[1].method)
+	r.Equal(400, observed[1].status)
+	r.Equal(err, observed[1].err)
+}
+
+func (s *clientWsTestSuite) TestClose() {
+	// a request waiting for its response is failed by Close
+	cc := s.wsClient.pending.add("waiting")
+
+	r := s.r()
+	r.NoError(s.wsClient.Close())
+	r.NoError(s.wsClient.Close())
+	_, _, err := waiter{cc}.wait(newContext())
+	r.ErrorIs(err, ErrWsConnectionClosed)
+	r.Zero(s.wsClient.pending.len())
+
+	_, err = s.wsClient.NewAccountStatusWsService().Do(newContext(), NewAccountStatusWsRequest())
+	r.ErrorIs(err, ErrWsConnectionClosed)
+}
+
+func (s *clientWsTestSuite) TestTimedOutRequest() {
+	// the response answers another request, so the request times out
+	s.mu.Lock()
+	s.responses[WsApiMethodAccountStatus] = `{"id":"other","status":200,"result":{}}`
+	s.mu.Unlock()
+
+	ctx, cancel := context.WithTimeout(newContext(), 50*time.Millisecond)
+	defer cancel()
+	_, err := s.wsClient.NewAccountStatusWsService().Do(ctx, NewAccountStatusWsRequest())
+	r := s.r()
+	r.ErrorIs(err, context.DeadlineExceeded)
+	r.Zero(s.wsClient.pending.len())
+}
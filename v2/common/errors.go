@@ -20,3 +20,9 @@ func IsAPIError(e error) bool {
 	_, ok := e.(*APIError)
 	return ok
 }
+
+// IsAPIErrorCode check if e is an API error with code
+func IsAPIErrorCode(e error, code int64) bool {
+	apiErr, ok := e.(*APIError)
+	return ok && apiErr.Code == code
+}
@@ -0,0 +1,87 @@
+package common
+
+import (
+	"context"
+	"errors"
+	"sync"
+)
+
+var (
+	ErrHighErrorRate      = errors.New("health: recent error rate is too high")
+	ErrTimeOffsetTooLarge = errors.New("health: time offset is too large")
+)
+
+const (
+	// MaxTimeOffset is the largest time offset in milliseconds considered sane, a larger one
+	// means the local clock is badly off or the offset was miscalculated
+	MaxTimeOffset int64 = 60000
+
+	errorRateMinSamples = 10
+	errorRateMax        = 0.5
+)
+
+// HealthChecker is implemented by clients that can report whether they are ready to serve requests,
+// Healthy returns nil when the client is healthy and the reason otherwise
+type HealthChecker interface {
+	Healthy(ctx context.Context) error
+}
+
+// CheckTimeOffset returns ErrTimeOffsetTooLarge if offset (in milliseconds) exceeds MaxTimeOffset
+func CheckTimeOffset(offset int64) error {
+	if offset > MaxTimeOffset || offset < -MaxTimeOffset {
+		return ErrTimeOffsetTooLarge
+	}
+	return nil
+}
+
+// ErrorRateWindow tracks outcomes of the last requests to compute recent error rate
+type ErrorRateWindow struct {
+	mu       sync.Mutex
+	outcomes []bool
+	next     int
+	count    int
+}
+
+// NewErrorRateWindow init ErrorRateWindow keeping outcomes of the last size requests
+func NewErrorRateWindow(size int) *ErrorRateWindow {
+	return &ErrorRateWindow{outcomes: make([]bool, size)}
+}
+
+// Add records outcome of a request
+func (w *ErrorRateWindow) Add(failed bool) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	w.outcomes[w.next] = failed
+	w.next = (w.next + 1) % len(w.outcomes)
+	if w.count < len(w.outcomes) {
+		w.count++
+	}
+}
+
+// ErrorRate returns share of failed requests and the number of requests it was computed from
+func (w *ErrorRateWindow) ErrorRate() (float64, int) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if w.count == 0 {
+		return 0, 0
+	}
+	failed := 0
+	for i := 0; i < w.count; i++ {
+		if w.outcomes[i] {
+			failed++
+		}
+	}
+	return float64(failed) / float64(w.count), w.count
+}
+
+// Check returns ErrHighErrorRate if at least half of the recent requests failed,
+// it needs a few requests recorded before reporting anything
+func (w *ErrorRateWindow) Check() error {
+	rate, samples := w.ErrorRate()
+	if samples >= errorRateMinSamples && rate >= errorRateMax {
+		return ErrHighErrorRate
+	}
+	return nil
+}
@@ -0,0 +1,44 @@
+package common
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestErrorRateWindow(t *testing.T) {
+	assert := assert.New(t)
+
+	w := NewErrorRateWindow(20)
+	assert.NoError(w.Check())
+
+	for i := 0; i < 5; i++ {
+		w.Add(true)
+	}
+	rate, samples := w.ErrorRate()
+	assert.Equal(1.0, rate)
+	assert.Equal(5, samples)
+	assert.NoError(w.Check(), "too few samples to judge")
+
+	for i := 0; i < 5; i++ {
+		w.Add(false)
+	}
+	assert.ErrorIs(w.Check(), ErrHighErrorRate)
+
+	for i := 0; i < 20; i++ {
+		w.Add(false)
+	}
+	rate, samples = w.ErrorRate()
+	assert.Equal(0.0, rate)
+	assert.Equal(20, samples)
+	assert.NoError(w.Check())
+}
+
+func TestCheckTimeOffset(t *testing.T) {
+	assert := assert.New(t)
+
+	assert.NoError(CheckTimeOffset(0))
+	assert.NoError(CheckTimeOffset(-1500))
+	assert.ErrorIs(CheckTimeOffset(MaxTimeOffset+1), ErrTimeOffsetTooLarge)
+	assert.ErrorIs(CheckTimeOffset(-MaxTimeOffset-1), ErrTimeOffsetTooLarge)
+}
@@ -0,0 +1,43 @@
+package common
+
+import "net/http"
+
+// RoundTripFunc sends an HTTP request and returns its response
+type RoundTripFunc func(req *http.Request) (*http.Response, error)
+
+// Interceptor wraps sending of a REST request. It may change the request before calling next,
+// inspect or replace the response or the error returned by next, or answer without calling
+// next at all. The body of the response is read once the outermost interceptor returned.
+type Interceptor func(req *http.Request, next RoundTripFunc) (*http.Response, error)
+
+// ChainInterceptors returns f wrapped by interceptors, the first one being the outermost
+func ChainInterceptors(f RoundTripFunc, interceptors ...Interceptor) RoundTripFunc {
+	for i := len(interceptors) - 1; i >= 0; i-- {
+		interceptor, next := interceptors[i], f
+		f = func(req *http.Request) (*http.Response, error) {
+			return interceptor(req, next)
+		}
+	}
+	return f
+}
+
+// BeforeSend returns Interceptor calling hook with every request before it is sent, e.g. to
+// set headers. Returning error fails the request without sending it.
+func BeforeSend(hook func(req *http.Request) error) Interceptor {
+	return func(req *http.Request, next RoundTripFunc) (*http.Response, error) {
+		if err := hook(req); err != nil {
+			return nil, err
+		}
+		return next(req)
+	}
+}
+
+// AfterReceive returns Interceptor calling hook with every request and its response or error
+// once received, e.g. to log or measure them
+func AfterReceive(hook func(req *http.Request, res *http.Response, err error)) Interceptor {
+	return func(req *http.Request, next RoundTripFunc) (*http.Response, error) {
+		res, err := next(req)
+		hook(req, res, err)
+		return res, err
+	}
+}
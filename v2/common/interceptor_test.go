@@ -0,0 +1,52 @@
+package common
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestChainInterceptors(t *testing.T) {
+	var calls []string
+	trace := func(name string) Interceptor {
+		return func(req *http.Request, next RoundTripFunc) (*http.Response, error) {
+			calls = append(calls, name+" before")
+			res, err := next(req)
+			calls = append(calls, name+" after")
+			return res, err
+		}
+	}
+	send := func(req *http.Request) (*http.Response, error) {
+		calls = append(calls, "send "+req.Header.Get("X-Test"))
+		return &http.Response{StatusCode: http.StatusOK}, nil
+	}
+
+	var received *http.Response
+	f := ChainInterceptors(send,
+		trace("outer"),
+		BeforeSend(func(req *http.Request) error {
+			req.Header.Set("X-Test", "set")
+			return nil
+		}),
+		AfterReceive(func(req *http.Request, res *http.Response, err error) {
+			received = res
+		}),
+		trace("inner"),
+	)
+	req, _ := http.NewRequest(http.MethodGet, "https://example.com", nil)
+	res, err := f(req)
+	assert.NoError(t, err)
+	assert.Same(t, res, received)
+	assert.Equal(t, []string{"outer before", "inner before", "send set", "inner after", "outer after"}, calls)
+
+	// a failing hook stops the request
+	calls = nil
+	f = ChainInterceptors(send, BeforeSend(func(req *http.Request) error {
+		return errors.New("blocked")
+	}))
+	_, err = f(req)
+	assert.EqualError(t, err, "blocked")
+	assert.Empty(t, calls)
+}
@@ -0,0 +1,51 @@
+package common
+
+import (
+	"encoding/json"
+	"io"
+
+	jsoniter "github.com/json-iterator/go"
+)
+
+// JSONCodec encodes and decodes JSON. SonicJSONCodec of github.com/bytedance/sonic is built with
+// the sonic build tag, so the module doesn't depend on sonic by default.
+type JSONCodec interface {
+	Marshal(v interface{}) ([]byte, error)
+	Unmarshal(data []byte, v interface{}) error
+}
+
+// JSONWriterCodec is implemented by JSONCodec which encodes into a writer, so callers can reuse
+// buffers instead of allocating the slice returned by Marshal. Values are followed by a newline
+// as json.Encoder writes them.
+type JSONWriterCodec interface {
+	MarshalTo(w io.Writer, v interface{}) error
+}
+
+var (
+	// StdJSONCodec is JSONCodec of encoding/json
+	StdJSONCodec JSONCodec = stdJSONCodec{}
+	// JsoniterJSONCodec is JSONCodec of json-iterator, compatible with encoding/json
+	JsoniterJSONCodec JSONCodec = jsoniterJSONCodec{jsoniter.ConfigCompatibleWithStandardLibrary}
+)
+
+type stdJSONCodec struct{}
+
+func (stdJSONCodec) MarshalTo(w io.Writer, v interface{}) error {
+	return json.NewEncoder(w).Encode(v)
+}
+
+func (stdJSONCodec) Marshal(v interface{}) ([]byte, error) {
+	return json.Marshal(v)
+}
+
+func (stdJSONCodec) Unmarshal(data []byte, v interface{}) error {
+	return json.Unmarshal(data, v)
+}
+
+type jsoniterJSONCodec struct {
+	jsoniter.API
+}
+
+func (c jsoniterJSONCodec) MarshalTo(w io.Writer, v interface{}) error {
+	return c.NewEncoder(w).Encode(v)
+}
@@ -0,0 +1,22 @@
+//go:build sonic
+
+package common
+
+import (
+	"io"
+
+	"github.com/bytedance/sonic"
+)
+
+// SonicJSONCodec is JSONCodec of sonic.ConfigStd, compatible with encoding/json. It is only
+// built with the sonic build tag, so the module doesn't depend on sonic otherwise: build with
+// -tags sonic and require github.com/bytedance/sonic in the go.mod of the main module.
+var SonicJSONCodec JSONCodec = sonicJSONCodec{sonic.ConfigStd}
+
+type sonicJSONCodec struct {
+	sonic.API
+}
+
+func (c sonicJSONCodec) MarshalTo(w io.Writer, v interface{}) error {
+	return c.NewEncoder(w).Encode(v)
+}
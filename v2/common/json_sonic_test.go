@@ -0,0 +1,7 @@
+//go:build sonic
+
+package common
+
+func init() {
+	testJSONCodecs["sonic"] = SonicJSONCodec
+}
@@ -0,0 +1,36 @@
+package common
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// testJSONCodecs are the codecs checked by TestJSONCodecs, codecs of build tags add themselves
+var testJSONCodecs = map[string]JSONCodec{"std": StdJSONCodec, "jsoniter": JsoniterJSONCodec}
+
+func TestJSONCodecs(t *testing.T) {
+	type level struct {
+		Price    string `json:"p"`
+		Quantity string `json:"q,omitempty"`
+		Time     int64  `json:"T"`
+	}
+	for name, codec := range testJSONCodecs {
+		t.Run(name, func(t *testing.T) {
+			assert := assert.New(t)
+			data, err := codec.Marshal(level{Price: "1.5", Time: 123})
+			assert.NoError(err)
+			assert.JSONEq(`{"p":"1.5","T":123}`, string(data))
+
+			var res []level
+			assert.NoError(codec.Unmarshal([]byte(`[{"p":"2","q":"3","T":4}]`), &res))
+			assert.Equal([]level{{Price: "2", Quantity: "3", Time: 4}}, res)
+			assert.Error(codec.Unmarshal([]byte(`{"p":`), &res))
+
+			buf := new(bytes.Buffer)
+			assert.NoError(codec.(JSONWriterCodec).MarshalTo(buf, level{Price: "1.5"}))
+			assert.Equal("{\"p\":\"1.5\",\"T\":0}\n", buf.String())
+		})
+	}
+}
@@ -0,0 +1,92 @@
+package common
+
+import (
+	"context"
+	"crypto/ed25519"
+	"crypto/hmac"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/pem"
+	"errors"
+	"hash"
+	"sync"
+)
+
+// ErrInvalidEd25519Key is returned when a private key is not a PEM encoded Ed25519 key
+var ErrInvalidEd25519Key = errors.New("signer: invalid Ed25519 private key")
+
+// Signer signs the canonical query string of a request and returns the signature.
+// Implementations may call out to an HSM, KMS or Vault so the secret key never
+// lives in process memory; ctx carries the deadline of the request being signed.
+type Signer interface {
+	Sign(ctx context.Context, payload string) (string, error)
+}
+
+// SignerFunc adapts a function to Signer
+type SignerFunc func(ctx context.Context, payload string) (string, error)
+
+// Sign calls f(ctx, payload)
+func (f SignerFunc) Sign(ctx context.Context, payload string) (string, error) {
+	return f(ctx, payload)
+}
+
+// HMACSigner signs payloads locally with HMAC-SHA256. It reuses HMAC states keyed with the
+// secret key, so a client should keep one HMACSigner rather than create one per request.
+type HMACSigner struct {
+	secretKey []byte
+	macs      sync.Pool
+}
+
+// NewHMACSigner init HMACSigner
+func NewHMACSigner(secretKey string) *HMACSigner {
+	s := &HMACSigner{secretKey: []byte(secretKey)}
+	s.macs.New = func() interface{} {
+		return hmac.New(sha256.New, s.secretKey)
+	}
+	return s
+}
+
+// Sign returns hex encoded HMAC-SHA256 of payload
+func (s *HMACSigner) Sign(_ context.Context, payload string) (string, error) {
+	mac := s.macs.Get().(hash.Hash)
+	defer s.macs.Put(mac)
+
+	mac.Reset()
+	if _, err := mac.Write([]byte(payload)); err != nil {
+		return "", err
+	}
+	var sum [sha256.Size]byte
+	var dst [2 * sha256.Size]byte
+	hex.Encode(dst[:], mac.Sum(sum[:0]))
+	return string(dst[:]), nil
+}
+
+// Ed25519Signer signs payloads locally with an Ed25519 private key, whose public key is
+// registered as the API key on Binance
+type Ed25519Signer struct {
+	privateKey ed25519.PrivateKey
+}
+
+// NewEd25519Signer init Ed25519Signer from a PEM encoded PKCS#8 private key
+func NewEd25519Signer(pemKey []byte) (*Ed25519Signer, error) {
+	block, _ := pem.Decode(pemKey)
+	if block == nil {
+		return nil, ErrInvalidEd25519Key
+	}
+	key, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+	if err != nil {
+		return nil, errors.Join(ErrInvalidEd25519Key, err)
+	}
+	privateKey, ok := key.(ed25519.PrivateKey)
+	if !ok {
+		return nil, ErrInvalidEd25519Key
+	}
+	return &Ed25519Signer{privateKey: privateKey}, nil
+}
+
+// Sign returns base64 encoded Ed25519 signature of payload
+func (s *Ed25519Signer) Sign(_ context.Context, payload string) (string, error) {
+	return base64.StdEncoding.EncodeToString(ed25519.Sign(s.privateKey, []byte(payload))), nil
+}
@@ -0,0 +1,182 @@
+package common
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+const (
+	SchemaErrorUnknownField = "unknown field"
+	SchemaErrorMissingField = "missing field"
+)
+
+// SchemaError is returned by StrictUnmarshal when a response does not match the type decoding it
+type SchemaError struct {
+	// Type is the Go type holding Field
+	Type string
+	// Field is the path of the field in the response, e.g. "positions[0].entryPrice"
+	Field  string
+	Reason string
+}
+
+func (e *SchemaError) Error() string {
+	return fmt.Sprintf("strict decode: %s %s in %s", e.Reason, e.Field, e.Type)
+}
+
+// StrictUnmarshal decodes data into v as json.Unmarshal does, then returns *SchemaError if data
+// holds an object field v has no field for, or lacks a field of v not tagged omitempty. Types
+// implementing json.Unmarshaler decode their own fields and are not checked.
+func StrictUnmarshal(data []byte, v interface{}) error {
+	if err := json.Unmarshal(data, v); err != nil {
+		return err
+	}
+	return CheckSchema(data, v)
+}
+
+// CheckSchema returns *SchemaError if data, already decoded into v, does not match the type of v
+// as StrictUnmarshal checks it. v is not modified.
+func CheckSchema(data []byte, v interface{}) error {
+	var raw interface{}
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+	return checkSchema(raw, reflect.TypeOf(v), "")
+}
+
+var unmarshalerType = reflect.TypeOf((*json.Unmarshaler)(nil)).Elem()
+
+type schemaField struct {
+	name     string
+	typ      reflect.Type
+	required bool
+}
+
+func checkSchema(raw interface{}, t reflect.Type, path string) error {
+	for t.Kind() == reflect.Pointer {
+		if t.Implements(unmarshalerType) {
+			return nil
+		}
+		t = t.Elem()
+	}
+	if reflect.PointerTo(t).Implements(unmarshalerType) {
+		return nil
+	}
+
+	switch t.Kind() {
+	case reflect.Struct:
+		obj, ok := raw.(map[string]interface{})
+		if !ok {
+			return nil
+		}
+		fields := schemaFields(t)
+		matched := make([]bool, len(fields))
+		for _, key := range sortedKeys(obj) {
+			i := matchField(fields, key)
+			if i < 0 {
+				return &SchemaError{Type: t.String(), Field: joinPath(path, key), Reason: SchemaErrorUnknownField}
+			}
+			matched[i] = true
+			if err := checkSchema(obj[key], fields[i].typ, joinPath(path, key)); err != nil {
+				return err
+			}
+		}
+		for i, f := range fields {
+			if f.required && !matched[i] {
+				return &SchemaError{Type: t.String(), Field: joinPath(path, f.name), Reason: SchemaErrorMissingField}
+			}
+		}
+	case reflect.Slice, reflect.Array:
+		items, ok := raw.([]interface{})
+		if !ok {
+			return nil
+		}
+		for i, item := range items {
+			if err := checkSchema(item, t.Elem(), path+"["+strconv.Itoa(i)+"]"); err != nil {
+				return err
+			}
+		}
+	case reflect.Map:
+		obj, ok := raw.(map[string]interface{})
+		if !ok {
+			return nil
+		}
+		for _, key := range sortedKeys(obj) {
+			if err := checkSchema(obj[key], t.Elem(), joinPath(path, key)); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// schemaFields returns the JSON fields of struct t, embedded structs without name are inlined
+// as encoding/json does
+func schemaFields(t reflect.Type) []schemaField {
+	var fields []schemaField
+	for i := 0; i < t.NumField(); i++ {
+		sf := t.Field(i)
+		tag := sf.Tag.Get("json")
+		if tag == "-" {
+			continue
+		}
+		name, opts, _ := strings.Cut(tag, ",")
+		if sf.Anonymous && name == "" {
+			ft := sf.Type
+			if ft.Kind() == reflect.Pointer {
+				ft = ft.Elem()
+			}
+			if ft.Kind() == reflect.Struct {
+				fields = append(fields, schemaFields(ft)...)
+				continue
+			}
+		}
+		if !sf.IsExported() {
+			continue
+		}
+		if name == "" {
+			name = sf.Name
+		}
+		fields = append(fields, schemaField{
+			name:     name,
+			typ:      sf.Type,
+			required: !strings.Contains(","+opts+",", ",omitempty,"),
+		})
+	}
+	return fields
+}
+
+// matchField returns the index of the field key is decoded into, preferring an exact match over
+// a case insensitive one as encoding/json does, -1 if none
+func matchField(fields []schemaField, key string) int {
+	fold := -1
+	for i, f := range fields {
+		if f.name == key {
+			return i
+		}
+		if fold < 0 && strings.EqualFold(f.name, key) {
+			fold = i
+		}
+	}
+	return fold
+}
+
+// sortedKeys returns keys of obj in order, so the first error reported does not vary
+func sortedKeys(obj map[string]interface{}) []string {
+	keys := make([]string, 0, len(obj))
+	for key := range obj {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func joinPath(path, key string) string {
+	if path == "" {
+		return key
+	}
+	return path + "." + key
+}
@@ -0,0 +1,78 @@
+package common
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type strictLevel struct {
+	Price string `json:"p"`
+	Qty   string `json:"q,omitempty"`
+}
+
+type strictEmbedded struct {
+	Time int64 `json:"E"`
+}
+
+type strictCustom struct {
+	Value string
+}
+
+func (c *strictCustom) UnmarshalJSON(data []byte) error {
+	c.Value = string(data)
+	return nil
+}
+
+type strictEvent struct {
+	strictEmbedded
+	Event  string        `json:"e"`
+	Levels []strictLevel `json:"levels"`
+	Custom strictCustom  `json:"custom,omitempty"`
+	Ignore string        `json:"-"`
+}
+
+func TestStrictUnmarshal(t *testing.T) {
+	tests := []struct {
+		name  string
+		data  string
+		field string
+		kind  string
+	}{
+		{"valid", `{"e":"depth","E":1,"levels":[{"p":"1","q":"2"},{"p":"3"}],"custom":{"any":1}}`, "", ""},
+		{"unknown field", `{"e":"depth","E":1,"levels":[],"x":1}`, "x", SchemaErrorUnknownField},
+		{"missing field", `{"e":"depth","levels":[]}`, "E", SchemaErrorMissingField},
+		{"nested missing field", `{"e":"depth","E":1,"levels":[{"p":"1"},{"q":"2"}]}`, "levels[1].p", SchemaErrorMissingField},
+		{"nested unknown field", `{"e":"depth","E":1,"levels":[{"p":"1","T":2}]}`, "levels[0].T", SchemaErrorUnknownField},
+		{"ignored field", `{"e":"depth","E":1,"levels":[],"Ignore":"x"}`, "Ignore", SchemaErrorUnknownField},
+		{"case insensitive match", `{"e":"depth","E":1,"LEVELS":[]}`, "", ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert := assert.New(t)
+			event := new(strictEvent)
+			err := StrictUnmarshal([]byte(tt.data), &event)
+			if tt.kind == "" {
+				assert.NoError(err)
+				return
+			}
+			schemaErr, ok := err.(*SchemaError)
+			if assert.True(ok, "%v", err) {
+				assert.Equal(tt.field, schemaErr.Field)
+				assert.Equal(tt.kind, schemaErr.Reason)
+			}
+			// the response is decoded as json.Unmarshal does
+			lenient := new(strictEvent)
+			assert.NoError(json.Unmarshal([]byte(tt.data), lenient))
+			assert.Equal(lenient, event)
+		})
+	}
+}
+
+func TestStrictUnmarshalSyntaxError(t *testing.T) {
+	err := StrictUnmarshal([]byte(`{"e":`), new(strictEvent))
+	assert.Error(t, err)
+	_, ok := err.(*SchemaError)
+	assert.False(t, ok)
+}
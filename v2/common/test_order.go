@@ -0,0 +1,9 @@
+package common
+
+import "context"
+
+// TestOrder is an order that is checked without being placed, so deployment smoke tests can
+// verify credentials and parameters against any market
+type TestOrder interface {
+	Test(ctx context.Context) error
+}
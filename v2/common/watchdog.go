@@ -0,0 +1,180 @@
+package common
+
+import (
+	"sync"
+	"time"
+)
+
+// StaleHandler is called when a stream has not advanced for longer than the watchdog timeout.
+// lastSeen is the local time at which the stream last advanced.
+type StaleHandler func(stream string, lastSeen time.Time)
+
+// RecoverHandler is called when a stale stream starts advancing again.
+type RecoverHandler func(stream string, staleFor time.Duration)
+
+type streamState struct {
+	lastSeen      time.Time
+	lastEventTime int64
+	stale         bool
+}
+
+// StreamWatchdog tracks a set of named streams and fires callbacks when one of them
+// stops delivering messages, or stops advancing its event time, for longer than Timeout.
+type StreamWatchdog struct {
+	Timeout   time.Duration
+	OnStale   StaleHandler
+	OnRecover RecoverHandler
+
+	mu      sync.Mutex
+	streams map[string]*streamState
+	stopC   chan struct{}
+	doneC   chan struct{}
+}
+
+// NewStreamWatchdog init StreamWatchdog
+func NewStreamWatchdog(timeout time.Duration, onStale StaleHandler) *StreamWatchdog {
+	return &StreamWatchdog{
+		Timeout: timeout,
+		OnStale: onStale,
+		streams: make(map[string]*streamState),
+	}
+}
+
+// Watch registers stream, its timer starts from now
+func (w *StreamWatchdog) Watch(stream string) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if _, ok := w.streams[stream]; !ok {
+		w.streams[stream] = &streamState{lastSeen: time.Now()}
+	}
+}
+
+// Unwatch stops tracking stream
+func (w *StreamWatchdog) Unwatch(stream string) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	delete(w.streams, stream)
+}
+
+// Touch records a message of stream. eventTime is the exchange event time in milliseconds,
+// a message whose eventTime does not advance past the previous one doesn't reset the timer.
+// Pass 0 as eventTime to only track message arrival.
+func (w *StreamWatchdog) Touch(stream string, eventTime int64) {
+	now := time.Now()
+
+	w.mu.Lock()
+	s, ok := w.streams[stream]
+	if !ok {
+		s = &streamState{}
+		w.streams[stream] = s
+	}
+	if eventTime != 0 {
+		if eventTime <= s.lastEventTime {
+			w.mu.Unlock()
+			return
+		}
+		s.lastEventTime = eventTime
+	}
+	wasStale, staleFor := s.stale, now.Sub(s.lastSeen)
+	s.lastSeen = now
+	s.stale = false
+	w.mu.Unlock()
+
+	if wasStale && w.OnRecover != nil {
+		w.OnRecover(stream, staleFor)
+	}
+}
+
+// IsStale reports whether stream is currently considered stale
+func (w *StreamWatchdog) IsStale(stream string) bool {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	s, ok := w.streams[stream]
+	return ok && s.stale
+}
+
+// LastSeen returns the local time stream last advanced
+func (w *StreamWatchdog) LastSeen(stream string) (time.Time, bool) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	s, ok := w.streams[stream]
+	if !ok {
+		return time.Time{}, false
+	}
+	return s.lastSeen, true
+}
+
+// Start runs the check loop in background until Stop is called
+func (w *StreamWatchdog) Start() {
+	w.mu.Lock()
+	if w.stopC != nil {
+		w.mu.Unlock()
+		return
+	}
+	w.stopC = make(chan struct{})
+	w.doneC = make(chan struct{})
+	stopC, doneC := w.stopC, w.doneC
+	w.mu.Unlock()
+
+	interval := w.Timeout / 4
+	if interval <= 0 {
+		interval = time.Millisecond
+	}
+
+	go func() {
+		defer close(doneC)
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-stopC:
+				return
+			case now := <-ticker.C:
+				w.check(now)
+			}
+		}
+	}()
+}
+
+// Stop stops the check loop
+func (w *StreamWatchdog) Stop() {
+	w.mu.Lock()
+	stopC, doneC := w.stopC, w.doneC
+	w.stopC, w.doneC = nil, nil
+	w.mu.Unlock()
+
+	if stopC == nil {
+		return
+	}
+	close(stopC)
+	<-doneC
+}
+
+// check fires OnStale once for every stream that became stale since the last check
+func (w *StreamWatchdog) check(now time.Time) {
+	type staleStream struct {
+		name     string
+		lastSeen time.Time
+	}
+
+	w.mu.Lock()
+	var stale []staleStream
+	for name, s := range w.streams {
+		if !s.stale && now.Sub(s.lastSeen) > w.Timeout {
+			s.stale = true
+			stale = append(stale, staleStream{name: name, lastSeen: s.lastSeen})
+		}
+	}
+	w.mu.Unlock()
+
+	if w.OnStale == nil {
+		return
+	}
+	for _, s := range stale {
+		w.OnStale(s.name, s.lastSeen)
+	}
+}
@@ -0,0 +1,84 @@
+package common
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStreamWatchdogFiresOnStale(t *testing.T) {
+	assert := assert.New(t)
+
+	var (
+		mu        sync.Mutex
+		stale     []string
+		recovered []string
+	)
+	w := NewStreamWatchdog(20*time.Millisecond, func(stream string, lastSeen time.Time) {
+		mu.Lock()
+		defer mu.Unlock()
+		stale = append(stale, stream)
+	})
+	w.OnRecover = func(stream string, staleFor time.Duration) {
+		mu.Lock()
+		defer mu.Unlock()
+		recovered = append(recovered, stream)
+	}
+	w.Watch("btcusdt@bookTicker")
+	w.Start()
+	defer w.Stop()
+
+	time.Sleep(60 * time.Millisecond)
+	assert.True(w.IsStale("btcusdt@bookTicker"))
+
+	w.Touch("btcusdt@bookTicker", 1)
+	assert.False(w.IsStale("btcusdt@bookTicker"))
+
+	mu.Lock()
+	defer mu.Unlock()
+	assert.Equal([]string{"btcusdt@bookTicker"}, stale)
+	assert.Equal([]string{"btcusdt@bookTicker"}, recovered)
+}
+
+func TestStreamWatchdogIgnoresNonMonotonicEventTime(t *testing.T) {
+	assert := assert.New(t)
+
+	w := NewStreamWatchdog(time.Minute, nil)
+	w.Touch("s", 100)
+	first, ok := w.LastSeen("s")
+	assert.True(ok)
+
+	time.Sleep(time.Millisecond)
+	w.Touch("s", 100)
+	second, _ := w.LastSeen("s")
+	assert.Equal(first, second)
+
+	w.Touch("s", 101)
+	third, _ := w.LastSeen("s")
+	assert.True(third.After(first))
+}
+
+func TestStreamWatchdogCheck(t *testing.T) {
+	assert := assert.New(t)
+
+	count := 0
+	w := NewStreamWatchdog(time.Second, func(stream string, lastSeen time.Time) {
+		count++
+	})
+	w.Touch("a", 0)
+	last, _ := w.LastSeen("a")
+
+	w.check(last.Add(500 * time.Millisecond))
+	assert.Equal(0, count)
+	w.check(last.Add(2 * time.Second))
+	assert.Equal(1, count)
+	// fires only once per stale period
+	w.check(last.Add(3 * time.Second))
+	assert.Equal(1, count)
+
+	w.Unwatch("a")
+	_, ok := w.LastSeen("a")
+	assert.False(ok)
+}
@@ -75,3 +75,186 @@ type ConvertTradeHistoryItem struct {
 	InverseRatio string `json:"inverseRatio"`
 	CreateTime   int64  `json:"createTime"`
 }
+
+// ConvertGetQuoteService request a quote for the requested token pair
+type ConvertGetQuoteService struct {
+	c          *Client
+	fromAsset  string
+	toAsset    string
+	fromAmount *string
+	toAmount   *string
+	walletType *string
+	validTime  *string
+}
+
+// FromAsset set fromAsset
+func (s *ConvertGetQuoteService) FromAsset(fromAsset string) *ConvertGetQuoteService {
+	s.fromAsset = fromAsset
+	return s
+}
+
+// ToAsset set toAsset
+func (s *ConvertGetQuoteService) ToAsset(toAsset string) *ConvertGetQuoteService {
+	s.toAsset = toAsset
+	return s
+}
+
+// FromAmount set fromAmount, the amount you will be debited after the conversion
+func (s *ConvertGetQuoteService) FromAmount(fromAmount string) *ConvertGetQuoteService {
+	s.fromAmount = &fromAmount
+	return s
+}
+
+// ToAmount set toAmount, the amount you will be credited after the conversion
+func (s *ConvertGetQuoteService) ToAmount(toAmount string) *ConvertGetQuoteService {
+	s.toAmount = &toAmount
+	return s
+}
+
+// WalletType set walletType, SPOT or FUNDING
+func (s *ConvertGetQuoteService) WalletType(walletType string) *ConvertGetQuoteService {
+	s.walletType = &walletType
+	return s
+}
+
+// ValidTime set validTime, 10s, 30s, 1m or 2m
+func (s *ConvertGetQuoteService) ValidTime(validTime string) *ConvertGetQuoteService {
+	s.validTime = &validTime
+	return s
+}
+
+// Do send request
+func (s *ConvertGetQuoteService) Do(ctx context.Context, opts ...RequestOption) (*ConvertQuote, error) {
+	r := &request{
+		method:   http.MethodPost,
+		endpoint: "/sapi/v1/convert/getQuote",
+		secType:  secTypeSigned,
+	}
+	r.setFormParam("fromAsset", s.fromAsset)
+	r.setFormParam("toAsset", s.toAsset)
+	if s.fromAmount != nil {
+		r.setFormParam("fromAmount", *s.fromAmount)
+	}
+	if s.toAmount != nil {
+		r.setFormParam("toAmount", *s.toAmount)
+	}
+	if s.walletType != nil {
+		r.setFormParam("walletType", *s.walletType)
+	}
+	if s.validTime != nil {
+		r.setFormParam("validTime", *s.validTime)
+	}
+	data, err := s.c.callAPI(ctx, r, opts...)
+	if err != nil {
+		return nil, err
+	}
+	res := ConvertQuote{}
+	if err = json.Unmarshal(data, &res); err != nil {
+		return nil, err
+	}
+	return &res, nil
+}
+
+// ConvertQuote define the convert quote
+type ConvertQuote struct {
+	QuoteId        string `json:"quoteId"`
+	Ratio          string `json:"ratio"`
+	InverseRatio   string `json:"inverseRatio"`
+	ValidTimestamp int64  `json:"validTimestamp"`
+	ToAmount       string `json:"toAmount"`
+	FromAmount     string `json:"fromAmount"`
+}
+
+// ConvertAcceptQuoteService accept the offered quote by quote ID
+type ConvertAcceptQuoteService struct {
+	c       *Client
+	quoteId string
+}
+
+// QuoteId set quoteId
+func (s *ConvertAcceptQuoteService) QuoteId(quoteId string) *ConvertAcceptQuoteService {
+	s.quoteId = quoteId
+	return s
+}
+
+// Do send request
+func (s *ConvertAcceptQuoteService) Do(ctx context.Context, opts ...RequestOption) (*ConvertAcceptQuote, error) {
+	r := &request{
+		method:   http.MethodPost,
+		endpoint: "/sapi/v1/convert/acceptQuote",
+		secType:  secTypeSigned,
+	}
+	r.setFormParam("quoteId", s.quoteId)
+	data, err := s.c.callAPI(ctx, r, opts...)
+	if err != nil {
+		return nil, err
+	}
+	res := ConvertAcceptQuote{}
+	if err = json.Unmarshal(data, &res); err != nil {
+		return nil, err
+	}
+	return &res, nil
+}
+
+// ConvertAcceptQuote define the accept quote response
+type ConvertAcceptQuote struct {
+	OrderId     string `json:"orderId"`
+	CreateTime  int64  `json:"createTime"`
+	OrderStatus string `json:"orderStatus"`
+}
+
+// ConvertOrderStatusService query order status by order ID or quote ID
+type ConvertOrderStatusService struct {
+	c       *Client
+	orderId *string
+	quoteId *string
+}
+
+// OrderId set orderId
+func (s *ConvertOrderStatusService) OrderId(orderId string) *ConvertOrderStatusService {
+	s.orderId = &orderId
+	return s
+}
+
+// QuoteId set quoteId
+func (s *ConvertOrderStatusService) QuoteId(quoteId string) *ConvertOrderStatusService {
+	s.quoteId = &quoteId
+	return s
+}
+
+// Do send request
+func (s *ConvertOrderStatusService) Do(ctx context.Context, opts ...RequestOption) (*ConvertOrderStatus, error) {
+	r := &request{
+		method:   http.MethodGet,
+		endpoint: "/sapi/v1/convert/orderStatus",
+		secType:  secTypeSigned,
+	}
+	if s.orderId != nil {
+		r.setParam("orderId", *s.orderId)
+	}
+	if s.quoteId != nil {
+		r.setParam("quoteId", *s.quoteId)
+	}
+	data, err := s.c.callAPI(ctx, r, opts...)
+	if err != nil {
+		return nil, err
+	}
+	res := ConvertOrderStatus{}
+	if err = json.Unmarshal(data, &res); err != nil {
+		return nil, err
+	}
+	return &res, nil
+}
+
+// ConvertOrderStatus define the convert order status
+type ConvertOrderStatus struct {
+	OrderId      int64  `json:"orderId"`
+	OrderStatus  string `json:"orderStatus"`
+	FromAsset    string `json:"fromAsset"`
+	FromAmount   string `json:"fromAmount"`
+	ToAsset      string `json:"toAsset"`
+	ToAmount     string `json:"toAmount"`
+	Ratio        string `json:"ratio"`
+	InverseRatio string `json:"inverseRatio"`
+	CreateTime   int64  `json:"createTime"`
+}
@@ -103,3 +103,104 @@ func (s *convertTradeTestSuite) assertConvertTradeHistoryItemEqual(e, a *Convert
 	r.Equal(e.InverseRatio, a.InverseRatio, "InverseRatio")
 	r.Equal(e.CreateTime, a.CreateTime, "CreateTime")
 }
+
+func (s *convertTradeTestSuite) TestConvertGetQuote() {
+	data := []byte(`{
+		"quoteId": "12415572564",
+		"ratio": "38163.7",
+		"inverseRatio": "0.0000262",
+		"validTimestamp": 1623319461670,
+		"toAmount": "3816.37",
+		"fromAmount": "0.1"
+	}`)
+	s.mockDo(data, nil)
+	defer s.assertDo()
+
+	s.assertReq(func(r *request) {
+		e := newSignedRequest().setFormParams(params{
+			"fromAsset":  "BTC",
+			"toAsset":    "USDT",
+			"fromAmount": "0.1",
+			"validTime":  "10s",
+		})
+		s.assertRequestEqual(e, r)
+	})
+
+	res, err := s.client.NewConvertGetQuoteService().
+		FromAsset("BTC").
+		ToAsset("USDT").
+		FromAmount("0.1").
+		ValidTime("10s").
+		Do(newContext())
+	s.r().NoError(err)
+	s.r().Equal(&ConvertQuote{
+		QuoteId:        "12415572564",
+		Ratio:          "38163.7",
+		InverseRatio:   "0.0000262",
+		ValidTimestamp: 1623319461670,
+		ToAmount:       "3816.37",
+		FromAmount:     "0.1",
+	}, res)
+}
+
+func (s *convertTradeTestSuite) TestConvertAcceptQuote() {
+	data := []byte(`{
+		"orderId": "933256278426274426",
+		"createTime": 1623381330472,
+		"orderStatus": "PROCESS"
+	}`)
+	s.mockDo(data, nil)
+	defer s.assertDo()
+
+	s.assertReq(func(r *request) {
+		e := newSignedRequest().setFormParams(params{
+			"quoteId": "12415572564",
+		})
+		s.assertRequestEqual(e, r)
+	})
+
+	res, err := s.client.NewConvertAcceptQuoteService().QuoteId("12415572564").Do(newContext())
+	s.r().NoError(err)
+	s.r().Equal(&ConvertAcceptQuote{
+		OrderId:     "933256278426274426",
+		CreateTime:  1623381330472,
+		OrderStatus: "PROCESS",
+	}, res)
+}
+
+func (s *convertTradeTestSuite) TestConvertOrderStatus() {
+	data := []byte(`{
+		"orderId": 933256278426274426,
+		"orderStatus": "SUCCESS",
+		"fromAsset": "BTC",
+		"fromAmount": "0.00054414",
+		"toAsset": "USDT",
+		"toAmount": "20",
+		"ratio": "36755",
+		"inverseRatio": "0.00002721",
+		"createTime": 1623381330472
+	}`)
+	s.mockDo(data, nil)
+	defer s.assertDo()
+
+	s.assertReq(func(r *request) {
+		e := newSignedRequest().setParams(params{
+			"orderId": "933256278426274426",
+		})
+		s.assertRequestEqual(e, r)
+	})
+
+	res, err := s.client.NewConvertOrderStatusService().OrderId("933256278426274426").Do(newContext())
+	s.r().NoError(err)
+	s.r().Equal(&ConvertOrderStatus{
+		OrderId:      933256278426274426,
+		OrderStatus:  "SUCCESS",
+		FromAsset:    "BTC",
+		FromAmount:   "0.00054414",
+		ToAsset:      "USDT",
+		ToAmount:     "20",
+		Ratio:        "36755",
+		InverseRatio: "0.00002721",
+		CreateTime:   1623381330472,
+	}, res)
+}
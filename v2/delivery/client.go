@@ -12,6 +12,7 @@ import (
 	"net/http"
 	"net/url"
 	"os"
+	"sync"
 	"time"
 
 	"github.com/adshao/go-binance/v2/common"
@@ -209,6 +210,7 @@ type Client struct {
 	Logger     *log.Logger
 	TimeOffset int64
 	do         doFunc
+	credMu     sync.RWMutex
 }
 
 func (c *Client) debug(format string, v ...interface{}) {
@@ -217,6 +219,31 @@ func (c *Client) debug(format string, v ...interface{}) {
 	}
 }
 
+// SetCredentials replaces API key and secret key at runtime. Requests built afterwards are
+// signed with the new pair, requests already sent are not affected.
+func (c *Client) SetCredentials(apiKey, secretKey string) {
+	c.credMu.Lock()
+	defer c.credMu.Unlock()
+
+	c.APIKey = apiKey
+	c.SecretKey = secretKey
+}
+
+func (c *Client) credentials() (apiKey, secretKey string) {
+	c.credMu.RLock()
+	defer c.credMu.RUnlock()
+
+	return c.APIKey, c.SecretKey
+}
+
+// Healthy pings the API and checks TimeOffset is sane, so it can back readiness probes
+func (c *Client) Healthy(ctx context.Context) error {
+	if err := c.NewPingService().Do(ctx); err != nil {
+		return err
+	}
+	return common.CheckTimeOffset(c.TimeOffset)
+}
+
 func (c *Client) parseRequest(r *request, opts ...RequestOption) (err error) {
 	// set request options from user
 	for _, opt := range opts {
@@ -245,13 +272,14 @@ func (c *Client) parseRequest(r *request, opts ...RequestOption) (err error) {
 		header.Set("Content-Type", "application/x-www-form-urlencoded")
 		body = bytes.NewBufferString(bodyString)
 	}
+	key, secret := c.credentials()
 	if r.secType == secTypeAPIKey || r.secType == secTypeSigned {
-		header.Set("X-MBX-APIKEY", c.APIKey)
+		header.Set("X-MBX-APIKEY", key)
 	}
 
 	if r.secType == secTypeSigned {
 		raw := fmt.Sprintf("%s%s", queryString, bodyString)
-		mac := hmac.New(sha256.New, []byte(c.SecretKey))
+		mac := hmac.New(sha256.New, []byte(secret))
 		_, err = mac.Write([]byte(raw))
 		if err != nil {
 			return err
@@ -0,0 +1,540 @@
+package delivery
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"net/url"
+	"os"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/adshao/go-binance/v2/common"
+	"github.com/google/uuid"
+	"github.com/gorilla/websocket"
+	"github.com/jpillora/backoff"
+)
+
+const (
+	reconnectMinInterval = 100 * time.Millisecond
+	reconnectMaxInterval = 10 * time.Second
+
+	// errorRateWindowSize is the number of last requests Healthy computes error rate from
+	errorRateWindowSize = 50
+)
+
+// WsApiMethodType define method name for websocket API
+type WsApiMethodType string
+
+// WsApiRequest define common websocket API request
+type WsApiRequest struct {
+	Id     string          `json:"id"`
+	Method WsApiMethodType `json:"method"`
+	Params params          `json:"params"`
+}
+
+const (
+	apiKey = "apiKey"
+)
+
+var (
+	ErrWsConnectionClosed = errors.New("ws error: connection closed")
+	ErrWsIdAlreadySent    = errors.New("ws error: request with same id already sent")
+	ErrWsReconnecting     = errors.New("ws error: connection is being reestablished")
+)
+
+type call struct {
+	response []byte
+	status   int
+	done     chan error
+}
+
+type waiter struct {
+	*call
+}
+
+func (w waiter) wait(ctx context.Context) ([]byte, int, error) {
+	select {
+	case err, ok := <-w.call.done:
+		if !ok {
+			err = ErrWsConnectionClosed
+		}
+		if err != nil {
+			return nil, w.call.status, err
+		}
+		return w.call.response, w.call.status, nil
+	case <-ctx.Done():
+		return nil, 0, ctx.Err()
+	}
+}
+
+// WsApiMetricsHandler is called on completion of every websocket API request with the method,
+// the symbol param if any, the time spent until the response, the response status (0 if no
+// response was received) and the error returned to the caller
+type WsApiMetricsHandler func(method string, symbol string, latency time.Duration, status int, err error)
+
+// WsApiMessageHandler handles raw message received on websocket API connection
+type WsApiMessageHandler func(message []byte)
+
+// ClientWs define API websocket client
+type ClientWs struct {
+	APIKey                      string
+	SecretKey                   string
+	Debug                       bool
+	Logger                      *log.Logger
+	Conn                        *websocket.Conn
+	TimeOffset                  int64
+	mu                          sync.Mutex
+	reconnectSignal             chan struct{}
+	connectionEstablishedSignal chan struct{}
+	pending                     PendingRequests
+	reconnectCount              atomic.Int64
+	connectedAt                 atomic.Int64
+	lastMessageAt               atomic.Int64
+	reconnecting                atomic.Bool
+	errorRate                   *common.ErrorRateWindow
+	unhandledMessageHandler     atomic.Pointer[WsApiMessageHandler]
+	closed                      chan struct{}
+	closeOnce                   sync.Once
+	credMu                      sync.RWMutex
+	signer                      common.Signer
+	hmac                        hmacSignerCache
+	// MetricsHandler is optional, set it before sending requests
+	MetricsHandler WsApiMetricsHandler
+}
+
+func (c *ClientWs) debug(format string, v ...interface{}) {
+	if c.Debug {
+		c.Logger.Println(fmt.Sprintf(format, v...))
+	}
+}
+
+// OnUnhandledMessage sets handler of messages that don't answer any pending request, such as
+// push events or server notices, which are dropped otherwise. Pass nil to drop them again.
+func (c *ClientWs) OnUnhandledMessage(handler WsApiMessageHandler) {
+	if handler == nil {
+		c.unhandledMessageHandler.Store(nil)
+		return
+	}
+	c.unhandledMessageHandler.Store(&handler)
+}
+
+func (c *ClientWs) handleUnhandledMessage(message []byte) {
+	if handler := c.unhandledMessageHandler.Load(); handler != nil {
+		(*handler)(message)
+	}
+}
+
+// SetCredentials replaces API key and secret key without reconnecting. Requests sent afterwards
+// are signed with the new pair, requests already in flight are not affected.
+func (c *ClientWs) SetCredentials(apiKey, secretKey string) {
+	c.credMu.Lock()
+	defer c.credMu.Unlock()
+
+	c.APIKey = apiKey
+	c.SecretKey = secretKey
+}
+
+// SetSigner makes the client sign requests with signer instead of SecretKey, so the secret
+// key can be kept in an external signing service. Pass nil to sign with SecretKey again.
+func (c *ClientWs) SetSigner(signer common.Signer) {
+	c.credMu.Lock()
+	defer c.credMu.Unlock()
+
+	c.signer = signer
+}
+
+func (c *ClientWs) credentials() (key string, signer common.Signer) {
+	c.credMu.RLock()
+	defer c.credMu.RUnlock()
+
+	if c.signer != nil {
+		return c.APIKey, c.signer
+	}
+	return c.APIKey, c.hmac.get(c.SecretKey)
+}
+
+// hmacSignerCache keeps the HMACSigner of the last secret key of a client, so signed requests
+// reuse its HMAC states
+type hmacSignerCache struct {
+	entry atomic.Pointer[hmacSignerEntry]
+}
+
+type hmacSignerEntry struct {
+	secretKey string
+	signer    *common.HMACSigner
+}
+
+func (c *hmacSignerCache) get(secretKey string) *common.HMACSigner {
+	if e := c.entry.Load(); e != nil && e.secretKey == secretKey {
+		return e.signer
+	}
+	e := &hmacSignerEntry{secretKey: secretKey, signer: common.NewHMACSigner(secretKey)}
+	c.entry.Store(e)
+	return e.signer
+}
+
+// NewClientWs init ClientWs
+func NewClientWs(apiKey, secretKey string) (*ClientWs, error) {
+	conn, err := WsApiInitReadWriteConn()
+	if err != nil {
+		return nil, err
+	}
+
+	client := &ClientWs{
+		APIKey:                      apiKey,
+		SecretKey:                   secretKey,
+		Logger:                      log.New(os.Stderr, "Binance-golang ", log.LstdFlags),
+		Conn:                        conn,
+		mu:                          sync.Mutex{},
+		reconnectSignal:             make(chan struct{}, 1),
+		connectionEstablishedSignal: make(chan struct{}, 1),
+		pending:                     NewPendingRequests(),
+		errorRate:                   common.NewErrorRateWindow(errorRateWindowSize),
+		closed:                      make(chan struct{}),
+	}
+	client.connectedAt.Store(time.Now().UnixNano())
+
+	go client.handleReconnect()
+	go client.read()
+
+	return client, nil
+}
+
+// Close closes the connection and stops reconnecting it, requests waiting for their response
+// fail with ErrWsConnectionClosed. The client can't be used afterwards.
+func (c *ClientWs) Close() error {
+	var err error
+	c.closeOnce.Do(func() {
+		close(c.closed)
+		c.mu.Lock()
+		err = c.Conn.Close()
+		c.mu.Unlock()
+		c.pending.closeAll()
+	})
+	return err
+}
+
+// isClosed reports whether Close was called
+func (c *ClientWs) isClosed() bool {
+	select {
+	case <-c.closed:
+		return true
+	default:
+		return false
+	}
+}
+
+// Write sends data into websocket connection
+func (c *ClientWs) Write(id string, data []byte) (waiter, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.isClosed() {
+		return waiter{}, ErrWsConnectionClosed
+	}
+
+	if c.pending.isAlreadyInList(id) {
+		return waiter{}, ErrWsIdAlreadySent
+	}
+
+	// the call is registered first so a fast response finds it
+	cc := c.pending.add(id)
+	if err := c.Conn.WriteMessage(websocket.TextMessage, data); err != nil {
+		c.debug("write: unable to write message into websocket conn '%v'", err)
+		c.pending.take([]byte(id))
+		return waiter{}, err
+	}
+
+	return waiter{cc}, nil
+}
+
+// doRequest sends request of method with params and waits for the raw response.
+// Signed requests get apiKey, timestamp and signature params added.
+func (c *ClientWs) doRequest(ctx context.Context, method WsApiMethodType, params params, signed bool) ([]byte, error) {
+	id, err := uuid.NewRandom()
+	if err != nil {
+		return nil, err
+	}
+
+	if signed {
+		key, signer := c.credentials()
+		params[apiKey] = key
+		params[timestampKey] = currentTimestamp() - c.TimeOffset
+
+		signature, err := signer.Sign(ctx, encodeParams(params))
+		if err != nil {
+			return nil, err
+		}
+		params[signatureKey] = signature
+	}
+
+	wsReq := WsApiRequest{
+		Id:     id.String(),
+		Method: method,
+		Params: params,
+	}
+
+	rawData, err := json.Marshal(wsReq)
+	if err != nil {
+		return nil, err
+	}
+
+	start := time.Now()
+	waiter, err := c.Write(wsReq.Id, rawData)
+	if err != nil {
+		c.observe(method, params, start, 0, err)
+		return nil, err
+	}
+
+	response, status, err := waiter.wait(ctx)
+	if err != nil && status == 0 {
+		// no response is awaited anymore, e.g. the context timed out
+		c.pending.take([]byte(wsReq.Id))
+	}
+	c.observe(method, params, start, status, err)
+	return response, err
+}
+
+// observe records outcome of completed request and reports it to MetricsHandler.
+// Only failures caused by connection, rate limits or server errors count against health.
+func (c *ClientWs) observe(method WsApiMethodType, params params, start time.Time, status int, err error) {
+	c.errorRate.Add(err != nil && (status == 0 || status == 418 || status == 429 || status >= 500))
+
+	if c.MetricsHandler == nil {
+		return
+	}
+	symbol, _ := params["symbol"].(string)
+	c.MetricsHandler(string(method), symbol, time.Since(start), status, err)
+}
+
+// encodeParams builds the canonical query string of params which is signed
+func encodeParams(params params) string {
+	queryValues := url.Values{}
+	for key, value := range params {
+		queryValues.Add(key, fmt.Sprintf("%v", value))
+	}
+	return queryValues.Encode()
+}
+
+// read data from connection
+func (c *ClientWs) read() {
+	defer func() {
+		// reading from closed connection 1000 times caused panic
+		// prevent panic for any case
+		if r := recover(); r != nil {
+		}
+	}()
+
+	for {
+		_, message, err := c.Conn.ReadMessage()
+		if err != nil {
+			if c.isClosed() {
+				return
+			}
+			c.debug("read: error reading message '%v'", message)
+			c.reconnectSignal <- struct{}{}
+
+			c.debug("read: wait to get connected")
+			select {
+			case <-c.connectionEstablishedSignal:
+			case <-c.closed:
+				return
+			}
+
+			c.debug("read: connection established")
+			continue
+		}
+		c.lastMessageAt.Store(time.Now().UnixNano())
+
+		msg := struct {
+			ID     string           `json:"id"`
+			Status int              `json:"status"`
+			Error  *common.APIError `json:"error"`
+		}{}
+		err = json.Unmarshal(message, &msg)
+		if err != nil {
+			c.handleUnhandledMessage(message)
+			continue
+		}
+
+		call := c.pending.take([]byte(msg.ID))
+		if call == nil {
+			c.handleUnhandledMessage(message)
+			continue
+		}
+
+		call.response = message
+		call.status = msg.Status
+		if msg.Error != nil {
+			call.done <- msg.Error
+		} else {
+			call.done <- nil
+		}
+		close(call.done)
+	}
+}
+
+// handleReconnect waits for reconnect signal and starts reconnect
+func (c *ClientWs) handleReconnect() {
+	for {
+		select {
+		case <-c.reconnectSignal:
+		case <-c.closed:
+			return
+		}
+		c.debug("reconnect: received signal")
+		c.reconnecting.Store(true)
+
+		b := &backoff.Backoff{
+			Min:    reconnectMinInterval,
+			Max:    reconnectMaxInterval,
+			Factor: 1.8,
+			Jitter: false,
+		}
+
+		conn := c.startReconnect(b)
+		if conn == nil {
+			return
+		}
+
+		b.Reset()
+
+		c.mu.Lock()
+		if c.isClosed() {
+			// Close has closed the previous connection already
+			c.mu.Unlock()
+			conn.Close()
+			return
+		}
+		c.Conn = conn
+		c.mu.Unlock()
+		c.connectedAt.Store(time.Now().UnixNano())
+		c.reconnecting.Store(false)
+
+		c.debug("reconnect: connected")
+		c.connectionEstablishedSignal <- struct{}{}
+	}
+}
+
+// startReconnect starts reconnect loop with increasing delay, nil once the client is closed
+func (c *ClientWs) startReconnect(b *backoff.Backoff) *websocket.Conn {
+	for {
+		c.reconnectCount.Add(1)
+		conn, err := WsApiInitReadWriteConn()
+		if err != nil {
+			delay := b.Duration()
+			c.debug("reconnect: error while reconnecting. try in %s", delay.Round(time.Millisecond))
+			select {
+			case <-time.After(delay):
+			case <-c.closed:
+				return nil
+			}
+			continue
+		}
+
+		return conn
+	}
+}
+
+// GetReconnectCount returns reconnect counter value (useful for metrics outside)
+func (c *ClientWs) GetReconnectCount() int64 {
+	return c.reconnectCount.Load()
+}
+
+// ConnectionAge returns time passed since the current connection was established.
+// Binance drops websocket API connections after 24 hours.
+func (c *ClientWs) ConnectionAge() time.Duration {
+	return time.Since(time.Unix(0, c.connectedAt.Load()))
+}
+
+// Healthy reports whether the client is ready to serve requests. It fails while the connection
+// is being reestablished, when most of the recent requests failed or when TimeOffset is not sane.
+func (c *ClientWs) Healthy(ctx context.Context) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	if c.reconnecting.Load() {
+		return ErrWsReconnecting
+	}
+	if err := c.errorRate.Check(); err != nil {
+		return err
+	}
+	return common.CheckTimeOffset(c.TimeOffset)
+}
+
+// LastMessageAt returns time the last message was received, zero time if none was received yet
+func (c *ClientWs) LastMessageAt() time.Time {
+	ts := c.lastMessageAt.Load()
+	if ts == 0 {
+		return time.Time{}
+	}
+	return time.Unix(0, ts)
+}
+
+// NewPendingRequests creates request list
+func NewPendingRequests() PendingRequests {
+	return PendingRequests{
+		mu:       sync.Mutex{},
+		requests: make(map[string]*call),
+	}
+}
+
+// PendingRequests state of requests that were sent/received
+type PendingRequests struct {
+	mu       sync.Mutex
+	requests map[string]*call
+}
+
+func (l *PendingRequests) add(id string) *call {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	c := &call{
+		done: make(chan error, 1),
+	}
+	l.requests[id] = c
+	return c
+}
+
+// take returns call of id and removes it, nil if there is none
+func (l *PendingRequests) take(id []byte) *call {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	c, ok := l.requests[string(id)]
+	if ok {
+		delete(l.requests, string(id))
+	}
+	return c
+}
+
+// len returns count of requests waiting for their response
+func (l *PendingRequests) len() int {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	return len(l.requests)
+}
+
+// closeAll removes all calls and completes them with ErrWsConnectionClosed
+func (l *PendingRequests) closeAll() {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	for id, c := range l.requests {
+		delete(l.requests, id)
+		close(c.done)
+	}
+}
+
+func (l *PendingRequests) isAlreadyInList(id string) bool {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	_, ok := l.requests[id]
+	return ok
+}
@@ -0,0 +1,211 @@
+package delivery
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/adshao/go-binance/v2/common"
+)
+
+// baseWsApiTestSuite serves websocket API requests from a local server
+type baseWsApiTestSuite struct {
+	baseTestSuite
+	server      *httptest.Server
+	origGetConn func(cfg *WsConfig) (*websocket.Conn, error)
+	mu          sync.Mutex
+	requests    []WsApiRequest
+	responses   map[WsApiMethodType]string
+	wsClient    *ClientWs
+}
+
+func (s *baseWsApiTestSuite) SetupTest() {
+	s.baseTestSuite.SetupTest()
+	s.requests = nil
+	s.responses = make(map[WsApiMethodType]string)
+
+	upgrader := websocket.Upgrader{}
+	s.server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		conn, err := upgrader.Upgrade(w, r, nil)
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		for {
+			_, message, err := conn.ReadMessage()
+			if err != nil {
+				return
+			}
+			req := WsApiRequest{}
+			if err := json.Unmarshal(message, &req); err != nil {
+				return
+			}
+			s.mu.Lock()
+			s.requests = append(s.requests, req)
+			result, ok := s.responses[req.Method]
+			s.mu.Unlock()
+			if !ok {
+				result = `{"id":"` + req.Id + `","status":400,"error":{"code":-1102,"msg":"unexpected method"}}`
+			} else {
+				result = strings.ReplaceAll(result, "{{id}}", req.Id)
+			}
+			if err := conn.WriteMessage(websocket.TextMessage, []byte(result)); err != nil {
+				return
+			}
+		}
+	}))
+
+	s.origGetConn = WsGetReadWriteConnection
+	endpoint := "ws" + strings.TrimPrefix(s.server.URL, "http")
+	WsGetReadWriteConnection = func(cfg *WsConfig) (*websocket.Conn, error) {
+		c, _, err := websocket.DefaultDialer.Dial(endpoint, nil)
+		return c, err
+	}
+
+	var err error
+	s.wsClient, err = NewClientWs(s.apiKey, s.secretKey)
+	s.r().NoError(err)
+}
+
+func (s *baseWsApiTestSuite) TearDownTest() {
+	WsGetReadWriteConnection = s.origGetConn
+	s.server.Close()
+}
+
+// respond sets result returned for method, '{{id}}' is replaced by the request id
+func (s *baseWsApiTestSuite) respond(method WsApiMethodType, result string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.responses[method] = `{"id":"{{id}}","status":200,"result":` + result + `}`
+}
+
+// lastRequest returns the last request received by the server
+func (s *baseWsApiTestSuite) lastRequest() WsApiRequest {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.r().NotEmpty(s.requests)
+	return s.requests[len(s.requests)-1]
+}
+
+type clientWsTestSuite struct {
+	baseWsApiTestSuite
+}
+
+func TestClientWs(t *testing.T) {
+	suite.Run(t, new(clientWsTestSuite))
+}
+
+func (s *clientWsTestSuite) TestSetCredentials() {
+	s.respond(WsApiMethodOrderStatus, `{"orderId":1,"symbol":"BTCUSD_PERP","status":"NEW"}`)
+	s.wsClient.SetCredentials("rotatedAPIKey", "rotatedSecretKey")
+
+	_, err := s.wsClient.NewOrderStatusWsService().Do(newContext(), NewOrderStatusWsRequest().Symbol("BTCUSD_PERP").OrderID(1))
+	r := s.r()
+	r.NoError(err)
+	r.Equal("rotatedAPIKey", s.lastRequest().Params[apiKey])
+}
+
+func (s *clientWsTestSuite) TestSigner() {
+	s.respond(WsApiMethodOrderStatus, `{"orderId":1,"symbol":"BTCUSD_PERP","status":"NEW"}`)
+
+	var payload string
+	s.wsClient.SetSigner(common.SignerFunc(func(ctx context.Context, p string) (string, error) {
+		payload = p
+		return "remoteSignature", nil
+	}))
+
+	_, err := s.wsClient.NewOrderStatusWsService().Do(newContext(), NewOrderStatusWsRequest().Symbol("BTCUSD_PERP").OrderID(1))
+	r := s.r()
+	r.NoError(err)
+	p := s.lastRequest().Params
+	r.Equal("remoteSignature", p[signatureKey])
+
+	signed, err := url.ParseQuery(payload)
+	r.NoError(err)
+	r.Equal(s.apiKey, signed.Get(apiKey))
+	r.Equal("1", signed.Get("orderId"))
+	r.NotEmpty(signed.Get(timestampKey))
+	r.NotContains(signed, signatureKey)
+}
+
+func (s *clientWsTestSuite) TestMetricsHandler() {
+	s.respond(WsApiMethodOrderStatus, `{"orderId":1,"symbol":"BTCUSD_PERP","status":"NEW"}`)
+
+	type observation struct {
+		method, symbol string
+		status         int
+		err            error
+	}
+	var observed []observation
+	s.wsClient.MetricsHandler = func(method string, symbol string, latency time.Duration, status int, err error) {
+		observed = append(observed, observation{method, symbol, status, err})
+	}
+
+	_, err := s.wsClient.NewOrderStatusWsService().Do(newContext(), NewOrderStatusWsRequest().Symbol("BTCUSD_PERP").OrderID(1))
+	r := s.r()
+	r.NoError(err)
+	_, err = s.wsClient.NewOrderCancelWsService().Do(newContext(), NewOrderCancelWsRequest().Symbol("BTCUSD_PERP").OrderID(1))
+	r.Error(err)
+
+	r.Len(observed, 2)
+	r.Equal(observation{string(WsApiMethodOrderStatus), "BTCUSD_PERP", 200, nil}, observed[0])
+	r.Equal(string(WsApiMethodOrderCancel), observed[1].method)
+	r.Equal(400, observed[1].status)
+	r.Equal(err, observed[1].err)
+}
+
+func (s *clientWsTestSuite) TestCredentialsSigner() {
+	r := s.r()
+	_, signer := s.wsClient.credentials()
+	_, again := s.wsClient.credentials()
+	r.Same(signer, again)
+
+	s.wsClient.SetCredentials("rotatedAPIKey", "rotatedSecretKey")
+	_, rotated := s.wsClient.credentials()
+	r.NotSame(signer, rotated)
+	expected, _ := common.NewHMACSigner("rotatedSecretKey").Sign(context.Background(), "a=1")
+	actual, err := rotated.Sign(context.Background(), "a=1")
+	r.NoError(err)
+	r.Equal(expected, actual)
+}
+
+func (s *clientWsTestSuite) TestClose() {
+	// a request waiting for its response is failed by Close
+	cc := s.wsClient.pending.add("waiting")
+
+	r := s.r()
+	r.NoError(s.wsClient.Close())
+	r.NoError(s.wsClient.Close())
+	_, _, err := waiter{cc}.wait(newContext())
+	r.ErrorIs(err, ErrWsConnectionClosed)
+	r.Zero(s.wsClient.pending.len())
+
+	_, err = s.wsClient.NewOrderStatusWsService().Do(newContext(), NewOrderStatusWsRequest().Symbol("BTCUSD_PERP").OrderID(1))
+	r.ErrorIs(err, ErrWsConnectionClosed)
+	s.Never(func() bool {
+		return s.wsClient.GetReconnectCount() > 0
+	}, 200*time.Millisecond, 10*time.Millisecond)
+}
+
+func (s *clientWsTestSuite) TestTimedOutRequest() {
+	// the response answers another request, so the request times out
+	s.mu.Lock()
+	s.responses[WsApiMethodOrderStatus] = `{"id":"other","status":200,"result":{}}`
+	s.mu.Unlock()
+
+	ctx, cancel := context.WithTimeout(newContext(), 50*time.Millisecond)
+	defer cancel()
+	_, err := s.wsClient.NewOrderStatusWsService().Do(ctx, NewOrderStatusWsRequest().Symbol("BTCUSD_PERP").OrderID(1))
+	r := s.r()
+	r.ErrorIs(err, context.DeadlineExceeded)
+	r.Zero(s.wsClient.pending.len())
+}
@@ -0,0 +1,471 @@
+package delivery
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/adshao/go-binance/v2/common"
+)
+
+const (
+	WsApiMethodOrderPlace  WsApiMethodType = "order.place"
+	WsApiMethodOrderCancel WsApiMethodType = "order.cancel"
+	WsApiMethodOrderModify WsApiMethodType = "order.modify"
+	WsApiMethodOrderStatus WsApiMethodType = "order.status"
+)
+
+// OrderPlaceWsRequest parameters for 'order.place' websocket API
+type OrderPlaceWsRequest struct {
+	symbol           string
+	side             SideType
+	positionSide     *PositionSideType
+	orderType        OrderType
+	timeInForce      *TimeInForceType
+	quantity         string
+	reduceOnly       *bool
+	price            *string
+	newClientOrderID *string
+	stopPrice        *string
+	workingType      *WorkingType
+	activationPrice  *string
+	callbackRate     *string
+	priceProtect     *bool
+	newOrderRespType NewOrderRespType
+	closePosition    *bool
+}
+
+// NewOrderPlaceWsRequest init OrderPlaceWsRequest
+func NewOrderPlaceWsRequest() *OrderPlaceWsRequest {
+	return &OrderPlaceWsRequest{}
+}
+
+// Symbol set symbol
+func (s *OrderPlaceWsRequest) Symbol(symbol string) *OrderPlaceWsRequest {
+	s.symbol = symbol
+	return s
+}
+
+// Side set side
+func (s *OrderPlaceWsRequest) Side(side SideType) *OrderPlaceWsRequest {
+	s.side = side
+	return s
+}
+
+// PositionSide set side
+func (s *OrderPlaceWsRequest) PositionSide(positionSide PositionSideType) *OrderPlaceWsRequest {
+	s.positionSide = &positionSide
+	return s
+}
+
+// Type set type
+func (s *OrderPlaceWsRequest) Type(orderType OrderType) *OrderPlaceWsRequest {
+	s.orderType = orderType
+	return s
+}
+
+// TimeInForce set timeInForce
+func (s *OrderPlaceWsRequest) TimeInForce(timeInForce TimeInForceType) *OrderPlaceWsRequest {
+	s.timeInForce = &timeInForce
+	return s
+}
+
+// Quantity set quantity in contracts
+func (s *OrderPlaceWsRequest) Quantity(quantity string) *OrderPlaceWsRequest {
+	s.quantity = quantity
+	return s
+}
+
+// ReduceOnly set reduceOnly
+func (s *OrderPlaceWsRequest) ReduceOnly(reduceOnly bool) *OrderPlaceWsRequest {
+	s.reduceOnly = &reduceOnly
+	return s
+}
+
+// Price set price
+func (s *OrderPlaceWsRequest) Price(price string) *OrderPlaceWsRequest {
+	s.price = &price
+	return s
+}
+
+// NewClientOrderID set newClientOrderID
+func (s *OrderPlaceWsRequest) NewClientOrderID(newClientOrderID string) *OrderPlaceWsRequest {
+	s.newClientOrderID = &newClientOrderID
+	return s
+}
+
+// StopPrice set stopPrice
+func (s *OrderPlaceWsRequest) StopPrice(stopPrice string) *OrderPlaceWsRequest {
+	s.stopPrice = &stopPrice
+	return s
+}
+
+// WorkingType set workingType
+func (s *OrderPlaceWsRequest) WorkingType(workingType WorkingType) *OrderPlaceWsRequest {
+	s.workingType = &workingType
+	return s
+}
+
+// ActivationPrice set activationPrice
+func (s *OrderPlaceWsRequest) ActivationPrice(activationPrice string) *OrderPlaceWsRequest {
+	s.activationPrice = &activationPrice
+	return s
+}
+
+// CallbackRate set callbackRate
+func (s *OrderPlaceWsRequest) CallbackRate(callbackRate string) *OrderPlaceWsRequest {
+	s.callbackRate = &callbackRate
+	return s
+}
+
+// PriceProtect set priceProtect
+func (s *OrderPlaceWsRequest) PriceProtect(priceProtect bool) *OrderPlaceWsRequest {
+	s.priceProtect = &priceProtect
+	return s
+}
+
+// NewOrderResponseType set newOrderResponseType
+func (s *OrderPlaceWsRequest) NewOrderResponseType(newOrderResponseType NewOrderRespType) *OrderPlaceWsRequest {
+	s.newOrderRespType = newOrderResponseType
+	return s
+}
+
+// ClosePosition set closePosition
+func (s *OrderPlaceWsRequest) ClosePosition(closePosition bool) *OrderPlaceWsRequest {
+	s.closePosition = &closePosition
+	return s
+}
+
+// buildParams builds params
+func (s *OrderPlaceWsRequest) buildParams() params {
+	m := params{
+		"symbol": s.symbol,
+		"side":   s.side,
+		"type":   s.orderType,
+	}
+	if s.newOrderRespType != "" {
+		m["newOrderRespType"] = s.newOrderRespType
+	}
+	if s.quantity != "" {
+		m["quantity"] = s.quantity
+	}
+	if s.positionSide != nil {
+		m["positionSide"] = *s.positionSide
+	}
+	if s.timeInForce != nil {
+		m["timeInForce"] = *s.timeInForce
+	}
+	if s.reduceOnly != nil {
+		m["reduceOnly"] = *s.reduceOnly
+	}
+	if s.price != nil {
+		m["price"] = *s.price
+	}
+	if s.newClientOrderID != nil {
+		m["newClientOrderId"] = *s.newClientOrderID
+	}
+	if s.stopPrice != nil {
+		m["stopPrice"] = *s.stopPrice
+	}
+	if s.workingType != nil {
+		m["workingType"] = *s.workingType
+	}
+	if s.priceProtect != nil {
+		m["priceProtect"] = *s.priceProtect
+	}
+	if s.activationPrice != nil {
+		m["activationPrice"] = *s.activationPrice
+	}
+	if s.callbackRate != nil {
+		m["callbackRate"] = *s.callbackRate
+	}
+	if s.closePosition != nil {
+		m["closePosition"] = *s.closePosition
+	}
+	return m
+}
+
+// CreateOrderWsResponse define 'order.place' websocket API response
+type CreateOrderWsResponse struct {
+	Id     string               `json:"id"`
+	Status int                  `json:"status"`
+	Result *CreateOrderResponse `json:"result"`
+
+	// error response
+	Error *common.APIError `json:"error,omitempty"`
+}
+
+// OrderPlaceWsService creates order
+type OrderPlaceWsService struct {
+	c *ClientWs
+}
+
+// NewOrderPlaceWsService init OrderPlaceWsService sharing the client connection
+func (c *ClientWs) NewOrderPlaceWsService() *OrderPlaceWsService {
+	return &OrderPlaceWsService{c: c}
+}
+
+// Do - sends 'order.place' request
+func (s *OrderPlaceWsService) Do(ctx context.Context, req *OrderPlaceWsRequest) (*CreateOrderResponse, error) {
+	rawResp, err := s.c.doRequest(ctx, WsApiMethodOrderPlace, req.buildParams(), true)
+	if err != nil {
+		return nil, err
+	}
+
+	res := CreateOrderWsResponse{}
+	if err := json.Unmarshal(rawResp, &res); err != nil {
+		return nil, err
+	}
+	return res.Result, nil
+}
+
+// OrderCancelWsRequest parameters for 'order.cancel' websocket API
+type OrderCancelWsRequest struct {
+	symbol            string
+	orderID           *int64
+	origClientOrderID *string
+}
+
+// NewOrderCancelWsRequest init OrderCancelWsRequest
+func NewOrderCancelWsRequest() *OrderCancelWsRequest {
+	return &OrderCancelWsRequest{}
+}
+
+// Symbol set symbol
+func (s *OrderCancelWsRequest) Symbol(symbol string) *OrderCancelWsRequest {
+	s.symbol = symbol
+	return s
+}
+
+// OrderID set orderID
+func (s *OrderCancelWsRequest) OrderID(orderID int64) *OrderCancelWsRequest {
+	s.orderID = &orderID
+	return s
+}
+
+// OrigClientOrderID set origClientOrderID
+func (s *OrderCancelWsRequest) OrigClientOrderID(origClientOrderID string) *OrderCancelWsRequest {
+	s.origClientOrderID = &origClientOrderID
+	return s
+}
+
+// buildParams builds params
+func (s *OrderCancelWsRequest) buildParams() params {
+	m := params{
+		"symbol": s.symbol,
+	}
+	if s.orderID != nil {
+		m["orderId"] = *s.orderID
+	}
+	if s.origClientOrderID != nil {
+		m["origClientOrderId"] = *s.origClientOrderID
+	}
+	return m
+}
+
+// CancelOrderWsResponse define 'order.cancel' websocket API response
+type CancelOrderWsResponse struct {
+	Id     string               `json:"id"`
+	Status int                  `json:"status"`
+	Result *CancelOrderResponse `json:"result"`
+
+	// error response
+	Error *common.APIError `json:"error,omitempty"`
+}
+
+// OrderCancelWsService cancel order
+type OrderCancelWsService struct {
+	c *ClientWs
+}
+
+// NewOrderCancelWsService init OrderCancelWsService sharing the client connection
+func (c *ClientWs) NewOrderCancelWsService() *OrderCancelWsService {
+	return &OrderCancelWsService{c: c}
+}
+
+// Do - sends 'order.cancel' request
+func (s *OrderCancelWsService) Do(ctx context.Context, req *OrderCancelWsRequest) (*CancelOrderResponse, error) {
+	rawResp, err := s.c.doRequest(ctx, WsApiMethodOrderCancel, req.buildParams(), true)
+	if err != nil {
+		return nil, err
+	}
+
+	res := CancelOrderWsResponse{}
+	if err := json.Unmarshal(rawResp, &res); err != nil {
+		return nil, err
+	}
+	return res.Result, nil
+}
+
+// OrderModifyWsRequest parameters for 'order.modify' websocket API
+type OrderModifyWsRequest struct {
+	symbol            string
+	side              SideType
+	orderID           *int64
+	origClientOrderID *string
+	quantity          *string
+	price             *string
+}
+
+// NewOrderModifyWsRequest init OrderModifyWsRequest
+func NewOrderModifyWsRequest() *OrderModifyWsRequest {
+	return &OrderModifyWsRequest{}
+}
+
+// Symbol set symbol
+func (s *OrderModifyWsRequest) Symbol(symbol string) *OrderModifyWsRequest {
+	s.symbol = symbol
+	return s
+}
+
+// Side set side
+func (s *OrderModifyWsRequest) Side(side SideType) *OrderModifyWsRequest {
+	s.side = side
+	return s
+}
+
+// OrderID set orderID
+func (s *OrderModifyWsRequest) OrderID(orderID int64) *OrderModifyWsRequest {
+	s.orderID = &orderID
+	return s
+}
+
+// OrigClientOrderID set origClientOrderID
+func (s *OrderModifyWsRequest) OrigClientOrderID(origClientOrderID string) *OrderModifyWsRequest {
+	s.origClientOrderID = &origClientOrderID
+	return s
+}
+
+// Quantity set quantity in contracts
+func (s *OrderModifyWsRequest) Quantity(quantity string) *OrderModifyWsRequest {
+	s.quantity = &quantity
+	return s
+}
+
+// Price set price
+func (s *OrderModifyWsRequest) Price(price string) *OrderModifyWsRequest {
+	s.price = &price
+	return s
+}
+
+// buildParams builds params
+func (s *OrderModifyWsRequest) buildParams() params {
+	m := params{
+		"symbol": s.symbol,
+		"side":   s.side,
+	}
+	if s.orderID != nil {
+		m["orderId"] = *s.orderID
+	}
+	if s.origClientOrderID != nil {
+		m["origClientOrderId"] = *s.origClientOrderID
+	}
+	if s.quantity != nil {
+		m["quantity"] = *s.quantity
+	}
+	if s.price != nil {
+		m["price"] = *s.price
+	}
+	return m
+}
+
+// OrderWsResponse define websocket API response holding a single order
+type OrderWsResponse struct {
+	Id     string `json:"id"`
+	Status int    `json:"status"`
+	Result *Order `json:"result"`
+
+	// error response
+	Error *common.APIError `json:"error,omitempty"`
+}
+
+// OrderModifyWsService modify price/quantity of a LIMIT order
+type OrderModifyWsService struct {
+	c *ClientWs
+}
+
+// NewOrderModifyWsService init OrderModifyWsService sharing the client connection
+func (c *ClientWs) NewOrderModifyWsService() *OrderModifyWsService {
+	return &OrderModifyWsService{c: c}
+}
+
+// Do - sends 'order.modify' request
+func (s *OrderModifyWsService) Do(ctx context.Context, req *OrderModifyWsRequest) (*Order, error) {
+	rawResp, err := s.c.doRequest(ctx, WsApiMethodOrderModify, req.buildParams(), true)
+	if err != nil {
+		return nil, err
+	}
+
+	res := OrderWsResponse{}
+	if err := json.Unmarshal(rawResp, &res); err != nil {
+		return nil, err
+	}
+	return res.Result, nil
+}
+
+// OrderStatusWsRequest parameters for 'order.status' websocket API
+type OrderStatusWsRequest struct {
+	symbol            string
+	orderID           *int64
+	origClientOrderID *string
+}
+
+// NewOrderStatusWsRequest init OrderStatusWsRequest
+func NewOrderStatusWsRequest() *OrderStatusWsRequest {
+	return &OrderStatusWsRequest{}
+}
+
+// Symbol set symbol
+func (s *OrderStatusWsRequest) Symbol(symbol string) *OrderStatusWsRequest {
+	s.symbol = symbol
+	return s
+}
+
+// OrderID set orderID
+func (s *OrderStatusWsRequest) OrderID(orderID int64) *OrderStatusWsRequest {
+	s.orderID = &orderID
+	return s
+}
+
+// OrigClientOrderID set origClientOrderID
+func (s *OrderStatusWsRequest) OrigClientOrderID(origClientOrderID string) *OrderStatusWsRequest {
+	s.origClientOrderID = &origClientOrderID
+	return s
+}
+
+// buildParams builds params
+func (s *OrderStatusWsRequest) buildParams() params {
+	m := params{
+		"symbol": s.symbol,
+	}
+	if s.orderID != nil {
+		m["orderId"] = *s.orderID
+	}
+	if s.origClientOrderID != nil {
+		m["origClientOrderId"] = *s.origClientOrderID
+	}
+	return m
+}
+
+// OrderStatusWsService query order
+type OrderStatusWsService struct {
+	c *ClientWs
+}
+
+// NewOrderStatusWsService init OrderStatusWsService sharing the client connection
+func (c *ClientWs) NewOrderStatusWsService() *OrderStatusWsService {
+	return &OrderStatusWsService{c: c}
+}
+
+// Do - sends 'order.status' request
+func (s *OrderStatusWsService) Do(ctx context.Context, req *OrderStatusWsRequest) (*Order, error) {
+	rawResp, err := s.c.doRequest(ctx, WsApiMethodOrderStatus, req.buildParams(), true)
+	if err != nil {
+		return nil, err
+	}
+
+	res := OrderWsResponse{}
+	if err := json.Unmarshal(rawResp, &res); err != nil {
+		return nil, err
+	}
+	return res.Result, nil
+}
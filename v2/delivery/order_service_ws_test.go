@@ -0,0 +1,106 @@
+package delivery
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type orderWsServiceTestSuite struct {
+	baseWsApiTestSuite
+}
+
+func TestOrderWsService(t *testing.T) {
+	suite.Run(t, new(orderWsServiceTestSuite))
+}
+
+func (s *orderWsServiceTestSuite) TestOrderPlace() {
+	s.respond(WsApiMethodOrderPlace, `{
+		"orderId": 328999071,
+		"symbol": "BTCUSD_PERP",
+		"pair": "BTCUSD",
+		"status": "NEW",
+		"clientOrderId": "ArY8Ng1rln0s9x3fclmAHy",
+		"price": "58000",
+		"avgPrice": "0.00",
+		"origQty": "1",
+		"executedQty": "0",
+		"cumQty": "0",
+		"cumBase": "0",
+		"timeInForce": "GTC",
+		"type": "LIMIT",
+		"reduceOnly": false,
+		"closePosition": false,
+		"side": "BUY",
+		"positionSide": "BOTH",
+		"stopPrice": "0",
+		"workingType": "CONTRACT_PRICE",
+		"priceProtect": false,
+		"origType": "LIMIT",
+		"updateTime": 1728416138285
+	}`)
+
+	res, err := s.wsClient.NewOrderPlaceWsService().Do(newContext(), NewOrderPlaceWsRequest().
+		Symbol("BTCUSD_PERP").Side(SideTypeBuy).Type(OrderTypeLimit).TimeInForce(TimeInForceTypeGTC).
+		Quantity("1").Price("58000"))
+	r := s.r()
+	r.NoError(err)
+	r.Equal(int64(328999071), res.OrderID)
+	r.Equal("BTCUSD", res.Pair)
+	r.Equal(OrderStatusTypeNew, res.Status)
+	r.Equal(PositionSideTypeBoth, res.PositionSide)
+
+	p := s.lastRequest().Params
+	r.Equal("BTCUSD_PERP", p["symbol"])
+	r.Equal("BUY", p["side"])
+	r.Equal("LIMIT", p["type"])
+	r.Equal("GTC", p["timeInForce"])
+	r.Equal("1", p["quantity"])
+	r.Equal("58000", p["price"])
+	r.NotContains(p, "newOrderRespType")
+	r.Equal(s.apiKey, p[apiKey])
+	r.NotEmpty(p[signatureKey])
+}
+
+func (s *orderWsServiceTestSuite) TestOrderCancel() {
+	s.respond(WsApiMethodOrderCancel, `{"orderId":328999071,"symbol":"BTCUSD_PERP","status":"CANCELED","origQty":"1"}`)
+
+	res, err := s.wsClient.NewOrderCancelWsService().Do(newContext(), NewOrderCancelWsRequest().
+		Symbol("BTCUSD_PERP").OrderID(328999071))
+	r := s.r()
+	r.NoError(err)
+	r.Equal(OrderStatusTypeCanceled, res.Status)
+	r.Equal(float64(328999071), s.lastRequest().Params["orderId"])
+}
+
+func (s *orderWsServiceTestSuite) TestOrderModify() {
+	s.respond(WsApiMethodOrderModify, `{"orderId":328971409,"symbol":"BTCUSD_PERP","status":"NEW","price":"59000","origQty":"2"}`)
+
+	res, err := s.wsClient.NewOrderModifyWsService().Do(newContext(), NewOrderModifyWsRequest().
+		Symbol("BTCUSD_PERP").Side(SideTypeBuy).OrigClientOrderID("myOrder").Quantity("2").Price("59000"))
+	r := s.r()
+	r.NoError(err)
+	r.Equal("59000", res.Price)
+	r.Equal("2", res.OrigQuantity)
+
+	p := s.lastRequest().Params
+	r.Equal("myOrder", p["origClientOrderId"])
+	r.Equal("2", p["quantity"])
+	r.Equal("59000", p["price"])
+	r.NotContains(p, "orderId")
+}
+
+func (s *orderWsServiceTestSuite) TestOrderStatus() {
+	s.respond(WsApiMethodOrderStatus, `{"orderId":328999071,"symbol":"BTCUSD_PERP","status":"NEW","time":1728416138285}`)
+
+	res, err := s.wsClient.NewOrderStatusWsService().Do(newContext(), NewOrderStatusWsRequest().
+		Symbol("BTCUSD_PERP").OrderID(328999071))
+	r := s.r()
+	r.NoError(err)
+	r.Equal(int64(1728416138285), res.Time)
+}
+
+func (s *orderWsServiceTestSuite) TestError() {
+	_, err := s.wsClient.NewOrderStatusWsService().Do(newContext(), NewOrderStatusWsRequest().Symbol("BTCUSD_PERP"))
+	s.r().Error(err)
+}
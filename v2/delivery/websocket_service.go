@@ -6,12 +6,17 @@ import (
 	"fmt"
 	"strings"
 	"time"
+
+	"github.com/gorilla/websocket"
 )
 
 // Endpoints
 const (
 	baseWsMainUrl    = "wss://dstream.binance.com/ws"
 	baseWsTestnetUrl = "wss://dstream.binancefuture.com/ws"
+
+	BaseWsApiMainURL    = "wss://ws-dapi.binance.com/ws-dapi/v1"
+	BaseWsApiTestnetURL = "wss://testnet.binancefuture.com/ws-dapi/v1"
 )
 
 var (
@@ -21,6 +26,9 @@ var (
 	WebsocketKeepalive = false
 	// UseTestnet switch all the WS streams from production to the testnet
 	UseTestnet = false
+	// WebsocketTimeoutReadWriteConnection is an interval for sending ping/pong messages if WebsocketKeepalive is enabled
+	// using for websocket API (read/write)
+	WebsocketTimeoutReadWriteConnection = time.Second * 10
 )
 
 // getWsEndpoint return the base endpoint of the WS according the UseTestnet flag
@@ -748,3 +756,22 @@ func WsUserDataServe(listenKey string, handler WsUserDataHandler, errHandler Err
 	}
 	return wsServe(cfg, wsHandler, errHandler)
 }
+
+// WsApiInitReadWriteConn create and serve connection
+func WsApiInitReadWriteConn() (*websocket.Conn, error) {
+	cfg := newWsConfig(getWsApiEndpoint())
+	conn, err := WsGetReadWriteConnection(cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	return conn, err
+}
+
+// getWsApiEndpoint return the base endpoint of the API WS according the UseTestnet flag
+func getWsApiEndpoint() string {
+	if UseTestnet {
+		return BaseWsApiTestnetURL
+	}
+	return BaseWsApiMainURL
+}
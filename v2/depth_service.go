@@ -4,6 +4,8 @@ import (
 	"context"
 	"net/http"
 
+	"github.com/bitly/go-simplejson"
+
 	"github.com/adshao/go-binance/v2/common"
 )
 
@@ -44,7 +46,12 @@ func (s *DepthService) Do(ctx context.Context, opts ...RequestOption) (res *Dept
 	if err != nil {
 		return nil, err
 	}
-	res = new(DepthResponse)
+	return newDepthResponse(j), nil
+}
+
+// newDepthResponse parses depth snapshot shared by REST and websocket API
+func newDepthResponse(j *simplejson.Json) *DepthResponse {
+	res := new(DepthResponse)
 	res.LastUpdateID = j.Get("lastUpdateId").MustInt64()
 	bidsLen := len(j.Get("bids").MustArray())
 	res.Bids = make([]Bid, bidsLen)
@@ -64,7 +71,7 @@ func (s *DepthService) Do(ctx context.Context, opts ...RequestOption) (res *Dept
 			Quantity: item.GetIndex(1).MustString(),
 		}
 	}
-	return res, nil
+	return res
 }
 
 // DepthResponse define depth info with bids and asks
@@ -0,0 +1,90 @@
+package binance
+
+import (
+	"context"
+
+	"github.com/adshao/go-binance/v2/common"
+)
+
+const (
+	WsApiMethodExchangeInfo WsApiMethodType = "exchangeInfo"
+)
+
+// ExchangeInfoWsRequest parameters for 'exchangeInfo' websocket API
+type ExchangeInfoWsRequest struct {
+	symbol      string
+	symbols     []string
+	permissions []string
+}
+
+// NewExchangeInfoWsRequest init ExchangeInfoWsRequest
+func NewExchangeInfoWsRequest() *ExchangeInfoWsRequest {
+	return &ExchangeInfoWsRequest{}
+}
+
+// Symbol set symbol
+func (s *ExchangeInfoWsRequest) Symbol(symbol string) *ExchangeInfoWsRequest {
+	s.symbol = symbol
+	return s
+}
+
+// Symbols set symbols
+func (s *ExchangeInfoWsRequest) Symbols(symbols ...string) *ExchangeInfoWsRequest {
+	s.symbols = symbols
+	return s
+}
+
+// Permissions set permissions
+func (s *ExchangeInfoWsRequest) Permissions(permissions ...string) *ExchangeInfoWsRequest {
+	s.permissions = permissions
+	return s
+}
+
+// buildParams builds params
+func (s *ExchangeInfoWsRequest) buildParams() params {
+	m := params{}
+	if s.symbol != "" {
+		m["symbol"] = s.symbol
+	}
+	if len(s.symbols) != 0 {
+		m["symbols"] = s.symbols
+	}
+	if len(s.permissions) != 0 {
+		m["permissions"] = s.permissions
+	}
+	return m
+}
+
+// ExchangeInfoWsResponse define 'exchangeInfo' websocket API response
+type ExchangeInfoWsResponse struct {
+	Id     string        `json:"id"`
+	Status int           `json:"status"`
+	Result *ExchangeInfo `json:"result"`
+
+	// error response
+	Error *common.APIError `json:"error,omitempty"`
+}
+
+// ExchangeInfoWsService exchange info over websocket API
+type ExchangeInfoWsService struct {
+	c *ClientWs
+}
+
+// NewExchangeInfoWsService init ExchangeInfoWsService sharing the client connection
+func (c *ClientWs) NewExchangeInfoWsService() *ExchangeInfoWsService {
+	return &ExchangeInfoWsService{c: c}
+}
+
+// Do - sends 'exchangeInfo' request
+func (s *ExchangeInfoWsService) Do(ctx context.Context, req *ExchangeInfoWsRequest) (*ExchangeInfo, error) {
+	rawResp, err := s.c.doRequest(ctx, WsApiMethodExchangeInfo, req.buildParams(), false)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := ExchangeInfoWsResponse{}
+	if err := json.Unmarshal(rawResp, &resp); err != nil {
+		return nil, err
+	}
+	return resp.Result, nil
+}
@@ -0,0 +1,47 @@
+package binance
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type exchangeInfoWsServiceTestSuite struct {
+	baseWsApiTestSuite
+}
+
+func TestExchangeInfoWsService(t *testing.T) {
+	suite.Run(t, new(exchangeInfoWsServiceTestSuite))
+}
+
+func (s *exchangeInfoWsServiceTestSuite) TestExchangeInfo() {
+	s.respond(WsApiMethodExchangeInfo, `{
+		"timezone": "UTC",
+		"serverTime": 1655969291181,
+		"rateLimits": [{"rateLimitType": "REQUEST_WEIGHT", "interval": "MINUTE", "intervalNum": 1, "limit": 6000}],
+		"exchangeFilters": [],
+		"symbols": [{
+			"symbol": "BNBBTC",
+			"status": "TRADING",
+			"baseAsset": "BNB",
+			"baseAssetPrecision": 8,
+			"quoteAsset": "BTC",
+			"quotePrecision": 8,
+			"orderTypes": ["LIMIT", "MARKET"],
+			"filters": [{"filterType": "PRICE_FILTER", "minPrice": "0.00000100", "maxPrice": "100000.00000000", "tickSize": "0.00000100"}]
+		}]
+	}`)
+
+	res, err := s.wsClient.NewExchangeInfoWsService().Do(newContext(), NewExchangeInfoWsRequest().Symbols("BNBBTC", "BTCUSDT"))
+	r := s.r()
+	r.NoError(err)
+	r.Equal("UTC", res.Timezone)
+	r.Equal(int64(1655969291181), res.ServerTime)
+	r.Len(res.RateLimits, 1)
+	r.Equal(int64(6000), res.RateLimits[0].Limit)
+	r.Len(res.Symbols, 1)
+	r.Equal("BNBBTC", res.Symbols[0].Symbol)
+	r.Equal("0.00000100", res.Symbols[0].PriceFilter().TickSize)
+
+	r.Equal(params{"symbols": []interface{}{"BNBBTC", "BTCUSDT"}}, s.lastRequest().Params)
+}
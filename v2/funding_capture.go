@@ -0,0 +1,488 @@
+package binance
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"time"
+
+	"github.com/adshao/go-binance/v2/common"
+	"github.com/adshao/go-binance/v2/futures"
+)
+
+const (
+	defaultFundingCaptureEntryBefore = time.Minute
+	defaultFundingCaptureExitAfter   = time.Minute
+	// fundingCapturePollInterval is how often the next funding time is queried while the
+	// funding whose entry time was missed is still published
+	fundingCapturePollInterval = time.Second
+)
+
+var (
+	ErrFundingCaptureQuantityTooSmall = errors.New("funding capture: notional is below lot size")
+	ErrFundingCaptureNotFilled        = errors.New("funding capture: entry orders not filled")
+	ErrFundingCaptureFailed           = errors.New("funding capture: capture failed")
+	ErrFundingCaptureSymbolNotFound   = errors.New("funding capture: symbol not found")
+)
+
+// FundingCaptureStep define step of a FundingCapture
+type FundingCaptureStep string
+
+// Steps of a FundingCapture, in order
+const (
+	// FundingCaptureStepPending waits for the entry time
+	FundingCaptureStepPending FundingCaptureStep = ""
+	// FundingCaptureStepOpening sends the entry orders, their outcome is unknown until verified
+	FundingCaptureStepOpening FundingCaptureStep = "OPENING"
+	// FundingCaptureStepHedged holds both positions until funding is settled
+	FundingCaptureStepHedged FundingCaptureStep = "HEDGED"
+	// FundingCaptureStepUnwinding sends the exit orders, also after an entry that was not filled
+	FundingCaptureStepUnwinding FundingCaptureStep = "UNWINDING"
+	FundingCaptureStepDone      FundingCaptureStep = "DONE"
+	// FundingCaptureStepFailed means the entry failed and what was executed was unwound
+	FundingCaptureStepFailed FundingCaptureStep = "FAILED"
+)
+
+// FundingCaptureState define resumable state of a FundingCapture, persist it on each
+// change and pass it back to Run after a restart
+type FundingCaptureState struct {
+	// ID prefixes the client order ids of the capture
+	ID     string             `json:"id"`
+	Symbol string             `json:"symbol"`
+	Step   FundingCaptureStep `json:"step"`
+	// FundingTime is the funding time captured in milliseconds
+	FundingTime     int64  `json:"fundingTime"`
+	Quantity        string `json:"quantity"`
+	SpotExecutedQty string `json:"spotExecutedQty"`
+	PerpExecutedQty string `json:"perpExecutedQty"`
+	FundingFee      string `json:"fundingFee"`
+	SpotUnwindQty   string `json:"spotUnwindQty"`
+	PerpUnwindQty   string `json:"perpUnwindQty"`
+	// Aborted is set when the entry was not filled and executed quantities are unwound
+	Aborted          bool   `json:"aborted"`
+	LastErrorMessage string `json:"lastErrorMessage"`
+}
+
+// client order ids of the entry and exit orders
+func (s *FundingCaptureState) spotEntryID() string { return s.ID + "-os" }
+func (s *FundingCaptureState) perpEntryID() string { return s.ID + "-op" }
+func (s *FundingCaptureState) spotExitID() string  { return s.ID + "-cs" }
+func (s *FundingCaptureState) perpExitID() string  { return s.ID + "-cp" }
+
+// FundingCaptureParams define parameters of a FundingCapture
+type FundingCaptureParams struct {
+	// Symbol is traded on both spot and USDⓈ-M futures, e.g. BTCUSDT
+	Symbol string
+	// Notional is the quote amount of each leg, converted to quantity with the mark price
+	Notional float64
+	// EntryBefore is how long before funding positions are opened, default 1 minute
+	EntryBefore time.Duration
+	// ExitAfter is how long after funding positions are closed, default 1 minute
+	ExitAfter time.Duration
+}
+
+// FundingCaptureStateHandler persists FundingCaptureState, an error stops the capture
+type FundingCaptureStateHandler func(state FundingCaptureState) error
+
+// FundingCapture buys spot and sells the perpetual of the same quantity shortly before funding,
+// collecting positive funding while hedged, then closes both after funding settlement.
+// Each step is reported to the state handler before it acts, so a capture interrupted by a
+// crash is resumed by passing the last state to Run. Spot fees taken in the base asset are not
+// accounted for, hold BNB for fees so the whole executed quantity can be sold back.
+type FundingCapture struct {
+	c       *Client
+	fc      *futures.Client
+	params  FundingCaptureParams
+	handler FundingCaptureStateHandler
+}
+
+// NewFundingCapture init FundingCapture trading spot with c and futures with fc
+func NewFundingCapture(c *Client, fc *futures.Client, params FundingCaptureParams) *FundingCapture {
+	if params.EntryBefore <= 0 {
+		params.EntryBefore = defaultFundingCaptureEntryBefore
+	}
+	if params.ExitAfter <= 0 {
+		params.ExitAfter = defaultFundingCaptureExitAfter
+	}
+	return &FundingCapture{c: c, fc: fc, params: params}
+}
+
+// OnState set handler persisting state changes
+func (f *FundingCapture) OnState(handler FundingCaptureStateHandler) *FundingCapture {
+	f.handler = handler
+	return f
+}
+
+// Run runs the capture from state until it is done or failed, pass the zero value to start a
+// new capture of the next funding. The returned state is the last one reached, resume from it
+// if an error interrupted a step.
+func (f *FundingCapture) Run(ctx context.Context, state FundingCaptureState) (FundingCaptureState, error) {
+	if state.ID == "" {
+		state.ID = "fc" + strconv.FormatInt(time.Now().UnixNano(), 36)
+		state.Symbol = f.params.Symbol
+	}
+	for {
+		var err error
+		switch state.Step {
+		case FundingCaptureStepPending:
+			err = f.open(ctx, &state)
+		case FundingCaptureStepOpening:
+			err = f.verify(ctx, &state)
+		case FundingCaptureStepHedged:
+			err = f.settle(ctx, &state)
+		case FundingCaptureStepUnwinding:
+			err = f.unwind(ctx, &state)
+		case FundingCaptureStepDone:
+			return state, nil
+		case FundingCaptureStepFailed:
+			return state, ErrFundingCaptureFailed
+		}
+		if err != nil {
+			return state, err
+		}
+	}
+}
+
+// open waits for the entry time then places both entry orders. If the entry time of the funding
+// already passed, the next funding whose entry time is ahead is captured instead.
+func (f *FundingCapture) open(ctx context.Context, state *FundingCaptureState) error {
+	if state.FundingTime == 0 || !time.Now().Before(f.entryTime(state.FundingTime)) {
+		fundingTime, err := f.nextFundingTime(ctx, state.Symbol, state.FundingTime)
+		if err != nil {
+			return err
+		}
+		state.FundingTime = fundingTime
+	}
+	if err := sleepUntil(ctx, f.entryTime(state.FundingTime)); err != nil {
+		return err
+	}
+
+	index, err := f.premiumIndex(ctx, state.Symbol)
+	if err != nil {
+		return err
+	}
+	quantity, err := f.quantity(ctx, state.Symbol, index.MarkPrice)
+	if err != nil {
+		return err
+	}
+	state.Quantity = quantity
+	if err = f.setStep(state, FundingCaptureStepOpening); err != nil {
+		return err
+	}
+
+	spotLeg, perpLeg := f.entryLegs(state)
+	res, err := NewOrderGroup(spotLeg, perpLeg).Do(ctx)
+	if err == nil {
+		return nil
+	}
+	if unwindErr := errors.Join(res.UnwindErrs...); unwindErr != nil {
+		// legs left executed are unwound by the exit step once verified, legs the group unwound
+		// are not unwound again
+		err = errors.Join(err, unwindErr)
+		state.Aborted = true
+		if res.Unwound[0] && res.UnwindErrs[0] == nil {
+			state.SpotUnwindQty = "0"
+			if order := spotLeg.UnwindOrder(); order != nil {
+				state.SpotUnwindQty = order.ExecutedQuantity
+			}
+		}
+		if res.Unwound[1] && res.UnwindErrs[1] == nil {
+			state.PerpUnwindQty = "0"
+			if order := perpLeg.UnwindOrder(); order != nil {
+				state.PerpUnwindQty = order.ExecutedQuantity
+			}
+		}
+		state.LastErrorMessage = err.Error()
+		if stepErr := f.setStep(state, FundingCaptureStepOpening); stepErr != nil {
+			return stepErr
+		}
+		return err
+	}
+	state.LastErrorMessage = err.Error()
+	if stepErr := f.setStep(state, FundingCaptureStepFailed); stepErr != nil {
+		return stepErr
+	}
+	return err
+}
+
+// verify checks both entry orders are filled. Otherwise, or if the entry was aborted after the
+// order group failed to unwind it, orders still open are canceled and the executed quantities
+// are unwound through the exit step, after which the capture fails.
+func (f *FundingCapture) verify(ctx context.Context, state *FundingCaptureState) error {
+	spotOrder, err := f.spotEntry(ctx, state)
+	if err != nil {
+		return err
+	}
+	perpOrder, err := f.perpEntry(ctx, state)
+	if err != nil {
+		return err
+	}
+	if !state.Aborted && spotOrder != nil && spotOrder.Status == OrderStatusTypeFilled &&
+		perpOrder != nil && perpOrder.Status == futures.OrderStatusTypeFilled {
+		state.SpotExecutedQty, state.PerpExecutedQty = spotOrder.ExecutedQuantity, perpOrder.ExecutedQuantity
+		return f.setStep(state, FundingCaptureStepHedged)
+	}
+
+	if spotOrder != nil && (spotOrder.Status == OrderStatusTypeNew || spotOrder.Status == OrderStatusTypePartiallyFilled) {
+		_, err = f.c.NewCancelOrderService().Symbol(state.Symbol).OrigClientOrderID(state.spotEntryID()).Do(ctx)
+		if err != nil && !common.IsAPIErrorCode(err, errCodeUnknownOrder) {
+			return err
+		}
+		if spotOrder, err = f.spotEntry(ctx, state); err != nil {
+			return err
+		}
+	}
+	if perpOrder != nil && (perpOrder.Status == futures.OrderStatusTypeNew || perpOrder.Status == futures.OrderStatusTypePartiallyFilled) {
+		_, err = f.fc.NewCancelOrderService().Symbol(state.Symbol).OrigClientOrderID(state.perpEntryID()).Do(ctx)
+		if err != nil && !common.IsAPIErrorCode(err, errCodeUnknownOrder) {
+			return err
+		}
+		if perpOrder, err = f.perpEntry(ctx, state); err != nil {
+			return err
+		}
+	}
+	state.SpotExecutedQty, state.PerpExecutedQty = "", ""
+	if spotOrder != nil {
+		state.SpotExecutedQty = spotOrder.ExecutedQuantity
+	}
+	if perpOrder != nil {
+		state.PerpExecutedQty = perpOrder.ExecutedQuantity
+	}
+	state.Aborted = true
+	state.LastErrorMessage = ErrFundingCaptureNotFilled.Error()
+	return f.setStep(state, FundingCaptureStepUnwinding)
+}
+
+// spotEntry returns the spot entry order, nil if it was never placed
+func (f *FundingCapture) spotEntry(ctx context.Context, state *FundingCaptureState) (*Order, error) {
+	order, err := f.c.NewGetOrderService().Symbol(state.Symbol).OrigClientOrderID(state.spotEntryID()).Do(ctx)
+	if common.IsAPIErrorCode(err, errCodeOrderDoesNotExist) {
+		return nil, nil
+	}
+	return order, err
+}
+
+// perpEntry returns the perp entry order, nil if it was never placed
+func (f *FundingCapture) perpEntry(ctx context.Context, state *FundingCaptureState) (*futures.Order, error) {
+	order, err := f.fc.NewGetOrderService().Symbol(state.Symbol).OrigClientOrderID(state.perpEntryID()).Do(ctx)
+	if common.IsAPIErrorCode(err, errCodeOrderDoesNotExist) {
+		return nil, nil
+	}
+	return order, err
+}
+
+// settle waits until funding is settled and records the funding fee received
+func (f *FundingCapture) settle(ctx context.Context, state *FundingCaptureState) error {
+	if err := sleepUntil(ctx, time.UnixMilli(state.FundingTime).Add(f.params.ExitAfter)); err != nil {
+		return err
+	}
+	incomes, err := f.fc.NewGetIncomeHistoryService().Symbol(state.Symbol).IncomeType(futures.IncomeTypeFundingFee).
+		StartTime(state.FundingTime).Do(ctx)
+	if err != nil {
+		return err
+	}
+	var fee float64
+	for _, income := range incomes {
+		v, err := strconv.ParseFloat(income.Income, 64)
+		if err != nil {
+			return err
+		}
+		fee += v
+	}
+	state.FundingFee = strconv.FormatFloat(fee, 'f', -1, 64)
+	return f.setStep(state, FundingCaptureStepUnwinding)
+}
+
+// unwind sells the spot position and buys back the perp position, orders already sent by a
+// previous run are not sent again
+func (f *FundingCapture) unwind(ctx context.Context, state *FundingCaptureState) error {
+	if isZeroQuantity(state.SpotExecutedQty) {
+		state.SpotUnwindQty = "0"
+	}
+	if isZeroQuantity(state.PerpExecutedQty) {
+		state.PerpUnwindQty = "0"
+	}
+	if state.SpotUnwindQty == "" {
+		order, err := f.c.NewGetOrderService().Symbol(state.Symbol).OrigClientOrderID(state.spotExitID()).Do(ctx)
+		if common.IsAPIErrorCode(err, errCodeOrderDoesNotExist) {
+			var res *CreateOrderResponse
+			res, err = f.c.NewCreateOrderService().Symbol(state.Symbol).Side(SideTypeSell).Type(OrderTypeMarket).
+				Quantity(state.SpotExecutedQty).NewClientOrderID(state.spotExitID()).
+				NewOrderRespType(NewOrderRespTypeRESULT).Do(ctx)
+			if err == nil {
+				order = &Order{ExecutedQuantity: res.ExecutedQuantity}
+			}
+		}
+		if err != nil {
+			return err
+		}
+		state.SpotUnwindQty = order.ExecutedQuantity
+	}
+	if state.PerpUnwindQty == "" {
+		order, err := f.fc.NewGetOrderService().Symbol(state.Symbol).OrigClientOrderID(state.perpExitID()).Do(ctx)
+		if common.IsAPIErrorCode(err, errCodeOrderDoesNotExist) {
+			var res *futures.CreateOrderResponse
+			res, err = f.fc.NewCreateOrderService().Symbol(state.Symbol).Side(futures.SideTypeBuy).
+				Type(futures.OrderTypeMarket).Quantity(state.PerpExecutedQty).NewClientOrderID(state.perpExitID()).
+				NewOrderResponseType(futures.NewOrderRespTypeRESULT).PositionIntent(futures.PositionIntentClose).Do(ctx)
+			if err == nil {
+				order = &futures.Order{ExecutedQuantity: res.ExecutedQuantity}
+			}
+		}
+		if err != nil {
+			return err
+		}
+		state.PerpUnwindQty = order.ExecutedQuantity
+	}
+	if state.Aborted {
+		return f.setStep(state, FundingCaptureStepFailed)
+	}
+	return f.setStep(state, FundingCaptureStepDone)
+}
+
+// entryLegs returns legs of the entry orders of state
+func (f *FundingCapture) entryLegs(state *FundingCaptureState) (*SpotOrderLeg, *futures.OrderLeg) {
+	spotLeg := f.c.NewSpotOrderLeg(f.c.NewCreateOrderService().Symbol(state.Symbol).Side(SideTypeBuy).
+		Type(OrderTypeMarket).Quantity(state.Quantity).NewClientOrderID(state.spotEntryID()).
+		NewOrderRespType(NewOrderRespTypeRESULT))
+	perpLeg := f.fc.NewOrderLeg(f.fc.NewCreateOrderService().Symbol(state.Symbol).Side(futures.SideTypeSell).
+		Type(futures.OrderTypeMarket).Quantity(state.Quantity).NewClientOrderID(state.perpEntryID()).
+		NewOrderResponseType(futures.NewOrderRespTypeRESULT).PositionIntent(futures.PositionIntentOpen))
+	return spotLeg, perpLeg
+}
+
+// entryTime returns when positions are opened for fundingTime
+func (f *FundingCapture) entryTime(fundingTime int64) time.Time {
+	return time.UnixMilli(fundingTime).Add(-f.params.EntryBefore)
+}
+
+// nextFundingTime returns the first funding time of symbol after the given one whose entry
+// time is ahead. A funding too close to enter is waited for until the next one is published.
+func (f *FundingCapture) nextFundingTime(ctx context.Context, symbol string, after int64) (int64, error) {
+	for {
+		index, err := f.premiumIndex(ctx, symbol)
+		if err != nil {
+			return 0, err
+		}
+		if index.NextFundingTime > after && time.Now().Before(f.entryTime(index.NextFundingTime)) {
+			return index.NextFundingTime, nil
+		}
+		if index.NextFundingTime > after {
+			after = index.NextFundingTime
+		}
+		wait := time.UnixMilli(after)
+		if !wait.After(time.Now()) {
+			wait = time.Now().Add(fundingCapturePollInterval)
+		}
+		if err := sleepUntil(ctx, wait); err != nil {
+			return 0, err
+		}
+	}
+}
+
+func (f *FundingCapture) premiumIndex(ctx context.Context, symbol string) (*futures.PremiumIndex, error) {
+	res, err := f.fc.NewPremiumIndexService().Symbol(symbol).Do(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if len(res) == 0 {
+		return nil, ErrFundingCaptureSymbolNotFound
+	}
+	return res[0], nil
+}
+
+// quantity converts notional to a quantity allowed by the market lot sizes of both legs
+func (f *FundingCapture) quantity(ctx context.Context, symbol string, markPrice string) (string, error) {
+	price, err := strconv.ParseFloat(markPrice, 64)
+	if err != nil {
+		return "", err
+	}
+	spotInfo, err := f.c.NewExchangeInfoService().Symbol(symbol).Do(ctx)
+	if err != nil {
+		return "", err
+	}
+	perpInfo, err := f.fc.NewExchangeInfoService().Do(ctx)
+	if err != nil {
+		return "", err
+	}
+	var (
+		step      float64
+		precision = -1
+	)
+	maxStep := func(stepSize string) error {
+		v, err := strconv.ParseFloat(stepSize, 64)
+		if err != nil {
+			return err
+		}
+		if v > step {
+			step = v
+		}
+		return nil
+	}
+	for i := range spotInfo.Symbols {
+		if s := &spotInfo.Symbols[i]; s.Symbol == symbol {
+			if lot := s.LotSizeFilter(); lot != nil {
+				if err = maxStep(lot.StepSize); err != nil {
+					return "", err
+				}
+			}
+			if lot := s.MarketLotSizeFilter(); lot != nil {
+				if err = maxStep(lot.StepSize); err != nil {
+					return "", err
+				}
+			}
+		}
+	}
+	for i := range perpInfo.Symbols {
+		if s := &perpInfo.Symbols[i]; s.Symbol == symbol {
+			precision = s.QuantityPrecision
+			if lot := s.LotSizeFilter(); lot != nil {
+				if err = maxStep(lot.StepSize); err != nil {
+					return "", err
+				}
+			}
+			if lot := s.MarketLotSizeFilter(); lot != nil {
+				if err = maxStep(lot.StepSize); err != nil {
+					return "", err
+				}
+			}
+		}
+	}
+	if precision < 0 || step == 0 {
+		return "", ErrFundingCaptureSymbolNotFound
+	}
+	quantity := common.AmountToLotSize(step, precision, f.params.Notional/price)
+	if quantity <= 0 {
+		return "", ErrFundingCaptureQuantityTooSmall
+	}
+	return strconv.FormatFloat(quantity, 'f', -1, 64), nil
+}
+
+func (f *FundingCapture) setStep(state *FundingCaptureState, step FundingCaptureStep) error {
+	state.Step = step
+	if f.handler == nil {
+		return nil
+	}
+	return f.handler(*state)
+}
+
+func isZeroQuantity(quantity string) bool {
+	v, err := strconv.ParseFloat(quantity, 64)
+	return err != nil || v == 0
+}
+
+// sleepUntil waits until t or ctx is done
+func sleepUntil(ctx context.Context, t time.Time) error {
+	d := time.Until(t)
+	if d <= 0 {
+		return nil
+	}
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
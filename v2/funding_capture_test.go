@@ -0,0 +1,168 @@
+package binance
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/adshao/go-binance/v2/futures"
+	"github.com/stretchr/testify/assert"
+)
+
+type roundTripFunc func(req *http.Request) (*http.Response, error)
+
+func (f roundTripFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
+
+type fundingCaptureTestExchange struct {
+	mu              sync.Mutex
+	orders          map[string]string
+	placed          []string
+	nextFundingTime int64
+	// reject, if set, returns the response of the orders it rejects
+	reject func(market string, form url.Values) *http.Response
+}
+
+func (e *fundingCaptureTestExchange) do(req *http.Request) (*http.Response, error) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	body, _ := io.ReadAll(req.Body)
+	form, _ := url.ParseQuery(string(body))
+	for k, v := range req.URL.Query() {
+		form[k] = v
+	}
+	market := "spot"
+	if strings.HasPrefix(req.URL.Path, "/fapi") {
+		market = "perp"
+	}
+	switch {
+	case strings.HasSuffix(req.URL.Path, "/premiumIndex"):
+		return newHTTPResponse([]byte(fmt.Sprintf(`{"symbol":"BTCUSDT","markPrice":"30000","nextFundingTime":%d}`, e.nextFundingTime)), http.StatusOK), nil
+	case req.URL.Path == "/api/v3/exchangeInfo":
+		return newHTTPResponse([]byte(`{"symbols":[{"symbol":"BTCUSDT","filters":[{"filterType":"LOT_SIZE","minQty":"0.00001","maxQty":"9000","stepSize":"0.00001"}]}]}`), http.StatusOK), nil
+	case req.URL.Path == "/fapi/v1/exchangeInfo":
+		return newHTTPResponse([]byte(`{"symbols":[{"symbol":"BTCUSDT","quantityPrecision":3,"filters":[{"filterType":"MARKET_LOT_SIZE","minQty":"0.001","maxQty":"120","stepSize":"0.001"}]}]}`), http.StatusOK), nil
+	case strings.HasSuffix(req.URL.Path, "/positionSide/dual"):
+		return newHTTPResponse([]byte(`{"dualSidePosition":false}`), http.StatusOK), nil
+	case strings.HasSuffix(req.URL.Path, "/income"):
+		return newHTTPResponse([]byte(`[{"symbol":"BTCUSDT","incomeType":"FUNDING_FEE","income":"1.5"}]`), http.StatusOK), nil
+	case strings.HasSuffix(req.URL.Path, "/order") && req.Method == http.MethodPost:
+		id := form.Get("newClientOrderId")
+		if e.reject != nil {
+			if res := e.reject(market, form); res != nil {
+				return res, nil
+			}
+		}
+		e.placed = append(e.placed, fmt.Sprintf("%s %s %s %s", market, id[strings.LastIndex(id, "-")+1:], form.Get("side"), form.Get("quantity")))
+		order := fmt.Sprintf(`{"clientOrderId":"%s","status":"FILLED","executedQty":"%s"}`, id, form.Get("quantity"))
+		e.orders[market+id] = order
+		return newHTTPResponse([]byte(order), http.StatusOK), nil
+	case strings.HasSuffix(req.URL.Path, "/order") && req.Method == http.MethodGet:
+		if order, ok := e.orders[market+form.Get("origClientOrderId")]; ok {
+			return newHTTPResponse([]byte(order), http.StatusOK), nil
+		}
+		return newHTTPResponse([]byte(`{"code":-2013,"msg":"Order does not exist."}`), http.StatusBadRequest), nil
+	}
+	return newHTTPResponse([]byte(`{}`), http.StatusNotFound), nil
+}
+
+func newFundingCaptureTest(params FundingCaptureParams) (*FundingCapture, *fundingCaptureTestExchange) {
+	e := &fundingCaptureTestExchange{orders: make(map[string]string)}
+	c := NewClient("apiKey", "secretKey")
+	c.do = e.do
+	fc := futures.NewClient("apiKey", "secretKey")
+	fc.HTTPClient = &http.Client{Transport: roundTripFunc(e.do)}
+	return NewFundingCapture(c, fc, params), e
+}
+
+func TestFundingCapture(t *testing.T) {
+	assert := assert.New(t)
+
+	f, e := newFundingCaptureTest(FundingCaptureParams{Symbol: "BTCUSDT", Notional: 1000,
+		EntryBefore: 10 * time.Millisecond, ExitAfter: time.Millisecond})
+	var steps []FundingCaptureStep
+	f.OnState(func(state FundingCaptureState) error {
+		steps = append(steps, state.Step)
+		return nil
+	})
+	state, err := f.Run(newContext(), FundingCaptureState{FundingTime: time.Now().Add(20 * time.Millisecond).UnixMilli()})
+	assert.NoError(err)
+	assert.Equal([]FundingCaptureStep{
+		FundingCaptureStepOpening, FundingCaptureStepHedged, FundingCaptureStepUnwinding, FundingCaptureStepDone,
+	}, steps)
+	assert.Equal("0.033", state.Quantity, "rounded to the coarser lot size")
+	assert.Equal("1.5", state.FundingFee)
+	assert.Equal("0.033", state.SpotUnwindQty)
+	assert.Equal("0.033", state.PerpUnwindQty)
+	assert.ElementsMatch([]string{"spot os BUY 0.033", "perp op SELL 0.033"}, e.placed[:2])
+	assert.Equal([]string{"spot cs SELL 0.033", "perp cp BUY 0.033"}, e.placed[2:])
+}
+
+func TestFundingCaptureEntryMissed(t *testing.T) {
+	assert := assert.New(t)
+
+	f, e := newFundingCaptureTest(FundingCaptureParams{Symbol: "BTCUSDT", Notional: 1000,
+		EntryBefore: 10 * time.Millisecond, ExitAfter: time.Millisecond})
+	missed := time.Now().Add(5 * time.Millisecond).UnixMilli()
+	next := time.Now().Add(50 * time.Millisecond).UnixMilli()
+	e.nextFundingTime = next
+
+	state, err := f.Run(newContext(), FundingCaptureState{FundingTime: missed})
+	assert.NoError(err)
+	assert.Equal(next, state.FundingTime, "capture skips to the next funding")
+	assert.Equal(FundingCaptureStepDone, state.Step)
+	assert.Len(e.placed, 4)
+}
+
+func TestFundingCaptureResume(t *testing.T) {
+	assert := assert.New(t)
+
+	f, e := newFundingCaptureTest(FundingCaptureParams{Symbol: "BTCUSDT", Notional: 1000})
+	state := FundingCaptureState{ID: "fc1", Symbol: "BTCUSDT", Step: FundingCaptureStepOpening, Quantity: "0.033"}
+	e.orders["spotfc1-os"] = `{"status":"FILLED","executedQty":"0.033"}`
+	state, err := f.Run(newContext(), state)
+	assert.ErrorIs(err, ErrFundingCaptureFailed)
+	assert.Equal(FundingCaptureStepFailed, state.Step)
+	assert.True(state.Aborted)
+	assert.Equal([]string{"spot cs SELL 0.033"}, e.placed, "missing perp entry is not unwound")
+
+	f, e = newFundingCaptureTest(FundingCaptureParams{Symbol: "BTCUSDT", Notional: 1000})
+	state = FundingCaptureState{ID: "fc2", Symbol: "BTCUSDT", Step: FundingCaptureStepUnwinding,
+		SpotExecutedQty: "0.033", PerpExecutedQty: "0.033"}
+	e.orders["spotfc2-cs"] = `{"status":"FILLED","executedQty":"0.033"}`
+	state, err = f.Run(newContext(), state)
+	assert.NoError(err)
+	assert.Equal(FundingCaptureStepDone, state.Step)
+	assert.Equal([]string{"perp cp BUY 0.033"}, e.placed, "exit order sent before the crash is not sent again")
+}
+
+func TestFundingCaptureUnwindFailure(t *testing.T) {
+	assert := assert.New(t)
+
+	f, e := newFundingCaptureTest(FundingCaptureParams{Symbol: "BTCUSDT", Notional: 1000, EntryBefore: 10 * time.Millisecond})
+	e.reject = func(market string, form url.Values) *http.Response {
+		if market == "perp" || form.Get("side") == "SELL" {
+			return newHTTPResponse([]byte(`{"code":-2019,"msg":"Margin is insufficient."}`), http.StatusBadRequest)
+		}
+		return nil
+	}
+	state, err := f.Run(newContext(), FundingCaptureState{FundingTime: time.Now().Add(20 * time.Millisecond).UnixMilli()})
+	assert.Error(err)
+	assert.Equal(FundingCaptureStepOpening, state.Step, "entry left executed can be resumed")
+	assert.True(state.Aborted)
+	assert.Equal([]string{"spot os BUY 0.033"}, e.placed)
+
+	e.reject = nil
+	state, err = f.Run(newContext(), state)
+	assert.ErrorIs(err, ErrFundingCaptureFailed)
+	assert.Equal(FundingCaptureStepFailed, state.Step)
+	assert.Equal("0.033", state.SpotUnwindQty)
+	assert.Equal([]string{"spot os BUY 0.033", "spot cs SELL 0.033"}, e.placed)
+}
@@ -2,7 +2,6 @@ package futures
 
 import (
 	"context"
-	"encoding/json"
 	"net/http"
 )
 
@@ -23,7 +22,7 @@ func (s *GetBalanceService) Do(ctx context.Context, opts ...RequestOption) (res
 		return []*Balance{}, err
 	}
 	res = make([]*Balance, 0)
-	err = json.Unmarshal(data, &res)
+	err = unmarshalResponse(data, &res)
 	if err != nil {
 		return []*Balance{}, err
 	}
@@ -58,7 +57,7 @@ func (s *GetAccountService) Do(ctx context.Context, opts ...RequestOption) (res
 		return nil, err
 	}
 	res = new(Account)
-	err = json.Unmarshal(data, res)
+	err = unmarshalResponse(data, res)
 	if err != nil {
 		return nil, err
 	}
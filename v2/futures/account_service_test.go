@@ -4,6 +4,8 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/suite"
+
+	"github.com/adshao/go-binance/v2/common"
 )
 
 type accountServiceTestSuite struct {
@@ -48,6 +50,23 @@ func (s *accountServiceTestSuite) TestGetBalance() {
 	s.assertBalanceEqual(e, res[0])
 }
 
+func (s *accountServiceTestSuite) TestGetBalanceStrictDecoding() {
+	StrictDecoding = true
+	defer func() { StrictDecoding = false }()
+
+	data := []byte(`[{"accountAlias": "SgsR", "asset": "USDT", "balance": "1", "crossWalletBalance": "1",
+		"crossUnPnl": "0", "availableBalance": "1", "maxWithdrawAmount": "1", "marginAvailable": true}]`)
+	s.mockDo(data, nil)
+	defer s.assertDo()
+	s.assertReq(func(r *request) {
+		e := newSignedRequest()
+		s.assertRequestEqual(e, r)
+	})
+
+	_, err := s.client.NewGetBalanceService().Do(newContext())
+	s.r().Equal(&common.SchemaError{Type: "futures.Balance", Field: "[0].marginAvailable", Reason: common.SchemaErrorUnknownField}, err)
+}
+
 func (s *accountServiceTestSuite) assertBalanceEqual(e, a *Balance) {
 	r := s.r()
 	r.Equal(e.AccountAlias, a.AccountAlias, "AccountAlias")
@@ -0,0 +1,200 @@
+package futures
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+)
+
+// adlQuantileHedge is the ADL quantile key that is not a position side
+const adlQuantileHedge = "HEDGE"
+
+// ADLRisk define ADL quantile of a position
+type ADLRisk struct {
+	Symbol       string
+	PositionSide PositionSideType
+	// Quantile is from 0 to 4, positions at 4 are the first to be auto-deleveraged
+	Quantile int
+}
+
+// ADLHandler handle a threshold crossing of ADLRisk
+type ADLHandler func(risk ADLRisk)
+
+type adlPositionKey struct {
+	symbol       string
+	positionSide PositionSideType
+}
+
+type adlThreshold struct {
+	quantile int
+	handler  ADLHandler
+	above    map[adlPositionKey]bool
+}
+
+// ADLMonitor polls 'adlQuantile' of all positions and fires handlers registered for thresholds
+// the quantile of a position rises to, e.g. to trim positions at risk of auto-deleveraging.
+// A handler fires again for a position only after its quantile went back below the threshold.
+type ADLMonitor struct {
+	c          *Client
+	interval   time.Duration
+	errHandler ErrHandler
+
+	mu         sync.Mutex
+	risks      map[adlPositionKey]ADLRisk
+	thresholds []*adlThreshold
+	stopC      chan struct{}
+	doneC      chan struct{}
+}
+
+// NewADLMonitor init ADLMonitor polling every interval
+func NewADLMonitor(c *Client, interval time.Duration, errHandler ErrHandler) *ADLMonitor {
+	return &ADLMonitor{
+		c:          c,
+		interval:   interval,
+		errHandler: errHandler,
+		risks:      make(map[adlPositionKey]ADLRisk),
+	}
+}
+
+// OnThreshold registers handler fired when ADL quantile of a position rises to quantile or above
+func (m *ADLMonitor) OnThreshold(quantile int, handler ADLHandler) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.thresholds = append(m.thresholds, &adlThreshold{
+		quantile: quantile,
+		handler:  handler,
+		above:    make(map[adlPositionKey]bool),
+	})
+	sort.SliceStable(m.thresholds, func(i, j int) bool {
+		return m.thresholds[i].quantile < m.thresholds[j].quantile
+	})
+}
+
+// Risk returns the last polled ADL quantile of the position of symbol on positionSide
+func (m *ADLMonitor) Risk(symbol string, positionSide PositionSideType) (ADLRisk, bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	risk, ok := m.risks[adlPositionKey{symbol: symbol, positionSide: positionSide}]
+	return risk, ok
+}
+
+// Risks returns the last polled ADL quantiles, highest first
+func (m *ADLMonitor) Risks() []ADLRisk {
+	m.mu.Lock()
+	res := make([]ADLRisk, 0, len(m.risks))
+	for _, risk := range m.risks {
+		res = append(res, risk)
+	}
+	m.mu.Unlock()
+
+	sort.Slice(res, func(i, j int) bool {
+		if res[i].Quantile != res[j].Quantile {
+			return res[i].Quantile > res[j].Quantile
+		}
+		if res[i].Symbol != res[j].Symbol {
+			return res[i].Symbol < res[j].Symbol
+		}
+		return res[i].PositionSide < res[j].PositionSide
+	})
+	return res
+}
+
+// Start polls ADL quantiles once and keeps polling in background until Stop is called
+func (m *ADLMonitor) Start(ctx context.Context) error {
+	if err := m.Check(ctx); err != nil {
+		return err
+	}
+
+	m.mu.Lock()
+	if m.stopC != nil {
+		m.mu.Unlock()
+		return nil
+	}
+	m.stopC = make(chan struct{})
+	m.doneC = make(chan struct{})
+	stopC, doneC := m.stopC, m.doneC
+	m.mu.Unlock()
+
+	go func() {
+		defer close(doneC)
+		ticker := time.NewTicker(m.interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-stopC:
+				return
+			case <-ticker.C:
+				ctx, cancel := context.WithTimeout(context.Background(), m.interval)
+				if err := m.Check(ctx); err != nil && m.errHandler != nil {
+					m.errHandler(err)
+				}
+				cancel()
+			}
+		}
+	}()
+	return nil
+}
+
+// Stop stops polling
+func (m *ADLMonitor) Stop() {
+	m.mu.Lock()
+	stopC, doneC := m.stopC, m.doneC
+	m.stopC, m.doneC = nil, nil
+	m.mu.Unlock()
+
+	if stopC == nil {
+		return
+	}
+	close(stopC)
+	<-doneC
+}
+
+// Check polls ADL quantiles and fires handlers of crossed thresholds, lowest threshold first.
+// Positions no longer reported are dropped.
+func (m *ADLMonitor) Check(ctx context.Context) error {
+	res, err := m.c.NewGetADLQuantileService().Do(ctx)
+	if err != nil {
+		return err
+	}
+	risks := make(map[adlPositionKey]ADLRisk)
+	for _, q := range res {
+		for side, quantile := range q.ADLQuantile {
+			if side == adlQuantileHedge {
+				continue
+			}
+			key := adlPositionKey{symbol: q.Symbol, positionSide: PositionSideType(side)}
+			risks[key] = ADLRisk{Symbol: q.Symbol, PositionSide: key.positionSide, Quantile: quantile}
+		}
+	}
+
+	type firing struct {
+		handler ADLHandler
+		risk    ADLRisk
+	}
+	var fired []firing
+	m.mu.Lock()
+	m.risks = risks
+	for _, t := range m.thresholds {
+		for key := range t.above {
+			if _, ok := risks[key]; !ok {
+				delete(t.above, key)
+			}
+		}
+		for key, risk := range risks {
+			above := risk.Quantile >= t.quantile
+			if above && !t.above[key] {
+				fired = append(fired, firing{handler: t.handler, risk: risk})
+			}
+			t.above[key] = above
+		}
+	}
+	m.mu.Unlock()
+
+	for _, f := range fired {
+		f.handler(f.risk)
+	}
+	return nil
+}
@@ -0,0 +1,51 @@
+package futures
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestADLMonitor(t *testing.T) {
+	assert := assert.New(t)
+
+	reply := `[{"symbol":"BTCUSDT","adlQuantile":{"LONG":2,"SHORT":0,"HEDGE":0}}]`
+	c := NewClient("apiKey", "secretKey")
+	c.do = func(req *http.Request) (*http.Response, error) {
+		return newHTTPResponse([]byte(reply), http.StatusOK), nil
+	}
+
+	var fired []ADLRisk
+	m := NewADLMonitor(c, 0, nil)
+	m.OnThreshold(3, func(risk ADLRisk) {
+		fired = append(fired, risk)
+	})
+
+	assert.NoError(m.Check(newContext()))
+	assert.Empty(fired)
+	assert.Equal([]ADLRisk{
+		{Symbol: "BTCUSDT", PositionSide: PositionSideTypeLong, Quantile: 2},
+		{Symbol: "BTCUSDT", PositionSide: PositionSideTypeShort, Quantile: 0},
+	}, m.Risks())
+
+	reply = `[{"symbol":"BTCUSDT","adlQuantile":{"LONG":4,"SHORT":0,"HEDGE":0}},{"symbol":"ETHUSDT","adlQuantile":{"BOTH":3}}]`
+	assert.NoError(m.Check(newContext()))
+	assert.ElementsMatch([]ADLRisk{
+		{Symbol: "BTCUSDT", PositionSide: PositionSideTypeLong, Quantile: 4},
+		{Symbol: "ETHUSDT", PositionSide: PositionSideTypeBoth, Quantile: 3},
+	}, fired)
+
+	fired = nil
+	assert.NoError(m.Check(newContext()))
+	assert.Empty(fired, "fires once while above threshold")
+
+	reply = `[{"symbol":"BTCUSDT","adlQuantile":{"LONG":1,"SHORT":0,"HEDGE":0}}]`
+	assert.NoError(m.Check(newContext()))
+	_, ok := m.Risk("ETHUSDT", PositionSideTypeBoth)
+	assert.False(ok, "closed position is dropped")
+
+	reply = `[{"symbol":"BTCUSDT","adlQuantile":{"LONG":3,"SHORT":0,"HEDGE":0}},{"symbol":"ETHUSDT","adlQuantile":{"BOTH":4}}]`
+	assert.NoError(m.Check(newContext()))
+	assert.Len(fired, 2, "fires again after going back below threshold")
+}
@@ -0,0 +1,48 @@
+package futures
+
+import (
+	"context"
+	"net/http"
+)
+
+// GetADLQuantileService get ADL quantile estimation of positions
+type GetADLQuantileService struct {
+	c      *Client
+	symbol string
+}
+
+// Symbol set symbol
+func (s *GetADLQuantileService) Symbol(symbol string) *GetADLQuantileService {
+	s.symbol = symbol
+	return s
+}
+
+// Do send request
+func (s *GetADLQuantileService) Do(ctx context.Context, opts ...RequestOption) (res []*ADLQuantile, err error) {
+	r := &request{
+		method:   http.MethodGet,
+		endpoint: "/fapi/v1/adlQuantile",
+		secType:  secTypeSigned,
+	}
+	if s.symbol != "" {
+		r.setParam("symbol", s.symbol)
+	}
+	data, _, err := s.c.callAPI(ctx, r, opts...)
+	if err != nil {
+		return []*ADLQuantile{}, err
+	}
+	res = make([]*ADLQuantile, 0)
+	err = unmarshalResponse(data, &res)
+	if err != nil {
+		return []*ADLQuantile{}, err
+	}
+	return res, nil
+}
+
+// ADLQuantile define ADL quantile of the positions of a symbol, from 0 to 4 where 4 is the
+// first to be auto-deleveraged. Keys are "BOTH" in One-way Mode, "LONG" and "SHORT" in Hedge
+// Mode, where "HEDGE" is only a sign the quantiles of both sides are the same.
+type ADLQuantile struct {
+	Symbol      string         `json:"symbol"`
+	ADLQuantile map[string]int `json:"adlQuantile"`
+}
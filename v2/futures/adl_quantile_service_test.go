@@ -0,0 +1,52 @@
+package futures
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type adlQuantileServiceTestSuite struct {
+	baseTestSuite
+}
+
+func TestADLQuantileService(t *testing.T) {
+	suite.Run(t, new(adlQuantileServiceTestSuite))
+}
+
+func (s *adlQuantileServiceTestSuite) TestGetADLQuantile() {
+	data := []byte(`[
+		{
+			"symbol": "ETHUSDT",
+			"adlQuantile": {
+				"LONG": 3,
+				"SHORT": 3,
+				"HEDGE": 0
+			}
+		},
+		{
+			"symbol": "BTCUSDT",
+			"adlQuantile": {
+				"LONG": 1,
+				"SHORT": 2,
+				"BOTH": 0
+			}
+		}
+	]`)
+	s.mockDo(data, nil)
+	defer s.assertDo()
+
+	symbol := "ETHUSDT"
+	s.assertReq(func(r *request) {
+		e := newSignedRequest().setParams(params{
+			"symbol": symbol,
+		})
+		s.assertRequestEqual(e, r)
+	})
+	res, err := s.client.NewGetADLQuantileService().Symbol(symbol).Do(newContext())
+	r := s.r()
+	r.NoError(err)
+	r.Len(res, 2)
+	r.Equal(&ADLQuantile{Symbol: "ETHUSDT", ADLQuantile: map[string]int{"LONG": 3, "SHORT": 3, "HEDGE": 0}}, res[0])
+	r.Equal(&ADLQuantile{Symbol: "BTCUSDT", ADLQuantile: map[string]int{"LONG": 1, "SHORT": 2, "BOTH": 0}}, res[1])
+}
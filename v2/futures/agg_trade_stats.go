@@ -0,0 +1,157 @@
+package futures
+
+import (
+	"fmt"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// TradeStats define rolling statistics of aggregate trades of a symbol
+type TradeStats struct {
+	Symbol      string
+	Window      time.Duration
+	Count       int
+	Volume      float64
+	QuoteVolume float64
+	BuyVolume   float64 // taker buy volume
+	SellVolume  float64 // taker sell volume
+}
+
+// VWAP returns volume weighted average price
+func (s TradeStats) VWAP() float64 {
+	if s.Volume == 0 {
+		return 0
+	}
+	return s.QuoteVolume / s.Volume
+}
+
+// Imbalance returns taker buy/sell imbalance in range [-1, 1]
+func (s TradeStats) Imbalance() float64 {
+	if s.Volume == 0 {
+		return 0
+	}
+	return (s.BuyVolume - s.SellVolume) / s.Volume
+}
+
+// AggTradeStats subscribes aggregate trades of a set of symbols through a combined stream
+// and maintains rolling trade statistics over the configured windows
+type AggTradeStats struct {
+	symbols    []string
+	windows    []time.Duration
+	errHandler ErrHandler
+	now        func() time.Time
+
+	mu    sync.Mutex
+	stats map[string]map[time.Duration]*rollingWindow
+	stopC chan struct{}
+	doneC chan struct{}
+}
+
+// NewAggTradeStats init AggTradeStats
+func NewAggTradeStats(symbols []string, windows ...time.Duration) *AggTradeStats {
+	a := &AggTradeStats{
+		symbols: symbols,
+		windows: windows,
+		now:     time.Now,
+		stats:   make(map[string]map[time.Duration]*rollingWindow, len(symbols)),
+	}
+	for _, symbol := range symbols {
+		a.stats[symbol] = make(map[time.Duration]*rollingWindow, len(windows))
+		for _, window := range windows {
+			a.stats[symbol][window] = newRollingWindow(window, 4)
+		}
+	}
+	return a
+}
+
+// ErrHandler set handler for stream errors
+func (a *AggTradeStats) ErrHandler(errHandler ErrHandler) *AggTradeStats {
+	a.errHandler = errHandler
+	return a
+}
+
+// Start subscribes aggTrade streams of all symbols
+func (a *AggTradeStats) Start() error {
+	doneC, stopC, err := WsCombinedAggTradeServe(a.symbols, a.handleEvent, a.handleError)
+	if err != nil {
+		return err
+	}
+
+	a.mu.Lock()
+	a.doneC, a.stopC = doneC, stopC
+	a.mu.Unlock()
+	return nil
+}
+
+// Stop closes the stream
+func (a *AggTradeStats) Stop() {
+	a.mu.Lock()
+	doneC, stopC := a.doneC, a.stopC
+	a.doneC, a.stopC = nil, nil
+	a.mu.Unlock()
+
+	if stopC == nil {
+		return
+	}
+	close(stopC)
+	<-doneC
+}
+
+// Stats returns statistics of symbol over window, window must be one of configured windows
+func (a *AggTradeStats) Stats(symbol string, window time.Duration) (TradeStats, error) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	windows, ok := a.stats[symbol]
+	if !ok {
+		return TradeStats{}, fmt.Errorf("agg trade stats: symbol %s is not subscribed", symbol)
+	}
+	w, ok := windows[window]
+	if !ok {
+		return TradeStats{}, fmt.Errorf("agg trade stats: window %s is not configured", window)
+	}
+	sums, count := w.snapshot(a.now())
+	return TradeStats{
+		Symbol:      symbol,
+		Window:      window,
+		Count:       count,
+		QuoteVolume: sums[0],
+		Volume:      sums[1],
+		BuyVolume:   sums[2],
+		SellVolume:  sums[3],
+	}, nil
+}
+
+func (a *AggTradeStats) handleEvent(event *WsAggTradeEvent) {
+	price, err := strconv.ParseFloat(event.Price, 64)
+	if err != nil {
+		a.handleError(err)
+		return
+	}
+	qty, err := strconv.ParseFloat(event.Quantity, 64)
+	if err != nil {
+		a.handleError(err)
+		return
+	}
+
+	// buyer is the maker means the taker sold
+	buy, sell := qty, 0.0
+	if event.Maker {
+		buy, sell = 0, qty
+	}
+
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	now := a.now()
+	for _, w := range a.stats[event.Symbol] {
+		w.add(now, price*qty, qty, buy, sell)
+	}
+}
+
+func (a *AggTradeStats) handleError(err error) {
+	if a.errHandler != nil {
+		a.errHandler(err)
+	}
+}
@@ -0,0 +1,68 @@
+package futures
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type aggTradeStatsTestSuite struct {
+	baseWsTestSuite
+}
+
+func TestAggTradeStats(t *testing.T) {
+	suite.Run(t, new(aggTradeStatsTestSuite))
+}
+
+func (s *aggTradeStatsTestSuite) TestStats() {
+	s.mockWsServeMessages(
+		[]byte(`{"stream":"btcusdt@aggTrade","data":{"e":"aggTrade","E":1,"s":"BTCUSDT","a":1,"p":"100","q":"1","f":1,"l":1,"T":1,"m":false}}`),
+		[]byte(`{"stream":"btcusdt@aggTrade","data":{"e":"aggTrade","E":2,"s":"BTCUSDT","a":2,"p":"110","q":"3","f":2,"l":2,"T":2,"m":true}}`),
+	)
+
+	a := NewAggTradeStats([]string{"BTCUSDT", "ETHUSDT"}, time.Minute, 5*time.Minute)
+	s.r().NoError(a.Start())
+	defer a.Stop()
+
+	s.r().Equal("wss://fstream.binance.com/stream?streams=btcusdt@aggTrade/ethusdt@aggTrade", s.endpoint)
+
+	stats, err := a.Stats("BTCUSDT", time.Minute)
+	s.r().NoError(err)
+	s.r().Equal(2, stats.Count)
+	s.r().Equal(4.0, stats.Volume)
+	s.r().Equal(430.0, stats.QuoteVolume)
+	s.r().Equal(107.5, stats.VWAP())
+	s.r().Equal(-0.5, stats.Imbalance())
+
+	stats, err = a.Stats("ETHUSDT", 5*time.Minute)
+	s.r().NoError(err)
+	s.r().Equal(0, stats.Count)
+	s.r().Equal(0.0, stats.VWAP())
+
+	_, err = a.Stats("BNBUSDT", time.Minute)
+	s.r().Error(err)
+	_, err = a.Stats("BTCUSDT", time.Hour)
+	s.r().Error(err)
+}
+
+func (s *aggTradeStatsTestSuite) TestWindows() {
+	now := time.Unix(1000, 0)
+	a := NewAggTradeStats([]string{"BTCUSDT"}, time.Minute, 5*time.Minute)
+	a.now = func() time.Time { return now }
+
+	a.handleEvent(&WsAggTradeEvent{Symbol: "BTCUSDT", Price: "100", Quantity: "2"})
+	now = now.Add(2 * time.Minute)
+	a.handleEvent(&WsAggTradeEvent{Symbol: "BTCUSDT", Price: "200", Quantity: "1"})
+
+	stats, err := a.Stats("BTCUSDT", time.Minute)
+	s.r().NoError(err)
+	s.r().Equal(1, stats.Count)
+	s.r().Equal(200.0, stats.VWAP())
+
+	stats, err = a.Stats("BTCUSDT", 5*time.Minute)
+	s.r().NoError(err)
+	s.r().Equal(2, stats.Count)
+	s.r().InDelta(133.333333, stats.VWAP(), 1e-6)
+	s.r().Equal(1.0, stats.Imbalance())
+}
@@ -0,0 +1,328 @@
+package algo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/adshao/go-binance/v2/futures"
+)
+
+// ErrChildClosed is returned when a child order is closed unfilled by anything but Abort,
+// e.g. canceled manually or expired as post only
+var ErrChildClosed = errors.New("algo: child order closed unfilled")
+
+// IcebergParams define parent order executed by Iceberg
+type IcebergParams struct {
+	Symbol       string
+	Side         futures.SideType
+	PositionSide futures.PositionSideType
+	ReduceOnly   bool
+	Price        string
+	// Quantity is the hidden parent quantity, VisibleQuantity the quantity of each child order
+	Quantity          float64
+	VisibleQuantity   float64
+	QuantityPrecision int
+	// TimeInForce of child orders, TimeInForceTypeGTC if not set
+	TimeInForce futures.TimeInForceType
+	// OnError is called when the live child order can not be queried or canceled, errors
+	// placing child orders end execution and are reported by Progress unless the order is
+	// found placed when queried
+	OnError futures.ErrHandler
+}
+
+// IcebergProgress define execution state of Iceberg
+type IcebergProgress struct {
+	Quantity float64
+	Filled   float64
+	// Visible is the unfilled quantity of the live child order
+	Visible float64
+	Placed  int
+	Done    bool
+	Err     error
+}
+
+// Iceberg keeps a single child limit order of the visible quantity on the book and replaces it
+// from the hidden parent quantity each time it is completely filled. Pass it the events of the
+// user data stream, fills are only seen through them.
+type Iceberg struct {
+	placer OrderPlacer
+	params IcebergParams
+	prefix string
+
+	mu       sync.Mutex
+	children map[string]*childState
+	live     string
+	seq      int
+	placed   int
+	err      error
+	cancel   context.CancelFunc
+	refillC  chan struct{}
+	doneC    chan struct{}
+}
+
+// NewIceberg init Iceberg placing child orders with placer
+func NewIceberg(placer OrderPlacer, params IcebergParams) (*Iceberg, error) {
+	if params.Symbol == "" || params.Price == "" || params.Quantity <= 0 || params.VisibleQuantity <= 0 {
+		return nil, ErrInvalidParams
+	}
+	if params.TimeInForce == "" {
+		params.TimeInForce = futures.TimeInForceTypeGTC
+	}
+	return &Iceberg{
+		placer:   placer,
+		params:   params,
+		prefix:   fmt.Sprintf("ice%d-", time.Now().UnixNano()),
+		children: make(map[string]*childState),
+		refillC:  make(chan struct{}, 1),
+		doneC:    make(chan struct{}),
+	}, nil
+}
+
+// Start starts placing child orders in background, starting a started Iceberg does nothing
+func (i *Iceberg) Start(ctx context.Context) {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+
+	if i.cancel != nil {
+		return
+	}
+	ctx, i.cancel = context.WithCancel(ctx)
+	i.refill()
+	go i.run(ctx)
+}
+
+// Abort stops replenishing, cancels the live child order and waits until done
+func (i *Iceberg) Abort() {
+	i.mu.Lock()
+	cancel := i.cancel
+	if cancel != nil && i.err == nil {
+		i.err = ErrAborted
+	}
+	i.mu.Unlock()
+
+	if cancel == nil {
+		return
+	}
+	cancel()
+	<-i.doneC
+}
+
+// Done returns channel closed when execution ended
+func (i *Iceberg) Done() <-chan struct{} {
+	return i.doneC
+}
+
+// Progress returns execution state
+func (i *Iceberg) Progress() IcebergProgress {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+
+	p := IcebergProgress{
+		Quantity: i.params.Quantity,
+		Filled:   i.filled(),
+		Placed:   i.placed,
+		Err:      i.err,
+	}
+	if child, ok := i.children[i.live]; ok && child.open() {
+		p.Visible = child.quantity - child.executed
+	}
+	select {
+	case <-i.doneC:
+		p.Done = true
+	default:
+	}
+	return p
+}
+
+// HandleUserDataEvent applies ORDER_TRADE_UPDATE events of child orders and replenishes the
+// visible quantity once the live child is filled, other events are ignored
+func (i *Iceberg) HandleUserDataEvent(event *futures.WsUserDataEvent) {
+	if event.Event != futures.UserDataEventTypeOrderTradeUpdate {
+		return
+	}
+	u := event.OrderTradeUpdate
+	executed, err := strconv.ParseFloat(u.AccumulatedFilledQty, 64)
+	if err != nil {
+		return
+	}
+
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	child, ok := i.children[u.ClientOrderID]
+	if !ok {
+		return
+	}
+	child.update(u.Status, executed)
+	if u.ClientOrderID == i.live && !child.open() {
+		i.refill()
+	}
+}
+
+// refill signals run to replace the live child order
+func (i *Iceberg) refill() {
+	select {
+	case i.refillC <- struct{}{}:
+	default:
+	}
+}
+
+func (i *Iceberg) run(ctx context.Context) {
+	defer close(i.doneC)
+	defer i.cancelLive()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-i.refillC:
+		}
+		if done := i.replenish(ctx); done {
+			return
+		}
+	}
+}
+
+// replenish places the next child order if the live one is closed, it returns true when
+// the parent quantity is filled or a child was closed unfilled
+func (i *Iceberg) replenish(ctx context.Context) bool {
+	i.mu.Lock()
+	if child, ok := i.children[i.live]; ok {
+		if child.open() {
+			i.mu.Unlock()
+			return false
+		}
+		if child.status != futures.OrderStatusTypeFilled && i.err == nil {
+			i.err = ErrChildClosed
+		}
+	}
+	remaining := roundDown(i.params.Quantity-i.filled(), i.params.QuantityPrecision)
+	if i.err != nil || remaining <= 0 {
+		i.mu.Unlock()
+		return true
+	}
+	quantity := math.Min(remaining, i.params.VisibleQuantity)
+	i.seq++
+	id := fmt.Sprintf("%s%d", i.prefix, i.seq)
+	child := &childState{quantity: quantity, status: futures.OrderStatusTypeNew}
+	i.children[id] = child
+	i.live = id
+	i.mu.Unlock()
+
+	res, err := i.placer.PlaceOrder(ctx, ChildOrder{
+		Symbol:        i.params.Symbol,
+		Side:          i.params.Side,
+		PositionSide:  i.params.PositionSide,
+		TimeInForce:   i.params.TimeInForce,
+		Quantity:      strconv.FormatFloat(quantity, 'f', i.params.QuantityPrecision, 64),
+		Price:         i.params.Price,
+		ReduceOnly:    i.params.ReduceOnly,
+		ClientOrderID: id,
+	})
+
+	if err != nil && placementUnknown(err) {
+		// the order may be live, it is queried before being replaced or canceled
+		i.mu.Lock()
+		child.unknown = true
+		i.mu.Unlock()
+		if qerr := i.resolveLive(ctx); qerr != nil {
+			i.handleError(qerr)
+		}
+	}
+
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	if err != nil {
+		if !child.unknown && child.status != futures.OrderStatusTypeRejected {
+			// placed although the response was lost
+			if !child.open() {
+				i.refill()
+			}
+			return false
+		}
+		if !child.unknown {
+			child.status = futures.OrderStatusTypeRejected
+		}
+		if i.err == nil {
+			i.err = err
+		}
+		return true
+	}
+	executed, _ := strconv.ParseFloat(res.ExecutedQuantity, 64)
+	i.placed++
+	child.update(res.Status, executed)
+	if !child.open() {
+		i.refill()
+	}
+	return false
+}
+
+// resolveLive queries the live child order if its placement outcome is unknown
+func (i *Iceberg) resolveLive(ctx context.Context) error {
+	i.mu.Lock()
+	id := i.live
+	child, ok := i.children[id]
+	unknown := ok && child.unknown
+	i.mu.Unlock()
+	if !unknown {
+		return nil
+	}
+
+	status, executed, err := queryChild(ctx, i.placer, i.params.Symbol, id)
+	if err != nil {
+		return err
+	}
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	if status != futures.OrderStatusTypeRejected {
+		i.placed++
+	}
+	child.resolve(status, executed)
+	return nil
+}
+
+// cancelLive cancels the live child order, if any, including one whose placement outcome is
+// unknown and can't be queried
+func (i *Iceberg) cancelLive() {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	if err := i.resolveLive(ctx); err != nil {
+		i.handleError(err)
+	}
+
+	i.mu.Lock()
+	id := i.live
+	child, ok := i.children[id]
+	live := ok && child.open()
+	i.mu.Unlock()
+	if !live {
+		return
+	}
+
+	if err := i.placer.CancelOrder(ctx, i.params.Symbol, id); err != nil {
+		i.handleError(err)
+		return
+	}
+	i.mu.Lock()
+	if child.open() {
+		child.status = futures.OrderStatusTypeCanceled
+	}
+	i.mu.Unlock()
+}
+
+func (i *Iceberg) filled() (filled float64) {
+	for _, child := range i.children {
+		filled += child.executed
+	}
+	return filled
+}
+
+func (i *Iceberg) handleError(err error) {
+	if i.params.OnError != nil {
+		i.params.OnError(err)
+	}
+}
@@ -0,0 +1,174 @@
+package algo
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/adshao/go-binance/v2/futures"
+	"github.com/stretchr/testify/assert"
+)
+
+func childUpdateEvent(clientOrderID string, status futures.OrderStatusType, executed string) *futures.WsUserDataEvent {
+	return &futures.WsUserDataEvent{
+		Event: futures.UserDataEventTypeOrderTradeUpdate,
+		WsUserDataOrderTradeUpdate: futures.WsUserDataOrderTradeUpdate{OrderTradeUpdate: futures.WsOrderTradeUpdate{
+			ClientOrderID:        clientOrderID,
+			Status:               status,
+			AccumulatedFilledQty: executed,
+		}},
+	}
+}
+
+func restingChild(order ChildOrder) (string, futures.OrderStatusType) {
+	return "0", futures.OrderStatusTypeNew
+}
+
+func (p *testPlacer) order(i int) ChildOrder {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	return p.orders[i]
+}
+
+func TestIceberg(t *testing.T) {
+	assert := assert.New(t)
+
+	p := &testPlacer{fill: restingChild}
+	iceberg, err := NewIceberg(p, IcebergParams{
+		Symbol:            "BTCUSDT",
+		Side:              futures.SideTypeBuy,
+		Price:             "100",
+		Quantity:          2.5,
+		VisibleQuantity:   1,
+		QuantityPrecision: 3,
+	})
+	assert.NoError(err)
+
+	iceberg.Start(context.Background())
+	for i, quantity := range []string{"1.000", "1.000", "0.500"} {
+		assert.Eventually(func() bool {
+			return iceberg.Progress().Placed == i+1
+		}, time.Second, time.Millisecond)
+		order := p.order(i)
+		assert.Equal(quantity, order.Quantity)
+		assert.Equal(futures.TimeInForceTypeGTC, order.TimeInForce)
+
+		if i == 0 {
+			iceberg.HandleUserDataEvent(childUpdateEvent(order.ClientOrderID, futures.OrderStatusTypePartiallyFilled, "0.4"))
+			assert.Equal(0.6, iceberg.Progress().Visible)
+		}
+		iceberg.HandleUserDataEvent(childUpdateEvent(order.ClientOrderID, futures.OrderStatusTypeFilled, quantity))
+	}
+
+	select {
+	case <-iceberg.Done():
+	case <-time.After(time.Second):
+		t.Fatal("iceberg not done")
+	}
+	progress := iceberg.Progress()
+	assert.Equal(2.5, progress.Filled)
+	assert.Equal(3, progress.Placed)
+	assert.NoError(progress.Err)
+	assert.Empty(p.canceled)
+}
+
+func TestIcebergChildClosed(t *testing.T) {
+	assert := assert.New(t)
+
+	p := &testPlacer{fill: restingChild}
+	iceberg, err := NewIceberg(p, IcebergParams{
+		Symbol:          "BTCUSDT",
+		Side:            futures.SideTypeSell,
+		Price:           "100",
+		Quantity:        3,
+		VisibleQuantity: 1,
+	})
+	assert.NoError(err)
+
+	iceberg.Start(context.Background())
+	assert.Eventually(func() bool {
+		return iceberg.Progress().Placed == 1
+	}, time.Second, time.Millisecond)
+	iceberg.HandleUserDataEvent(childUpdateEvent(p.order(0).ClientOrderID, futures.OrderStatusTypeCanceled, "0"))
+
+	<-iceberg.Done()
+	assert.ErrorIs(iceberg.Progress().Err, ErrChildClosed)
+	assert.Equal(1, iceberg.Progress().Placed)
+}
+
+func TestIcebergAbort(t *testing.T) {
+	assert := assert.New(t)
+
+	p := &testPlacer{fill: restingChild}
+	iceberg, err := NewIceberg(p, IcebergParams{
+		Symbol:          "BTCUSDT",
+		Side:            futures.SideTypeSell,
+		Price:           "100",
+		Quantity:        3,
+		VisibleQuantity: 1,
+	})
+	assert.NoError(err)
+
+	iceberg.Start(context.Background())
+	assert.Eventually(func() bool {
+		return iceberg.Progress().Placed == 1
+	}, time.Second, time.Millisecond)
+	iceberg.Abort()
+
+	progress := iceberg.Progress()
+	assert.True(progress.Done)
+	assert.ErrorIs(progress.Err, ErrAborted)
+	assert.Zero(progress.Visible)
+	assert.Equal([]string{p.order(0).ClientOrderID}, p.canceled)
+}
+
+func TestIcebergUnknownPlacement(t *testing.T) {
+	assert := assert.New(t)
+
+	newIceberg := func(p *testPlacer) *Iceberg {
+		iceberg, err := NewIceberg(p, IcebergParams{
+			Symbol:          "BTCUSDT",
+			Side:            futures.SideTypeBuy,
+			Price:           "100",
+			Quantity:        3,
+			VisibleQuantity: 1,
+		})
+		assert.NoError(err)
+		return iceberg
+	}
+
+	// the child placed although the response was lost is kept live and canceled on abort
+	p := &testPlacer{fill: restingChild, fail: func(order ChildOrder) (bool, error) {
+		return true, context.DeadlineExceeded
+	}}
+	iceberg := newIceberg(p)
+	iceberg.Start(context.Background())
+	assert.Eventually(func() bool {
+		return iceberg.Progress().Placed == 1
+	}, time.Second, time.Millisecond)
+	progress := iceberg.Progress()
+	assert.NoError(progress.Err)
+	assert.Equal(1.0, progress.Visible)
+	id := p.order(0).ClientOrderID
+	assert.Equal([]string{id}, p.queried)
+	iceberg.Abort()
+	assert.Equal([]string{id}, p.canceled)
+
+	// the child never placed ends execution with the placement error
+	p = &testPlacer{fill: restingChild, fail: func(order ChildOrder) (bool, error) {
+		return false, context.DeadlineExceeded
+	}}
+	iceberg = newIceberg(p)
+	iceberg.Start(context.Background())
+	select {
+	case <-iceberg.Done():
+	case <-time.After(time.Second):
+		t.Fatal("iceberg not done")
+	}
+	progress = iceberg.Progress()
+	assert.ErrorIs(progress.Err, context.DeadlineExceeded)
+	assert.Zero(progress.Placed)
+	assert.Len(p.queried, 1)
+	assert.Empty(p.canceled)
+}
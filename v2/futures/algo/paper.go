@@ -0,0 +1,560 @@
+package algo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/adshao/go-binance/v2/common"
+	"github.com/adshao/go-binance/v2/futures"
+)
+
+// ErrNoMarketData is returned by PaperPlacer when no book was received for the symbol yet
+var ErrNoMarketData = errors.New("algo: no market data for symbol")
+
+// paperEpsilon absorbs float rounding of simulated quantities
+const paperEpsilon = 1e-9
+
+// PaperPosition define simulated position of a symbol and position side
+type PaperPosition struct {
+	Symbol       string
+	PositionSide futures.PositionSideType
+	// Amount is positive for long, negative for short
+	Amount      float64
+	EntryPrice  float64
+	RealizedPnL float64
+	Commission  float64
+}
+
+type paperLevel struct {
+	price    float64
+	quantity float64
+}
+
+// paperBook define simulated book of a symbol, bids sorted best first, asks sorted best first
+type paperBook struct {
+	bids []paperLevel
+	asks []paperLevel
+	time int64
+}
+
+type paperOrder struct {
+	order    ChildOrder
+	id       int64
+	price    float64
+	quantity float64
+	executed float64
+	cumQuote float64
+	status   futures.OrderStatusType
+	time     int64
+}
+
+type paperPositionKey struct {
+	symbol       string
+	positionSide futures.PositionSideType
+}
+
+// PaperPlacer is an OrderPlacer simulating child orders against market data, so algorithms and
+// strategies run end to end without real orders. Feed it bookTicker or partial depth events,
+// orders crossing the book fill as taker at the book prices, resting orders fill as maker at
+// their price once the opposite side of the book reaches it. Liquidity taken from a level is
+// gone until the next event of the symbol. Execution reports are delivered as
+// ORDER_TRADE_UPDATE events to the handlers registered with OnUserDataEvent.
+type PaperPlacer struct {
+	fees futures.FeeRates
+
+	mu    sync.Mutex
+	books map[string]*paperBook
+	// orders are the open orders by client order id, resting are those of each symbol by time
+	// priority. Orders are removed once they are filled, canceled or expired.
+	orders    map[string]*paperOrder
+	resting   map[string][]*paperOrder
+	positions map[paperPositionKey]*PaperPosition
+	handlers  []futures.WsUserDataHandler
+	seq       int64
+	tradeID   int64
+}
+
+// NewPaperPlacer init PaperPlacer charging commissions at fees
+func NewPaperPlacer(fees futures.FeeRates) *PaperPlacer {
+	return &PaperPlacer{
+		fees:      fees,
+		books:     make(map[string]*paperBook),
+		orders:    make(map[string]*paperOrder),
+		resting:   make(map[string][]*paperOrder),
+		positions: make(map[paperPositionKey]*PaperPosition),
+	}
+}
+
+// OnUserDataEvent registers handler receiving synthetic execution reports, e.g.
+// HandleUserDataEvent of TWAP or Iceberg. Handlers are called synchronously.
+func (p *PaperPlacer) OnUserDataEvent(handler futures.WsUserDataHandler) *PaperPlacer {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.handlers = append(p.handlers, handler)
+	return p
+}
+
+// HandleBookTicker replaces the book of the symbol with the best bid and ask of event and fills
+// resting orders it reaches
+func (p *PaperPlacer) HandleBookTicker(event *futures.WsBookTickerEvent) {
+	bids, err := parsePaperLevels([]common.PriceLevel{{Price: event.BestBidPrice, Quantity: event.BestBidQty}})
+	if err != nil {
+		return
+	}
+	asks, err := parsePaperLevels([]common.PriceLevel{{Price: event.BestAskPrice, Quantity: event.BestAskQty}})
+	if err != nil {
+		return
+	}
+	p.setBook(event.Symbol, bids, asks, event.Time)
+}
+
+// HandleDepthEvent replaces the book of the symbol with the levels of event, as pushed by partial
+// depth streams, and fills resting orders it reaches
+func (p *PaperPlacer) HandleDepthEvent(event *futures.WsDepthEvent) {
+	bids, err := parsePaperLevels(event.Bids)
+	if err != nil {
+		return
+	}
+	asks, err := parsePaperLevels(event.Asks)
+	if err != nil {
+		return
+	}
+	p.setBook(event.Symbol, bids, asks, event.Time)
+}
+
+// PlaceOrder simulates a limit order, the response holds its state after matching against the
+// current book
+func (p *PaperPlacer) PlaceOrder(ctx context.Context, order ChildOrder) (*futures.CreateOrderResponse, error) {
+	quantity, err := strconv.ParseFloat(order.Quantity, 64)
+	if err != nil || quantity <= 0 {
+		return nil, ErrInvalidParams
+	}
+	price, err := strconv.ParseFloat(order.Price, 64)
+	if err != nil || price <= 0 {
+		return nil, ErrInvalidParams
+	}
+	if order.TimeInForce == "" {
+		order.TimeInForce = futures.TimeInForceTypeGTC
+	}
+	if order.PositionSide == "" {
+		order.PositionSide = futures.PositionSideTypeBoth
+	}
+
+	p.mu.Lock()
+	book, ok := p.books[order.Symbol]
+	if !ok {
+		p.mu.Unlock()
+		return nil, ErrNoMarketData
+	}
+	p.seq++
+	if order.ClientOrderID == "" {
+		order.ClientOrderID = fmt.Sprintf("paper-%d", p.seq)
+	}
+	if _, ok := p.orders[order.ClientOrderID]; ok {
+		p.mu.Unlock()
+		return nil, &common.APIError{Code: -4116, Message: "ClientOrderId is duplicated."}
+	}
+	if order.ReduceOnly && !p.reduces(order, quantity) {
+		p.mu.Unlock()
+		return nil, &common.APIError{Code: -2022, Message: "ReduceOnly Order is rejected."}
+	}
+
+	o := &paperOrder{
+		order:    order,
+		id:       p.seq,
+		price:    price,
+		quantity: quantity,
+		status:   futures.OrderStatusTypeNew,
+		time:     book.now(),
+	}
+	events := []*futures.WsUserDataEvent{p.report(o, futures.OrderExecutionTypeNew, 0, 0, false, 0, 0)}
+
+	available := p.available(book, o)
+	switch {
+	case order.TimeInForce == futures.TimeInForceTypeGTX && available > 0:
+		// post only orders never take liquidity
+		o.status = futures.OrderStatusTypeExpired
+		events = append(events, p.report(o, futures.OrderExecutionTypeExpired, 0, 0, false, 0, 0))
+	case order.TimeInForce == futures.TimeInForceTypeFOK && available < quantity-paperEpsilon:
+		o.status = futures.OrderStatusTypeExpired
+		events = append(events, p.report(o, futures.OrderExecutionTypeExpired, 0, 0, false, 0, 0))
+	default:
+		events = append(events, p.match(book, o, false)...)
+		if o.open() && (order.TimeInForce == futures.TimeInForceTypeIOC || order.TimeInForce == futures.TimeInForceTypeFOK) {
+			o.status = futures.OrderStatusTypeExpired
+			events = append(events, p.report(o, futures.OrderExecutionTypeExpired, 0, 0, false, 0, 0))
+		}
+	}
+	if o.open() {
+		p.orders[order.ClientOrderID] = o
+		p.resting[order.Symbol] = append(p.resting[order.Symbol], o)
+	}
+	res := o.response()
+	handlers := p.handlers
+	p.mu.Unlock()
+
+	dispatchPaperEvents(handlers, events)
+	return res, nil
+}
+
+// CancelOrder cancels a resting simulated order
+func (p *PaperPlacer) CancelOrder(ctx context.Context, symbol, clientOrderID string) error {
+	p.mu.Lock()
+	o, ok := p.orders[clientOrderID]
+	if !ok || o.order.Symbol != symbol {
+		p.mu.Unlock()
+		return &common.APIError{Code: -2011, Message: "Unknown order sent."}
+	}
+	o.status = futures.OrderStatusTypeCanceled
+	p.removeOrders(symbol)
+	if book, ok := p.books[symbol]; ok {
+		o.time = book.now()
+	}
+	events := []*futures.WsUserDataEvent{p.report(o, futures.OrderExecutionTypeCanceled, 0, 0, false, 0, 0)}
+	handlers := p.handlers
+	p.mu.Unlock()
+
+	dispatchPaperEvents(handlers, events)
+	return nil
+}
+
+// Order returns state of the open simulated order with clientOrderID, orders are forgotten once
+// they are filled, canceled or expired
+func (p *PaperPlacer) Order(clientOrderID string) (*futures.CreateOrderResponse, bool) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	o, ok := p.orders[clientOrderID]
+	if !ok {
+		return nil, false
+	}
+	return o.response(), true
+}
+
+// QueryOrder returns the open simulated order with clientOrderID, the *common.APIError -2013
+// once it is forgotten
+func (p *PaperPlacer) QueryOrder(ctx context.Context, symbol, clientOrderID string) (*futures.Order, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	o, ok := p.orders[clientOrderID]
+	if !ok || o.order.Symbol != symbol {
+		return nil, &common.APIError{Code: errCodeOrderDoesNotExist, Message: "Order does not exist."}
+	}
+	res := o.response()
+	return &futures.Order{
+		Symbol:           res.Symbol,
+		OrderID:          res.OrderID,
+		ClientOrderID:    res.ClientOrderID,
+		Price:            res.Price,
+		ReduceOnly:       res.ReduceOnly,
+		OrigQuantity:     res.OrigQuantity,
+		ExecutedQuantity: res.ExecutedQuantity,
+		CumQuote:         res.CumQuote,
+		Status:           res.Status,
+		TimeInForce:      res.TimeInForce,
+		Type:             res.Type,
+		Side:             res.Side,
+		StopPrice:        res.StopPrice,
+		UpdateTime:       res.UpdateTime,
+		AvgPrice:         res.AvgPrice,
+		PositionSide:     res.PositionSide,
+	}, nil
+}
+
+// Position returns simulated position of symbol and positionSide, PositionSideTypeBoth in one-way mode
+func (p *PaperPlacer) Position(symbol string, positionSide futures.PositionSideType) PaperPosition {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if pos, ok := p.positions[paperPositionKey{symbol: symbol, positionSide: positionSide}]; ok {
+		return *pos
+	}
+	return PaperPosition{Symbol: symbol, PositionSide: positionSide}
+}
+
+// Positions returns all simulated positions, including closed ones holding realized PnL
+func (p *PaperPlacer) Positions() []PaperPosition {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	res := make([]PaperPosition, 0, len(p.positions))
+	for _, pos := range p.positions {
+		res = append(res, *pos)
+	}
+	sort.Slice(res, func(i, j int) bool {
+		if res[i].Symbol != res[j].Symbol {
+			return res[i].Symbol < res[j].Symbol
+		}
+		return res[i].PositionSide < res[j].PositionSide
+	})
+	return res
+}
+
+// setBook replaces the book of symbol and fills resting orders it reaches
+func (p *PaperPlacer) setBook(symbol string, bids, asks []paperLevel, eventTime int64) {
+	sort.Slice(bids, func(i, j int) bool { return bids[i].price > bids[j].price })
+	sort.Slice(asks, func(i, j int) bool { return asks[i].price < asks[j].price })
+
+	p.mu.Lock()
+	book := &paperBook{bids: bids, asks: asks, time: eventTime}
+	p.books[symbol] = book
+
+	// older orders have time priority on the simulated liquidity
+	var events []*futures.WsUserDataEvent
+	for _, o := range p.resting[symbol] {
+		events = append(events, p.match(book, o, true)...)
+	}
+	p.removeOrders(symbol)
+	handlers := p.handlers
+	p.mu.Unlock()
+
+	dispatchPaperEvents(handlers, events)
+}
+
+// removeOrders removes the orders of symbol which are no longer open
+func (p *PaperPlacer) removeOrders(symbol string) {
+	orders := p.resting[symbol]
+	resting := orders[:0]
+	for _, o := range orders {
+		if o.open() {
+			resting = append(resting, o)
+		} else {
+			delete(p.orders, o.order.ClientOrderID)
+		}
+	}
+	clear(orders[len(resting):])
+	if len(resting) == 0 {
+		delete(p.resting, symbol)
+		return
+	}
+	p.resting[symbol] = resting
+}
+
+// available returns quantity of the book o can fill against at its limit price
+func (p *PaperPlacer) available(book *paperBook, o *paperOrder) float64 {
+	var quantity float64
+	for _, level := range book.opposite(o.order.Side) {
+		if !o.crosses(level.price) {
+			break
+		}
+		quantity += level.quantity
+	}
+	return quantity
+}
+
+// match fills o against the levels of book it crosses, as maker at its price when resting and as
+// taker at the level prices otherwise, and takes the filled quantity off the levels
+func (p *PaperPlacer) match(book *paperBook, o *paperOrder, maker bool) []*futures.WsUserDataEvent {
+	var events []*futures.WsUserDataEvent
+	levels := book.opposite(o.order.Side)
+	for i := range levels {
+		remaining := o.quantity - o.executed
+		if remaining <= paperEpsilon || !o.crosses(levels[i].price) {
+			break
+		}
+		quantity := math.Min(remaining, levels[i].quantity)
+		if quantity <= paperEpsilon {
+			continue
+		}
+		levels[i].quantity -= quantity
+		price := levels[i].price
+		if maker {
+			price = o.price
+		}
+
+		o.executed += quantity
+		o.cumQuote += quantity * price
+		o.time = book.now()
+		o.status = futures.OrderStatusTypePartiallyFilled
+		if o.quantity-o.executed <= paperEpsilon {
+			o.executed = o.quantity
+			o.status = futures.OrderStatusTypeFilled
+		}
+		commission := quantity * price * p.fees.Rate(maker)
+		realizedPnL := p.applyFill(o.order, quantity, price, commission)
+		events = append(events, p.report(o, futures.OrderExecutionTypeTrade, quantity, price, maker, commission, realizedPnL))
+	}
+	return events
+}
+
+// reduces reports whether order only reduces the position it applies to
+func (p *PaperPlacer) reduces(order ChildOrder, quantity float64) bool {
+	pos, ok := p.positions[paperPositionKey{symbol: order.Symbol, positionSide: order.PositionSide}]
+	if !ok {
+		return false
+	}
+	if order.Side == futures.SideTypeBuy {
+		return pos.Amount < 0 && quantity <= -pos.Amount+paperEpsilon
+	}
+	return pos.Amount > 0 && quantity <= pos.Amount+paperEpsilon
+}
+
+// applyFill updates the position of order with a fill and returns the PnL it realized
+func (p *PaperPlacer) applyFill(order ChildOrder, quantity, price, commission float64) float64 {
+	key := paperPositionKey{symbol: order.Symbol, positionSide: order.PositionSide}
+	pos, ok := p.positions[key]
+	if !ok {
+		pos = &PaperPosition{Symbol: order.Symbol, PositionSide: order.PositionSide}
+		p.positions[key] = pos
+	}
+	pos.Commission += commission
+
+	signed := quantity
+	if order.Side == futures.SideTypeSell {
+		signed = -quantity
+	}
+	var realized float64
+	switch {
+	case pos.Amount == 0 || (pos.Amount > 0) == (signed > 0):
+		total := math.Abs(pos.Amount) + quantity
+		pos.EntryPrice = (math.Abs(pos.Amount)*pos.EntryPrice + quantity*price) / total
+		pos.Amount += signed
+	default:
+		closed := math.Min(math.Abs(pos.Amount), quantity)
+		if pos.Amount > 0 {
+			realized = closed * (price - pos.EntryPrice)
+		} else {
+			realized = closed * (pos.EntryPrice - price)
+		}
+		pos.Amount += signed
+		switch {
+		case math.Abs(pos.Amount) <= paperEpsilon:
+			pos.Amount, pos.EntryPrice = 0, 0
+		case quantity > closed:
+			// the fill flipped the position, the rest opens at the fill price
+			pos.EntryPrice = price
+		}
+	}
+	pos.RealizedPnL += realized
+	return realized
+}
+
+// report builds execution report of o
+func (p *PaperPlacer) report(o *paperOrder, executionType futures.OrderExecutionType, lastQuantity, lastPrice float64,
+	maker bool, commission, realizedPnL float64) *futures.WsUserDataEvent {
+	u := futures.WsOrderTradeUpdate{
+		Symbol:               o.order.Symbol,
+		ClientOrderID:        o.order.ClientOrderID,
+		Side:                 o.order.Side,
+		Type:                 futures.OrderTypeLimit,
+		TimeInForce:          o.order.TimeInForce,
+		OriginalQty:          o.order.Quantity,
+		OriginalPrice:        o.order.Price,
+		AveragePrice:         formatPaperFloat(o.avgPrice()),
+		StopPrice:            "0",
+		ExecutionType:        executionType,
+		Status:               o.status,
+		ID:                   o.id,
+		LastFilledQty:        formatPaperFloat(lastQuantity),
+		AccumulatedFilledQty: formatPaperFloat(o.executed),
+		LastFilledPrice:      formatPaperFloat(lastPrice),
+		TradeTime:            o.time,
+		IsMaker:              maker,
+		IsReduceOnly:         o.order.ReduceOnly,
+		OriginalType:         futures.OrderTypeLimit,
+		PositionSide:         o.order.PositionSide,
+		RealizedPnL:          formatPaperFloat(realizedPnL),
+	}
+	if executionType == futures.OrderExecutionTypeTrade {
+		p.tradeID++
+		u.TradeID = p.tradeID
+		u.Commission = formatPaperFloat(commission)
+	}
+	return &futures.WsUserDataEvent{
+		Event:                      futures.UserDataEventTypeOrderTradeUpdate,
+		Time:                       o.time,
+		TransactionTime:            o.time,
+		WsUserDataOrderTradeUpdate: futures.WsUserDataOrderTradeUpdate{OrderTradeUpdate: u},
+	}
+}
+
+func (o *paperOrder) open() bool {
+	return o.status == futures.OrderStatusTypeNew || o.status == futures.OrderStatusTypePartiallyFilled
+}
+
+// crosses reports whether o can fill against a level of the opposite side at price
+func (o *paperOrder) crosses(price float64) bool {
+	if o.order.Side == futures.SideTypeBuy {
+		return price <= o.price
+	}
+	return price >= o.price
+}
+
+func (o *paperOrder) avgPrice() float64 {
+	if o.executed == 0 {
+		return 0
+	}
+	return o.cumQuote / o.executed
+}
+
+func (o *paperOrder) response() *futures.CreateOrderResponse {
+	return &futures.CreateOrderResponse{
+		Symbol:           o.order.Symbol,
+		OrderID:          o.id,
+		ClientOrderID:    o.order.ClientOrderID,
+		Price:            o.order.Price,
+		OrigQuantity:     o.order.Quantity,
+		ExecutedQuantity: formatPaperFloat(o.executed),
+		CumQuote:         formatPaperFloat(o.cumQuote),
+		ReduceOnly:       o.order.ReduceOnly,
+		Status:           o.status,
+		StopPrice:        "0",
+		TimeInForce:      o.order.TimeInForce,
+		Type:             futures.OrderTypeLimit,
+		Side:             o.order.Side,
+		UpdateTime:       o.time,
+		AvgPrice:         formatPaperFloat(o.avgPrice()),
+		PositionSide:     o.order.PositionSide,
+	}
+}
+
+// opposite returns the levels an order of side fills against
+func (b *paperBook) opposite(side futures.SideType) []paperLevel {
+	if side == futures.SideTypeBuy {
+		return b.asks
+	}
+	return b.bids
+}
+
+// now returns time of the last market data event, the wall clock if the event had none
+func (b *paperBook) now() int64 {
+	if b.time > 0 {
+		return b.time
+	}
+	return time.Now().UnixMilli()
+}
+
+func parsePaperLevels(levels []common.PriceLevel) ([]paperLevel, error) {
+	res := make([]paperLevel, 0, len(levels))
+	for i := range levels {
+		price, quantity, err := levels[i].Parse()
+		if err != nil {
+			return nil, err
+		}
+		if quantity > 0 {
+			res = append(res, paperLevel{price: price, quantity: quantity})
+		}
+	}
+	return res, nil
+}
+
+func dispatchPaperEvents(handlers []futures.WsUserDataHandler, events []*futures.WsUserDataEvent) {
+	for _, event := range events {
+		for _, handler := range handlers {
+			handler(event)
+		}
+	}
+}
+
+// formatPaperFloat formats v without the float noise of simulated sums
+func formatPaperFloat(v float64) string {
+	return strconv.FormatFloat(math.Round(v*1e8)/1e8, 'f', -1, 64)
+}
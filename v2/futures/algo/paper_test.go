@@ -0,0 +1,254 @@
+package algo
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/adshao/go-binance/v2/common"
+	"github.com/adshao/go-binance/v2/futures"
+	"github.com/stretchr/testify/assert"
+)
+
+type paperReports struct {
+	mu     sync.Mutex
+	events []futures.WsOrderTradeUpdate
+}
+
+func (r *paperReports) handle(event *futures.WsUserDataEvent) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.events = append(r.events, event.OrderTradeUpdate)
+}
+
+func (r *paperReports) executionTypes() []futures.OrderExecutionType {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	res := make([]futures.OrderExecutionType, 0, len(r.events))
+	for _, e := range r.events {
+		res = append(res, e.ExecutionType)
+	}
+	return res
+}
+
+func newTestPaperPlacer() (*PaperPlacer, *paperReports) {
+	reports := &paperReports{}
+	p := NewPaperPlacer(futures.FeeRates{Maker: 0.0002, Taker: 0.0005}).OnUserDataEvent(reports.handle)
+	p.HandleDepthEvent(&futures.WsDepthEvent{
+		Symbol: "BTCUSDT",
+		Time:   1000,
+		Bids:   []futures.Bid{{Price: "99", Quantity: "1"}, {Price: "98", Quantity: "2"}},
+		Asks:   []futures.Ask{{Price: "101", Quantity: "1"}, {Price: "102", Quantity: "2"}},
+	})
+	return p, reports
+}
+
+func TestPaperPlacerTakerIOC(t *testing.T) {
+	assert := assert.New(t)
+	p, reports := newTestPaperPlacer()
+
+	res, err := p.PlaceOrder(context.Background(), ChildOrder{
+		Symbol:        "BTCUSDT",
+		Side:          futures.SideTypeBuy,
+		TimeInForce:   futures.TimeInForceTypeIOC,
+		Quantity:      "4",
+		Price:         "102",
+		ClientOrderID: "ioc",
+	})
+	assert.NoError(err)
+	assert.Equal(futures.OrderStatusTypeExpired, res.Status)
+	assert.Equal("3", res.ExecutedQuantity)
+	assert.Equal("305", res.CumQuote)
+	assert.Equal([]futures.OrderExecutionType{
+		futures.OrderExecutionTypeNew,
+		futures.OrderExecutionTypeTrade,
+		futures.OrderExecutionTypeTrade,
+		futures.OrderExecutionTypeExpired,
+	}, reports.executionTypes())
+	assert.Equal("101", reports.events[1].LastFilledPrice)
+	assert.False(reports.events[1].IsMaker)
+	assert.Equal("0.0505", reports.events[1].Commission)
+	assert.Equal(int64(1000), reports.events[1].TradeTime)
+
+	pos := p.Position("BTCUSDT", futures.PositionSideTypeBoth)
+	assert.Equal(3.0, pos.Amount)
+	assert.InDelta(305.0/3, pos.EntryPrice, 1e-9)
+
+	// the taken liquidity is gone until the next event
+	_, err = p.PlaceOrder(context.Background(), ChildOrder{
+		Symbol:        "BTCUSDT",
+		Side:          futures.SideTypeBuy,
+		TimeInForce:   futures.TimeInForceTypeFOK,
+		Quantity:      "1",
+		Price:         "102",
+		ClientOrderID: "fok",
+	})
+	assert.NoError(err)
+	last := reports.events[len(reports.events)-1]
+	assert.Equal("fok", last.ClientOrderID)
+	assert.Equal(futures.OrderStatusTypeExpired, last.Status)
+	assert.Equal("0", last.AccumulatedFilledQty)
+	// expired orders are forgotten
+	_, ok := p.Order("fok")
+	assert.False(ok)
+}
+
+func TestPaperPlacerRestingOrder(t *testing.T) {
+	assert := assert.New(t)
+	p, reports := newTestPaperPlacer()
+
+	res, err := p.PlaceOrder(context.Background(), ChildOrder{
+		Symbol:        "BTCUSDT",
+		Side:          futures.SideTypeBuy,
+		TimeInForce:   futures.TimeInForceTypeGTX,
+		Quantity:      "1",
+		Price:         "101",
+		ClientOrderID: "crossing",
+	})
+	assert.NoError(err)
+	assert.Equal(futures.OrderStatusTypeExpired, res.Status)
+
+	res, err = p.PlaceOrder(context.Background(), ChildOrder{
+		Symbol:        "BTCUSDT",
+		Side:          futures.SideTypeBuy,
+		TimeInForce:   futures.TimeInForceTypeGTX,
+		Quantity:      "2",
+		Price:         "100",
+		ClientOrderID: "maker",
+	})
+	assert.NoError(err)
+	assert.Equal(futures.OrderStatusTypeNew, res.Status)
+
+	p.HandleBookTicker(&futures.WsBookTickerEvent{
+		Symbol:       "BTCUSDT",
+		Time:         2000,
+		BestBidPrice: "99",
+		BestBidQty:   "1",
+		BestAskPrice: "99.5",
+		BestAskQty:   "0.5",
+	})
+	res, _ = p.Order("maker")
+	assert.Equal(futures.OrderStatusTypePartiallyFilled, res.Status)
+	assert.Equal("0.5", res.ExecutedQuantity)
+	last := reports.events[len(reports.events)-1]
+	assert.Equal("100", last.LastFilledPrice)
+	assert.True(last.IsMaker)
+	assert.Equal("0.01", last.Commission)
+
+	assert.NoError(p.CancelOrder(context.Background(), "BTCUSDT", "maker"))
+	assert.Equal(futures.OrderStatusTypeCanceled, reports.events[len(reports.events)-1].Status)
+	_, ok := p.Order("maker")
+	assert.False(ok)
+	err = p.CancelOrder(context.Background(), "BTCUSDT", "maker")
+	assert.True(common.IsAPIErrorCode(err, -2011))
+}
+
+func TestPaperPlacerForgetsDoneOrders(t *testing.T) {
+	assert := assert.New(t)
+	p, _ := newTestPaperPlacer()
+	p.HandleBookTicker(&futures.WsBookTickerEvent{
+		Symbol:       "ETHUSDT",
+		Time:         1000,
+		BestBidPrice: "9",
+		BestBidQty:   "1",
+		BestAskPrice: "11",
+		BestAskQty:   "1",
+	})
+
+	for _, order := range []ChildOrder{
+		{Symbol: "BTCUSDT", Side: futures.SideTypeBuy, Quantity: "1", Price: "100", ClientOrderID: "first"},
+		{Symbol: "BTCUSDT", Side: futures.SideTypeBuy, Quantity: "1", Price: "100", ClientOrderID: "second"},
+		{Symbol: "ETHUSDT", Side: futures.SideTypeBuy, Quantity: "1", Price: "10", ClientOrderID: "eth"},
+	} {
+		_, err := p.PlaceOrder(context.Background(), order)
+		assert.NoError(err)
+	}
+	assert.Len(p.resting["BTCUSDT"], 2)
+
+	// the older order fills first and is forgotten, the order of the other symbol isn't matched
+	p.HandleBookTicker(&futures.WsBookTickerEvent{
+		Symbol:       "BTCUSDT",
+		Time:         2000,
+		BestBidPrice: "98",
+		BestBidQty:   "1",
+		BestAskPrice: "99",
+		BestAskQty:   "1",
+	})
+	_, ok := p.Order("first")
+	assert.False(ok)
+	res, ok := p.Order("second")
+	assert.True(ok)
+	assert.Equal(futures.OrderStatusTypeNew, res.Status)
+	res, ok = p.Order("eth")
+	assert.True(ok)
+	assert.Equal(futures.OrderStatusTypeNew, res.Status)
+	assert.Len(p.orders, 2)
+	assert.Len(p.resting["BTCUSDT"], 1)
+
+	// client order ids of done orders may be reused
+	assert.NoError(p.CancelOrder(context.Background(), "BTCUSDT", "second"))
+	assert.NoError(p.CancelOrder(context.Background(), "ETHUSDT", "eth"))
+	assert.Empty(p.orders)
+	assert.Empty(p.resting)
+	_, err := p.PlaceOrder(context.Background(), ChildOrder{
+		Symbol: "BTCUSDT", Side: futures.SideTypeBuy, Quantity: "1", Price: "90", ClientOrderID: "first",
+	})
+	assert.NoError(err)
+}
+
+func TestPaperPlacerPositions(t *testing.T) {
+	assert := assert.New(t)
+	p, _ := newTestPaperPlacer()
+
+	_, err := p.PlaceOrder(context.Background(), ChildOrder{
+		Symbol:     "BTCUSDT",
+		Side:       futures.SideTypeSell,
+		Quantity:   "1",
+		Price:      "99",
+		ReduceOnly: true,
+	})
+	assert.True(common.IsAPIErrorCode(err, -2022))
+
+	_, err = p.PlaceOrder(context.Background(), ChildOrder{Symbol: "BTCUSDT", Side: futures.SideTypeBuy, Quantity: "1", Price: "101"})
+	assert.NoError(err)
+	// sells 1 at 99 closing the long, 1 at 98 opening a short
+	_, err = p.PlaceOrder(context.Background(), ChildOrder{Symbol: "BTCUSDT", Side: futures.SideTypeSell, Quantity: "2", Price: "98"})
+	assert.NoError(err)
+
+	pos := p.Position("BTCUSDT", futures.PositionSideTypeBoth)
+	assert.Equal(-1.0, pos.Amount)
+	assert.Equal(98.0, pos.EntryPrice)
+	assert.Equal(-2.0, pos.RealizedPnL)
+	assert.InDelta((101+99+98)*0.0005, pos.Commission, 1e-9)
+	assert.Len(p.Positions(), 1)
+
+	_, err = p.PlaceOrder(context.Background(), ChildOrder{Symbol: "ETHUSDT", Side: futures.SideTypeBuy, Quantity: "1", Price: "1"})
+	assert.ErrorIs(err, ErrNoMarketData)
+}
+
+func TestPaperPlacerDrivesTWAP(t *testing.T) {
+	assert := assert.New(t)
+	p, _ := newTestPaperPlacer()
+
+	twap, err := NewTWAP(p, TWAPParams{
+		Symbol:            "BTCUSDT",
+		Side:              futures.SideTypeSell,
+		Quantity:          2,
+		QuantityPrecision: 3,
+		Duration:          20 * time.Millisecond,
+		Slices:            2,
+		Price: func(ctx context.Context) (string, error) {
+			return "98", nil
+		},
+	})
+	assert.NoError(err)
+	p.OnUserDataEvent(twap.HandleUserDataEvent)
+
+	twap.Start(context.Background())
+	<-twap.Done()
+	assert.Equal(2.0, twap.Progress().Filled)
+	assert.Equal(-2.0, p.Position("BTCUSDT", futures.PositionSideTypeBoth).Amount)
+}
@@ -0,0 +1,142 @@
+// Package algo implements execution algorithms slicing a parent order into child orders
+// placed through the futures websocket API.
+package algo
+
+import (
+	"context"
+	"math"
+	"strconv"
+
+	"github.com/adshao/go-binance/v2/common"
+	"github.com/adshao/go-binance/v2/futures"
+)
+
+const (
+	// errCodeUnknownStatus is returned when the outcome of a request is unknown to the exchange
+	errCodeUnknownStatus = -1007
+	// errCodeOrderDoesNotExist is returned when querying an order which was never placed
+	errCodeOrderDoesNotExist = -2013
+)
+
+// ChildOrder define order placed by an algorithm
+type ChildOrder struct {
+	Symbol        string
+	Side          futures.SideType
+	PositionSide  futures.PositionSideType
+	TimeInForce   futures.TimeInForceType
+	Quantity      string
+	Price         string
+	ReduceOnly    bool
+	ClientOrderID string
+}
+
+// OrderPlacer places, cancels and queries child orders of algorithms
+type OrderPlacer interface {
+	PlaceOrder(ctx context.Context, order ChildOrder) (*futures.CreateOrderResponse, error)
+	CancelOrder(ctx context.Context, symbol, clientOrderID string) error
+	// QueryOrder returns the order with clientOrderID, or the *common.APIError -2013 if there
+	// is none. Algorithms query child orders whose placement outcome is unknown, e.g. after a
+	// timeout.
+	QueryOrder(ctx context.Context, symbol, clientOrderID string) (*futures.Order, error)
+}
+
+// wsOrderPlacer places child orders as limit orders with OrderPlaceWsService
+type wsOrderPlacer struct {
+	place  *futures.OrderPlaceWsService
+	cancel *futures.OrderCancelWsService
+	status *futures.OrderStatusWsService
+}
+
+// NewWsOrderPlacer init OrderPlacer sending limit orders over the connection of c
+func NewWsOrderPlacer(c *futures.ClientWs) OrderPlacer {
+	return &wsOrderPlacer{
+		place:  c.NewOrderPlaceWsService(),
+		cancel: c.NewOrderCancelWsService(),
+		status: c.NewOrderStatusWsService(),
+	}
+}
+
+func (p *wsOrderPlacer) PlaceOrder(ctx context.Context, order ChildOrder) (*futures.CreateOrderResponse, error) {
+	req := futures.NewOrderPlaceWsRequest().
+		Symbol(order.Symbol).
+		Side(order.Side).
+		Type(futures.OrderTypeLimit).
+		TimeInForce(order.TimeInForce).
+		Quantity(order.Quantity).
+		Price(order.Price).
+		NewClientOrderID(order.ClientOrderID).
+		NewOrderResponseType(futures.NewOrderRespTypeRESULT)
+	if order.PositionSide != "" {
+		req.PositionSide(order.PositionSide)
+	}
+	if order.ReduceOnly {
+		req.ReduceOnly(true)
+	}
+	return p.place.Do(ctx, req)
+}
+
+func (p *wsOrderPlacer) CancelOrder(ctx context.Context, symbol, clientOrderID string) error {
+	req := futures.NewCancelOrderRequest().Symbol(symbol).OrigClientOrderID(clientOrderID)
+	_, err := p.cancel.Do(ctx, req)
+	return err
+}
+
+func (p *wsOrderPlacer) QueryOrder(ctx context.Context, symbol, clientOrderID string) (*futures.Order, error) {
+	return p.status.Do(ctx, futures.NewOrderStatusWsRequest().Symbol(symbol).OrigClientOrderID(clientOrderID))
+}
+
+// childState define state of a child order placed by an algorithm
+type childState struct {
+	quantity float64
+	executed float64
+	status   futures.OrderStatusType
+	// unknown is set while the placement outcome of the order is unknown, the order counts as
+	// open until it is queried
+	unknown bool
+}
+
+func (c *childState) open() bool {
+	return c.status == futures.OrderStatusTypeNew || c.status == futures.OrderStatusTypePartiallyFilled
+}
+
+// update applies status and executed quantity, events and responses may arrive in any order
+func (c *childState) update(status futures.OrderStatusType, executed float64) {
+	if executed > c.executed {
+		c.executed = executed
+	}
+	if c.open() {
+		c.status = status
+	}
+}
+
+// resolve applies the state queried of an order whose placement outcome was unknown
+func (c *childState) resolve(status futures.OrderStatusType, executed float64) {
+	c.unknown = false
+	c.update(status, executed)
+}
+
+// placementUnknown reports whether a placement failing with err may have placed the order,
+// which is the case unless the exchange rejected it
+func placementUnknown(err error) bool {
+	return !common.IsAPIError(err) || common.IsAPIErrorCode(err, errCodeUnknownStatus)
+}
+
+// queryChild returns status and executed quantity of the child order with clientOrderID,
+// OrderStatusTypeRejected if it was never placed
+func queryChild(ctx context.Context, placer OrderPlacer, symbol, clientOrderID string) (futures.OrderStatusType, float64, error) {
+	order, err := placer.QueryOrder(ctx, symbol, clientOrderID)
+	switch {
+	case common.IsAPIErrorCode(err, errCodeOrderDoesNotExist):
+		return futures.OrderStatusTypeRejected, 0, nil
+	case err != nil:
+		return "", 0, err
+	}
+	executed, _ := strconv.ParseFloat(order.ExecutedQuantity, 64)
+	return order.Status, executed, nil
+}
+
+// roundDown rounds quantity down to precision, tolerating float error
+func roundDown(quantity float64, precision int) float64 {
+	unit := math.Pow10(precision)
+	return math.Floor(quantity*unit+1e-9) / unit
+}
@@ -0,0 +1,351 @@
+package algo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/adshao/go-binance/v2/futures"
+)
+
+var (
+	ErrInvalidParams = errors.New("algo: invalid params")
+	ErrAborted       = errors.New("algo: aborted")
+)
+
+// PriceFunc returns limit price of the next child order, e.g. the best bid for a post only buy
+type PriceFunc func(ctx context.Context) (string, error)
+
+// TWAPParams define parent order executed by TWAP
+type TWAPParams struct {
+	Symbol       string
+	Side         futures.SideType
+	PositionSide futures.PositionSideType
+	ReduceOnly   bool
+	// Quantity is the parent quantity, child quantities are rounded down to QuantityPrecision
+	Quantity          float64
+	QuantityPrecision int
+	// Duration is spread over Slices child orders placed at equal intervals, the first one immediately
+	Duration time.Duration
+	Slices   int
+	// TimeInForce of child orders, TimeInForceTypeIOC or TimeInForceTypeGTX
+	TimeInForce futures.TimeInForceType
+	Price       PriceFunc
+	// OnError is called when a child order can not be placed, queried or canceled, the
+	// quantity is caught up by the next slices. Children whose placement outcome is unknown,
+	// e.g. after a timeout, count as open until they are queried.
+	OnError futures.ErrHandler
+}
+
+// TWAPProgress define execution state of TWAP
+type TWAPProgress struct {
+	Quantity float64
+	Filled   float64
+	// Open is the unfilled quantity of live child orders
+	Open   float64
+	Placed int
+	Slices int
+	Done   bool
+	Err    error
+}
+
+// TWAP slices a parent quantity over a duration and places child limit orders, each sized to
+// bring the filled quantity up to the schedule. Pass it the events of the user data stream so
+// fills of resting post only children are counted. The last child rests until it is filled or
+// its slice interval ends, child orders left then are canceled.
+type TWAP struct {
+	placer OrderPlacer
+	params TWAPParams
+	prefix string
+
+	mu       sync.Mutex
+	children map[string]*childState
+	placed   int
+	err      error
+	cancel   context.CancelFunc
+	closedC  chan struct{}
+	doneC    chan struct{}
+}
+
+// NewTWAP init TWAP placing child orders with placer
+func NewTWAP(placer OrderPlacer, params TWAPParams) (*TWAP, error) {
+	if params.Symbol == "" || params.Quantity <= 0 || params.Slices <= 0 || params.Duration <= 0 || params.Price == nil {
+		return nil, ErrInvalidParams
+	}
+	if params.TimeInForce == "" {
+		params.TimeInForce = futures.TimeInForceTypeIOC
+	}
+	return &TWAP{
+		placer:   placer,
+		params:   params,
+		prefix:   fmt.Sprintf("twap%d-", time.Now().UnixNano()),
+		children: make(map[string]*childState),
+		closedC:  make(chan struct{}, 1),
+		doneC:    make(chan struct{}),
+	}, nil
+}
+
+// Start starts placing child orders in background, starting a started TWAP does nothing
+func (t *TWAP) Start(ctx context.Context) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if t.cancel != nil {
+		return
+	}
+	ctx, t.cancel = context.WithCancel(ctx)
+	go t.run(ctx)
+}
+
+// Abort stops placing child orders, cancels the live ones and waits until done
+func (t *TWAP) Abort() {
+	t.mu.Lock()
+	cancel := t.cancel
+	if cancel != nil && t.err == nil {
+		t.err = ErrAborted
+	}
+	t.mu.Unlock()
+
+	if cancel == nil {
+		return
+	}
+	cancel()
+	<-t.doneC
+}
+
+// Done returns channel closed when execution ended
+func (t *TWAP) Done() <-chan struct{} {
+	return t.doneC
+}
+
+// Progress returns execution state
+func (t *TWAP) Progress() TWAPProgress {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	filled, open := t.quantities()
+	p := TWAPProgress{
+		Quantity: t.params.Quantity,
+		Filled:   filled,
+		Open:     open,
+		Placed:   t.placed,
+		Slices:   t.params.Slices,
+		Err:      t.err,
+	}
+	select {
+	case <-t.doneC:
+		p.Done = true
+	default:
+	}
+	return p
+}
+
+// HandleUserDataEvent applies ORDER_TRADE_UPDATE events of child orders, other events are ignored
+func (t *TWAP) HandleUserDataEvent(event *futures.WsUserDataEvent) {
+	if event.Event != futures.UserDataEventTypeOrderTradeUpdate {
+		return
+	}
+	u := event.OrderTradeUpdate
+	executed, err := strconv.ParseFloat(u.AccumulatedFilledQty, 64)
+	if err != nil {
+		return
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if child, ok := t.children[u.ClientOrderID]; ok {
+		child.update(u.Status, executed)
+		if !child.open() {
+			t.closed()
+		}
+	}
+}
+
+// closed signals run that a child order was closed
+func (t *TWAP) closed() {
+	select {
+	case t.closedC <- struct{}{}:
+	default:
+	}
+}
+
+func (t *TWAP) run(ctx context.Context) {
+	defer close(t.doneC)
+	defer t.cancelOpen()
+
+	interval := t.params.Duration / time.Duration(t.params.Slices)
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for slice := 1; ; slice++ {
+		t.placeSlice(ctx, slice)
+		if t.Progress().Filled >= t.params.Quantity {
+			return
+		}
+		if slice >= t.params.Slices {
+			t.waitClosed(ctx, ticker.C)
+			return
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// waitClosed waits until no child order is live, the deadline ticks or ctx is done
+func (t *TWAP) waitClosed(ctx context.Context, deadline <-chan time.Time) {
+	for {
+		t.mu.Lock()
+		_, open := t.quantities()
+		t.mu.Unlock()
+		if open <= 0 {
+			return
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-deadline:
+			return
+		case <-t.closedC:
+		}
+	}
+}
+
+// placeSlice places child order bringing filled and open quantity up to the schedule of slice
+func (t *TWAP) placeSlice(ctx context.Context, slice int) {
+	t.resolveUnknown(ctx)
+
+	t.mu.Lock()
+	filled, open := t.quantities()
+	target := t.params.Quantity * float64(slice) / float64(t.params.Slices)
+	quantity := roundDown(target-filled-open, t.params.QuantityPrecision)
+	if quantity <= 0 {
+		t.mu.Unlock()
+		return
+	}
+	// register before placing, user data events may arrive before the response
+	id := fmt.Sprintf("%s%d", t.prefix, slice)
+	child := &childState{quantity: quantity, status: futures.OrderStatusTypeNew}
+	t.children[id] = child
+	t.mu.Unlock()
+
+	price, err := t.params.Price(ctx)
+	if err != nil {
+		t.mu.Lock()
+		child.status = futures.OrderStatusTypeRejected
+		t.mu.Unlock()
+		t.handleError(err)
+		return
+	}
+	res, err := t.placeChild(ctx, id, quantity, price)
+	if err != nil {
+		t.handleError(err)
+		t.mu.Lock()
+		if placementUnknown(err) {
+			// the order may be live, it is queried before placing more
+			child.unknown = true
+		} else {
+			child.status = futures.OrderStatusTypeRejected
+		}
+		t.mu.Unlock()
+		t.resolveUnknown(ctx)
+		return
+	}
+	executed, _ := strconv.ParseFloat(res.ExecutedQuantity, 64)
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.placed++
+	child.update(res.Status, executed)
+}
+
+func (t *TWAP) placeChild(ctx context.Context, id string, quantity float64, price string) (*futures.CreateOrderResponse, error) {
+	return t.placer.PlaceOrder(ctx, ChildOrder{
+		Symbol:        t.params.Symbol,
+		Side:          t.params.Side,
+		PositionSide:  t.params.PositionSide,
+		TimeInForce:   t.params.TimeInForce,
+		Quantity:      strconv.FormatFloat(quantity, 'f', t.params.QuantityPrecision, 64),
+		Price:         price,
+		ReduceOnly:    t.params.ReduceOnly,
+		ClientOrderID: id,
+	})
+}
+
+// resolveUnknown queries child orders whose placement outcome is unknown
+func (t *TWAP) resolveUnknown(ctx context.Context) {
+	t.mu.Lock()
+	var ids []string
+	for id, child := range t.children {
+		if child.unknown {
+			ids = append(ids, id)
+		}
+	}
+	t.mu.Unlock()
+
+	for _, id := range ids {
+		status, executed, err := queryChild(ctx, t.placer, t.params.Symbol, id)
+		if err != nil {
+			t.handleError(err)
+			continue
+		}
+		t.mu.Lock()
+		if status != futures.OrderStatusTypeRejected {
+			t.placed++
+		}
+		t.children[id].resolve(status, executed)
+		if !t.children[id].open() {
+			t.closed()
+		}
+		t.mu.Unlock()
+	}
+}
+
+// cancelOpen cancels live child orders, including those whose placement outcome is unknown
+// and can't be queried
+func (t *TWAP) cancelOpen() {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	t.resolveUnknown(ctx)
+
+	t.mu.Lock()
+	var ids []string
+	for id, child := range t.children {
+		if child.open() {
+			ids = append(ids, id)
+		}
+	}
+	t.mu.Unlock()
+
+	for _, id := range ids {
+		if err := t.placer.CancelOrder(ctx, t.params.Symbol, id); err != nil {
+			t.handleError(err)
+			continue
+		}
+		t.mu.Lock()
+		if child := t.children[id]; child.open() {
+			child.status = futures.OrderStatusTypeCanceled
+		}
+		t.mu.Unlock()
+	}
+}
+
+func (t *TWAP) quantities() (filled, open float64) {
+	for _, child := range t.children {
+		filled += child.executed
+		if child.open() {
+			open += child.quantity - child.executed
+		}
+	}
+	return filled, open
+}
+
+func (t *TWAP) handleError(err error) {
+	if t.params.OnError != nil {
+		t.params.OnError(err)
+	}
+}
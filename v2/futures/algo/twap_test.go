@@ -0,0 +1,298 @@
+package algo
+
+import (
+	"context"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/adshao/go-binance/v2/common"
+	"github.com/adshao/go-binance/v2/futures"
+	"github.com/stretchr/testify/assert"
+)
+
+type testPlacer struct {
+	mu       sync.Mutex
+	orders   []ChildOrder
+	canceled []string
+	queried  []string
+	// fill returns executed quantity and status of a placed order
+	fill func(order ChildOrder) (string, futures.OrderStatusType)
+	// fail, if set, returns error placing order and whether the order was placed still
+	fail   func(order ChildOrder) (bool, error)
+	placed map[string]ChildOrder
+}
+
+func (p *testPlacer) PlaceOrder(ctx context.Context, order ChildOrder) (*futures.CreateOrderResponse, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.orders = append(p.orders, order)
+	if p.fail != nil {
+		if placed, err := p.fail(order); err != nil {
+			if placed {
+				p.place(order)
+			}
+			return nil, err
+		}
+	}
+	p.place(order)
+	executed, status := p.fill(order)
+	return &futures.CreateOrderResponse{
+		Symbol:           order.Symbol,
+		ClientOrderID:    order.ClientOrderID,
+		OrigQuantity:     order.Quantity,
+		ExecutedQuantity: executed,
+		Status:           status,
+	}, nil
+}
+
+func (p *testPlacer) place(order ChildOrder) {
+	if p.placed == nil {
+		p.placed = make(map[string]ChildOrder)
+	}
+	p.placed[order.ClientOrderID] = order
+}
+
+func (p *testPlacer) CancelOrder(ctx context.Context, symbol, clientOrderID string) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.canceled = append(p.canceled, clientOrderID)
+	return nil
+}
+
+func (p *testPlacer) QueryOrder(ctx context.Context, symbol, clientOrderID string) (*futures.Order, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.queried = append(p.queried, clientOrderID)
+	order, ok := p.placed[clientOrderID]
+	if !ok {
+		return nil, &common.APIError{Code: errCodeOrderDoesNotExist, Message: "Order does not exist."}
+	}
+	executed, status := p.fill(order)
+	return &futures.Order{
+		Symbol:           order.Symbol,
+		ClientOrderID:    order.ClientOrderID,
+		OrigQuantity:     order.Quantity,
+		ExecutedQuantity: executed,
+		Status:           status,
+	}, nil
+}
+
+func (p *testPlacer) canceledIDs() []string {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	return append([]string(nil), p.canceled...)
+}
+
+func (p *testPlacer) quantities() []string {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	res := make([]string, 0, len(p.orders))
+	for _, o := range p.orders {
+		res = append(res, o.Quantity)
+	}
+	return res
+}
+
+func fixedPrice(ctx context.Context) (string, error) {
+	return "100", nil
+}
+
+func TestTWAPCatchesUp(t *testing.T) {
+	assert := assert.New(t)
+
+	p := &testPlacer{fill: func(order ChildOrder) (string, futures.OrderStatusType) {
+		// IOC children fill half
+		q, _ := strconv.ParseFloat(order.Quantity, 64)
+		return strconv.FormatFloat(q/2, 'f', 3, 64), futures.OrderStatusTypeExpired
+	}}
+	twap, err := NewTWAP(p, TWAPParams{
+		Symbol:            "BTCUSDT",
+		Side:              futures.SideTypeBuy,
+		Quantity:          4,
+		QuantityPrecision: 3,
+		Duration:          40 * time.Millisecond,
+		Slices:            4,
+		Price:             fixedPrice,
+	})
+	assert.NoError(err)
+
+	twap.Start(context.Background())
+	select {
+	case <-twap.Done():
+	case <-time.After(time.Second):
+		t.Fatal("twap not done")
+	}
+
+	assert.Equal([]string{"1.000", "1.500", "1.750", "1.875"}, p.quantities())
+	for _, o := range p.orders {
+		assert.Equal(futures.TimeInForceTypeIOC, o.TimeInForce)
+	}
+	progress := twap.Progress()
+	assert.True(progress.Done)
+	assert.Equal(4, progress.Placed)
+	assert.InDelta(3.063, progress.Filled, 1e-9)
+	assert.Zero(progress.Open)
+	assert.NoError(progress.Err)
+	assert.Empty(p.canceled)
+}
+
+func TestTWAPAbort(t *testing.T) {
+	assert := assert.New(t)
+
+	p := &testPlacer{fill: func(order ChildOrder) (string, futures.OrderStatusType) {
+		// post only children rest
+		return "0", futures.OrderStatusTypeNew
+	}}
+	twap, err := NewTWAP(p, TWAPParams{
+		Symbol:            "BTCUSDT",
+		Side:              futures.SideTypeSell,
+		Quantity:          2,
+		QuantityPrecision: 3,
+		Duration:          time.Hour,
+		Slices:            2,
+		TimeInForce:       futures.TimeInForceTypeGTX,
+		Price:             fixedPrice,
+	})
+	assert.NoError(err)
+
+	twap.Start(context.Background())
+	assert.Eventually(func() bool {
+		return twap.Progress().Placed == 1
+	}, time.Second, time.Millisecond)
+
+	id := p.order(0).ClientOrderID
+	twap.HandleUserDataEvent(&futures.WsUserDataEvent{
+		Event: futures.UserDataEventTypeOrderTradeUpdate,
+		WsUserDataOrderTradeUpdate: futures.WsUserDataOrderTradeUpdate{OrderTradeUpdate: futures.WsOrderTradeUpdate{
+			ClientOrderID:        id,
+			Status:               futures.OrderStatusTypePartiallyFilled,
+			AccumulatedFilledQty: "0.4",
+		}},
+	})
+	progress := twap.Progress()
+	assert.Equal(0.4, progress.Filled)
+	assert.Equal(0.6, progress.Open)
+
+	twap.Abort()
+	progress = twap.Progress()
+	assert.True(progress.Done)
+	assert.ErrorIs(progress.Err, ErrAborted)
+	assert.Zero(progress.Open)
+	assert.Equal([]string{id}, p.canceled)
+}
+
+func TestTWAPUnknownPlacement(t *testing.T) {
+	assert := assert.New(t)
+
+	p := &testPlacer{
+		fill: func(order ChildOrder) (string, futures.OrderStatusType) {
+			return "0", futures.OrderStatusTypeNew
+		},
+		fail: func(order ChildOrder) (bool, error) {
+			switch {
+			case strings.HasSuffix(order.ClientOrderID, "-1"):
+				// placed although the response was lost
+				return true, context.DeadlineExceeded
+			case strings.HasSuffix(order.ClientOrderID, "-2"):
+				return false, context.DeadlineExceeded
+			}
+			return false, nil
+		},
+	}
+	twap, err := NewTWAP(p, TWAPParams{
+		Symbol:            "BTCUSDT",
+		Side:              futures.SideTypeBuy,
+		Quantity:          3,
+		QuantityPrecision: 3,
+		Duration:          30 * time.Millisecond,
+		Slices:            3,
+		TimeInForce:       futures.TimeInForceTypeGTX,
+		Price:             fixedPrice,
+	})
+	assert.NoError(err)
+
+	twap.Start(context.Background())
+	select {
+	case <-twap.Done():
+	case <-time.After(time.Second):
+		t.Fatal("twap not done")
+	}
+
+	// the child found live counts as placed and is not placed again, the one
+	// never placed is rejected and its quantity goes to the next slice
+	assert.Equal(2, twap.Progress().Placed)
+	assert.Equal([]string{"1.000", "1.000", "2.000"}, p.quantities())
+	first, second, third := p.order(0).ClientOrderID, p.order(1).ClientOrderID, p.order(2).ClientOrderID
+	assert.Equal([]string{first, second}, p.queried)
+	assert.ElementsMatch([]string{first, third}, p.canceledIDs())
+}
+
+func TestTWAPLastSliceRests(t *testing.T) {
+	assert := assert.New(t)
+
+	p := &testPlacer{fill: func(order ChildOrder) (string, futures.OrderStatusType) {
+		return "0", futures.OrderStatusTypeNew
+	}}
+	newTWAP := func(duration time.Duration) *TWAP {
+		twap, err := NewTWAP(p, TWAPParams{
+			Symbol:            "BTCUSDT",
+			Side:              futures.SideTypeBuy,
+			Quantity:          1,
+			QuantityPrecision: 3,
+			Duration:          duration,
+			Slices:            1,
+			TimeInForce:       futures.TimeInForceTypeGTX,
+			Price:             fixedPrice,
+		})
+		assert.NoError(err)
+		return twap
+	}
+
+	// the last child is not canceled as soon as it is placed
+	twap := newTWAP(time.Hour)
+	twap.Start(context.Background())
+	assert.Eventually(func() bool {
+		return twap.Progress().Placed == 1
+	}, time.Second, time.Millisecond)
+	assert.Never(func() bool {
+		return twap.Progress().Done
+	}, 20*time.Millisecond, time.Millisecond)
+	twap.HandleUserDataEvent(&futures.WsUserDataEvent{
+		Event: futures.UserDataEventTypeOrderTradeUpdate,
+		WsUserDataOrderTradeUpdate: futures.WsUserDataOrderTradeUpdate{OrderTradeUpdate: futures.WsOrderTradeUpdate{
+			ClientOrderID:        p.order(0).ClientOrderID,
+			Status:               futures.OrderStatusTypeFilled,
+			AccumulatedFilledQty: "1",
+		}},
+	})
+	select {
+	case <-twap.Done():
+	case <-time.After(time.Second):
+		t.Fatal("twap not done")
+	}
+	assert.Equal(1.0, twap.Progress().Filled)
+	assert.Empty(p.canceled)
+
+	// it is canceled once its slice interval ended unfilled
+	twap = newTWAP(20 * time.Millisecond)
+	twap.Start(context.Background())
+	select {
+	case <-twap.Done():
+	case <-time.After(time.Second):
+		t.Fatal("twap not done")
+	}
+	assert.Equal([]string{p.order(1).ClientOrderID}, p.canceled)
+}
+
+func TestNewTWAPInvalidParams(t *testing.T) {
+	_, err := NewTWAP(&testPlacer{}, TWAPParams{Symbol: "BTCUSDT", Quantity: 1, Slices: 1, Duration: time.Minute})
+	assert.ErrorIs(t, err, ErrInvalidParams)
+}
@@ -0,0 +1,220 @@
+package futures
+
+import (
+	"errors"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/adshao/go-binance/v2/common"
+)
+
+const apiKeyPoolDefaultCooldown = time.Minute
+
+var (
+	ErrAPIKeyPoolEmpty   = errors.New("api key pool: no key pair")
+	ErrNoAPIKeyAvailable = errors.New("api key pool: all keys are excluded")
+)
+
+// orderPlacementEndpoints are the endpoints whose POST requests count toward the order rate limits
+var orderPlacementEndpoints = map[string]bool{
+	"/fapi/v1/order":       true,
+	"/fapi/v1/batchOrders": true,
+}
+
+// APIKeyPair define an API key and its secret key
+type APIKeyPair struct {
+	APIKey    string
+	SecretKey string
+}
+
+// APIKeyHealth define the health of a key pair of APIKeyPool
+type APIKeyHealth struct {
+	APIKey string
+	// Requests and Failures count the orders sent with the key and those which failed
+	Requests int
+	Failures int
+	// OrderCount is the usage of the order rate limits of the key as last reported, by interval
+	OrderCount map[string]int
+	// ExcludedUntil is when the key is used again after it was rate limited or rejected, zero
+	// if it is not excluded
+	ExcludedUntil time.Time
+}
+
+// APIKeyPool holds a Client per key pair, each pair being the key of a different account, and
+// hands the clients out in turn, spreading orders over the order rate limits of the accounts.
+// Orders are canceled, queried and streamed with the client that placed them, as they belong to
+// its account. A client is excluded for Cooldown, or for as long as Retry-After asks, once its
+// orders are rate limited or its key is rejected by the exchange. Request weight limits and
+// bans apply to the IP, so they exclude every client of the pool.
+type APIKeyPool struct {
+	// Cooldown is how long a key is excluded if the response has no Retry-After
+	Cooldown time.Duration
+	// OnExclude, if set, is called when a key is excluded after failing with err
+	OnExclude func(apiKey string, until time.Time, err error)
+
+	clock common.Clock
+	mu    sync.Mutex
+	keys  []*poolAPIKey
+	next  int
+}
+
+// poolAPIKey is a key pair of APIKeyPool and its client
+type poolAPIKey struct {
+	pool   *APIKeyPool
+	client *Client
+	health APIKeyHealth
+}
+
+// NewAPIKeyPool init APIKeyPool of pairs, creating a client of each. Configure the clients
+// through Clients before use, e.g. to set BaseURL.
+func NewAPIKeyPool(pairs ...APIKeyPair) (*APIKeyPool, error) {
+	if len(pairs) == 0 {
+		return nil, ErrAPIKeyPoolEmpty
+	}
+	p := &APIKeyPool{
+		Cooldown: apiKeyPoolDefaultCooldown,
+		clock:    common.SystemClock,
+	}
+	for _, pair := range pairs {
+		key := &poolAPIKey{
+			pool:   p,
+			client: NewClient(pair.APIKey, pair.SecretKey),
+			health: APIKeyHealth{APIKey: pair.APIKey},
+		}
+		key.client.poolKey = key
+		p.keys = append(p.keys, key)
+	}
+	return p, nil
+}
+
+// Client returns the client of the next key pair which is not excluded
+func (p *APIKeyPool) Client() (*Client, error) {
+	key, err := p.take()
+	if err != nil {
+		return nil, err
+	}
+	return key.client, nil
+}
+
+// Clients returns the client of every key pair, in the order they were given
+func (p *APIKeyPool) Clients() []*Client {
+	clients := make([]*Client, 0, len(p.keys))
+	for _, key := range p.keys {
+		clients = append(clients, key.client)
+	}
+	return clients
+}
+
+// Health returns the health of every key pair, in the order they were given
+func (p *APIKeyPool) Health() []APIKeyHealth {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	now := p.clock.Now()
+	health := make([]APIKeyHealth, 0, len(p.keys))
+	for _, key := range p.keys {
+		h := key.health
+		h.OrderCount = mergeUsage(nil, h.OrderCount)
+		if !now.Before(h.ExcludedUntil) {
+			h.ExcludedUntil = time.Time{}
+		}
+		health = append(health, h)
+	}
+	return health
+}
+
+// take returns the next key pair which is not excluded
+func (p *APIKeyPool) take() (*poolAPIKey, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	now := p.clock.Now()
+	for i := 0; i < len(p.keys); i++ {
+		key := p.keys[(p.next+i)%len(p.keys)]
+		if now.Before(key.health.ExcludedUntil) {
+			continue
+		}
+		p.next = (p.next + i + 1) % len(p.keys)
+		return key, nil
+	}
+	return nil, ErrNoAPIKeyAvailable
+}
+
+// done records the outcome of a request sent with the key, order tells whether it placed orders
+func (k *poolAPIKey) done(order bool, res apiResponse, err error) {
+	p := k.pool
+	p.mu.Lock()
+	if order {
+		k.health.Requests++
+		if err != nil {
+			k.health.Failures++
+		}
+		if usage := parseRateLimitUsage(res.header, p.clock.Now()); usage.OrderCount != nil {
+			k.health.OrderCount = usage.OrderCount
+		}
+	}
+	scope := exclusionScope(res, err)
+	if scope == excludeNone {
+		p.mu.Unlock()
+		return
+	}
+	delay := p.Cooldown
+	if retryAfter, ok := parseRetryAfter(res.header.Get("Retry-After")); ok {
+		delay = retryAfter
+	}
+	until := p.clock.Now().Add(delay)
+	excluded := []*poolAPIKey{k}
+	if scope == excludeIP {
+		excluded = p.keys
+	}
+	for _, key := range excluded {
+		if key.health.ExcludedUntil.Before(until) {
+			key.health.ExcludedUntil = until
+		}
+	}
+	p.mu.Unlock()
+
+	if p.OnExclude != nil {
+		for _, key := range excluded {
+			p.OnExclude(key.health.APIKey, until, err)
+		}
+	}
+}
+
+// exclusion define which keys of APIKeyPool a failure excludes
+type exclusion int
+
+const (
+	excludeNone exclusion = iota
+	// excludeKey excludes the key whose orders are rate limited or which is rejected
+	excludeKey
+	// excludeIP excludes all keys, as the IP is rate limited or banned
+	excludeIP
+)
+
+// exclusionScope returns which keys must not be used for a while after a request failed with
+// res and err
+func exclusionScope(res apiResponse, err error) exclusion {
+	if res.status == http.StatusTeapot {
+		return excludeIP
+	}
+	apiErr, ok := err.(*common.APIError)
+	switch {
+	case ok && apiErr.Code == -1003:
+		// too many requests, the weight limit is per IP
+		return excludeIP
+	case ok && (apiErr.Code == -1015 || apiErr.Code == -2014 || apiErr.Code == -2015):
+		// too many orders, bad API key format, invalid key or permissions
+		return excludeKey
+	case res.status == http.StatusTooManyRequests:
+		// order rate limits are per account
+		return excludeKey
+	}
+	return excludeNone
+}
+
+// isOrderPlacement reports whether r places orders, counting toward the order rate limits
+func isOrderPlacement(r *request) bool {
+	return r.method == http.MethodPost && orderPlacementEndpoints[r.endpoint]
+}
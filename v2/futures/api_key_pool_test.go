@@ -0,0 +1,130 @@
+package futures
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/adshao/go-binance/v2/common"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestAPIKeyPool(t *testing.T, do doFunc) (*APIKeyPool, *common.FakeClock) {
+	p, err := NewAPIKeyPool(
+		APIKeyPair{APIKey: "key1", SecretKey: "secret1"},
+		APIKeyPair{APIKey: "key2", SecretKey: "secret2"},
+	)
+	require.NoError(t, err)
+	clock := common.NewFakeClock(time.Unix(6000, 0))
+	p.clock = clock
+	for _, c := range p.Clients() {
+		c.do = do
+	}
+	return p, clock
+}
+
+func TestNewAPIKeyPoolEmpty(t *testing.T) {
+	_, err := NewAPIKeyPool()
+	assert.ErrorIs(t, err, ErrAPIKeyPoolEmpty)
+}
+
+func TestAPIKeyPool(t *testing.T) {
+	var keys []string
+	pool, _ := newTestAPIKeyPool(t, func(req *http.Request) (*http.Response, error) {
+		keys = append(keys, req.Header.Get("X-MBX-APIKEY"))
+		res := newHTTPResponse([]byte(`{"orderId":1}`), http.StatusOK)
+		res.Header = http.Header{}
+		res.Header.Set("X-MBX-ORDER-COUNT-10S", "3")
+		return res, nil
+	})
+
+	var clients []*Client
+	for i := 0; i < 3; i++ {
+		c, err := pool.Client()
+		require.NoError(t, err)
+		_, err = c.NewCreateOrderService().Symbol("BTCUSDT").Side(SideTypeBuy).
+			Type(OrderTypeMarket).Quantity("0.1").Do(newContext())
+		require.NoError(t, err)
+		clients = append(clients, c)
+	}
+	// orders are canceled with the key of the account that placed them
+	_, err := clients[1].NewCancelOrderService().Symbol("BTCUSDT").OrderID(1).Do(newContext())
+	require.NoError(t, err)
+	assert.Equal(t, []string{"key1", "key2", "key1", "key2"}, keys)
+
+	health := pool.Health()
+	require.Len(t, health, 2)
+	assert.Equal(t, APIKeyHealth{APIKey: "key1", Requests: 2, OrderCount: map[string]int{"10s": 3}}, health[0])
+	assert.Equal(t, 1, health[1].Requests, "only orders are counted")
+}
+
+func TestAPIKeyPoolExclusion(t *testing.T) {
+	var keys []string
+	pool, clock := newTestAPIKeyPool(t, func(req *http.Request) (*http.Response, error) {
+		key := req.Header.Get("X-MBX-APIKEY")
+		keys = append(keys, key)
+		if key == "key1" {
+			return newHTTPResponse([]byte(`{"code":-1015,"msg":"Too many new orders."}`), http.StatusBadRequest), nil
+		}
+		return newHTTPResponse([]byte(`{"orderId":1}`), http.StatusOK), nil
+	})
+	var excluded []string
+	pool.OnExclude = func(apiKey string, until time.Time, err error) {
+		excluded = append(excluded, apiKey)
+		assert.True(t, common.IsAPIErrorCode(err, -1015))
+		assert.Equal(t, clock.Now().Add(pool.Cooldown), until)
+	}
+	placeOrder := func() error {
+		c, err := pool.Client()
+		if err != nil {
+			return err
+		}
+		_, err = c.NewCreateOrderService().Symbol("BTCUSDT").Side(SideTypeBuy).
+			Type(OrderTypeMarket).Quantity("0.1").Do(newContext())
+		return err
+	}
+
+	assert.Error(t, placeOrder())
+	assert.NoError(t, placeOrder())
+	assert.NoError(t, placeOrder())
+	assert.Equal(t, []string{"key1", "key2", "key2"}, keys)
+	assert.Equal(t, []string{"key1"}, excluded)
+	health := pool.Health()
+	assert.Equal(t, 1, health[0].Failures)
+	assert.Equal(t, clock.Now().Add(pool.Cooldown), health[0].ExcludedUntil)
+
+	// the key is used again after the cooldown
+	clock.Advance(pool.Cooldown)
+	assert.True(t, pool.Health()[0].ExcludedUntil.IsZero())
+	keys = nil
+	assert.Error(t, placeOrder())
+	assert.NoError(t, placeOrder())
+	assert.Equal(t, []string{"key1", "key2"}, keys)
+}
+
+func TestAPIKeyPoolBan(t *testing.T) {
+	pool, clock := newTestAPIKeyPool(t, nil)
+	header := http.Header{}
+	header.Set("Retry-After", "120")
+
+	key, err := pool.take()
+	require.NoError(t, err)
+	key.done(true, apiResponse{status: http.StatusTooManyRequests}, &common.APIError{Code: -1015})
+	key, err = pool.take()
+	require.NoError(t, err)
+	assert.Equal(t, "key2", key.health.APIKey)
+
+	// a ban of the IP excludes every key
+	key.done(false, apiResponse{status: http.StatusTeapot, header: header}, &common.APIError{Code: -1003})
+	_, err = pool.Client()
+	assert.ErrorIs(t, err, ErrNoAPIKeyAvailable)
+
+	clock.Advance(pool.Cooldown)
+	_, err = pool.take()
+	assert.ErrorIs(t, err, ErrNoAPIKeyAvailable)
+	clock.Advance(time.Minute)
+	key, err = pool.take()
+	require.NoError(t, err)
+	assert.Equal(t, "key1", key.health.APIKey)
+}
@@ -0,0 +1,253 @@
+package futures
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/adshao/go-binance/v2/common"
+	"github.com/google/uuid"
+)
+
+// AuditTransport define transport of an audited request
+type AuditTransport string
+
+// AuditDirection define whether an audit record is a request or its response
+type AuditDirection string
+
+// Audit record transports and directions
+const (
+	AuditTransportREST AuditTransport = "REST"
+	AuditTransportWs   AuditTransport = "WS"
+
+	AuditDirectionRequest  AuditDirection = "REQUEST"
+	AuditDirectionResponse AuditDirection = "RESPONSE"
+)
+
+// orderEndpoints are the REST endpoints creating, modifying or canceling orders, their non GET
+// requests are audited and published on the event bus
+var orderEndpoints = map[string]bool{
+	"/fapi/v1/order":              true,
+	"/fapi/v1/order/test":         true,
+	"/fapi/v1/batchOrders":        true,
+	"/fapi/v1/allOpenOrders":      true,
+	"/fapi/v1/countdownCancelAll": true,
+}
+
+// orderWsMethods are the websocket API methods creating, modifying or canceling orders
+var orderWsMethods = map[WsApiMethodType]bool{
+	WsApiMethodOrderPlace:  true,
+	WsApiMethodOrderCancel: true,
+	WsApiMethodOrderModify: true,
+}
+
+// AuditRecord define an order request or its response written to an AuditSink
+type AuditRecord struct {
+	Time      time.Time      `json:"time"`
+	Transport AuditTransport `json:"transport"`
+	// ConnectionID identifies the websocket connection a request was sent on, empty for REST
+	ConnectionID string `json:"connectionId,omitempty"`
+	// RequestID pairs a request with its response
+	RequestID string         `json:"requestId"`
+	Direction AuditDirection `json:"direction"`
+	// Method is the websocket API method or the HTTP method and endpoint, e.g. "POST /fapi/v1/order"
+	Method string `json:"method"`
+	// Params are the request params without credentials and signature
+	Params map[string]string `json:"params,omitempty"`
+	// Status is the HTTP or websocket API status of a response, 0 if none was received
+	Status   int             `json:"status,omitempty"`
+	Response json.RawMessage `json:"response,omitempty"`
+	Error    string          `json:"error,omitempty"`
+}
+
+// AuditSink receives audit records of order requests, Write must be safe for concurrent use.
+// A request is only sent once its record was written, a failed write fails the request.
+type AuditSink interface {
+	Write(record *AuditRecord) error
+}
+
+// AuditLog is an AuditSink appending records as JSON lines to a writer
+type AuditLog struct {
+	mu     sync.Mutex
+	w      io.Writer
+	closer io.Closer
+}
+
+// NewAuditLog init AuditLog writing into w
+func NewAuditLog(w io.Writer) *AuditLog {
+	return &AuditLog{w: w}
+}
+
+// OpenAuditLog init AuditLog appending to file at path, created if missing
+func OpenAuditLog(path string) (*AuditLog, error) {
+	file, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o600)
+	if err != nil {
+		return nil, err
+	}
+	return &AuditLog{w: file, closer: file}, nil
+}
+
+// Write appends record
+func (l *AuditLog) Write(record *AuditRecord) error {
+	data, err := json.Marshal(record)
+	if err != nil {
+		return err
+	}
+	data = append(data, '\n')
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	_, err = l.w.Write(data)
+	return err
+}
+
+// Close closes the file opened by OpenAuditLog
+func (l *AuditLog) Close() error {
+	if l.closer == nil {
+		return nil
+	}
+	return l.closer.Close()
+}
+
+// orderRequest tracks an order request for the audit sink and the event bus
+type orderRequest struct {
+	sink  AuditSink
+	bus   *EventBus
+	debug func(format string, v ...interface{})
+	// record and event are filled with fields shared by request and response
+	record AuditRecord
+	event  Event
+}
+
+// startOrderRequest writes request record of an order request and publishes its submission,
+// the request must not be sent if writing the record failed
+func startOrderRequest(sink AuditSink, bus *EventBus, debug func(format string, v ...interface{}),
+	transport AuditTransport, connectionID, requestID, method string, params map[string]string) (*orderRequest, error) {
+	o := &orderRequest{
+		sink:  sink,
+		bus:   bus,
+		debug: debug,
+		record: AuditRecord{
+			Transport:    transport,
+			ConnectionID: connectionID,
+			RequestID:    requestID,
+			Method:       method,
+		},
+		event: Event{
+			Transport:     transport,
+			ConnectionID:  connectionID,
+			RequestID:     requestID,
+			Method:        method,
+			Symbol:        params["symbol"],
+			ClientOrderID: params["newClientOrderId"],
+		},
+	}
+	if o.event.ClientOrderID == "" {
+		o.event.ClientOrderID = params["origClientOrderId"]
+	}
+	if sink != nil {
+		record := o.record
+		record.Time = time.Now()
+		record.Direction = AuditDirectionRequest
+		record.Params = params
+		if err := sink.Write(&record); err != nil {
+			return nil, err
+		}
+	}
+	if bus != nil {
+		event := o.event
+		event.Type = EventTypeOrderSubmitted
+		event.Time = time.Now()
+		bus.Publish(&event)
+	}
+	return o, nil
+}
+
+// done writes response record and publishes the outcome of the request
+func (o *orderRequest) done(status int, response []byte, err error) {
+	if o.sink != nil {
+		record := o.record
+		record.Time = time.Now()
+		record.Direction = AuditDirectionResponse
+		record.Status = status
+		if json.Valid(response) {
+			record.Response = response
+		}
+		if err != nil {
+			record.Error = err.Error()
+		}
+		// the request was sent already, a failed write can only be logged
+		if auditErr := o.sink.Write(&record); auditErr != nil {
+			o.debug("audit: unable to write response record: %v", auditErr)
+		}
+	}
+	if o.bus == nil {
+		return
+	}
+	event := o.event
+	event.Time = time.Now()
+	event.Status = status
+	switch {
+	case err == nil:
+		event.Type = EventTypeOrderAcked
+	case common.IsAPIError(err):
+		event.Type = EventTypeOrderRejected
+		event.Error = err.Error()
+	default:
+		return
+	}
+	o.bus.Publish(&event)
+}
+
+// isOrderRequest reports whether r creates, modifies or cancels orders
+func isOrderRequest(r *request) bool {
+	return r.method != http.MethodGet && orderEndpoints[r.endpoint]
+}
+
+// startOrderRequest tracks r if it is an order request and an audit sink or event bus is set
+func (c *Client) startOrderRequest(r *request) (*orderRequest, error) {
+	if c.AuditSink == nil && c.EventBus == nil || !isOrderRequest(r) {
+		return nil, nil
+	}
+	id, err := uuid.NewRandom()
+	if err != nil {
+		return nil, err
+	}
+	params := make(map[string]string)
+	for _, values := range []url.Values{r.query, r.form} {
+		for k, v := range values {
+			if len(v) > 0 {
+				params[k] = v[0]
+			}
+		}
+	}
+	return startOrderRequest(c.AuditSink, c.EventBus, c.debug, AuditTransportREST, "", id.String(), r.method+" "+r.endpoint, params)
+}
+
+// startOrderRequest tracks request id of method if it is an order request and an audit sink or
+// event bus is set
+func (c *ClientWs) startOrderRequest(id string, method WsApiMethodType, p params) (*orderRequest, error) {
+	if c.AuditSink == nil && c.EventBus == nil || !orderWsMethods[method] {
+		return nil, nil
+	}
+	params := make(map[string]string, len(p))
+	for k, v := range p {
+		switch k {
+		case apiKey, signatureKey:
+			continue
+		}
+		params[k] = fmt.Sprint(v)
+	}
+	return startOrderRequest(c.AuditSink, c.EventBus, c.debug, AuditTransportWs, c.connectionID(), id, string(method), params)
+}
+
+// connectionID returns id of the current connection
+func (c *ClientWs) connectionID() string {
+	return strconv.FormatInt(c.connectedAt.Load(), 36)
+}
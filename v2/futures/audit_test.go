@@ -0,0 +1,114 @@
+package futures
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+)
+
+func readAuditRecords(t *testing.T, data []byte) []AuditRecord {
+	var records []AuditRecord
+	for _, line := range strings.Split(strings.TrimSpace(string(data)), "\n") {
+		if line == "" {
+			continue
+		}
+		record := AuditRecord{}
+		assert.NoError(t, json.Unmarshal([]byte(line), &record))
+		records = append(records, record)
+	}
+	return records
+}
+
+func TestAuditREST(t *testing.T) {
+	assert := assert.New(t)
+
+	reply, code := `{"orderId":1,"symbol":"BTCUSDT","status":"NEW"}`, http.StatusOK
+	c := NewClient("apiKey", "secretKey")
+	c.do = func(req *http.Request) (*http.Response, error) {
+		return newHTTPResponse([]byte(reply), code), nil
+	}
+	buf := &bytes.Buffer{}
+	c.AuditSink = NewAuditLog(buf)
+
+	_, err := c.NewCreateOrderService().Symbol("BTCUSDT").Side(SideTypeBuy).Type(OrderTypeMarket).
+		Quantity("1").Do(newContext())
+	assert.NoError(err)
+	_, err = c.NewGetOrderService().Symbol("BTCUSDT").OrderID(1).Do(newContext())
+	assert.NoError(err)
+	reply, code = `{"code":-2011,"msg":"Unknown order sent."}`, http.StatusBadRequest
+	_, err = c.NewCancelOrderService().Symbol("BTCUSDT").OrderID(1).Do(newContext())
+	assert.Error(err)
+
+	records := readAuditRecords(t, buf.Bytes())
+	if !assert.Len(records, 4, "queries are not audited") {
+		return
+	}
+	request, response := records[0], records[1]
+	assert.Equal(AuditTransportREST, request.Transport)
+	assert.Equal(AuditDirectionRequest, request.Direction)
+	assert.Equal("POST /fapi/v1/order", request.Method)
+	assert.Equal("BTCUSDT", request.Params["symbol"])
+	assert.NotContains(request.Params, "signature")
+	assert.Equal(request.RequestID, response.RequestID)
+	assert.Equal(AuditDirectionResponse, response.Direction)
+	assert.Equal(http.StatusOK, response.Status)
+	assert.JSONEq(`{"orderId":1,"symbol":"BTCUSDT","status":"NEW"}`, string(response.Response))
+
+	assert.Equal("DELETE /fapi/v1/order", records[3].Method)
+	assert.Equal(http.StatusBadRequest, records[3].Status)
+	assert.Contains(records[3].Error, "-2011")
+}
+
+func TestOpenAuditLog(t *testing.T) {
+	assert := assert.New(t)
+
+	path := filepath.Join(t.TempDir(), "audit.log")
+	for i := 0; i < 2; i++ {
+		l, err := OpenAuditLog(path)
+		assert.NoError(err)
+		assert.NoError(l.Write(&AuditRecord{RequestID: "r", Direction: AuditDirectionRequest}))
+		assert.NoError(l.Close())
+	}
+	data, err := os.ReadFile(path)
+	assert.NoError(err)
+	assert.Len(readAuditRecords(t, data), 2, "records are appended")
+}
+
+type auditWsTestSuite struct {
+	baseWsApiTestSuite
+}
+
+func TestAuditWs(t *testing.T) {
+	suite.Run(t, new(auditWsTestSuite))
+}
+
+func (s *auditWsTestSuite) TestOrderPlace() {
+	buf := &bytes.Buffer{}
+	s.wsClient.AuditSink = NewAuditLog(buf)
+	s.respond(WsApiMethodOrderPlace, `{"orderId":1,"symbol":"BTCUSDT","status":"NEW"}`)
+
+	_, err := s.wsClient.NewOrderPlaceWsService().Do(newContext(), NewOrderPlaceWsRequest().
+		Symbol("BTCUSDT").Side(SideTypeBuy).Type(OrderTypeMarket).Quantity("1"))
+	r := s.r()
+	r.NoError(err)
+
+	records := readAuditRecords(s.T(), buf.Bytes())
+	r.Len(records, 2)
+	r.Equal(AuditTransportWs, records[0].Transport)
+	r.Equal("order.place", records[0].Method)
+	r.NotEmpty(records[0].ConnectionID)
+	r.Equal(s.lastRequest().Id, records[0].RequestID)
+	r.Equal("BTCUSDT", records[0].Params["symbol"])
+	r.NotContains(records[0].Params, "signature")
+	r.NotContains(records[0].Params, "apiKey")
+	r.Equal(records[0].ConnectionID, records[1].ConnectionID)
+	r.Equal(200, records[1].Status)
+	r.Contains(string(records[1].Response), `"orderId":1`)
+}
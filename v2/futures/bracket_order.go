@@ -0,0 +1,498 @@
+package futures
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/adshao/go-binance/v2/common"
+)
+
+// API error codes of orders that are unknown to the exchange
+const (
+	errCodeUnknownOrder      = -2011
+	errCodeOrderDoesNotExist = -2013
+)
+
+const bracketRequestTimeout = 10 * time.Second
+
+// BracketLeg define order of a bracket
+type BracketLeg string
+
+// Bracket legs, used as suffix of the client order id
+const (
+	BracketLegEntry      BracketLeg = "e"
+	BracketLegTakeProfit BracketLeg = "tp"
+	BracketLegStopLoss   BracketLeg = "sl"
+)
+
+var bracketLegs = []BracketLeg{BracketLegEntry, BracketLegTakeProfit, BracketLegStopLoss}
+
+// BracketOrder define entry order protected by take profit and stop loss orders
+type BracketOrder struct {
+	Symbol   string
+	Side     SideType
+	Quantity string
+	// EntryType is OrderTypeLimit or OrderTypeMarket, EntryPrice is required for limit entries
+	EntryType  OrderType
+	EntryPrice string
+	// TakeProfitPrice and StopLossPrice are the stop prices of the exit legs
+	TakeProfitPrice string
+	StopLossPrice   string
+	WorkingType     WorkingType
+}
+
+// BracketState define state of a bracket managed by BracketOrderManager, status of a leg
+// is empty if the order was never placed
+type BracketState struct {
+	ID               string
+	Symbol           string
+	EntryStatus      OrderStatusType
+	EntryExecutedQty float64
+	TakeProfitStatus OrderStatusType
+	StopLossStatus   OrderStatusType
+	// Closed is set when no order of the bracket is live anymore
+	Closed bool
+
+	// placing is set while Place sends the orders, events only update the state meanwhile
+	placing bool
+	// entryUnknown is set when the outcome of placing the entry could not be resolved
+	entryUnknown bool
+}
+
+// ClientOrderID returns client order id of leg of the bracket
+func (b BracketState) ClientOrderID(leg BracketLeg) string {
+	return fmt.Sprintf("%s-%s", b.ID, leg)
+}
+
+func (b *BracketState) status(leg BracketLeg) *OrderStatusType {
+	switch leg {
+	case BracketLegTakeProfit:
+		return &b.TakeProfitStatus
+	case BracketLegStopLoss:
+		return &b.StopLossStatus
+	default:
+		return &b.EntryStatus
+	}
+}
+
+// resolve returns legs to cancel for the current state and whether the bracket is closed:
+// an exit leg fill cancels everything else, an entry closed without fill cancels the exit
+// legs and losing both exit legs cancels the entry. Unknown entry status closes nothing.
+func (b *BracketState) resolve() (cancel []BracketLeg, closed bool) {
+	entryOpen := isOpenOrderStatus(b.EntryStatus)
+	tpOpen := isOpenOrderStatus(b.TakeProfitStatus)
+	slOpen := isOpenOrderStatus(b.StopLossStatus)
+
+	switch {
+	case b.TakeProfitStatus == OrderStatusTypeFilled || b.StopLossStatus == OrderStatusTypeFilled:
+	case b.EntryStatus != "" && !entryOpen && b.EntryStatus != OrderStatusTypeFilled && b.EntryExecutedQty == 0:
+	case !tpOpen && !slOpen:
+	default:
+		return nil, false
+	}
+	if entryOpen {
+		cancel = append(cancel, BracketLegEntry)
+	}
+	if tpOpen {
+		cancel = append(cancel, BracketLegTakeProfit)
+	}
+	if slOpen {
+		cancel = append(cancel, BracketLegStopLoss)
+	}
+	return cancel, true
+}
+
+// BracketHandler handle a closed bracket
+type BracketHandler func(state BracketState)
+
+// BracketOrderManager emulates OCO for futures: it places an entry order together with reduce
+// only TAKE_PROFIT_MARKET and STOP_MARKET orders and cancels the remaining orders once an exit
+// leg fills. Pass it the events of the user data stream. Legs are tagged by client order id,
+// so Recover can rebuild the brackets from open orders after a restart.
+type BracketOrderManager struct {
+	c          *Client
+	errHandler ErrHandler
+	// prefix of client order ids of brackets placed by the manager
+	prefix string
+
+	mu       sync.Mutex
+	brackets map[string]*BracketState
+	lastID   int64
+	onClose  BracketHandler
+}
+
+// NewBracketOrderManager init BracketOrderManager, errHandler receives errors of cancellations
+// done while handling events
+func NewBracketOrderManager(c *Client, errHandler ErrHandler) *BracketOrderManager {
+	return &BracketOrderManager{
+		c:          c,
+		errHandler: errHandler,
+		prefix:     "bkt",
+		brackets:   make(map[string]*BracketState),
+	}
+}
+
+// OnClose registers handler called when a bracket is closed
+func (m *BracketOrderManager) OnClose(handler BracketHandler) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.onClose = handler
+}
+
+// Brackets returns live brackets sorted by id
+func (m *BracketOrderManager) Brackets() []BracketState {
+	m.mu.Lock()
+	res := make([]BracketState, 0, len(m.brackets))
+	for _, b := range m.brackets {
+		res = append(res, *b)
+	}
+	m.mu.Unlock()
+
+	sort.Slice(res, func(i, j int) bool {
+		return res[i].ID < res[j].ID
+	})
+	return res
+}
+
+// Place places entry, take profit and stop loss orders of the bracket. The bracket is
+// registered before the entry is sent, so events of orders filled meanwhile are applied once
+// all orders are placed. If the entry fails nothing is left, if an exit order fails the orders
+// already placed are canceled and the quantity the entry executed is closed with a market order.
+// An entry whose placement outcome is unknown, e.g. after a timeout, is queried by client order
+// id: exit orders are placed if it was placed, and if the query fails the bracket is kept with
+// the error returned, so Cancel can cancel its entry.
+func (m *BracketOrderManager) Place(ctx context.Context, order BracketOrder, opts ...RequestOption) (BracketState, error) {
+	b := &BracketState{ID: m.newID(), Symbol: order.Symbol, placing: true}
+
+	exitSide := SideTypeSell
+	if order.Side == SideTypeSell {
+		exitSide = SideTypeBuy
+	}
+	entry := m.c.NewCreateOrderService().Symbol(order.Symbol).Side(order.Side).Type(order.EntryType).
+		Quantity(order.Quantity).NewClientOrderID(b.ClientOrderID(BracketLegEntry)).
+		PositionIntent(PositionIntentOpen)
+	if order.EntryType == OrderTypeLimit {
+		entry.Price(order.EntryPrice).TimeInForce(TimeInForceTypeGTC)
+	}
+	legs := map[BracketLeg]*CreateOrderService{
+		BracketLegEntry:      entry,
+		BracketLegTakeProfit: m.exitOrder(b, order, exitSide, BracketLegTakeProfit, OrderTypeTakeProfitMarket, order.TakeProfitPrice),
+		BracketLegStopLoss:   m.exitOrder(b, order, exitSide, BracketLegStopLoss, OrderTypeStopMarket, order.StopLossPrice),
+	}
+
+	m.mu.Lock()
+	m.brackets[b.ID] = b
+	m.mu.Unlock()
+
+	for _, leg := range bracketLegs {
+		res, err := legs[leg].Do(ctx, opts...)
+		if err != nil && leg == BracketLegEntry && (!common.IsAPIError(err) || common.IsAPIErrorCode(err, errCodeUnknownStatus)) {
+			var placed bool
+			res, placed, err = m.queryEntry(b, err, opts...)
+			if err != nil && placed {
+				m.mu.Lock()
+				b.entryUnknown = true
+				state := *b
+				m.mu.Unlock()
+				return state, err
+			}
+		}
+		if err != nil {
+			if leg != BracketLegEntry {
+				if flattenErr := m.flatten(b, leg, exitSide, opts...); flattenErr != nil {
+					err = errors.Join(err, flattenErr)
+				}
+			}
+			m.mu.Lock()
+			delete(m.brackets, b.ID)
+			m.mu.Unlock()
+			return BracketState{}, err
+		}
+		m.mu.Lock()
+		// events received meanwhile are more recent than the response
+		if *b.status(leg) == "" {
+			*b.status(leg) = res.Status
+		}
+		if leg == BracketLegEntry {
+			if executed, _ := parseOptionalFloat(res.ExecutedQuantity); executed > b.EntryExecutedQty {
+				b.EntryExecutedQty = executed
+			}
+		}
+		m.mu.Unlock()
+	}
+
+	m.mu.Lock()
+	b.placing = false
+	cancel, closed := b.resolve()
+	state := *b
+	m.mu.Unlock()
+	if closed {
+		if err := m.cancelLegs(ctx, &state, cancel, opts...); err != nil {
+			return state, err
+		}
+		m.close(state.ID)
+		state.Closed = true
+	}
+	return state, nil
+}
+
+// queryEntry queries the entry of bracket whose placement failed with err of unknown outcome.
+// It returns the entry if it was placed, err if it was never placed and the query error with
+// placed set if the outcome is still unknown.
+func (m *BracketOrderManager) queryEntry(b *BracketState, err error, opts ...RequestOption) (res *CreateOrderResponse, placed bool, queryErr error) {
+	ctx, stop := context.WithTimeout(context.Background(), bracketRequestTimeout)
+	defer stop()
+
+	order, queryErr := m.c.NewGetOrderService().Symbol(b.Symbol).OrigClientOrderID(b.ClientOrderID(BracketLegEntry)).Do(ctx, opts...)
+	switch {
+	case common.IsAPIErrorCode(queryErr, errCodeOrderDoesNotExist):
+		return nil, false, err
+	case queryErr != nil:
+		return nil, true, errors.Join(err, queryErr)
+	}
+	return &CreateOrderResponse{
+		Symbol:           order.Symbol,
+		ClientOrderID:    order.ClientOrderID,
+		Status:           order.Status,
+		ExecutedQuantity: order.ExecutedQuantity,
+	}, true, nil
+}
+
+// flatten cancels the orders placed of a bracket whose exit order failed and closes the
+// quantity its entry executed, so no position is left unprotected. The failed leg is canceled
+// too, it may be live if the outcome of placing it is unknown.
+func (m *BracketOrderManager) flatten(b *BracketState, failed BracketLeg, exitSide SideType, opts ...RequestOption) error {
+	ctx, stop := context.WithTimeout(context.Background(), bracketRequestTimeout)
+	defer stop()
+
+	m.mu.Lock()
+	placed := append(placedLegs(b), failed)
+	m.mu.Unlock()
+	if err := m.cancelLegs(ctx, b, placed, opts...); err != nil {
+		return err
+	}
+	entry, err := m.c.NewGetOrderService().Symbol(b.Symbol).OrigClientOrderID(b.ClientOrderID(BracketLegEntry)).Do(ctx, opts...)
+	if err != nil {
+		return err
+	}
+	if executed, err := parseOptionalFloat(entry.ExecutedQuantity); err != nil || executed == 0 {
+		return err
+	}
+	_, err = m.c.NewCreateOrderService().Symbol(b.Symbol).Side(exitSide).Type(OrderTypeMarket).
+		Quantity(entry.ExecutedQuantity).PositionIntent(PositionIntentClose).Do(ctx, opts...)
+	return err
+}
+
+func (m *BracketOrderManager) exitOrder(b *BracketState, order BracketOrder, side SideType, leg BracketLeg, orderType OrderType, stopPrice string) *CreateOrderService {
+	s := m.c.NewCreateOrderService().Symbol(order.Symbol).Side(side).Type(orderType).
+		Quantity(order.Quantity).StopPrice(stopPrice).NewClientOrderID(b.ClientOrderID(leg)).
+		PositionIntent(PositionIntentClose)
+	if order.WorkingType != "" {
+		s.WorkingType(order.WorkingType)
+	}
+	return s
+}
+
+// Cancel cancels all live orders of bracket id
+func (m *BracketOrderManager) Cancel(ctx context.Context, id string, opts ...RequestOption) error {
+	m.mu.Lock()
+	b, ok := m.brackets[id]
+	var cancel []BracketLeg
+	if ok {
+		for _, leg := range bracketLegs {
+			if isOpenOrderStatus(*b.status(leg)) || leg == BracketLegEntry && b.entryUnknown {
+				cancel = append(cancel, leg)
+			}
+		}
+	}
+	m.mu.Unlock()
+	if !ok {
+		return nil
+	}
+
+	if err := m.cancelLegs(ctx, b, cancel, opts...); err != nil {
+		return err
+	}
+	m.close(id)
+	return nil
+}
+
+// HandleUserDataEvent applies ORDER_TRADE_UPDATE events of bracket orders and cancels the
+// remaining orders of brackets closed by the event, other events are ignored
+func (m *BracketOrderManager) HandleUserDataEvent(event *WsUserDataEvent) {
+	if event.Event != UserDataEventTypeOrderTradeUpdate {
+		return
+	}
+	u := event.OrderTradeUpdate
+	id, leg, ok := m.parseClientOrderID(u.ClientOrderID)
+	if !ok {
+		return
+	}
+
+	m.mu.Lock()
+	b, ok := m.brackets[id]
+	if !ok {
+		m.mu.Unlock()
+		return
+	}
+	*b.status(leg) = u.Status
+	if leg == BracketLegEntry {
+		if executed, err := parseOptionalFloat(u.AccumulatedFilledQty); err == nil {
+			b.EntryExecutedQty = executed
+		}
+	}
+	if b.placing {
+		m.mu.Unlock()
+		return
+	}
+	cancel, closed := b.resolve()
+	state := *b
+	m.mu.Unlock()
+
+	if !closed {
+		return
+	}
+	ctx, stop := context.WithTimeout(context.Background(), bracketRequestTimeout)
+	defer stop()
+	if err := m.cancelLegs(ctx, &state, cancel); err != nil {
+		m.handleError(err)
+		return
+	}
+	m.close(id)
+}
+
+// Recover rebuilds brackets from open orders of the account, e.g. after a restart. Status of
+// legs that are not open is queried, so brackets whose exit leg filled in the meantime are
+// closed by canceling their remaining orders.
+func (m *BracketOrderManager) Recover(ctx context.Context, opts ...RequestOption) error {
+	orders, err := m.c.NewListOpenOrdersService().Do(ctx, opts...)
+	if err != nil {
+		return err
+	}
+
+	found := make(map[string]*BracketState)
+	for _, order := range orders {
+		id, leg, ok := m.parseClientOrderID(order.ClientOrderID)
+		if !ok {
+			continue
+		}
+		b, ok := found[id]
+		if !ok {
+			b = &BracketState{ID: id, Symbol: order.Symbol}
+			found[id] = b
+		}
+		*b.status(leg) = order.Status
+		if leg == BracketLegEntry {
+			b.EntryExecutedQty, _ = parseOptionalFloat(order.ExecutedQuantity)
+		}
+	}
+
+	for id, b := range found {
+		for _, leg := range bracketLegs {
+			if *b.status(leg) != "" {
+				continue
+			}
+			order, err := m.c.NewGetOrderService().Symbol(b.Symbol).OrigClientOrderID(b.ClientOrderID(leg)).Do(ctx, opts...)
+			if common.IsAPIErrorCode(err, errCodeOrderDoesNotExist) {
+				continue
+			}
+			if err != nil {
+				return err
+			}
+			*b.status(leg) = order.Status
+			if leg == BracketLegEntry {
+				b.EntryExecutedQty, _ = parseOptionalFloat(order.ExecutedQuantity)
+			}
+		}
+
+		m.mu.Lock()
+		m.brackets[id] = b
+		m.mu.Unlock()
+
+		if cancel, closed := b.resolve(); closed {
+			if err := m.cancelLegs(ctx, b, cancel, opts...); err != nil {
+				return err
+			}
+			m.close(id)
+		}
+	}
+	return nil
+}
+
+// cancelLegs cancels legs of bracket, orders already gone are ignored
+func (m *BracketOrderManager) cancelLegs(ctx context.Context, b *BracketState, legs []BracketLeg, opts ...RequestOption) error {
+	for _, leg := range legs {
+		_, err := m.c.NewCancelOrderService().Symbol(b.Symbol).OrigClientOrderID(b.ClientOrderID(leg)).Do(ctx, opts...)
+		if err != nil && !common.IsAPIErrorCode(err, errCodeUnknownOrder) {
+			return err
+		}
+	}
+	return nil
+}
+
+func (m *BracketOrderManager) close(id string) {
+	m.mu.Lock()
+	b, ok := m.brackets[id]
+	delete(m.brackets, id)
+	onClose := m.onClose
+	m.mu.Unlock()
+
+	if ok && onClose != nil {
+		state := *b
+		state.Closed = true
+		onClose(state)
+	}
+}
+
+// newID returns unique bracket id, client order ids built from it stay within 36 characters
+func (m *BracketOrderManager) newID() string {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	id := time.Now().UnixNano()
+	if id <= m.lastID {
+		id = m.lastID + 1
+	}
+	m.lastID = id
+	return fmt.Sprintf("%s%d", m.prefix, id)
+}
+
+func (m *BracketOrderManager) parseClientOrderID(clientOrderID string) (id string, leg BracketLeg, ok bool) {
+	if !strings.HasPrefix(clientOrderID, m.prefix) {
+		return "", "", false
+	}
+	i := strings.LastIndex(clientOrderID, "-")
+	if i < 0 {
+		return "", "", false
+	}
+	id, leg = clientOrderID[:i], BracketLeg(clientOrderID[i+1:])
+	for _, l := range bracketLegs {
+		if l == leg {
+			return id, leg, true
+		}
+	}
+	return "", "", false
+}
+
+func (m *BracketOrderManager) handleError(err error) {
+	if m.errHandler != nil {
+		m.errHandler(err)
+	}
+}
+
+// placedLegs returns legs of bracket that were placed
+func placedLegs(b *BracketState) []BracketLeg {
+	var legs []BracketLeg
+	for _, leg := range bracketLegs {
+		if *b.status(leg) != "" {
+			legs = append(legs, leg)
+		}
+	}
+	return legs
+}
@@ -0,0 +1,305 @@
+package futures
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/adshao/go-binance/v2/common"
+	"github.com/stretchr/testify/assert"
+)
+
+type bracketTestExchange struct {
+	mu       sync.Mutex
+	orders   map[string]string
+	canceled []string
+	placed   []url.Values
+	// reject is the order type rejected by the exchange
+	reject string
+	// onPlace is called with client order id of orders placed
+	onPlace func(id string)
+	// lost is the order type whose response is lost, lostPlaced tells whether it is placed
+	lost       string
+	lostPlaced bool
+	// failQuery fails order queries
+	failQuery bool
+}
+
+func (e *bracketTestExchange) do(req *http.Request) (*http.Response, error) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	body, _ := io.ReadAll(req.Body)
+	form, _ := url.ParseQuery(string(body))
+	switch req.Method + " " + req.URL.Path {
+	case "GET /fapi/v1/positionSide/dual":
+		return newHTTPResponse([]byte(`{"dualSidePosition":false}`), http.StatusOK), nil
+	case "POST /fapi/v1/order":
+		if form.Get("type") == e.reject {
+			return newHTTPResponse([]byte(`{"code":-2021,"msg":"Order would immediately trigger."}`), http.StatusBadRequest), nil
+		}
+		if form.Get("type") == e.lost && !e.lostPlaced {
+			return nil, io.ErrUnexpectedEOF
+		}
+		e.placed = append(e.placed, form)
+		id := form.Get("newClientOrderId")
+		status := "NEW"
+		if form.Get("type") == "MARKET" {
+			status = "FILLED"
+		}
+		e.orders[id] = status
+		if e.onPlace != nil {
+			e.onPlace(id)
+		}
+		if form.Get("type") == e.lost {
+			return nil, io.ErrUnexpectedEOF
+		}
+		return newHTTPResponse([]byte(fmt.Sprintf(`{"clientOrderId":"%s","status":"%s"}`, id, status)), http.StatusOK), nil
+	case "DELETE /fapi/v1/order":
+		id := form.Get("origClientOrderId")
+		if e.orders[id] != "NEW" {
+			return newHTTPResponse([]byte(`{"code":-2011,"msg":"Unknown order sent."}`), http.StatusBadRequest), nil
+		}
+		e.canceled = append(e.canceled, id)
+		e.orders[id] = "CANCELED"
+		return newHTTPResponse([]byte(`{}`), http.StatusOK), nil
+	case "GET /fapi/v1/openOrders":
+		var orders []string
+		for id, status := range e.orders {
+			if status == "NEW" {
+				orders = append(orders, fmt.Sprintf(`{"symbol":"BTCUSDT","clientOrderId":"%s","status":"NEW"}`, id))
+			}
+		}
+		return newHTTPResponse([]byte("["+strings.Join(orders, ",")+"]"), http.StatusOK), nil
+	case "GET /fapi/v1/order":
+		if e.failQuery {
+			return nil, io.ErrUnexpectedEOF
+		}
+		id := req.URL.Query().Get("origClientOrderId")
+		status, ok := e.orders[id]
+		if !ok {
+			return newHTTPResponse([]byte(`{"code":-2013,"msg":"Order does not exist."}`), http.StatusBadRequest), nil
+		}
+		executed := "0"
+		if status == "FILLED" {
+			executed = "1"
+		}
+		return newHTTPResponse([]byte(fmt.Sprintf(`{"symbol":"BTCUSDT","clientOrderId":"%s","status":"%s","executedQty":"%s"}`, id, status, executed)), http.StatusOK), nil
+	}
+	return newHTTPResponse([]byte(`{}`), http.StatusNotFound), nil
+}
+
+func orderTradeUpdateEvent(clientOrderID string, status OrderStatusType) *WsUserDataEvent {
+	return &WsUserDataEvent{
+		Event: UserDataEventTypeOrderTradeUpdate,
+		WsUserDataOrderTradeUpdate: WsUserDataOrderTradeUpdate{OrderTradeUpdate: WsOrderTradeUpdate{
+			Symbol:        "BTCUSDT",
+			ClientOrderID: clientOrderID,
+			Status:        status,
+		}},
+	}
+}
+
+func TestBracketOrderManager(t *testing.T) {
+	assert := assert.New(t)
+
+	e := &bracketTestExchange{orders: make(map[string]string)}
+	c := NewClient("apiKey", "secretKey")
+	c.do = e.do
+
+	m := NewBracketOrderManager(c, func(err error) {
+		assert.NoError(err)
+	})
+	var closed []BracketState
+	m.OnClose(func(state BracketState) {
+		closed = append(closed, state)
+	})
+
+	b, err := m.Place(newContext(), BracketOrder{
+		Symbol:          "BTCUSDT",
+		Side:            SideTypeBuy,
+		Quantity:        "1",
+		EntryType:       OrderTypeMarket,
+		TakeProfitPrice: "110",
+		StopLossPrice:   "90",
+	})
+	assert.NoError(err)
+	assert.Equal(OrderStatusTypeFilled, b.EntryStatus)
+	assert.Equal(OrderStatusTypeNew, b.TakeProfitStatus)
+	assert.Equal(OrderStatusTypeNew, b.StopLossStatus)
+	assert.Len(m.Brackets(), 1)
+
+	m.HandleUserDataEvent(orderTradeUpdateEvent(b.ClientOrderID(BracketLegTakeProfit), OrderStatusTypePartiallyFilled))
+	assert.Empty(e.canceled)
+
+	m.HandleUserDataEvent(orderTradeUpdateEvent(b.ClientOrderID(BracketLegTakeProfit), OrderStatusTypeFilled))
+	assert.Equal([]string{b.ClientOrderID(BracketLegStopLoss)}, e.canceled)
+	assert.Empty(m.Brackets())
+	if assert.Len(closed, 1) {
+		assert.True(closed[0].Closed)
+		assert.Equal(OrderStatusTypeFilled, closed[0].TakeProfitStatus)
+	}
+
+	e.canceled = nil
+	b, err = m.Place(newContext(), BracketOrder{
+		Symbol:          "BTCUSDT",
+		Side:            SideTypeSell,
+		Quantity:        "1",
+		EntryType:       OrderTypeLimit,
+		EntryPrice:      "100",
+		TakeProfitPrice: "90",
+		StopLossPrice:   "110",
+	})
+	assert.NoError(err)
+	m.HandleUserDataEvent(orderTradeUpdateEvent(b.ClientOrderID(BracketLegEntry), OrderStatusTypeCanceled))
+	assert.ElementsMatch([]string{b.ClientOrderID(BracketLegTakeProfit), b.ClientOrderID(BracketLegStopLoss)}, e.canceled)
+	assert.Empty(m.Brackets())
+}
+
+func TestBracketOrderManagerFlatten(t *testing.T) {
+	assert := assert.New(t)
+
+	e := &bracketTestExchange{orders: make(map[string]string), reject: string(OrderTypeStopMarket)}
+	c := NewClient("apiKey", "secretKey")
+	c.do = e.do
+
+	m := NewBracketOrderManager(c, nil)
+	_, err := m.Place(newContext(), BracketOrder{
+		Symbol:          "BTCUSDT",
+		Side:            SideTypeBuy,
+		Quantity:        "1",
+		EntryType:       OrderTypeMarket,
+		TakeProfitPrice: "110",
+		StopLossPrice:   "120",
+	})
+	assert.True(common.IsAPIErrorCode(err, -2021))
+	assert.Empty(m.Brackets())
+
+	// the filled entry is not left without stop loss
+	if assert.Len(e.placed, 3) {
+		assert.Equal([]string{e.placed[1].Get("newClientOrderId")}, e.canceled)
+		assert.Equal("SELL", e.placed[2].Get("side"))
+		assert.Equal("MARKET", e.placed[2].Get("type"))
+		assert.Equal("1", e.placed[2].Get("quantity"))
+		assert.Equal("true", e.placed[2].Get("reduceOnly"))
+	}
+}
+
+func TestBracketOrderManagerEntryUnknown(t *testing.T) {
+	assert := assert.New(t)
+
+	e := &bracketTestExchange{orders: make(map[string]string), lost: string(OrderTypeLimit), lostPlaced: true}
+	c := NewClient("apiKey", "secretKey")
+	c.do = e.do
+	m := NewBracketOrderManager(c, nil)
+	order := BracketOrder{
+		Symbol:          "BTCUSDT",
+		Side:            SideTypeBuy,
+		Quantity:        "1",
+		EntryType:       OrderTypeLimit,
+		EntryPrice:      "100",
+		TakeProfitPrice: "110",
+		StopLossPrice:   "90",
+	}
+
+	// the entry placed although the response was lost gets its exit orders
+	b, err := m.Place(newContext(), order)
+	assert.NoError(err)
+	assert.Equal(OrderStatusTypeNew, b.EntryStatus)
+	assert.Equal(OrderStatusTypeNew, b.TakeProfitStatus)
+	assert.Equal(OrderStatusTypeNew, b.StopLossStatus)
+	assert.Len(e.placed, 3)
+	assert.NoError(m.Cancel(newContext(), b.ID))
+
+	// the entry never placed leaves nothing
+	e.placed, e.canceled, e.lostPlaced = nil, nil, false
+	_, err = m.Place(newContext(), order)
+	assert.ErrorIs(err, io.ErrUnexpectedEOF)
+	assert.Empty(e.placed)
+	assert.Empty(m.Brackets())
+
+	// the bracket is kept while the entry can't be queried, Cancel cancels it
+	e.lostPlaced, e.failQuery = true, true
+	_, err = m.Place(newContext(), order)
+	assert.ErrorIs(err, io.ErrUnexpectedEOF)
+	assert.Len(e.placed, 1)
+	brackets := m.Brackets()
+	if assert.Len(brackets, 1) {
+		assert.NoError(m.Cancel(newContext(), brackets[0].ID))
+		assert.Equal([]string{brackets[0].ClientOrderID(BracketLegEntry)}, e.canceled)
+	}
+	assert.Empty(m.Brackets())
+}
+
+func TestBracketOrderManagerEventsWhilePlacing(t *testing.T) {
+	assert := assert.New(t)
+
+	e := &bracketTestExchange{orders: make(map[string]string)}
+	c := NewClient("apiKey", "secretKey")
+	c.do = e.do
+
+	m := NewBracketOrderManager(c, nil)
+	var closed []BracketState
+	m.OnClose(func(state BracketState) {
+		closed = append(closed, state)
+	})
+	e.onPlace = func(id string) {
+		// take profit fills before the stop loss is acknowledged
+		if strings.HasSuffix(id, "-sl") {
+			m.HandleUserDataEvent(orderTradeUpdateEvent(strings.TrimSuffix(id, "-sl")+"-tp", OrderStatusTypeFilled))
+		}
+	}
+
+	b, err := m.Place(newContext(), BracketOrder{
+		Symbol:          "BTCUSDT",
+		Side:            SideTypeBuy,
+		Quantity:        "1",
+		EntryType:       OrderTypeMarket,
+		TakeProfitPrice: "110",
+		StopLossPrice:   "90",
+	})
+	assert.NoError(err)
+	assert.True(b.Closed)
+	assert.Equal(OrderStatusTypeFilled, b.TakeProfitStatus)
+	assert.Equal([]string{b.ClientOrderID(BracketLegStopLoss)}, e.canceled)
+	assert.Empty(m.Brackets())
+	assert.Len(closed, 1)
+}
+
+func TestBracketOrderManagerRecover(t *testing.T) {
+	assert := assert.New(t)
+
+	e := &bracketTestExchange{orders: map[string]string{
+		// stop loss filled while the manager was down
+		"bkt1-e":  "FILLED",
+		"bkt1-tp": "NEW",
+		"bkt1-sl": "FILLED",
+		// still protected
+		"bkt2-e":  "FILLED",
+		"bkt2-tp": "NEW",
+		"bkt2-sl": "NEW",
+		"other":   "NEW",
+	}}
+	c := NewClient("apiKey", "secretKey")
+	c.do = e.do
+
+	m := NewBracketOrderManager(c, nil)
+	assert.NoError(m.Recover(newContext()))
+	assert.Equal([]string{"bkt1-tp"}, e.canceled)
+
+	brackets := m.Brackets()
+	if assert.Len(brackets, 1) {
+		assert.Equal(BracketState{
+			ID:               "bkt2",
+			Symbol:           "BTCUSDT",
+			EntryStatus:      OrderStatusTypeFilled,
+			EntryExecutedQty: 1,
+			TakeProfitStatus: OrderStatusTypeNew,
+			StopLossStatus:   OrderStatusTypeNew,
+		}, brackets[0])
+	}
+}
@@ -0,0 +1,171 @@
+package futures
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/adshao/go-binance/v2/common"
+)
+
+// errCodeUnknownStatus is returned when the outcome of a request is unknown to the exchange
+const errCodeUnknownStatus = -1007
+
+var ErrCancelReplaceOrderLive = errors.New("cancel replace: order of unknown placement outcome is live")
+
+// CancelReplaceResult define result of CancelReplacer.Replace
+type CancelReplaceResult struct {
+	// CanceledClientOrderID is the client order id of the replaced order, empty if there was none
+	CanceledClientOrderID string
+	// Canceled is nil if there was no live order or it was already gone
+	Canceled *CancelOrderResponse
+	Order    *CreateOrderResponse
+}
+
+type quoteState struct {
+	mu            sync.Mutex
+	symbol        string
+	clientOrderID string
+	// unknown is set while the placement outcome of the order is unknown
+	unknown bool
+}
+
+// CancelReplacer replaces orders of logical quotes by canceling then placing, keeping at most one
+// live order per quote id. A new order is only placed once the previous one is known to be gone,
+// so a failed cancel returns an error and keeps the previous order. An order whose placement
+// timed out may not be known by the exchange yet, so it is queried when its cancel reports an
+// unknown order. Quote ids are prefixes of client order ids, keep them within 22 characters.
+type CancelReplacer struct {
+	c *Client
+
+	mu     sync.Mutex
+	quotes map[string]*quoteState
+	lastID int64
+}
+
+// NewCancelReplacer init CancelReplacer
+func NewCancelReplacer(c *Client) *CancelReplacer {
+	return &CancelReplacer{
+		c:      c,
+		quotes: make(map[string]*quoteState),
+	}
+}
+
+// ClientOrderID returns client order id of the last order placed for quoteID
+func (r *CancelReplacer) ClientOrderID(quoteID string) (string, bool) {
+	q := r.quote(quoteID)
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	return q.clientOrderID, q.clientOrderID != ""
+}
+
+// Replace cancels the order of quoteID, if any, and places order in its place. Orders already
+// filled or canceled when canceled are ignored. Client order id of order is set by Replace.
+func (r *CancelReplacer) Replace(ctx context.Context, quoteID string, order *CreateOrderService, opts ...RequestOption) (*CancelReplaceResult, error) {
+	q := r.quote(quoteID)
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	res := &CancelReplaceResult{CanceledClientOrderID: q.clientOrderID}
+	canceled, err := r.cancel(ctx, q, opts...)
+	if err != nil {
+		return res, err
+	}
+	res.Canceled = canceled
+
+	// record the id before sending, an order whose placement outcome is unknown is canceled
+	// by the next call
+	clientOrderID := quoteID + "-" + strconv.FormatInt(r.newID(), 36)
+	q.symbol, q.clientOrderID = order.symbol, clientOrderID
+	res.Order, err = order.NewClientOrderID(clientOrderID).Do(ctx, opts...)
+	if err != nil {
+		q.unknown = !common.IsAPIError(err) || common.IsAPIErrorCode(err, errCodeUnknownStatus)
+		return res, err
+	}
+	if !isOpenOrderStatus(res.Order.Status) {
+		q.clientOrderID = ""
+	}
+	return res, nil
+}
+
+// Cancel cancels the order of quoteID, if any
+func (r *CancelReplacer) Cancel(ctx context.Context, quoteID string, opts ...RequestOption) (*CancelOrderResponse, error) {
+	q := r.quote(quoteID)
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	return r.cancel(ctx, q, opts...)
+}
+
+// Forget drops the order of quoteID without canceling it, e.g. after it was reported filled
+func (r *CancelReplacer) Forget(quoteID string) {
+	q := r.quote(quoteID)
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	q.clientOrderID, q.unknown = "", false
+}
+
+// cancel cancels the recorded order of q, "unknown order" errors mean it is already gone
+// unless its placement outcome is unknown
+func (r *CancelReplacer) cancel(ctx context.Context, q *quoteState, opts ...RequestOption) (*CancelOrderResponse, error) {
+	if q.clientOrderID == "" {
+		return nil, nil
+	}
+	res, err := r.c.NewCancelOrderService().Symbol(q.symbol).OrigClientOrderID(q.clientOrderID).Do(ctx, opts...)
+	if err != nil {
+		if !common.IsAPIErrorCode(err, errCodeUnknownOrder) {
+			return nil, err
+		}
+		if q.unknown {
+			if err := r.checkGone(ctx, q, opts...); err != nil {
+				return nil, err
+			}
+		}
+	}
+	q.clientOrderID, q.unknown = "", false
+	return res, nil
+}
+
+// checkGone queries the order of q whose placement outcome is unknown, returning an error
+// unless it was never placed or is no longer open
+func (r *CancelReplacer) checkGone(ctx context.Context, q *quoteState, opts ...RequestOption) error {
+	order, err := r.c.NewGetOrderService().Symbol(q.symbol).OrigClientOrderID(q.clientOrderID).Do(ctx, opts...)
+	switch {
+	case common.IsAPIErrorCode(err, errCodeOrderDoesNotExist):
+		return nil
+	case err != nil:
+		return err
+	case isOpenOrderStatus(order.Status):
+		return ErrCancelReplaceOrderLive
+	}
+	return nil
+}
+
+func (r *CancelReplacer) quote(quoteID string) *quoteState {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	q, ok := r.quotes[quoteID]
+	if !ok {
+		q = &quoteState{}
+		r.quotes[quoteID] = q
+	}
+	return q
+}
+
+// newID returns increasing id, so client order ids are not reused across restarts
+func (r *CancelReplacer) newID() int64 {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	id := time.Now().UnixNano()
+	if id <= r.lastID {
+		id = r.lastID + 1
+	}
+	r.lastID = id
+	return id
+}
@@ -0,0 +1,151 @@
+package futures
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCancelReplacer(t *testing.T) {
+	assert := assert.New(t)
+
+	var (
+		calls       []string
+		cancelReply = `{}`
+		cancelCode  = http.StatusOK
+		placeErr    error
+	)
+	c := NewClient("apiKey", "secretKey")
+	c.do = func(req *http.Request) (*http.Response, error) {
+		body, _ := io.ReadAll(req.Body)
+		form, _ := url.ParseQuery(string(body))
+		switch req.Method {
+		case http.MethodPost:
+			calls = append(calls, "place "+form.Get("newClientOrderId"))
+			if placeErr != nil {
+				return nil, placeErr
+			}
+			return newHTTPResponse([]byte(fmt.Sprintf(`{"clientOrderId":"%s","status":"NEW"}`, form.Get("newClientOrderId"))), http.StatusOK), nil
+		case http.MethodDelete:
+			calls = append(calls, "cancel "+form.Get("origClientOrderId"))
+			return newHTTPResponse([]byte(cancelReply), cancelCode), nil
+		}
+		return newHTTPResponse([]byte(`{}`), http.StatusNotFound), nil
+	}
+	newOrder := func() *CreateOrderService {
+		return c.NewCreateOrderService().Symbol("BTCUSDT").Side(SideTypeBuy).Type(OrderTypeLimit).
+			TimeInForce(TimeInForceTypeGTC).Quantity("1").Price("100")
+	}
+
+	r := NewCancelReplacer(c)
+	res, err := r.Replace(newContext(), "bid1", newOrder())
+	assert.NoError(err)
+	assert.Empty(res.CanceledClientOrderID)
+	first := res.Order.ClientOrderID
+	assert.True(strings.HasPrefix(first, "bid1-"))
+	assert.Equal([]string{"place " + first}, calls)
+
+	calls = nil
+	cancelReply, cancelCode = `{"code":-2011,"msg":"Unknown order sent."}`, http.StatusBadRequest
+	res, err = r.Replace(newContext(), "bid1", newOrder())
+	assert.NoError(err, "order filled before cancel is ignored")
+	assert.Equal(first, res.CanceledClientOrderID)
+	assert.Nil(res.Canceled)
+	second := res.Order.ClientOrderID
+	assert.NotEqual(first, second)
+	assert.Equal([]string{"cancel " + first, "place " + second}, calls)
+
+	calls = nil
+	cancelReply, cancelCode = `{"code":-1001,"msg":"Internal error."}`, http.StatusInternalServerError
+	_, err = r.Replace(newContext(), "bid1", newOrder())
+	assert.Error(err)
+	assert.Equal([]string{"cancel " + second}, calls, "nothing placed while the live order may remain")
+	id, ok := r.ClientOrderID("bid1")
+	assert.True(ok)
+	assert.Equal(second, id)
+
+	calls = nil
+	cancelReply, cancelCode = `{}`, http.StatusOK
+	placeErr = errors.New("timeout")
+	_, err = r.Replace(newContext(), "bid1", newOrder())
+	assert.Error(err)
+	third, _ := r.ClientOrderID("bid1")
+	assert.Equal([]string{"cancel " + second, "place " + third}, calls)
+
+	calls = nil
+	_, err = r.Cancel(newContext(), "bid1")
+	assert.NoError(err)
+	assert.Equal([]string{"cancel " + third}, calls, "order of unknown placement outcome is canceled")
+	_, ok = r.ClientOrderID("bid1")
+	assert.False(ok)
+}
+
+func TestCancelReplacerUnknownPlacement(t *testing.T) {
+	assert := assert.New(t)
+
+	var (
+		calls      []string
+		placeErr   = errors.New("timeout")
+		queryReply = `{"clientOrderId":"bid1","status":"NEW"}`
+		queryCode  = http.StatusOK
+	)
+	c := NewClient("apiKey", "secretKey")
+	c.do = func(req *http.Request) (*http.Response, error) {
+		body, _ := io.ReadAll(req.Body)
+		form, _ := url.ParseQuery(string(body))
+		switch req.Method {
+		case http.MethodPost:
+			calls = append(calls, "place "+form.Get("newClientOrderId"))
+			if placeErr != nil {
+				return nil, placeErr
+			}
+			return newHTTPResponse([]byte(fmt.Sprintf(`{"clientOrderId":"%s","status":"NEW"}`, form.Get("newClientOrderId"))), http.StatusOK), nil
+		case http.MethodDelete:
+			calls = append(calls, "cancel "+form.Get("origClientOrderId"))
+			return newHTTPResponse([]byte(`{"code":-2011,"msg":"Unknown order sent."}`), http.StatusBadRequest), nil
+		case http.MethodGet:
+			calls = append(calls, "query "+req.URL.Query().Get("origClientOrderId"))
+			return newHTTPResponse([]byte(queryReply), queryCode), nil
+		}
+		return newHTTPResponse([]byte(`{}`), http.StatusNotFound), nil
+	}
+	newOrder := func() *CreateOrderService {
+		return c.NewCreateOrderService().Symbol("BTCUSDT").Side(SideTypeBuy).Type(OrderTypeLimit).
+			TimeInForce(TimeInForceTypeGTC).Quantity("1").Price("100")
+	}
+
+	r := NewCancelReplacer(c)
+	_, err := r.Replace(newContext(), "bid1", newOrder())
+	assert.Error(err)
+	first, _ := r.ClientOrderID("bid1")
+
+	// the order timed out was not known when canceled but turns out live
+	calls = nil
+	placeErr = nil
+	_, err = r.Replace(newContext(), "bid1", newOrder())
+	assert.ErrorIs(err, ErrCancelReplaceOrderLive)
+	assert.Equal([]string{"cancel " + first, "query " + first}, calls, "nothing placed while the order is live")
+	id, ok := r.ClientOrderID("bid1")
+	assert.True(ok)
+	assert.Equal(first, id)
+
+	// it was never placed
+	calls = nil
+	queryReply, queryCode = `{"code":-2013,"msg":"Order does not exist."}`, http.StatusBadRequest
+	res, err := r.Replace(newContext(), "bid1", newOrder())
+	assert.NoError(err)
+	second := res.Order.ClientOrderID
+	assert.Equal([]string{"cancel " + first, "query " + first, "place " + second}, calls)
+
+	// orders placed are gone when unknown
+	calls = nil
+	_, err = r.Cancel(newContext(), "bid1")
+	assert.NoError(err)
+	assert.Equal([]string{"cancel " + second}, calls)
+}
@@ -0,0 +1,201 @@
+package futures
+
+import (
+	"context"
+	"errors"
+	"net"
+	"sync"
+	"time"
+
+	"github.com/adshao/go-binance/v2/common"
+)
+
+var ErrCircuitOpen = errors.New("circuit breaker: order placement suspended after consecutive failures")
+
+// CircuitState define state of a CircuitBreaker
+type CircuitState string
+
+// Circuit breaker states
+const (
+	// CircuitClosed lets orders through
+	CircuitClosed CircuitState = "CLOSED"
+	// CircuitOpen rejects orders until the cooldown elapsed
+	CircuitOpen CircuitState = "OPEN"
+	// CircuitHalfOpen lets a few probe orders through, closing on success and opening on failure
+	CircuitHalfOpen CircuitState = "HALF_OPEN"
+)
+
+// CircuitBreaker suspends order placement after Threshold consecutive failures, so a
+// misconfigured strategy or an exchange incident doesn't keep consuming the order rate limit.
+// Once Cooldown elapsed, up to HalfOpenProbes orders are let through to probe whether the
+// failures are over. Set it as CircuitBreaker of Client and ClientWs to guard orders placed
+// through them, several clients may share one.
+type CircuitBreaker struct {
+	Threshold int
+	Cooldown  time.Duration
+	// HalfOpenProbes is the number of orders let through at once while half-open, 1 if not set
+	HalfOpenProbes int
+	// IsFailure tells whether the error of a placement counts as failure, by default rejects of
+	// the exchange and timeouts do. Errors not counted neither trip nor reset the breaker.
+	IsFailure func(err error) bool
+	// OnStateChange, if set, is called on every state change, without holding the breaker
+	OnStateChange func(from, to CircuitState)
+
+	clock    common.Clock
+	mu       sync.Mutex
+	state    CircuitState
+	failures int
+	openedAt time.Time
+	probes   int
+}
+
+// NewCircuitBreaker init CircuitBreaker tripping after threshold consecutive failures for cooldown
+func NewCircuitBreaker(threshold int, cooldown time.Duration) *CircuitBreaker {
+	return &CircuitBreaker{
+		Threshold: threshold,
+		Cooldown:  cooldown,
+		clock:     common.SystemClock,
+		state:     CircuitClosed,
+	}
+}
+
+// State returns the current state, an open breaker whose cooldown elapsed is reported half-open
+func (b *CircuitBreaker) State() CircuitState {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.state == CircuitOpen && b.cooledDown() {
+		return CircuitHalfOpen
+	}
+	return b.state
+}
+
+// Reset closes the breaker and clears the count of failures
+func (b *CircuitBreaker) Reset() {
+	b.mu.Lock()
+	from := b.state
+	b.state, b.failures, b.probes = CircuitClosed, 0, 0
+	b.mu.Unlock()
+
+	b.changed(from, CircuitClosed)
+}
+
+// allow returns ErrCircuitOpen if an order may not be placed now, otherwise done must be called
+// with the outcome of the placement
+func (b *CircuitBreaker) allow() error {
+	b.mu.Lock()
+	from := b.state
+	switch b.state {
+	case CircuitOpen:
+		if !b.cooledDown() {
+			b.mu.Unlock()
+			return ErrCircuitOpen
+		}
+		b.state, b.probes = CircuitHalfOpen, 0
+		fallthrough
+	case CircuitHalfOpen:
+		probes := b.HalfOpenProbes
+		if probes <= 0 {
+			probes = 1
+		}
+		if b.probes >= probes {
+			b.mu.Unlock()
+			return ErrCircuitOpen
+		}
+		b.probes++
+	}
+	to := b.state
+	b.mu.Unlock()
+
+	b.changed(from, to)
+	return nil
+}
+
+// done records outcome err of a placement let through by allow
+func (b *CircuitBreaker) done(err error) {
+	isFailure := b.IsFailure
+	if isFailure == nil {
+		isFailure = isCircuitFailure
+	}
+
+	b.mu.Lock()
+	from := b.state
+	if b.state == CircuitHalfOpen {
+		b.probes--
+	}
+	switch {
+	case err == nil:
+		b.failures = 0
+		b.state = CircuitClosed
+	case isFailure(err):
+		b.failures++
+		if b.state == CircuitHalfOpen || (b.state == CircuitClosed && b.failures >= b.Threshold) {
+			b.state = CircuitOpen
+			b.openedAt = b.clock.Now()
+		}
+	}
+	to := b.state
+	b.mu.Unlock()
+
+	b.changed(from, to)
+}
+
+// release frees the probe taken by allow for an order which was not placed after all, without
+// recording an outcome
+func (b *CircuitBreaker) release() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.state == CircuitHalfOpen {
+		b.probes--
+	}
+}
+
+func (b *CircuitBreaker) cooledDown() bool {
+	return b.clock.Now().Sub(b.openedAt) >= b.Cooldown
+}
+
+func (b *CircuitBreaker) changed(from, to CircuitState) {
+	if from != to && b.OnStateChange != nil {
+		b.OnStateChange(from, to)
+	}
+}
+
+// isCircuitFailure reports whether err is a reject of the exchange or a timeout
+func isCircuitFailure(err error) bool {
+	var apiErr *common.APIError
+	if errors.As(err, &apiErr) || errors.Is(err, context.DeadlineExceeded) {
+		return true
+	}
+	var netErr net.Error
+	return errors.As(err, &netErr) && netErr.Timeout()
+}
+
+// allowOrder checks whether breaker, if any, lets an order through and returns the function
+// recording its outcome
+func allowOrder(breaker *CircuitBreaker) (func(err error), error) {
+	if breaker == nil {
+		return func(error) {}, nil
+	}
+	if err := breaker.allow(); err != nil {
+		return nil, err
+	}
+	return breaker.done, nil
+}
+
+// admitOrders lets orders of symbols through breaker and then through the rate limiters, so
+// that orders rejected by the breaker take no rate limit capacity. It returns the function
+// recording the outcome of the request placing them.
+func admitOrders(ctx context.Context, breaker *CircuitBreaker, limiter *OrderLimiter, throttle *SymbolThrottle, symbols ...string) (func(err error), error) {
+	done, err := allowOrder(breaker)
+	if err != nil {
+		return nil, err
+	}
+	if err := throttleOrders(ctx, limiter, throttle, symbols...); err != nil {
+		if breaker != nil {
+			breaker.release()
+		}
+		return nil, err
+	}
+	return done, nil
+}
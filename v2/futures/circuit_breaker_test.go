@@ -0,0 +1,247 @@
+package futures
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/adshao/go-binance/v2/common"
+	"github.com/stretchr/testify/assert"
+)
+
+func newTestCircuitBreaker(threshold int, cooldown time.Duration) (*CircuitBreaker, *common.FakeClock, *[]CircuitState) {
+	clock := common.NewFakeClock(time.Unix(1000, 0))
+	b := NewCircuitBreaker(threshold, cooldown)
+	b.clock = clock
+	var states []CircuitState
+	b.OnStateChange = func(from, to CircuitState) {
+		states = append(states, to)
+	}
+	return b, clock, &states
+}
+
+func TestCircuitBreaker(t *testing.T) {
+	b, clock, states := newTestCircuitBreaker(3, time.Minute)
+	reject := &common.APIError{Code: -2019, Message: "Margin is insufficient."}
+
+	// a success resets the count of consecutive failures
+	for _, err := range []error{reject, reject, nil, reject, context.DeadlineExceeded} {
+		assert.NoError(t, b.allow())
+		b.done(err)
+	}
+	assert.Equal(t, CircuitClosed, b.State())
+
+	// errors other than rejects and timeouts are not counted
+	assert.NoError(t, b.allow())
+	b.done(context.Canceled)
+	assert.NoError(t, b.allow())
+	b.done(reject)
+	assert.Equal(t, CircuitOpen, b.State())
+	assert.ErrorIs(t, b.allow(), ErrCircuitOpen)
+
+	// a single probe is let through once cooled down, its failure opens the breaker again
+	clock.Advance(time.Minute)
+	assert.Equal(t, CircuitHalfOpen, b.State())
+	assert.NoError(t, b.allow())
+	assert.ErrorIs(t, b.allow(), ErrCircuitOpen)
+	b.done(reject)
+	assert.Equal(t, CircuitOpen, b.State())
+
+	// a successful probe closes it
+	clock.Advance(time.Minute)
+	assert.NoError(t, b.allow())
+	b.done(nil)
+	assert.Equal(t, CircuitClosed, b.State())
+	assert.NoError(t, b.allow())
+	b.done(nil)
+
+	assert.Equal(t, []CircuitState{CircuitOpen, CircuitHalfOpen, CircuitOpen, CircuitHalfOpen, CircuitClosed}, *states)
+}
+
+func TestCircuitBreakerHalfOpenProbes(t *testing.T) {
+	b, clock, _ := newTestCircuitBreaker(1, time.Second)
+	b.HalfOpenProbes = 2
+	b.IsFailure = func(err error) bool { return err != nil }
+
+	assert.NoError(t, b.allow())
+	b.done(errors.New("failure"))
+	clock.Advance(time.Second)
+
+	assert.NoError(t, b.allow())
+	assert.NoError(t, b.allow())
+	assert.ErrorIs(t, b.allow(), ErrCircuitOpen)
+	// a successful probe closes the breaker while the other one is in flight
+	b.done(nil)
+	assert.Equal(t, CircuitClosed, b.State())
+
+	b.Reset()
+	assert.Equal(t, CircuitClosed, b.State())
+}
+
+func TestAdmitOrdersReleasesProbe(t *testing.T) {
+	b, clock, _ := newTestCircuitBreaker(1, time.Second)
+	assert.NoError(t, b.allow())
+	b.done(&common.APIError{Code: -2019})
+	clock.Advance(time.Second)
+
+	// an order held back by the throttle leaves the probe to the next one
+	throttle, _ := newTestSymbolThrottle(1, 0)
+	_, err := admitOrders(context.Background(), b, nil, throttle, "BTCUSDT")
+	assert.ErrorIs(t, err, ErrSymbolThrottled)
+	done, err := admitOrders(context.Background(), b, nil, nil, "BTCUSDT")
+	assert.NoError(t, err)
+	done(nil)
+	assert.Equal(t, CircuitClosed, b.State())
+}
+
+func TestCreateOrderCircuitBreaker(t *testing.T) {
+	c := NewClient("apiKey", "secretKey")
+	c.CircuitBreaker = NewCircuitBreaker(2, time.Hour)
+	requests := 0
+	c.do = func(req *http.Request) (*http.Response, error) {
+		requests++
+		return &http.Response{
+			StatusCode: http.StatusBadRequest,
+			Body:       io.NopCloser(bytes.NewBufferString(`{"code":-2019,"msg":"Margin is insufficient."}`)),
+		}, nil
+	}
+
+	for i := 0; i < 3; i++ {
+		_, err := c.NewCreateOrderService().Symbol("BTCUSDT").Side(SideTypeBuy).
+			Type(OrderTypeMarket).Quantity("0.1").Do(newContext())
+		if i < 2 {
+			assert.True(t, common.IsAPIError(err))
+		} else {
+			assert.ErrorIs(t, err, ErrCircuitOpen)
+		}
+	}
+	assert.Equal(t, 2, requests)
+	assert.Equal(t, CircuitOpen, c.CircuitBreaker.State())
+}
+
+func TestModifyOrderCircuitBreaker(t *testing.T) {
+	c := NewClient("apiKey", "secretKey")
+	c.CircuitBreaker = NewCircuitBreaker(1, time.Hour)
+	requests := 0
+	c.do = func(req *http.Request) (*http.Response, error) {
+		requests++
+		return &http.Response{
+			StatusCode: http.StatusBadRequest,
+			Body:       io.NopCloser(bytes.NewBufferString(`{"code":-2019,"msg":"Margin is insufficient."}`)),
+		}, nil
+	}
+
+	_, err := c.NewModifyOrderService().Symbol("BTCUSDT").Side(SideTypeBuy).OrderID(1).
+		Quantity("0.1").Price("60000").Do(newContext())
+	assert.True(t, common.IsAPIError(err))
+	_, err = c.NewModifyBatchOrdersService().OrderList([]*ModifyOrderService{
+		c.NewModifyOrderService().Symbol("BTCUSDT").Side(SideTypeBuy).OrderID(1).Quantity("0.1").Price("60000"),
+	}).Do(newContext())
+	assert.ErrorIs(t, err, ErrCircuitOpen)
+	assert.Equal(t, 1, requests)
+}
+
+func TestCreateBatchOrdersCircuitBreaker(t *testing.T) {
+	c := NewClient("apiKey", "secretKey")
+	c.CircuitBreaker = NewCircuitBreaker(2, time.Hour)
+	requests := 0
+	c.do = func(req *http.Request) (*http.Response, error) {
+		requests++
+		body := `[{"code":-2019,"msg":"Margin is insufficient."},{"code":-2019,"msg":"Margin is insufficient."}]`
+		if requests == 1 {
+			// a batch with an accepted order is not a failure
+			body = `[{"orderId":1,"symbol":"BTCUSDT","status":"NEW"},{"code":-2019,"msg":"Margin is insufficient."}]`
+		}
+		return &http.Response{StatusCode: http.StatusOK, Body: io.NopCloser(bytes.NewBufferString(body))}, nil
+	}
+
+	for i := 0; i < 4; i++ {
+		_, err := c.NewCreateBatchOrdersService().OrderList([]*CreateOrderService{
+			c.NewCreateOrderService().Symbol("BTCUSDT").Side(SideTypeBuy).Type(OrderTypeMarket).Quantity("0.1"),
+			c.NewCreateOrderService().Symbol("BTCUSDT").Side(SideTypeBuy).Type(OrderTypeMarket).Quantity("0.1"),
+		}).Do(newContext())
+		if i < 3 {
+			assert.NoError(t, err)
+		} else {
+			assert.ErrorIs(t, err, ErrCircuitOpen)
+		}
+	}
+	assert.Equal(t, 3, requests)
+	assert.Equal(t, CircuitOpen, c.CircuitBreaker.State())
+}
+
+func TestCreateOrderTestSkipsCircuitBreaker(t *testing.T) {
+	c := NewClient("apiKey", "secretKey")
+	c.CircuitBreaker = NewCircuitBreaker(1, time.Hour)
+	requests := 0
+	c.do = func(req *http.Request) (*http.Response, error) {
+		requests++
+		return &http.Response{
+			StatusCode: http.StatusBadRequest,
+			Body:       io.NopCloser(bytes.NewBufferString(`{"code":-2019,"msg":"Margin is insufficient."}`)),
+		}, nil
+	}
+
+	for i := 0; i < 2; i++ {
+		_, err := c.NewCreateOrderService().Symbol("BTCUSDT").Side(SideTypeBuy).
+			Type(OrderTypeMarket).Quantity("0.1").Test(newContext())
+		assert.True(t, common.IsAPIError(err))
+	}
+	assert.Equal(t, CircuitClosed, c.CircuitBreaker.State())
+
+	// test orders still go through an open breaker
+	assert.NoError(t, c.CircuitBreaker.allow())
+	c.CircuitBreaker.done(&common.APIError{Code: -2019})
+	_, err := c.NewCreateOrderService().Symbol("BTCUSDT").Side(SideTypeBuy).
+		Type(OrderTypeMarket).Quantity("0.1").Test(newContext())
+	assert.True(t, common.IsAPIError(err))
+	assert.Equal(t, 3, requests)
+}
+
+func (s *clientWsTestSuite) TestOrderPlaceCircuitBreaker() {
+	s.wsClient.CircuitBreaker = NewCircuitBreaker(1, time.Hour)
+	req := NewOrderPlaceWsRequest().Symbol("BTCUSDT").Side(SideTypeBuy).Type(OrderTypeMarket).Quantity("1")
+
+	// unscripted methods get a reject
+	_, err := s.wsClient.NewOrderPlaceWsService().Do(newContext(), req)
+	s.True(common.IsAPIError(err))
+	_, err = s.wsClient.NewOrderPlaceWsService().Do(newContext(), req)
+	s.ErrorIs(err, ErrCircuitOpen)
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.Len(s.requests, 1)
+}
+
+func (s *clientWsTestSuite) TestOrderModifyGuards() {
+	s.wsClient.RiskChecker = &RiskChecker{
+		Limits:     RiskLimits{MaxOrderNotional: 1000},
+		MarkPrices: fakeMarkPrices{"BTCUSDT": 60000},
+	}
+	s.wsClient.CircuitBreaker = NewCircuitBreaker(1, time.Hour)
+	s.wsClient.SymbolThrottle, _ = newTestSymbolThrottle(1, 2)
+	service := s.wsClient.NewOrderModifyWsService()
+
+	_, err := service.Do(newContext(), NewModifyOrderRequest().Symbol("BTCUSDT").Side(SideTypeBuy).
+		OrderID(1).Quantity("0.1").Price("60000"))
+	assertRiskRule(s.T(), RiskRuleOrderNotional, err)
+
+	req := NewModifyOrderRequest().Symbol("BTCUSDT").Side(SideTypeBuy).OrderID(1).Quantity("0.01").Price("60000")
+	// unscripted methods get a reject
+	_, err = service.Do(newContext(), req)
+	s.True(common.IsAPIError(err))
+	_, err = service.Do(newContext(), req)
+	s.ErrorIs(err, ErrCircuitOpen)
+
+	// the orders rejected before they were sent took nothing from the throttle
+	s.wsClient.CircuitBreaker.Reset()
+	_, err = service.Do(newContext(), req)
+	s.True(common.IsAPIError(err))
+	s.Equal(SymbolThrottleStats{Allowed: 2}, s.wsClient.SymbolThrottle.Stats()["BTCUSDT"])
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.Len(s.requests, 2)
+}
@@ -3,8 +3,6 @@ package futures
 import (
 	"bytes"
 	"context"
-	"crypto/hmac"
-	"crypto/sha256"
 	"crypto/tls"
 	"encoding/json"
 	"fmt"
@@ -13,6 +11,8 @@ import (
 	"net/http"
 	"net/url"
 	"os"
+	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/bitly/go-simplejson"
@@ -109,6 +109,7 @@ const (
 	OrderExecutionTypeCalculated  OrderExecutionType = "CALCULATED"
 	OrderExecutionTypeExpired     OrderExecutionType = "EXPIRED"
 	OrderExecutionTypeTrade       OrderExecutionType = "TRADE"
+	OrderExecutionTypeAmendment   OrderExecutionType = "AMENDMENT"
 
 	OrderStatusTypeNew             OrderStatusType = "NEW"
 	OrderStatusTypePartiallyFilled OrderStatusType = "PARTIALLY_FILLED"
@@ -247,7 +248,35 @@ type Client struct {
 	Debug      bool
 	Logger     *log.Logger
 	TimeOffset int64
-	do         doFunc
+	// RiskChecker, if set, checks orders before they are created
+	RiskChecker OrderRiskChecker
+	// CircuitBreaker, if set, suspends order placement after consecutive failures
+	CircuitBreaker *CircuitBreaker
+	// SymbolThrottle, if set, limits the rate of orders placed per symbol
+	SymbolThrottle *SymbolThrottle
+	// OrderLimiter, if set, limits the rate of orders placed by the account
+	OrderLimiter *OrderLimiter
+	// WeightScheduler, if set, holds back requests of lower priority when request weight headroom is low
+	WeightScheduler *WeightScheduler
+	// RetryPolicy, if set, retries queries and cancels failing transiently
+	RetryPolicy *RetryPolicy
+	// Interceptors wrap sending of every request attempt, the first one being the outermost
+	Interceptors []common.Interceptor
+	// AuditSink, if set, receives every order request and its response
+	AuditSink AuditSink
+	// EventBus, if set, receives order lifecycle events
+	EventBus *EventBus
+	do       doFunc
+	credMu   sync.RWMutex
+	signer   common.Signer
+	hmac     hmacSignerCache
+
+	rateLimits rateLimitGauge
+	// poolKey is the key of the APIKeyPool which created the client, if any
+	poolKey *poolAPIKey
+
+	positionModeMu sync.Mutex
+	dualSide       *bool
 }
 
 func (c *Client) debug(format string, v ...interface{}) {
@@ -256,6 +285,63 @@ func (c *Client) debug(format string, v ...interface{}) {
 	}
 }
 
+// SetCredentials replaces API key and secret key at runtime. Requests built afterwards are
+// signed with the new pair, requests already sent are not affected.
+func (c *Client) SetCredentials(apiKey, secretKey string) {
+	c.credMu.Lock()
+	defer c.credMu.Unlock()
+
+	c.APIKey = apiKey
+	c.SecretKey = secretKey
+}
+
+// SetSigner makes the client sign requests with signer instead of SecretKey, e.g. an
+// Ed25519Signer of an Ed25519 API key. Pass nil to sign with SecretKey again.
+func (c *Client) SetSigner(signer common.Signer) {
+	c.credMu.Lock()
+	defer c.credMu.Unlock()
+
+	c.signer = signer
+}
+
+func (c *Client) credentials() (apiKey string, signer common.Signer) {
+	c.credMu.RLock()
+	defer c.credMu.RUnlock()
+
+	if c.signer != nil {
+		return c.APIKey, c.signer
+	}
+	return c.APIKey, c.hmac.get(c.SecretKey)
+}
+
+// Healthy pings the API and checks TimeOffset is sane, so it can back readiness probes
+func (c *Client) Healthy(ctx context.Context) error {
+	if err := c.NewPingService().Do(ctx); err != nil {
+		return err
+	}
+	return common.CheckTimeOffset(c.TimeOffset)
+}
+
+// hmacSignerCache keeps the HMACSigner of the last secret key of a client, so signed requests
+// reuse its HMAC states
+type hmacSignerCache struct {
+	entry atomic.Pointer[hmacSignerEntry]
+}
+
+type hmacSignerEntry struct {
+	secretKey string
+	signer    *common.HMACSigner
+}
+
+func (c *hmacSignerCache) get(secretKey string) *common.HMACSigner {
+	if e := c.entry.Load(); e != nil && e.secretKey == secretKey {
+		return e.signer
+	}
+	e := &hmacSignerEntry{secretKey: secretKey, signer: common.NewHMACSigner(secretKey)}
+	c.entry.Store(e)
+	return e.signer
+}
+
 func (c *Client) parseRequest(r *request, opts ...RequestOption) (err error) {
 	// set request options from user
 	for _, opt := range opts {
@@ -284,19 +370,18 @@ func (c *Client) parseRequest(r *request, opts ...RequestOption) (err error) {
 		header.Set("Content-Type", "application/x-www-form-urlencoded")
 		body = bytes.NewBufferString(bodyString)
 	}
+	key, signer := c.credentials()
 	if r.secType == secTypeAPIKey || r.secType == secTypeSigned {
-		header.Set("X-MBX-APIKEY", c.APIKey)
+		header.Set("X-MBX-APIKEY", key)
 	}
 
 	if r.secType == secTypeSigned {
-		raw := fmt.Sprintf("%s%s", queryString, bodyString)
-		mac := hmac.New(sha256.New, []byte(c.SecretKey))
-		_, err = mac.Write([]byte(raw))
+		signature, err := signer.Sign(context.Background(), queryString+bodyString)
 		if err != nil {
 			return err
 		}
 		v := url.Values{}
-		v.Set(signatureKey, fmt.Sprintf("%x", (mac.Sum(nil))))
+		v.Set(signatureKey, signature)
 		if queryString == "" {
 			queryString = v.Encode()
 		} else {
@@ -319,9 +404,69 @@ func (c *Client) callAPI(ctx context.Context, r *request, opts ...RequestOption)
 	if err != nil {
 		return []byte{}, &http.Header{}, err
 	}
+	policy := c.RetryPolicy
+	if policy == nil || !retrySafe(r) {
+		data, header, _, err = c.callOnce(ctx, r)
+		return data, header, err
+	}
+
+	b := policy.backoff()
+	for attempt := 1; ; attempt++ {
+		data, header, res, err := c.callOnce(ctx, r)
+		if err == nil {
+			return data, header, nil
+		}
+		delay, ok := policy.retryDelay(b, res, err)
+		if !ok || attempt >= policy.MaxAttempts {
+			return data, header, err
+		}
+		c.debug("retry in %s after error: %v", delay, err)
+		select {
+		case <-time.After(delay):
+		case <-ctx.Done():
+			return []byte{}, &http.Header{}, ctx.Err()
+		}
+		// signed requests get a fresh timestamp and signature
+		if err := c.parseRequest(r); err != nil {
+			return []byte{}, &http.Header{}, err
+		}
+	}
+}
+
+// apiResponse define status and header of a REST response, zero if none was received
+type apiResponse struct {
+	status int
+	header http.Header
+}
+
+// callOnce sends request built by parseRequest
+func (c *Client) callOnce(ctx context.Context, r *request) (data []byte, header *http.Header, apiRes apiResponse, err error) {
+	if c.WeightScheduler != nil {
+		if err := c.WeightScheduler.wait(ctx, requestPriority(r)); err != nil {
+			return []byte{}, &http.Header{}, apiRes, err
+		}
+	}
+	if c.poolKey != nil {
+		defer func() {
+			c.poolKey.done(isOrderPlacement(r), apiRes, err)
+		}()
+	}
+	order, err := c.startOrderRequest(r)
+	if err != nil {
+		return []byte{}, &http.Header{}, apiRes, err
+	}
+	var (
+		status int
+		raw    []byte
+	)
+	if order != nil {
+		defer func() {
+			order.done(status, raw, err)
+		}()
+	}
 	req, err := http.NewRequest(r.method, r.fullURL, r.body)
 	if err != nil {
-		return []byte{}, &http.Header{}, err
+		return []byte{}, &http.Header{}, apiRes, err
 	}
 	req = req.WithContext(ctx)
 	req.Header = r.header
@@ -330,14 +475,25 @@ func (c *Client) callAPI(ctx context.Context, r *request, opts ...RequestOption)
 	if f == nil {
 		f = c.HTTPClient.Do
 	}
-	res, err := f(req)
+	res, err := common.ChainInterceptors(common.RoundTripFunc(f), c.Interceptors...)(req)
 	if err != nil {
-		return []byte{}, &http.Header{}, err
+		return []byte{}, &http.Header{}, apiRes, err
 	}
+	if c.WeightScheduler != nil {
+		c.WeightScheduler.observe(res.StatusCode, res.Header)
+	}
+	usage := parseRateLimitUsage(res.Header, time.Now())
+	c.rateLimits.update(usage)
+	if r.rateLimitUsage != nil {
+		*r.rateLimitUsage = usage
+	}
+	status = res.StatusCode
+	apiRes = apiResponse{status: res.StatusCode, header: res.Header}
 	data, err = io.ReadAll(res.Body)
 	if err != nil {
-		return []byte{}, &http.Header{}, err
+		return []byte{}, &http.Header{}, apiRes, err
 	}
+	raw = data
 	defer func() {
 		cerr := res.Body.Close()
 		// Only overwrite the retured error if the original error was nil and an
@@ -356,9 +512,34 @@ func (c *Client) callAPI(ctx context.Context, r *request, opts ...RequestOption)
 		if e != nil {
 			c.debug("failed to unmarshal json: %s", e)
 		}
-		return nil, &http.Header{}, apiErr
+		return nil, &http.Header{}, apiRes, apiErr
+	}
+	return data, &res.Header, apiRes, nil
+}
+
+// unmarshalResponse decodes REST and websocket responses with JSONCodec, or with
+// common.StrictUnmarshal if StrictDecoding is enabled
+func unmarshalResponse(data []byte, v interface{}) error {
+	if StrictDecoding {
+		return common.StrictUnmarshal(data, v)
 	}
-	return data, &res.Header, nil
+	return JSONCodec.Unmarshal(data, v)
+}
+
+// unmarshalOrderResponse decodes responses of requests placing, modifying or canceling orders.
+// Those already took effect on the exchange, so with StrictDecoding a *common.SchemaError is
+// passed to StrictDecodingErrHandler instead of failing the request, a caller retrying on the
+// error would place the order twice.
+func unmarshalOrderResponse(data []byte, v interface{}) error {
+	if err := JSONCodec.Unmarshal(data, v); err != nil {
+		return err
+	}
+	if StrictDecoding {
+		if err := common.CheckSchema(data, v); err != nil && StrictDecodingErrHandler != nil {
+			StrictDecodingErrHandler(err)
+		}
+	}
+	return nil
 }
 
 // SetApiEndpoint set api Endpoint
@@ -442,6 +623,16 @@ func (c *Client) NewCreateBatchOrdersService() *CreateBatchOrdersService {
 	return &CreateBatchOrdersService{c: c}
 }
 
+// NewModifyOrderService init modifying order service
+func (c *Client) NewModifyOrderService() *ModifyOrderService {
+	return &ModifyOrderService{c: c}
+}
+
+// NewModifyBatchOrdersService init modifying batch order service
+func (c *Client) NewModifyBatchOrdersService() *ModifyBatchOrdersService {
+	return &ModifyBatchOrdersService{c: c}
+}
+
 // NewGetOrderService init get order service
 func (c *Client) NewGetOrderService() *GetOrderService {
 	return &GetOrderService{c: c}
@@ -457,6 +648,16 @@ func (c *Client) NewCancelAllOpenOrdersService() *CancelAllOpenOrdersService {
 	return &CancelAllOpenOrdersService{c: c}
 }
 
+// NewSliceOrderService init slice order service
+func (c *Client) NewSliceOrderService() *SliceOrderService {
+	return &SliceOrderService{c: c}
+}
+
+// NewCountdownCancelAllService init auto-cancel countdown service
+func (c *Client) NewCountdownCancelAllService() *CountdownCancelAllService {
+	return &CountdownCancelAllService{c: c}
+}
+
 // NewCancelMultipleOrdersService init cancel multiple orders service
 func (c *Client) NewCancelMultipleOrdersService() *CancelMultiplesOrdersService {
 	return &CancelMultiplesOrdersService{c: c}
@@ -492,6 +693,11 @@ func (c *Client) NewGetPositionRiskService() *GetPositionRiskService {
 	return &GetPositionRiskService{c: c}
 }
 
+// NewGetADLQuantileService init getting ADL quantile service
+func (c *Client) NewGetADLQuantileService() *GetADLQuantileService {
+	return &GetADLQuantileService{c: c}
+}
+
 // NewGetPositionMarginHistoryService init getting position margin history service
 func (c *Client) NewGetPositionMarginHistoryService() *GetPositionMarginHistoryService {
 	return &GetPositionMarginHistoryService{c: c}
@@ -6,10 +6,14 @@ import (
 	"io/ioutil"
 	"net/http"
 	"net/url"
+	"testing"
 
+	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
 	"github.com/stretchr/testify/suite"
+
+	"github.com/adshao/go-binance/v2/common"
 )
 
 type baseTestSuite struct {
@@ -158,3 +162,102 @@ func (s *baseTestSuite) assertTradeV3Equal(e, a *TradeV3) {
 	r.Equal(e.IsMaker, a.IsMaker, "IsMaker")
 	r.Equal(e.IsBestMatch, a.IsBestMatch, "IsBestMatch")
 }
+
+type baseWsTestSuite struct {
+	baseTestSuite
+	origWsServe func(*WsConfig, WsHandler, ErrHandler) (chan struct{}, chan struct{}, error)
+	endpoint    string
+}
+
+func (s *baseWsTestSuite) SetupTest() {
+	s.baseTestSuite.SetupTest()
+	s.origWsServe = wsServe
+}
+
+func (s *baseWsTestSuite) TearDownTest() {
+	wsServe = s.origWsServe
+	s.endpoint = ""
+}
+
+// mockWsServeMessages replaces wsServe with a stream replaying messages
+func (s *baseWsTestSuite) mockWsServeMessages(messages ...[]byte) {
+	wsServe = func(cfg *WsConfig, handler WsHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error) {
+		s.endpoint = cfg.Endpoint
+		doneC = make(chan struct{})
+		stopC = make(chan struct{})
+		go func() {
+			<-stopC
+			close(doneC)
+		}()
+		for _, m := range messages {
+			handler(m)
+		}
+		return doneC, stopC, nil
+	}
+}
+
+func TestHMACSignerCache(t *testing.T) {
+	assert := assert.New(t)
+	var cache hmacSignerCache
+	signer := cache.get("secret")
+	assert.Same(signer, cache.get("secret"))
+
+	rotated := cache.get("rotated")
+	assert.NotSame(signer, rotated)
+	expected, _ := common.NewHMACSigner("rotated").Sign(context.Background(), "a=1")
+	actual, err := rotated.Sign(context.Background(), "a=1")
+	assert.NoError(err)
+	assert.Equal(expected, actual)
+}
+
+func TestClientSetSigner(t *testing.T) {
+	c := newRetryTestClient()
+	var signature string
+	c.do = func(req *http.Request) (*http.Response, error) {
+		signature = req.URL.Query().Get(signatureKey)
+		return newHTTPResponse([]byte(`{}`), http.StatusOK), nil
+	}
+	c.SetSigner(common.SignerFunc(func(ctx context.Context, payload string) (string, error) {
+		return "signed+/=", nil
+	}))
+
+	_, err := c.NewGetOrderService().Symbol("BTCUSDT").OrderID(1).Do(newContext())
+	require.NoError(t, err)
+	assert.Equal(t, "signed+/=", signature)
+
+	c.SetSigner(nil)
+	_, err = c.NewGetOrderService().Symbol("BTCUSDT").OrderID(1).Do(newContext())
+	require.NoError(t, err)
+	assert.Len(t, signature, 64)
+}
+
+func TestClientInterceptors(t *testing.T) {
+	c := newRetryTestClient()
+	sent := 0
+	c.do = func(req *http.Request) (*http.Response, error) {
+		sent++
+		assert.Equal(t, "value", req.Header.Get("X-Custom"))
+		return newHTTPResponse([]byte(`{"serverTime":1499827319559}`), http.StatusOK), nil
+	}
+	// the first attempt fails without being sent, as injected by chaos testing
+	attempts := 0
+	c.Interceptors = []common.Interceptor{
+		common.BeforeSend(func(req *http.Request) error {
+			req.Header.Set("X-Custom", "value")
+			return nil
+		}),
+		func(req *http.Request, next common.RoundTripFunc) (*http.Response, error) {
+			attempts++
+			if attempts == 1 {
+				return newHTTPResponse([]byte(`{"code":-1001,"msg":"Internal error"}`), http.StatusServiceUnavailable), nil
+			}
+			return next(req)
+		},
+	}
+
+	serverTime, err := c.NewServerTimeService().Do(newContext())
+	require.NoError(t, err)
+	assert.Equal(t, int64(1499827319559), serverTime)
+	assert.Equal(t, 2, attempts)
+	assert.Equal(t, 1, sent)
+}
@@ -1,17 +1,19 @@
 package futures
 
 import (
+	"bytes"
 	"context"
-	"encoding/json"
 	"errors"
 	"fmt"
 	"log"
+	"net"
 	"os"
 	"sync"
 	"sync/atomic"
 	"time"
 
 	"github.com/adshao/go-binance/v2/common"
+	"github.com/google/uuid"
 	"github.com/gorilla/websocket"
 	"github.com/jpillora/backoff"
 )
@@ -19,37 +21,70 @@ import (
 const (
 	reconnectMinInterval = 100 * time.Millisecond
 	reconnectMaxInterval = 10 * time.Second
+
+	// errorRateWindowSize is the number of last requests Healthy computes error rate from
+	errorRateWindowSize = 50
 )
 
 var (
 	ErrWsConnectionClosed = errors.New("ws error: connection closed")
 	ErrWsIdAlreadySent    = errors.New("ws error: request with same id already sent")
+	ErrWsReconnecting     = errors.New("ws error: connection is being reestablished")
 )
 
 type call struct {
 	response []byte
-	done     chan error
+	// buf holds response, it is owned by the waiting caller
+	buf    *bytes.Buffer
+	status int
+	failed bool
+	done   chan error
 }
 
 type waiter struct {
 	*call
 }
 
-func (w waiter) wait(ctx context.Context) ([]byte, error) {
+func (w waiter) wait(ctx context.Context) ([]byte, int, error) {
 	select {
 	case err, ok := <-w.call.done:
 		if !ok {
 			err = ErrWsConnectionClosed
 		}
+		if err == nil && w.call.failed {
+			err = decodeWsApiError(w.call.response)
+		}
 		if err != nil {
-			return nil, err
+			return nil, w.call.status, err
 		}
-		return w.call.response, nil
+		return w.call.response, w.call.status, nil
 	case <-ctx.Done():
-		return nil, ctx.Err()
+		return nil, 0, ctx.Err()
+	}
+}
+
+// decodeWsApiError returns the error held by response
+func decodeWsApiError(response []byte) error {
+	msg := struct {
+		Error *common.APIError `json:"error"`
+	}{}
+	if err := JSONCodec.Unmarshal(response, &msg); err != nil {
+		return err
 	}
+	if msg.Error == nil {
+		return &common.APIError{Message: "websocket API error response without error"}
+	}
+	return msg.Error
 }
 
+// WsApiMetricsHandler is called on completion of every websocket API request with the method,
+// the symbol param if any, the time spent until the response, the response status (0 if no
+// response was received) and the error returned to the caller
+type WsApiMetricsHandler func(method string, symbol string, latency time.Duration, status int, err error)
+
+// WsApiMessageHandler handles raw message received on websocket API connection
+type WsApiMessageHandler func(message []byte)
+
 // ClientWs define API websocket client
 type ClientWs struct {
 	APIKey                      string
@@ -63,6 +98,36 @@ type ClientWs struct {
 	connectionEstablishedSignal chan struct{}
 	pending                     PendingRequests
 	reconnectCount              atomic.Int64
+	connectedAt                 atomic.Int64
+	lastMessageAt               atomic.Int64
+	reconnecting                atomic.Bool
+	errorRate                   *common.ErrorRateWindow
+	unhandledMessageHandler     atomic.Pointer[WsApiMessageHandler]
+	credMu                      sync.RWMutex
+	signer                      common.Signer
+	hmac                        hmacSignerCache
+	clock                       common.Clock
+	outbound                    atomic.Pointer[wsPriorityQueues]
+	limiter                     atomic.Pointer[wsRateLimiter]
+	writeTimeout                time.Duration
+	endpoint                    string
+	socket                      *WsSocketConfig
+	closed                      chan struct{}
+	closeOnce                   sync.Once
+	// MetricsHandler is optional, set it before sending requests
+	MetricsHandler WsApiMetricsHandler
+	// RiskChecker, if set, checks orders before they are placed
+	RiskChecker OrderRiskChecker
+	// CircuitBreaker, if set, suspends order placement after consecutive failures
+	CircuitBreaker *CircuitBreaker
+	// SymbolThrottle, if set, limits the rate of orders placed per symbol
+	SymbolThrottle *SymbolThrottle
+	// OrderLimiter, if set, limits the rate of orders placed by the account
+	OrderLimiter *OrderLimiter
+	// AuditSink, if set, receives every order request and its response
+	AuditSink AuditSink
+	// EventBus, if set, receives order and connection lifecycle events
+	EventBus *EventBus
 }
 
 func (c *ClientWs) debug(format string, v ...interface{}) {
@@ -71,49 +136,325 @@ func (c *ClientWs) debug(format string, v ...interface{}) {
 	}
 }
 
-// NewClientWs init ClientWs
-func NewClientWs(apiKey, secretKey string) (*ClientWs, error) {
-	conn, err := WsApiInitReadWriteConn()
-	if err != nil {
-		return nil, err
+// OnUnhandledMessage sets handler of messages that don't answer any pending request, such as
+// push events or server notices, which are dropped otherwise. Pass nil to drop them again.
+func (c *ClientWs) OnUnhandledMessage(handler WsApiMessageHandler) {
+	if handler == nil {
+		c.unhandledMessageHandler.Store(nil)
+		return
+	}
+	c.unhandledMessageHandler.Store(&handler)
+}
+
+func (c *ClientWs) handleUnhandledMessage(message []byte) {
+	if handler := c.unhandledMessageHandler.Load(); handler != nil {
+		(*handler)(message)
+	}
+}
+
+// SetCredentials replaces API key and secret key without reconnecting. Requests sent afterwards
+// are signed with the new pair, requests already in flight are not affected.
+func (c *ClientWs) SetCredentials(apiKey, secretKey string) {
+	c.credMu.Lock()
+	defer c.credMu.Unlock()
+
+	c.APIKey = apiKey
+	c.SecretKey = secretKey
+}
+
+// SetSigner makes the client sign requests with signer instead of SecretKey, so the secret
+// key can be kept in an external signing service. Pass nil to sign with SecretKey again.
+func (c *ClientWs) SetSigner(signer common.Signer) {
+	c.credMu.Lock()
+	defer c.credMu.Unlock()
+
+	c.signer = signer
+}
+
+func (c *ClientWs) credentials() (key string, signer common.Signer) {
+	c.credMu.RLock()
+	defer c.credMu.RUnlock()
+
+	if c.signer != nil {
+		return c.APIKey, c.signer
 	}
+	return c.APIKey, c.hmac.get(c.SecretKey)
+}
+
+// ClientWsOption configures a ClientWs created by NewClientWs
+type ClientWsOption func(c *ClientWs)
+
+// WithWsApiEndpoint makes the client connect and reconnect to endpoint instead of the one
+// picked by WsApiEndpointSelector and UseTestnet
+func WithWsApiEndpoint(endpoint string) ClientWsOption {
+	return func(c *ClientWs) {
+		c.endpoint = endpoint
+	}
+}
 
+// WithWsSocketConfig makes the client dial its connections with socket instead of
+// WsSocketOptions
+func WithWsSocketConfig(socket WsSocketConfig) ClientWsOption {
+	return func(c *ClientWs) {
+		c.socket = &socket
+	}
+}
+
+// NewClientWs init ClientWs
+func NewClientWs(apiKey, secretKey string, opts ...ClientWsOption) (*ClientWs, error) {
 	client := &ClientWs{
 		APIKey:                      apiKey,
 		SecretKey:                   secretKey,
 		Logger:                      log.New(os.Stderr, "Binance-golang ", log.LstdFlags),
-		Conn:                        conn,
 		mu:                          sync.Mutex{},
 		reconnectSignal:             make(chan struct{}, 1),
 		connectionEstablishedSignal: make(chan struct{}, 1),
 		pending:                     NewPendingRequests(),
+		errorRate:                   common.NewErrorRateWindow(errorRateWindowSize),
+		clock:                       WsApiClock,
+		writeTimeout:                WsSocketOptions.WriteTimeout,
+		closed:                      make(chan struct{}),
+	}
+	for _, opt := range opts {
+		opt(client)
+	}
+	if client.socket != nil {
+		client.writeTimeout = client.socket.WriteTimeout
 	}
 
+	conn, err := client.dial()
+	if err != nil {
+		return nil, err
+	}
+	client.Conn = conn
+	client.connectedAt.Store(client.clock.Now().UnixNano())
+
 	go client.handleReconnect()
 	go client.read()
 
 	return client, nil
 }
 
-// Write sends data into websocket connection
+// Close closes the connection and stops reconnecting it, requests waiting for their response
+// fail with ErrWsConnectionClosed. The client can't be used afterwards.
+func (c *ClientWs) Close() error {
+	var err error
+	c.closeOnce.Do(func() {
+		close(c.closed)
+		c.mu.Lock()
+		err = c.Conn.Close()
+		c.mu.Unlock()
+		c.pending.closeAll()
+	})
+	return err
+}
+
+// isClosed reports whether Close was called
+func (c *ClientWs) isClosed() bool {
+	select {
+	case <-c.closed:
+		return true
+	default:
+		return false
+	}
+}
+
+// dial connects to the websocket API with the endpoint and socket settings of the client, the
+// package settings are read at each dial for those not set
+func (c *ClientWs) dial() (*websocket.Conn, error) {
+	endpoint := c.endpoint
+	if endpoint == "" {
+		endpoint = getWsApiEndpoint()
+	}
+	cfg := newWsConfig(endpoint)
+	cfg.Compression = WsApiCompression
+	if c.socket != nil {
+		cfg.Socket = *c.socket
+	}
+	return WsGetReadWriteConnection(cfg)
+}
+
+// Write sends data into websocket connection with WsPriorityNormal
 func (c *ClientWs) Write(id string, data []byte) (waiter, error) {
+	return c.WritePriority(id, data, WsPriorityNormal)
+}
+
+// WritePriority sends data into websocket connection once the rate limit lets it through, in
+// pipelined mode it is queued by priority
+func (c *ClientWs) WritePriority(id string, data []byte, priority WsRequestPriority) (waiter, error) {
+	priority = priority.clamp()
+	if err := c.waitRateLimit(context.Background(), priority); err != nil {
+		return waiter{}, err
+	}
+	if c.outbound.Load() == nil {
+		return c.writeDirect(id, data)
+	}
+	buf := getWsBuffer()
+	buf.Write(data)
+	return c.send(id, buf, priority)
+}
+
+// writeDirect writes data into websocket connection under the lock of the connection
+func (c *ClientWs) writeDirect(id string, data []byte) (waiter, error) {
 	c.mu.Lock()
 	defer c.mu.Unlock()
 
+	if c.isClosed() {
+		return waiter{}, ErrWsConnectionClosed
+	}
+
 	if c.pending.isAlreadyInList(id) {
 		return waiter{}, ErrWsIdAlreadySent
 	}
 
+	// the call is registered first so a fast response finds it
+	cc := c.pending.add(id)
+	c.setWriteDeadline(c.Conn)
 	if err := c.Conn.WriteMessage(websocket.TextMessage, data); err != nil {
 		c.debug("write: unable to write message into websocket conn '%v'", err)
+		c.pending.take([]byte(id))
+		c.closeOnTimeout(c.Conn, err)
 		return waiter{}, err
 	}
 
-	cc := c.pending.add(id)
-
 	return waiter{cc}, nil
 }
 
+// setWriteDeadline applies writeTimeout to the next writes of conn
+func (c *ClientWs) setWriteDeadline(conn *websocket.Conn) {
+	if c.writeTimeout > 0 {
+		_ = conn.SetWriteDeadline(time.Now().Add(c.writeTimeout))
+	}
+}
+
+// closeOnTimeout closes conn if writing failed on the deadline, a frame may have been partially
+// written so the read loop reconnects
+func (c *ClientWs) closeOnTimeout(conn *websocket.Conn, err error) {
+	var netErr net.Error
+	if errors.As(err, &netErr) && netErr.Timeout() {
+		conn.Close()
+	}
+}
+
+// doRequest sends request of method with params and waits for the raw response.
+// Signed requests get apiKey, timestamp and signature params added once the rate limit lets
+// the request of priority through. If err is nil, release must be called once the response
+// was decoded and is not used anymore.
+func (c *ClientWs) doRequest(ctx context.Context, method WsApiMethodType, params params, signed bool, priority WsRequestPriority) (response []byte, release func(), err error) {
+	priority = priority.clamp()
+	if err := c.waitRateLimit(ctx, priority); err != nil {
+		return nil, nil, err
+	}
+
+	id, err := uuid.NewRandom()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var signer common.Signer
+	if signed {
+		var key string
+		key, signer = c.credentials()
+		params[apiKey] = key
+		params[timestampKey] = currentTimestamp() - c.TimeOffset
+	}
+	// the signature and the payload are built from the same ordered params
+	ordered := sortParams(params)
+	if signed {
+		signature, err := signer.Sign(ctx, ordered.encode())
+		if err != nil {
+			return nil, nil, err
+		}
+		params[signatureKey] = signature
+		ordered = ordered.set(signatureKey, signature)
+	}
+
+	wsReq := orderedWsApiRequest{
+		Id:     id.String(),
+		Method: method,
+		Params: ordered,
+	}
+
+	reqBuf := getWsBuffer()
+	if err := wsReq.encodeTo(reqBuf); err != nil {
+		putWsBuffer(reqBuf)
+		return nil, nil, err
+	}
+
+	order, err := c.startOrderRequest(wsReq.Id, method, params)
+	if err != nil {
+		putWsBuffer(reqBuf)
+		return nil, nil, err
+	}
+
+	start := c.clock.Now()
+	waiter, err := c.send(wsReq.Id, reqBuf, priority)
+	if err != nil {
+		c.observe(method, params, start, 0, err)
+		if order != nil {
+			order.done(0, nil, err)
+		}
+		return nil, nil, err
+	}
+
+	response, status, err := waiter.wait(ctx)
+	if err != nil && status == 0 {
+		// no response is awaited anymore, e.g. the context timed out
+		c.pending.take([]byte(wsReq.Id))
+	}
+	c.observe(method, params, start, status, err)
+	if order != nil {
+		// the body of an error response is only safe to read once it was received
+		body := response
+		if err != nil && status != 0 {
+			body = waiter.call.response
+		}
+		// the audit sink and the event bus may keep the body, so its buffer is not reused
+		order.done(status, body, err)
+		if err != nil {
+			return nil, nil, err
+		}
+		return response, func() {}, nil
+	}
+	if err != nil {
+		// an error response was decoded by wait, a timed out request has no buffer yet
+		if status != 0 {
+			putWsBuffer(waiter.call.buf)
+		}
+		return nil, nil, err
+	}
+	return response, func() { putWsBuffer(waiter.call.buf) }, nil
+}
+
+// observe records outcome of completed request and reports it to MetricsHandler.
+// Only failures caused by connection, rate limits or server errors count against health.
+func (c *ClientWs) observe(method WsApiMethodType, params params, start time.Time, status int, err error) {
+	c.errorRate.Add(err != nil && (status == 0 || status == 418 || status == 429 || status >= 500))
+
+	if c.MetricsHandler == nil {
+		return
+	}
+	symbol, _ := params["symbol"].(string)
+	c.MetricsHandler(string(method), symbol, c.clock.Now().Sub(start), status, err)
+}
+
+// publishConnectionEvent publishes event of the current connection on EventBus, if set
+func (c *ClientWs) publishConnectionEvent(eventType EventType, err error) {
+	if c.EventBus == nil {
+		return
+	}
+	event := &Event{
+		Type:         eventType,
+		Time:         c.clock.Now(),
+		Transport:    AuditTransportWs,
+		ConnectionID: c.connectionID(),
+	}
+	if err != nil {
+		event.Error = err.Error()
+	}
+	c.EventBus.Publish(event)
+}
+
 // read data from connection
 func (c *ClientWs) read() {
 	defer func() {
@@ -124,44 +465,84 @@ func (c *ClientWs) read() {
 	}()
 
 	for {
-		_, message, err := c.Conn.ReadMessage()
+		buf, err := c.readMessage()
 		if err != nil {
-			c.debug("read: error reading message '%v'", message)
+			if c.isClosed() {
+				return
+			}
+			c.debug("read: error reading message '%v'", err)
+			c.publishConnectionEvent(EventTypeConnectionLost, err)
 			c.reconnectSignal <- struct{}{}
 
 			c.debug("read: wait to get connected")
-			<-c.connectionEstablishedSignal
+			select {
+			case <-c.connectionEstablishedSignal:
+			case <-c.closed:
+				return
+			}
 
 			c.debug("read: connection established")
 			continue
 		}
+		c.lastMessageAt.Store(c.clock.Now().UnixNano())
+		message := buf.Bytes()
 
-		msg := struct {
-			ID    string           `json:"id"`
-			Error *common.APIError `json:"error"`
-		}{}
-		err = json.Unmarshal(message, &msg)
-		if err != nil {
-			continue
+		h, ok := scanWsApiResponseHeader(message)
+		if !ok {
+			// ids with escapes are rare enough to decode the message for them
+			msg := struct {
+				ID     string           `json:"id"`
+				Status int              `json:"status"`
+				Error  *common.APIError `json:"error"`
+			}{}
+			if err := JSONCodec.Unmarshal(message, &msg); err != nil || msg.ID == "" {
+				c.handleUnhandledMessage(message)
+				continue
+			}
+			h = wsApiResponseHeader{ID: []byte(msg.ID), Status: msg.Status, Failed: msg.Error != nil || msg.Status >= 400}
 		}
 
-		if call := c.pending.get(msg.ID); call != nil {
-			call.response = message
-			if msg.Error != nil {
-				call.done <- msg.Error
-			} else {
-				call.done <- nil
-			}
-			close(call.done)
-			c.pending.remove(msg.ID)
+		call := c.pending.take(h.ID)
+		if call == nil {
+			c.handleUnhandledMessage(message)
+			continue
 		}
+
+		// the response is decoded by the waiting caller, which releases buf. Buffers of unhandled
+		// messages are not reused as handlers may keep them.
+		call.response = message
+		call.buf = buf
+		call.status = h.Status
+		call.failed = h.Failed
+		call.done <- nil
+		close(call.done)
 	}
 }
 
+// readMessage reads the next message into a pooled buffer
+func (c *ClientWs) readMessage() (*bytes.Buffer, error) {
+	_, r, err := c.Conn.NextReader()
+	if err != nil {
+		return nil, err
+	}
+	buf := getWsBuffer()
+	if _, err := buf.ReadFrom(r); err != nil {
+		putWsBuffer(buf)
+		return nil, err
+	}
+	return buf, nil
+}
+
 // handleReconnect waits for reconnect signal and starts reconnect
 func (c *ClientWs) handleReconnect() {
-	for range c.reconnectSignal {
+	for {
+		select {
+		case <-c.reconnectSignal:
+		case <-c.closed:
+			return
+		}
 		c.debug("reconnect: received signal")
+		c.reconnecting.Store(true)
 
 		b := &backoff.Backoff{
 			Min:    reconnectMinInterval,
@@ -171,27 +552,45 @@ func (c *ClientWs) handleReconnect() {
 		}
 
 		conn := c.startReconnect(b)
+		if conn == nil {
+			return
+		}
 
 		b.Reset()
 
 		c.mu.Lock()
+		if c.isClosed() {
+			// Close has closed the previous connection already
+			c.mu.Unlock()
+			conn.Close()
+			return
+		}
 		c.Conn = conn
 		c.mu.Unlock()
+		c.connectedAt.Store(c.clock.Now().UnixNano())
+		c.reconnecting.Store(false)
+		c.publishConnectionEvent(EventTypeReconnected, nil)
 
 		c.debug("reconnect: connected")
 		c.connectionEstablishedSignal <- struct{}{}
 	}
 }
 
-// startReconnect starts reconnect loop with increasing delay
+// startReconnect starts reconnect loop with increasing delay, nil once the client is closed
 func (c *ClientWs) startReconnect(b *backoff.Backoff) *websocket.Conn {
 	for {
 		c.reconnectCount.Add(1)
-		conn, err := WsApiInitReadWriteConn()
+		conn, err := c.dial()
 		if err != nil {
 			delay := b.Duration()
 			c.debug("reconnect: error while reconnecting. try in %s", delay.Round(time.Millisecond))
-			time.Sleep(delay)
+			timer := c.clock.NewTimer(delay)
+			select {
+			case <-timer.C():
+			case <-c.closed:
+				timer.Stop()
+				return nil
+			}
 			continue
 		}
 
@@ -204,6 +603,36 @@ func (c *ClientWs) GetReconnectCount() int64 {
 	return c.reconnectCount.Load()
 }
 
+// ConnectionAge returns time passed since the current connection was established.
+// Binance drops websocket API connections after 24 hours.
+func (c *ClientWs) ConnectionAge() time.Duration {
+	return c.clock.Now().Sub(time.Unix(0, c.connectedAt.Load()))
+}
+
+// Healthy reports whether the client is ready to serve requests. It fails while the connection
+// is being reestablished, when most of the recent requests failed or when TimeOffset is not sane.
+func (c *ClientWs) Healthy(ctx context.Context) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	if c.reconnecting.Load() {
+		return ErrWsReconnecting
+	}
+	if err := c.errorRate.Check(); err != nil {
+		return err
+	}
+	return common.CheckTimeOffset(c.TimeOffset)
+}
+
+// LastMessageAt returns time the last message was received, zero time if none was received yet
+func (c *ClientWs) LastMessageAt() time.Time {
+	ts := c.lastMessageAt.Load()
+	if ts == 0 {
+		return time.Time{}
+	}
+	return time.Unix(0, ts)
+}
+
 // NewPendingRequests creates request list
 func NewPendingRequests() PendingRequests {
 	return PendingRequests{
@@ -229,18 +658,35 @@ func (l *PendingRequests) add(id string) *call {
 	return c
 }
 
-func (l *PendingRequests) get(id string) *call {
+// take returns call of id and removes it, nil if there is none
+func (l *PendingRequests) take(id []byte) *call {
 	l.mu.Lock()
 	defer l.mu.Unlock()
 
-	return l.requests[id]
+	c, ok := l.requests[string(id)]
+	if ok {
+		delete(l.requests, string(id))
+	}
+	return c
 }
 
-func (l *PendingRequests) remove(id string) {
+// len returns count of requests waiting for their response
+func (l *PendingRequests) len() int {
 	l.mu.Lock()
 	defer l.mu.Unlock()
 
-	delete(l.requests, id)
+	return len(l.requests)
+}
+
+// closeAll removes all calls and completes them with ErrWsConnectionClosed
+func (l *PendingRequests) closeAll() {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	for id, c := range l.requests {
+		delete(l.requests, id)
+		close(c.done)
+	}
 }
 
 func (l *PendingRequests) isAlreadyInList(id string) bool {
@@ -0,0 +1,234 @@
+package futures
+
+import (
+	"bytes"
+	"errors"
+	"net"
+	"sync"
+	"sync/atomic"
+
+	"github.com/gorilla/websocket"
+)
+
+var ErrWsQueueFull = errors.New("ws error: outbound queue is full")
+
+// wsOutbound define a request waiting in the outbound queue
+type wsOutbound struct {
+	id   string
+	buf  *bytes.Buffer
+	call *call
+}
+
+type wsQueueSlot struct {
+	seq atomic.Uint64
+	req *wsOutbound
+}
+
+// wsOutboundQueue is a bounded lock-free queue of many producers and a single consumer. Each
+// slot carries a sequence number telling whether it is free for the producer of a position or
+// filled for the consumer, so producers only contend on a CAS of tail.
+type wsOutboundQueue struct {
+	mask   uint64
+	slots  []wsQueueSlot
+	head   atomic.Uint64
+	tail   atomic.Uint64
+	signal chan struct{}
+}
+
+// newWsOutboundQueue init wsOutboundQueue of size rounded up to a power of two
+func newWsOutboundQueue(size int) *wsOutboundQueue {
+	n := 2
+	for n < size {
+		n <<= 1
+	}
+	q := &wsOutboundQueue{
+		mask:   uint64(n - 1),
+		slots:  make([]wsQueueSlot, n),
+		signal: make(chan struct{}, 1),
+	}
+	for i := range q.slots {
+		q.slots[i].seq.Store(uint64(i))
+	}
+	return q
+}
+
+// push enqueues req, false if the queue is full
+func (q *wsOutboundQueue) push(req *wsOutbound) bool {
+	for {
+		tail := q.tail.Load()
+		slot := &q.slots[tail&q.mask]
+		seq := slot.seq.Load()
+		switch {
+		case seq == tail:
+			if q.tail.CompareAndSwap(tail, tail+1) {
+				slot.req = req
+				slot.seq.Store(tail + 1)
+				select {
+				case q.signal <- struct{}{}:
+				default:
+				}
+				return true
+			}
+		case seq < tail:
+			// the consumer has not freed the slot of the previous round yet
+			return false
+		}
+		// another producer took the position, retry with the next one
+	}
+}
+
+// pop dequeues the next request, nil if the queue is empty. Only the consumer calls it.
+func (q *wsOutboundQueue) pop() *wsOutbound {
+	head := q.head.Load()
+	slot := &q.slots[head&q.mask]
+	if slot.seq.Load() != head+1 {
+		return nil
+	}
+	req := slot.req
+	slot.req = nil
+	slot.seq.Store(head + uint64(len(q.slots)))
+	q.head.Store(head + 1)
+	return req
+}
+
+// EnablePipelining makes requests be enqueued on lock-free queues of queueSize per priority and
+// written by a single goroutine, instead of each caller locking the connection to write.
+// Queued requests of higher priority are written first. Responses are matched as before, so
+// callers only wait for their response. Requests fail with ErrWsQueueFull when the queue of
+// their priority is full. Call it once, before sending requests.
+func (c *ClientWs) EnablePipelining(queueSize int) {
+	q := newWsPriorityQueues(queueSize)
+	if !c.outbound.CompareAndSwap(nil, q) {
+		return
+	}
+	go c.writeLoop(q)
+}
+
+// send writes request id of buf with priority and returns waiter of its response, buf is
+// released once written
+func (c *ClientWs) send(id string, buf *bytes.Buffer, priority WsRequestPriority) (waiter, error) {
+	if c.isClosed() {
+		putWsBuffer(buf)
+		return waiter{}, ErrWsConnectionClosed
+	}
+	q := c.outbound.Load()
+	if q == nil {
+		w, err := c.writeDirect(id, buf.Bytes())
+		putWsBuffer(buf)
+		return w, err
+	}
+
+	if c.pending.isAlreadyInList(id) {
+		putWsBuffer(buf)
+		return waiter{}, ErrWsIdAlreadySent
+	}
+	// the call is registered first so a fast response finds it
+	cc := c.pending.add(id)
+	if !q.push(&wsOutbound{id: id, buf: buf, call: cc}, priority) {
+		c.pending.take([]byte(id))
+		putWsBuffer(buf)
+		return waiter{}, ErrWsQueueFull
+	}
+	return waiter{cc}, nil
+}
+
+// writeLoop writes queued requests, draining the queues under a single lock of the connection.
+// The frames of a drained batch are sent together if the connection was dialed by the default
+// WsGetReadWriteConnection.
+func (c *ClientWs) writeLoop(q *wsPriorityQueues) {
+	var batch []*wsOutbound
+	for {
+		select {
+		case <-q.signal:
+		case <-c.closed:
+			return
+		}
+		c.mu.Lock()
+		conn := c.Conn
+		coalescing := coalescingConnOf(conn)
+		if coalescing != nil {
+			coalescing.begin()
+		}
+		c.setWriteDeadline(conn)
+		for req := q.pop(); req != nil; req = q.pop() {
+			err := conn.WriteMessage(websocket.TextMessage, req.buf.Bytes())
+			putWsBuffer(req.buf)
+			if err != nil {
+				c.failWrite(req.id, err)
+				c.closeOnTimeout(conn, err)
+				continue
+			}
+			batch = append(batch, req)
+		}
+		if coalescing != nil {
+			if err := coalescing.flush(); err != nil {
+				for _, req := range batch {
+					c.failWrite(req.id, err)
+				}
+				c.closeOnTimeout(conn, err)
+			}
+		}
+		c.mu.Unlock()
+		clear(batch)
+		batch = batch[:0]
+	}
+}
+
+// failWrite completes request id with err, no response will come unless it was answered already
+func (c *ClientWs) failWrite(id string, err error) {
+	c.debug("write: unable to write message into websocket conn '%v'", err)
+	if call := c.pending.take([]byte(id)); call != nil {
+		call.done <- err
+		close(call.done)
+	}
+}
+
+// coalescingConn holds writes between begin and flush, so a burst of frames goes out in one
+// write of the underlying connection instead of a syscall per frame
+type coalescingConn struct {
+	net.Conn
+	mu       sync.Mutex
+	batching bool
+	buf      []byte
+}
+
+func (c *coalescingConn) Write(p []byte) (int, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.batching {
+		c.buf = append(c.buf, p...)
+		return len(p), nil
+	}
+	return c.Conn.Write(p)
+}
+
+func (c *coalescingConn) begin() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.batching = true
+}
+
+func (c *coalescingConn) flush() error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.batching = false
+	if len(c.buf) == 0 {
+		return nil
+	}
+	_, err := c.Conn.Write(c.buf)
+	c.buf = c.buf[:0]
+	return err
+}
+
+// coalescingConnOf returns the coalescingConn under conn and its TLS layer, nil if it has none
+func coalescingConnOf(conn *websocket.Conn) *coalescingConn {
+	nc := conn.UnderlyingConn()
+	if tlsConn, ok := nc.(interface{ NetConn() net.Conn }); ok {
+		nc = tlsConn.NetConn()
+	}
+	coalescing, _ := nc.(*coalescingConn)
+	return coalescing
+}
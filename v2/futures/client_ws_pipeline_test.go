@@ -0,0 +1,210 @@
+package futures
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"runtime"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+)
+
+func TestWsOutboundQueue(t *testing.T) {
+	assert := assert.New(t)
+	q := newWsOutboundQueue(5)
+	assert.Len(q.slots, 8)
+	for i := 0; i < 8; i++ {
+		assert.True(q.push(&wsOutbound{id: fmt.Sprint(i)}))
+	}
+	assert.False(q.push(&wsOutbound{id: "8"}))
+	assert.Equal("0", q.pop().id)
+	assert.True(q.push(&wsOutbound{id: "8"}))
+	for i := 1; i <= 8; i++ {
+		assert.Equal(fmt.Sprint(i), q.pop().id)
+	}
+	assert.Nil(q.pop())
+}
+
+func TestWsOutboundQueueConcurrentProducers(t *testing.T) {
+	const producers, perProducer = 8, 1000
+	q := newWsOutboundQueue(64)
+	var wg sync.WaitGroup
+	for p := 0; p < producers; p++ {
+		wg.Add(1)
+		go func(p int) {
+			defer wg.Done()
+			for i := 0; i < perProducer; i++ {
+				req := &wsOutbound{id: fmt.Sprintf("%d-%d", p, i)}
+				for !q.push(req) {
+					// full, wait for the consumer
+					runtime.Gosched()
+				}
+			}
+		}(p)
+	}
+
+	seen := make(map[string]bool, producers*perProducer)
+	next := make([]int, producers)
+	for len(seen) < producers*perProducer {
+		req := q.pop()
+		if req == nil {
+			<-q.signal
+			continue
+		}
+		assert.False(t, seen[req.id], req.id)
+		seen[req.id] = true
+		// requests of each producer keep their order
+		var p, i int
+		fmt.Sscanf(req.id, "%d-%d", &p, &i)
+		assert.Equal(t, next[p], i)
+		next[p]++
+	}
+	wg.Wait()
+	assert.Nil(t, q.pop())
+}
+
+type clientWsPipelineTestSuite struct {
+	baseWsApiTestSuite
+}
+
+func TestClientWsPipeline(t *testing.T) {
+	suite.Run(t, new(clientWsPipelineTestSuite))
+}
+
+func (s *clientWsPipelineTestSuite) TestConcurrentRequests() {
+	s.wsClient.EnablePipelining(16)
+	s.respond(WsApiMethodTickerPrice, `{"symbol":"BTCUSDT","price":"6000.01","time":1589437530011}`)
+
+	const n = 50
+	var wg sync.WaitGroup
+	errs := make(chan error, n)
+	for i := 0; i < n; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, err := s.wsClient.NewTickerPriceWsService().Do(newContext(), NewTickerPriceWsRequest().Symbol("BTCUSDT"))
+			if err != nil && err != ErrWsQueueFull {
+				errs <- err
+			}
+		}()
+	}
+	wg.Wait()
+	close(errs)
+	for err := range errs {
+		s.r().NoError(err)
+	}
+
+	// unscripted methods still fail with their error response
+	_, err := s.wsClient.NewTickerBookWsService().Do(newContext(), NewTickerBookWsRequest())
+	s.r().Error(err)
+}
+
+func (s *clientWsPipelineTestSuite) TestWriteFailure() {
+	s.wsClient.EnablePipelining(16)
+	s.wsClient.mu.Lock()
+	s.wsClient.Conn.Close()
+	s.wsClient.mu.Unlock()
+
+	_, err := s.wsClient.NewTickerPriceWsService().Do(newContext(), NewTickerPriceWsRequest().Symbol("BTCUSDT"))
+	s.r().Error(err)
+
+	// the closed connection is replaced before the server goes away
+	s.Eventually(func() bool {
+		return s.wsClient.GetReconnectCount() > 0 && !s.wsClient.reconnecting.Load()
+	}, 5*time.Second, 10*time.Millisecond)
+}
+
+// slowWriteConn blocks every write for delay, as a congested link where each write waits for
+// room in the socket buffer
+type slowWriteConn struct {
+	net.Conn
+	delay time.Duration
+}
+
+func (c *slowWriteConn) Write(p []byte) (int, error) {
+	time.Sleep(c.delay)
+	return c.Conn.Write(p)
+}
+
+// newBenchmarkClientWs connects a client to a server answering every request at once, through
+// a connection adding writeDelay to every write
+func newBenchmarkClientWs(b *testing.B, writeDelay time.Duration) *ClientWs {
+	upgrader := websocket.Upgrader{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		conn, err := upgrader.Upgrade(w, r, nil)
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		for {
+			_, message, err := conn.ReadMessage()
+			if err != nil {
+				return
+			}
+			req := struct {
+				ID string `json:"id"`
+			}{}
+			_ = json.Unmarshal(message, &req)
+			response := `{"id":"` + req.ID + `","status":200,"result":{"symbol":"BTCUSDT","price":"6000.01","time":1}}`
+			if err := conn.WriteMessage(websocket.TextMessage, []byte(response)); err != nil {
+				return
+			}
+		}
+	}))
+	b.Cleanup(server.Close)
+
+	origGetConn := WsGetReadWriteConnection
+	b.Cleanup(func() { WsGetReadWriteConnection = origGetConn })
+	endpoint := "ws" + strings.TrimPrefix(server.URL, "http")
+	WsGetReadWriteConnection = func(cfg *WsConfig) (*websocket.Conn, error) {
+		dialer := websocket.Dialer{
+			NetDialContext: func(ctx context.Context, network, addr string) (net.Conn, error) {
+				conn, err := (&net.Dialer{}).DialContext(ctx, network, addr)
+				if err != nil {
+					return nil, err
+				}
+				return &coalescingConn{Conn: &slowWriteConn{Conn: conn, delay: writeDelay}}, nil
+			},
+		}
+		c, _, err := dialer.Dial(endpoint, nil)
+		return c, err
+	}
+	client, err := NewClientWs("apiKey", "secretKey")
+	if err != nil {
+		b.Fatal(err)
+	}
+	return client
+}
+
+func BenchmarkClientWsRequests(b *testing.B) {
+	for _, pipelined := range []bool{false, true} {
+		b.Run(fmt.Sprintf("pipelined=%v", pipelined), func(b *testing.B) {
+			client := newBenchmarkClientWs(b, 50*time.Microsecond)
+			if pipelined {
+				client.EnablePipelining(4096)
+			}
+			service := client.NewTickerPriceWsService()
+			b.SetParallelism(32)
+			b.ReportAllocs()
+			b.ResetTimer()
+			b.RunParallel(func(pb *testing.PB) {
+				req := NewTickerPriceWsRequest().Symbol("BTCUSDT")
+				for pb.Next() {
+					if _, err := service.Do(newContext(), req); err != nil {
+						b.Error(err)
+						return
+					}
+				}
+			})
+		})
+	}
+}
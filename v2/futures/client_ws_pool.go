@@ -0,0 +1,64 @@
+package futures
+
+import (
+	"bytes"
+	"sync"
+	"sync/atomic"
+)
+
+// WsApiBufferMaxSize is the capacity above which buffers of websocket API messages are left to
+// the garbage collector instead of returning to the pool, so a few large responses do not pin
+// memory
+var WsApiBufferMaxSize = 64 << 10
+
+// WsApiBufferPoolStats define usage of the buffer pool of websocket API messages, shared by all
+// clients
+type WsApiBufferPoolStats struct {
+	// Gets counts buffers taken for requests and responses
+	Gets int64
+	// News counts buffers allocated because the pool was empty, a high ratio to Gets means
+	// buffers are dropped or held
+	News int64
+	// Puts counts buffers returned
+	Puts int64
+	// Dropped counts buffers over WsApiBufferMaxSize. Buffers of unhandled messages, of
+	// responses passed to AuditSink or EventBus and of requests which timed out are kept by
+	// their holders and counted in neither Puts nor Dropped.
+	Dropped int64
+}
+
+var (
+	wsBufferPool = sync.Pool{New: func() interface{} {
+		wsBufferStats.news.Add(1)
+		return new(bytes.Buffer)
+	}}
+	wsBufferStats struct {
+		gets, news, puts, dropped atomic.Int64
+	}
+)
+
+// GetWsApiBufferPoolStats returns usage of the buffer pool of websocket API messages
+func GetWsApiBufferPoolStats() WsApiBufferPoolStats {
+	return WsApiBufferPoolStats{
+		Gets:    wsBufferStats.gets.Load(),
+		News:    wsBufferStats.news.Load(),
+		Puts:    wsBufferStats.puts.Load(),
+		Dropped: wsBufferStats.dropped.Load(),
+	}
+}
+
+func getWsBuffer() *bytes.Buffer {
+	wsBufferStats.gets.Add(1)
+	buf := wsBufferPool.Get().(*bytes.Buffer)
+	buf.Reset()
+	return buf
+}
+
+func putWsBuffer(buf *bytes.Buffer) {
+	if buf.Cap() > WsApiBufferMaxSize {
+		wsBufferStats.dropped.Add(1)
+		return
+	}
+	wsBufferStats.puts.Add(1)
+	wsBufferPool.Put(buf)
+}
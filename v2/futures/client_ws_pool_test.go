@@ -0,0 +1,73 @@
+package futures
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+)
+
+type wsBufferPoolTestSuite struct {
+	baseWsApiTestSuite
+}
+
+func TestWsBufferPool(t *testing.T) {
+	suite.Run(t, new(wsBufferPoolTestSuite))
+}
+
+func (s *wsBufferPoolTestSuite) TestBuffersReturned() {
+	r := s.r()
+	s.respond(WsApiMethodTickerPrice, `{"symbol":"BTCUSDT","price":"6000.01","time":1589437530011}`)
+
+	before := GetWsApiBufferPoolStats()
+	_, err := s.wsClient.NewTickerPriceWsService().Do(newContext(), NewTickerPriceWsRequest().Symbol("BTCUSDT"))
+	r.NoError(err)
+	after := GetWsApiBufferPoolStats()
+	// one buffer for the request and one for the response
+	r.Equal(before.Gets+2, after.Gets)
+	r.Equal(before.Puts+2, after.Puts)
+
+	// error responses are released once decoded
+	before = after
+	_, err = s.wsClient.NewTickerBookWsService().Do(newContext(), NewTickerBookWsRequest())
+	r.Error(err)
+	after = GetWsApiBufferPoolStats()
+	r.Equal(before.Gets+2, after.Gets)
+	r.Equal(before.Puts+2, after.Puts)
+}
+
+func (s *wsBufferPoolTestSuite) TestLargeBuffersDropped() {
+	orig := WsApiBufferMaxSize
+	WsApiBufferMaxSize = 16
+	defer func() { WsApiBufferMaxSize = orig }()
+	s.respond(WsApiMethodTickerPrice, `{"symbol":"BTCUSDT","price":"6000.01","time":1589437530011}`)
+
+	before := GetWsApiBufferPoolStats()
+	_, err := s.wsClient.NewTickerPriceWsService().Do(newContext(), NewTickerPriceWsRequest().Symbol("BTCUSDT"))
+	s.r().NoError(err)
+	after := GetWsApiBufferPoolStats()
+	s.r().Equal(before.Dropped+2, after.Dropped)
+	s.r().Equal(before.Puts, after.Puts)
+}
+
+func TestEncodeWsRequest(t *testing.T) {
+	assert := assert.New(t)
+	p := params{"symbol": "BTCUSDT", "side": SideTypeBuy, "timestamp": int64(1499827319559), "price": 0.1}
+	req := &orderedWsApiRequest{Id: "1", Method: WsApiMethodOrderPlace, Params: sortParams(p)}
+	expected, err := json.Marshal(WsApiRequest{Id: "1", Method: WsApiMethodOrderPlace, Params: p})
+	assert.NoError(err)
+
+	buf := new(bytes.Buffer)
+	assert.NoError(req.encodeTo(buf))
+	assert.Equal(string(expected), buf.String())
+
+	// a reused buffer is written in place
+	allocs := testing.AllocsPerRun(100, func() {
+		buf.Reset()
+		_ = req.encodeTo(buf)
+	})
+	assert.Equal(float64(0), allocs)
+	assert.Equal(string(expected), buf.String())
+}
@@ -0,0 +1,181 @@
+package futures
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/adshao/go-binance/v2/common"
+)
+
+// WsRequestPriority define the class of a websocket API request. When requests queue up in
+// pipelined mode or behind the rate limit set by SetRateLimit, higher classes go first.
+type WsRequestPriority int
+
+const (
+	WsPriorityLow WsRequestPriority = iota
+	WsPriorityNormal
+	// WsPriorityHigh is the default of cancels and of reduce-only or close-position orders
+	WsPriorityHigh
+	wsPriorityCount
+)
+
+// clamp returns p within the defined classes
+func (p WsRequestPriority) clamp() WsRequestPriority {
+	if p < WsPriorityLow {
+		return WsPriorityLow
+	}
+	if p > WsPriorityHigh {
+		return WsPriorityHigh
+	}
+	return p
+}
+
+// wsPriorityQueues holds an outbound queue per priority, sharing the signal of the writer
+type wsPriorityQueues struct {
+	queues [wsPriorityCount]*wsOutboundQueue
+	signal chan struct{}
+}
+
+// newWsPriorityQueues init wsPriorityQueues, each priority having a queue of size
+func newWsPriorityQueues(size int) *wsPriorityQueues {
+	q := &wsPriorityQueues{signal: make(chan struct{}, 1)}
+	for i := range q.queues {
+		q.queues[i] = newWsOutboundQueue(size)
+		q.queues[i].signal = q.signal
+	}
+	return q
+}
+
+func (q *wsPriorityQueues) push(req *wsOutbound, priority WsRequestPriority) bool {
+	return q.queues[priority].push(req)
+}
+
+// pop dequeues the next request of the highest priority, nil if all queues are empty
+func (q *wsPriorityQueues) pop() *wsOutbound {
+	for i := len(q.queues) - 1; i >= 0; i-- {
+		if req := q.queues[i].pop(); req != nil {
+			return req
+		}
+	}
+	return nil
+}
+
+// SetRateLimit lets at most limit requests be sent per interval, windows are aligned to
+// multiples of interval. Requests over the limit wait for the next window, those of higher
+// priority first. A limit of 0 removes the rate limit.
+func (c *ClientWs) SetRateLimit(limit int, interval time.Duration) {
+	c.SetSharedRateLimit(limit, interval, nil, nil)
+}
+
+// SetSharedRateLimit sets the rate limit like SetRateLimit, counting requests in store so that
+// processes using the same API key share it. Requests are counted in memory while store fails,
+// onError is called with its errors.
+func (c *ClientWs) SetSharedRateLimit(limit int, interval time.Duration, store RateLimitStore, onError ErrHandler) {
+	if limit <= 0 {
+		c.limiter.Store(nil)
+		return
+	}
+	l := newWsRateLimiter(limit, interval, c.clock)
+	l.counter.store, l.counter.onError = store, onError
+	c.limiter.Store(l)
+}
+
+// waitRateLimit blocks until the rate limit lets a request of priority through
+func (c *ClientWs) waitRateLimit(ctx context.Context, priority WsRequestPriority) error {
+	if l := c.limiter.Load(); l != nil {
+		return l.wait(ctx, priority)
+	}
+	return nil
+}
+
+// wsRateLimitKey is the RateLimitStore key of websocket API requests
+const wsRateLimitKey = "ws-requests"
+
+// wsRateLimiter admits limit requests within fixed windows, waiting requests are admitted by
+// priority
+type wsRateLimiter struct {
+	limit   int
+	window  time.Duration
+	clock   common.Clock
+	counter windowCounter
+
+	mu          sync.Mutex
+	windowStart time.Time
+	// full is set once the current window is known to be full
+	full    bool
+	waiting [wsPriorityCount]int
+	// changed is closed when a waiter leaves, so those of lower priority can go next
+	changed chan struct{}
+}
+
+func newWsRateLimiter(limit int, window time.Duration, clock common.Clock) *wsRateLimiter {
+	return &wsRateLimiter{
+		limit:   limit,
+		window:  window,
+		clock:   clock,
+		counter: windowCounter{local: NewMemoryRateLimitStore()},
+		changed: make(chan struct{}),
+	}
+}
+
+// wait blocks until a request of priority fits into the current window and no request of
+// higher priority waits
+func (l *wsRateLimiter) wait(ctx context.Context, priority WsRequestPriority) error {
+	l.mu.Lock()
+	l.waiting[priority]++
+	defer func() {
+		l.waiting[priority]--
+		close(l.changed)
+		l.changed = make(chan struct{})
+		l.mu.Unlock()
+	}()
+
+	for {
+		now := l.clock.Now()
+		start := now.Truncate(l.window)
+		if !start.Equal(l.windowStart) {
+			l.windowStart = start
+			l.full = false
+		}
+		if !l.full && !l.outranked(priority) {
+			if l.counter.take(ctx, wsRateLimitKey, start, 1, l.limit) {
+				return nil
+			}
+			l.full = true
+		}
+
+		changed := l.changed
+		var timer common.Timer
+		var timerC <-chan time.Time
+		if l.full {
+			timer = l.clock.NewTimer(start.Add(l.window).Sub(now))
+			timerC = timer.C()
+		}
+		l.mu.Unlock()
+		var err error
+		select {
+		case <-timerC:
+		case <-changed:
+		case <-ctx.Done():
+			err = ctx.Err()
+		}
+		if timer != nil {
+			timer.Stop()
+		}
+		l.mu.Lock()
+		if err != nil {
+			return err
+		}
+	}
+}
+
+// outranked reports whether requests of higher priority than priority are waiting
+func (l *wsRateLimiter) outranked(priority WsRequestPriority) bool {
+	for p := priority + 1; p < wsPriorityCount; p++ {
+		if l.waiting[p] > 0 {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,123 @@
+package futures
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/adshao/go-binance/v2/common"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWsPriorityQueues(t *testing.T) {
+	q := newWsPriorityQueues(4)
+	assert.True(t, q.push(&wsOutbound{id: "low"}, WsPriorityLow))
+	assert.True(t, q.push(&wsOutbound{id: "normal"}, WsPriorityNormal))
+	assert.True(t, q.push(&wsOutbound{id: "high"}, WsPriorityHigh))
+	<-q.signal
+
+	for _, id := range []string{"high", "normal", "low"} {
+		assert.Equal(t, id, q.pop().id)
+	}
+	assert.Nil(t, q.pop())
+}
+
+func TestWsRateLimiterPriority(t *testing.T) {
+	clock := common.NewFakeClock(time.Unix(1000, 0))
+	l := newWsRateLimiter(1, time.Second, clock)
+	require.NoError(t, l.wait(context.Background(), WsPriorityNormal))
+
+	admitted := make(chan WsRequestPriority, 2)
+	waitAsync := func(priority WsRequestPriority) {
+		go func() {
+			if err := l.wait(context.Background(), priority); err == nil {
+				admitted <- priority
+			}
+		}()
+	}
+	waitAsync(WsPriorityNormal)
+	clock.BlockUntil(1)
+	waitAsync(WsPriorityHigh)
+	clock.BlockUntil(2)
+
+	// the next window goes to the request of higher priority although it came later
+	clock.Advance(time.Second)
+	assert.Equal(t, WsPriorityHigh, <-admitted)
+	clock.BlockUntil(1)
+	select {
+	case p := <-admitted:
+		t.Fatalf("admitted %v over the limit", p)
+	default:
+	}
+
+	clock.Advance(time.Second)
+	assert.Equal(t, WsPriorityNormal, <-admitted)
+}
+
+func TestWsRateLimiterCanceled(t *testing.T) {
+	clock := common.NewFakeClock(time.Unix(1000, 0))
+	l := newWsRateLimiter(1, time.Second, clock)
+	require.NoError(t, l.wait(context.Background(), WsPriorityHigh))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	assert.ErrorIs(t, l.wait(ctx, WsPriorityHigh), context.Canceled)
+	assert.Equal(t, 0, l.waiting[WsPriorityHigh])
+}
+
+func TestWsRateLimiterStore(t *testing.T) {
+	clock := common.NewFakeClock(time.Unix(1000, 0))
+	store := NewMemoryRateLimitStore()
+	l1 := newWsRateLimiter(2, time.Second, clock)
+	l2 := newWsRateLimiter(2, time.Second, clock)
+	l1.counter.store, l2.counter.store = store, store
+	require.NoError(t, l1.wait(context.Background(), WsPriorityNormal))
+	require.NoError(t, l2.wait(context.Background(), WsPriorityNormal))
+
+	// the window is used up by both limiters
+	admitted := make(chan struct{})
+	go func() {
+		if err := l1.wait(context.Background(), WsPriorityHigh); err == nil {
+			close(admitted)
+		}
+	}()
+	clock.BlockUntil(1)
+	select {
+	case <-admitted:
+		t.Fatal("admitted over the shared limit")
+	default:
+	}
+	clock.Advance(time.Second)
+	<-admitted
+}
+
+func TestWsRequestPriority(t *testing.T) {
+	assert.Equal(t, WsPriorityNormal, NewOrderPlaceWsRequest().requestPriority())
+	assert.Equal(t, WsPriorityHigh, NewOrderPlaceWsRequest().ReduceOnly(true).requestPriority())
+	assert.Equal(t, WsPriorityHigh, NewOrderPlaceWsRequest().ClosePosition(true).requestPriority())
+	assert.Equal(t, WsPriorityNormal, NewOrderPlaceWsRequest().ReduceOnly(false).requestPriority())
+	assert.Equal(t, WsPriorityLow, NewOrderPlaceWsRequest().ReduceOnly(true).Priority(WsPriorityLow).requestPriority())
+	assert.Equal(t, WsPriorityHigh, NewCancelOrderRequest().requestPriority())
+	assert.Equal(t, WsPriorityNormal, NewCancelOrderRequest().Priority(WsPriorityNormal).requestPriority())
+	assert.Equal(t, WsPriorityHigh, WsRequestPriority(10).clamp())
+	assert.Equal(t, WsPriorityLow, WsRequestPriority(-1).clamp())
+}
+
+func (s *clientWsTestSuite) TestSetRateLimit() {
+	s.respond(WsApiMethodOrderCancel, `{"orderId":1,"symbol":"BTCUSDT","status":"CANCELED"}`)
+	s.wsClient.SetRateLimit(1, time.Hour)
+
+	_, err := s.wsClient.NewOrderCancelWsService().Do(newContext(), NewCancelOrderRequest().Symbol("BTCUSDT").OrderID(1))
+	s.r().NoError(err)
+
+	// the window is used up
+	ctx, cancel := context.WithTimeout(newContext(), 10*time.Millisecond)
+	defer cancel()
+	_, err = s.wsClient.NewOrderCancelWsService().Do(ctx, NewCancelOrderRequest().Symbol("BTCUSDT").OrderID(1))
+	s.r().ErrorIs(err, context.DeadlineExceeded)
+
+	s.wsClient.SetRateLimit(0, 0)
+	_, err = s.wsClient.NewOrderCancelWsService().Do(newContext(), NewCancelOrderRequest().Symbol("BTCUSDT").OrderID(1))
+	s.r().NoError(err)
+}
@@ -0,0 +1,154 @@
+package futures
+
+import "net/http"
+
+// wsApiResponseHeader define the fields of a websocket API response needed to route it
+type wsApiResponseHeader struct {
+	// ID is a subslice of the message, valid until the message is reused
+	ID     []byte
+	Status int
+	// Failed is set if the response holds an error or has an error status
+	Failed bool
+}
+
+// scanWsApiResponseHeader reads id, status and the presence of error from the top level object
+// of message without decoding the rest of it, and stops once id and status are found as
+// responses put them first. ok is false if message is not an object with a plain string id.
+func scanWsApiResponseHeader(message []byte) (h wsApiResponseHeader, ok bool) {
+	i := skipSpace(message, 0)
+	if i >= len(message) || message[i] != '{' {
+		return h, false
+	}
+	i++
+	hasStatus := false
+	for {
+		i = skipSpace(message, i)
+		if i >= len(message) {
+			return h, false
+		}
+		if message[i] == '}' {
+			break
+		}
+		if message[i] == ',' {
+			i++
+			continue
+		}
+		keyStart, keyEnd, next, escaped := scanString(message, i)
+		if next < 0 {
+			return h, false
+		}
+		i = skipSpace(message, next)
+		if i >= len(message) || message[i] != ':' {
+			return h, false
+		}
+		i = skipSpace(message, i+1)
+
+		key := message[keyStart:keyEnd]
+		switch {
+		case !escaped && string(key) == "id":
+			start, end, next, escaped := scanString(message, i)
+			if next < 0 || escaped {
+				return h, false
+			}
+			h.ID, i = message[start:end], next
+		case !escaped && string(key) == "status":
+			j := i
+			for j < len(message) && message[j] >= '0' && message[j] <= '9' {
+				h.Status = h.Status*10 + int(message[j]-'0')
+				j++
+			}
+			if j == i {
+				return h, false
+			}
+			hasStatus, i = true, j
+		default:
+			if !escaped && string(key) == "error" && !hasLiteral(message, i, "null") {
+				h.Failed = true
+			}
+			if i = skipValue(message, i); i < 0 {
+				return h, false
+			}
+		}
+		if h.ID != nil && hasStatus {
+			break
+		}
+	}
+	if h.ID == nil {
+		return h, false
+	}
+	if h.Status >= http.StatusBadRequest {
+		h.Failed = true
+	}
+	return h, true
+}
+
+func skipSpace(data []byte, i int) int {
+	for i < len(data) && (data[i] == ' ' || data[i] == '\t' || data[i] == '\n' || data[i] == '\r') {
+		i++
+	}
+	return i
+}
+
+func hasLiteral(data []byte, i int, literal string) bool {
+	return len(data)-i >= len(literal) && string(data[i:i+len(literal)]) == literal
+}
+
+// scanString returns bounds of the content of the string starting at i and the index after it,
+// next is -1 if there is no string at i
+func scanString(data []byte, i int) (start, end, next int, escaped bool) {
+	if i >= len(data) || data[i] != '"' {
+		return 0, 0, -1, false
+	}
+	for j := i + 1; j < len(data); j++ {
+		switch data[j] {
+		case '\\':
+			escaped = true
+			j++
+		case '"':
+			return i + 1, j, j + 1, escaped
+		}
+	}
+	return 0, 0, -1, false
+}
+
+// skipValue returns the index after the value starting at i, -1 if it is malformed
+func skipValue(data []byte, i int) int {
+	if i >= len(data) {
+		return -1
+	}
+	switch data[i] {
+	case '"':
+		_, _, next, _ := scanString(data, i)
+		return next
+	case '{', '[':
+		depth := 0
+		for j := i; j < len(data); j++ {
+			switch data[j] {
+			case '"':
+				_, _, next, _ := scanString(data, j)
+				if next < 0 {
+					return -1
+				}
+				j = next - 1
+			case '{', '[':
+				depth++
+			case '}', ']':
+				depth--
+				if depth == 0 {
+					return j + 1
+				}
+			}
+		}
+		return -1
+	default:
+		j := i
+		for j < len(data) && data[j] != ',' && data[j] != '}' && data[j] != ']' &&
+			data[j] != ' ' && data[j] != '\t' && data[j] != '\n' && data[j] != '\r' {
+			j++
+		}
+		if j == i {
+			return -1
+		}
+		return j
+	}
+}
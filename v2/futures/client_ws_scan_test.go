@@ -0,0 +1,49 @@
+package futures
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestScanWsApiResponseHeader(t *testing.T) {
+	tests := []struct {
+		name    string
+		message string
+		ok      bool
+		id      string
+		status  int
+		failed  bool
+	}{
+		{"result", `{"id":"a1","status":200,"result":{"orderId":1}}`, true, "a1", 200, false},
+		{"status after result", ` { "result" : {"x":[1,{"id":"b"}],"s":"}\"]"} , "rateLimits":[], "status":200, "id":"a2"}`, true, "a2", 200, false},
+		{"error", `{"id":"a3","status":400,"error":{"code":-1102,"msg":"bad"}}`, true, "a3", 400, true},
+		{"error before status", `{"id":"a4","error":{"code":-1},"status":200}`, true, "a4", 200, true},
+		{"null error", `{"id":"a5","error":null,"result":true}`, true, "a5", 0, false},
+		{"event", `{"e":"ORDER_TRADE_UPDATE","E":1}`, false, "", 0, false},
+		{"escaped id", `{"id":"a\"6","status":200}`, false, "", 0, false},
+		{"not an object", `[{"id":"a7"}]`, false, "", 0, false},
+		{"truncated", `{"result":{"id":"a8"`, false, "", 0, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h, ok := scanWsApiResponseHeader([]byte(tt.message))
+			assert.Equal(t, tt.ok, ok)
+			if tt.ok {
+				assert.Equal(t, tt.id, string(h.ID))
+				assert.Equal(t, tt.status, h.Status)
+				assert.Equal(t, tt.failed, h.Failed)
+			}
+		})
+	}
+}
+
+func TestScanWsApiResponseHeaderAllocs(t *testing.T) {
+	message := []byte(`{"id":"f1b2c3d4-0000-4000-8000-000000000000","status":200,"result":{"orderId":1,"symbol":"BTCUSDT"}}`)
+	pending := NewPendingRequests()
+	allocs := testing.AllocsPerRun(100, func() {
+		h, _ := scanWsApiResponseHeader(message)
+		pending.take(h.ID)
+	})
+	assert.Equal(t, float64(0), allocs)
+}
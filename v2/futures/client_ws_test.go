@@ -0,0 +1,321 @@
+package futures
+
+import (
+	"context"
+	"crypto/tls"
+	"encoding/json"
+	"errors"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/adshao/go-binance/v2/common"
+)
+
+// baseWsApiTestSuite serves websocket API requests from a local server
+type baseWsApiTestSuite struct {
+	baseTestSuite
+	server      *httptest.Server
+	origGetConn func(cfg *WsConfig) (*websocket.Conn, error)
+	mu          sync.Mutex
+	requests    []WsApiRequest
+	responses   map[WsApiMethodType]string
+	wsClient    *ClientWs
+}
+
+func (s *baseWsApiTestSuite) SetupTest() {
+	s.baseTestSuite.SetupTest()
+	s.requests = nil
+	s.responses = make(map[WsApiMethodType]string)
+
+	upgrader := websocket.Upgrader{}
+	s.server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		conn, err := upgrader.Upgrade(w, r, nil)
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		for {
+			_, message, err := conn.ReadMessage()
+			if err != nil {
+				return
+			}
+			req := WsApiRequest{}
+			if err := json.Unmarshal(message, &req); err != nil {
+				return
+			}
+			s.mu.Lock()
+			s.requests = append(s.requests, req)
+			result, ok := s.responses[req.Method]
+			s.mu.Unlock()
+			if !ok {
+				result = `{"id":"` + req.Id + `","status":400,"error":{"code":-1102,"msg":"unexpected method"}}`
+			} else {
+				result = strings.ReplaceAll(result, "{{id}}", req.Id)
+			}
+			if err := conn.WriteMessage(websocket.TextMessage, []byte(result)); err != nil {
+				return
+			}
+		}
+	}))
+
+	s.origGetConn = WsGetReadWriteConnection
+	endpoint := "ws" + strings.TrimPrefix(s.server.URL, "http")
+	WsGetReadWriteConnection = func(cfg *WsConfig) (*websocket.Conn, error) {
+		c, _, err := websocket.DefaultDialer.Dial(endpoint, nil)
+		return c, err
+	}
+
+	var err error
+	s.wsClient, err = NewClientWs(s.apiKey, s.secretKey)
+	s.r().NoError(err)
+}
+
+func (s *baseWsApiTestSuite) TearDownTest() {
+	WsGetReadWriteConnection = s.origGetConn
+	s.server.Close()
+}
+
+// respond sets result returned for method, '{{id}}' is replaced by the request id
+func (s *baseWsApiTestSuite) respond(method WsApiMethodType, result string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.responses[method] = `{"id":"{{id}}","status":200,"result":` + result + `}`
+}
+
+// lastRequest returns the last request received by the server
+func (s *baseWsApiTestSuite) lastRequest() WsApiRequest {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.r().NotEmpty(s.requests)
+	return s.requests[len(s.requests)-1]
+}
+
+type clientWsTestSuite struct {
+	baseWsApiTestSuite
+}
+
+func TestClientWs(t *testing.T) {
+	suite.Run(t, new(clientWsTestSuite))
+}
+
+// dialTuned makes clients dial the test server with the default dialer and WsSocketOptions
+func (s *clientWsTestSuite) dialTuned(options WsSocketConfig) {
+	origOptions := WsSocketOptions
+	s.T().Cleanup(func() { WsSocketOptions = origOptions })
+	WsSocketOptions = options
+
+	endpoint := "ws" + strings.TrimPrefix(s.server.URL, "http")
+	WsGetReadWriteConnection = func(cfg *WsConfig) (*websocket.Conn, error) {
+		s.Equal(options.WriteTimeout, cfg.Socket.WriteTimeout)
+		dialCfg := *cfg
+		dialCfg.Endpoint = endpoint
+		return s.origGetConn(&dialCfg)
+	}
+}
+
+func (s *clientWsTestSuite) TestSocketOptions() {
+	s.dialTuned(WsSocketConfig{EnableNagle: true, ReadBufferSize: 1 << 16, WriteBufferSize: 1 << 16})
+	client, err := NewClientWs(s.apiKey, s.secretKey)
+	s.r().NoError(err)
+
+	s.respond(WsApiMethodTickerPrice, `{"symbol":"BTCUSDT","price":"6000.01","time":1589437530011}`)
+	res, err := client.NewTickerPriceWsService().Do(newContext(), NewTickerPriceWsRequest().Symbol("BTCUSDT"))
+	s.r().NoError(err)
+	s.r().Len(res, 1)
+	s.Equal("6000.01", res[0].Price)
+}
+
+func (s *clientWsTestSuite) TestSocketLocalAddr() {
+	// reserve a free local port to dial from
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	s.r().NoError(err)
+	localAddr := l.Addr().(*net.TCPAddr)
+	s.r().NoError(l.Close())
+
+	s.dialTuned(WsSocketConfig{LocalAddr: localAddr})
+	client, err := NewClientWs(s.apiKey, s.secretKey)
+	s.r().NoError(err)
+	s.Equal(localAddr.String(), client.Conn.LocalAddr().String())
+}
+
+func (s *clientWsTestSuite) TestClientWsOptions() {
+	// reserve a free local port to dial from
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	s.r().NoError(err)
+	localAddr := l.Addr().(*net.TCPAddr)
+	s.r().NoError(l.Close())
+
+	var endpoints []string
+	endpoint := "ws" + strings.TrimPrefix(s.server.URL, "http")
+	WsGetReadWriteConnection = func(cfg *WsConfig) (*websocket.Conn, error) {
+		endpoints = append(endpoints, cfg.Endpoint)
+		dialCfg := *cfg
+		dialCfg.Endpoint = endpoint
+		return s.origGetConn(&dialCfg)
+	}
+
+	client, err := NewClientWs(s.apiKey, s.secretKey, WithWsApiEndpoint("wss://alt.example.com/ws-fapi/v1"),
+		WithWsSocketConfig(WsSocketConfig{LocalAddr: localAddr}))
+	s.r().NoError(err)
+	s.Equal(localAddr.String(), client.Conn.LocalAddr().String())
+	s.Nil(WsSocketOptions.LocalAddr)
+
+	// clients without options keep the package settings
+	_, err = NewClientWs(s.apiKey, s.secretKey)
+	s.r().NoError(err)
+	s.Equal([]string{"wss://alt.example.com/ws-fapi/v1", getWsApiEndpoint()}, endpoints)
+}
+
+func (s *clientWsTestSuite) TestClose() {
+	client, err := NewClientWs(s.apiKey, s.secretKey)
+	s.r().NoError(err)
+	// a request waiting for its response is failed by Close
+	cc := client.pending.add("waiting")
+
+	s.r().NoError(client.Close())
+	s.r().NoError(client.Close())
+	_, _, err = waiter{cc}.wait(newContext())
+	s.ErrorIs(err, ErrWsConnectionClosed)
+
+	_, err = client.NewTickerPriceWsService().Do(newContext(), NewTickerPriceWsRequest().Symbol("BTCUSDT"))
+	s.ErrorIs(err, ErrWsConnectionClosed)
+	s.Never(func() bool {
+		return client.GetReconnectCount() > 0
+	}, 200*time.Millisecond, 10*time.Millisecond)
+}
+
+func (s *clientWsTestSuite) TestWriteTimeout() {
+	s.dialTuned(WsSocketConfig{WriteTimeout: time.Nanosecond})
+	client, err := NewClientWs(s.apiKey, s.secretKey)
+	s.r().NoError(err)
+
+	_, err = client.NewTickerPriceWsService().Do(newContext(), NewTickerPriceWsRequest().Symbol("BTCUSDT"))
+	var netErr net.Error
+	s.r().ErrorAs(err, &netErr)
+	s.True(netErr.Timeout())
+
+	// the connection is replaced before the server goes away
+	s.Eventually(func() bool {
+		return client.GetReconnectCount() > 0 && !client.reconnecting.Load()
+	}, 5*time.Second, 10*time.Millisecond)
+}
+
+func (s *clientWsTestSuite) TestSetCredentials() {
+	s.respond(WsApiMethodOrderCancel, `{"orderId":1,"symbol":"BTCUSDT","status":"CANCELED"}`)
+	s.wsClient.SetCredentials("rotatedAPIKey", "rotatedSecretKey")
+
+	_, err := s.wsClient.NewOrderCancelWsService().Do(newContext(), NewCancelOrderRequest().Symbol("BTCUSDT").OrderID(1))
+	r := s.r()
+	r.NoError(err)
+	r.Equal("rotatedAPIKey", s.lastRequest().Params[apiKey])
+}
+
+func (s *clientWsTestSuite) TestSigner() {
+	s.respond(WsApiMethodOrderCancel, `{"orderId":1,"symbol":"BTCUSDT","status":"CANCELED"}`)
+
+	var payload string
+	s.wsClient.SetSigner(common.SignerFunc(func(ctx context.Context, p string) (string, error) {
+		payload = p
+		return "remoteSignature", nil
+	}))
+
+	_, err := s.wsClient.NewOrderCancelWsService().Do(newContext(), NewCancelOrderRequest().Symbol("BTCUSDT").OrderID(1))
+	r := s.r()
+	r.NoError(err)
+	p := s.lastRequest().Params
+	r.Equal("remoteSignature", p[signatureKey])
+
+	signed, err := url.ParseQuery(payload)
+	r.NoError(err)
+	r.Equal(s.apiKey, signed.Get(apiKey))
+	r.Equal("1", signed.Get("orderId"))
+	r.NotEmpty(signed.Get(timestampKey))
+	r.NotContains(signed, signatureKey)
+}
+
+func (s *clientWsTestSuite) TestMetricsHandler() {
+	s.respond(WsApiMethodTickerPrice, `{"symbol":"BTCUSDT","price":"6000.01","time":1589437530011}`)
+
+	type observation struct {
+		method, symbol string
+		status         int
+		err            error
+	}
+	var observed []observation
+	s.wsClient.MetricsHandler = func(method string, symbol string, latency time.Duration, status int, err error) {
+		observed = append(observed, observation{method, symbol, status, err})
+	}
+
+	_, err := s.wsClient.NewTickerPriceWsService().Do(newContext(), NewTickerPriceWsRequest().Symbol("BTCUSDT"))
+	r := s.r()
+	r.NoError(err)
+	_, err = s.wsClient.NewOrderCancelWsService().Do(newContext(), NewCancelOrderRequest().Symbol("BTCUSDT").OrderID(1))
+	r.Error(err)
+
+	r.Len(observed, 2)
+	r.Equal(observation{string(WsApiMethodTickerPrice), "BTCUSDT", 200, nil}, observed[0])
+	r.Equal(string(WsApiMethodOrderCancel), observed[1].method)
+	r.Equal(400, observed[1].status)
+	r.Equal(err, observed[1].err)
+}
+
+func TestNewWsDialer(t *testing.T) {
+	cache := tls.NewLRUClientSessionCache(0)
+	cfg := &WsConfig{Endpoint: "wss://example.com", Socket: WsSocketConfig{TLSSessionCache: cache}}
+	dialer := newWsDialer(cfg, false)
+	assert.Equal(t, cache, dialer.TLSClientConfig.ClientSessionCache)
+
+	cfg.Socket.TLSSessionCache = nil
+	assert.Nil(t, newWsDialer(cfg, true).TLSClientConfig)
+}
+
+func TestNewWsDialerCompression(t *testing.T) {
+	upgrader := websocket.Upgrader{EnableCompression: true}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		conn, err := upgrader.Upgrade(w, r, nil)
+		if err != nil {
+			return
+		}
+		conn.Close()
+	}))
+	defer server.Close()
+	endpoint := "ws" + strings.TrimPrefix(server.URL, "http")
+
+	for _, compression := range []bool{false, true} {
+		cfg := &WsConfig{Endpoint: endpoint, Compression: compression}
+		conn, resp, err := newWsDialer(cfg, false).Dial(cfg.Endpoint, nil)
+		if !assert.NoError(t, err) {
+			continue
+		}
+		conn.Close()
+		assert.Equal(t, compression, strings.Contains(resp.Header.Get("Sec-Websocket-Extensions"), "permessage-deflate"))
+	}
+}
+
+func TestWsCompressionPerConnectionType(t *testing.T) {
+	origGetConn, origStream, origApi := WsGetReadWriteConnection, WsStreamCompression, WsApiCompression
+	defer func() {
+		WsGetReadWriteConnection, WsStreamCompression, WsApiCompression = origGetConn, origStream, origApi
+	}()
+	WsStreamCompression, WsApiCompression = true, false
+
+	var cfg *WsConfig
+	WsGetReadWriteConnection = func(c *WsConfig) (*websocket.Conn, error) {
+		cfg = c
+		return nil, errors.New("dial")
+	}
+	_, err := WsApiInitReadWriteConn()
+	assert.Error(t, err)
+	assert.False(t, cfg.Compression)
+	assert.True(t, newWsConfig(baseWsMainUrl).Compression)
+}
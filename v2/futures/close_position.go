@@ -0,0 +1,90 @@
+package futures
+
+import (
+	"context"
+	"math"
+	"strconv"
+)
+
+// ClosePositionOptions define how ClosePosition closes a position
+type ClosePositionOptions struct {
+	// PositionSide selects the position to close in Hedge Mode, empty closes all positions of the symbol
+	PositionSide PositionSideType
+	// Type is OrderTypeMarket if not set, Price is required for OrderTypeLimit
+	Type        OrderType
+	Price       string
+	TimeInForce TimeInForceType
+}
+
+// ClosePosition reads the positions of symbol and sends reduce only orders closing them. Orders
+// are split into chunks of the maximum quantity of the LOT_SIZE filter, MARKET_LOT_SIZE filter
+// for market orders. Responses of the sent orders are returned, including on error.
+func (c *Client) ClosePosition(ctx context.Context, symbol string, options ClosePositionOptions, opts ...RequestOption) ([]*CreateOrderResponse, error) {
+	positions, err := c.NewGetPositionRiskService().Symbol(symbol).Do(ctx, opts...)
+	if err != nil {
+		return nil, err
+	}
+	amounts := make(map[PositionSideType]float64)
+	for _, p := range positions {
+		positionSide := PositionSideType(p.PositionSide)
+		if p.Symbol != symbol || options.PositionSide != "" && positionSide != options.PositionSide {
+			continue
+		}
+		amount, err := parseOptionalFloat(p.PositionAmt)
+		if err != nil {
+			return nil, err
+		}
+		if amount != 0 {
+			amounts[positionSide] = amount
+		}
+	}
+	if len(amounts) == 0 {
+		return []*CreateOrderResponse{}, nil
+	}
+
+	orderType := options.Type
+	if orderType == "" {
+		orderType = OrderTypeMarket
+	}
+	maxQty, step, precision, err := c.orderLotSize(ctx, symbol, orderType, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	res := make([]*CreateOrderResponse, 0)
+	for _, positionSide := range []PositionSideType{PositionSideTypeBoth, PositionSideTypeLong, PositionSideTypeShort} {
+		amount, ok := amounts[positionSide]
+		if !ok {
+			continue
+		}
+		side := SideTypeSell
+		if amount < 0 {
+			side = SideTypeBuy
+		}
+		chunks, err := splitQuantity(math.Abs(amount), maxQty, step, precision)
+		if err != nil {
+			return res, err
+		}
+		for _, quantity := range chunks {
+			s := c.NewCreateOrderService().Symbol(symbol).Side(side).Type(orderType).
+				Quantity(strconv.FormatFloat(quantity, 'f', precision, 64)).
+				NewOrderResponseType(NewOrderRespTypeRESULT).PositionIntent(PositionIntentClose)
+			if positionSide != PositionSideTypeBoth {
+				s.PositionSide(positionSide)
+			}
+			if orderType == OrderTypeLimit {
+				timeInForce := options.TimeInForce
+				if timeInForce == "" {
+					timeInForce = TimeInForceTypeGTC
+				}
+				s.Price(options.Price).TimeInForce(timeInForce)
+			}
+			order, err := s.Do(ctx, opts...)
+			if err != nil {
+				return res, err
+			}
+			res = append(res, order)
+		}
+	}
+	return res, nil
+}
@@ -0,0 +1,65 @@
+package futures
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestClosePosition(t *testing.T) {
+	assert := assert.New(t)
+
+	var orders []url.Values
+	c := NewClient("apiKey", "secretKey")
+	c.do = func(req *http.Request) (*http.Response, error) {
+		switch req.URL.Path {
+		case "/fapi/v2/positionRisk":
+			return newHTTPResponse([]byte(`[
+				{"symbol":"BTCUSDT","positionAmt":"250.5","positionSide":"LONG"},
+				{"symbol":"BTCUSDT","positionAmt":"-1","positionSide":"SHORT"}
+			]`), http.StatusOK), nil
+		case "/fapi/v1/exchangeInfo":
+			return newHTTPResponse([]byte(`{"symbols":[{"symbol":"BTCUSDT","quantityPrecision":3,"filters":[
+				{"filterType":"LOT_SIZE","maxQty":"1000","minQty":"0.001","stepSize":"0.001"},
+				{"filterType":"MARKET_LOT_SIZE","maxQty":"120","minQty":"0.001","stepSize":"0.001"}
+			]}]}`), http.StatusOK), nil
+		case "/fapi/v1/positionSide/dual":
+			return newHTTPResponse([]byte(`{"dualSidePosition":true}`), http.StatusOK), nil
+		case "/fapi/v1/order":
+			body, _ := io.ReadAll(req.Body)
+			form, _ := url.ParseQuery(string(body))
+			orders = append(orders, form)
+			data := fmt.Sprintf(`{"symbol":"BTCUSDT","orderId":%d,"status":"FILLED","executedQty":"%s","avgPrice":"100"}`, len(orders), form.Get("quantity"))
+			return newHTTPResponse([]byte(data), http.StatusOK), nil
+		}
+		return newHTTPResponse([]byte(`{}`), http.StatusNotFound), nil
+	}
+
+	res, err := c.ClosePosition(newContext(), "BTCUSDT", ClosePositionOptions{PositionSide: PositionSideTypeLong})
+	assert.NoError(err)
+	if assert.Len(res, 3) {
+		assert.Equal("120.000", res[0].ExecutedQuantity)
+		assert.Equal("10.500", res[2].ExecutedQuantity)
+	}
+	for _, o := range orders {
+		assert.Equal("SELL", o.Get("side"))
+		assert.Equal("LONG", o.Get("positionSide"))
+		assert.Equal("MARKET", o.Get("type"))
+		assert.Empty(o.Get("reduceOnly"))
+	}
+
+	orders = nil
+	res, err = c.ClosePosition(newContext(), "BTCUSDT", ClosePositionOptions{Type: OrderTypeLimit, Price: "100"})
+	assert.NoError(err)
+	assert.Len(res, 2, "limit orders use LOT_SIZE")
+	if assert.Len(orders, 2) {
+		assert.Equal("250.500", orders[0].Get("quantity"))
+		assert.Equal("BUY", orders[1].Get("side"))
+		assert.Equal("SHORT", orders[1].Get("positionSide"))
+		assert.Equal("GTC", orders[1].Get("timeInForce"))
+	}
+}
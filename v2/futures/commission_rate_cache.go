@@ -0,0 +1,123 @@
+package futures
+
+import (
+	"context"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// FeeRates define maker and taker commission rates of a symbol
+type FeeRates struct {
+	Maker float64
+	Taker float64
+}
+
+// Rate returns the maker rate if maker is true, the taker rate otherwise
+func (r FeeRates) Rate(maker bool) float64 {
+	if maker {
+		return r.Maker
+	}
+	return r.Taker
+}
+
+type feeRatesEntry struct {
+	rates      FeeRates
+	updateTime time.Time
+}
+
+// CommissionRateCache caches 'commissionRate' of each symbol on first use and does the fee math
+// on top of it, so strategies can check break-even without a request per decision
+type CommissionRateCache struct {
+	c   *Client
+	ttl time.Duration
+
+	mu    sync.RWMutex
+	rates map[string]feeRatesEntry
+}
+
+// NewCommissionRateCache init CommissionRateCache refetching rates older than ttl, zero ttl
+// keeps rates until Invalidate is called
+func NewCommissionRateCache(c *Client, ttl time.Duration) *CommissionRateCache {
+	return &CommissionRateCache{
+		c:     c,
+		ttl:   ttl,
+		rates: make(map[string]feeRatesEntry),
+	}
+}
+
+// Rates returns commission rates of symbol, fetching them if not cached or expired
+func (f *CommissionRateCache) Rates(ctx context.Context, symbol string) (FeeRates, error) {
+	f.mu.RLock()
+	entry, ok := f.rates[symbol]
+	f.mu.RUnlock()
+	if ok && (f.ttl == 0 || time.Since(entry.updateTime) < f.ttl) {
+		return entry.rates, nil
+	}
+
+	res, err := f.c.NewCommissionRateService().Symbol(symbol).Do(ctx)
+	if err != nil {
+		return FeeRates{}, err
+	}
+	var rates FeeRates
+	if rates.Maker, err = strconv.ParseFloat(res.MakerCommissionRate, 64); err != nil {
+		return FeeRates{}, err
+	}
+	if rates.Taker, err = strconv.ParseFloat(res.TakerCommissionRate, 64); err != nil {
+		return FeeRates{}, err
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.rates[symbol] = feeRatesEntry{rates: rates, updateTime: time.Now()}
+	return rates, nil
+}
+
+// Invalidate drops cached rates of symbol, all symbols if symbol is empty
+func (f *CommissionRateCache) Invalidate(symbol string) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if symbol == "" {
+		f.rates = make(map[string]feeRatesEntry)
+		return
+	}
+	delete(f.rates, symbol)
+}
+
+// ExpectedFee returns commission of an order of quantity at price, maker tells whether it
+// adds liquidity
+func (f *CommissionRateCache) ExpectedFee(ctx context.Context, symbol string, quantity, price float64, maker bool) (float64, error) {
+	rates, err := f.Rates(ctx, symbol)
+	if err != nil {
+		return 0, err
+	}
+	return quantity * price * rates.Rate(maker), nil
+}
+
+// NetNotional returns notional of an order after commission: the cost of a buy including fees,
+// the proceeds of a sell net of fees
+func (f *CommissionRateCache) NetNotional(ctx context.Context, symbol string, side SideType, quantity, price float64, maker bool) (float64, error) {
+	fee, err := f.ExpectedFee(ctx, symbol, quantity, price, maker)
+	if err != nil {
+		return 0, err
+	}
+	if side == SideTypeSell {
+		return quantity*price - fee, nil
+	}
+	return quantity*price + fee, nil
+}
+
+// BreakEvenPrice returns the exit price at which a position opened with side at entryPrice
+// covers the commissions of both entry and exit
+func (f *CommissionRateCache) BreakEvenPrice(ctx context.Context, symbol string, side SideType, entryPrice float64, entryMaker, exitMaker bool) (float64, error) {
+	rates, err := f.Rates(ctx, symbol)
+	if err != nil {
+		return 0, err
+	}
+	entryRate, exitRate := rates.Rate(entryMaker), rates.Rate(exitMaker)
+	if side == SideTypeSell {
+		return entryPrice * (1 - entryRate) / (1 + exitRate), nil
+	}
+	return entryPrice * (1 + entryRate) / (1 - exitRate), nil
+}
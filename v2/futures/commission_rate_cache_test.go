@@ -0,0 +1,49 @@
+package futures
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCommissionRateCache(t *testing.T) {
+	assert := assert.New(t)
+
+	var requests []string
+	c := NewClient("apiKey", "secretKey")
+	c.do = func(req *http.Request) (*http.Response, error) {
+		symbol := req.URL.Query().Get("symbol")
+		requests = append(requests, symbol)
+		return newHTTPResponse([]byte(`{"symbol":"`+symbol+`","makerCommissionRate":"0.0002","takerCommissionRate":"0.0004"}`), http.StatusOK), nil
+	}
+
+	f := NewCommissionRateCache(c, 0)
+	rates, err := f.Rates(newContext(), "BTCUSDT")
+	assert.NoError(err)
+	assert.Equal(FeeRates{Maker: 0.0002, Taker: 0.0004}, rates)
+
+	fee, err := f.ExpectedFee(newContext(), "BTCUSDT", 2, 100, false)
+	assert.NoError(err)
+	assert.InDelta(0.08, fee, 1e-12)
+	assert.Equal([]string{"BTCUSDT"}, requests, "rates are cached")
+
+	notional, err := f.NetNotional(newContext(), "BTCUSDT", SideTypeBuy, 2, 100, true)
+	assert.NoError(err)
+	assert.InDelta(200.04, notional, 1e-9)
+	notional, err = f.NetNotional(newContext(), "BTCUSDT", SideTypeSell, 2, 100, true)
+	assert.NoError(err)
+	assert.InDelta(199.96, notional, 1e-9)
+
+	price, err := f.BreakEvenPrice(newContext(), "BTCUSDT", SideTypeBuy, 100, true, false)
+	assert.NoError(err)
+	assert.InDelta(100*1.0002/0.9996, price, 1e-9)
+	price, err = f.BreakEvenPrice(newContext(), "BTCUSDT", SideTypeSell, 100, false, false)
+	assert.NoError(err)
+	assert.InDelta(100*0.9996/1.0004, price, 1e-9)
+
+	f.Invalidate("BTCUSDT")
+	_, err = f.Rates(newContext(), "BTCUSDT")
+	assert.NoError(err)
+	assert.Equal([]string{"BTCUSDT", "BTCUSDT"}, requests)
+}
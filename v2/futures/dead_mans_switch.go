@@ -0,0 +1,103 @@
+package futures
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// DeadMansSwitch keeps the auto-cancel countdown of a symbol armed by setting it again on
+// a timer, so the exchange cancels all open orders of the symbol if the process dies or
+// loses connectivity for longer than Countdown.
+type DeadMansSwitch struct {
+	Symbol    string
+	Countdown time.Duration
+	// Interval between refreshes, a third of Countdown if not set
+	Interval time.Duration
+	// OnError is called when a refresh fails, the countdown keeps running until the next one
+	OnError ErrHandler
+
+	c     *Client
+	mu    sync.Mutex
+	stopC chan struct{}
+	doneC chan struct{}
+}
+
+// NewDeadMansSwitch init DeadMansSwitch for symbol, it does nothing until armed
+func NewDeadMansSwitch(c *Client, symbol string, countdown time.Duration) *DeadMansSwitch {
+	return &DeadMansSwitch{
+		Symbol:    symbol,
+		Countdown: countdown,
+		c:         c,
+	}
+}
+
+// Arm sets the countdown and starts refreshing it in background, arming an armed switch does nothing
+func (d *DeadMansSwitch) Arm(ctx context.Context) error {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	if d.stopC != nil {
+		return nil
+	}
+	if err := d.refresh(ctx); err != nil {
+		return err
+	}
+
+	interval := d.Interval
+	if interval <= 0 {
+		interval = d.Countdown / 3
+	}
+	d.stopC = make(chan struct{})
+	d.doneC = make(chan struct{})
+	stopC, doneC := d.stopC, d.doneC
+
+	go func() {
+		defer close(doneC)
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-stopC:
+				return
+			case <-ticker.C:
+				ctx, cancel := context.WithTimeout(context.Background(), interval)
+				err := d.refresh(ctx)
+				cancel()
+				if err != nil && d.OnError != nil {
+					d.OnError(err)
+				}
+			}
+		}
+	}()
+	return nil
+}
+
+// Disarm stops refreshing and cancels the countdown, open orders are kept
+func (d *DeadMansSwitch) Disarm(ctx context.Context) error {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	if d.stopC != nil {
+		close(d.stopC)
+		<-d.doneC
+		d.stopC, d.doneC = nil, nil
+	}
+
+	_, err := d.c.NewCountdownCancelAllService().Symbol(d.Symbol).CountdownTime(0).Do(ctx)
+	return err
+}
+
+// Armed reports whether the countdown is being refreshed
+func (d *DeadMansSwitch) Armed() bool {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	return d.stopC != nil
+}
+
+func (d *DeadMansSwitch) refresh(ctx context.Context) error {
+	_, err := d.c.NewCountdownCancelAllService().Symbol(d.Symbol).
+		CountdownTime(d.Countdown.Milliseconds()).Do(ctx)
+	return err
+}
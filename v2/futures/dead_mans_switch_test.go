@@ -0,0 +1,61 @@
+package futures
+
+import (
+	"net/http"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDeadMansSwitch(t *testing.T) {
+	assert := assert.New(t)
+
+	var (
+		mu         sync.Mutex
+		countdowns []string
+	)
+	c := NewClient("apiKey", "secretKey")
+	c.do = func(req *http.Request) (*http.Response, error) {
+		if err := req.ParseForm(); err != nil {
+			return nil, err
+		}
+		mu.Lock()
+		defer mu.Unlock()
+		assert.Equal("/fapi/v1/countdownCancelAll", req.URL.Path)
+		assert.Equal("BTCUSDT", req.PostForm.Get("symbol"))
+		countdowns = append(countdowns, req.PostForm.Get("countdownTime"))
+		return newHTTPResponse([]byte(`{"symbol":"BTCUSDT","countdownTime":"0"}`), http.StatusOK), nil
+	}
+
+	d := NewDeadMansSwitch(c, "BTCUSDT", 30*time.Millisecond)
+	assert.False(d.Armed())
+	assert.NoError(d.Arm(newContext()))
+	assert.True(d.Armed())
+
+	time.Sleep(55 * time.Millisecond)
+	assert.NoError(d.Disarm(newContext()))
+	assert.False(d.Armed())
+
+	mu.Lock()
+	defer mu.Unlock()
+	assert.GreaterOrEqual(len(countdowns), 3)
+	for _, countdown := range countdowns[:len(countdowns)-1] {
+		assert.Equal("30", countdown)
+	}
+	assert.Equal("0", countdowns[len(countdowns)-1])
+}
+
+func TestDeadMansSwitchArmError(t *testing.T) {
+	assert := assert.New(t)
+
+	c := NewClient("apiKey", "secretKey")
+	c.do = func(req *http.Request) (*http.Response, error) {
+		return newHTTPResponse([]byte(`{"code":-1121,"msg":"Invalid symbol."}`), http.StatusBadRequest), nil
+	}
+
+	d := NewDeadMansSwitch(c, "UNKNOWN", time.Minute)
+	assert.Error(d.Arm(newContext()))
+	assert.False(d.Armed())
+}
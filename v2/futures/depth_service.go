@@ -5,6 +5,7 @@ import (
 	"net/http"
 
 	"github.com/adshao/go-binance/v2/common"
+	"github.com/bitly/go-simplejson"
 )
 
 // DepthService show depth info
@@ -44,7 +45,11 @@ func (s *DepthService) Do(ctx context.Context, opts ...RequestOption) (res *Dept
 	if err != nil {
 		return nil, err
 	}
-	res = new(DepthResponse)
+	return newDepthResponse(j), nil
+}
+
+func newDepthResponse(j *simplejson.Json) *DepthResponse {
+	res := new(DepthResponse)
 	res.Time = j.Get("E").MustInt64()
 	res.TradeTime = j.Get("T").MustInt64()
 	res.LastUpdateID = j.Get("lastUpdateId").MustInt64()
@@ -66,7 +71,7 @@ func (s *DepthService) Do(ctx context.Context, opts ...RequestOption) (res *Dept
 			Quantity: item.GetIndex(1).MustString(),
 		}
 	}
-	return res, nil
+	return res
 }
 
 // DepthResponse define depth info with bids and asks
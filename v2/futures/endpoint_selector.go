@@ -0,0 +1,232 @@
+package futures
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/gorilla/websocket"
+)
+
+var ErrNoEndpointReachable = errors.New("endpoint selector: no endpoint reachable")
+
+// EndpointProbe measures the round trip time to endpoint
+type EndpointProbe func(ctx context.Context, endpoint string) (time.Duration, error)
+
+// ProbeWsEndpoint dials websocket endpoint and measures the round trip of a ping frame, the
+// time of the handshake is not counted
+func ProbeWsEndpoint(ctx context.Context, endpoint string) (time.Duration, error) {
+	conn, _, err := newWsDialer(newWsConfig(endpoint), false).DialContext(ctx, endpoint, nil)
+	if err != nil {
+		return 0, err
+	}
+	defer conn.Close()
+
+	pong := make(chan struct{}, 1)
+	conn.SetPongHandler(func(string) error {
+		select {
+		case pong <- struct{}{}:
+		default:
+		}
+		return nil
+	})
+	// control frames are handled while reading
+	go func() {
+		for {
+			if _, _, err := conn.ReadMessage(); err != nil {
+				return
+			}
+		}
+	}()
+
+	start := time.Now()
+	deadline, ok := ctx.Deadline()
+	if !ok {
+		deadline = start.Add(10 * time.Second)
+	}
+	if err := conn.WriteControl(websocket.PingMessage, nil, deadline); err != nil {
+		return 0, err
+	}
+	select {
+	case <-pong:
+		return time.Since(start), nil
+	case <-ctx.Done():
+		return 0, ctx.Err()
+	}
+}
+
+// ProbeRestEndpoint measures the round trip of a server time request to REST endpoint on a
+// connection established by a first request, so the handshakes are not counted
+func ProbeRestEndpoint(ctx context.Context, endpoint string) (time.Duration, error) {
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	defer transport.CloseIdleConnections()
+	c := NewClient("", "")
+	c.BaseURL = endpoint
+	c.HTTPClient = &http.Client{Transport: transport}
+
+	if _, err := c.NewServerTimeService().Do(ctx); err != nil {
+		return 0, err
+	}
+	start := time.Now()
+	if _, err := c.NewServerTimeService().Do(ctx); err != nil {
+		return 0, err
+	}
+	return time.Since(start), nil
+}
+
+// EndpointRTT define the result of probing an endpoint
+type EndpointRTT struct {
+	Endpoint string
+	RTT      time.Duration
+	// Err is set if the endpoint could not be probed
+	Err error
+}
+
+// EndpointSelector probes a set of equivalent endpoints, e.g. the endpoints of several regions,
+// and selects the one of the lowest round trip time. Evaluated periodically, it falls back to
+// the next fastest endpoint once the selected one becomes unreachable or slower.
+type EndpointSelector struct {
+	Endpoints []string
+	Probe     EndpointProbe
+	// Interval between evaluations once started, a minute if not set
+	Interval time.Duration
+	// Timeout of probing an endpoint, 5 seconds if not set
+	Timeout time.Duration
+	// OnSelect is called when another endpoint is selected
+	OnSelect func(endpoint string, rtt time.Duration)
+	// OnError is called when an evaluation finds no reachable endpoint
+	OnError ErrHandler
+
+	mu       sync.Mutex
+	selected string
+	results  []EndpointRTT
+	stopC    chan struct{}
+	doneC    chan struct{}
+}
+
+// NewEndpointSelector init EndpointSelector of endpoints, the first one is selected until an
+// evaluation finds a faster one
+func NewEndpointSelector(probe EndpointProbe, endpoints ...string) *EndpointSelector {
+	return &EndpointSelector{
+		Endpoints: endpoints,
+		Probe:     probe,
+	}
+}
+
+// Endpoint returns the selected endpoint
+func (s *EndpointSelector) Endpoint() string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.selected == "" && len(s.Endpoints) > 0 {
+		return s.Endpoints[0]
+	}
+	return s.selected
+}
+
+// Results returns the results of the last evaluation, fastest first
+func (s *EndpointSelector) Results() []EndpointRTT {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return append([]EndpointRTT(nil), s.results...)
+}
+
+// Evaluate probes all endpoints concurrently and selects the fastest reachable one. The
+// selection is kept if none is reachable.
+func (s *EndpointSelector) Evaluate(ctx context.Context) (string, error) {
+	timeout := s.Timeout
+	if timeout <= 0 {
+		timeout = 5 * time.Second
+	}
+
+	results := make([]EndpointRTT, len(s.Endpoints))
+	var wg sync.WaitGroup
+	for i, endpoint := range s.Endpoints {
+		wg.Add(1)
+		go func(i int, endpoint string) {
+			defer wg.Done()
+			ctx, cancel := context.WithTimeout(ctx, timeout)
+			defer cancel()
+			rtt, err := s.Probe(ctx, endpoint)
+			results[i] = EndpointRTT{Endpoint: endpoint, RTT: rtt, Err: err}
+		}(i, endpoint)
+	}
+	wg.Wait()
+	// unreachable endpoints go last
+	sort.SliceStable(results, func(i, j int) bool {
+		if (results[i].Err == nil) != (results[j].Err == nil) {
+			return results[i].Err == nil
+		}
+		return results[i].RTT < results[j].RTT
+	})
+
+	s.mu.Lock()
+	s.results = results
+	if len(results) == 0 || results[0].Err != nil {
+		s.mu.Unlock()
+		return "", ErrNoEndpointReachable
+	}
+	best := results[0]
+	changed := best.Endpoint != s.selected
+	s.selected = best.Endpoint
+	s.mu.Unlock()
+
+	if changed && s.OnSelect != nil {
+		s.OnSelect(best.Endpoint, best.RTT)
+	}
+	return best.Endpoint, nil
+}
+
+// Start evaluates the endpoints and keeps evaluating them every Interval in background,
+// starting a started selector does nothing
+func (s *EndpointSelector) Start(ctx context.Context) error {
+	s.mu.Lock()
+	if s.stopC != nil {
+		s.mu.Unlock()
+		return nil
+	}
+	s.stopC = make(chan struct{})
+	s.doneC = make(chan struct{})
+	stopC, doneC := s.stopC, s.doneC
+	s.mu.Unlock()
+
+	_, err := s.Evaluate(ctx)
+
+	interval := s.Interval
+	if interval <= 0 {
+		interval = time.Minute
+	}
+	go func() {
+		defer close(doneC)
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-stopC:
+				return
+			case <-ticker.C:
+				if _, err := s.Evaluate(context.Background()); err != nil && s.OnError != nil {
+					s.OnError(err)
+				}
+			}
+		}
+	}()
+	return err
+}
+
+// Stop stops evaluating the endpoints, the selection is kept
+func (s *EndpointSelector) Stop() {
+	s.mu.Lock()
+	stopC, doneC := s.stopC, s.doneC
+	s.stopC, s.doneC = nil, nil
+	s.mu.Unlock()
+
+	if stopC != nil {
+		close(stopC)
+		<-doneC
+	}
+}
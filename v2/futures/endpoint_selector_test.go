@@ -0,0 +1,142 @@
+package futures
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeProbe returns the scripted RTT or error of endpoints
+type fakeProbe struct {
+	mu   sync.Mutex
+	rtts map[string]time.Duration
+	errs map[string]error
+}
+
+func (p *fakeProbe) set(endpoint string, rtt time.Duration, err error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.rtts[endpoint], p.errs[endpoint] = rtt, err
+}
+
+func (p *fakeProbe) probe(ctx context.Context, endpoint string) (time.Duration, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.rtts[endpoint], p.errs[endpoint]
+}
+
+func newFakeProbe() *fakeProbe {
+	return &fakeProbe{rtts: map[string]time.Duration{}, errs: map[string]error{}}
+}
+
+func TestEndpointSelectorEvaluate(t *testing.T) {
+	p := newFakeProbe()
+	p.set("a", 30*time.Millisecond, nil)
+	p.set("b", 10*time.Millisecond, nil)
+	p.set("c", 0, errors.New("unreachable"))
+
+	var selected []string
+	s := NewEndpointSelector(p.probe, "a", "b", "c")
+	s.OnSelect = func(endpoint string, rtt time.Duration) {
+		selected = append(selected, endpoint)
+	}
+	assert.Equal(t, "a", s.Endpoint())
+
+	endpoint, err := s.Evaluate(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, "b", endpoint)
+	assert.Equal(t, "b", s.Endpoint())
+	results := s.Results()
+	require.Len(t, results, 3)
+	assert.Equal(t, []string{"b", "a", "c"}, []string{results[0].Endpoint, results[1].Endpoint, results[2].Endpoint})
+	assert.Error(t, results[2].Err)
+
+	// falls back to the next fastest once the selected endpoint is unreachable
+	p.set("b", 0, errors.New("unreachable"))
+	endpoint, err = s.Evaluate(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, "a", endpoint)
+
+	// the selection is kept if no endpoint is reachable
+	p.set("a", 0, errors.New("unreachable"))
+	_, err = s.Evaluate(context.Background())
+	assert.ErrorIs(t, err, ErrNoEndpointReachable)
+	assert.Equal(t, "a", s.Endpoint())
+	assert.Equal(t, []string{"b", "a"}, selected)
+}
+
+func TestEndpointSelectorStart(t *testing.T) {
+	p := newFakeProbe()
+	p.set("a", 30*time.Millisecond, nil)
+	p.set("b", 10*time.Millisecond, nil)
+
+	s := NewEndpointSelector(p.probe, "a", "b")
+	s.Interval = 5 * time.Millisecond
+	require.NoError(t, s.Start(context.Background()))
+	defer s.Stop()
+	assert.Equal(t, "b", s.Endpoint())
+
+	p.set("a", time.Millisecond, nil)
+	assert.Eventually(t, func() bool {
+		return s.Endpoint() == "a"
+	}, time.Second, time.Millisecond)
+}
+
+func TestProbeWsEndpoint(t *testing.T) {
+	upgrader := websocket.Upgrader{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		conn, err := upgrader.Upgrade(w, r, nil)
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		// pings are answered while reading
+		for {
+			if _, _, err := conn.ReadMessage(); err != nil {
+				return
+			}
+		}
+	}))
+	defer server.Close()
+
+	rtt, err := ProbeWsEndpoint(context.Background(), "ws"+strings.TrimPrefix(server.URL, "http"))
+	require.NoError(t, err)
+	assert.Greater(t, rtt, time.Duration(0))
+
+	server.Close()
+	_, err = ProbeWsEndpoint(context.Background(), "ws"+strings.TrimPrefix(server.URL, "http"))
+	assert.Error(t, err)
+}
+
+func TestProbeRestEndpoint(t *testing.T) {
+	var requests int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		assert.Equal(t, "/fapi/v1/time", r.URL.Path)
+		w.Write([]byte(`{"serverTime":1499827319559}`))
+	}))
+	defer server.Close()
+
+	rtt, err := ProbeRestEndpoint(context.Background(), server.URL)
+	require.NoError(t, err)
+	assert.Greater(t, rtt, time.Duration(0))
+	assert.Equal(t, 2, requests)
+}
+
+func TestWsApiEndpointSelector(t *testing.T) {
+	defer func() { WsApiEndpointSelector = nil }()
+	WsApiEndpointSelector = NewEndpointSelector(newFakeProbe().probe, "wss://a", "wss://b")
+	assert.Equal(t, "wss://a", getWsApiEndpoint())
+
+	WsApiEndpointSelector = nil
+	assert.Equal(t, BaseWsApiMainURL, getWsApiEndpoint())
+}
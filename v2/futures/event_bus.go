@@ -0,0 +1,135 @@
+package futures
+
+import (
+	"encoding/json"
+	"errors"
+	"sync"
+	"time"
+)
+
+var ErrEventDropped = errors.New("event bus: channel sink full, event dropped")
+
+// EventType define type of a trading gateway event
+type EventType string
+
+// Event types published on EventBus
+const (
+	// EventTypeOrderSubmitted is published before an order request is sent
+	EventTypeOrderSubmitted EventType = "ORDER_SUBMITTED"
+	// EventTypeOrderAcked is published when the exchange accepted an order request
+	EventTypeOrderAcked EventType = "ORDER_ACKED"
+	// EventTypeOrderRejected is published when the exchange rejected an order request, requests
+	// whose outcome is unknown, e.g. timed out, publish no outcome
+	EventTypeOrderRejected EventType = "ORDER_REJECTED"
+	// EventTypeConnectionLost is published when the websocket API connection is lost
+	EventTypeConnectionLost EventType = "CONNECTION_LOST"
+	// EventTypeReconnected is published when the websocket API connection is reestablished
+	EventTypeReconnected EventType = "RECONNECTED"
+)
+
+// Event define an order or connection lifecycle event
+type Event struct {
+	Type      EventType      `json:"type"`
+	Time      time.Time      `json:"time"`
+	Transport AuditTransport `json:"transport"`
+	// ConnectionID identifies the websocket connection, empty for REST
+	ConnectionID string `json:"connectionId,omitempty"`
+	// RequestID pairs the outcome of an order request with its submission
+	RequestID     string `json:"requestId,omitempty"`
+	Method        string `json:"method,omitempty"`
+	Symbol        string `json:"symbol,omitempty"`
+	ClientOrderID string `json:"clientOrderId,omitempty"`
+	Status        int    `json:"status,omitempty"`
+	Error         string `json:"error,omitempty"`
+}
+
+// EventSink receives events published on EventBus. Publish is called synchronously on the
+// request path, so it must not block.
+type EventSink interface {
+	Publish(event *Event) error
+}
+
+// EventBus publishes order and connection lifecycle events of clients to sinks, so external
+// systems can observe the trading gateway. Set it as EventBus of Client and ClientWs.
+type EventBus struct {
+	errHandler ErrHandler
+
+	mu    sync.RWMutex
+	sinks []EventSink
+}
+
+// NewEventBus init EventBus, errors of sinks go to errHandler
+func NewEventBus(errHandler ErrHandler) *EventBus {
+	return &EventBus{errHandler: errHandler}
+}
+
+// Subscribe adds sink
+func (b *EventBus) Subscribe(sink EventSink) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.sinks = append(b.sinks, sink)
+}
+
+// Publish sends event to all sinks
+func (b *EventBus) Publish(event *Event) {
+	b.mu.RLock()
+	sinks := b.sinks
+	b.mu.RUnlock()
+
+	for _, sink := range sinks {
+		if err := sink.Publish(event); err != nil && b.errHandler != nil {
+			b.errHandler(err)
+		}
+	}
+}
+
+// ChannelSink is an EventSink delivering events into a buffered channel, events are dropped
+// with ErrEventDropped while the channel is full
+type ChannelSink struct {
+	c chan Event
+}
+
+// NewChannelSink init ChannelSink buffering size events
+func NewChannelSink(size int) *ChannelSink {
+	return &ChannelSink{c: make(chan Event, size)}
+}
+
+// C returns channel of events
+func (s *ChannelSink) C() <-chan Event {
+	return s.c
+}
+
+// Publish sends event into the channel without blocking
+func (s *ChannelSink) Publish(event *Event) error {
+	select {
+	case s.c <- *event:
+		return nil
+	default:
+		return ErrEventDropped
+	}
+}
+
+// PublishFunc publishes payload to topic of a message broker, e.g. wrapping a Kafka producer
+// or a NATS connection
+type PublishFunc func(topic string, payload []byte) error
+
+// PublisherSink is an EventSink publishing events as JSON to a message broker topic
+type PublisherSink struct {
+	topic   string
+	publish PublishFunc
+}
+
+// NewPublisherSink init PublisherSink publishing to topic with publish
+func NewPublisherSink(topic string, publish PublishFunc) *PublisherSink {
+	return &PublisherSink{topic: topic, publish: publish}
+}
+
+// Publish marshals event and publishes it
+func (s *PublisherSink) Publish(event *Event) error {
+	payload, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+	return s.publish(s.topic, payload)
+}
@@ -0,0 +1,116 @@
+package futures
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+)
+
+func TestEventBusREST(t *testing.T) {
+	assert := assert.New(t)
+
+	reply, code := `{"orderId":1,"symbol":"BTCUSDT","status":"NEW"}`, http.StatusOK
+	c := NewClient("apiKey", "secretKey")
+	c.do = func(req *http.Request) (*http.Response, error) {
+		return newHTTPResponse([]byte(reply), code), nil
+	}
+	sink := NewChannelSink(10)
+	c.EventBus = NewEventBus(nil)
+	c.EventBus.Subscribe(sink)
+
+	_, err := c.NewCreateOrderService().Symbol("BTCUSDT").Side(SideTypeBuy).Type(OrderTypeMarket).
+		Quantity("1").NewClientOrderID("c1").Do(newContext())
+	assert.NoError(err)
+	reply, code = `{"code":-2011,"msg":"Unknown order sent."}`, http.StatusBadRequest
+	_, err = c.NewCancelOrderService().Symbol("BTCUSDT").OrigClientOrderID("c1").Do(newContext())
+	assert.Error(err)
+
+	var events []Event
+	for len(sink.C()) > 0 {
+		events = append(events, <-sink.C())
+	}
+	if !assert.Len(events, 4) {
+		return
+	}
+	assert.Equal(EventTypeOrderSubmitted, events[0].Type)
+	assert.Equal("c1", events[0].ClientOrderID)
+	assert.Equal("BTCUSDT", events[0].Symbol)
+	assert.Equal(EventTypeOrderAcked, events[1].Type)
+	assert.Equal(events[0].RequestID, events[1].RequestID)
+	assert.Equal(EventTypeOrderSubmitted, events[2].Type)
+	assert.Equal(EventTypeOrderRejected, events[3].Type)
+	assert.Equal("c1", events[3].ClientOrderID)
+	assert.Equal(http.StatusBadRequest, events[3].Status)
+}
+
+func TestEventBusSinks(t *testing.T) {
+	assert := assert.New(t)
+
+	var errs []error
+	bus := NewEventBus(func(err error) {
+		errs = append(errs, err)
+	})
+	var published []string
+	bus.Subscribe(NewPublisherSink("orders", func(topic string, payload []byte) error {
+		published = append(published, topic+" "+string(payload))
+		return nil
+	}))
+	bus.Subscribe(NewChannelSink(1))
+
+	bus.Publish(&Event{Type: EventTypeReconnected, Transport: AuditTransportWs, Time: time.Unix(0, 0).UTC()})
+	bus.Publish(&Event{Type: EventTypeConnectionLost, Transport: AuditTransportWs})
+	assert.Len(published, 2)
+	event := Event{}
+	assert.NoError(json.Unmarshal([]byte(published[0][len("orders "):]), &event))
+	assert.Equal(EventTypeReconnected, event.Type)
+	assert.Equal([]error{ErrEventDropped}, errs, "full channel sink drops events")
+}
+
+type eventBusWsTestSuite struct {
+	baseWsApiTestSuite
+}
+
+func TestEventBusWs(t *testing.T) {
+	suite.Run(t, new(eventBusWsTestSuite))
+}
+
+func (s *eventBusWsTestSuite) TestLifecycle() {
+	sink := NewChannelSink(10)
+	s.wsClient.EventBus = NewEventBus(nil)
+	s.wsClient.EventBus.Subscribe(sink)
+	s.respond(WsApiMethodOrderPlace, `{"orderId":1,"symbol":"BTCUSDT","status":"NEW"}`)
+
+	_, err := s.wsClient.NewOrderPlaceWsService().Do(newContext(), NewOrderPlaceWsRequest().
+		Symbol("BTCUSDT").Side(SideTypeBuy).Type(OrderTypeMarket).Quantity("1"))
+	r := s.r()
+	r.NoError(err)
+
+	next := func() Event {
+		select {
+		case event := <-sink.C():
+			return event
+		case <-time.After(5 * time.Second):
+			s.FailNow("no event")
+		}
+		return Event{}
+	}
+	submitted, acked := next(), next()
+	r.Equal(EventTypeOrderSubmitted, submitted.Type)
+	r.Equal(AuditTransportWs, submitted.Transport)
+	r.Equal(s.lastRequest().Id, submitted.RequestID)
+	r.Equal(EventTypeOrderAcked, acked.Type)
+	r.Equal(submitted.ConnectionID, acked.ConnectionID)
+
+	s.wsClient.mu.Lock()
+	s.wsClient.Conn.Close()
+	s.wsClient.mu.Unlock()
+	lost, reconnected := next(), next()
+	r.Equal(EventTypeConnectionLost, lost.Type)
+	r.Equal(submitted.ConnectionID, lost.ConnectionID)
+	r.Equal(EventTypeReconnected, reconnected.Type)
+	r.NotEqual(lost.ConnectionID, reconnected.ConnectionID)
+}
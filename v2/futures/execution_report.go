@@ -0,0 +1,369 @@
+package futures
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"io"
+	"sort"
+	"strconv"
+	"sync"
+)
+
+// ExecutionReport define execution quality of an order
+type ExecutionReport struct {
+	Symbol        string   `json:"symbol"`
+	OrderID       int64    `json:"orderId"`
+	ClientOrderID string   `json:"clientOrderId"`
+	Side          SideType `json:"side"`
+	// ArrivalTime is when the order was accepted in milliseconds, ArrivalMid the mid price then,
+	// 0 if no book ticker precedes it
+	ArrivalTime    int64   `json:"arrivalTime"`
+	ArrivalMid     float64 `json:"arrivalMid"`
+	Fills          int     `json:"fills"`
+	FilledQuantity float64 `json:"filledQuantity"`
+	AveragePrice   float64 `json:"averagePrice"`
+	MakerQuantity  float64 `json:"makerQuantity"`
+	TakerQuantity  float64 `json:"takerQuantity"`
+	Commission     float64 `json:"commission"`
+	// SlippageBps is the cost of AveragePrice against ArrivalMid in basis points, negative if the
+	// order got a better price
+	SlippageBps float64 `json:"slippageBps"`
+	// EffectiveSpreadBps is twice the cost of the fills against the mid price at their time in
+	// basis points, weighted by quantity
+	EffectiveSpreadBps float64 `json:"effectiveSpreadBps"`
+}
+
+// ExecutionBreakdown define execution quality of the maker or taker fills of a symbol
+type ExecutionBreakdown struct {
+	Symbol             string  `json:"symbol"`
+	Maker              bool    `json:"maker"`
+	Fills              int     `json:"fills"`
+	Quantity           float64 `json:"quantity"`
+	Notional           float64 `json:"notional"`
+	Commission         float64 `json:"commission"`
+	EffectiveSpreadBps float64 `json:"effectiveSpreadBps"`
+}
+
+// ExecutionReporter joins the fills of the user data stream with book tickers to measure the
+// execution quality of orders: slippage against the mid price when they arrived, effective
+// spread of their fills, and maker/taker breakdown. Pass it the events of the user data and
+// book ticker streams, or the book tickers replayed by MarketDataReplayer, in any order: every
+// book ticker is kept until Reset, so feed it the period to report on.
+type ExecutionReporter struct {
+	errHandler ErrHandler
+
+	mu     sync.Mutex
+	quotes map[string][]executionQuote
+	sorted bool
+	orders map[int64]*executionOrder
+}
+
+// executionQuote define the mid price of a symbol at a time in milliseconds
+type executionQuote struct {
+	time int64
+	mid  float64
+}
+
+type executionOrder struct {
+	symbol        string
+	clientOrderID string
+	side          SideType
+	arrivalTime   int64
+	commission    float64
+	fills         []executionFill
+}
+
+type executionFill struct {
+	time       int64
+	price      float64
+	quantity   float64
+	maker      bool
+	commission float64
+}
+
+// NewExecutionReporter init ExecutionReporter
+func NewExecutionReporter(errHandler ErrHandler) *ExecutionReporter {
+	return &ExecutionReporter{
+		errHandler: errHandler,
+		quotes:     make(map[string][]executionQuote),
+		sorted:     true,
+		orders:     make(map[int64]*executionOrder),
+	}
+}
+
+// HandleBookTickerEvent records the mid price of event
+func (r *ExecutionReporter) HandleBookTickerEvent(event *WsBookTickerEvent) {
+	bid, err := strconv.ParseFloat(event.BestBidPrice, 64)
+	if err != nil {
+		r.handleError(err)
+		return
+	}
+	ask, err := strconv.ParseFloat(event.BestAskPrice, 64)
+	if err != nil {
+		r.handleError(err)
+		return
+	}
+	t := event.TransactionTime
+	if t == 0 {
+		t = event.Time
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	quotes := r.quotes[event.Symbol]
+	if n := len(quotes); n > 0 && quotes[n-1].time > t {
+		r.sorted = false
+	}
+	r.quotes[event.Symbol] = append(quotes, executionQuote{time: t, mid: (bid + ask) / 2})
+}
+
+// HandleUserDataEvent records acceptance and fills of orders from ORDER_TRADE_UPDATE event,
+// other events are ignored
+func (r *ExecutionReporter) HandleUserDataEvent(event *WsUserDataEvent) {
+	if event.Event != UserDataEventTypeOrderTradeUpdate {
+		return
+	}
+	u := event.OrderTradeUpdate
+	t := u.TradeTime
+	if t == 0 {
+		t = event.TransactionTime
+	}
+
+	var fill executionFill
+	if u.ExecutionType == OrderExecutionTypeTrade {
+		var err error
+		if fill.price, err = strconv.ParseFloat(u.LastFilledPrice, 64); err != nil {
+			r.handleError(err)
+			return
+		}
+		if fill.quantity, err = strconv.ParseFloat(u.LastFilledQty, 64); err != nil {
+			r.handleError(err)
+			return
+		}
+		if fill.commission, err = parseOptionalFloat(u.Commission); err != nil {
+			r.handleError(err)
+			return
+		}
+		fill.time, fill.maker = t, u.IsMaker
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	o, ok := r.orders[u.ID]
+	if !ok {
+		// the order arrived before the reporter started, its first fill stands for arrival
+		o = &executionOrder{symbol: u.Symbol, clientOrderID: u.ClientOrderID, side: u.Side, arrivalTime: t}
+		r.orders[u.ID] = o
+	}
+	switch u.ExecutionType {
+	case OrderExecutionTypeNew:
+		o.arrivalTime = t
+	case OrderExecutionTypeTrade:
+		o.fills = append(o.fills, fill)
+		o.commission += fill.commission
+	}
+}
+
+// Reports returns the report of every order filled, sorted by arrival time
+func (r *ExecutionReporter) Reports() []ExecutionReport {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.sortQuotes()
+	reports := make([]ExecutionReport, 0, len(r.orders))
+	for id, o := range r.orders {
+		if len(o.fills) == 0 {
+			continue
+		}
+		report := ExecutionReport{
+			Symbol:        o.symbol,
+			OrderID:       id,
+			ClientOrderID: o.clientOrderID,
+			Side:          o.side,
+			ArrivalTime:   o.arrivalTime,
+			Fills:         len(o.fills),
+			Commission:    o.commission,
+		}
+		report.ArrivalMid, _ = r.midAt(o.symbol, o.arrivalTime)
+
+		var notional, spreadCost, spreadQuantity float64
+		for _, fill := range o.fills {
+			report.FilledQuantity += fill.quantity
+			notional += fill.price * fill.quantity
+			if fill.maker {
+				report.MakerQuantity += fill.quantity
+			} else {
+				report.TakerQuantity += fill.quantity
+			}
+			if mid, ok := r.midAt(o.symbol, fill.time); ok {
+				spreadCost += 2 * executionCostBps(o.side, fill.price, mid) * fill.quantity
+				spreadQuantity += fill.quantity
+			}
+		}
+		if report.FilledQuantity > 0 {
+			report.AveragePrice = notional / report.FilledQuantity
+		}
+		if report.ArrivalMid > 0 {
+			report.SlippageBps = executionCostBps(o.side, report.AveragePrice, report.ArrivalMid)
+		}
+		if spreadQuantity > 0 {
+			report.EffectiveSpreadBps = spreadCost / spreadQuantity
+		}
+		reports = append(reports, report)
+	}
+
+	sort.Slice(reports, func(i, j int) bool {
+		if reports[i].ArrivalTime != reports[j].ArrivalTime {
+			return reports[i].ArrivalTime < reports[j].ArrivalTime
+		}
+		return reports[i].OrderID < reports[j].OrderID
+	})
+	return reports
+}
+
+// Breakdown returns the execution quality of maker and taker fills of every symbol, sorted by
+// symbol with maker first
+func (r *ExecutionReporter) Breakdown() []ExecutionBreakdown {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.sortQuotes()
+	type key struct {
+		symbol string
+		maker  bool
+	}
+	breakdowns := make(map[key]*ExecutionBreakdown)
+	spreads := make(map[key][2]float64)
+	for _, o := range r.orders {
+		for _, fill := range o.fills {
+			k := key{symbol: o.symbol, maker: fill.maker}
+			b, ok := breakdowns[k]
+			if !ok {
+				b = &ExecutionBreakdown{Symbol: o.symbol, Maker: fill.maker}
+				breakdowns[k] = b
+			}
+			b.Fills++
+			b.Quantity += fill.quantity
+			b.Notional += fill.price * fill.quantity
+			b.Commission += fill.commission
+			if mid, ok := r.midAt(o.symbol, fill.time); ok {
+				s := spreads[k]
+				spreads[k] = [2]float64{s[0] + 2*executionCostBps(o.side, fill.price, mid)*fill.quantity, s[1] + fill.quantity}
+			}
+		}
+	}
+
+	res := make([]ExecutionBreakdown, 0, len(breakdowns))
+	for k, b := range breakdowns {
+		if s := spreads[k]; s[1] > 0 {
+			b.EffectiveSpreadBps = s[0] / s[1]
+		}
+		res = append(res, *b)
+	}
+	sort.Slice(res, func(i, j int) bool {
+		if res[i].Symbol != res[j].Symbol {
+			return res[i].Symbol < res[j].Symbol
+		}
+		return res[i].Maker && !res[j].Maker
+	})
+	return res
+}
+
+// Reset forgets recorded book tickers and orders
+func (r *ExecutionReporter) Reset() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.quotes = make(map[string][]executionQuote)
+	r.orders = make(map[int64]*executionOrder)
+	r.sorted = true
+}
+
+// sortQuotes sorts quotes by time once book tickers arrived out of order
+func (r *ExecutionReporter) sortQuotes() {
+	if r.sorted {
+		return
+	}
+	for _, quotes := range r.quotes {
+		sort.SliceStable(quotes, func(i, j int) bool {
+			return quotes[i].time < quotes[j].time
+		})
+	}
+	r.sorted = true
+}
+
+// midAt returns the mid price of symbol at time t, false if no book ticker precedes it
+func (r *ExecutionReporter) midAt(symbol string, t int64) (float64, bool) {
+	quotes := r.quotes[symbol]
+	i := sort.Search(len(quotes), func(i int) bool {
+		return quotes[i].time > t
+	})
+	if i == 0 {
+		return 0, false
+	}
+	return quotes[i-1].mid, true
+}
+
+func (r *ExecutionReporter) handleError(err error) {
+	if r.errHandler != nil {
+		r.errHandler(err)
+	}
+}
+
+// executionCostBps returns the cost of trading on side at price against mid in basis points
+func executionCostBps(side SideType, price, mid float64) float64 {
+	cost := (price - mid) / mid * 1e4
+	if side == SideTypeSell {
+		return -cost
+	}
+	return cost
+}
+
+// executionReportHeader define the columns written by WriteExecutionReportsCSV
+var executionReportHeader = []string{
+	"symbol", "order_id", "client_order_id", "side", "arrival_time", "arrival_mid", "fills",
+	"filled_quantity", "average_price", "maker_quantity", "taker_quantity", "commission",
+	"slippage_bps", "effective_spread_bps",
+}
+
+// WriteExecutionReportsCSV writes reports into w as CSV, the first row is the header
+func WriteExecutionReportsCSV(w io.Writer, reports []ExecutionReport) error {
+	cw := csv.NewWriter(w)
+	if err := cw.Write(executionReportHeader); err != nil {
+		return err
+	}
+	for _, report := range reports {
+		err := cw.Write([]string{
+			report.Symbol,
+			strconv.FormatInt(report.OrderID, 10),
+			report.ClientOrderID,
+			string(report.Side),
+			strconv.FormatInt(report.ArrivalTime, 10),
+			formatReportFloat(report.ArrivalMid),
+			strconv.Itoa(report.Fills),
+			formatReportFloat(report.FilledQuantity),
+			formatReportFloat(report.AveragePrice),
+			formatReportFloat(report.MakerQuantity),
+			formatReportFloat(report.TakerQuantity),
+			formatReportFloat(report.Commission),
+			formatReportFloat(report.SlippageBps),
+			formatReportFloat(report.EffectiveSpreadBps),
+		})
+		if err != nil {
+			return err
+		}
+	}
+	cw.Flush()
+	return cw.Error()
+}
+
+// WriteExecutionReportsJSON writes reports into w as a JSON array
+func WriteExecutionReportsJSON(w io.Writer, reports []ExecutionReport) error {
+	return json.NewEncoder(w).Encode(reports)
+}
+
+func formatReportFloat(f float64) string {
+	return strconv.FormatFloat(f, 'f', -1, 64)
+}
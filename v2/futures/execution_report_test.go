@@ -0,0 +1,95 @@
+package futures
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func executionEvent(orderID int64, side SideType, executionType OrderExecutionType, time int64, price, qty string, maker bool) *WsUserDataEvent {
+	return &WsUserDataEvent{
+		Event: UserDataEventTypeOrderTradeUpdate,
+		WsUserDataOrderTradeUpdate: WsUserDataOrderTradeUpdate{OrderTradeUpdate: WsOrderTradeUpdate{
+			Symbol:          "BTCUSDT",
+			ClientOrderID:   "order",
+			Side:            side,
+			ExecutionType:   executionType,
+			ID:              orderID,
+			LastFilledPrice: price,
+			LastFilledQty:   qty,
+			Commission:      "0.01",
+			TradeTime:       time,
+			IsMaker:         maker,
+		}},
+	}
+}
+
+func newTestExecutionReporter(t *testing.T) *ExecutionReporter {
+	r := NewExecutionReporter(func(err error) {
+		t.Error(err)
+	})
+	// book tickers may arrive after the fills and out of order, e.g. when replayed
+	r.HandleUserDataEvent(executionEvent(1, SideTypeBuy, OrderExecutionTypeNew, 1500, "0", "0", false))
+	r.HandleUserDataEvent(executionEvent(1, SideTypeBuy, OrderExecutionTypeTrade, 2500, "101.5", "1", false))
+	r.HandleUserDataEvent(executionEvent(1, SideTypeBuy, OrderExecutionTypeTrade, 2600, "101", "1", true))
+	r.HandleUserDataEvent(executionEvent(2, SideTypeSell, OrderExecutionTypeTrade, 900, "100", "2", true))
+	r.HandleUserDataEvent(executionEvent(3, SideTypeSell, OrderExecutionTypeNew, 3000, "0", "0", false))
+	r.HandleBookTickerEvent(&WsBookTickerEvent{Symbol: "BTCUSDT", TransactionTime: 2000, BestBidPrice: "100", BestAskPrice: "102"})
+	r.HandleBookTickerEvent(&WsBookTickerEvent{Symbol: "BTCUSDT", TransactionTime: 1000, BestBidPrice: "99", BestAskPrice: "101"})
+	return r
+}
+
+func TestExecutionReporter(t *testing.T) {
+	r := newTestExecutionReporter(t)
+
+	reports := r.Reports()
+	require.Len(t, reports, 2)
+	// no book ticker precedes the order
+	assert.Equal(t, ExecutionReport{
+		Symbol: "BTCUSDT", OrderID: 2, ClientOrderID: "order", Side: SideTypeSell, ArrivalTime: 900,
+		Fills: 1, FilledQuantity: 2, AveragePrice: 100, MakerQuantity: 2, Commission: 0.01,
+	}, reports[0])
+
+	report := reports[1]
+	assert.Equal(t, int64(1), report.OrderID)
+	assert.Equal(t, 100.0, report.ArrivalMid)
+	assert.Equal(t, 2, report.Fills)
+	assert.Equal(t, 101.25, report.AveragePrice)
+	assert.Equal(t, 1.0, report.MakerQuantity)
+	assert.Equal(t, 1.0, report.TakerQuantity)
+	assert.InDelta(t, 0.02, report.Commission, 1e-9)
+	assert.InDelta(t, 125, report.SlippageBps, 1e-9)
+	// the taker fill paid 0.5 above the mid of 101, the maker fill at the mid
+	assert.InDelta(t, 0.5/101*1e4, report.EffectiveSpreadBps, 1e-9)
+
+	breakdown := r.Breakdown()
+	require.Len(t, breakdown, 2)
+	assert.Equal(t, ExecutionBreakdown{Symbol: "BTCUSDT", Maker: true, Fills: 2, Quantity: 3, Notional: 301, Commission: 0.02}, breakdown[0])
+	assert.False(t, breakdown[1].Maker)
+	assert.InDelta(t, 1/101.0*1e4, breakdown[1].EffectiveSpreadBps, 1e-9)
+
+	r.Reset()
+	assert.Empty(t, r.Reports())
+}
+
+func TestWriteExecutionReports(t *testing.T) {
+	reports := newTestExecutionReporter(t).Reports()
+
+	var buf bytes.Buffer
+	require.NoError(t, WriteExecutionReportsCSV(&buf, reports))
+	records, err := csv.NewReader(&buf).ReadAll()
+	require.NoError(t, err)
+	require.Len(t, records, 3)
+	assert.Equal(t, executionReportHeader, records[0])
+	assert.Equal(t, []string{"BTCUSDT", "2", "order", "SELL", "900", "0", "1", "2", "100", "2", "0", "0.01", "0", "0"}, records[1])
+
+	buf.Reset()
+	require.NoError(t, WriteExecutionReportsJSON(&buf, reports))
+	var decoded []ExecutionReport
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &decoded))
+	assert.Equal(t, reports, decoded)
+}
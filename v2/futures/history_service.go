@@ -0,0 +1,327 @@
+package futures
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/adshao/go-binance/v2/common"
+	"github.com/jpillora/backoff"
+)
+
+const (
+	historyDefaultKlinesLimit     = 1500
+	historyDefaultAggTradesLimit  = 1000
+	historyDefaultWeightPerMinute = 1200
+	historyDefaultMaxRetries      = 5
+	historyAggTradesWeight        = 20
+	historyAggTradesMaxWindow     = int64(time.Hour / time.Millisecond)
+)
+
+// KlinesPageHandler handle a page of downloaded klines, returning error stops the download
+type KlinesPageHandler func(klines []*Kline) error
+
+// AggTradesPageHandler handle a page of downloaded aggregate trades, returning error stops the download
+type AggTradesPageHandler func(trades []*AggTrade) error
+
+// HistoryService downloads klines and aggregate trades of arbitrary time ranges page by page,
+// throttling requests to stay within a request weight budget and retrying transient failures
+type HistoryService struct {
+	c          *Client
+	limiter    *weightLimiter
+	maxRetries int
+	limit      *int
+}
+
+// NewHistoryService init history service
+func (c *Client) NewHistoryService() *HistoryService {
+	return &HistoryService{
+		c:          c,
+		limiter:    newWeightLimiter(historyDefaultWeightPerMinute, time.Minute),
+		maxRetries: historyDefaultMaxRetries,
+	}
+}
+
+// WeightPerMinute set request weight budget per minute used by the service
+func (s *HistoryService) WeightPerMinute(weight int) *HistoryService {
+	s.limiter = newWeightLimiter(weight, time.Minute)
+	return s
+}
+
+// MaxRetries set number of retries of a page on transient failures
+func (s *HistoryService) MaxRetries(maxRetries int) *HistoryService {
+	s.maxRetries = maxRetries
+	return s
+}
+
+// Limit set page size
+func (s *HistoryService) Limit(limit int) *HistoryService {
+	s.limit = &limit
+	return s
+}
+
+// Klines downloads klines of symbol and interval with open time in [startTime, endTime] milliseconds,
+// handler is called once per page in chronological order
+func (s *HistoryService) Klines(ctx context.Context, symbol, interval string, startTime, endTime int64, handler KlinesPageHandler) error {
+	limit := historyDefaultKlinesLimit
+	if s.limit != nil {
+		limit = *s.limit
+	}
+
+	for startTime <= endTime {
+		var klines []*Kline
+		err := s.do(ctx, klinesWeight(limit), func() (err error) {
+			klines, err = s.c.NewKlinesService().Symbol(symbol).Interval(interval).
+				StartTime(startTime).EndTime(endTime).Limit(limit).Do(ctx, WithRequestPriority(RequestPriorityLow))
+			return err
+		})
+		if err != nil {
+			return err
+		}
+		if len(klines) == 0 {
+			return nil
+		}
+		if err := handler(klines); err != nil {
+			return err
+		}
+		if len(klines) < limit {
+			return nil
+		}
+		startTime = klines[len(klines)-1].CloseTime + 1
+	}
+	return nil
+}
+
+// KlinesChan is like Klines but delivers klines into the returned channel,
+// the error channel receives at most one error and both channels are closed when done
+func (s *HistoryService) KlinesChan(ctx context.Context, symbol, interval string, startTime, endTime int64) (<-chan *Kline, <-chan error) {
+	resC := make(chan *Kline, historyDefaultKlinesLimit)
+	errC := make(chan error, 1)
+	go func() {
+		defer close(errC)
+		defer close(resC)
+		err := s.Klines(ctx, symbol, interval, startTime, endTime, func(klines []*Kline) error {
+			for _, k := range klines {
+				select {
+				case resC <- k:
+				case <-ctx.Done():
+					return ctx.Err()
+				}
+			}
+			return nil
+		})
+		if err != nil {
+			errC <- err
+		}
+	}()
+	return resC, errC
+}
+
+// AggTrades downloads aggregate trades of symbol with trade time in [startTime, endTime] milliseconds,
+// handler is called once per page in chronological order
+func (s *HistoryService) AggTrades(ctx context.Context, symbol string, startTime, endTime int64, handler AggTradesPageHandler) error {
+	limit := historyDefaultAggTradesLimit
+	if s.limit != nil {
+		limit = *s.limit
+	}
+
+	var fromID *int64
+	for startTime <= endTime {
+		var trades []*AggTrade
+		err := s.do(ctx, historyAggTradesWeight, func() (err error) {
+			service := s.c.NewAggTradesService().Symbol(symbol).Limit(limit)
+			if fromID != nil {
+				service.FromID(*fromID)
+			} else {
+				// time range of a request can't exceed one hour
+				windowEnd := startTime + historyAggTradesMaxWindow - 1
+				if windowEnd > endTime {
+					windowEnd = endTime
+				}
+				service.StartTime(startTime).EndTime(windowEnd)
+			}
+			trades, err = service.Do(ctx, WithRequestPriority(RequestPriorityLow))
+			return err
+		})
+		if err != nil {
+			return err
+		}
+
+		if len(trades) == 0 {
+			if fromID != nil {
+				return nil
+			}
+			// no trade within the window, move to the next one
+			startTime += historyAggTradesMaxWindow
+			continue
+		}
+
+		n := len(trades)
+		for n > 0 && trades[n-1].Timestamp > endTime {
+			n--
+		}
+		if n > 0 {
+			if err := handler(trades[:n]); err != nil {
+				return err
+			}
+		}
+		if n < len(trades) {
+			return nil
+		}
+
+		last := trades[len(trades)-1]
+		if fromID == nil && len(trades) < limit {
+			// window is exhausted, continue from the next window by time
+			startTime = last.Timestamp + 1
+			continue
+		}
+		next := last.AggTradeID + 1
+		fromID = &next
+		startTime = last.Timestamp
+	}
+	return nil
+}
+
+// AggTradesChan is like AggTrades but delivers trades into the returned channel,
+// the error channel receives at most one error and both channels are closed when done
+func (s *HistoryService) AggTradesChan(ctx context.Context, symbol string, startTime, endTime int64) (<-chan *AggTrade, <-chan error) {
+	resC := make(chan *AggTrade, historyDefaultAggTradesLimit)
+	errC := make(chan error, 1)
+	go func() {
+		defer close(errC)
+		defer close(resC)
+		err := s.AggTrades(ctx, symbol, startTime, endTime, func(trades []*AggTrade) error {
+			for _, t := range trades {
+				select {
+				case resC <- t:
+				case <-ctx.Done():
+					return ctx.Err()
+				}
+			}
+			return nil
+		})
+		if err != nil {
+			errC <- err
+		}
+	}()
+	return resC, errC
+}
+
+// do waits for weight budget and calls f, retrying transient failures with backoff
+func (s *HistoryService) do(ctx context.Context, weight int, f func() error) error {
+	b := &backoff.Backoff{
+		Min:    500 * time.Millisecond,
+		Max:    30 * time.Second,
+		Factor: 2,
+		Jitter: true,
+	}
+	for attempt := 0; ; attempt++ {
+		if err := s.limiter.wait(ctx, weight); err != nil {
+			return err
+		}
+		err := f()
+		if err == nil || attempt >= s.maxRetries || !isTransientError(err) {
+			return err
+		}
+		delay := b.Duration()
+		if isRateLimitError(err) {
+			// back off for the rest of the rate limit window
+			delay = time.Minute
+		}
+		s.c.debug("history: retry in %s after error: %v", delay, err)
+		select {
+		case <-time.After(delay):
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+}
+
+// klinesWeight returns request weight of klines endpoint with limit
+func klinesWeight(limit int) int {
+	switch {
+	case limit < 100:
+		return 1
+	case limit < 500:
+		return 2
+	case limit <= 1000:
+		return 5
+	default:
+		return 10
+	}
+}
+
+// isTransientError reports whether request may succeed if retried
+func isTransientError(err error) bool {
+	if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return false
+	}
+	apiErr, ok := err.(*common.APIError)
+	if !ok {
+		// network error or unparsable response
+		return true
+	}
+	switch apiErr.Code {
+	case 0, -1000, -1001, -1003, -1007, -1008:
+		// unknown, disconnected, too many requests, timeout, server busy
+		return true
+	}
+	return false
+}
+
+// isRateLimitError reports whether err is caused by exceeding the request weight limit
+func isRateLimitError(err error) bool {
+	apiErr, ok := err.(*common.APIError)
+	return ok && apiErr.Code == -1003
+}
+
+// weightLimiter limits request weight consumed within fixed windows aligned to the window size
+type weightLimiter struct {
+	limit  int
+	window time.Duration
+
+	mu          sync.Mutex
+	windowStart time.Time
+	used        int
+}
+
+func newWeightLimiter(limit int, window time.Duration) *weightLimiter {
+	return &weightLimiter{
+		limit:  limit,
+		window: window,
+	}
+}
+
+// wait blocks until weight fits into the current window
+func (l *weightLimiter) wait(ctx context.Context, weight int) error {
+	for {
+		delay := l.reserve(time.Now(), weight)
+		if delay <= 0 {
+			return nil
+		}
+		select {
+		case <-time.After(delay):
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+}
+
+// reserve consumes weight and returns 0 or returns the delay until the next window
+func (l *weightLimiter) reserve(now time.Time, weight int) time.Duration {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	start := now.Truncate(l.window)
+	if !start.Equal(l.windowStart) {
+		l.windowStart = start
+		l.used = 0
+	}
+	// a single request heavier than the limit is allowed into an empty window
+	if l.used > 0 && l.used+weight > l.limit {
+		return start.Add(l.window).Sub(now)
+	}
+	l.used += weight
+	return 0
+}
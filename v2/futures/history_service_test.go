@@ -0,0 +1,151 @@
+package futures
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+
+	"github.com/adshao/go-binance/v2/common"
+)
+
+type historyServiceTestSuite struct {
+	baseTestSuite
+	queries   []url.Values
+	responses []*http.Response
+	errs      []error
+}
+
+func TestHistoryService(t *testing.T) {
+	suite.Run(t, new(historyServiceTestSuite))
+}
+
+func (s *historyServiceTestSuite) SetupTest() {
+	s.baseTestSuite.SetupTest()
+	s.queries = nil
+	s.responses = nil
+	s.errs = nil
+	s.client.Client.do = func(req *http.Request) (*http.Response, error) {
+		s.queries = append(s.queries, req.URL.Query())
+		s.r().NotEmpty(s.responses, "unexpected request %s", req.URL)
+		res, err := s.responses[0], s.errs[0]
+		s.responses, s.errs = s.responses[1:], s.errs[1:]
+		return res, err
+	}
+}
+
+func (s *historyServiceTestSuite) queue(data string, err error, statusCode ...int) {
+	code := http.StatusOK
+	if len(statusCode) > 0 {
+		code = statusCode[0]
+	}
+	s.responses = append(s.responses, newHTTPResponse([]byte(data), code))
+	s.errs = append(s.errs, err)
+}
+
+func klinesResponse(openTimes ...int64) string {
+	rows := make([]string, len(openTimes))
+	for i, t := range openTimes {
+		rows[i] = fmt.Sprintf(`[%d,"1","2","0.5","1.5","10",%d,"15",5,"4","6","0"]`, t, t+59999)
+	}
+	return "[" + strings.Join(rows, ",") + "]"
+}
+
+func (s *historyServiceTestSuite) TestKlines() {
+	s.queue(klinesResponse(0, 60000), nil)
+	s.queue(klinesResponse(120000), nil)
+
+	var openTimes []int64
+	err := s.client.NewHistoryService().Limit(2).Klines(newContext(), "BTCUSDT", "1m", 0, 300000, func(klines []*Kline) error {
+		for _, k := range klines {
+			openTimes = append(openTimes, k.OpenTime)
+		}
+		return nil
+	})
+	s.r().NoError(err)
+	s.r().Equal([]int64{0, 60000, 120000}, openTimes)
+	s.r().Len(s.queries, 2)
+	s.r().Equal("0", s.queries[0].Get("startTime"))
+	s.r().Equal("120000", s.queries[1].Get("startTime"))
+	s.r().Equal("300000", s.queries[1].Get("endTime"))
+	s.r().Equal("2", s.queries[1].Get("limit"))
+}
+
+func (s *historyServiceTestSuite) TestKlinesRetry() {
+	s.queue(`{"code":-1001,"msg":"Internal error; unable to process your request. Please try again."}`, nil, http.StatusServiceUnavailable)
+	s.queue(klinesResponse(0), nil)
+
+	resC, errC := s.client.NewHistoryService().Limit(2).KlinesChan(newContext(), "BTCUSDT", "1m", 0, 60000)
+	var klines []*Kline
+	for k := range resC {
+		klines = append(klines, k)
+	}
+	s.r().NoError(<-errC)
+	s.r().Len(klines, 1)
+	s.r().Len(s.queries, 2)
+}
+
+func (s *historyServiceTestSuite) TestKlinesNonTransientError() {
+	s.queue(`{"code":-1121,"msg":"Invalid symbol."}`, nil, http.StatusBadRequest)
+
+	err := s.client.NewHistoryService().Klines(newContext(), "INVALID", "1m", 0, 60000, func(klines []*Kline) error {
+		return nil
+	})
+	s.r().Equal(&common.APIError{Code: -1121, Message: "Invalid symbol."}, err)
+}
+
+func (s *historyServiceTestSuite) TestKlinesHandlerError() {
+	s.queue(klinesResponse(0, 60000), nil)
+
+	stop := errors.New("stop")
+	err := s.client.NewHistoryService().Limit(2).Klines(newContext(), "BTCUSDT", "1m", 0, 300000, func(klines []*Kline) error {
+		return stop
+	})
+	s.r().Equal(stop, err)
+}
+
+func (s *historyServiceTestSuite) TestAggTrades() {
+	hour := historyAggTradesMaxWindow
+	// first window is empty
+	s.queue(`[]`, nil)
+	// second window is full, continue by id
+	s.queue(fmt.Sprintf(`[{"a":1,"p":"1","q":"1","f":1,"l":1,"T":%d,"m":true},{"a":2,"p":"1","q":"1","f":2,"l":2,"T":%d,"m":true}]`, hour+1, hour+2), nil)
+	s.queue(fmt.Sprintf(`[{"a":3,"p":"1","q":"1","f":3,"l":3,"T":%d,"m":true},{"a":4,"p":"1","q":"1","f":4,"l":4,"T":%d,"m":true}]`, hour+3, 2*hour+1), nil)
+
+	var ids []int64
+	err := s.client.NewHistoryService().Limit(2).AggTrades(newContext(), "BTCUSDT", 0, 2*hour, func(trades []*AggTrade) error {
+		for _, t := range trades {
+			ids = append(ids, t.AggTradeID)
+		}
+		return nil
+	})
+	s.r().NoError(err)
+	s.r().Equal([]int64{1, 2, 3}, ids)
+	s.r().Len(s.queries, 3)
+	s.r().Equal("0", s.queries[0].Get("startTime"))
+	s.r().Equal(fmt.Sprint(hour-1), s.queries[0].Get("endTime"))
+	s.r().Equal(fmt.Sprint(hour), s.queries[1].Get("startTime"))
+	s.r().Equal("3", s.queries[2].Get("fromId"))
+	s.r().Empty(s.queries[2].Get("startTime"))
+}
+
+func (s *historyServiceTestSuite) TestWeightLimiter() {
+	l := newWeightLimiter(10, time.Minute)
+	now := time.Unix(60, 0)
+	s.r().Zero(l.reserve(now, 6))
+	s.r().Zero(l.reserve(now.Add(time.Second), 4))
+	s.r().Equal(59*time.Second, l.reserve(now.Add(time.Second), 1))
+	s.r().Zero(l.reserve(now.Add(time.Minute), 20))
+
+	ctx, cancel := context.WithCancel(newContext())
+	cancel()
+	l = newWeightLimiter(1, time.Hour)
+	s.r().NoError(l.wait(ctx, 1))
+	s.r().Equal(context.Canceled, l.wait(ctx, 1))
+}
@@ -2,7 +2,6 @@ package futures
 
 import (
 	"context"
-	"encoding/json"
 	"net/http"
 )
 
@@ -53,7 +52,9 @@ func (s *GetIncomeHistoryService) Do(ctx context.Context, opts ...RequestOption)
 		endpoint: "/fapi/v1/income",
 		secType:  secTypeSigned,
 	}
-	r.setParam("symbol", s.symbol)
+	if s.symbol != "" {
+		r.setParam("symbol", s.symbol)
+	}
 	if s.incomeType != "" {
 		r.setParam("incomeType", s.incomeType)
 	}
@@ -72,7 +73,7 @@ func (s *GetIncomeHistoryService) Do(ctx context.Context, opts ...RequestOption)
 		return nil, err
 	}
 	res = make([]*IncomeHistory, 0)
-	err = json.Unmarshal(data, &res)
+	err = unmarshalResponse(data, &res)
 	if err != nil {
 		return nil, err
 	}
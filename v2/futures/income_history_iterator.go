@@ -0,0 +1,112 @@
+package futures
+
+import (
+	"context"
+	"time"
+)
+
+const (
+	historyDefaultIncomeLimit = 1000
+	historyIncomeWeight       = 30
+	// historyIncomeMaxWindow keeps each request within the time range the income endpoint accepts
+	historyIncomeMaxWindow = int64(7 * 24 * time.Hour / time.Millisecond)
+)
+
+// IncomeHistoryIterator pages income history of a time range, see HistoryService.Income
+type IncomeHistoryIterator struct {
+	s          *HistoryService
+	symbol     string
+	incomeType string
+	endTime    int64
+
+	// cursor is the start of the next request, boundary holds incomes at cursor already returned
+	cursor   int64
+	boundary map[incomeKey]struct{}
+}
+
+// Income returns iterator over income with time in [startTime, endTime] milliseconds. Requests
+// are split into windows the endpoint accepts and pages of the page limit, throttled and retried
+// like the other downloads of the service.
+func (s *HistoryService) Income(startTime, endTime int64) *IncomeHistoryIterator {
+	return &IncomeHistoryIterator{
+		s:        s,
+		endTime:  endTime,
+		cursor:   startTime,
+		boundary: make(map[incomeKey]struct{}),
+	}
+}
+
+// Symbol set symbol
+func (it *IncomeHistoryIterator) Symbol(symbol string) *IncomeHistoryIterator {
+	it.symbol = symbol
+	return it
+}
+
+// IncomeType set income type
+func (it *IncomeHistoryIterator) IncomeType(incomeType string) *IncomeHistoryIterator {
+	it.incomeType = incomeType
+	return it
+}
+
+// Next returns the next page of income in chronological order, false once the range is exhausted
+func (it *IncomeHistoryIterator) Next(ctx context.Context) ([]*IncomeHistory, bool, error) {
+	limit := historyDefaultIncomeLimit
+	if it.s.limit != nil {
+		limit = *it.s.limit
+	}
+
+	for it.cursor <= it.endTime {
+		windowEnd := it.cursor + historyIncomeMaxWindow - 1
+		if windowEnd > it.endTime {
+			windowEnd = it.endTime
+		}
+		var incomes []*IncomeHistory
+		err := it.s.do(ctx, historyIncomeWeight, func() (err error) {
+			service := it.s.c.NewGetIncomeHistoryService().StartTime(it.cursor).EndTime(windowEnd).Limit(int64(limit))
+			if it.symbol != "" {
+				service.Symbol(it.symbol)
+			}
+			if it.incomeType != "" {
+				service.IncomeType(it.incomeType)
+			}
+			incomes, err = service.Do(ctx)
+			return err
+		})
+		if err != nil {
+			return nil, false, err
+		}
+
+		page := make([]*IncomeHistory, 0, len(incomes))
+		for _, income := range incomes {
+			if _, ok := it.boundary[incomeKey{tranID: income.TranID, incomeType: income.IncomeType}]; ok && income.Time == it.cursor {
+				continue
+			}
+			page = append(page, income)
+		}
+
+		if len(incomes) < limit {
+			// window is exhausted, continue from the next one
+			it.cursor = windowEnd + 1
+			it.boundary = make(map[incomeKey]struct{})
+		} else if last := incomes[len(incomes)-1].Time; len(page) == 0 {
+			// the whole page shares one time already returned, skip past it rather than loop forever
+			it.cursor = last + 1
+			it.boundary = make(map[incomeKey]struct{})
+		} else {
+			// continue from the time of the last income, which may have more incomes on the next page
+			if last > it.cursor {
+				it.cursor = last
+				it.boundary = make(map[incomeKey]struct{})
+			}
+			for _, income := range page {
+				if income.Time == last {
+					it.boundary[incomeKey{tranID: income.TranID, incomeType: income.IncomeType}] = struct{}{}
+				}
+			}
+		}
+		if len(page) > 0 {
+			return page, true, nil
+		}
+	}
+	return nil, false, nil
+}
@@ -0,0 +1,58 @@
+package futures
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIncomeHistoryIterator(t *testing.T) {
+	assert := assert.New(t)
+
+	day := int64(24 * 60 * 60 * 1000)
+	var incomes []*IncomeHistory
+	for i, ts := range []int64{1000, 2000, 2000, 2000, 3000, 1000 + 8*day} {
+		incomes = append(incomes, &IncomeHistory{TranID: int64(i + 1), IncomeType: IncomeTypeFundingFee, Time: ts})
+	}
+	var windows [][2]int64
+	c := NewClient("apiKey", "secretKey")
+	c.do = func(req *http.Request) (*http.Response, error) {
+		query := req.URL.Query()
+		startTime, _ := strconv.ParseInt(query.Get("startTime"), 10, 64)
+		endTime, _ := strconv.ParseInt(query.Get("endTime"), 10, 64)
+		limit, _ := strconv.Atoi(query.Get("limit"))
+		windows = append(windows, [2]int64{startTime, endTime})
+		res := make([]*IncomeHistory, 0)
+		for _, income := range incomes {
+			if income.Time >= startTime && income.Time <= endTime && len(res) < limit {
+				res = append(res, income)
+			}
+		}
+		data, _ := json.Marshal(res)
+		return newHTTPResponse(data, http.StatusOK), nil
+	}
+
+	it := c.NewHistoryService().Limit(3).Income(0, 10*day).IncomeType(IncomeTypeFundingFee)
+	var ids []int64
+	for {
+		page, ok, err := it.Next(newContext())
+		assert.NoError(err)
+		if !ok {
+			break
+		}
+		assert.NotEmpty(page)
+		for _, income := range page {
+			ids = append(ids, income.TranID)
+		}
+	}
+	assert.Equal([]int64{1, 2, 3, 4, 5, 6}, ids, "each income returned once across pages and windows")
+	assert.Equal([2]int64{0, 7*day - 1}, windows[0])
+	assert.Equal(10*day, windows[len(windows)-1][1])
+
+	_, ok, err := it.Next(newContext())
+	assert.NoError(err)
+	assert.False(ok)
+}
@@ -0,0 +1,243 @@
+package futures
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// Income types aggregated by IncomeTracker
+const (
+	IncomeTypeFundingFee  = "FUNDING_FEE"
+	IncomeTypeRealizedPnL = "REALIZED_PNL"
+	IncomeTypeCommission  = "COMMISSION"
+)
+
+var ErrIncomeTrackerPeriod = errors.New("income tracker: period must be positive")
+
+// IncomeSummary define income of a symbol in an asset aggregated over a period
+type IncomeSummary struct {
+	Symbol string
+	Asset  string
+	// PeriodStart is the start of the period in milliseconds
+	PeriodStart int64
+	FundingFee  float64
+	RealizedPnL float64
+	Commission  float64
+}
+
+// Net returns sum of funding fee, realized PnL and commission
+func (s IncomeSummary) Net() float64 {
+	return s.FundingFee + s.RealizedPnL + s.Commission
+}
+
+type incomeSummaryKey struct {
+	symbol      string
+	asset       string
+	periodStart int64
+}
+
+type incomeKey struct {
+	tranID     int64
+	incomeType string
+}
+
+// IncomeTracker pages the income history with IncomeHistoryIterator and aggregates funding fees,
+// realized PnL and commissions per symbol, asset and period. Each Update only fetches income newer
+// than the previous one, so it can run on a schedule with Start.
+type IncomeTracker struct {
+	history *HistoryService
+	period  time.Duration
+	now     func() time.Time
+
+	mu        sync.RWMutex
+	summaries map[incomeSummaryKey]*IncomeSummary
+	// cursor is the time of the last aggregated income, boundary holds incomes at that time
+	cursor   int64
+	boundary map[incomeKey]struct{}
+	stopC    chan struct{}
+	doneC    chan struct{}
+}
+
+// NewIncomeTracker init IncomeTracker aggregating income since startTime (milliseconds)
+// into periods of the given length aligned to unix epoch, e.g. 24h for daily summaries
+func NewIncomeTracker(c *Client, period time.Duration, startTime int64) (*IncomeTracker, error) {
+	if period <= 0 {
+		return nil, ErrIncomeTrackerPeriod
+	}
+	return &IncomeTracker{
+		history:   c.NewHistoryService(),
+		period:    period,
+		now:       time.Now,
+		summaries: make(map[incomeSummaryKey]*IncomeSummary),
+		cursor:    startTime,
+		boundary:  make(map[incomeKey]struct{}),
+	}, nil
+}
+
+// Update fetches income newer than the previous update and aggregates it
+func (t *IncomeTracker) Update(ctx context.Context) error {
+	t.mu.RLock()
+	startTime := t.cursor
+	t.mu.RUnlock()
+
+	it := t.history.Income(startTime, t.now().UnixMilli())
+	for {
+		incomes, ok, err := it.Next(ctx)
+		if err != nil || !ok {
+			return err
+		}
+		if err := t.add(incomes); err != nil {
+			return err
+		}
+	}
+}
+
+// Start runs Update every interval in background until Stop is called,
+// errors go to errHandler and the next run resumes where the failed one stopped
+func (t *IncomeTracker) Start(interval time.Duration, errHandler ErrHandler) {
+	t.mu.Lock()
+	if t.stopC != nil {
+		t.mu.Unlock()
+		return
+	}
+	t.stopC = make(chan struct{})
+	t.doneC = make(chan struct{})
+	stopC, doneC := t.stopC, t.doneC
+	t.mu.Unlock()
+
+	go func() {
+		defer close(doneC)
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-stopC:
+				return
+			case <-ticker.C:
+				ctx, cancel := context.WithTimeout(context.Background(), interval)
+				if err := t.Update(ctx); err != nil && errHandler != nil {
+					errHandler(err)
+				}
+				cancel()
+			}
+		}
+	}()
+}
+
+// Stop stops scheduled updates
+func (t *IncomeTracker) Stop() {
+	t.mu.Lock()
+	stopC, doneC := t.stopC, t.doneC
+	t.stopC, t.doneC = nil, nil
+	t.mu.Unlock()
+
+	if stopC == nil {
+		return
+	}
+	close(stopC)
+	<-doneC
+}
+
+// Summaries returns aggregated income sorted by period, symbol and asset
+func (t *IncomeTracker) Summaries() []IncomeSummary {
+	t.mu.RLock()
+	res := make([]IncomeSummary, 0, len(t.summaries))
+	for _, s := range t.summaries {
+		res = append(res, *s)
+	}
+	t.mu.RUnlock()
+
+	sort.Slice(res, func(i, j int) bool {
+		if res[i].PeriodStart != res[j].PeriodStart {
+			return res[i].PeriodStart < res[j].PeriodStart
+		}
+		if res[i].Symbol != res[j].Symbol {
+			return res[i].Symbol < res[j].Symbol
+		}
+		return res[i].Asset < res[j].Asset
+	})
+	return res
+}
+
+// Totals returns income of symbol aggregated over all periods, per asset
+func (t *IncomeTracker) Totals(symbol string) map[string]IncomeSummary {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+
+	res := make(map[string]IncomeSummary)
+	for _, s := range t.summaries {
+		if s.Symbol != symbol {
+			continue
+		}
+		total := res[s.Asset]
+		total.Symbol, total.Asset = s.Symbol, s.Asset
+		total.FundingFee += s.FundingFee
+		total.RealizedPnL += s.RealizedPnL
+		total.Commission += s.Commission
+		res[s.Asset] = total
+	}
+	return res
+}
+
+// add aggregates incomes sorted by time, skipping ones already aggregated by a previous update
+func (t *IncomeTracker) add(incomes []*IncomeHistory) error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	for _, income := range incomes {
+		key := incomeKey{tranID: income.TranID, incomeType: income.IncomeType}
+		if income.Time < t.cursor {
+			continue
+		}
+		if _, ok := t.boundary[key]; ok && income.Time == t.cursor {
+			continue
+		}
+		if err := t.aggregate(income); err != nil {
+			return err
+		}
+
+		if income.Time > t.cursor {
+			t.cursor = income.Time
+			t.boundary = make(map[incomeKey]struct{})
+		}
+		t.boundary[key] = struct{}{}
+	}
+	return nil
+}
+
+func (t *IncomeTracker) aggregate(income *IncomeHistory) error {
+	switch income.IncomeType {
+	case IncomeTypeFundingFee, IncomeTypeRealizedPnL, IncomeTypeCommission:
+	default:
+		return nil
+	}
+	amount, err := strconv.ParseFloat(income.Income, 64)
+	if err != nil {
+		return err
+	}
+
+	periodMs := t.period.Milliseconds()
+	key := incomeSummaryKey{
+		symbol:      income.Symbol,
+		asset:       income.Asset,
+		periodStart: income.Time - income.Time%periodMs,
+	}
+	s, ok := t.summaries[key]
+	if !ok {
+		s = &IncomeSummary{Symbol: key.symbol, Asset: key.asset, PeriodStart: key.periodStart}
+		t.summaries[key] = s
+	}
+	switch income.IncomeType {
+	case IncomeTypeFundingFee:
+		s.FundingFee += amount
+	case IncomeTypeRealizedPnL:
+		s.RealizedPnL += amount
+	case IncomeTypeCommission:
+		s.Commission += amount
+	}
+	return nil
+}
@@ -0,0 +1,97 @@
+package futures
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIncomeTracker(t *testing.T) {
+	assert := assert.New(t)
+
+	day := int64(24 * time.Hour / time.Millisecond)
+	start := 20000 * day
+
+	var (
+		mu       sync.Mutex
+		incomes  []*IncomeHistory
+		requests int
+	)
+	// two days of incomes, more than a page
+	for i := 0; i < 1200; i++ {
+		income := &IncomeHistory{
+			Symbol:     "BTCUSDT",
+			Asset:      "USDT",
+			IncomeType: IncomeTypeRealizedPnL,
+			Income:     "1",
+			Time:       start + int64(i)*2*day/1200,
+			TranID:     int64(i),
+		}
+		switch i % 3 {
+		case 1:
+			income.IncomeType, income.Income = IncomeTypeCommission, "-0.5"
+		case 2:
+			income.IncomeType, income.Income = IncomeTypeFundingFee, "0.25"
+		}
+		incomes = append(incomes, income)
+	}
+
+	c := NewClient("apiKey", "secretKey")
+	c.do = func(req *http.Request) (*http.Response, error) {
+		assert.Equal("/fapi/v1/income", req.URL.Path)
+		q := req.URL.Query()
+		startTime, _ := strconv.ParseInt(q.Get("startTime"), 10, 64)
+		endTime, _ := strconv.ParseInt(q.Get("endTime"), 10, 64)
+		limit, _ := strconv.Atoi(q.Get("limit"))
+
+		mu.Lock()
+		defer mu.Unlock()
+		requests++
+		page := make([]*IncomeHistory, 0)
+		for _, income := range incomes {
+			if income.Time >= startTime && income.Time <= endTime && len(page) < limit {
+				page = append(page, income)
+			}
+		}
+		data, err := json.Marshal(page)
+		if err != nil {
+			return nil, err
+		}
+		return newHTTPResponse(data, http.StatusOK), nil
+	}
+
+	_, err := NewIncomeTracker(c, 0, start)
+	assert.ErrorIs(err, ErrIncomeTrackerPeriod)
+
+	tracker, err := NewIncomeTracker(c, 24*time.Hour, start)
+	assert.NoError(err)
+	tracker.now = func() time.Time {
+		return time.UnixMilli(start + 3*day)
+	}
+	assert.NoError(tracker.Update(newContext()))
+	assert.Equal(2, requests)
+
+	summaries := tracker.Summaries()
+	assert.Len(summaries, 2)
+	assert.Equal(IncomeSummary{Symbol: "BTCUSDT", Asset: "USDT", PeriodStart: start, RealizedPnL: 200, Commission: -100, FundingFee: 50}, summaries[0])
+	assert.Equal(start+day, summaries[1].PeriodStart)
+	assert.Equal(150.0, summaries[1].Net())
+
+	// incremental update only aggregates new incomes
+	mu.Lock()
+	incomes = append(incomes, &IncomeHistory{Symbol: "BTCUSDT", Asset: "USDT", IncomeType: IncomeTypeFundingFee, Income: "-2", Time: start + 2*day, TranID: 6000})
+	mu.Unlock()
+	assert.NoError(tracker.Update(newContext()))
+
+	totals := tracker.Totals("BTCUSDT")
+	assert.Len(totals, 1)
+	assert.Equal(400.0, totals["USDT"].RealizedPnL)
+	assert.Equal(-200.0, totals["USDT"].Commission)
+	assert.Equal(98.0, totals["USDT"].FundingFee)
+	assert.Len(tracker.Summaries(), 3)
+}
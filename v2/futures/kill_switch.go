@@ -0,0 +1,132 @@
+package futures
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/jpillora/backoff"
+)
+
+const (
+	killSwitchDefaultConcurrency = 5
+	killSwitchDefaultMaxRetries  = 3
+)
+
+// CancelAllResult define outcome of canceling open orders of a symbol
+type CancelAllResult struct {
+	Symbol string
+	// Orders is the number of open orders the symbol had when listed
+	Orders   int
+	Attempts int
+	Err      error
+}
+
+// CancelAllEverywhereService cancels open orders of all symbols, for use in emergency stop paths.
+// Symbols are canceled concurrently and transient failures are retried.
+type CancelAllEverywhereService struct {
+	c           *Client
+	concurrency int
+	maxRetries  int
+}
+
+// NewCancelAllEverywhereService init cancel all everywhere service
+func (c *Client) NewCancelAllEverywhereService() *CancelAllEverywhereService {
+	return &CancelAllEverywhereService{
+		c:           c,
+		concurrency: killSwitchDefaultConcurrency,
+		maxRetries:  killSwitchDefaultMaxRetries,
+	}
+}
+
+// CancelAllEverywhere cancels open orders of all symbols with default concurrency and retries
+func (c *Client) CancelAllEverywhere(ctx context.Context) ([]*CancelAllResult, error) {
+	return c.NewCancelAllEverywhereService().Do(ctx)
+}
+
+// Concurrency set number of symbols canceled at the same time
+func (s *CancelAllEverywhereService) Concurrency(concurrency int) *CancelAllEverywhereService {
+	s.concurrency = concurrency
+	return s
+}
+
+// MaxRetries set number of retries of listing and of each symbol cancel on transient failures
+func (s *CancelAllEverywhereService) MaxRetries(maxRetries int) *CancelAllEverywhereService {
+	s.maxRetries = maxRetries
+	return s
+}
+
+// Do lists open orders and cancels them symbol by symbol. The error is only returned when open
+// orders can't be listed, failures of single symbols are reported in their result.
+// Results are sorted by symbol.
+func (s *CancelAllEverywhereService) Do(ctx context.Context, opts ...RequestOption) ([]*CancelAllResult, error) {
+	var orders []*Order
+	_, err := s.retry(ctx, func() (err error) {
+		orders, err = s.c.NewListOpenOrdersService().Do(ctx, opts...)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	bySymbol := make(map[string]*CancelAllResult)
+	for _, o := range orders {
+		res, ok := bySymbol[o.Symbol]
+		if !ok {
+			res = &CancelAllResult{Symbol: o.Symbol}
+			bySymbol[o.Symbol] = res
+		}
+		res.Orders++
+	}
+	results := make([]*CancelAllResult, 0, len(bySymbol))
+	for _, res := range bySymbol {
+		results = append(results, res)
+	}
+	sort.Slice(results, func(i, j int) bool {
+		return results[i].Symbol < results[j].Symbol
+	})
+
+	concurrency := s.concurrency
+	if concurrency <= 0 {
+		concurrency = 1
+	}
+	sem := make(chan struct{}, concurrency)
+	wg := sync.WaitGroup{}
+	for _, res := range results {
+		wg.Add(1)
+		sem <- struct{}{}
+		go func(res *CancelAllResult) {
+			defer wg.Done()
+			defer func() { <-sem }()
+			res.Attempts, res.Err = s.retry(ctx, func() error {
+				return s.c.NewCancelAllOpenOrdersService().Symbol(res.Symbol).Do(ctx, opts...)
+			})
+		}(res)
+	}
+	wg.Wait()
+	return results, nil
+}
+
+// retry calls f until it succeeds, fails permanently or retries are exhausted, returns number of attempts
+func (s *CancelAllEverywhereService) retry(ctx context.Context, f func() error) (int, error) {
+	b := &backoff.Backoff{
+		Min:    100 * time.Millisecond,
+		Max:    2 * time.Second,
+		Factor: 2,
+		Jitter: true,
+	}
+	for attempt := 1; ; attempt++ {
+		err := f()
+		if err == nil || attempt > s.maxRetries || !isTransientError(err) {
+			return attempt, err
+		}
+		delay := b.Duration()
+		s.c.debug("kill switch: retry in %s after error: %v", delay, err)
+		select {
+		case <-time.After(delay):
+		case <-ctx.Done():
+			return attempt, ctx.Err()
+		}
+	}
+}
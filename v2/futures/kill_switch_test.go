@@ -0,0 +1,79 @@
+package futures
+
+import (
+	"io"
+	"net/http"
+	"net/url"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/adshao/go-binance/v2/common"
+)
+
+func TestCancelAllEverywhere(t *testing.T) {
+	assert := assert.New(t)
+
+	var (
+		mu      sync.Mutex
+		cancels = make(map[string]int)
+	)
+	c := NewClient("apiKey", "secretKey")
+	c.do = func(req *http.Request) (*http.Response, error) {
+		if req.Method == http.MethodGet {
+			assert.Equal("/fapi/v1/openOrders", req.URL.Path)
+			return newHTTPResponse([]byte(`[
+				{"symbol":"BTCUSDT","orderId":1},
+				{"symbol":"ETHUSDT","orderId":2},
+				{"symbol":"BTCUSDT","orderId":3},
+				{"symbol":"XRPUSDT","orderId":4}
+			]`), http.StatusOK), nil
+		}
+		// form params of DELETE requests are not parsed by ParseForm
+		body, err := io.ReadAll(req.Body)
+		if err != nil {
+			return nil, err
+		}
+		form, err := url.ParseQuery(string(body))
+		if err != nil {
+			return nil, err
+		}
+		assert.Equal("/fapi/v1/allOpenOrders", req.URL.Path)
+		symbol := form.Get("symbol")
+
+		mu.Lock()
+		cancels[symbol]++
+		attempt := cancels[symbol]
+		mu.Unlock()
+
+		switch {
+		case symbol == "BTCUSDT" && attempt == 1:
+			return newHTTPResponse([]byte(`{"code":-1001,"msg":"Internal error; unable to process your request."}`), http.StatusServiceUnavailable), nil
+		case symbol == "XRPUSDT":
+			return newHTTPResponse([]byte(`{"code":-1121,"msg":"Invalid symbol."}`), http.StatusBadRequest), nil
+		}
+		return newHTTPResponse([]byte(`{"code":"200","msg":"The operation of cancel all open order is done."}`), http.StatusOK), nil
+	}
+
+	res, err := c.NewCancelAllEverywhereService().Concurrency(2).Do(newContext())
+	assert.NoError(err)
+	assert.Len(res, 3)
+
+	assert.Equal(&CancelAllResult{Symbol: "BTCUSDT", Orders: 2, Attempts: 2}, res[0])
+	assert.Equal(&CancelAllResult{Symbol: "ETHUSDT", Orders: 1, Attempts: 1}, res[1])
+	assert.Equal("XRPUSDT", res[2].Symbol)
+	assert.Equal(1, res[2].Attempts)
+	assert.Equal(int64(-1121), res[2].Err.(*common.APIError).Code)
+}
+
+func TestCancelAllEverywhereListError(t *testing.T) {
+	c := NewClient("apiKey", "secretKey")
+	c.do = func(req *http.Request) (*http.Response, error) {
+		return newHTTPResponse([]byte(`{"code":-2015,"msg":"Invalid API-key, IP, or permissions for action."}`), http.StatusUnauthorized), nil
+	}
+
+	res, err := c.CancelAllEverywhere(newContext())
+	assert.Error(t, err)
+	assert.Nil(t, res)
+}
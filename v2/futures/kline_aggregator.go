@@ -0,0 +1,317 @@
+package futures
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+const klineAggregatorBaseInterval = "1m"
+
+// KlineAggregator builds higher timeframe klines (3m, 5m, 15m, 1h, ...) locally from 1m kline streams
+type KlineAggregator struct {
+	symbols   []string
+	intervals map[string]int64 // interval => duration in milliseconds
+	// order holds the intervals by ascending duration, closed bars are emitted in this order
+	order      []string
+	handler    WsKlineHandler
+	errHandler ErrHandler
+
+	mu      sync.Mutex
+	bars    map[string]map[string]*aggregatedKline // symbol => interval => bar in progress
+	minutes map[string]*WsKline                    // symbol => 1m kline in progress
+	stopC   chan struct{}
+	doneC   chan struct{}
+}
+
+// NewKlineAggregator init KlineAggregator, intervals must be multiples of 1m like 3m, 15m, 1h, 4h
+func NewKlineAggregator(symbols []string, intervals ...string) (*KlineAggregator, error) {
+	a := &KlineAggregator{
+		symbols:   symbols,
+		intervals: make(map[string]int64, len(intervals)),
+		bars:      make(map[string]map[string]*aggregatedKline, len(symbols)),
+		minutes:   make(map[string]*WsKline, len(symbols)),
+	}
+	for _, interval := range intervals {
+		d, err := parseKlineInterval(interval)
+		if err != nil {
+			return nil, err
+		}
+		if _, ok := a.intervals[interval]; !ok {
+			a.order = append(a.order, interval)
+		}
+		a.intervals[interval] = d.Milliseconds()
+	}
+	sort.SliceStable(a.order, func(i, j int) bool {
+		return a.intervals[a.order[i]] < a.intervals[a.order[j]]
+	})
+	for _, symbol := range symbols {
+		a.bars[symbol] = make(map[string]*aggregatedKline, len(intervals))
+	}
+	return a, nil
+}
+
+// OnClose set handler called with every closed aggregated kline, bars closed by the same 1m
+// kline are passed by ascending interval
+func (a *KlineAggregator) OnClose(handler WsKlineHandler) *KlineAggregator {
+	a.handler = handler
+	return a
+}
+
+// ErrHandler set handler for stream errors
+func (a *KlineAggregator) ErrHandler(errHandler ErrHandler) *KlineAggregator {
+	a.errHandler = errHandler
+	return a
+}
+
+// Start subscribes 1m kline streams of all symbols
+func (a *KlineAggregator) Start() error {
+	pairs := make(map[string]string, len(a.symbols))
+	for _, symbol := range a.symbols {
+		pairs[symbol] = klineAggregatorBaseInterval
+	}
+	doneC, stopC, err := WsCombinedKlineServe(pairs, a.handleEvent, a.handleError)
+	if err != nil {
+		return err
+	}
+
+	a.mu.Lock()
+	a.doneC, a.stopC = doneC, stopC
+	a.mu.Unlock()
+	return nil
+}
+
+// Stop closes the stream
+func (a *KlineAggregator) Stop() {
+	a.mu.Lock()
+	doneC, stopC := a.doneC, a.stopC
+	a.doneC, a.stopC = nil, nil
+	a.mu.Unlock()
+
+	if stopC == nil {
+		return
+	}
+	close(stopC)
+	<-doneC
+}
+
+// Current returns the kline in progress of symbol and interval, including the 1m kline in progress
+func (a *KlineAggregator) Current(symbol, interval string) (*WsKline, bool) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	ms, ok := a.intervals[interval]
+	if !ok {
+		return nil, false
+	}
+	bar := a.bars[symbol][interval]
+	if minute := a.minutes[symbol]; minute != nil {
+		start := minute.StartTime - minute.StartTime%ms
+		if bar == nil || bar.startTime != start {
+			bar = newAggregatedKline(start, ms)
+		} else {
+			bar = bar.clone()
+		}
+		if err := bar.merge(minute); err != nil {
+			return nil, false
+		}
+	}
+	if bar == nil {
+		return nil, false
+	}
+	k := bar.toWsKline(symbol, interval)
+	k.IsFinal = false
+	return k, true
+}
+
+func (a *KlineAggregator) handleEvent(event *WsKlineEvent) {
+	k := event.Kline
+	if k.Interval != klineAggregatorBaseInterval {
+		return
+	}
+
+	var closed []*WsKlineEvent
+	a.mu.Lock()
+	bars, ok := a.bars[event.Symbol]
+	if !ok {
+		a.mu.Unlock()
+		return
+	}
+	if !k.IsFinal {
+		a.minutes[event.Symbol] = &k
+		a.mu.Unlock()
+		return
+	}
+	delete(a.minutes, event.Symbol)
+
+	var err error
+	for _, interval := range a.order {
+		ms := a.intervals[interval]
+		start := k.StartTime - k.StartTime%ms
+		bar := bars[interval]
+		if bar != nil && bar.startTime != start {
+			// missing minutes at the end of previous bar, close it anyway
+			closed = append(closed, a.newEvent(event, bar.toWsKline(event.Symbol, interval)))
+			bar = nil
+		}
+		if bar == nil {
+			bar = newAggregatedKline(start, ms)
+			bars[interval] = bar
+		}
+		if err = bar.merge(&k); err != nil {
+			delete(bars, interval)
+			break
+		}
+		if k.EndTime+1 >= bar.startTime+ms {
+			closed = append(closed, a.newEvent(event, bar.toWsKline(event.Symbol, interval)))
+			delete(bars, interval)
+		}
+	}
+	a.mu.Unlock()
+
+	if err != nil {
+		a.handleError(err)
+	}
+	if a.handler == nil {
+		return
+	}
+	for _, e := range closed {
+		a.handler(e)
+	}
+}
+
+func (a *KlineAggregator) newEvent(source *WsKlineEvent, k *WsKline) *WsKlineEvent {
+	return &WsKlineEvent{
+		Event:  source.Event,
+		Time:   source.Time,
+		Symbol: source.Symbol,
+		Kline:  *k,
+	}
+}
+
+func (a *KlineAggregator) handleError(err error) {
+	if a.errHandler != nil {
+		a.errHandler(err)
+	}
+}
+
+// aggregatedKline accumulates 1m klines of a higher timeframe bar
+type aggregatedKline struct {
+	startTime            int64
+	duration             int64
+	firstTradeID         int64
+	lastTradeID          int64
+	tradeNum             int64
+	open                 decimal.Decimal
+	close                decimal.Decimal
+	high                 decimal.Decimal
+	low                  decimal.Decimal
+	volume               decimal.Decimal
+	quoteVolume          decimal.Decimal
+	activeBuyVolume      decimal.Decimal
+	activeBuyQuoteVolume decimal.Decimal
+	empty                bool
+}
+
+func newAggregatedKline(startTime, duration int64) *aggregatedKline {
+	return &aggregatedKline{
+		startTime: startTime,
+		duration:  duration,
+		empty:     true,
+	}
+}
+
+func (b *aggregatedKline) clone() *aggregatedKline {
+	c := *b
+	return &c
+}
+
+func (b *aggregatedKline) merge(k *WsKline) error {
+	values := make([]decimal.Decimal, 8)
+	for i, s := range []string{k.Open, k.Close, k.High, k.Low, k.Volume, k.QuoteVolume, k.ActiveBuyVolume, k.ActiveBuyQuoteVolume} {
+		if s == "" {
+			continue
+		}
+		v, err := decimal.NewFromString(s)
+		if err != nil {
+			return err
+		}
+		values[i] = v
+	}
+	open, close, high, low := values[0], values[1], values[2], values[3]
+
+	if b.empty {
+		b.open, b.high, b.low = open, high, low
+		b.firstTradeID = k.FirstTradeID
+		b.empty = false
+	} else {
+		if high.GreaterThan(b.high) {
+			b.high = high
+		}
+		if low.LessThan(b.low) {
+			b.low = low
+		}
+	}
+	// trade ids are -1 when there is no trade within the minute
+	if k.LastTradeID >= 0 {
+		if b.firstTradeID < 0 {
+			b.firstTradeID = k.FirstTradeID
+		}
+		b.lastTradeID = k.LastTradeID
+	}
+	b.close = close
+	b.volume = b.volume.Add(values[4])
+	b.quoteVolume = b.quoteVolume.Add(values[5])
+	b.activeBuyVolume = b.activeBuyVolume.Add(values[6])
+	b.activeBuyQuoteVolume = b.activeBuyQuoteVolume.Add(values[7])
+	b.tradeNum += k.TradeNum
+	return nil
+}
+
+func (b *aggregatedKline) toWsKline(symbol, interval string) *WsKline {
+	return &WsKline{
+		StartTime:            b.startTime,
+		EndTime:              b.startTime + b.duration - 1,
+		Symbol:               symbol,
+		Interval:             interval,
+		FirstTradeID:         b.firstTradeID,
+		LastTradeID:          b.lastTradeID,
+		Open:                 b.open.String(),
+		Close:                b.close.String(),
+		High:                 b.high.String(),
+		Low:                  b.low.String(),
+		Volume:               b.volume.String(),
+		TradeNum:             b.tradeNum,
+		IsFinal:              true,
+		QuoteVolume:          b.quoteVolume.String(),
+		ActiveBuyVolume:      b.activeBuyVolume.String(),
+		ActiveBuyQuoteVolume: b.activeBuyQuoteVolume.String(),
+	}
+}
+
+// parseKlineInterval parses minute based kline interval like 3m, 1h, 1d
+func parseKlineInterval(interval string) (time.Duration, error) {
+	if len(interval) < 2 {
+		return 0, fmt.Errorf("invalid kline interval: %s", interval)
+	}
+	n, err := strconv.Atoi(interval[:len(interval)-1])
+	if err != nil || n <= 0 {
+		return 0, fmt.Errorf("invalid kline interval: %s", interval)
+	}
+	var unit time.Duration
+	switch interval[len(interval)-1] {
+	case 'm':
+		unit = time.Minute
+	case 'h':
+		unit = time.Hour
+	case 'd':
+		unit = 24 * time.Hour
+	default:
+		return 0, fmt.Errorf("invalid kline interval: %s", interval)
+	}
+	return time.Duration(n) * unit, nil
+}
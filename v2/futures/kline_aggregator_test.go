@@ -0,0 +1,136 @@
+package futures
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type klineAggregatorTestSuite struct {
+	baseWsTestSuite
+}
+
+func TestKlineAggregator(t *testing.T) {
+	suite.Run(t, new(klineAggregatorTestSuite))
+}
+
+func minuteKline(start int64, open, close, high, low, volume string, final bool) []byte {
+	return []byte(fmt.Sprintf(`{"stream":"btcusdt@kline_1m","data":{"e":"kline","E":%d,"s":"BTCUSDT","k":{
+		"t":%d,"T":%d,"s":"BTCUSDT","i":"1m","f":%d,"L":%d,"o":"%s","c":"%s","h":"%s","l":"%s",
+		"v":"%s","n":2,"x":%t,"q":"1.1","V":"0.1","Q":"0.2"}}}`,
+		start+60000, start, start+59999, start, start+1, open, close, high, low, volume, final))
+}
+
+func (s *klineAggregatorTestSuite) TestAggregate() {
+	s.mockWsServeMessages(
+		minuteKline(0, "10", "11", "12", "9", "1.1", true),
+		minuteKline(60000, "11", "12", "14", "10", "2.2", false),
+		minuteKline(60000, "11", "13", "15", "10", "2.2", true),
+		minuteKline(120000, "13", "12", "13", "8", "0.7", true),
+		minuteKline(180000, "12", "12", "12", "12", "0", true),
+	)
+
+	a, err := NewKlineAggregator([]string{"BTCUSDT"}, "3m", "5m")
+	s.r().NoError(err)
+
+	var closed []*WsKlineEvent
+	a.OnClose(func(event *WsKlineEvent) {
+		closed = append(closed, event)
+	})
+	s.r().NoError(a.Start())
+	defer a.Stop()
+
+	s.r().Equal("wss://fstream.binance.com/stream?streams=btcusdt@kline_1m", s.endpoint)
+	s.r().Len(closed, 1)
+	s.r().Equal(&WsKlineEvent{
+		Event:  "kline",
+		Time:   180000,
+		Symbol: "BTCUSDT",
+		Kline: WsKline{
+			StartTime:            0,
+			EndTime:              179999,
+			Symbol:               "BTCUSDT",
+			Interval:             "3m",
+			FirstTradeID:         0,
+			LastTradeID:          120001,
+			Open:                 "10",
+			Close:                "12",
+			High:                 "15",
+			Low:                  "8",
+			Volume:               "4",
+			TradeNum:             6,
+			IsFinal:              true,
+			QuoteVolume:          "3.3",
+			ActiveBuyVolume:      "0.3",
+			ActiveBuyQuoteVolume: "0.6",
+		},
+	}, closed[0])
+
+	current, ok := a.Current("BTCUSDT", "5m")
+	s.r().True(ok)
+	s.r().False(current.IsFinal)
+	s.r().Equal("10", current.Open)
+	s.r().Equal("12", current.Close)
+	s.r().Equal("4", current.Volume)
+	s.r().Equal(int64(299999), current.EndTime)
+
+	current, ok = a.Current("BTCUSDT", "3m")
+	s.r().True(ok)
+	s.r().Equal(int64(180000), current.StartTime)
+
+	_, ok = a.Current("BTCUSDT", "1h")
+	s.r().False(ok)
+}
+
+func (s *klineAggregatorTestSuite) TestMissingMinute() {
+	a, err := NewKlineAggregator([]string{"BTCUSDT"}, "3m")
+	s.r().NoError(err)
+
+	var closed []*WsKlineEvent
+	a.OnClose(func(event *WsKlineEvent) {
+		closed = append(closed, event)
+	})
+
+	k := WsKline{Interval: "1m", Open: "1", Close: "2", High: "3", Low: "1", Volume: "1", IsFinal: true}
+	k.StartTime, k.EndTime = 0, 59999
+	a.handleEvent(&WsKlineEvent{Symbol: "BTCUSDT", Kline: k})
+	// minute 2 of the first bar is never received
+	k.StartTime, k.EndTime = 180000, 239999
+	a.handleEvent(&WsKlineEvent{Symbol: "BTCUSDT", Kline: k})
+
+	s.r().Len(closed, 1)
+	s.r().Equal(int64(0), closed[0].Kline.StartTime)
+	s.r().Equal("1", closed[0].Kline.Volume)
+
+	current, ok := a.Current("BTCUSDT", "3m")
+	s.r().True(ok)
+	s.r().Equal(int64(180000), current.StartTime)
+}
+
+func (s *klineAggregatorTestSuite) TestInvalidInterval() {
+	for _, interval := range []string{"", "m", "0m", "1w", "abc"} {
+		_, err := NewKlineAggregator([]string{"BTCUSDT"}, interval)
+		s.r().Error(err, interval)
+	}
+}
+
+func (s *klineAggregatorTestSuite) TestCloseOrder() {
+	a, err := NewKlineAggregator([]string{"BTCUSDT"}, "15m", "5m", "3m", "5m")
+	s.r().NoError(err)
+
+	var intervals []string
+	a.OnClose(func(event *WsKlineEvent) {
+		intervals = append(intervals, event.Kline.Interval)
+	})
+
+	k := WsKline{Interval: "1m", Open: "1", Close: "1", High: "1", Low: "1", Volume: "1", IsFinal: true}
+	for minute := int64(0); minute < 15; minute++ {
+		k.StartTime, k.EndTime = minute*60000, minute*60000+59999
+		a.handleEvent(&WsKlineEvent{Symbol: "BTCUSDT", Kline: k})
+	}
+
+	// the last minute closes every bar, by ascending interval
+	s.r().Len(intervals, 5+3+1)
+	s.r().Equal([]string{"3m", "5m", "15m"}, intervals[len(intervals)-3:])
+}
@@ -0,0 +1,140 @@
+package futures
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+)
+
+var (
+	ErrBracketsNotFound = errors.New("leverage bracket: brackets of symbol not loaded")
+	ErrLeverageTooHigh  = errors.New("leverage bracket: leverage above the maximum of symbol")
+)
+
+// LeverageBracketCache caches 'leverageBracket' data of all symbols and does the order sizing
+// math on top of it. Call Refresh before use and whenever brackets may have changed.
+type LeverageBracketCache struct {
+	c *Client
+
+	mu         sync.RWMutex
+	brackets   map[string][]Bracket
+	updateTime time.Time
+}
+
+// NewLeverageBracketCache init LeverageBracketCache
+func NewLeverageBracketCache(c *Client) *LeverageBracketCache {
+	return &LeverageBracketCache{
+		c:        c,
+		brackets: make(map[string][]Bracket),
+	}
+}
+
+// Refresh loads brackets of all symbols
+func (l *LeverageBracketCache) Refresh(ctx context.Context) error {
+	res, err := l.c.NewGetLeverageBracketService().Do(ctx)
+	if err != nil {
+		return err
+	}
+
+	brackets := make(map[string][]Bracket, len(res))
+	for _, b := range res {
+		brackets[b.Symbol] = b.Brackets
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.brackets = brackets
+	l.updateTime = time.Now()
+	return nil
+}
+
+// UpdateTime returns local time of the last refresh
+func (l *LeverageBracketCache) UpdateTime() time.Time {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+
+	return l.updateTime
+}
+
+// Brackets returns cached brackets of symbol
+func (l *LeverageBracketCache) Brackets(symbol string) ([]Bracket, bool) {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+
+	b, ok := l.brackets[symbol]
+	return b, ok
+}
+
+// MaxLeverage returns the highest leverage allowed for symbol
+func (l *LeverageBracketCache) MaxLeverage(symbol string) (int, error) {
+	brackets, ok := l.Brackets(symbol)
+	if !ok {
+		return 0, ErrBracketsNotFound
+	}
+	max := 0
+	for _, b := range brackets {
+		if b.InitialLeverage > max {
+			max = b.InitialLeverage
+		}
+	}
+	return max, nil
+}
+
+// MaxNotional returns the largest position notional allowed for symbol at leverage
+func (l *LeverageBracketCache) MaxNotional(symbol string, leverage int) (float64, error) {
+	brackets, ok := l.Brackets(symbol)
+	if !ok {
+		return 0, ErrBracketsNotFound
+	}
+	max, found := 0.0, false
+	for _, b := range brackets {
+		if b.InitialLeverage >= leverage && b.NotionalCap > max {
+			max, found = b.NotionalCap, true
+		}
+	}
+	if !found {
+		return 0, ErrLeverageTooHigh
+	}
+	return max, nil
+}
+
+// MaxQty returns the largest position quantity allowed for symbol at price and leverage
+func (l *LeverageBracketCache) MaxQty(symbol string, price float64, leverage int) (float64, error) {
+	notional, err := l.MaxNotional(symbol, leverage)
+	if err != nil {
+		return 0, err
+	}
+	if price <= 0 {
+		return 0, nil
+	}
+	return notional / price, nil
+}
+
+// InitialMargin returns initial margin of position of quantity at price and leverage,
+// ErrLeverageTooHigh is returned if the position notional exceeds the cap of leverage
+func (l *LeverageBracketCache) InitialMargin(symbol string, quantity, price float64, leverage int) (float64, error) {
+	maxNotional, err := l.MaxNotional(symbol, leverage)
+	if err != nil {
+		return 0, err
+	}
+	notional := quantity * price
+	if notional > maxNotional {
+		return 0, ErrLeverageTooHigh
+	}
+	return notional / float64(leverage), nil
+}
+
+// MaintMargin returns maintenance margin of position notional, using the bracket it falls into
+func (l *LeverageBracketCache) MaintMargin(symbol string, notional float64) (float64, error) {
+	brackets, ok := l.Brackets(symbol)
+	if !ok {
+		return 0, ErrBracketsNotFound
+	}
+	for _, b := range brackets {
+		if notional >= b.NotionalFloor && notional < b.NotionalCap {
+			return notional*b.MaintMarginRatio - b.Cum, nil
+		}
+	}
+	return 0, ErrLeverageTooHigh
+}
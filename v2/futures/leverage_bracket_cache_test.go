@@ -0,0 +1,57 @@
+package futures
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLeverageBracketCache(t *testing.T) {
+	assert := assert.New(t)
+
+	c := NewClient("apiKey", "secretKey")
+	c.do = func(req *http.Request) (*http.Response, error) {
+		assert.Equal("/fapi/v1/leverageBracket", req.URL.Path)
+		return newHTTPResponse([]byte(`[{
+			"symbol": "BTCUSDT",
+			"brackets": [
+				{"bracket": 1, "initialLeverage": 125, "notionalCap": 50000, "notionalFloor": 0, "maintMarginRatio": 0.004, "cum": 0},
+				{"bracket": 2, "initialLeverage": 100, "notionalCap": 500000, "notionalFloor": 50000, "maintMarginRatio": 0.005, "cum": 50},
+				{"bracket": 3, "initialLeverage": 50, "notionalCap": 8000000, "notionalFloor": 500000, "maintMarginRatio": 0.01, "cum": 2550}
+			]
+		}]`), http.StatusOK), nil
+	}
+
+	l := NewLeverageBracketCache(c)
+	_, err := l.MaxQty("BTCUSDT", 50000, 20)
+	assert.ErrorIs(err, ErrBracketsNotFound)
+
+	assert.NoError(l.Refresh(newContext()))
+	assert.False(l.UpdateTime().IsZero())
+
+	maxLeverage, err := l.MaxLeverage("BTCUSDT")
+	assert.NoError(err)
+	assert.Equal(125, maxLeverage)
+
+	qty, err := l.MaxQty("BTCUSDT", 50000, 20)
+	assert.NoError(err)
+	assert.Equal(160.0, qty)
+
+	qty, err = l.MaxQty("BTCUSDT", 50000, 100)
+	assert.NoError(err)
+	assert.Equal(10.0, qty)
+
+	_, err = l.MaxQty("BTCUSDT", 50000, 150)
+	assert.ErrorIs(err, ErrLeverageTooHigh)
+
+	margin, err := l.InitialMargin("BTCUSDT", 2, 50000, 100)
+	assert.NoError(err)
+	assert.Equal(1000.0, margin)
+	_, err = l.InitialMargin("BTCUSDT", 20, 50000, 100)
+	assert.ErrorIs(err, ErrLeverageTooHigh)
+
+	maint, err := l.MaintMargin("BTCUSDT", 100000)
+	assert.NoError(err)
+	assert.Equal(450.0, maint)
+}
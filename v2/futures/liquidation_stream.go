@@ -0,0 +1,206 @@
+package futures
+
+import (
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// LiquidationStats define aggregated liquidations of a symbol within the rolling window
+type LiquidationStats struct {
+	Symbol       string
+	Count        int
+	BuyNotional  float64
+	SellNotional float64
+	Window       time.Duration
+}
+
+// Notional returns total liquidated notional
+func (s LiquidationStats) Notional() float64 {
+	return s.BuyNotional + s.SellNotional
+}
+
+// NotionalPerMinute returns total liquidated notional normalized to one minute
+func (s LiquidationStats) NotionalPerMinute() float64 {
+	if s.Window <= 0 {
+		return 0
+	}
+	return s.Notional() * float64(time.Minute) / float64(s.Window)
+}
+
+// LiquidationStream consumes the '!forceOrder@arr' stream, filters it by symbols
+// and delivers the events into a buffered channel
+type LiquidationStream struct {
+	symbols    map[string]struct{}
+	window     time.Duration
+	errHandler ErrHandler
+	events     chan *WsLiquidationOrderEvent
+	dropped    atomic.Int64
+	now        func() time.Time
+
+	mu      sync.Mutex
+	windows map[string]*rollingWindow
+	stopC   chan struct{}
+	doneC   chan struct{}
+}
+
+// NewLiquidationStream init LiquidationStream with the size of the delivery buffer.
+// Events of all symbols are delivered if no symbols are given.
+func NewLiquidationStream(bufferSize int, symbols ...string) *LiquidationStream {
+	l := &LiquidationStream{
+		events:  make(chan *WsLiquidationOrderEvent, bufferSize),
+		windows: make(map[string]*rollingWindow),
+		now:     time.Now,
+	}
+	if len(symbols) > 0 {
+		l.symbols = make(map[string]struct{}, len(symbols))
+		for _, symbol := range symbols {
+			l.symbols[symbol] = struct{}{}
+		}
+	}
+	return l
+}
+
+// Window enables rolling-window aggregation of liquidation notional
+func (l *LiquidationStream) Window(window time.Duration) *LiquidationStream {
+	l.window = window
+	return l
+}
+
+// ErrHandler set handler for stream errors
+func (l *LiquidationStream) ErrHandler(errHandler ErrHandler) *LiquidationStream {
+	l.errHandler = errHandler
+	return l
+}
+
+// Start subscribes '!forceOrder@arr' stream
+func (l *LiquidationStream) Start() error {
+	doneC, stopC, err := WsAllLiquidationOrderServe(l.handleEvent, l.handleError)
+	if err != nil {
+		return err
+	}
+
+	l.mu.Lock()
+	l.doneC, l.stopC = doneC, stopC
+	l.mu.Unlock()
+	return nil
+}
+
+// Stop closes the stream
+func (l *LiquidationStream) Stop() {
+	l.mu.Lock()
+	doneC, stopC := l.doneC, l.stopC
+	l.doneC, l.stopC = nil, nil
+	l.mu.Unlock()
+
+	if stopC == nil {
+		return
+	}
+	close(stopC)
+	<-doneC
+}
+
+// Events returns channel of filtered liquidation events
+func (l *LiquidationStream) Events() <-chan *WsLiquidationOrderEvent {
+	return l.events
+}
+
+// Dropped returns number of events dropped because the buffer was full
+func (l *LiquidationStream) Dropped() int64 {
+	return l.dropped.Load()
+}
+
+// Stats returns aggregated liquidations of symbol within the window
+func (l *LiquidationStream) Stats(symbol string) LiquidationStats {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	stats := LiquidationStats{Symbol: symbol, Window: l.window}
+	w, ok := l.windows[symbol]
+	if !ok {
+		return stats
+	}
+	sums, count := w.snapshot(l.now())
+	stats.Count = count
+	stats.BuyNotional = sums[0]
+	stats.SellNotional = sums[1]
+	return stats
+}
+
+// AllStats returns aggregated liquidations of every symbol seen within the window
+func (l *LiquidationStream) AllStats() []LiquidationStats {
+	l.mu.Lock()
+	symbols := make([]string, 0, len(l.windows))
+	for symbol := range l.windows {
+		symbols = append(symbols, symbol)
+	}
+	l.mu.Unlock()
+
+	res := make([]LiquidationStats, 0, len(symbols))
+	for _, symbol := range symbols {
+		if stats := l.Stats(symbol); stats.Count > 0 {
+			res = append(res, stats)
+		}
+	}
+	return res
+}
+
+func (l *LiquidationStream) handleEvent(event *WsLiquidationOrderEvent) {
+	order := event.LiquidationOrder
+	if l.symbols != nil {
+		if _, ok := l.symbols[order.Symbol]; !ok {
+			return
+		}
+	}
+
+	if l.window > 0 {
+		if err := l.aggregate(&order); err != nil {
+			l.handleError(err)
+		}
+	}
+
+	select {
+	case l.events <- event:
+	default:
+		l.dropped.Add(1)
+	}
+}
+
+func (l *LiquidationStream) aggregate(order *WsLiquidationOrder) error {
+	price := order.AvgPrice
+	if price == "" || price == "0" {
+		price = order.Price
+	}
+	p, err := strconv.ParseFloat(price, 64)
+	if err != nil {
+		return err
+	}
+	q, err := strconv.ParseFloat(order.AccumulatedFilledQty, 64)
+	if err != nil {
+		return err
+	}
+
+	notional := p * q
+	values := []float64{0, notional}
+	if order.Side == SideTypeBuy {
+		values = []float64{notional, 0}
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	w, ok := l.windows[order.Symbol]
+	if !ok {
+		w = newRollingWindow(l.window, 2)
+		l.windows[order.Symbol] = w
+	}
+	w.add(l.now(), values...)
+	return nil
+}
+
+func (l *LiquidationStream) handleError(err error) {
+	if l.errHandler != nil {
+		l.errHandler(err)
+	}
+}
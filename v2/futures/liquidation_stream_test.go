@@ -0,0 +1,69 @@
+package futures
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type liquidationStreamTestSuite struct {
+	baseWsTestSuite
+}
+
+func TestLiquidationStream(t *testing.T) {
+	suite.Run(t, new(liquidationStreamTestSuite))
+}
+
+func (s *liquidationStreamTestSuite) TestFilterAndBuffer() {
+	s.mockWsServeMessages(
+		[]byte(`{"e":"forceOrder","E":1568014460893,"o":{"s":"BTCUSDT","S":"SELL","o":"LIMIT","f":"IOC","q":"0.014","p":"9910","ap":"9910","X":"FILLED","l":"0.014","z":"0.014","T":1568014460893}}`),
+		[]byte(`{"e":"forceOrder","E":1568014460894,"o":{"s":"ETHUSDT","S":"BUY","o":"LIMIT","f":"IOC","q":"1","p":"200","ap":"200","X":"FILLED","l":"1","z":"1","T":1568014460894}}`),
+		[]byte(`{"e":"forceOrder","E":1568014460895,"o":{"s":"BTCUSDT","S":"BUY","o":"LIMIT","f":"IOC","q":"0.1","p":"10000","ap":"10000","X":"FILLED","l":"0.1","z":"0.1","T":1568014460895}}`),
+	)
+
+	l := NewLiquidationStream(1, "BTCUSDT")
+	s.r().NoError(l.Start())
+	defer l.Stop()
+
+	s.r().Equal("wss://fstream.binance.com/ws/!forceOrder@arr", s.endpoint)
+
+	event := <-l.Events()
+	s.r().Equal("BTCUSDT", event.LiquidationOrder.Symbol)
+	s.r().Equal(int64(1568014460893), event.Time)
+	s.r().Equal(int64(1), l.Dropped())
+	s.r().Equal(LiquidationStats{Symbol: "BTCUSDT"}, l.Stats("BTCUSDT"))
+}
+
+func (s *liquidationStreamTestSuite) TestAggregate() {
+	now := time.Unix(1000, 0)
+	l := NewLiquidationStream(10).Window(time.Minute)
+	l.now = func() time.Time { return now }
+
+	order := func(symbol string, side SideType, price, qty string) *WsLiquidationOrderEvent {
+		return &WsLiquidationOrderEvent{LiquidationOrder: WsLiquidationOrder{
+			Symbol: symbol, Side: side, AvgPrice: price, AccumulatedFilledQty: qty,
+		}}
+	}
+
+	l.handleEvent(order("BTCUSDT", SideTypeSell, "10000", "1"))
+	now = now.Add(30 * time.Second)
+	l.handleEvent(order("BTCUSDT", SideTypeBuy, "10000", "0.5"))
+	l.handleEvent(order("ETHUSDT", SideTypeBuy, "200", "2"))
+
+	stats := l.Stats("BTCUSDT")
+	s.r().Equal(2, stats.Count)
+	s.r().Equal(5000.0, stats.BuyNotional)
+	s.r().Equal(10000.0, stats.SellNotional)
+	s.r().Equal(15000.0, stats.NotionalPerMinute())
+	s.r().Len(l.AllStats(), 2)
+
+	// first liquidation leaves the window
+	now = now.Add(31 * time.Second)
+	stats = l.Stats("BTCUSDT")
+	s.r().Equal(1, stats.Count)
+	s.r().Equal(5000.0, stats.Notional())
+
+	now = now.Add(time.Minute)
+	s.r().Len(l.AllStats(), 0)
+}
@@ -0,0 +1,189 @@
+package futures
+
+import (
+	"context"
+	"math"
+	"sort"
+	"sync"
+	"time"
+)
+
+// MarginStatus define margin state of the account
+type MarginStatus struct {
+	MaintMargin   float64
+	MarginBalance float64
+	// MarginRatio is MaintMargin divided by MarginBalance, the account is liquidated at 1. It is
+	// +Inf if MarginBalance is not positive while margin is held or the balance is negative.
+	MarginRatio float64
+	UpdateTime  int64
+}
+
+// MarginHandler handle a threshold crossing of MarginStatus
+type MarginHandler func(status MarginStatus)
+
+// DeleverageAction reduces account risk when margin ratio crossed a threshold, e.g. by
+// reducing positions, returned error goes to the watchdog error handler
+type DeleverageAction func(ctx context.Context, status MarginStatus) error
+
+type marginThreshold struct {
+	ratio   float64
+	handler MarginHandler
+	action  DeleverageAction
+	above   bool
+}
+
+// MarginWatchdog polls account margin ratio and fires handlers registered for thresholds
+// the ratio rises to. A handler fires again only after the ratio went back below its threshold.
+type MarginWatchdog struct {
+	c          *Client
+	interval   time.Duration
+	errHandler ErrHandler
+
+	mu         sync.Mutex
+	status     *MarginStatus
+	thresholds []*marginThreshold
+	stopC      chan struct{}
+	doneC      chan struct{}
+}
+
+// NewMarginWatchdog init MarginWatchdog polling every interval
+func NewMarginWatchdog(c *Client, interval time.Duration, errHandler ErrHandler) *MarginWatchdog {
+	return &MarginWatchdog{
+		c:          c,
+		interval:   interval,
+		errHandler: errHandler,
+	}
+}
+
+// OnThreshold registers handler fired when margin ratio rises to ratio or above, e.g. 0.8 for a warning
+func (w *MarginWatchdog) OnThreshold(ratio float64, handler MarginHandler) {
+	w.addThreshold(&marginThreshold{ratio: ratio, handler: handler})
+}
+
+// OnThresholdDeleverage registers action invoked when margin ratio rises to ratio or above
+func (w *MarginWatchdog) OnThresholdDeleverage(ratio float64, action DeleverageAction) {
+	w.addThreshold(&marginThreshold{ratio: ratio, action: action})
+}
+
+func (w *MarginWatchdog) addThreshold(t *marginThreshold) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	w.thresholds = append(w.thresholds, t)
+	sort.SliceStable(w.thresholds, func(i, j int) bool {
+		return w.thresholds[i].ratio < w.thresholds[j].ratio
+	})
+}
+
+// Status returns the last polled margin status
+func (w *MarginWatchdog) Status() (MarginStatus, bool) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if w.status == nil {
+		return MarginStatus{}, false
+	}
+	return *w.status, true
+}
+
+// Start polls margin status once and keeps polling in background until Stop is called
+func (w *MarginWatchdog) Start(ctx context.Context) error {
+	if err := w.Check(ctx); err != nil {
+		return err
+	}
+
+	w.mu.Lock()
+	if w.stopC != nil {
+		w.mu.Unlock()
+		return nil
+	}
+	w.stopC = make(chan struct{})
+	w.doneC = make(chan struct{})
+	stopC, doneC := w.stopC, w.doneC
+	w.mu.Unlock()
+
+	go func() {
+		defer close(doneC)
+		ticker := time.NewTicker(w.interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-stopC:
+				return
+			case <-ticker.C:
+				ctx, cancel := context.WithTimeout(context.Background(), w.interval)
+				if err := w.Check(ctx); err != nil {
+					w.handleError(err)
+				}
+				cancel()
+			}
+		}
+	}()
+	return nil
+}
+
+// Stop stops polling
+func (w *MarginWatchdog) Stop() {
+	w.mu.Lock()
+	stopC, doneC := w.stopC, w.doneC
+	w.stopC, w.doneC = nil, nil
+	w.mu.Unlock()
+
+	if stopC == nil {
+		return
+	}
+	close(stopC)
+	<-doneC
+}
+
+// Check polls margin status and fires handlers of crossed thresholds, lowest threshold first
+func (w *MarginWatchdog) Check(ctx context.Context) error {
+	account, err := w.c.NewGetAccountService().Do(ctx)
+	if err != nil {
+		return err
+	}
+	status := MarginStatus{UpdateTime: account.UpdateTime}
+	if status.MaintMargin, err = parseOptionalFloat(account.TotalMaintMargin); err != nil {
+		return err
+	}
+	if status.MarginBalance, err = parseOptionalFloat(account.TotalMarginBalance); err != nil {
+		return err
+	}
+	switch {
+	case status.MarginBalance > 0:
+		status.MarginRatio = status.MaintMargin / status.MarginBalance
+	case status.MarginBalance < 0 || status.MaintMargin > 0:
+		// nothing is left to cover the margin, all thresholds are crossed
+		status.MarginRatio = math.Inf(1)
+	}
+
+	var fired []*marginThreshold
+	w.mu.Lock()
+	w.status = &status
+	for _, t := range w.thresholds {
+		above := status.MarginRatio >= t.ratio
+		if above && !t.above {
+			fired = append(fired, t)
+		}
+		t.above = above
+	}
+	w.mu.Unlock()
+
+	for _, t := range fired {
+		if t.handler != nil {
+			t.handler(status)
+		}
+		if t.action != nil {
+			if err := t.action(ctx, status); err != nil {
+				w.handleError(err)
+			}
+		}
+	}
+	return nil
+}
+
+func (w *MarginWatchdog) handleError(err error) {
+	if w.errHandler != nil {
+		w.errHandler(err)
+	}
+}
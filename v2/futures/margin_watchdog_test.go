@@ -0,0 +1,95 @@
+package futures
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMarginWatchdog(t *testing.T) {
+	assert := assert.New(t)
+
+	maintMargin := "100"
+	c := NewClient("apiKey", "secretKey")
+	c.do = func(req *http.Request) (*http.Response, error) {
+		data := fmt.Sprintf(`{"totalMaintMargin":"%s","totalMarginBalance":"1000","updateTime":1728972000000}`, maintMargin)
+		return newHTTPResponse([]byte(data), http.StatusOK), nil
+	}
+
+	var (
+		warnings    []float64
+		deleverages []float64
+		errs        []error
+	)
+	w := NewMarginWatchdog(c, time.Hour, func(err error) {
+		errs = append(errs, err)
+	})
+	w.OnThreshold(0.5, func(status MarginStatus) {
+		warnings = append(warnings, status.MarginRatio)
+	})
+	w.OnThresholdDeleverage(0.8, func(ctx context.Context, status MarginStatus) error {
+		deleverages = append(deleverages, status.MarginRatio)
+		return errors.New("reduce failed")
+	})
+
+	assert.NoError(w.Start(newContext()))
+	defer w.Stop()
+	status, ok := w.Status()
+	assert.True(ok)
+	assert.Equal(MarginStatus{MaintMargin: 100, MarginBalance: 1000, MarginRatio: 0.1, UpdateTime: 1728972000000}, status)
+	assert.Empty(warnings)
+
+	maintMargin = "600"
+	assert.NoError(w.Check(newContext()))
+	assert.Equal([]float64{0.6}, warnings)
+	assert.Empty(deleverages)
+
+	maintMargin = "900"
+	assert.NoError(w.Check(newContext()))
+	assert.Equal([]float64{0.6}, warnings, "warning fires again only after going back below")
+	assert.Equal([]float64{0.9}, deleverages)
+	assert.Len(errs, 1)
+
+	maintMargin = "100"
+	assert.NoError(w.Check(newContext()))
+	maintMargin = "550"
+	assert.NoError(w.Check(newContext()))
+	assert.Equal([]float64{0.6, 0.55}, warnings)
+}
+
+func TestMarginWatchdogNoMarginBalance(t *testing.T) {
+	assert := assert.New(t)
+
+	maintMargin, marginBalance := "100", "-5"
+	c := NewClient("apiKey", "secretKey")
+	c.do = func(req *http.Request) (*http.Response, error) {
+		data := fmt.Sprintf(`{"totalMaintMargin":"%s","totalMarginBalance":"%s"}`, maintMargin, marginBalance)
+		return newHTTPResponse([]byte(data), http.StatusOK), nil
+	}
+	var warnings []float64
+	w := NewMarginWatchdog(c, time.Hour, nil)
+	w.OnThreshold(0.8, func(status MarginStatus) {
+		warnings = append(warnings, status.MarginRatio)
+	})
+
+	// a balance wiped out is the worst ratio, not the best
+	assert.NoError(w.Check(newContext()))
+	assert.Equal([]float64{math.Inf(1)}, warnings)
+
+	marginBalance = "0"
+	assert.NoError(w.Check(newContext()))
+	status, _ := w.Status()
+	assert.True(math.IsInf(status.MarginRatio, 1))
+
+	// an empty account holds no margin
+	maintMargin = "0"
+	assert.NoError(w.Check(newContext()))
+	status, _ = w.Status()
+	assert.Equal(0.0, status.MarginRatio)
+}
@@ -2,7 +2,6 @@ package futures
 
 import (
 	"context"
-	"encoding/json"
 	"net/http"
 
 	"github.com/adshao/go-binance/v2/common"
@@ -36,7 +35,7 @@ func (s *PremiumIndexService) Do(ctx context.Context, opts ...RequestOption) (re
 		return []*PremiumIndex{}, err
 	}
 	res = make([]*PremiumIndex, 0)
-	err = json.Unmarshal(data, &res)
+	err = unmarshalResponse(data, &res)
 	if err != nil {
 		return []*PremiumIndex{}, err
 	}
@@ -107,7 +106,7 @@ func (s *FundingRateService) Do(ctx context.Context, opts ...RequestOption) (res
 		return []*FundingRate{}, err
 	}
 	res = make([]*FundingRate, 0)
-	err = json.Unmarshal(data, &res)
+	err = unmarshalResponse(data, &res)
 	if err != nil {
 		return []*FundingRate{}, err
 	}
@@ -155,7 +154,7 @@ func (s *GetLeverageBracketService) Do(ctx context.Context, opts ...RequestOptio
 	}
 
 	res = make([]*LeverageBracket, 0)
-	err = json.Unmarshal(data, &res)
+	err = unmarshalResponse(data, &res)
 	if err != nil {
 		return []*LeverageBracket{}, err
 	}
@@ -0,0 +1,238 @@
+package futures
+
+import (
+	"math"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// MarkPriceInfo define latest mark price and funding state of a symbol
+type MarkPriceInfo struct {
+	Symbol               string
+	MarkPrice            float64
+	IndexPrice           float64
+	EstimatedSettlePrice float64
+	FundingRate          float64
+	NextFundingTime      int64
+	Time                 int64
+}
+
+// Premium returns relative difference between mark price and index price
+func (i MarkPriceInfo) Premium() float64 {
+	if i.IndexPrice == 0 {
+		return 0
+	}
+	return (i.MarkPrice - i.IndexPrice) / i.IndexPrice
+}
+
+// MarkPriceThresholdHandler handle a threshold crossing of MarkPriceInfo
+type MarkPriceThresholdHandler func(info MarkPriceInfo)
+
+type markPriceThreshold struct {
+	symbol    string
+	threshold float64
+	value     func(info MarkPriceInfo) float64
+	handler   MarkPriceThresholdHandler
+	above     map[string]bool
+}
+
+// MarkPriceAggregator maintains mark price, index price and funding rate of all symbols
+// from the '!markPrice@arr' stream
+type MarkPriceAggregator struct {
+	rate       time.Duration
+	errHandler ErrHandler
+
+	mu         sync.RWMutex
+	prices     map[string]MarkPriceInfo
+	thresholds []*markPriceThreshold
+	stopC      chan struct{}
+	doneC      chan struct{}
+}
+
+// NewMarkPriceAggregator init MarkPriceAggregator, rate is the stream update speed (1s or 3s)
+func NewMarkPriceAggregator(rate time.Duration, errHandler ErrHandler) *MarkPriceAggregator {
+	return &MarkPriceAggregator{
+		rate:       rate,
+		errHandler: errHandler,
+		prices:     make(map[string]MarkPriceInfo),
+	}
+}
+
+// Start subscribes '!markPrice@arr' stream
+func (a *MarkPriceAggregator) Start() error {
+	doneC, stopC, err := WsAllMarkPriceServeWithRate(a.rate, a.handleEvent, a.handleError)
+	if err != nil {
+		return err
+	}
+
+	a.mu.Lock()
+	a.doneC, a.stopC = doneC, stopC
+	a.mu.Unlock()
+	return nil
+}
+
+// Stop closes the stream
+func (a *MarkPriceAggregator) Stop() {
+	a.mu.Lock()
+	doneC, stopC := a.doneC, a.stopC
+	a.doneC, a.stopC = nil, nil
+	a.mu.Unlock()
+
+	if stopC == nil {
+		return
+	}
+	close(stopC)
+	<-doneC
+}
+
+// Done returns channel closed when the stream is closed
+func (a *MarkPriceAggregator) Done() <-chan struct{} {
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+
+	return a.doneC
+}
+
+// Get returns latest info of symbol
+func (a *MarkPriceAggregator) Get(symbol string) (MarkPriceInfo, bool) {
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+
+	info, ok := a.prices[symbol]
+	return info, ok
+}
+
+// GetAll returns latest info of all symbols
+func (a *MarkPriceAggregator) GetAll() map[string]MarkPriceInfo {
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+
+	res := make(map[string]MarkPriceInfo, len(a.prices))
+	for symbol, info := range a.prices {
+		res[symbol] = info
+	}
+	return res
+}
+
+// MarkPrice returns latest mark price of symbol
+func (a *MarkPriceAggregator) MarkPrice(symbol string) (float64, bool) {
+	info, ok := a.Get(symbol)
+	return info.MarkPrice, ok
+}
+
+// IndexPrice returns latest index price of symbol
+func (a *MarkPriceAggregator) IndexPrice(symbol string) (float64, bool) {
+	info, ok := a.Get(symbol)
+	return info.IndexPrice, ok
+}
+
+// FundingRate returns latest funding rate of symbol
+func (a *MarkPriceAggregator) FundingRate(symbol string) (float64, bool) {
+	info, ok := a.Get(symbol)
+	return info.FundingRate, ok
+}
+
+// NextFundingTime returns next funding time of symbol in milliseconds
+func (a *MarkPriceAggregator) NextFundingTime(symbol string) (int64, bool) {
+	info, ok := a.Get(symbol)
+	return info.NextFundingTime, ok
+}
+
+// OnFundingRateThreshold registers handler fired when absolute funding rate of symbol rises to threshold or above.
+// Empty symbol matches all symbols. Handler fires again only after the rate went back below threshold.
+func (a *MarkPriceAggregator) OnFundingRateThreshold(symbol string, threshold float64, handler MarkPriceThresholdHandler) {
+	a.addThreshold(symbol, threshold, func(info MarkPriceInfo) float64 {
+		return math.Abs(info.FundingRate)
+	}, handler)
+}
+
+// OnPremiumThreshold registers handler fired when absolute premium of mark price over index price
+// of symbol rises to threshold or above. Empty symbol matches all symbols.
+func (a *MarkPriceAggregator) OnPremiumThreshold(symbol string, threshold float64, handler MarkPriceThresholdHandler) {
+	a.addThreshold(symbol, threshold, func(info MarkPriceInfo) float64 {
+		return math.Abs(info.Premium())
+	}, handler)
+}
+
+func (a *MarkPriceAggregator) addThreshold(symbol string, threshold float64, value func(info MarkPriceInfo) float64, handler MarkPriceThresholdHandler) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	a.thresholds = append(a.thresholds, &markPriceThreshold{
+		symbol:    symbol,
+		threshold: threshold,
+		value:     value,
+		handler:   handler,
+		above:     make(map[string]bool),
+	})
+}
+
+func (a *MarkPriceAggregator) handleEvent(event WsAllMarkPriceEvent) {
+	infos := make([]MarkPriceInfo, 0, len(event))
+	for _, e := range event {
+		info, err := newMarkPriceInfo(e)
+		if err != nil {
+			a.handleError(err)
+			continue
+		}
+		infos = append(infos, info)
+	}
+
+	var fired []func()
+	a.mu.Lock()
+	for _, info := range infos {
+		a.prices[info.Symbol] = info
+		for _, t := range a.thresholds {
+			if t.symbol != "" && t.symbol != info.Symbol {
+				continue
+			}
+			above := t.value(info) >= t.threshold
+			if above && !t.above[info.Symbol] {
+				handler, info := t.handler, info
+				fired = append(fired, func() { handler(info) })
+			}
+			t.above[info.Symbol] = above
+		}
+	}
+	a.mu.Unlock()
+
+	for _, f := range fired {
+		f()
+	}
+}
+
+func (a *MarkPriceAggregator) handleError(err error) {
+	if a.errHandler != nil {
+		a.errHandler(err)
+	}
+}
+
+func newMarkPriceInfo(e *WsMarkPriceEvent) (info MarkPriceInfo, err error) {
+	info = MarkPriceInfo{
+		Symbol:          e.Symbol,
+		NextFundingTime: e.NextFundingTime,
+		Time:            e.Time,
+	}
+	if info.MarkPrice, err = parseOptionalFloat(e.MarkPrice); err != nil {
+		return info, err
+	}
+	if info.IndexPrice, err = parseOptionalFloat(e.IndexPrice); err != nil {
+		return info, err
+	}
+	if info.EstimatedSettlePrice, err = parseOptionalFloat(e.EstimatedSettlePrice); err != nil {
+		return info, err
+	}
+	if info.FundingRate, err = parseOptionalFloat(e.FundingRate); err != nil {
+		return info, err
+	}
+	return info, nil
+}
+
+// parseOptionalFloat parses s, empty string is parsed as zero
+func parseOptionalFloat(s string) (float64, error) {
+	if s == "" {
+		return 0, nil
+	}
+	return strconv.ParseFloat(s, 64)
+}
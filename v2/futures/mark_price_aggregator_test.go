@@ -0,0 +1,94 @@
+package futures
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type markPriceAggregatorTestSuite struct {
+	baseWsTestSuite
+}
+
+func TestMarkPriceAggregator(t *testing.T) {
+	suite.Run(t, new(markPriceAggregatorTestSuite))
+}
+
+func (s *markPriceAggregatorTestSuite) TestAggregate() {
+	s.mockWsServeMessages([]byte(`[
+		{"e":"markPriceUpdate","E":1562305380000,"s":"BTCUSDT","p":"11185.87786614","i":"11184.87786614","P":"11784.62659091","r":"0.00030000","T":1562306400000},
+		{"e":"markPriceUpdate","E":1562305380000,"s":"ETHUSDT","p":"200.5","i":"200","P":"","r":"-0.00010000","T":1562306400000}
+	]`))
+
+	a := NewMarkPriceAggregator(time.Second, nil)
+	s.r().NoError(a.Start())
+	defer a.Stop()
+
+	s.r().Equal("wss://fstream.binance.com/ws/!markPrice@arr@1s", s.endpoint)
+
+	info, ok := a.Get("BTCUSDT")
+	s.r().True(ok)
+	s.r().Equal(MarkPriceInfo{
+		Symbol:               "BTCUSDT",
+		MarkPrice:            11185.87786614,
+		IndexPrice:           11184.87786614,
+		EstimatedSettlePrice: 11784.62659091,
+		FundingRate:          0.0003,
+		NextFundingTime:      1562306400000,
+		Time:                 1562305380000,
+	}, info)
+
+	rate, ok := a.FundingRate("ETHUSDT")
+	s.r().True(ok)
+	s.r().Equal(-0.0001, rate)
+	s.r().InDelta(0.0025, a.GetAll()["ETHUSDT"].Premium(), 1e-12)
+
+	_, ok = a.MarkPrice("BNBUSDT")
+	s.r().False(ok)
+}
+
+func (s *markPriceAggregatorTestSuite) TestInvalidRate() {
+	a := NewMarkPriceAggregator(2*time.Second, nil)
+	s.r().Error(a.Start())
+}
+
+func (s *markPriceAggregatorTestSuite) TestFundingRateThreshold() {
+	a := NewMarkPriceAggregator(time.Second, nil)
+
+	var fired []MarkPriceInfo
+	a.OnFundingRateThreshold("BTCUSDT", 0.001, func(info MarkPriceInfo) {
+		fired = append(fired, info)
+	})
+
+	event := func(rate string) WsAllMarkPriceEvent {
+		return WsAllMarkPriceEvent{{Symbol: "BTCUSDT", MarkPrice: "100", IndexPrice: "100", FundingRate: rate}}
+	}
+	a.handleEvent(event("0.0005"))
+	s.r().Len(fired, 0)
+	a.handleEvent(event("-0.0015"))
+	s.r().Len(fired, 1)
+	s.r().Equal(-0.0015, fired[0].FundingRate)
+	// still above threshold, not fired again
+	a.handleEvent(event("0.002"))
+	s.r().Len(fired, 1)
+	a.handleEvent(event("0.0001"))
+	a.handleEvent(event("0.001"))
+	s.r().Len(fired, 2)
+}
+
+func (s *markPriceAggregatorTestSuite) TestParseError() {
+	var errs []error
+	a := NewMarkPriceAggregator(time.Second, func(err error) {
+		errs = append(errs, err)
+	})
+	a.handleEvent(WsAllMarkPriceEvent{
+		{Symbol: "BTCUSDT", MarkPrice: "invalid"},
+		{Symbol: "ETHUSDT", MarkPrice: "1"},
+	})
+	s.r().Len(errs, 1)
+	_, ok := a.Get("BTCUSDT")
+	s.r().False(ok)
+	_, ok = a.Get("ETHUSDT")
+	s.r().True(ok)
+}
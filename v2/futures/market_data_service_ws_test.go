@@ -0,0 +1,125 @@
+package futures
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+
+	"github.com/adshao/go-binance/v2/common"
+)
+
+type marketDataWsServiceTestSuite struct {
+	baseWsApiTestSuite
+}
+
+func TestMarketDataWsService(t *testing.T) {
+	suite.Run(t, new(marketDataWsServiceTestSuite))
+}
+
+func (s *marketDataWsServiceTestSuite) TestDepth() {
+	s.respond(WsApiMethodDepth, `{
+		"lastUpdateId": 1027024,
+		"E": 1589436922972,
+		"T": 1589436922959,
+		"bids": [["4.00000000", "431.00000000"]],
+		"asks": [["4.00000200", "12.00000000"]]
+	}`)
+
+	res, err := s.wsClient.NewDepthWsService().Do(newContext(), NewDepthWsRequest().Symbol("BTCUSDT").Limit(5))
+	r := s.r()
+	r.NoError(err)
+	r.Equal(&DepthResponse{
+		LastUpdateID: 1027024,
+		Time:         1589436922972,
+		TradeTime:    1589436922959,
+		Bids:         []Bid{{Price: "4.00000000", Quantity: "431.00000000"}},
+		Asks:         []Ask{{Price: "4.00000200", Quantity: "12.00000000"}},
+	}, res)
+
+	req := s.lastRequest()
+	r.Equal(WsApiMethodDepth, req.Method)
+	r.Equal(params{"symbol": "BTCUSDT", "limit": float64(5)}, req.Params)
+}
+
+func (s *marketDataWsServiceTestSuite) TestTickerPrice() {
+	s.respond(WsApiMethodTickerPrice, `{"symbol":"BTCUSDT","price":"6000.01","time":1589437530011}`)
+
+	res, err := s.wsClient.NewTickerPriceWsService().Do(newContext(), NewTickerPriceWsRequest().Symbol("BTCUSDT"))
+	r := s.r()
+	r.NoError(err)
+	r.Equal([]*SymbolPrice{{Symbol: "BTCUSDT", Price: "6000.01", Time: 1589437530011}}, res)
+	r.Equal(params{"symbol": "BTCUSDT"}, s.lastRequest().Params)
+
+	s.respond(WsApiMethodTickerPrice, `[{"symbol":"BTCUSDT","price":"6000.01"},{"symbol":"ETHUSDT","price":"200.01"}]`)
+	res, err = s.wsClient.NewTickerPriceWsService().Do(newContext(), NewTickerPriceWsRequest())
+	r.NoError(err)
+	r.Len(res, 2)
+	r.Empty(s.lastRequest().Params)
+}
+
+func (s *marketDataWsServiceTestSuite) TestTickerPriceStrictDecoding() {
+	StrictDecoding = true
+	defer func() { StrictDecoding = false }()
+	r := s.r()
+
+	s.mu.Lock()
+	s.responses[WsApiMethodTickerPrice] = `{"id":"{{id}}","status":200,
+		"result":{"symbol":"BTCUSDT","price":"6000.01","time":1589437530011},
+		"rateLimits":[{"rateLimitType":"REQUEST_WEIGHT","interval":"MINUTE","intervalNum":1,"limit":2400,"count":2}]}`
+	s.mu.Unlock()
+	res, err := s.wsClient.NewTickerPriceWsService().Do(newContext(), NewTickerPriceWsRequest().Symbol("BTCUSDT"))
+	r.NoError(err)
+	r.Len(res, 1)
+
+	s.respond(WsApiMethodTickerPrice, `{"symbol":"BTCUSDT","price":"6000.01"}`)
+	_, err = s.wsClient.NewTickerPriceWsService().Do(newContext(), NewTickerPriceWsRequest().Symbol("BTCUSDT"))
+	r.Equal(&common.SchemaError{Type: "futures.SymbolPrice", Field: "[0].time", Reason: common.SchemaErrorMissingField}, err)
+
+	s.respond(WsApiMethodTickerPrice, `{"symbol":"BTCUSDT","price":"6000.01","time":1589437530011,"markPrice":"6000"}`)
+	_, err = s.wsClient.NewTickerPriceWsService().Do(newContext(), NewTickerPriceWsRequest().Symbol("BTCUSDT"))
+	r.Equal(&common.SchemaError{Type: "futures.SymbolPrice", Field: "[0].markPrice", Reason: common.SchemaErrorUnknownField}, err)
+}
+
+func (s *marketDataWsServiceTestSuite) TestTickerBook() {
+	s.respond(WsApiMethodTickerBook, `[{
+		"lastUpdateId": 1027024,
+		"symbol": "BTCUSDT",
+		"bidPrice": "4.00000000",
+		"bidQty": "431.00000000",
+		"askPrice": "4.00000200",
+		"askQty": "9.00000000",
+		"time": 1589437530011
+	}]`)
+
+	res, err := s.wsClient.NewTickerBookWsService().Do(newContext(), NewTickerBookWsRequest())
+	r := s.r()
+	r.NoError(err)
+	r.Len(res, 1)
+	r.Equal(&BookTicker{
+		Symbol:      "BTCUSDT",
+		BidPrice:    "4.00000000",
+		BidQuantity: "431.00000000",
+		AskPrice:    "4.00000200",
+		AskQuantity: "9.00000000",
+		Time:        1589437530011,
+	}, res[0])
+}
+
+func (s *marketDataWsServiceTestSuite) TestError() {
+	_, err := s.wsClient.NewTickerBookWsService().Do(newContext(), NewTickerBookWsRequest())
+	s.r().Equal(&common.APIError{Code: -1102, Message: "unexpected method"}, err)
+}
+
+func (s *marketDataWsServiceTestSuite) TestSignedRequest() {
+	s.respond(WsApiMethodOrderCancel, `{"orderId":1,"symbol":"BTCUSDT","status":"CANCELED"}`)
+
+	res, err := s.wsClient.NewOrderCancelWsService().Do(newContext(), NewCancelOrderRequest().Symbol("BTCUSDT").OrderID(1))
+	r := s.r()
+	r.NoError(err)
+	r.Equal(int64(1), res.OrderID)
+
+	req := s.lastRequest()
+	r.Equal(s.apiKey, req.Params[apiKey])
+	r.NotEmpty(req.Params[timestampKey])
+	r.NotEmpty(req.Params[signatureKey])
+}
@@ -0,0 +1,239 @@
+package futures
+
+import (
+	"errors"
+	"sync"
+)
+
+var (
+	ErrAccountNotFound   = errors.New("multi account: account not found")
+	ErrAccountExists     = errors.New("multi account: account already exists")
+	ErrNoAccounts        = errors.New("multi account: no accounts")
+	ErrEmptyAccountLabel = errors.New("multi account: empty account label")
+	ErrManagerClosed     = errors.New("multi account: manager closed")
+)
+
+// AccountCredentials define API key pair of an account identified by Label
+type AccountCredentials struct {
+	Label     string
+	APIKey    string
+	SecretKey string
+}
+
+type managedAccount struct {
+	AccountCredentials
+	client   *Client
+	clientWs *ClientWs
+}
+
+// MultiAccountManager holds API key pairs of several accounts and lazily creates
+// REST and websocket API clients for them. Use Next* methods to spread requests
+// over accounts in round-robin order, or the label based ones to route explicitly.
+type MultiAccountManager struct {
+	mu       sync.Mutex
+	accounts []*managedAccount
+	byLabel  map[string]*managedAccount
+	next     int
+	closed   bool
+}
+
+// NewMultiAccountManager init MultiAccountManager with accounts
+func NewMultiAccountManager(accounts ...AccountCredentials) (*MultiAccountManager, error) {
+	m := &MultiAccountManager{
+		byLabel: make(map[string]*managedAccount),
+	}
+	for _, a := range accounts {
+		if err := m.Add(a); err != nil {
+			return nil, err
+		}
+	}
+	return m, nil
+}
+
+// Add registers account, labels must be unique
+func (m *MultiAccountManager) Add(account AccountCredentials) error {
+	if account.Label == "" {
+		return ErrEmptyAccountLabel
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if _, ok := m.byLabel[account.Label]; ok {
+		return ErrAccountExists
+	}
+	a := &managedAccount{AccountCredentials: account}
+	m.accounts = append(m.accounts, a)
+	m.byLabel[account.Label] = a
+	return nil
+}
+
+// Labels returns labels of all accounts in the order they were added
+func (m *MultiAccountManager) Labels() []string {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	res := make([]string, 0, len(m.accounts))
+	for _, a := range m.accounts {
+		res = append(res, a.Label)
+	}
+	return res
+}
+
+// Client returns REST client of account, created on first use
+func (m *MultiAccountManager) Client(label string) (*Client, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	a, ok := m.byLabel[label]
+	if !ok {
+		return nil, ErrAccountNotFound
+	}
+	return m.client(a), nil
+}
+
+// ClientWs returns websocket API client of account, the connection is established on first use
+func (m *MultiAccountManager) ClientWs(label string) (*ClientWs, error) {
+	m.mu.Lock()
+	a, ok := m.byLabel[label]
+	m.mu.Unlock()
+	if !ok {
+		return nil, ErrAccountNotFound
+	}
+	return m.clientWs(a)
+}
+
+// SetCredentials rotates API key pair of account, clients already created keep their
+// connections and sign subsequent requests with the new pair
+func (m *MultiAccountManager) SetCredentials(label, apiKey, secretKey string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	a, ok := m.byLabel[label]
+	if !ok {
+		return ErrAccountNotFound
+	}
+	a.APIKey, a.SecretKey = apiKey, secretKey
+	if a.client != nil {
+		a.client.SetCredentials(apiKey, secretKey)
+	}
+	if a.clientWs != nil {
+		a.clientWs.SetCredentials(apiKey, secretKey)
+	}
+	return nil
+}
+
+// NextLabel returns label of the next account in round-robin order
+func (m *MultiAccountManager) NextLabel() (string, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	a, err := m.nextAccount()
+	if err != nil {
+		return "", err
+	}
+	return a.Label, nil
+}
+
+// NextClient returns label and REST client of the next account in round-robin order
+func (m *MultiAccountManager) NextClient() (string, *Client, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	a, err := m.nextAccount()
+	if err != nil {
+		return "", nil, err
+	}
+	return a.Label, m.client(a), nil
+}
+
+// NextClientWs returns label and websocket API client of the next account in round-robin order
+func (m *MultiAccountManager) NextClientWs() (string, *ClientWs, error) {
+	m.mu.Lock()
+	a, err := m.nextAccount()
+	m.mu.Unlock()
+	if err != nil {
+		return "", nil, err
+	}
+	c, err := m.clientWs(a)
+	if err != nil {
+		return "", nil, err
+	}
+	return a.Label, c, nil
+}
+
+func (m *MultiAccountManager) nextAccount() (*managedAccount, error) {
+	if len(m.accounts) == 0 {
+		return nil, ErrNoAccounts
+	}
+	a := m.accounts[m.next%len(m.accounts)]
+	m.next++
+	return a, nil
+}
+
+func (m *MultiAccountManager) client(a *managedAccount) *Client {
+	if a.client == nil {
+		a.client = NewClient(a.APIKey, a.SecretKey)
+	}
+	return a.client
+}
+
+// Close closes the websocket API clients created by the manager, they can't be created anymore
+// afterwards. REST clients are left usable.
+func (m *MultiAccountManager) Close() error {
+	m.mu.Lock()
+	m.closed = true
+	var clients []*ClientWs
+	for _, a := range m.accounts {
+		if a.clientWs != nil {
+			clients = append(clients, a.clientWs)
+			a.clientWs = nil
+		}
+	}
+	m.mu.Unlock()
+
+	var errs []error
+	for _, c := range clients {
+		errs = append(errs, c.Close())
+	}
+	return errors.Join(errs...)
+}
+
+// clientWs returns websocket API client of a, dialing it without holding the lock so other
+// accounts are not blocked meanwhile. If another caller installed a client first, the one
+// dialed is closed.
+func (m *MultiAccountManager) clientWs(a *managedAccount) (*ClientWs, error) {
+	m.mu.Lock()
+	c, closed := a.clientWs, m.closed
+	apiKey, secretKey := a.APIKey, a.SecretKey
+	m.mu.Unlock()
+	if closed {
+		return nil, ErrManagerClosed
+	}
+	if c != nil {
+		return c, nil
+	}
+
+	c, err := NewClientWs(apiKey, secretKey)
+	if err != nil {
+		return nil, err
+	}
+
+	m.mu.Lock()
+	switch {
+	case m.closed:
+		m.mu.Unlock()
+		c.Close()
+		return nil, ErrManagerClosed
+	case a.clientWs != nil:
+		installed := a.clientWs
+		m.mu.Unlock()
+		c.Close()
+		return installed, nil
+	}
+	// credentials may have been rotated while dialing
+	c.SetCredentials(a.APIKey, a.SecretKey)
+	a.clientWs = c
+	m.mu.Unlock()
+	return c, nil
+}
@@ -0,0 +1,157 @@
+package futures
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type multiAccountTestSuite struct {
+	baseWsApiTestSuite
+}
+
+func TestMultiAccountManager(t *testing.T) {
+	suite.Run(t, new(multiAccountTestSuite))
+}
+
+func (s *multiAccountTestSuite) TestAdd() {
+	r := s.r()
+	_, err := NewMultiAccountManager(AccountCredentials{APIKey: "k"})
+	r.ErrorIs(err, ErrEmptyAccountLabel)
+
+	m, err := NewMultiAccountManager(AccountCredentials{Label: "a", APIKey: "ka", SecretKey: "sa"})
+	r.NoError(err)
+	r.ErrorIs(m.Add(AccountCredentials{Label: "a"}), ErrAccountExists)
+	r.NoError(m.Add(AccountCredentials{Label: "b", APIKey: "kb", SecretKey: "sb"}))
+	r.Equal([]string{"a", "b"}, m.Labels())
+}
+
+func (s *multiAccountTestSuite) TestClient() {
+	r := s.r()
+	m, err := NewMultiAccountManager(
+		AccountCredentials{Label: "a", APIKey: "ka", SecretKey: "sa"},
+		AccountCredentials{Label: "b", APIKey: "kb", SecretKey: "sb"},
+	)
+	r.NoError(err)
+
+	c, err := m.Client("b")
+	r.NoError(err)
+	r.Equal("kb", c.APIKey)
+	r.Equal("sb", c.SecretKey)
+
+	again, err := m.Client("b")
+	r.NoError(err)
+	r.Same(c, again)
+
+	_, err = m.Client("c")
+	r.ErrorIs(err, ErrAccountNotFound)
+}
+
+func (s *multiAccountTestSuite) TestClientWs() {
+	r := s.r()
+	m, err := NewMultiAccountManager(AccountCredentials{Label: "a", APIKey: "ka", SecretKey: "sa"})
+	r.NoError(err)
+
+	c, err := m.ClientWs("a")
+	r.NoError(err)
+	r.Equal("ka", c.APIKey)
+
+	again, err := m.ClientWs("a")
+	r.NoError(err)
+	r.Same(c, again)
+
+	_, err = m.ClientWs("c")
+	r.ErrorIs(err, ErrAccountNotFound)
+}
+
+func (s *multiAccountTestSuite) TestRoundRobin() {
+	r := s.r()
+	m, err := NewMultiAccountManager()
+	r.NoError(err)
+	_, err = m.NextLabel()
+	r.ErrorIs(err, ErrNoAccounts)
+
+	r.NoError(m.Add(AccountCredentials{Label: "a", APIKey: "ka"}))
+	r.NoError(m.Add(AccountCredentials{Label: "b", APIKey: "kb"}))
+
+	labels := make([]string, 0)
+	for i := 0; i < 3; i++ {
+		label, c, err := m.NextClient()
+		r.NoError(err)
+		r.Equal("k"+label, c.APIKey)
+		labels = append(labels, label)
+	}
+	r.Equal([]string{"a", "b", "a"}, labels)
+
+	label, c, err := m.NextClientWs()
+	r.NoError(err)
+	r.Equal("b", label)
+	r.Equal("kb", c.APIKey)
+}
+
+func (s *multiAccountTestSuite) TestSetCredentials() {
+	r := s.r()
+	m, err := NewMultiAccountManager(AccountCredentials{Label: "a", APIKey: "ka", SecretKey: "sa"})
+	r.NoError(err)
+	c, err := m.Client("a")
+	r.NoError(err)
+	ws, err := m.ClientWs("a")
+	r.NoError(err)
+
+	r.NoError(m.SetCredentials("a", "ka2", "sa2"))
+	r.ErrorIs(m.SetCredentials("b", "kb", "sb"), ErrAccountNotFound)
+
+	req := &request{method: "GET", endpoint: "/fapi/v1/order", secType: secTypeSigned}
+	r.NoError(c.parseRequest(req))
+	r.Equal("ka2", req.header.Get("X-MBX-APIKEY"))
+
+	s.respond(WsApiMethodOrderCancel, `{"orderId": 1, "symbol": "BTCUSDT", "status": "CANCELED"}`)
+	_, err = ws.NewOrderCancelWsService().Do(newContext(), NewCancelOrderRequest().Symbol("BTCUSDT").OrderID(1))
+	r.NoError(err)
+	r.Equal("ka2", s.lastRequest().Params[apiKey])
+
+	again, err := m.ClientWs("a")
+	r.NoError(err)
+	r.Same(ws, again)
+}
+
+func (s *multiAccountTestSuite) TestClientWsConcurrent() {
+	r := s.r()
+	m, err := NewMultiAccountManager(AccountCredentials{Label: "a", APIKey: "ka", SecretKey: "sa"})
+	r.NoError(err)
+
+	clients := make([]*ClientWs, 4)
+	var wg sync.WaitGroup
+	for i := range clients {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			clients[i], _ = m.ClientWs("a")
+		}(i)
+	}
+	wg.Wait()
+	for _, c := range clients {
+		r.Same(clients[0], c)
+	}
+	r.NoError(m.Close())
+}
+
+func (s *multiAccountTestSuite) TestClose() {
+	r := s.r()
+	m, err := NewMultiAccountManager(AccountCredentials{Label: "a", APIKey: "ka", SecretKey: "sa"})
+	r.NoError(err)
+	ws, err := m.ClientWs("a")
+	r.NoError(err)
+
+	r.NoError(m.Close())
+	_, err = ws.NewTickerPriceWsService().Do(newContext(), NewTickerPriceWsRequest().Symbol("BTCUSDT"))
+	r.ErrorIs(err, ErrWsConnectionClosed)
+
+	_, err = m.ClientWs("a")
+	r.ErrorIs(err, ErrManagerClosed)
+	_, _, err = m.NextClientWs()
+	r.ErrorIs(err, ErrManagerClosed)
+	_, err = m.Client("a")
+	r.NoError(err)
+}
@@ -0,0 +1,353 @@
+package futures
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+)
+
+// TrackedOrder define live order maintained by OpenOrderTracker
+type TrackedOrder struct {
+	Symbol           string
+	OrderID          int64
+	ClientOrderID    string
+	Side             SideType
+	PositionSide     PositionSideType
+	Type             OrderType
+	Status           OrderStatusType
+	Price            float64
+	OrigQuantity     float64
+	ExecutedQuantity float64
+	// Time is the creation time and UpdateTime the time of the last change, both in milliseconds
+	Time       int64
+	UpdateTime int64
+}
+
+// OpenOrderTracker maintains the set of live orders of the account from placements passed to
+// Track/TrackCreated, ORDER_TRADE_UPDATE events passed to HandleUserDataEvent and periodic
+// 'openOrders' reconciliation, so cleanup logic can query stale or misplaced orders.
+type OpenOrderTracker struct {
+	c          *Client
+	interval   time.Duration
+	errHandler ErrHandler
+	queue      *QueueEstimator
+
+	mu     sync.RWMutex
+	orders map[int64]TrackedOrder
+	// closed keeps update time of orders closed since the last reconciliation, so a snapshot
+	// taken before they were closed doesn't bring them back
+	closed map[int64]int64
+	stopC  chan struct{}
+	doneC  chan struct{}
+}
+
+// NewOpenOrderTracker init OpenOrderTracker reconciling every interval
+func NewOpenOrderTracker(c *Client, interval time.Duration, errHandler ErrHandler) *OpenOrderTracker {
+	return &OpenOrderTracker{
+		c:          c,
+		interval:   interval,
+		errHandler: errHandler,
+		orders:     make(map[int64]TrackedOrder),
+		closed:     make(map[int64]int64),
+	}
+}
+
+// QueueEstimator set estimator following the live orders, call it before Start
+func (t *OpenOrderTracker) QueueEstimator(queue *QueueEstimator) *OpenOrderTracker {
+	t.queue = queue
+	return t
+}
+
+// Start seeds orders and starts periodic reconciliation in background
+func (t *OpenOrderTracker) Start(ctx context.Context) error {
+	if err := t.Reconcile(ctx); err != nil {
+		return err
+	}
+
+	t.mu.Lock()
+	if t.stopC != nil {
+		t.mu.Unlock()
+		return nil
+	}
+	t.stopC = make(chan struct{})
+	t.doneC = make(chan struct{})
+	stopC, doneC := t.stopC, t.doneC
+	t.mu.Unlock()
+
+	go func() {
+		defer close(doneC)
+		ticker := time.NewTicker(t.interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-stopC:
+				return
+			case <-ticker.C:
+				ctx, cancel := context.WithTimeout(context.Background(), t.interval)
+				if err := t.Reconcile(ctx); err != nil {
+					t.handleError(err)
+				}
+				cancel()
+			}
+		}
+	}()
+	return nil
+}
+
+// Stop stops periodic reconciliation
+func (t *OpenOrderTracker) Stop() {
+	t.mu.Lock()
+	stopC, doneC := t.stopC, t.doneC
+	t.stopC, t.doneC = nil, nil
+	t.mu.Unlock()
+
+	if stopC == nil {
+		return
+	}
+	close(stopC)
+	<-doneC
+}
+
+// Track records order, e.g. one returned by order status queries
+func (t *OpenOrderTracker) Track(order *Order) {
+	o, err := newTrackedOrder(order)
+	if err != nil {
+		t.handleError(err)
+		return
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.apply(o)
+}
+
+// TrackCreated records order just placed with CreateOrderService
+func (t *OpenOrderTracker) TrackCreated(res *CreateOrderResponse) {
+	t.Track(&Order{
+		Symbol:           res.Symbol,
+		OrderID:          res.OrderID,
+		ClientOrderID:    res.ClientOrderID,
+		Price:            res.Price,
+		OrigQuantity:     res.OrigQuantity,
+		ExecutedQuantity: res.ExecutedQuantity,
+		Status:           res.Status,
+		Type:             res.Type,
+		Side:             res.Side,
+		PositionSide:     res.PositionSide,
+		Time:             res.UpdateTime,
+		UpdateTime:       res.UpdateTime,
+	})
+}
+
+// HandleUserDataEvent applies ORDER_TRADE_UPDATE event, other events are ignored.
+// Pass it the events of the user data stream.
+func (t *OpenOrderTracker) HandleUserDataEvent(event *WsUserDataEvent) {
+	if event.Event != UserDataEventTypeOrderTradeUpdate {
+		return
+	}
+
+	u := event.OrderTradeUpdate
+	updateTime := u.TradeTime
+	if updateTime == 0 {
+		updateTime = event.TransactionTime
+	}
+	o, err := newTrackedOrder(&Order{
+		Symbol:           u.Symbol,
+		OrderID:          u.ID,
+		ClientOrderID:    u.ClientOrderID,
+		Price:            u.OriginalPrice,
+		OrigQuantity:     u.OriginalQty,
+		ExecutedQuantity: u.AccumulatedFilledQty,
+		Status:           u.Status,
+		Type:             u.Type,
+		Side:             u.Side,
+		PositionSide:     u.PositionSide,
+		Time:             updateTime,
+		UpdateTime:       updateTime,
+	})
+	if err != nil {
+		t.handleError(err)
+		return
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if old, ok := t.orders[o.OrderID]; ok {
+		o.Time = old.Time
+	}
+	t.apply(o)
+}
+
+// Reconcile replaces tracked orders with 'openOrders' snapshot. Orders changed by events or
+// placements newer than the snapshot request are kept.
+func (t *OpenOrderTracker) Reconcile(ctx context.Context) error {
+	requestTime := currentTimestamp() - t.c.TimeOffset
+	orders, err := t.c.NewListOpenOrdersService().Do(ctx)
+	if err != nil {
+		return err
+	}
+
+	snapshot := make(map[int64]TrackedOrder, len(orders))
+	for _, order := range orders {
+		o, err := newTrackedOrder(order)
+		if err != nil {
+			return err
+		}
+		snapshot[o.OrderID] = o
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	for id, old := range t.orders {
+		if _, ok := snapshot[id]; !ok && old.UpdateTime < requestTime {
+			delete(t.orders, id)
+			t.untrackQueue(id)
+		}
+	}
+	for id, o := range snapshot {
+		if old, ok := t.orders[id]; ok && old.UpdateTime >= requestTime {
+			continue
+		}
+		if _, ok := t.closed[id]; ok {
+			continue
+		}
+		t.orders[id] = o
+		t.trackQueue(o)
+	}
+	for id, updateTime := range t.closed {
+		if updateTime < requestTime {
+			delete(t.closed, id)
+		}
+	}
+	return nil
+}
+
+// Order returns live order by id
+func (t *OpenOrderTracker) Order(orderID int64) (TrackedOrder, bool) {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+
+	o, ok := t.orders[orderID]
+	return o, ok
+}
+
+// Orders returns live orders of symbol sorted by creation time, empty symbol returns orders of all symbols
+func (t *OpenOrderTracker) Orders(symbol string) []TrackedOrder {
+	return t.filter(func(o TrackedOrder) bool {
+		return symbol == "" || o.Symbol == symbol
+	})
+}
+
+// QueueAhead returns the quantity estimated ahead of live order in the queue of its price level,
+// false if no QueueEstimator is set or the level of the order wasn't reported yet
+func (t *OpenOrderTracker) QueueAhead(orderID int64) (float64, bool) {
+	if t.queue == nil {
+		return 0, false
+	}
+	if _, ok := t.Order(orderID); !ok {
+		return 0, false
+	}
+	return t.queue.Ahead(orderID)
+}
+
+// OlderThan returns live orders created more than age ago
+func (t *OpenOrderTracker) OlderThan(age time.Duration) []TrackedOrder {
+	before := currentTimestamp() - t.c.TimeOffset - age.Milliseconds()
+	return t.filter(func(o TrackedOrder) bool {
+		return o.Time < before
+	})
+}
+
+// OutsidePriceBand returns live orders of symbol priced below low or above high.
+// Orders without price, such as stop market orders, are never outside.
+func (t *OpenOrderTracker) OutsidePriceBand(symbol string, low, high float64) []TrackedOrder {
+	return t.filter(func(o TrackedOrder) bool {
+		return o.Symbol == symbol && o.Price != 0 && (o.Price < low || o.Price > high)
+	})
+}
+
+func (t *OpenOrderTracker) filter(match func(o TrackedOrder) bool) []TrackedOrder {
+	t.mu.RLock()
+	res := make([]TrackedOrder, 0)
+	for _, o := range t.orders {
+		if match(o) {
+			res = append(res, o)
+		}
+	}
+	t.mu.RUnlock()
+
+	sort.Slice(res, func(i, j int) bool {
+		if res[i].Time != res[j].Time {
+			return res[i].Time < res[j].Time
+		}
+		return res[i].OrderID < res[j].OrderID
+	})
+	return res
+}
+
+// apply stores live order or removes closed one, stale updates are ignored
+func (t *OpenOrderTracker) apply(o TrackedOrder) {
+	if old, ok := t.orders[o.OrderID]; ok && old.UpdateTime > o.UpdateTime {
+		return
+	}
+	if closedTime, ok := t.closed[o.OrderID]; ok && closedTime >= o.UpdateTime {
+		return
+	}
+	if !isOpenOrderStatus(o.Status) {
+		delete(t.orders, o.OrderID)
+		t.closed[o.OrderID] = o.UpdateTime
+		t.untrackQueue(o.OrderID)
+		return
+	}
+	t.orders[o.OrderID] = o
+	t.trackQueue(o)
+}
+
+func (t *OpenOrderTracker) trackQueue(o TrackedOrder) {
+	if t.queue != nil {
+		t.queue.track(o.OrderID, o)
+	}
+}
+
+func (t *OpenOrderTracker) untrackQueue(orderID int64) {
+	if t.queue != nil {
+		t.queue.untrack(orderID)
+	}
+}
+
+func (t *OpenOrderTracker) handleError(err error) {
+	if t.errHandler != nil {
+		t.errHandler(err)
+	}
+}
+
+// isOpenOrderStatus reports whether order in status can still be filled
+func isOpenOrderStatus(status OrderStatusType) bool {
+	return status == OrderStatusTypeNew || status == OrderStatusTypePartiallyFilled
+}
+
+func newTrackedOrder(order *Order) (o TrackedOrder, err error) {
+	o = TrackedOrder{
+		Symbol:        order.Symbol,
+		OrderID:       order.OrderID,
+		ClientOrderID: order.ClientOrderID,
+		Side:          order.Side,
+		PositionSide:  order.PositionSide,
+		Type:          order.Type,
+		Status:        order.Status,
+		Time:          order.Time,
+		UpdateTime:    order.UpdateTime,
+	}
+	if o.Price, err = parseOptionalFloat(order.Price); err != nil {
+		return o, err
+	}
+	if o.OrigQuantity, err = parseOptionalFloat(order.OrigQuantity); err != nil {
+		return o, err
+	}
+	if o.ExecutedQuantity, err = parseOptionalFloat(order.ExecutedQuantity); err != nil {
+		return o, err
+	}
+	return o, nil
+}
@@ -0,0 +1,99 @@
+package futures
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestOpenOrderTracker(t *testing.T) {
+	assert := assert.New(t)
+
+	now := time.Now().UnixMilli()
+	snapshot := `[
+		{"symbol":"BTCUSDT","orderId":1,"price":"60000","origQty":"0.1","executedQty":"0","status":"NEW","type":"LIMIT","side":"BUY","time":1728972000000,"updateTime":1728972000000},
+		{"symbol":"BTCUSDT","orderId":2,"price":"70000","origQty":"0.1","executedQty":"0.05","status":"PARTIALLY_FILLED","type":"LIMIT","side":"SELL","time":1728972100000,"updateTime":1728972100000}
+	]`
+	c := NewClient("apiKey", "secretKey")
+	c.do = func(req *http.Request) (*http.Response, error) {
+		assert.Equal("/fapi/v1/openOrders", req.URL.Path)
+		return newHTTPResponse([]byte(snapshot), http.StatusOK), nil
+	}
+
+	tracker := NewOpenOrderTracker(c, time.Hour, func(err error) {
+		t.Error(err)
+	})
+	assert.NoError(tracker.Start(newContext()))
+	defer tracker.Stop()
+
+	orders := tracker.Orders("BTCUSDT")
+	assert.Len(orders, 2)
+	assert.Equal(int64(1), orders[0].OrderID)
+	assert.Equal(60000.0, orders[0].Price)
+	assert.Equal(0.05, orders[1].ExecutedQuantity)
+
+	tracker.TrackCreated(&CreateOrderResponse{
+		Symbol:       "ETHUSDT",
+		OrderID:      3,
+		Price:        "2500",
+		OrigQuantity: "1",
+		Status:       OrderStatusTypeNew,
+		Type:         OrderTypeLimit,
+		Side:         SideTypeBuy,
+		UpdateTime:   now - 1000,
+	})
+	_, ok := tracker.Order(3)
+	assert.True(ok)
+	assert.Len(tracker.Orders(""), 3)
+
+	// order 1 is filled, a later snapshot taken before the fill must not bring it back
+	tracker.HandleUserDataEvent(&WsUserDataEvent{
+		Event: UserDataEventTypeOrderTradeUpdate,
+		WsUserDataOrderTradeUpdate: WsUserDataOrderTradeUpdate{OrderTradeUpdate: WsOrderTradeUpdate{
+			Symbol:               "BTCUSDT",
+			ID:                   1,
+			OriginalPrice:        "60000",
+			OriginalQty:          "0.1",
+			AccumulatedFilledQty: "0.1",
+			Status:               OrderStatusTypeFilled,
+			TradeTime:            now + int64(time.Hour/time.Millisecond),
+		}},
+	})
+	_, ok = tracker.Order(1)
+	assert.False(ok)
+	assert.NoError(tracker.Reconcile(newContext()))
+	_, ok = tracker.Order(1)
+	assert.False(ok)
+
+	// order 3 was placed before the snapshot request and is missing from it
+	_, ok = tracker.Order(3)
+	assert.False(ok)
+
+	old := tracker.OlderThan(time.Hour)
+	assert.Len(old, 1)
+	assert.Equal(int64(2), old[0].OrderID)
+
+	outside := tracker.OutsidePriceBand("BTCUSDT", 55000, 65000)
+	assert.Len(outside, 1)
+	assert.Equal(int64(2), outside[0].OrderID)
+	assert.Empty(tracker.OutsidePriceBand("BTCUSDT", 55000, 75000))
+}
+
+func TestOpenOrderTrackerIgnoresStaleUpdates(t *testing.T) {
+	assert := assert.New(t)
+
+	tracker := NewOpenOrderTracker(NewClient("apiKey", "secretKey"), time.Hour, nil)
+	tracker.Track(&Order{Symbol: "BTCUSDT", OrderID: 1, Price: "60000", Status: OrderStatusTypePartiallyFilled, UpdateTime: 200})
+	tracker.Track(&Order{Symbol: "BTCUSDT", OrderID: 1, Price: "60000", Status: OrderStatusTypeNew, UpdateTime: 100})
+
+	o, ok := tracker.Order(1)
+	assert.True(ok)
+	assert.Equal(OrderStatusTypePartiallyFilled, o.Status)
+
+	tracker.Track(&Order{Symbol: "BTCUSDT", OrderID: 1, Status: OrderStatusTypeCanceled, UpdateTime: 300})
+	tracker.Track(&Order{Symbol: "BTCUSDT", OrderID: 1, Status: OrderStatusTypeNew, UpdateTime: 250})
+	_, ok = tracker.Order(1)
+	assert.False(ok)
+}
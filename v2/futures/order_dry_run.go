@@ -0,0 +1,180 @@
+package futures
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+
+	"github.com/adshao/go-binance/v2/common"
+)
+
+// dryRunEpsilon absorbs float rounding when checking step and tick multiples
+const dryRunEpsilon = 1e-9
+
+var ErrDryRunSymbolNotFound = errors.New("order dry run: symbol not found in exchange info")
+
+// FilterError is returned when an order breaks a filter of its symbol
+type FilterError struct {
+	Filter SymbolFilterType
+	Symbol string
+	Reason string
+}
+
+func (e *FilterError) Error() string {
+	return fmt.Sprintf("order dry run: %s of %s: %s", e.Filter, e.Symbol, e.Reason)
+}
+
+// DryRunResult define estimates of an order that passed the dry run
+type DryRunResult struct {
+	// Notional is quantity times price, mark price for market orders, 0 if unknown
+	Notional float64
+	// InitialMargin is the margin the order needs at the leverage, 0 if not estimated
+	InitialMargin float64
+}
+
+// OrderDryRun validates futures orders locally against the filters of their symbol and estimates
+// the initial margin they need, no request is sent. The futures websocket API has no test method,
+// so it stands in for the spot 'order.test' in deployment smoke tests.
+type OrderDryRun struct {
+	symbols    map[string]Symbol
+	brackets   *LeverageBracketCache
+	markPrices MarkPriceSource
+}
+
+// NewOrderDryRun init OrderDryRun with filters of info, margin is estimated with brackets if not nil
+func NewOrderDryRun(info *ExchangeInfo, brackets *LeverageBracketCache) *OrderDryRun {
+	symbols := make(map[string]Symbol, len(info.Symbols))
+	for _, s := range info.Symbols {
+		symbols[s.Symbol] = s
+	}
+	return &OrderDryRun{symbols: symbols, brackets: brackets}
+}
+
+// MarkPrices set source of mark prices, needed to check market orders against MIN_NOTIONAL and
+// limit orders against PERCENT_PRICE
+func (d *OrderDryRun) MarkPrices(markPrices MarkPriceSource) *OrderDryRun {
+	d.markPrices = markPrices
+	return d
+}
+
+// Check validates order and estimates its margin at leverage, *FilterError is returned if it
+// breaks a filter
+func (d *OrderDryRun) Check(order RiskOrder, leverage int) (*DryRunResult, error) {
+	symbol, ok := d.symbols[order.Symbol]
+	if !ok {
+		return nil, ErrDryRunSymbolNotFound
+	}
+	var markPrice float64
+	if d.markPrices != nil {
+		markPrice, _ = d.markPrices.MarkPrice(order.Symbol)
+	}
+
+	market := order.Type == OrderTypeMarket || order.Type == OrderTypeStopMarket || order.Type == OrderTypeTakeProfitMarket
+	if !market {
+		if err := checkPriceFilter(&symbol, order.Price); err != nil {
+			return nil, err
+		}
+		if err := checkPercentPriceFilter(&symbol, order.Price, markPrice); err != nil {
+			return nil, err
+		}
+	}
+	if !order.ClosePosition {
+		filterType, min, max, step := SymbolFilterTypeLotSize, "", "", ""
+		if f := symbol.LotSizeFilter(); f != nil {
+			min, max, step = f.MinQuantity, f.MaxQuantity, f.StepSize
+		}
+		if f := symbol.MarketLotSizeFilter(); market && f != nil {
+			filterType, min, max, step = SymbolFilterTypeMarketLotSize, f.MinQuantity, f.MaxQuantity, f.StepSize
+		}
+		if err := checkRange(filterType, order.Symbol, "quantity", order.Quantity, min, max, step); err != nil {
+			return nil, err
+		}
+	}
+
+	price := order.Price
+	if market {
+		price = markPrice
+	}
+	res := &DryRunResult{Notional: order.Quantity * price}
+	if f := symbol.MinNotionalFilter(); f != nil && price > 0 && !order.ReduceOnly && !order.ClosePosition {
+		if min, err := strconv.ParseFloat(f.Notional, 64); err == nil && res.Notional < min-dryRunEpsilon {
+			return nil, &FilterError{Filter: SymbolFilterTypeMinNotional, Symbol: order.Symbol,
+				Reason: fmt.Sprintf("notional %v below %v", res.Notional, f.Notional)}
+		}
+	}
+	if d.brackets != nil && leverage > 0 && res.Notional > 0 {
+		margin, err := d.brackets.InitialMargin(order.Symbol, order.Quantity, price, leverage)
+		if err != nil {
+			return nil, err
+		}
+		res.InitialMargin = margin
+	}
+	return res, nil
+}
+
+// TestOrder returns order as a common.TestOrder dry run at leverage
+func (d *OrderDryRun) TestOrder(order RiskOrder, leverage int) common.TestOrder {
+	return &dryRunTestOrder{d: d, order: order, leverage: leverage}
+}
+
+type dryRunTestOrder struct {
+	d        *OrderDryRun
+	order    RiskOrder
+	leverage int
+}
+
+func (o *dryRunTestOrder) Test(ctx context.Context) error {
+	_, err := o.d.Check(o.order, o.leverage)
+	return err
+}
+
+func checkPriceFilter(symbol *Symbol, price float64) error {
+	f := symbol.PriceFilter()
+	if f == nil {
+		return nil
+	}
+	return checkRange(SymbolFilterTypePrice, symbol.Symbol, "price", price, f.MinPrice, f.MaxPrice, f.TickSize)
+}
+
+func checkPercentPriceFilter(symbol *Symbol, price, markPrice float64) error {
+	f := symbol.PercentPriceFilter()
+	if f == nil || markPrice <= 0 {
+		return nil
+	}
+	up, errUp := strconv.ParseFloat(f.MultiplierUp, 64)
+	down, errDown := strconv.ParseFloat(f.MultiplierDown, 64)
+	if errUp != nil || errDown != nil {
+		return nil
+	}
+	if price > markPrice*up+dryRunEpsilon || price < markPrice*down-dryRunEpsilon {
+		return &FilterError{Filter: SymbolFilterTypePercentPrice, Symbol: symbol.Symbol,
+			Reason: fmt.Sprintf("price %v outside [%v, %v]", price, markPrice*down, markPrice*up)}
+	}
+	return nil
+}
+
+// checkRange checks v against min and max, and that v-min is a multiple of step. Zero or
+// unparsable bounds are not checked, as the exchange does.
+func checkRange(filter SymbolFilterType, symbol, name string, v float64, minStr, maxStr, stepStr string) error {
+	min, _ := strconv.ParseFloat(minStr, 64)
+	max, _ := strconv.ParseFloat(maxStr, 64)
+	step, _ := strconv.ParseFloat(stepStr, 64)
+	if v <= 0 {
+		return &FilterError{Filter: filter, Symbol: symbol, Reason: fmt.Sprintf("%s %v is not positive", name, v)}
+	}
+	if min > 0 && v < min-dryRunEpsilon {
+		return &FilterError{Filter: filter, Symbol: symbol, Reason: fmt.Sprintf("%s %v below %s", name, v, minStr)}
+	}
+	if max > 0 && v > max+dryRunEpsilon {
+		return &FilterError{Filter: filter, Symbol: symbol, Reason: fmt.Sprintf("%s %v above %s", name, v, maxStr)}
+	}
+	if step > 0 {
+		n := (v - min) / step
+		if math.Abs(n-math.Round(n)) > dryRunEpsilon*math.Max(1, n) {
+			return &FilterError{Filter: filter, Symbol: symbol, Reason: fmt.Sprintf("%s %v is not a multiple of %s", name, v, stepStr)}
+		}
+	}
+	return nil
+}
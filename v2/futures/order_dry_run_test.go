@@ -0,0 +1,75 @@
+package futures
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func newTestOrderDryRun(t *testing.T) *OrderDryRun {
+	c := NewClient("apiKey", "secretKey")
+	c.do = func(req *http.Request) (*http.Response, error) {
+		return newHTTPResponse([]byte(`[{
+			"symbol": "BTCUSDT",
+			"brackets": [{"bracket": 1, "initialLeverage": 20, "notionalCap": 50000, "notionalFloor": 0, "maintMarginRatio": 0.004, "cum": 0}]
+		}]`), http.StatusOK), nil
+	}
+	brackets := NewLeverageBracketCache(c)
+	assert.NoError(t, brackets.Refresh(newContext()))
+
+	info := &ExchangeInfo{Symbols: []Symbol{{
+		Symbol: "BTCUSDT",
+		Filters: []map[string]interface{}{
+			{"filterType": "PRICE_FILTER", "minPrice": "0.10", "maxPrice": "1000000", "tickSize": "0.10"},
+			{"filterType": "LOT_SIZE", "minQty": "0.001", "maxQty": "1000", "stepSize": "0.001"},
+			{"filterType": "MARKET_LOT_SIZE", "minQty": "0.001", "maxQty": "120", "stepSize": "0.001"},
+			{"filterType": "MIN_NOTIONAL", "notional": "100"},
+			{"filterType": "PERCENT_PRICE", "multiplierUp": "1.05", "multiplierDown": "0.95", "multiplierDecimal": "4"},
+		},
+	}}}
+	return NewOrderDryRun(info, brackets).MarkPrices(fakeMarkPrices{"BTCUSDT": 50000})
+}
+
+func TestOrderDryRun(t *testing.T) {
+	assert := assert.New(t)
+	d := newTestOrderDryRun(t)
+
+	res, err := d.Check(RiskOrder{Symbol: "BTCUSDT", Side: SideTypeBuy, Type: OrderTypeLimit, Quantity: 0.01, Price: 50000.1}, 10)
+	assert.NoError(err)
+	assert.InDelta(500.001, res.Notional, 1e-9)
+	assert.InDelta(50.0001, res.InitialMargin, 1e-9)
+
+	res, err = d.Check(RiskOrder{Symbol: "BTCUSDT", Side: SideTypeSell, Type: OrderTypeMarket, Quantity: 0.5}, 10)
+	assert.NoError(err)
+	assert.Equal(25000.0, res.Notional)
+	assert.Equal(2500.0, res.InitialMargin)
+
+	tests := []struct {
+		order  RiskOrder
+		filter SymbolFilterType
+	}{
+		{RiskOrder{Symbol: "BTCUSDT", Type: OrderTypeLimit, Quantity: 0.01, Price: 50000.15}, SymbolFilterTypePrice},
+		{RiskOrder{Symbol: "BTCUSDT", Type: OrderTypeLimit, Quantity: 0.01, Price: 60000}, SymbolFilterTypePercentPrice},
+		{RiskOrder{Symbol: "BTCUSDT", Type: OrderTypeLimit, Quantity: 0.0015, Price: 50000}, SymbolFilterTypeLotSize},
+		{RiskOrder{Symbol: "BTCUSDT", Type: OrderTypeMarket, Quantity: 200}, SymbolFilterTypeMarketLotSize},
+		{RiskOrder{Symbol: "BTCUSDT", Type: OrderTypeLimit, Quantity: 0.001, Price: 50000}, SymbolFilterTypeMinNotional},
+	}
+	for _, test := range tests {
+		_, err := d.Check(test.order, 10)
+		var filterErr *FilterError
+		if assert.ErrorAs(err, &filterErr) {
+			assert.Equal(test.filter, filterErr.Filter)
+		}
+	}
+
+	// reduce only orders are not limited by notional
+	_, err = d.Check(RiskOrder{Symbol: "BTCUSDT", Type: OrderTypeLimit, Quantity: 0.001, Price: 50000, ReduceOnly: true}, 10)
+	assert.NoError(err)
+	_, err = d.Check(RiskOrder{Symbol: "BTCUSDT", Type: OrderTypeMarket, Quantity: 2}, 20)
+	assert.ErrorIs(err, ErrLeverageTooHigh)
+	_, err = d.Check(RiskOrder{Symbol: "ETHUSDT", Type: OrderTypeMarket, Quantity: 1}, 10)
+	assert.ErrorIs(err, ErrDryRunSymbolNotFound)
+
+	assert.NoError(d.TestOrder(RiskOrder{Symbol: "BTCUSDT", Type: OrderTypeMarket, Quantity: 0.01}, 10).Test(newContext()))
+}
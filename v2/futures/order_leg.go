@@ -0,0 +1,110 @@
+package futures
+
+import (
+	"context"
+	"strconv"
+	"time"
+
+	"github.com/adshao/go-binance/v2/common"
+)
+
+// OrderLeg wraps an order so it can be unwound after it was placed, e.g. as leg of a
+// binance.OrderGroup
+type OrderLeg struct {
+	c             *Client
+	order         *CreateOrderService
+	clientOrderID string
+	res           *CreateOrderResponse
+	unwind        *CreateOrderResponse
+}
+
+// NewOrderLeg init OrderLeg of order, a client order id is set if order has none
+func (c *Client) NewOrderLeg(order *CreateOrderService) *OrderLeg {
+	l := &OrderLeg{c: c, order: order}
+	if order.newClientOrderID != nil {
+		l.clientOrderID = *order.newClientOrderID
+	} else {
+		l.clientOrderID = "leg" + strconv.FormatInt(time.Now().UnixNano(), 36)
+		order.NewClientOrderID(l.clientOrderID)
+	}
+	return l
+}
+
+// Place sends the order
+func (l *OrderLeg) Place(ctx context.Context) (err error) {
+	l.res, err = l.order.Do(ctx)
+	return err
+}
+
+// Unwind cancels the order if it is still open and closes its executed quantity with a market
+// order. Orders that were never placed are ignored.
+func (l *OrderLeg) Unwind(ctx context.Context) error {
+	order, err := l.c.NewGetOrderService().Symbol(l.order.symbol).OrigClientOrderID(l.clientOrderID).Do(ctx)
+	if common.IsAPIErrorCode(err, errCodeOrderDoesNotExist) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	if isOpenOrderStatus(order.Status) {
+		_, err = l.c.NewCancelOrderService().Symbol(l.order.symbol).OrigClientOrderID(l.clientOrderID).Do(ctx)
+		if err != nil && !common.IsAPIErrorCode(err, errCodeUnknownOrder) {
+			return err
+		}
+		if order, err = l.c.NewGetOrderService().Symbol(l.order.symbol).OrigClientOrderID(l.clientOrderID).Do(ctx); err != nil {
+			return err
+		}
+	}
+	executed, err := parseOptionalFloat(order.ExecutedQuantity)
+	if err != nil || executed == 0 {
+		return err
+	}
+
+	side, intent := SideTypeSell, PositionIntentClose
+	if l.order.side == SideTypeSell {
+		side = SideTypeBuy
+	}
+	if l.intent() == PositionIntentClose {
+		intent = PositionIntentOpen
+	}
+	s := l.c.NewCreateOrderService().Symbol(l.order.symbol).Side(side).Type(OrderTypeMarket).
+		Quantity(order.ExecutedQuantity).NewOrderResponseType(NewOrderRespTypeRESULT).PositionIntent(intent)
+	if l.order.positionSide != nil && *l.order.positionSide != PositionSideTypeBoth {
+		s.PositionSide(*l.order.positionSide)
+	}
+	l.unwind, err = s.Do(ctx)
+	return err
+}
+
+// intent returns whether the order opened or closed a position. In Hedge Mode it follows from
+// side and positionSide, as reduceOnly is not sent.
+func (l *OrderLeg) intent() PositionIntent {
+	if l.order.positionIntent != nil {
+		return *l.order.positionIntent
+	}
+	if l.order.positionSide != nil && *l.order.positionSide != PositionSideTypeBoth {
+		if intentPositionSide(l.order.side, PositionIntentOpen) == *l.order.positionSide {
+			return PositionIntentOpen
+		}
+		return PositionIntentClose
+	}
+	if l.order.reduceOnly != nil && *l.order.reduceOnly || l.order.closePosition != nil && *l.order.closePosition {
+		return PositionIntentClose
+	}
+	return PositionIntentOpen
+}
+
+// ClientOrderID returns client order id of the order
+func (l *OrderLeg) ClientOrderID() string {
+	return l.clientOrderID
+}
+
+// Order returns response of the placed order, nil if placing failed
+func (l *OrderLeg) Order() *CreateOrderResponse {
+	return l.res
+}
+
+// UnwindOrder returns response of the order closing the executed quantity, nil if none was sent
+func (l *OrderLeg) UnwindOrder() *CreateOrderResponse {
+	return l.unwind
+}
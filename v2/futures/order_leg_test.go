@@ -0,0 +1,85 @@
+package futures
+
+import (
+	"io"
+	"net/http"
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestOrderLegUnwind(t *testing.T) {
+	assert := assert.New(t)
+
+	var orders []url.Values
+	c := NewClient("apiKey", "secretKey")
+	c.do = func(req *http.Request) (*http.Response, error) {
+		body, _ := io.ReadAll(req.Body)
+		form, _ := url.ParseQuery(string(body))
+		switch req.Method + " " + req.URL.Path {
+		case "GET /fapi/v1/positionSide/dual":
+			return newHTTPResponse([]byte(`{"dualSidePosition":true}`), http.StatusOK), nil
+		case "POST /fapi/v1/order":
+			orders = append(orders, form)
+			return newHTTPResponse([]byte(`{"status":"FILLED","executedQty":"2"}`), http.StatusOK), nil
+		case "GET /fapi/v1/order":
+			return newHTTPResponse([]byte(`{"symbol":"BTCUSDT","status":"FILLED","executedQty":"2"}`), http.StatusOK), nil
+		}
+		return newHTTPResponse([]byte(`{}`), http.StatusNotFound), nil
+	}
+
+	leg := c.NewOrderLeg(c.NewCreateOrderService().Symbol("BTCUSDT").Side(SideTypeSell).
+		Type(OrderTypeMarket).Quantity("2").PositionSide(PositionSideTypeShort))
+	assert.NoError(leg.Place(newContext()))
+	assert.Equal(OrderStatusTypeFilled, leg.Order().Status)
+
+	assert.NoError(leg.Unwind(newContext()))
+	if assert.Len(orders, 2) {
+		assert.Equal(leg.ClientOrderID(), orders[0].Get("newClientOrderId"))
+		assert.Equal("BUY", orders[1].Get("side"))
+		assert.Equal("SHORT", orders[1].Get("positionSide"))
+		assert.Equal("2", orders[1].Get("quantity"))
+	}
+	assert.NotNil(leg.UnwindOrder())
+}
+
+func TestOrderLegUnwindHedgeModeClose(t *testing.T) {
+	assert := assert.New(t)
+
+	var orders []url.Values
+	c := NewClient("apiKey", "secretKey")
+	c.do = func(req *http.Request) (*http.Response, error) {
+		body, _ := io.ReadAll(req.Body)
+		form, _ := url.ParseQuery(string(body))
+		switch req.Method + " " + req.URL.Path {
+		case "GET /fapi/v1/positionSide/dual":
+			return newHTTPResponse([]byte(`{"dualSidePosition":true}`), http.StatusOK), nil
+		case "POST /fapi/v1/order":
+			orders = append(orders, form)
+			return newHTTPResponse([]byte(`{"status":"FILLED","executedQty":"2"}`), http.StatusOK), nil
+		case "GET /fapi/v1/order":
+			return newHTTPResponse([]byte(`{"symbol":"BTCUSDT","status":"FILLED","executedQty":"2"}`), http.StatusOK), nil
+		}
+		return newHTTPResponse([]byte(`{}`), http.StatusNotFound), nil
+	}
+
+	// closing legs, by intent and by positionSide, are unwound by reopening their position
+	for _, order := range []*CreateOrderService{
+		c.NewCreateOrderService().Symbol("BTCUSDT").Side(SideTypeSell).
+			Type(OrderTypeMarket).Quantity("2").PositionIntent(PositionIntentClose),
+		c.NewCreateOrderService().Symbol("BTCUSDT").Side(SideTypeSell).
+			Type(OrderTypeMarket).Quantity("2").PositionSide(PositionSideTypeLong),
+	} {
+		orders = nil
+		leg := c.NewOrderLeg(order)
+		assert.NoError(leg.Place(newContext()))
+		assert.NoError(leg.Unwind(newContext()))
+		if assert.Len(orders, 2) {
+			assert.Equal("LONG", orders[0].Get("positionSide"))
+			assert.Equal("BUY", orders[1].Get("side"))
+			assert.Equal("LONG", orders[1].Get("positionSide"))
+			assert.Equal("", orders[1].Get("reduceOnly"))
+		}
+	}
+}
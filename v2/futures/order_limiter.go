@@ -0,0 +1,121 @@
+package futures
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/adshao/go-binance/v2/common"
+)
+
+var ErrOrderRateLimited = errors.New("order limiter: order rate of account exceeded")
+
+// defaultOrderLimits are the ORDERS rate limits of the exchange
+var defaultOrderLimits = []OrderLimit{
+	{Interval: 10 * time.Second, Limit: 300},
+	{Interval: time.Minute, Limit: 1200},
+}
+
+// OrderLimit define the number of orders allowed within an interval
+type OrderLimit struct {
+	Interval time.Duration
+	Limit    int
+}
+
+// OrderLimiter limits the orders placed by the account within fixed windows aligned to
+// multiples of their interval, like the ORDERS rate limits of the exchange, so that orders
+// aren't rejected with -1015. Set it as OrderLimiter of Client and ClientWs, clients placing
+// orders of the same account should share one, processes doing so share the counts through
+// Store. The zero value applies the limits of the exchange.
+type OrderLimiter struct {
+	// Limits are the orders allowed within each interval, those of the exchange if empty
+	Limits []OrderLimit
+	// MaxDelay bounds waiting for capacity, orders which would wait longer fail with
+	// ErrOrderRateLimited. Orders fail at once if it is 0.
+	MaxDelay time.Duration
+	// Store, if set, counts orders of the account shared with other processes, e.g. through
+	// Redis. Orders are counted in memory while it fails.
+	Store RateLimitStore
+	// OnError is called when Store fails
+	OnError ErrHandler
+
+	clock common.Clock
+	local MemoryRateLimitStore
+}
+
+// NewOrderLimiter init OrderLimiter with limits, those of the exchange if none is given
+func NewOrderLimiter(limits ...OrderLimit) *OrderLimiter {
+	return &OrderLimiter{
+		Limits: limits,
+		clock:  common.SystemClock,
+	}
+}
+
+// wait blocks until an order may be placed and returns the function giving back its capacity
+// if the order is not placed after all
+func (l *OrderLimiter) wait(ctx context.Context) (func(), error) {
+	clock := orSystemClock(l.clock)
+	deadline := clock.Now().Add(l.MaxDelay)
+	for {
+		now := clock.Now()
+		next, ok := l.take(ctx, now)
+		if ok {
+			return func() { l.giveBack(now) }, nil
+		}
+		if next.After(deadline) {
+			return nil, ErrOrderRateLimited
+		}
+		timer := clock.NewTimer(next.Sub(now))
+		select {
+		case <-timer.C():
+		case <-ctx.Done():
+			timer.Stop()
+			return nil, ctx.Err()
+		}
+	}
+}
+
+// take counts an order within the current window of every limit. If one of them is full, it
+// gives back what was counted and returns the end of the full window.
+func (l *OrderLimiter) take(ctx context.Context, now time.Time) (time.Time, bool) {
+	limits := l.limits()
+	counter := l.counter()
+	for i, limit := range limits {
+		start := now.Truncate(limit.Interval)
+		if counter.take(ctx, orderLimitKey(limit.Interval), start, 1, limit.Limit) {
+			continue
+		}
+		giveBackOrder(ctx, counter, limits[:i], now)
+		return start.Add(limit.Interval), false
+	}
+	return time.Time{}, true
+}
+
+// giveBack gives back an order counted at now
+func (l *OrderLimiter) giveBack(now time.Time) {
+	giveBackOrder(context.Background(), l.counter(), l.limits(), now)
+}
+
+func (l *OrderLimiter) limits() []OrderLimit {
+	if len(l.Limits) == 0 {
+		return defaultOrderLimits
+	}
+	return l.Limits
+}
+
+func (l *OrderLimiter) counter() windowCounter {
+	return windowCounter{store: l.Store, onError: l.OnError, local: &l.local}
+}
+
+// giveBackOrder gives back an order counted at now within the windows of limits
+func giveBackOrder(ctx context.Context, counter windowCounter, limits []OrderLimit, now time.Time) {
+	for _, limit := range limits {
+		counter.add(ctx, orderLimitKey(limit.Interval), now.Truncate(limit.Interval), -1)
+	}
+}
+
+// orderLimitKey returns the RateLimitStore key of orders counted within interval
+func orderLimitKey(interval time.Duration) string {
+	return fmt.Sprintf("orders:%s", interval)
+}
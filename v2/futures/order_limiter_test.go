@@ -0,0 +1,109 @@
+package futures
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/adshao/go-binance/v2/common"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestOrderLimiter(limits ...OrderLimit) (*OrderLimiter, *common.FakeClock) {
+	clock := common.NewFakeClock(time.Unix(6000, 0))
+	l := NewOrderLimiter(limits...)
+	l.clock = clock
+	return l, clock
+}
+
+// waitOrder waits for l to let an order through, keeping its capacity
+func waitOrder(ctx context.Context, l *OrderLimiter) error {
+	_, err := l.wait(ctx)
+	return err
+}
+
+func TestOrderLimiter(t *testing.T) {
+	l, clock := newTestOrderLimiter(
+		OrderLimit{Interval: 10 * time.Second, Limit: 2},
+		OrderLimit{Interval: time.Minute, Limit: 3},
+	)
+	ctx := context.Background()
+
+	assert.NoError(t, waitOrder(ctx, l))
+	assert.NoError(t, waitOrder(ctx, l))
+	assert.ErrorIs(t, waitOrder(ctx, l), ErrOrderRateLimited)
+
+	clock.Advance(10 * time.Second)
+	assert.NoError(t, waitOrder(ctx, l))
+	// the minute is full, the order counted within the 10 seconds is given back
+	assert.ErrorIs(t, waitOrder(ctx, l), ErrOrderRateLimited)
+	count, _ := l.local.Add(ctx, orderLimitKey(10*time.Second), clock.Now().Truncate(10*time.Second), 0)
+	assert.Equal(t, 1, count)
+}
+
+func TestOrderLimiterDelay(t *testing.T) {
+	l, clock := newTestOrderLimiter(OrderLimit{Interval: time.Second, Limit: 1})
+	l.MaxDelay = time.Second
+	ctx := context.Background()
+	require.NoError(t, waitOrder(ctx, l))
+
+	errC := make(chan error, 1)
+	go func() {
+		errC <- waitOrder(ctx, l)
+	}()
+	clock.BlockUntil(1)
+	clock.Advance(time.Second)
+	assert.NoError(t, <-errC)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	go func() {
+		errC <- waitOrder(ctx, l)
+	}()
+	clock.BlockUntil(1)
+	cancel()
+	assert.ErrorIs(t, <-errC, context.Canceled)
+}
+
+func TestOrderLimiterZeroValue(t *testing.T) {
+	l := &OrderLimiter{}
+	for i := 0; i < 300; i++ {
+		require.NoError(t, waitOrder(context.Background(), l))
+	}
+	assert.ErrorIs(t, waitOrder(context.Background(), l), ErrOrderRateLimited)
+}
+
+func TestOrderLimiterStore(t *testing.T) {
+	store := NewMemoryRateLimitStore()
+	l1, clock := newTestOrderLimiter(OrderLimit{Interval: time.Minute, Limit: 2})
+	l2, _ := newTestOrderLimiter(OrderLimit{Interval: time.Minute, Limit: 2})
+	l2.clock = clock
+	l1.Store, l2.Store = store, store
+	ctx := context.Background()
+
+	// orders of one process hold back the other
+	assert.NoError(t, waitOrder(ctx, l1))
+	assert.NoError(t, waitOrder(ctx, l2))
+	assert.ErrorIs(t, waitOrder(ctx, l1), ErrOrderRateLimited)
+	assert.ErrorIs(t, waitOrder(ctx, l2), ErrOrderRateLimited)
+}
+
+func TestClientOrderLimiter(t *testing.T) {
+	c := NewClient("apiKey", "secretKey")
+	c.OrderLimiter, _ = newTestOrderLimiter(OrderLimit{Interval: time.Minute, Limit: 1})
+	requests := 0
+	c.do = func(req *http.Request) (*http.Response, error) {
+		requests++
+		return newHTTPResponse([]byte(`{}`), http.StatusOK), nil
+	}
+
+	create := func() error {
+		_, err := c.NewCreateOrderService().Symbol("BTCUSDT").Side(SideTypeBuy).
+			Type(OrderTypeMarket).Quantity("0.1").Do(newContext())
+		return err
+	}
+	require.NoError(t, create())
+	assert.ErrorIs(t, create(), ErrOrderRateLimited)
+	assert.Equal(t, 1, requests)
+}
@@ -4,11 +4,20 @@ import (
 	"context"
 	"encoding/json"
 	"errors"
-	"fmt"
 	"net/http"
-	"strings"
+
+	"github.com/adshao/go-binance/v2/common"
+)
+
+const (
+	// maxBatchOrders is the number of orders a batchOrders request may place
+	maxBatchOrders = 5
+	// maxBatchCancels is the number of orders a batchOrders request may cancel
+	maxBatchCancels = 10
 )
 
+var ErrCancelBatchOrderIDs = errors.New("cancel batch orders: either orderIdList or origClientOrderIdList must be sent")
+
 // CreateOrderService create order
 type CreateOrderService struct {
 	c                *Client
@@ -28,6 +37,7 @@ type CreateOrderService struct {
 	priceProtect     *bool
 	newOrderRespType NewOrderRespType
 	closePosition    *bool
+	positionIntent   *PositionIntent
 }
 
 // Symbol set symbol
@@ -126,7 +136,19 @@ func (s *CreateOrderService) ClosePosition(closePosition bool) *CreateOrderServi
 	return s
 }
 
+// PositionIntent set whether the order opens or closes a position, positionSide and reduceOnly
+// are then set according to the account position mode
+func (s *CreateOrderService) PositionIntent(intent PositionIntent) *CreateOrderService {
+	s.positionIntent = &intent
+	return s
+}
+
 func (s *CreateOrderService) createOrder(ctx context.Context, endpoint string, opts ...RequestOption) (data []byte, header *http.Header, err error) {
+	if s.positionIntent != nil {
+		if err := s.applyPositionIntent(ctx, s.c, opts...); err != nil {
+			return []byte{}, &http.Header{}, err
+		}
+	}
 
 	r := &request{
 		method:   http.MethodPost,
@@ -175,8 +197,21 @@ func (s *CreateOrderService) createOrder(ctx context.Context, endpoint string, o
 	if s.closePosition != nil {
 		m["closePosition"] = *s.closePosition
 	}
+	if err := checkOrderRisk(s.c.RiskChecker, m); err != nil {
+		return []byte{}, &http.Header{}, err
+	}
+	done := func(error) {}
+	// test orders are not placed, they take no order rate and their outcome says nothing of
+	// the account
+	if endpoint != "/fapi/v1/order/test" {
+		done, err = admitOrders(ctx, s.c.CircuitBreaker, s.c.OrderLimiter, s.c.SymbolThrottle, s.symbol)
+		if err != nil {
+			return []byte{}, &http.Header{}, err
+		}
+	}
 	r.setFormParams(m)
 	data, header, err = s.c.callAPI(ctx, r, opts...)
+	done(err)
 	if err != nil {
 		return []byte{}, &http.Header{}, err
 	}
@@ -190,7 +225,7 @@ func (s *CreateOrderService) Do(ctx context.Context, opts ...RequestOption) (res
 		return nil, err
 	}
 	res = new(CreateOrderResponse)
-	err = json.Unmarshal(data, res)
+	err = unmarshalOrderResponse(data, res)
 	res.RateLimitOrder10s = header.Get("X-Mbx-Order-Count-10s")
 	res.RateLimitOrder1m = header.Get("X-Mbx-Order-Count-1m")
 
@@ -200,31 +235,49 @@ func (s *CreateOrderService) Do(ctx context.Context, opts ...RequestOption) (res
 	return res, nil
 }
 
+// Test send test api to check if the request is valid, no order is placed
+func (s *CreateOrderService) Test(ctx context.Context, opts ...RequestOption) (res *CreateOrderResponse, err error) {
+	data, _, err := s.createOrder(ctx, "/fapi/v1/order/test", opts...)
+	if err != nil {
+		return nil, err
+	}
+	res = new(CreateOrderResponse)
+	if err = unmarshalResponse(data, res); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
 // CreateOrderResponse define create order response
 type CreateOrderResponse struct {
-	Symbol            string           `json:"symbol"`
-	OrderID           int64            `json:"orderId"`
-	ClientOrderID     string           `json:"clientOrderId"`
-	Price             string           `json:"price"`
-	OrigQuantity      string           `json:"origQty"`
-	ExecutedQuantity  string           `json:"executedQty"`
-	CumQuote          string           `json:"cumQuote"`
-	ReduceOnly        bool             `json:"reduceOnly"`
-	Status            OrderStatusType  `json:"status"`
-	StopPrice         string           `json:"stopPrice"`
-	TimeInForce       TimeInForceType  `json:"timeInForce"`
-	Type              OrderType        `json:"type"`
-	Side              SideType         `json:"side"`
-	UpdateTime        int64            `json:"updateTime"`
-	WorkingType       WorkingType      `json:"workingType"`
-	ActivatePrice     string           `json:"activatePrice"`
-	PriceRate         string           `json:"priceRate"`
-	AvgPrice          string           `json:"avgPrice"`
-	PositionSide      PositionSideType `json:"positionSide"`
-	ClosePosition     bool             `json:"closePosition"`
-	PriceProtect      bool             `json:"priceProtect"`
-	RateLimitOrder10s string           `json:"rateLimitOrder10s,omitempty"`
-	RateLimitOrder1m  string           `json:"rateLimitOrder1m,omitempty"`
+	Symbol                  string           `json:"symbol"`
+	OrderID                 int64            `json:"orderId"`
+	ClientOrderID           string           `json:"clientOrderId"`
+	Price                   string           `json:"price"`
+	OrigQuantity            string           `json:"origQty"`
+	ExecutedQuantity        string           `json:"executedQty"`
+	CumQuantity             string           `json:"cumQty"`
+	CumQuote                string           `json:"cumQuote"`
+	ReduceOnly              bool             `json:"reduceOnly"`
+	Status                  OrderStatusType  `json:"status"`
+	StopPrice               string           `json:"stopPrice"`
+	TimeInForce             TimeInForceType  `json:"timeInForce"`
+	Type                    OrderType        `json:"type"`
+	Side                    SideType         `json:"side"`
+	UpdateTime              int64            `json:"updateTime"`
+	WorkingType             WorkingType      `json:"workingType"`
+	ActivatePrice           string           `json:"activatePrice,omitempty"`
+	PriceRate               string           `json:"priceRate,omitempty"`
+	AvgPrice                string           `json:"avgPrice"`
+	OrigType                string           `json:"origType,omitempty"`
+	PositionSide            PositionSideType `json:"positionSide"`
+	ClosePosition           bool             `json:"closePosition"`
+	PriceProtect            bool             `json:"priceProtect"`
+	PriceMatch              string           `json:"priceMatch,omitempty"`
+	SelfTradePreventionMode string           `json:"selfTradePreventionMode,omitempty"`
+	GoodTillDate            int64            `json:"goodTillDate,omitempty"`
+	RateLimitOrder10s       string           `json:"rateLimitOrder10s,omitempty"`
+	RateLimitOrder1m        string           `json:"rateLimitOrder1m,omitempty"`
 }
 
 // ListOpenOrdersService list opened orders
@@ -254,7 +307,7 @@ func (s *ListOpenOrdersService) Do(ctx context.Context, opts ...RequestOption) (
 		return []*Order{}, err
 	}
 	res = make([]*Order, 0)
-	err = json.Unmarshal(data, &res)
+	err = unmarshalResponse(data, &res)
 	if err != nil {
 		return []*Order{}, err
 	}
@@ -305,7 +358,7 @@ func (s *GetOpenOrderService) Do(ctx context.Context, opts ...RequestOption) (re
 		return nil, err
 	}
 	res = new(Order)
-	err = json.Unmarshal(data, res)
+	err = unmarshalResponse(data, res)
 	if err != nil {
 		return nil, err
 	}
@@ -357,7 +410,7 @@ func (s *GetOrderService) Do(ctx context.Context, opts ...RequestOption) (res *O
 		return nil, err
 	}
 	res = new(Order)
-	err = json.Unmarshal(data, res)
+	err = unmarshalResponse(data, res)
 	if err != nil {
 		return nil, err
 	}
@@ -366,30 +419,33 @@ func (s *GetOrderService) Do(ctx context.Context, opts ...RequestOption) (res *O
 
 // Order define order info
 type Order struct {
-	Symbol           string           `json:"symbol"`
-	OrderID          int64            `json:"orderId"`
-	ClientOrderID    string           `json:"clientOrderId"`
-	Price            string           `json:"price"`
-	ReduceOnly       bool             `json:"reduceOnly"`
-	OrigQuantity     string           `json:"origQty"`
-	ExecutedQuantity string           `json:"executedQty"`
-	CumQuantity      string           `json:"cumQty"`
-	CumQuote         string           `json:"cumQuote"`
-	Status           OrderStatusType  `json:"status"`
-	TimeInForce      TimeInForceType  `json:"timeInForce"`
-	Type             OrderType        `json:"type"`
-	Side             SideType         `json:"side"`
-	StopPrice        string           `json:"stopPrice"`
-	Time             int64            `json:"time"`
-	UpdateTime       int64            `json:"updateTime"`
-	WorkingType      WorkingType      `json:"workingType"`
-	ActivatePrice    string           `json:"activatePrice"`
-	PriceRate        string           `json:"priceRate"`
-	AvgPrice         string           `json:"avgPrice"`
-	OrigType         string           `json:"origType"`
-	PositionSide     PositionSideType `json:"positionSide"`
-	PriceProtect     bool             `json:"priceProtect"`
-	ClosePosition    bool             `json:"closePosition"`
+	Symbol                  string           `json:"symbol"`
+	OrderID                 int64            `json:"orderId"`
+	ClientOrderID           string           `json:"clientOrderId"`
+	Price                   string           `json:"price"`
+	ReduceOnly              bool             `json:"reduceOnly"`
+	OrigQuantity            string           `json:"origQty"`
+	ExecutedQuantity        string           `json:"executedQty"`
+	CumQuantity             string           `json:"cumQty"`
+	CumQuote                string           `json:"cumQuote"`
+	Status                  OrderStatusType  `json:"status"`
+	TimeInForce             TimeInForceType  `json:"timeInForce"`
+	Type                    OrderType        `json:"type"`
+	Side                    SideType         `json:"side"`
+	StopPrice               string           `json:"stopPrice"`
+	Time                    int64            `json:"time"`
+	UpdateTime              int64            `json:"updateTime"`
+	WorkingType             WorkingType      `json:"workingType"`
+	ActivatePrice           string           `json:"activatePrice,omitempty"`
+	PriceRate               string           `json:"priceRate,omitempty"`
+	AvgPrice                string           `json:"avgPrice"`
+	OrigType                string           `json:"origType"`
+	PositionSide            PositionSideType `json:"positionSide"`
+	PriceProtect            bool             `json:"priceProtect"`
+	ClosePosition           bool             `json:"closePosition"`
+	PriceMatch              string           `json:"priceMatch,omitempty"`
+	SelfTradePreventionMode string           `json:"selfTradePreventionMode,omitempty"`
+	GoodTillDate            int64            `json:"goodTillDate,omitempty"`
 }
 
 // ListOrdersService all account orders; active, canceled, or filled
@@ -457,7 +513,7 @@ func (s *ListOrdersService) Do(ctx context.Context, opts ...RequestOption) (res
 		return []*Order{}, err
 	}
 	res = make([]*Order, 0)
-	err = json.Unmarshal(data, &res)
+	err = unmarshalResponse(data, &res)
 	if err != nil {
 		return []*Order{}, err
 	}
@@ -509,7 +565,7 @@ func (s *CancelOrderService) Do(ctx context.Context, opts ...RequestOption) (res
 		return nil, err
 	}
 	res = new(CancelOrderResponse)
-	err = json.Unmarshal(data, res)
+	err = unmarshalOrderResponse(data, res)
 	if err != nil {
 		return nil, err
 	}
@@ -568,7 +624,56 @@ func (s *CancelAllOpenOrdersService) Do(ctx context.Context, opts ...RequestOpti
 	return nil
 }
 
-// CancelMultiplesOrdersService cancel a list of orders
+// CountdownCancelAllService sets auto-cancel countdown of all open orders of a symbol,
+// orders are canceled when the countdown expires before being set again
+type CountdownCancelAllService struct {
+	c             *Client
+	symbol        string
+	countdownTime int64
+}
+
+// Symbol set symbol
+func (s *CountdownCancelAllService) Symbol(symbol string) *CountdownCancelAllService {
+	s.symbol = symbol
+	return s
+}
+
+// CountdownTime set countdownTime in milliseconds, 0 cancels the countdown
+func (s *CountdownCancelAllService) CountdownTime(countdownTime int64) *CountdownCancelAllService {
+	s.countdownTime = countdownTime
+	return s
+}
+
+// Do send request
+func (s *CountdownCancelAllService) Do(ctx context.Context, opts ...RequestOption) (res *CountdownCancelAll, err error) {
+	r := &request{
+		method:   http.MethodPost,
+		endpoint: "/fapi/v1/countdownCancelAll",
+		secType:  secTypeSigned,
+	}
+	r.setFormParams(params{
+		"symbol":        s.symbol,
+		"countdownTime": s.countdownTime,
+	})
+	data, _, err := s.c.callAPI(ctx, r, opts...)
+	if err != nil {
+		return nil, err
+	}
+	res = new(CountdownCancelAll)
+	err = unmarshalOrderResponse(data, res)
+	if err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// CountdownCancelAll define auto-cancel countdown info
+type CountdownCancelAll struct {
+	Symbol        string `json:"symbol"`
+	CountdownTime int64  `json:"countdownTime,string"`
+}
+
+// CancelMultiplesOrdersService cancel a list of orders of a symbol
 type CancelMultiplesOrdersService struct {
 	c                     *Client
 	symbol                string
@@ -576,52 +681,199 @@ type CancelMultiplesOrdersService struct {
 	origClientOrderIDList []string
 }
 
+// CancelBatchOrdersResponse define result of canceling a batch of orders
+type CancelBatchOrdersResponse struct {
+	// N is the number of orders of the batch
+	N int
+	// Orders are the orders canceled, in the order of the batch
+	Orders []*CancelOrderResponse
+	// Errors has an item per order of the batch, nil if it was canceled or the
+	// *common.APIError it failed with
+	Errors []error
+
+	results []*CancelOrderResponse
+}
+
+// Result returns the order canceled for order i of the batch, or the error it failed with
+func (r *CancelBatchOrdersResponse) Result(i int) (*CancelOrderResponse, error) {
+	return r.results[i], r.Errors[i]
+}
+
 // Symbol set symbol
 func (s *CancelMultiplesOrdersService) Symbol(symbol string) *CancelMultiplesOrdersService {
 	s.symbol = symbol
 	return s
 }
 
-// OrderID set orderID
+// OrderIDList set the IDs of the orders to cancel
 func (s *CancelMultiplesOrdersService) OrderIDList(orderIDList []int64) *CancelMultiplesOrdersService {
 	s.orderIDList = orderIDList
 	return s
 }
 
-// OrigClientOrderID set origClientOrderID
+// OrigClientOrderIDList set the client IDs of the orders to cancel, instead of OrderIDList
 func (s *CancelMultiplesOrdersService) OrigClientOrderIDList(origClientOrderIDList []string) *CancelMultiplesOrdersService {
 	s.origClientOrderIDList = origClientOrderIDList
 	return s
 }
 
-// Do send request
+// Do send request, the response has an item per order, empty for the orders which failed.
+// DoBatch returns the error of each order and splits lists of more than 10 orders.
 func (s *CancelMultiplesOrdersService) Do(ctx context.Context, opts ...RequestOption) (res []*CancelOrderResponse, err error) {
+	r, err := s.request(s.orderIDList, s.origClientOrderIDList)
+	if err != nil {
+		return nil, err
+	}
+	data, _, err := s.c.callAPI(ctx, r, opts...)
+	if err != nil {
+		return nil, err
+	}
+	res = make([]*CancelOrderResponse, 0)
+	err = unmarshalOrderResponse(data, &res)
+	if err != nil {
+		return []*CancelOrderResponse{}, err
+	}
+	return res, nil
+}
+
+// DoBatch send requests of at most 10 orders in turn and returns the outcome of each order. If
+// a request fails as a whole, its error is set for each of its orders and the first one is
+// returned along with the outcome of the other orders.
+func (s *CancelMultiplesOrdersService) DoBatch(ctx context.Context, opts ...RequestOption) (res *CancelBatchOrdersResponse, err error) {
+	n := max(len(s.orderIDList), len(s.origClientOrderIDList))
+	res = &CancelBatchOrdersResponse{
+		N:       n,
+		Errors:  make([]error, n),
+		results: make([]*CancelOrderResponse, n),
+	}
+	if len(s.orderIDList) > 0 && len(s.origClientOrderIDList) > 0 {
+		for i := range res.Errors {
+			res.Errors[i] = ErrCancelBatchOrderIDs
+		}
+		return res, ErrCancelBatchOrderIDs
+	}
+	err = doInBatches(n, maxBatchCancels, res.Errors, func(start, end int) error {
+		var orderIDs []int64
+		var clientOrderIDs []string
+		if len(s.orderIDList) > 0 {
+			orderIDs = s.orderIDList[start:end]
+		} else {
+			clientOrderIDs = s.origClientOrderIDList[start:end]
+		}
+		batch, err := s.cancelBatch(ctx, orderIDs, clientOrderIDs, opts...)
+		if err != nil {
+			return err
+		}
+		copy(res.Errors[start:end], batch.Errors)
+		copy(res.results[start:end], batch.results)
+		return nil
+	})
+	for _, order := range res.results {
+		if order != nil {
+			res.Orders = append(res.Orders, order)
+		}
+	}
+	return res, err
+}
+
+// request returns the request canceling orders
+func (s *CancelMultiplesOrdersService) request(orderIDs []int64, clientOrderIDs []string) (*request, error) {
 	r := &request{
 		method:   http.MethodDelete,
 		endpoint: "/fapi/v1/batchOrders",
 		secType:  secTypeSigned,
 	}
 	r.setFormParam("symbol", s.symbol)
-	if s.orderIDList != nil {
-		// convert a slice of integers to a string e.g. [1 2 3] => "[1,2,3]"
-		orderIDListString := strings.Join(strings.Fields(fmt.Sprint(s.orderIDList)), ",")
-		r.setFormParam("orderIdList", orderIDListString)
+	if len(orderIDs) > 0 {
+		b, err := JSONCodec.Marshal(orderIDs)
+		if err != nil {
+			return nil, err
+		}
+		r.setFormParam("orderIdList", string(b))
 	}
-	if s.origClientOrderIDList != nil {
-		r.setFormParam("origClientOrderIdList", s.origClientOrderIDList)
+	if len(clientOrderIDs) > 0 {
+		b, err := JSONCodec.Marshal(clientOrderIDs)
+		if err != nil {
+			return nil, err
+		}
+		r.setFormParam("origClientOrderIdList", string(b))
+	}
+	return r, nil
+}
+
+// cancelBatch cancels orders with a single request
+func (s *CancelMultiplesOrdersService) cancelBatch(ctx context.Context, orderIDs []int64, clientOrderIDs []string, opts ...RequestOption) (*CancelBatchOrdersResponse, error) {
+	r, err := s.request(orderIDs, clientOrderIDs)
+	if err != nil {
+		return nil, err
 	}
 	data, _, err := s.c.callAPI(ctx, r, opts...)
 	if err != nil {
 		return nil, err
 	}
-	res = make([]*CancelOrderResponse, 0)
-	err = json.Unmarshal(data, &res)
+	rawMessages, errs, err := splitBatchResponse(data)
 	if err != nil {
-		return []*CancelOrderResponse{}, err
+		return nil, err
+	}
+
+	res := &CancelBatchOrdersResponse{
+		N:       len(rawMessages),
+		Errors:  errs,
+		results: make([]*CancelOrderResponse, len(rawMessages)),
+	}
+	for i, raw := range rawMessages {
+		if raw == nil {
+			continue
+		}
+		o := new(CancelOrderResponse)
+		if err := unmarshalOrderResponse(raw, o); err != nil {
+			return nil, err
+		}
+		res.results[i] = o
+		res.Orders = append(res.Orders, o)
 	}
 	return res, nil
 }
 
+// doInBatches calls do with the bounds of each batch of at most size of n orders in turn. The
+// error of a batch failing as a whole is set in errs for each of its orders, the first one is
+// returned.
+func doInBatches(n, size int, errs []error, do func(start, end int) error) error {
+	var first error
+	for start := 0; start < n; start += size {
+		end := min(start+size, n)
+		if err := do(start, end); err != nil {
+			for i := start; i < end; i++ {
+				errs[i] = err
+			}
+			if first == nil {
+				first = err
+			}
+		}
+	}
+	return first
+}
+
+// splitBatchResponse splits the response of a batch request into the item of each order,
+// nil if the order failed, and the error of each order, nil if it succeeded
+func splitBatchResponse(data []byte) ([]json.RawMessage, []error, error) {
+	var rawMessages []json.RawMessage
+	if err := JSONCodec.Unmarshal(data, &rawMessages); err != nil {
+		return nil, nil, err
+	}
+	errs := make([]error, len(rawMessages))
+	for i, raw := range rawMessages {
+		apiErr := new(common.APIError)
+		if err := JSONCodec.Unmarshal(raw, apiErr); err != nil {
+			return nil, nil, err
+		}
+		if apiErr.Code != 0 {
+			rawMessages[i], errs[i] = nil, apiErr
+		}
+	}
+	return rawMessages, errs, nil
+}
+
 // ListLiquidationOrdersService list liquidation orders
 type ListLiquidationOrdersService struct {
 	c         *Client
@@ -679,7 +931,7 @@ func (s *ListLiquidationOrdersService) Do(ctx context.Context, opts ...RequestOp
 		return []*LiquidationOrder{}, err
 	}
 	res = make([]*LiquidationOrder, 0)
-	err = json.Unmarshal(data, &res)
+	err = unmarshalResponse(data, &res)
 	if err != nil {
 		return []*LiquidationOrder{}, err
 	}
@@ -766,7 +1018,7 @@ func (s *ListUserLiquidationOrdersService) Do(ctx context.Context, opts ...Reque
 		return []*UserLiquidationOrder{}, err
 	}
 	res = make([]*UserLiquidationOrder, 0)
-	err = json.Unmarshal(data, &res)
+	err = unmarshalResponse(data, &res)
 	if err != nil {
 		return []*UserLiquidationOrder{}, err
 	}
@@ -797,29 +1049,78 @@ type UserLiquidationOrder struct {
 	UpdateTime       int64            `json:"updateTime"`
 }
 
+// CreateBatchOrdersService places several orders at once
 type CreateBatchOrdersService struct {
 	c      *Client
 	orders []*CreateOrderService
 }
 
+// CreateBatchOrdersResponse define result of placing a batch of orders
 type CreateBatchOrdersResponse struct {
+	// N is the number of orders of the batch
+	N int
+	// Orders are the orders placed, in the order of the batch
 	Orders []*Order
+	// Errors has an item per order of the batch, nil if it was placed or the *common.APIError
+	// it was rejected with
+	Errors []error
+
+	results []*Order
+}
+
+// Result returns the order placed for order i of the batch, or the error it failed with
+func (r *CreateBatchOrdersResponse) Result(i int) (*Order, error) {
+	return r.results[i], r.Errors[i]
 }
 
+// OrderList set the orders of the batch. Batches of more than 5 orders are split into
+// requests of 5 orders sent in turn.
 func (s *CreateBatchOrdersService) OrderList(orders []*CreateOrderService) *CreateBatchOrdersService {
 	s.orders = orders
 	return s
 }
 
+// Do send request. If a request of a split batch fails as a whole, its error is set for each
+// of its orders and the first one is returned along with the outcome of the other orders.
 func (s *CreateBatchOrdersService) Do(ctx context.Context, opts ...RequestOption) (res *CreateBatchOrdersResponse, err error) {
+	res = &CreateBatchOrdersResponse{
+		N:       len(s.orders),
+		Errors:  make([]error, len(s.orders)),
+		results: make([]*Order, len(s.orders)),
+	}
+	err = doInBatches(len(s.orders), maxBatchOrders, res.Errors, func(start, end int) error {
+		batch, err := s.doBatch(ctx, s.orders[start:end], opts...)
+		if err != nil {
+			return err
+		}
+		copy(res.Errors[start:end], batch.Errors)
+		copy(res.results[start:end], batch.results)
+		return nil
+	})
+	for _, order := range res.results {
+		if order != nil {
+			res.Orders = append(res.Orders, order)
+		}
+	}
+	return res, err
+}
+
+// doBatch places orders with a single request
+func (s *CreateBatchOrdersService) doBatch(ctx context.Context, orders []*CreateOrderService, opts ...RequestOption) (*CreateBatchOrdersResponse, error) {
 	r := &request{
 		method:   http.MethodPost,
 		endpoint: "/fapi/v1/batchOrders",
 		secType:  secTypeSigned,
 	}
 
-	orders := []params{}
-	for _, order := range s.orders {
+	batch := []params{}
+	symbols := make([]string, 0, len(orders))
+	for _, order := range orders {
+		if order.positionIntent != nil {
+			if err := order.applyPositionIntent(ctx, s.c, opts...); err != nil {
+				return nil, err
+			}
+		}
 		m := params{
 			"symbol":           order.symbol,
 			"side":             order.side,
@@ -861,11 +1162,15 @@ func (s *CreateBatchOrdersService) Do(ctx context.Context, opts ...RequestOption
 		if order.closePosition != nil {
 			m["closePosition"] = *order.closePosition
 		}
-		orders = append(orders, m)
+		if err := checkOrderRisk(s.c.RiskChecker, m); err != nil {
+			return nil, err
+		}
+		batch = append(batch, m)
+		symbols = append(symbols, order.symbol)
 	}
-	b, err := json.Marshal(orders)
+	b, err := json.Marshal(batch)
 	if err != nil {
-		return &CreateBatchOrdersResponse{}, err
+		return nil, err
 	}
 	m := params{
 		"batchOrders": string(b),
@@ -873,35 +1178,260 @@ func (s *CreateBatchOrdersService) Do(ctx context.Context, opts ...RequestOption
 
 	r.setFormParams(m)
 
+	done, err := admitOrders(ctx, s.c.CircuitBreaker, s.c.OrderLimiter, s.c.SymbolThrottle, symbols...)
+	if err != nil {
+		return nil, err
+	}
 	data, _, err := s.c.callAPI(ctx, r, opts...)
+	if err != nil {
+		done(err)
+		return nil, err
+	}
+	rawMessages, errs, err := splitBatchResponse(data)
+	done(batchOutcome(errs, err))
+	if err != nil {
+		return nil, err
+	}
+
+	res := &CreateBatchOrdersResponse{
+		N:       len(rawMessages),
+		Errors:  errs,
+		results: make([]*Order, len(rawMessages)),
+	}
+	for i, raw := range rawMessages {
+		if raw == nil {
+			continue
+		}
+		o := new(Order)
+		if err := unmarshalOrderResponse(raw, o); err != nil {
+			return nil, err
+		}
+		res.results[i] = o
+		res.Orders = append(res.Orders, o)
+	}
+	return res, nil
+}
 
+// batchOutcome returns the outcome of a batch recorded by the circuit breaker: err if the
+// response is invalid, the first reject if every order was rejected, nil otherwise
+func batchOutcome(errs []error, err error) error {
 	if err != nil {
-		return &CreateBatchOrdersResponse{}, err
+		return err
+	}
+	for _, e := range errs {
+		if e == nil {
+			return nil
+		}
 	}
+	if len(errs) == 0 {
+		return nil
+	}
+	return errs[0]
+}
+
+// ModifyOrderService modify the price or quantity of a LIMIT order
+type ModifyOrderService struct {
+	c                 *Client
+	symbol            string
+	side              SideType
+	orderID           *int64
+	origClientOrderID *string
+	quantity          string
+	price             string
+}
 
-	rawMessages := make([]*json.RawMessage, 0)
+// Symbol set symbol
+func (s *ModifyOrderService) Symbol(symbol string) *ModifyOrderService {
+	s.symbol = symbol
+	return s
+}
+
+// Side set side
+func (s *ModifyOrderService) Side(side SideType) *ModifyOrderService {
+	s.side = side
+	return s
+}
+
+// OrderID set orderID
+func (s *ModifyOrderService) OrderID(orderID int64) *ModifyOrderService {
+	s.orderID = &orderID
+	return s
+}
+
+// OrigClientOrderID set origClientOrderID
+func (s *ModifyOrderService) OrigClientOrderID(origClientOrderID string) *ModifyOrderService {
+	s.origClientOrderID = &origClientOrderID
+	return s
+}
 
-	err = json.Unmarshal(data, &rawMessages)
+// Quantity set quantity
+func (s *ModifyOrderService) Quantity(quantity string) *ModifyOrderService {
+	s.quantity = quantity
+	return s
+}
 
+// Price set price
+func (s *ModifyOrderService) Price(price string) *ModifyOrderService {
+	s.price = price
+	return s
+}
+
+func (s *ModifyOrderService) params() params {
+	m := params{
+		"symbol":   s.symbol,
+		"side":     s.side,
+		"quantity": s.quantity,
+		"price":    s.price,
+	}
+	if s.orderID != nil {
+		m["orderId"] = *s.orderID
+	}
+	if s.origClientOrderID != nil {
+		m["origClientOrderId"] = *s.origClientOrderID
+	}
+	return m
+}
+
+// Do send request
+func (s *ModifyOrderService) Do(ctx context.Context, opts ...RequestOption) (res *Order, err error) {
+	if s.orderID == nil && s.origClientOrderID == nil {
+		return nil, errors.New("either orderId or origClientOrderId must be sent")
+	}
+	r := &request{
+		method:   http.MethodPut,
+		endpoint: "/fapi/v1/order",
+		secType:  secTypeSigned,
+	}
+	m := s.params()
+	if err := checkModifyRisk(s.c.RiskChecker, m); err != nil {
+		return nil, err
+	}
+	done, err := admitOrders(ctx, s.c.CircuitBreaker, s.c.OrderLimiter, s.c.SymbolThrottle, s.symbol)
+	if err != nil {
+		return nil, err
+	}
+	r.setFormParams(m)
+	data, _, err := s.c.callAPI(ctx, r, opts...)
+	done(err)
+	if err != nil {
+		return nil, err
+	}
+	res = new(Order)
+	err = unmarshalOrderResponse(data, res)
 	if err != nil {
-		return &CreateBatchOrdersResponse{}, err
+		return nil, err
 	}
+	return res, nil
+}
 
-	batchCreateOrdersResponse := new(CreateBatchOrdersResponse)
+// ModifyBatchOrdersService modify several LIMIT orders at once, e.g. to re-price a quote ladder
+type ModifyBatchOrdersService struct {
+	c      *Client
+	orders []*ModifyOrderService
+}
 
-	for _, j := range rawMessages {
-		o := new(Order)
-		if err := json.Unmarshal(*j, o); err != nil {
-			return &CreateBatchOrdersResponse{}, err
-		}
+// ModifyBatchOrdersResponse define result of modifying a batch of orders
+type ModifyBatchOrdersResponse struct {
+	// N is the number of orders of the batch
+	N int
+	// Orders are the orders modified, in the order of the batch
+	Orders []*Order
+	// Errors has an item per order of the batch, nil if it was modified or the
+	// *common.APIError it failed with
+	Errors []error
 
-		if o.ClientOrderID != "" {
-			batchCreateOrdersResponse.Orders = append(batchCreateOrdersResponse.Orders, o)
-			continue
-		}
+	results []*Order
+}
+
+// Result returns the order modified for order i of the batch, or the error it failed with
+func (r *ModifyBatchOrdersResponse) Result(i int) (*Order, error) {
+	return r.results[i], r.Errors[i]
+}
 
+// OrderList set the modifications of the batch. Batches of more than 5 orders are split into
+// requests of 5 orders sent in turn.
+func (s *ModifyBatchOrdersService) OrderList(orders []*ModifyOrderService) *ModifyBatchOrdersService {
+	s.orders = orders
+	return s
+}
+
+// Do send request. If a request of a split batch fails as a whole, its error is set for each
+// of its orders and the first one is returned along with the outcome of the other orders.
+func (s *ModifyBatchOrdersService) Do(ctx context.Context, opts ...RequestOption) (res *ModifyBatchOrdersResponse, err error) {
+	res = &ModifyBatchOrdersResponse{
+		N:       len(s.orders),
+		Errors:  make([]error, len(s.orders)),
+		results: make([]*Order, len(s.orders)),
 	}
+	err = doInBatches(len(s.orders), maxBatchOrders, res.Errors, func(start, end int) error {
+		batch, err := s.doBatch(ctx, s.orders[start:end], opts...)
+		if err != nil {
+			return err
+		}
+		copy(res.Errors[start:end], batch.Errors)
+		copy(res.results[start:end], batch.results)
+		return nil
+	})
+	for _, order := range res.results {
+		if order != nil {
+			res.Orders = append(res.Orders, order)
+		}
+	}
+	return res, err
+}
 
-	return batchCreateOrdersResponse, nil
+// doBatch modifies orders with a single request
+func (s *ModifyBatchOrdersService) doBatch(ctx context.Context, orders []*ModifyOrderService, opts ...RequestOption) (*ModifyBatchOrdersResponse, error) {
+	r := &request{
+		method:   http.MethodPut,
+		endpoint: "/fapi/v1/batchOrders",
+		secType:  secTypeSigned,
+	}
+	batch := make([]params, 0, len(orders))
+	symbols := make([]string, 0, len(orders))
+	for _, order := range orders {
+		m := order.params()
+		if err := checkModifyRisk(s.c.RiskChecker, m); err != nil {
+			return nil, err
+		}
+		batch = append(batch, m)
+		symbols = append(symbols, order.symbol)
+	}
+	b, err := json.Marshal(batch)
+	if err != nil {
+		return nil, err
+	}
+	r.setFormParam("batchOrders", string(b))
+	done, err := admitOrders(ctx, s.c.CircuitBreaker, s.c.OrderLimiter, s.c.SymbolThrottle, symbols...)
+	if err != nil {
+		return nil, err
+	}
+	data, _, err := s.c.callAPI(ctx, r, opts...)
+	if err != nil {
+		done(err)
+		return nil, err
+	}
+	rawMessages, errs, err := splitBatchResponse(data)
+	done(batchOutcome(errs, err))
+	if err != nil {
+		return nil, err
+	}
 
+	res := &ModifyBatchOrdersResponse{
+		N:       len(rawMessages),
+		Errors:  errs,
+		results: make([]*Order, len(rawMessages)),
+	}
+	for i, raw := range rawMessages {
+		if raw == nil {
+			continue
+		}
+		o := new(Order)
+		if err := unmarshalOrderResponse(raw, o); err != nil {
+			return nil, err
+		}
+		res.results[i] = o
+		res.Orders = append(res.Orders, o)
+	}
+	return res, nil
 }
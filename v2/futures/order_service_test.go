@@ -1,8 +1,16 @@
 package futures
 
 import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
 	"testing"
 
+	"github.com/adshao/go-binance/v2/common"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	"github.com/stretchr/testify/suite"
 )
 
@@ -113,6 +121,34 @@ func (s *orderServiceTestSuite) TestCreateOrder() {
 	s.assertCreateOrderResponseEqual(e, res)
 }
 
+func (s *orderServiceTestSuite) TestCreateOrderStrictDecoding() {
+	StrictDecoding = true
+	var schemaErrs []error
+	StrictDecodingErrHandler = func(err error) { schemaErrs = append(schemaErrs, err) }
+	defer func() {
+		StrictDecoding = false
+		StrictDecodingErrHandler = nil
+	}()
+
+	data := []byte(`{"clientOrderId": "testOrder", "cumQty": "0", "cumQuote": "0", "executedQty": "0",
+		"orderId": 22542179, "origQty": "10", "price": "10000", "reduceOnly": false, "side": "SELL",
+		"status": "NEW", "stopPrice": "0", "symbol": "BTCUSDT", "timeInForce": "GTC", "type": "LIMIT",
+		"origType": "LIMIT", "updateTime": 1566818724722, "workingType": "CONTRACT_PRICE", "avgPrice": "0",
+		"positionSide": "BOTH", "closePosition": false, "priceProtect": false, "priceMatch": "NONE",
+		"selfTradePreventionMode": "NONE", "goodTillDate": 0, "newField": 1}`)
+	s.mockDo(data, nil)
+	defer s.assertDo()
+	s.assertReq(func(r *request) {})
+
+	res, err := s.client.NewCreateOrderService().Symbol("BTCUSDT").Side(SideTypeSell).
+		Type(OrderTypeLimit).TimeInForce(TimeInForceTypeGTC).Quantity("10").Price("10000").
+		Do(newContext())
+	s.r().NoError(err)
+	s.r().Equal(int64(22542179), res.OrderID)
+	s.r().Equal("NONE", res.SelfTradePreventionMode)
+	s.r().Equal([]error{&common.SchemaError{Type: "futures.CreateOrderResponse", Field: "newField", Reason: common.SchemaErrorUnknownField}}, schemaErrs)
+}
+
 func (s *baseOrderTestSuite) assertCreateOrderResponseEqual(e, a *CreateOrderResponse) {
 	r := s.r()
 	r.Equal(e.ClientOrderID, a.ClientOrderID, "ClientOrderID")
@@ -546,6 +582,166 @@ func (s *orderServiceTestSuite) TestCancelAllOpenOrders() {
 	s.r().NoError(err)
 }
 
+func (s *orderServiceTestSuite) TestCountdownCancelAll() {
+	data := []byte(`{
+		"symbol": "BTCUSDT",
+		"countdownTime": "100000"
+	}`)
+	s.mockDo(data, nil)
+	defer s.assertDo()
+
+	symbol := "BTCUSDT"
+	s.assertReq(func(r *request) {
+		e := newSignedRequest().setFormParams(params{
+			"symbol":        symbol,
+			"countdownTime": 100000,
+		})
+		s.assertRequestEqual(e, r)
+	})
+
+	res, err := s.client.NewCountdownCancelAllService().Symbol(symbol).
+		CountdownTime(100000).Do(newContext())
+	s.r().NoError(err)
+	s.r().Equal(&CountdownCancelAll{Symbol: symbol, CountdownTime: 100000}, res)
+}
+
+func (s *orderServiceTestSuite) TestCreateBatchOrders() {
+	data := []byte(`[
+		{"clientOrderId":"quote1","orderId":1,"symbol":"BTCUSDT","side":"BUY","status":"NEW"},
+		{"code":-2019,"msg":"Margin is insufficient."}
+	]`)
+	s.mockDo(data, nil)
+	defer s.assertDo()
+
+	s.assertReq(func(r *request) {
+		e := newSignedRequest().setFormParams(params{
+			"batchOrders": `[{"newClientOrderId":"quote1","newOrderRespType":"","price":"100","quantity":"1","side":"BUY","symbol":"BTCUSDT","timeInForce":"GTX","type":"LIMIT"},` +
+				`{"newClientOrderId":"quote2","newOrderRespType":"","price":"101","quantity":"1","side":"SELL","symbol":"BTCUSDT","timeInForce":"GTX","type":"LIMIT"}]`,
+		})
+		s.assertRequestEqual(e, r)
+	})
+
+	res, err := s.client.NewCreateBatchOrdersService().OrderList([]*CreateOrderService{
+		newQuote(s.client.Client, "quote1", SideTypeBuy, "100"),
+		newQuote(s.client.Client, "quote2", SideTypeSell, "101"),
+	}).Do(newContext())
+	r := s.r()
+	r.NoError(err)
+	r.Equal(2, res.N)
+	r.Len(res.Orders, 1)
+	order, err := res.Result(0)
+	r.NoError(err)
+	r.Equal(int64(1), order.OrderID)
+	order, err = res.Result(1)
+	r.Nil(order)
+	r.True(common.IsAPIErrorCode(err, -2019))
+}
+
+func (s *orderServiceTestSuite) TestCancelBatchOrders() {
+	data := []byte(`[
+		{"clientOrderId":"quote1","orderId":1,"symbol":"BTCUSDT","status":"CANCELED"},
+		{"code":-2011,"msg":"Unknown order sent."}
+	]`)
+	s.mockDo(data, nil)
+	defer s.assertDo()
+
+	s.assertReq(func(r *request) {
+		e := newSignedRequest().setFormParams(params{
+			"symbol":                "BTCUSDT",
+			"origClientOrderIdList": `["quote1","quote2"]`,
+		})
+		s.assertRequestEqual(e, r)
+	})
+
+	res, err := s.client.NewCancelMultipleOrdersService().Symbol("BTCUSDT").
+		OrigClientOrderIDList([]string{"quote1", "quote2"}).DoBatch(newContext())
+	r := s.r()
+	r.NoError(err)
+	r.Equal(2, res.N)
+	r.Equal([]*CancelOrderResponse{{ClientOrderID: "quote1", OrderID: 1, Symbol: "BTCUSDT", Status: OrderStatusTypeCanceled}}, res.Orders)
+	r.NoError(res.Errors[0])
+	r.True(common.IsAPIErrorCode(res.Errors[1], -2011))
+}
+
+func (s *orderServiceTestSuite) TestCancelMultipleOrders() {
+	data := []byte(`[
+		{"clientOrderId":"quote1","orderId":1,"symbol":"BTCUSDT","status":"CANCELED"},
+		{"code":-2011,"msg":"Unknown order sent."}
+	]`)
+	s.mockDo(data, nil)
+	defer s.assertDo()
+
+	s.assertReq(func(r *request) {
+		e := newSignedRequest().setFormParams(params{
+			"symbol":      "BTCUSDT",
+			"orderIdList": "[1,2]",
+		})
+		s.assertRequestEqual(e, r)
+	})
+
+	res, err := s.client.NewCancelMultipleOrdersService().Symbol("BTCUSDT").
+		OrderIDList([]int64{1, 2}).Do(newContext())
+	r := s.r()
+	r.NoError(err)
+	r.Len(res, 2)
+	r.Equal(int64(1), res[0].OrderID)
+	r.Equal(&CancelOrderResponse{}, res[1])
+}
+
+func (s *orderServiceTestSuite) TestModifyOrder() {
+	data := []byte(`{"clientOrderId":"quote1","orderId":1,"symbol":"BTCUSDT","side":"BUY","price":"99","origQty":"2","status":"NEW"}`)
+	s.mockDo(data, nil)
+	defer s.assertDo()
+
+	s.assertReq(func(r *request) {
+		e := newSignedRequest().setFormParams(params{
+			"symbol":   "BTCUSDT",
+			"side":     SideTypeBuy,
+			"orderId":  1,
+			"quantity": "2",
+			"price":    "99",
+		})
+		s.assertRequestEqual(e, r)
+	})
+
+	res, err := s.client.NewModifyOrderService().Symbol("BTCUSDT").Side(SideTypeBuy).
+		OrderID(1).Quantity("2").Price("99").Do(newContext())
+	r := s.r()
+	r.NoError(err)
+	r.Equal("99", res.Price)
+	r.Equal("2", res.OrigQuantity)
+}
+
+func (s *orderServiceTestSuite) TestModifyBatchOrders() {
+	data := []byte(`[
+		{"clientOrderId":"quote1","orderId":1,"symbol":"BTCUSDT","side":"BUY","price":"99","status":"NEW"},
+		{"code":-5027,"msg":"No need to modify the order."}
+	]`)
+	s.mockDo(data, nil)
+	defer s.assertDo()
+
+	s.assertReq(func(r *request) {
+		e := newSignedRequest().setFormParams(params{
+			"batchOrders": `[{"orderId":1,"price":"99","quantity":"1","side":"BUY","symbol":"BTCUSDT"},` +
+				`{"origClientOrderId":"quote2","price":"101","quantity":"1","side":"SELL","symbol":"BTCUSDT"}]`,
+		})
+		s.assertRequestEqual(e, r)
+	})
+
+	res, err := s.client.NewModifyBatchOrdersService().OrderList([]*ModifyOrderService{
+		s.client.NewModifyOrderService().Symbol("BTCUSDT").Side(SideTypeBuy).OrderID(1).Quantity("1").Price("99"),
+		s.client.NewModifyOrderService().Symbol("BTCUSDT").Side(SideTypeSell).OrigClientOrderID("quote2").Quantity("1").Price("101"),
+	}).Do(newContext())
+	r := s.r()
+	r.NoError(err)
+	r.Equal(2, res.N)
+	order, err := res.Result(0)
+	r.NoError(err)
+	r.Equal("99", order.Price)
+	_, err = res.Result(1)
+	r.True(common.IsAPIErrorCode(err, -5027))
+}
+
 func (s *orderServiceTestSuite) TestListLiquidationOrders() {
 	data := []byte(`[
 		{
@@ -613,3 +809,100 @@ func (s *orderServiceTestSuite) assertLiquidationEqual(e, a *LiquidationOrder) {
 	r.Equal(e.Type, a.Type, "Type")
 	r.Equal(e.Side, a.Side, "Side")
 }
+
+func newQuote(c *Client, id string, side SideType, price string) *CreateOrderService {
+	return c.NewCreateOrderService().Symbol("BTCUSDT").Side(side).Type(OrderTypeLimit).
+		TimeInForce(TimeInForceTypeGTX).Quantity("1").Price(price).NewClientOrderID(id)
+}
+
+func TestCreateBatchOrdersSplit(t *testing.T) {
+	c := NewClient("apiKey", "secretKey")
+	requests := scriptedDo(c,
+		scriptedResponse{status: http.StatusOK, body: `[{"orderId":1},{"orderId":2},{"orderId":3},{"orderId":4},{"code":-2019,"msg":"Margin is insufficient."}]`},
+		scriptedResponse{status: http.StatusServiceUnavailable, body: `{"code":-1001,"msg":"Internal error"}`},
+		scriptedResponse{status: http.StatusOK, body: `[{"orderId":11},{"orderId":12}]`},
+	)
+	var quotes []*CreateOrderService
+	for i := 0; i < 12; i++ {
+		quotes = append(quotes, newQuote(c, fmt.Sprintf("quote%d", i), SideTypeBuy, "100"))
+	}
+
+	res, err := c.NewCreateBatchOrdersService().OrderList(quotes).Do(newContext())
+	assert.True(t, common.IsAPIErrorCode(err, -1001))
+	require.Len(t, *requests, 3)
+	for i, req := range *requests {
+		body, err := io.ReadAll(req.Body)
+		require.NoError(t, err)
+		form, err := url.ParseQuery(string(body))
+		require.NoError(t, err)
+		var batch []map[string]interface{}
+		require.NoError(t, json.Unmarshal([]byte(form.Get("batchOrders")), &batch))
+		assert.Len(t, batch, []int{5, 5, 2}[i])
+		assert.Equal(t, fmt.Sprintf("quote%d", i*5), batch[0]["newClientOrderId"])
+	}
+
+	assert.Equal(t, 12, res.N)
+	require.Len(t, res.Errors, 12)
+	assert.Len(t, res.Orders, 6)
+	order, err := res.Result(3)
+	require.NoError(t, err)
+	assert.Equal(t, int64(4), order.OrderID)
+	_, err = res.Result(4)
+	assert.True(t, common.IsAPIErrorCode(err, -2019))
+	for i := 5; i < 10; i++ {
+		_, err = res.Result(i)
+		assert.True(t, common.IsAPIErrorCode(err, -1001))
+	}
+	order, err = res.Result(11)
+	require.NoError(t, err)
+	assert.Equal(t, int64(12), order.OrderID)
+}
+
+func TestBatchOrdersFailure(t *testing.T) {
+	c := NewClient("apiKey", "secretKey")
+	failure := scriptedResponse{status: http.StatusServiceUnavailable, body: `{"code":-1001,"msg":"Internal error"}`}
+	scriptedDo(c, failure, failure)
+
+	// every order of a batch failing as a whole holds the error
+	created, err := c.NewCreateBatchOrdersService().OrderList([]*CreateOrderService{
+		newQuote(c, "quote1", SideTypeBuy, "100"),
+		newQuote(c, "quote2", SideTypeSell, "101"),
+	}).Do(newContext())
+	assert.True(t, common.IsAPIErrorCode(err, -1001))
+	assert.Equal(t, 2, created.N)
+	order, err := created.Result(1)
+	assert.Nil(t, order)
+	assert.True(t, common.IsAPIErrorCode(err, -1001))
+
+	canceled, err := c.NewCancelMultipleOrdersService().Symbol("BTCUSDT").OrderIDList([]int64{1, 2}).DoBatch(newContext())
+	assert.True(t, common.IsAPIErrorCode(err, -1001))
+	assert.Equal(t, 2, canceled.N)
+	assert.Empty(t, canceled.Orders)
+	_, err = canceled.Result(1)
+	assert.True(t, common.IsAPIErrorCode(err, -1001))
+}
+
+func TestCancelBatchOrdersSplit(t *testing.T) {
+	c := NewClient("apiKey", "secretKey")
+	requests := scriptedDo(c, scriptedResponse{status: http.StatusOK, body: `[{"orderId":1}]`})
+	orderIDs := make([]int64, 25)
+	for i := range orderIDs {
+		orderIDs[i] = int64(i + 1)
+	}
+
+	res, err := c.NewCancelMultipleOrdersService().Symbol("BTCUSDT").OrderIDList(orderIDs).DoBatch(newContext())
+	require.NoError(t, err)
+	assert.Equal(t, 25, res.N)
+	require.Len(t, *requests, 3)
+	body, err := io.ReadAll((*requests)[2].Body)
+	require.NoError(t, err)
+	form, err := url.ParseQuery(string(body))
+	require.NoError(t, err)
+	assert.Equal(t, "[21,22,23,24,25]", form.Get("orderIdList"))
+
+	res, err = c.NewCancelMultipleOrdersService().Symbol("BTCUSDT").OrderIDList([]int64{1}).
+		OrigClientOrderIDList([]string{"quote1"}).DoBatch(newContext())
+	assert.ErrorIs(t, err, ErrCancelBatchOrderIDs)
+	_, err = res.Result(0)
+	assert.ErrorIs(t, err, ErrCancelBatchOrderIDs)
+}
@@ -1,16 +1,11 @@
 package futures
 
 import (
+	"bytes"
 	"context"
-	"crypto/hmac"
-	"crypto/sha256"
-	"encoding/json"
 	"errors"
-	"fmt"
-	"net/url"
 
 	"github.com/adshao/go-binance/v2/common"
-	"github.com/google/uuid"
 )
 
 // WsApiMethodType define method name for websocket API
@@ -23,17 +18,62 @@ type WsApiRequest struct {
 	Params params          `json:"params"`
 }
 
+// orderedWsApiRequest define WsApiRequest sent with ordered params
+type orderedWsApiRequest struct {
+	Id     string          `json:"id"`
+	Method WsApiMethodType `json:"method"`
+	Params orderedParams   `json:"params"`
+}
+
+// encodeTo writes r into buf in a single pass, as encoding/json encodes WsApiRequest
+func (r *orderedWsApiRequest) encodeTo(buf *bytes.Buffer) error {
+	b := buf.AvailableBuffer()
+	b = append(b, `{"id":`...)
+	b = appendJSONString(b, r.Id)
+	b = append(b, `,"method":`...)
+	b = appendJSONString(b, string(r.Method))
+	b = append(b, `,"params":`...)
+	b, err := r.Params.appendJSON(b)
+	if err != nil {
+		return err
+	}
+	b = append(b, '}')
+	// b shares the memory of buf unless it outgrew it
+	buf.Write(b)
+	return nil
+}
+
+// WsApiRateLimit define usage of a rate limit returned with websocket API responses
+type WsApiRateLimit struct {
+	RateLimitType string `json:"rateLimitType"`
+	Interval      string `json:"interval"`
+	IntervalNum   int64  `json:"intervalNum"`
+	Limit         int64  `json:"limit"`
+	Count         int64  `json:"count"`
+}
+
 const (
 	apiKey                                 = "apiKey"
 	WsApiMethodOrderPlace  WsApiMethodType = "order.place"
 	WsApiMethodOrderCancel WsApiMethodType = "order.cancel"
+	WsApiMethodOrderModify WsApiMethodType = "order.modify"
+	WsApiMethodOrderStatus WsApiMethodType = "order.status"
 )
 
 var ErrorRequestIDNotSet = errors.New("ws service: request id is not set")
 
 // OrderPlaceWsService creates order
 type OrderPlaceWsService struct {
-	c *ClientWs
+	c    *ClientWs
+	pool *WsPool
+}
+
+// client returns the client the next request is sent with
+func (s *OrderPlaceWsService) client() *ClientWs {
+	if s.pool != nil {
+		return s.pool.Client()
+	}
+	return s.c
 }
 
 // NewOrderPlaceWsService init OrderPlaceWsService
@@ -46,6 +86,11 @@ func NewOrderPlaceWsService(apiKey, secretKey string) (*OrderPlaceWsService, err
 	return &OrderPlaceWsService{c: client}, nil
 }
 
+// NewOrderPlaceWsService init OrderPlaceWsService sharing the client connection
+func (c *ClientWs) NewOrderPlaceWsService() *OrderPlaceWsService {
+	return &OrderPlaceWsService{c: c}
+}
+
 // OrderPlaceWsRequest parameters for 'order.place' websocket API
 type OrderPlaceWsRequest struct {
 	symbol                  string
@@ -65,6 +110,7 @@ type OrderPlaceWsRequest struct {
 	newOrderRespType        NewOrderRespType
 	closePosition           *bool
 	selfTradePreventionMode *string
+	priority                *WsRequestPriority
 }
 
 // NewOrderPlaceWsRequest init OrderPlaceWsRequest
@@ -174,11 +220,30 @@ func (s *OrderPlaceWsRequest) SelfTradePreventionMode(selfTradePreventionMode st
 	return s
 }
 
+// Priority set priority of the request, WsPriorityHigh for reduce-only or close-position orders
+// and WsPriorityNormal for others if not set
+func (s *OrderPlaceWsRequest) Priority(priority WsRequestPriority) *OrderPlaceWsRequest {
+	s.priority = &priority
+	return s
+}
+
+// requestPriority returns priority of the request
+func (s *OrderPlaceWsRequest) requestPriority() WsRequestPriority {
+	switch {
+	case s.priority != nil:
+		return *s.priority
+	case s.reduceOnly != nil && *s.reduceOnly, s.closePosition != nil && *s.closePosition:
+		return WsPriorityHigh
+	}
+	return WsPriorityNormal
+}
+
 // CreateOrderWsResponse define 'order.place' websocket API response
 type CreateOrderWsResponse struct {
-	Id     string               `json:"id"`
-	Status int                  `json:"status"`
-	Result *CreateOrderResponse `json:"result"`
+	Id         string               `json:"id"`
+	Status     int                  `json:"status"`
+	Result     *CreateOrderResponse `json:"result"`
+	RateLimits []WsApiRateLimit     `json:"rateLimits,omitempty"`
 
 	// error response
 	Error *common.APIError `json:"error,omitempty"`
@@ -237,44 +302,26 @@ func (s *OrderPlaceWsRequest) buildParams() params {
 
 // Do - sends 'order.place' request
 func (s *OrderPlaceWsService) Do(ctx context.Context, req *OrderPlaceWsRequest) (*CreateOrderResponse, error) {
-	id, err := uuid.NewRandom()
-	if err != nil {
-		return nil, err
-	}
-
+	c := s.client()
 	params := req.buildParams()
-	params[apiKey] = s.c.APIKey
-	params[timestampKey] = currentTimestamp() - s.c.TimeOffset
-
-	signature, err := getSignature(s.c.SecretKey, params)
-	if err != nil {
-		return nil, err
-	}
-	params[signatureKey] = signature
-
-	wsReq := WsApiRequest{
-		Id:     id.String(),
-		Method: WsApiMethodOrderPlace,
-		Params: params,
-	}
-
-	rawData, err := json.Marshal(wsReq)
-	if err != nil {
+	if err := checkOrderRisk(c.RiskChecker, params); err != nil {
 		return nil, err
 	}
 
-	waiter, err := s.c.Write(wsReq.Id, rawData)
+	done, err := admitOrders(ctx, c.CircuitBreaker, c.OrderLimiter, c.SymbolThrottle, req.symbol)
 	if err != nil {
 		return nil, err
 	}
 
-	rawResp, err := waiter.wait(ctx)
+	rawResp, release, err := c.doRequest(ctx, WsApiMethodOrderPlace, params, true, req.requestPriority())
+	done(err)
 	if err != nil {
 		return nil, err
 	}
+	defer release()
 
 	res := CreateOrderWsResponse{}
-	if err := json.Unmarshal(rawResp, &res); err != nil {
+	if err := unmarshalOrderResponse(rawResp, &res); err != nil {
 		return nil, err
 	}
 
@@ -283,25 +330,10 @@ func (s *OrderPlaceWsService) Do(ctx context.Context, req *OrderPlaceWsRequest)
 
 // GetReconnectCount returns count of reconnect attempts by client
 func (s *OrderPlaceWsService) GetReconnectCount() int64 {
-	return s.c.GetReconnectCount()
-}
-
-// getSignature creates signature for params
-func getSignature(secretKey string, params params) (string, error) {
-	queryValues := url.Values{}
-	for key, value := range params {
-		queryValues.Add(key, fmt.Sprintf("%v", value))
-	}
-	queryString := queryValues.Encode()
-
-	raw := fmt.Sprintf("%s", queryString)
-	mac := hmac.New(sha256.New, []byte(secretKey))
-	_, err := mac.Write([]byte(raw))
-	if err != nil {
-		return "", err
+	if s.pool != nil {
+		return s.pool.GetReconnectCount()
 	}
-
-	return fmt.Sprintf("%x", mac.Sum(nil)), nil
+	return s.c.GetReconnectCount()
 }
 
 // NewCancelOrderRequest init CancelOrderRequest
@@ -314,6 +346,7 @@ type CancelOrderRequest struct {
 	symbol            string
 	orderID           *int64
 	origClientOrderID *string
+	priority          *WsRequestPriority
 }
 
 // Symbol set symbol
@@ -334,6 +367,20 @@ func (s *CancelOrderRequest) OrigClientOrderID(origClientOrderID string) *Cancel
 	return s
 }
 
+// Priority set priority of the request, WsPriorityHigh if not set
+func (s *CancelOrderRequest) Priority(priority WsRequestPriority) *CancelOrderRequest {
+	s.priority = &priority
+	return s
+}
+
+// requestPriority returns priority of the request
+func (s *CancelOrderRequest) requestPriority() WsRequestPriority {
+	if s.priority != nil {
+		return *s.priority
+	}
+	return WsPriorityHigh
+}
+
 // buildParams builds params
 func (s *CancelOrderRequest) buildParams() params {
 	m := params{
@@ -353,9 +400,10 @@ func (s *CancelOrderRequest) buildParams() params {
 
 // CancelOrderWsResponse define 'order.cancel' websocket API response
 type CancelOrderWsResponse struct {
-	Id     string               `json:"id"`
-	Status int                  `json:"status"`
-	Result *CancelOrderResponse `json:"result"`
+	Id         string               `json:"id"`
+	Status     int                  `json:"status"`
+	Result     *CancelOrderResponse `json:"result"`
+	RateLimits []WsApiRateLimit     `json:"rateLimits,omitempty"`
 
 	// error response
 	Error *common.APIError `json:"error,omitempty"`
@@ -363,7 +411,16 @@ type CancelOrderWsResponse struct {
 
 // OrderCancelWsService cancel order
 type OrderCancelWsService struct {
-	c *ClientWs
+	c    *ClientWs
+	pool *WsPool
+}
+
+// client returns the client the next request is sent with
+func (s *OrderCancelWsService) client() *ClientWs {
+	if s.pool != nil {
+		return s.pool.Client()
+	}
+	return s.c
 }
 
 // NewOrderCancelWsService init OrderCancelWsService
@@ -376,46 +433,186 @@ func NewOrderCancelWsService(apiKey, secretKey string) (*OrderCancelWsService, e
 	return &OrderCancelWsService{c: client}, nil
 }
 
+// NewOrderCancelWsService init OrderCancelWsService sharing the client connection
+func (c *ClientWs) NewOrderCancelWsService() *OrderCancelWsService {
+	return &OrderCancelWsService{c: c}
+}
+
 // Do - sends 'order.cancel' request
 func (s *OrderCancelWsService) Do(ctx context.Context, req *CancelOrderRequest) (*CancelOrderResponse, error) {
-	id, err := uuid.NewRandom()
+	rawResp, release, err := s.client().doRequest(ctx, WsApiMethodOrderCancel, req.buildParams(), true, req.requestPriority())
 	if err != nil {
 		return nil, err
 	}
+	defer release()
 
-	params := req.buildParams()
-	params[apiKey] = s.c.APIKey
-	params[timestampKey] = currentTimestamp() - s.c.TimeOffset
-
-	signature, err := getSignature(s.c.SecretKey, params)
-	if err != nil {
+	res := CancelOrderWsResponse{}
+	if err := unmarshalOrderResponse(rawResp, &res); err != nil {
 		return nil, err
 	}
-	params[signatureKey] = signature
 
-	wsReq := WsApiRequest{
-		Id:     id.String(),
-		Method: WsApiMethodOrderCancel,
-		Params: params,
+	return res.Result, nil
+}
+
+// GetReconnectCount returns count of reconnect attempts by client
+func (s *OrderCancelWsService) GetReconnectCount() int64 {
+	if s.pool != nil {
+		return s.pool.GetReconnectCount()
 	}
+	return s.c.GetReconnectCount()
+}
+
+// NewModifyOrderRequest init ModifyOrderRequest
+func NewModifyOrderRequest() *ModifyOrderRequest {
+	return &ModifyOrderRequest{}
+}
+
+// ModifyOrderRequest parameters for 'order.modify' websocket API
+type ModifyOrderRequest struct {
+	symbol            string
+	side              SideType
+	orderID           *int64
+	origClientOrderID *string
+	quantity          string
+	price             string
+	priority          *WsRequestPriority
+}
+
+// Symbol set symbol
+func (s *ModifyOrderRequest) Symbol(symbol string) *ModifyOrderRequest {
+	s.symbol = symbol
+	return s
+}
+
+// Side set side
+func (s *ModifyOrderRequest) Side(side SideType) *ModifyOrderRequest {
+	s.side = side
+	return s
+}
+
+// OrderID set orderID
+func (s *ModifyOrderRequest) OrderID(orderID int64) *ModifyOrderRequest {
+	s.orderID = &orderID
+	return s
+}
 
-	rawData, err := json.Marshal(wsReq)
+// OrigClientOrderID set origClientOrderID
+func (s *ModifyOrderRequest) OrigClientOrderID(origClientOrderID string) *ModifyOrderRequest {
+	s.origClientOrderID = &origClientOrderID
+	return s
+}
+
+// Quantity set quantity
+func (s *ModifyOrderRequest) Quantity(quantity string) *ModifyOrderRequest {
+	s.quantity = quantity
+	return s
+}
+
+// Price set price
+func (s *ModifyOrderRequest) Price(price string) *ModifyOrderRequest {
+	s.price = price
+	return s
+}
+
+// Priority set priority of the request, WsPriorityHigh if not set
+func (s *ModifyOrderRequest) Priority(priority WsRequestPriority) *ModifyOrderRequest {
+	s.priority = &priority
+	return s
+}
+
+// requestPriority returns priority of the request
+func (s *ModifyOrderRequest) requestPriority() WsRequestPriority {
+	if s.priority != nil {
+		return *s.priority
+	}
+	return WsPriorityHigh
+}
+
+// buildParams builds params
+func (s *ModifyOrderRequest) buildParams() params {
+	m := params{
+		"symbol":   s.symbol,
+		"side":     s.side,
+		"quantity": s.quantity,
+		"price":    s.price,
+	}
+
+	if s.orderID != nil {
+		m["orderId"] = *s.orderID
+	}
+
+	if s.origClientOrderID != nil {
+		m["origClientOrderId"] = *s.origClientOrderID
+	}
+
+	return m
+}
+
+// ModifyOrderWsResponse define 'order.modify' websocket API response
+type ModifyOrderWsResponse struct {
+	Id         string           `json:"id"`
+	Status     int              `json:"status"`
+	Result     *Order           `json:"result"`
+	RateLimits []WsApiRateLimit `json:"rateLimits,omitempty"`
+
+	// error response
+	Error *common.APIError `json:"error,omitempty"`
+}
+
+// OrderModifyWsService modify price or quantity of a LIMIT order
+type OrderModifyWsService struct {
+	c    *ClientWs
+	pool *WsPool
+}
+
+// client returns the client the next request is sent with
+func (s *OrderModifyWsService) client() *ClientWs {
+	if s.pool != nil {
+		return s.pool.Client()
+	}
+	return s.c
+}
+
+// NewOrderModifyWsService init OrderModifyWsService
+func NewOrderModifyWsService(apiKey, secretKey string) (*OrderModifyWsService, error) {
+	client, err := NewClientWs(apiKey, secretKey)
 	if err != nil {
 		return nil, err
 	}
 
-	waiter, err := s.c.Write(wsReq.Id, rawData)
+	return &OrderModifyWsService{c: client}, nil
+}
+
+// NewOrderModifyWsService init OrderModifyWsService sharing the client connection
+func (c *ClientWs) NewOrderModifyWsService() *OrderModifyWsService {
+	return &OrderModifyWsService{c: c}
+}
+
+// Do - sends 'order.modify' request
+func (s *OrderModifyWsService) Do(ctx context.Context, req *ModifyOrderRequest) (*Order, error) {
+	if req.orderID == nil && req.origClientOrderID == nil {
+		return nil, errors.New("either orderId or origClientOrderId must be sent")
+	}
+	c := s.client()
+	params := req.buildParams()
+	if err := checkModifyRisk(c.RiskChecker, params); err != nil {
+		return nil, err
+	}
+
+	done, err := admitOrders(ctx, c.CircuitBreaker, c.OrderLimiter, c.SymbolThrottle, req.symbol)
 	if err != nil {
 		return nil, err
 	}
 
-	rawResp, err := waiter.wait(ctx)
+	rawResp, release, err := c.doRequest(ctx, WsApiMethodOrderModify, params, true, req.requestPriority())
+	done(err)
 	if err != nil {
 		return nil, err
 	}
+	defer release()
 
-	res := CancelOrderWsResponse{}
-	if err := json.Unmarshal(rawResp, &res); err != nil {
+	res := ModifyOrderWsResponse{}
+	if err := unmarshalOrderResponse(rawResp, &res); err != nil {
 		return nil, err
 	}
 
@@ -423,6 +620,81 @@ func (s *OrderCancelWsService) Do(ctx context.Context, req *CancelOrderRequest)
 }
 
 // GetReconnectCount returns count of reconnect attempts by client
-func (s *OrderCancelWsService) GetReconnectCount() int64 {
+func (s *OrderModifyWsService) GetReconnectCount() int64 {
+	if s.pool != nil {
+		return s.pool.GetReconnectCount()
+	}
 	return s.c.GetReconnectCount()
 }
+
+// NewOrderStatusWsRequest init OrderStatusWsRequest
+func NewOrderStatusWsRequest() *OrderStatusWsRequest {
+	return &OrderStatusWsRequest{}
+}
+
+// OrderStatusWsRequest parameters for 'order.status' websocket API
+type OrderStatusWsRequest struct {
+	symbol            string
+	orderID           *int64
+	origClientOrderID *string
+}
+
+// Symbol set symbol
+func (s *OrderStatusWsRequest) Symbol(symbol string) *OrderStatusWsRequest {
+	s.symbol = symbol
+	return s
+}
+
+// OrderID set orderID
+func (s *OrderStatusWsRequest) OrderID(orderID int64) *OrderStatusWsRequest {
+	s.orderID = &orderID
+	return s
+}
+
+// OrigClientOrderID set origClientOrderID
+func (s *OrderStatusWsRequest) OrigClientOrderID(origClientOrderID string) *OrderStatusWsRequest {
+	s.origClientOrderID = &origClientOrderID
+	return s
+}
+
+// buildParams builds params
+func (s *OrderStatusWsRequest) buildParams() params {
+	m := params{
+		"symbol": s.symbol,
+	}
+	if s.orderID != nil {
+		m["orderId"] = *s.orderID
+	}
+	if s.origClientOrderID != nil {
+		m["origClientOrderId"] = *s.origClientOrderID
+	}
+	return m
+}
+
+// OrderStatusWsService query order
+type OrderStatusWsService struct {
+	c *ClientWs
+}
+
+// NewOrderStatusWsService init OrderStatusWsService sharing the client connection
+func (c *ClientWs) NewOrderStatusWsService() *OrderStatusWsService {
+	return &OrderStatusWsService{c: c}
+}
+
+// Do - sends 'order.status' request
+func (s *OrderStatusWsService) Do(ctx context.Context, req *OrderStatusWsRequest) (*Order, error) {
+	if req.orderID == nil && req.origClientOrderID == nil {
+		return nil, errors.New("either orderId or origClientOrderId must be sent")
+	}
+	rawResp, release, err := s.c.doRequest(ctx, WsApiMethodOrderStatus, req.buildParams(), true, WsPriorityNormal)
+	if err != nil {
+		return nil, err
+	}
+	defer release()
+
+	res := ModifyOrderWsResponse{}
+	if err := unmarshalResponse(rawResp, &res); err != nil {
+		return nil, err
+	}
+	return res.Result, nil
+}
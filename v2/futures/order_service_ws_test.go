@@ -0,0 +1,78 @@
+package futures
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type orderWsServiceTestSuite struct {
+	baseWsApiTestSuite
+}
+
+func TestOrderWsService(t *testing.T) {
+	suite.Run(t, new(orderWsServiceTestSuite))
+}
+
+func (s *orderWsServiceTestSuite) TestModifyOrder() {
+	s.respond(WsApiMethodOrderModify, `{
+		"orderId": 20072994037,
+		"symbol": "BTCUSDT",
+		"status": "NEW",
+		"clientOrderId": "LJ9R4QZDihCaS8UAOOLpgW",
+		"price": "30005",
+		"origQty": "1",
+		"side": "BUY",
+		"type": "LIMIT",
+		"updateTime": 1629182711600
+	}`)
+
+	res, err := s.wsClient.NewOrderModifyWsService().Do(newContext(), NewModifyOrderRequest().
+		Symbol("BTCUSDT").Side(SideTypeBuy).OrderID(20072994037).Quantity("1").Price("30005"))
+	r := s.r()
+	r.NoError(err)
+	r.Equal(int64(20072994037), res.OrderID)
+	r.Equal("30005", res.Price)
+	r.Equal(int64(1629182711600), res.UpdateTime)
+
+	req := s.lastRequest()
+	r.Equal(WsApiMethodOrderModify, req.Method)
+	r.Equal("BTCUSDT", req.Params["symbol"])
+	r.Equal("BUY", req.Params["side"])
+	r.Equal("1", req.Params["quantity"])
+	r.Equal("30005", req.Params["price"])
+	r.EqualValues(20072994037, req.Params["orderId"])
+	r.NotEmpty(req.Params[signatureKey])
+}
+
+func (s *orderWsServiceTestSuite) TestModifyOrderWithoutID() {
+	_, err := s.wsClient.NewOrderModifyWsService().Do(newContext(), NewModifyOrderRequest().
+		Symbol("BTCUSDT").Side(SideTypeBuy).Quantity("1").Price("30005"))
+	s.r().Error(err)
+}
+
+func (s *orderWsServiceTestSuite) TestOrderStatus() {
+	s.respond(WsApiMethodOrderStatus, `{
+		"orderId": 20072994037,
+		"symbol": "BTCUSDT",
+		"status": "PARTIALLY_FILLED",
+		"clientOrderId": "twap1-1",
+		"origQty": "2",
+		"executedQty": "0.5"
+	}`)
+
+	res, err := s.wsClient.NewOrderStatusWsService().Do(newContext(), NewOrderStatusWsRequest().
+		Symbol("BTCUSDT").OrigClientOrderID("twap1-1"))
+	r := s.r()
+	r.NoError(err)
+	r.Equal(OrderStatusTypePartiallyFilled, res.Status)
+	r.Equal("0.5", res.ExecutedQuantity)
+
+	req := s.lastRequest()
+	r.Equal(WsApiMethodOrderStatus, req.Method)
+	r.Equal("twap1-1", req.Params["origClientOrderId"])
+	r.NotEmpty(req.Params[signatureKey])
+
+	_, err = s.wsClient.NewOrderStatusWsService().Do(newContext(), NewOrderStatusWsRequest().Symbol("BTCUSDT"))
+	r.Error(err)
+}
@@ -0,0 +1,252 @@
+package futures
+
+import (
+	"context"
+	"errors"
+	"math"
+	"strconv"
+	"time"
+
+	"github.com/adshao/go-binance/v2/common"
+)
+
+var (
+	ErrSymbolNotFound      = errors.New("order slicer: symbol not found in exchange info")
+	ErrNotionalPriceNotSet = errors.New("order slicer: price or reference price is required for notional cap")
+	ErrStepSizeNotSet      = errors.New("order slicer: step size is required to cap chunk quantity")
+	ErrChunkBelowStepSize  = errors.New("order slicer: chunk cap is below step size")
+)
+
+// SliceOrderService splits an order too large for the exchange into chunks allowed by the
+// LOT_SIZE filter, MARKET_LOT_SIZE filter for market orders, and an optional notional cap,
+// then sends them one after another
+type SliceOrderService struct {
+	c              *Client
+	symbol         string
+	side           SideType
+	positionSide   *PositionSideType
+	orderType      OrderType
+	timeInForce    *TimeInForceType
+	quantity       float64
+	price          *string
+	reduceOnly     *bool
+	maxNotional    float64
+	referencePrice float64
+	interval       time.Duration
+	allOrAbort     bool
+}
+
+// Symbol set symbol
+func (s *SliceOrderService) Symbol(symbol string) *SliceOrderService {
+	s.symbol = symbol
+	return s
+}
+
+// Side set side
+func (s *SliceOrderService) Side(side SideType) *SliceOrderService {
+	s.side = side
+	return s
+}
+
+// PositionSide set positionSide
+func (s *SliceOrderService) PositionSide(positionSide PositionSideType) *SliceOrderService {
+	s.positionSide = &positionSide
+	return s
+}
+
+// Type set type
+func (s *SliceOrderService) Type(orderType OrderType) *SliceOrderService {
+	s.orderType = orderType
+	return s
+}
+
+// TimeInForce set timeInForce
+func (s *SliceOrderService) TimeInForce(timeInForce TimeInForceType) *SliceOrderService {
+	s.timeInForce = &timeInForce
+	return s
+}
+
+// Quantity set total quantity
+func (s *SliceOrderService) Quantity(quantity float64) *SliceOrderService {
+	s.quantity = quantity
+	return s
+}
+
+// Price set price
+func (s *SliceOrderService) Price(price string) *SliceOrderService {
+	s.price = &price
+	return s
+}
+
+// ReduceOnly set reduceOnly
+func (s *SliceOrderService) ReduceOnly(reduceOnly bool) *SliceOrderService {
+	s.reduceOnly = &reduceOnly
+	return s
+}
+
+// MaxNotional set the largest notional of a chunk, priced at the order price or the reference
+// price for market orders
+func (s *SliceOrderService) MaxNotional(maxNotional float64) *SliceOrderService {
+	s.maxNotional = maxNotional
+	return s
+}
+
+// ReferencePrice set price used to cap notional of market orders, e.g. mark price
+func (s *SliceOrderService) ReferencePrice(referencePrice float64) *SliceOrderService {
+	s.referencePrice = referencePrice
+	return s
+}
+
+// Interval set pause between chunks
+func (s *SliceOrderService) Interval(interval time.Duration) *SliceOrderService {
+	s.interval = interval
+	return s
+}
+
+// AllOrAbort set whether a failed chunk cancels the live chunks sent before it
+func (s *SliceOrderService) AllOrAbort(allOrAbort bool) *SliceOrderService {
+	s.allOrAbort = allOrAbort
+	return s
+}
+
+// Chunks returns quantities the order is split into
+func (s *SliceOrderService) Chunks(ctx context.Context, opts ...RequestOption) ([]float64, int, error) {
+	maxQty, step, precision, err := s.c.orderLotSize(ctx, s.symbol, s.orderType, opts...)
+	if err != nil {
+		return nil, 0, err
+	}
+	if s.maxNotional > 0 {
+		price := s.referencePrice
+		if s.price != nil {
+			if price, err = strconv.ParseFloat(*s.price, 64); err != nil {
+				return nil, 0, err
+			}
+		}
+		if price <= 0 {
+			return nil, 0, ErrNotionalPriceNotSet
+		}
+		if notionalQty := s.maxNotional / price; maxQty <= 0 || notionalQty < maxQty {
+			maxQty = notionalQty
+		}
+	}
+	chunks, err := splitQuantity(s.quantity, maxQty, step, precision)
+	if err != nil {
+		return nil, 0, err
+	}
+	return chunks, precision, nil
+}
+
+// Do sends the chunks and returns responses of the chunks sent, including on error. With
+// AllOrAbort, a failed chunk cancels the chunks that are still live.
+func (s *SliceOrderService) Do(ctx context.Context, opts ...RequestOption) ([]*CreateOrderResponse, error) {
+	chunks, precision, err := s.Chunks(ctx, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	res := make([]*CreateOrderResponse, 0, len(chunks))
+	for i, quantity := range chunks {
+		if i > 0 && s.interval > 0 {
+			select {
+			case <-ctx.Done():
+				return res, s.abort(res, ctx.Err(), opts...)
+			case <-time.After(s.interval):
+			}
+		}
+		order := s.c.NewCreateOrderService().Symbol(s.symbol).Side(s.side).Type(s.orderType).
+			Quantity(strconv.FormatFloat(quantity, 'f', precision, 64)).
+			NewOrderResponseType(NewOrderRespTypeRESULT)
+		if s.positionSide != nil {
+			order.PositionSide(*s.positionSide)
+		}
+		if s.timeInForce != nil {
+			order.TimeInForce(*s.timeInForce)
+		}
+		if s.price != nil {
+			order.Price(*s.price)
+		}
+		if s.reduceOnly != nil {
+			order.ReduceOnly(*s.reduceOnly)
+		}
+		r, err := order.Do(ctx, opts...)
+		if err != nil {
+			return res, s.abort(res, err, opts...)
+		}
+		res = append(res, r)
+	}
+	return res, nil
+}
+
+// abort cancels live chunks if AllOrAbort is set, it returns err unless canceling failed
+func (s *SliceOrderService) abort(sent []*CreateOrderResponse, err error, opts ...RequestOption) error {
+	if !s.allOrAbort {
+		return err
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	for _, r := range sent {
+		if !isOpenOrderStatus(r.Status) {
+			continue
+		}
+		_, cancelErr := s.c.NewCancelOrderService().Symbol(s.symbol).OrderID(r.OrderID).Do(ctx, opts...)
+		if cancelErr != nil && !common.IsAPIErrorCode(cancelErr, errCodeUnknownOrder) {
+			return cancelErr
+		}
+		r.Status = OrderStatusTypeCanceled
+	}
+	return err
+}
+
+// orderLotSize returns maximum quantity, step size and quantity precision of orders of orderType
+func (c *Client) orderLotSize(ctx context.Context, symbol string, orderType OrderType, opts ...RequestOption) (maxQty, step float64, precision int, err error) {
+	info, err := c.NewExchangeInfoService().Do(ctx, opts...)
+	if err != nil {
+		return 0, 0, 0, err
+	}
+	for i := range info.Symbols {
+		s := &info.Symbols[i]
+		if s.Symbol != symbol {
+			continue
+		}
+		var maxQuantity, stepSize string
+		if f := s.LotSizeFilter(); f != nil {
+			maxQuantity, stepSize = f.MaxQuantity, f.StepSize
+		}
+		if f := s.MarketLotSizeFilter(); f != nil && orderType == OrderTypeMarket {
+			maxQuantity, stepSize = f.MaxQuantity, f.StepSize
+		}
+		if maxQty, err = parseOptionalFloat(maxQuantity); err != nil {
+			return 0, 0, 0, err
+		}
+		if step, err = parseOptionalFloat(stepSize); err != nil {
+			return 0, 0, 0, err
+		}
+		return maxQty, step, s.QuantityPrecision, nil
+	}
+	return 0, 0, 0, ErrSymbolNotFound
+}
+
+// splitQuantity splits quantity into chunks not larger than maxQty rounded down to step,
+// zero maxQty disables the limit. It fails if maxQty can't be rounded to a step.
+func splitQuantity(quantity, maxQty, step float64, precision int) ([]float64, error) {
+	if maxQty <= 0 || quantity <= maxQty {
+		return []float64{quantity}, nil
+	}
+	if step <= 0 {
+		return nil, ErrStepSizeNotSet
+	}
+	maxQty = common.AmountToLotSize(step, precision, maxQty)
+	// count in units of precision to keep chunks exact
+	unit := math.Pow10(precision)
+	remaining, max := math.Round(quantity*unit), math.Round(maxQty*unit)
+	if max <= 0 {
+		return nil, ErrChunkBelowStepSize
+	}
+	chunks := make([]float64, 0, int(math.Ceil(remaining/max)))
+	for remaining > 0 {
+		chunk := math.Min(remaining, max)
+		chunks = append(chunks, chunk/unit)
+		remaining -= chunk
+	}
+	return chunks, nil
+}
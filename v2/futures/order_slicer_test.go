@@ -0,0 +1,99 @@
+package futures
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSliceOrderService(t *testing.T) {
+	assert := assert.New(t)
+
+	var (
+		orders   []url.Values
+		canceled []string
+		fail     = 0
+	)
+	c := NewClient("apiKey", "secretKey")
+	c.do = func(req *http.Request) (*http.Response, error) {
+		body, _ := io.ReadAll(req.Body)
+		form, _ := url.ParseQuery(string(body))
+		switch req.Method + " " + req.URL.Path {
+		case "GET /fapi/v1/exchangeInfo":
+			return newHTTPResponse([]byte(`{"symbols":[{"symbol":"BTCUSDT","quantityPrecision":3,"filters":[
+				{"filterType":"LOT_SIZE","maxQty":"1000","minQty":"0.001","stepSize":"0.001"},
+				{"filterType":"MARKET_LOT_SIZE","maxQty":"120","minQty":"0.001","stepSize":"0.001"}
+			]}]}`), http.StatusOK), nil
+		case "POST /fapi/v1/order":
+			orders = append(orders, form)
+			if len(orders) == fail {
+				return newHTTPResponse([]byte(`{"code":-2019,"msg":"Margin is insufficient."}`), http.StatusBadRequest), nil
+			}
+			data := fmt.Sprintf(`{"symbol":"BTCUSDT","orderId":%d,"status":"NEW","origQty":"%s"}`, len(orders), form.Get("quantity"))
+			return newHTTPResponse([]byte(data), http.StatusOK), nil
+		case "DELETE /fapi/v1/order":
+			canceled = append(canceled, form.Get("orderId"))
+			return newHTTPResponse([]byte(`{}`), http.StatusOK), nil
+		}
+		return newHTTPResponse([]byte(`{}`), http.StatusNotFound), nil
+	}
+
+	chunks, _, err := c.NewSliceOrderService().Symbol("BTCUSDT").Type(OrderTypeMarket).Quantity(250).Chunks(newContext())
+	assert.NoError(err)
+	assert.Equal([]float64{120, 120, 10}, chunks)
+
+	_, _, err = c.NewSliceOrderService().Symbol("BTCUSDT").Type(OrderTypeMarket).Quantity(250).MaxNotional(1000).Chunks(newContext())
+	assert.ErrorIs(err, ErrNotionalPriceNotSet)
+
+	chunks, _, err = c.NewSliceOrderService().Symbol("BTCUSDT").Type(OrderTypeLimit).Price("100").
+		Quantity(25).MaxNotional(1000).Chunks(newContext())
+	assert.NoError(err)
+	assert.Equal([]float64{10, 10, 5}, chunks)
+
+	_, _, err = c.NewSliceOrderService().Symbol("ETHUSDT").Quantity(1).Chunks(newContext())
+	assert.ErrorIs(err, ErrSymbolNotFound)
+
+	res, err := c.NewSliceOrderService().Symbol("BTCUSDT").Side(SideTypeBuy).Type(OrderTypeLimit).
+		TimeInForce(TimeInForceTypeGTC).Price("100").Quantity(2500).Do(newContext())
+	assert.NoError(err)
+	assert.Len(res, 3)
+	if assert.Len(orders, 3) {
+		assert.Equal("1000.000", orders[0].Get("quantity"))
+		assert.Equal("500.000", orders[2].Get("quantity"))
+		assert.Equal("100", orders[2].Get("price"))
+		assert.Equal("GTC", orders[2].Get("timeInForce"))
+	}
+
+	orders, fail = nil, 3
+	res, err = c.NewSliceOrderService().Symbol("BTCUSDT").Side(SideTypeBuy).Type(OrderTypeLimit).
+		TimeInForce(TimeInForceTypeGTC).Price("100").Quantity(2500).AllOrAbort(true).Do(newContext())
+	assert.Error(err)
+	assert.Len(res, 2)
+	assert.Equal([]string{"1", "2"}, canceled)
+	assert.Equal(OrderStatusTypeCanceled, res[0].Status)
+}
+
+func TestSplitQuantity(t *testing.T) {
+	assert := assert.New(t)
+
+	assertChunks := func(expected []float64, quantity, maxQty, step float64, precision int) {
+		chunks, err := splitQuantity(quantity, maxQty, step, precision)
+		if assert.NoError(err) {
+			assert.Equal(expected, chunks)
+		}
+	}
+	assertChunks([]float64{1.5}, 1.5, 0, 0, 3)
+	assertChunks([]float64{1.5}, 1.5, 2, 0.001, 3)
+	assertChunks([]float64{1, 1, 0.3}, 2.3, 1, 0.1, 1)
+	assertChunks([]float64{0.9, 0.9, 0.5}, 2.3, 0.95, 0.1, 1)
+
+	// a cap below the step would send the whole quantity at once
+	_, err := splitQuantity(2.3, 0.05, 0.1, 1)
+	assert.ErrorIs(err, ErrChunkBelowStepSize)
+	_, err = splitQuantity(2.3, 0.0001, 0, 3)
+	assert.ErrorIs(err, ErrStepSizeNotSet)
+}
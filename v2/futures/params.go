@@ -0,0 +1,227 @@
+package futures
+
+import (
+	"bytes"
+	"encoding"
+	"encoding/json"
+	"fmt"
+	"math"
+	"net/url"
+	"reflect"
+	"slices"
+	"strconv"
+	"unicode/utf8"
+)
+
+// param define a key/value pair of orderedParams
+type param struct {
+	key   string
+	value interface{}
+}
+
+// orderedParams define params sorted by key. The signed query string and the JSON payload of a
+// websocket API request are both built from it, so they list params in the same order on every
+// run whichever JSONCodec is used.
+type orderedParams []param
+
+// sortParams returns p sorted by key
+func sortParams(p params) orderedParams {
+	ordered := make(orderedParams, 0, len(p)+1)
+	for key, value := range p {
+		ordered = append(ordered, param{key: key, value: value})
+	}
+	slices.SortFunc(ordered, func(a, b param) int {
+		switch {
+		case a.key < b.key:
+			return -1
+		case a.key > b.key:
+			return 1
+		}
+		return 0
+	})
+	return ordered
+}
+
+// set returns p with value of key set, keeping it sorted
+func (p orderedParams) set(key string, value interface{}) orderedParams {
+	i, found := slices.BinarySearchFunc(p, key, func(e param, key string) int {
+		switch {
+		case e.key < key:
+			return -1
+		case e.key > key:
+			return 1
+		}
+		return 0
+	})
+	if found {
+		p[i].value = value
+		return p
+	}
+	return slices.Insert(p, i, param{key: key, value: value})
+}
+
+// encode builds the canonical query string which is signed: values escaped as url.Values
+// encodes them, numbers formatted as they are in the JSON payload so the signature matches it
+func (p orderedParams) encode() string {
+	size := 0
+	for _, e := range p {
+		size += len(e.key) + 16
+	}
+	b := make([]byte, 0, size)
+	for i, e := range p {
+		if i > 0 {
+			b = append(b, '&')
+		}
+		b = append(b, url.QueryEscape(e.key)...)
+		b = append(b, '=')
+		b = appendQueryValue(b, e.value)
+	}
+	return string(b)
+}
+
+// MarshalJSON encodes p as a JSON object with keys in order
+func (p orderedParams) MarshalJSON() ([]byte, error) {
+	return p.appendJSON(make([]byte, 0, 32*len(p)+2))
+}
+
+// appendJSON appends the JSON object of p to b
+func (p orderedParams) appendJSON(b []byte) ([]byte, error) {
+	b = append(b, '{')
+	for i, e := range p {
+		if i > 0 {
+			b = append(b, ',')
+		}
+		b = appendJSONString(b, e.key)
+		b = append(b, ':')
+		var err error
+		if b, err = appendJSONValue(b, e.value); err != nil {
+			return nil, err
+		}
+	}
+	return append(b, '}'), nil
+}
+
+// MarshalJSON encodes p with keys sorted, as orderedParams does
+func (p params) MarshalJSON() ([]byte, error) {
+	return sortParams(p).MarshalJSON()
+}
+
+// encodeParams builds the canonical query string of params which is signed
+func encodeParams(params params) string {
+	return sortParams(params).encode()
+}
+
+// appendQueryValue appends the escaped query form of v to b
+func appendQueryValue(b []byte, v interface{}) []byte {
+	switch v := v.(type) {
+	case string:
+		return append(b, url.QueryEscape(v)...)
+	case int64:
+		return strconv.AppendInt(b, v, 10)
+	case int:
+		return strconv.AppendInt(b, int64(v), 10)
+	case bool:
+		return strconv.AppendBool(b, v)
+	case float64:
+		return escapeExponentSign(appendJSONFloat(b, v, 64), len(b))
+	}
+	rv := reflect.ValueOf(v)
+	switch rv.Kind() {
+	case reflect.String:
+		// named string types such as SideType
+		return append(b, url.QueryEscape(rv.String())...)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return strconv.AppendInt(b, rv.Int(), 10)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return strconv.AppendUint(b, rv.Uint(), 10)
+	case reflect.Float32, reflect.Float64:
+		return escapeExponentSign(appendJSONFloat(b, rv.Float(), rv.Type().Bits()), len(b))
+	}
+	return append(b, url.QueryEscape(fmt.Sprint(v))...)
+}
+
+// escapeExponentSign escapes the sign of e+21 appended to b after start, the only byte of
+// numbers url.Values escapes
+func escapeExponentSign(b []byte, start int) []byte {
+	if i := bytes.IndexByte(b[start:], '+'); i >= 0 {
+		i += start
+		b = append(b[:i], append([]byte("%2B"), b[i+1:]...)...)
+	}
+	return b
+}
+
+// appendJSONValue appends the JSON encoding of v to b, as encoding/json encodes it
+func appendJSONValue(b []byte, v interface{}) ([]byte, error) {
+	switch v := v.(type) {
+	case string:
+		return appendJSONString(b, v), nil
+	case int64:
+		return strconv.AppendInt(b, v, 10), nil
+	case int:
+		return strconv.AppendInt(b, int64(v), 10), nil
+	case bool:
+		return strconv.AppendBool(b, v), nil
+	case float64:
+		return appendJSONFloat(b, v, 64), nil
+	case float32:
+		return appendJSONFloat(b, float64(v), 32), nil
+	}
+	// named types such as SideType, unless they encode themselves
+	_, marshaler := v.(json.Marshaler)
+	_, textMarshaler := v.(encoding.TextMarshaler)
+	if !marshaler && !textMarshaler {
+		rv := reflect.ValueOf(v)
+		switch rv.Kind() {
+		case reflect.String:
+			return appendJSONString(b, rv.String()), nil
+		case reflect.Bool:
+			return strconv.AppendBool(b, rv.Bool()), nil
+		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+			return strconv.AppendInt(b, rv.Int(), 10), nil
+		case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+			return strconv.AppendUint(b, rv.Uint(), 10), nil
+		case reflect.Float32, reflect.Float64:
+			return appendJSONFloat(b, rv.Float(), rv.Type().Bits()), nil
+		}
+	}
+	data, err := JSONCodec.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	return append(b, data...), nil
+}
+
+// appendJSONString appends s quoted, strings which encoding/json would escape are encoded by it
+func appendJSONString(b []byte, s string) []byte {
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		if c < 0x20 || c == '"' || c == '\\' || c == '<' || c == '>' || c == '&' || c >= utf8.RuneSelf {
+			data, _ := json.Marshal(s)
+			return append(b, data...)
+		}
+	}
+	b = append(b, '"')
+	b = append(b, s...)
+	return append(b, '"')
+}
+
+// appendJSONFloat appends f of bits size as encoding/json formats floats, which %v does not:
+// 1e+06 is sent as 1000000
+func appendJSONFloat(b []byte, f float64, bits int) []byte {
+	format := byte('f')
+	if abs := math.Abs(f); abs != 0 {
+		if bits == 64 && (abs < 1e-6 || abs >= 1e21) || bits == 32 && (float32(abs) < 1e-6 || float32(abs) >= 1e21) {
+			format = 'e'
+		}
+	}
+	b = strconv.AppendFloat(b, f, format, -1, bits)
+	if format == 'e' {
+		// clean up e-09 to e-9
+		n := len(b)
+		if n >= 4 && b[n-4] == 'e' && b[n-3] == '-' && b[n-2] == '0' {
+			b[n-2] = b[n-1]
+			b = b[:n-1]
+		}
+	}
+	return b
+}
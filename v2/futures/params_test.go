@@ -0,0 +1,91 @@
+package futures
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEncodeParams(t *testing.T) {
+	assert := assert.New(t)
+	p := params{
+		"symbol":           "BTCUSDT",
+		"side":             SideTypeBuy,
+		"quantity":         "0.001",
+		"reduceOnly":       true,
+		"timestamp":        int64(1499827319559),
+		"limit":            5,
+		"newClientOrderId": "a b&c=d+e",
+	}
+	// strings, integers and bools are encoded as url.Values encodes their %v form
+	values := url.Values{}
+	for key, value := range p {
+		values.Add(key, fmt.Sprintf("%v", value))
+	}
+	assert.Equal(values.Encode(), encodeParams(p))
+	assert.Equal("", encodeParams(params{}))
+}
+
+func TestEncodeParamsFloat(t *testing.T) {
+	for _, f := range []float64{0, 1, -2.5, 1000000, 0.1, 1e-7, 123456789.123, 1e21, 1.5e-10} {
+		data, err := json.Marshal(f)
+		assert.NoError(t, err)
+		assert.Equal(t, "price="+url.QueryEscape(string(data)), encodeParams(params{"price": f}))
+	}
+	assert.Equal(t, "a=1000000&b=x+y", encodeParams(params{"a": float32(1e6), "b": "x y"}))
+}
+
+func TestOrderedParams(t *testing.T) {
+	assert := assert.New(t)
+	p := params{
+		"symbol":    "BTCUSDT",
+		"side":      SideTypeSell,
+		"price":     0.1,
+		"quantity":  float32(0.1),
+		"timestamp": int64(1499827319559),
+		"limit":     5,
+		"note":      "<a&b>\"é\"",
+		"flag":      true,
+	}
+	ordered := sortParams(p)
+	keys := make([]string, 0, len(ordered))
+	for _, e := range ordered {
+		keys = append(keys, e.key)
+	}
+	assert.Equal([]string{"flag", "limit", "note", "price", "quantity", "side", "symbol", "timestamp"}, keys)
+
+	// the payload is encoded as encoding/json encodes the map
+	data, err := json.Marshal(map[string]interface{}(p))
+	assert.NoError(err)
+	payload, err := ordered.MarshalJSON()
+	assert.NoError(err)
+	assert.Equal(string(data), string(payload))
+	payload, err = json.Marshal(p)
+	assert.NoError(err)
+	assert.Equal(string(data), string(payload))
+
+	ordered = ordered.set("signature", "abc").set("apiKey", "key").set("limit", 10)
+	assert.Equal("apiKey=key&flag=true&limit=10&note=%3Ca%26b%3E%22%C3%A9%22&price=0.1&quantity=0.1&side=SELL"+
+		"&signature=abc&symbol=BTCUSDT&timestamp=1499827319559", ordered.encode())
+}
+
+func BenchmarkEncodeParams(b *testing.B) {
+	p := params{
+		"symbol":           "BTCUSDT",
+		"side":             SideTypeBuy,
+		"type":             OrderTypeLimit,
+		"timeInForce":      TimeInForceTypeGTC,
+		"quantity":         "0.001",
+		"price":            "60000.1",
+		"newClientOrderId": "client-1",
+		"apiKey":           "apiKey",
+		"timestamp":        int64(1499827319559),
+	}
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		encodeParams(p)
+	}
+}
@@ -0,0 +1,98 @@
+package futures
+
+import (
+	"context"
+	"errors"
+)
+
+// PositionIntent define whether an order opens or closes a position
+type PositionIntent string
+
+// Position intents
+const (
+	PositionIntentOpen  PositionIntent = "OPEN"
+	PositionIntentClose PositionIntent = "CLOSE"
+)
+
+var (
+	ErrReduceOnlyInHedgeMode    = errors.New("position intent: reduceOnly can not be sent in Hedge Mode")
+	ErrPositionSideMismatch     = errors.New("position intent: positionSide contradicts side and intent")
+	ErrPositionSideInOneWayMode = errors.New("position intent: positionSide must be BOTH in One-way Mode")
+	ErrCloseFlagOnOpenIntent    = errors.New("position intent: reduceOnly or closePosition set on an opening order")
+)
+
+// DualSidePosition returns the account position mode: true - Hedge Mode, false - One-way Mode.
+// The mode is cached after the first request and updated by ChangePositionModeService.
+func (c *Client) DualSidePosition(ctx context.Context, opts ...RequestOption) (bool, error) {
+	c.positionModeMu.Lock()
+	dualSide := c.dualSide
+	c.positionModeMu.Unlock()
+	if dualSide != nil {
+		return *dualSide, nil
+	}
+
+	res, err := c.NewGetPositionModeService().Do(ctx, opts...)
+	if err != nil {
+		return false, err
+	}
+	return res.DualSidePosition, nil
+}
+
+// InvalidatePositionMode drops the cached position mode, e.g. after it was changed elsewhere
+func (c *Client) InvalidatePositionMode() {
+	c.positionModeMu.Lock()
+	defer c.positionModeMu.Unlock()
+
+	c.dualSide = nil
+}
+
+func (c *Client) setDualSidePosition(dualSide bool) {
+	c.positionModeMu.Lock()
+	defer c.positionModeMu.Unlock()
+
+	c.dualSide = &dualSide
+}
+
+// intentPositionSide returns position side of Hedge Mode an order of side and intent trades
+func intentPositionSide(side SideType, intent PositionIntent) PositionSideType {
+	if (side == SideTypeBuy) == (intent == PositionIntentOpen) {
+		return PositionSideTypeLong
+	}
+	return PositionSideTypeShort
+}
+
+// applyPositionIntent sets positionSide and reduceOnly of the order according to its intent
+// and the position mode, rejecting combinations the exchange would reject
+func (s *CreateOrderService) applyPositionIntent(ctx context.Context, c *Client, opts ...RequestOption) error {
+	dualSide, err := c.DualSidePosition(ctx, opts...)
+	if err != nil {
+		return err
+	}
+	closePosition := s.closePosition != nil && *s.closePosition
+	reduceOnly := s.reduceOnly != nil && *s.reduceOnly
+	if *s.positionIntent == PositionIntentOpen && (closePosition || reduceOnly) {
+		return ErrCloseFlagOnOpenIntent
+	}
+
+	if dualSide {
+		if reduceOnly {
+			return ErrReduceOnlyInHedgeMode
+		}
+		s.reduceOnly = nil
+		positionSide := intentPositionSide(s.side, *s.positionIntent)
+		if s.positionSide != nil && *s.positionSide != positionSide {
+			return ErrPositionSideMismatch
+		}
+		s.positionSide = &positionSide
+		return nil
+	}
+
+	if s.positionSide != nil && *s.positionSide != PositionSideTypeBoth {
+		return ErrPositionSideInOneWayMode
+	}
+	if *s.positionIntent == PositionIntentClose && !closePosition {
+		reduceOnly = true
+		s.reduceOnly = &reduceOnly
+	}
+	return nil
+}
@@ -0,0 +1,74 @@
+package futures
+
+import (
+	"io"
+	"net/http"
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCreateOrderPositionIntent(t *testing.T) {
+	assert := assert.New(t)
+
+	dualSide := "true"
+	modeRequests := 0
+	var form url.Values
+	c := NewClient("apiKey", "secretKey")
+	c.do = func(req *http.Request) (*http.Response, error) {
+		if req.URL.Path == "/fapi/v1/positionSide/dual" {
+			if req.Method == http.MethodGet {
+				modeRequests++
+			}
+			return newHTTPResponse([]byte(`{"dualSidePosition":`+dualSide+`}`), http.StatusOK), nil
+		}
+		body, _ := io.ReadAll(req.Body)
+		form, _ = url.ParseQuery(string(body))
+		return newHTTPResponse([]byte(`{"orderId":1}`), http.StatusOK), nil
+	}
+
+	_, err := c.NewCreateOrderService().Symbol("BTCUSDT").Side(SideTypeSell).Type(OrderTypeMarket).
+		Quantity("1").PositionIntent(PositionIntentClose).Do(newContext())
+	assert.NoError(err)
+	assert.Equal("LONG", form.Get("positionSide"))
+	assert.Empty(form.Get("reduceOnly"))
+
+	_, err = c.NewCreateOrderService().Symbol("BTCUSDT").Side(SideTypeSell).Type(OrderTypeMarket).
+		Quantity("1").PositionIntent(PositionIntentOpen).Do(newContext())
+	assert.NoError(err)
+	assert.Equal("SHORT", form.Get("positionSide"))
+	assert.Equal(1, modeRequests, "position mode is cached")
+
+	_, err = c.NewCreateOrderService().Symbol("BTCUSDT").Side(SideTypeSell).Type(OrderTypeMarket).
+		Quantity("1").ReduceOnly(true).PositionIntent(PositionIntentClose).Do(newContext())
+	assert.ErrorIs(err, ErrReduceOnlyInHedgeMode)
+
+	_, err = c.NewCreateOrderService().Symbol("BTCUSDT").Side(SideTypeBuy).Type(OrderTypeMarket).
+		Quantity("1").PositionSide(PositionSideTypeShort).PositionIntent(PositionIntentOpen).Do(newContext())
+	assert.ErrorIs(err, ErrPositionSideMismatch)
+
+	assert.NoError(c.NewChangePositionModeService().DualSide(false).Do(newContext()))
+	_, err = c.NewCreateOrderService().Symbol("BTCUSDT").Side(SideTypeBuy).Type(OrderTypeMarket).
+		Quantity("1").PositionIntent(PositionIntentClose).Do(newContext())
+	assert.NoError(err)
+	assert.Empty(form.Get("positionSide"))
+	assert.Equal("true", form.Get("reduceOnly"))
+	assert.Equal(1, modeRequests, "changed position mode is cached")
+
+	_, err = c.NewCreateOrderService().Symbol("BTCUSDT").Side(SideTypeBuy).Type(OrderTypeMarket).
+		Quantity("1").ReduceOnly(true).PositionIntent(PositionIntentOpen).Do(newContext())
+	assert.ErrorIs(err, ErrCloseFlagOnOpenIntent)
+
+	_, err = c.NewCreateOrderService().Symbol("BTCUSDT").Side(SideTypeBuy).Type(OrderTypeMarket).
+		Quantity("1").PositionSide(PositionSideTypeLong).PositionIntent(PositionIntentOpen).Do(newContext())
+	assert.ErrorIs(err, ErrPositionSideInOneWayMode)
+
+	c.InvalidatePositionMode()
+	dualSide = "true"
+	_, err = c.NewCreateOrderService().Symbol("BTCUSDT").Side(SideTypeBuy).Type(OrderTypeMarket).
+		Quantity("1").PositionIntent(PositionIntentOpen).Do(newContext())
+	assert.NoError(err)
+	assert.Equal("LONG", form.Get("positionSide"))
+	assert.Equal(2, modeRequests)
+}
@@ -2,7 +2,6 @@ package futures
 
 import (
 	"context"
-	"encoding/json"
 	"net/http"
 )
 
@@ -72,7 +71,7 @@ func (s *GetPositionMarginHistoryService) Do(ctx context.Context, opts ...Reques
 		return nil, err
 	}
 	res = make([]*PositionMarginHistory, 0)
-	err = json.Unmarshal(data, &res)
+	err = unmarshalResponse(data, &res)
 	if err != nil {
 		return nil, err
 	}
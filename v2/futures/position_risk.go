@@ -2,7 +2,6 @@ package futures
 
 import (
 	"context"
-	"encoding/json"
 	"net/http"
 )
 
@@ -33,7 +32,7 @@ func (s *GetPositionRiskService) Do(ctx context.Context, opts ...RequestOption)
 		return []*PositionRisk{}, err
 	}
 	res = make([]*PositionRisk, 0)
-	err = json.Unmarshal(data, &res)
+	err = unmarshalResponse(data, &res)
 	if err != nil {
 		return []*PositionRisk{}, err
 	}
@@ -56,4 +55,5 @@ type PositionRisk struct {
 	PositionSide     string `json:"positionSide"`
 	Notional         string `json:"notional"`
 	IsolatedWallet   string `json:"isolatedWallet"`
+	UpdateTime       int64  `json:"updateTime"`
 }
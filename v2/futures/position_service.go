@@ -2,7 +2,6 @@ package futures
 
 import (
 	"context"
-	"encoding/json"
 	"net/http"
 )
 
@@ -41,7 +40,7 @@ func (s *ChangeLeverageService) Do(ctx context.Context, opts ...RequestOption) (
 		return nil, err
 	}
 	res = new(SymbolLeverage)
-	err = json.Unmarshal(data, &res)
+	err = unmarshalResponse(data, &res)
 	if err != nil {
 		return nil, err
 	}
@@ -175,6 +174,7 @@ func (s *ChangePositionModeService) Do(ctx context.Context, opts ...RequestOptio
 	if err != nil {
 		return err
 	}
+	s.c.setDualSidePosition(s.dualSide)
 	return nil
 }
 
@@ -201,10 +201,11 @@ func (s *GetPositionModeService) Do(ctx context.Context, opts ...RequestOption)
 		return nil, err
 	}
 	res = &PositionMode{}
-	err = json.Unmarshal(data, &res)
+	err = unmarshalResponse(data, &res)
 	if err != nil {
 		return nil, err
 	}
+	s.c.setDualSidePosition(res.DualSidePosition)
 	return res, nil
 }
 
@@ -260,7 +261,7 @@ func (s *GetMultiAssetModeService) Do(ctx context.Context, opts ...RequestOption
 		return nil, err
 	}
 	res = &MultiAssetMode{}
-	err = json.Unmarshal(data, &res)
+	err = unmarshalResponse(data, &res)
 	if err != nil {
 		return nil, err
 	}
@@ -0,0 +1,291 @@
+package futures
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// TrackedPosition define position of a symbol and position side maintained by PositionTracker
+type TrackedPosition struct {
+	Symbol        string
+	Side          PositionSideType
+	Amount        float64
+	EntryPrice    float64
+	MarkPrice     float64
+	UnrealizedPnL float64
+	// UpdateTime is the exchange time of the last change in milliseconds
+	UpdateTime int64
+}
+
+// PositionHandler handle a change of TrackedPosition, a closed position has zero Amount
+type PositionHandler func(position TrackedPosition)
+
+type positionKey struct {
+	symbol string
+	side   PositionSideType
+}
+
+type positionSubscription struct {
+	symbol  string
+	handler PositionHandler
+}
+
+// PositionTracker maintains open positions of the account. It is seeded from 'positionRisk',
+// kept up to date by ACCOUNT_UPDATE events passed to HandleUserDataEvent and periodically
+// reconciled with 'positionRisk' to correct drift, e.g. after missed events.
+type PositionTracker struct {
+	c          *Client
+	interval   time.Duration
+	errHandler ErrHandler
+
+	mu            sync.RWMutex
+	positions     map[positionKey]TrackedPosition
+	subscriptions []positionSubscription
+	stopC         chan struct{}
+	doneC         chan struct{}
+}
+
+// NewPositionTracker init PositionTracker reconciling every interval
+func NewPositionTracker(c *Client, interval time.Duration, errHandler ErrHandler) *PositionTracker {
+	return &PositionTracker{
+		c:          c,
+		interval:   interval,
+		errHandler: errHandler,
+		positions:  make(map[positionKey]TrackedPosition),
+	}
+}
+
+// Start seeds positions and starts periodic reconciliation in background
+func (t *PositionTracker) Start(ctx context.Context) error {
+	if err := t.Reconcile(ctx); err != nil {
+		return err
+	}
+
+	t.mu.Lock()
+	if t.stopC != nil {
+		t.mu.Unlock()
+		return nil
+	}
+	t.stopC = make(chan struct{})
+	t.doneC = make(chan struct{})
+	stopC, doneC := t.stopC, t.doneC
+	t.mu.Unlock()
+
+	go func() {
+		defer close(doneC)
+		ticker := time.NewTicker(t.interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-stopC:
+				return
+			case <-ticker.C:
+				ctx, cancel := context.WithTimeout(context.Background(), t.interval)
+				if err := t.Reconcile(ctx); err != nil {
+					t.handleError(err)
+				}
+				cancel()
+			}
+		}
+	}()
+	return nil
+}
+
+// Stop stops periodic reconciliation
+func (t *PositionTracker) Stop() {
+	t.mu.Lock()
+	stopC, doneC := t.stopC, t.doneC
+	t.stopC, t.doneC = nil, nil
+	t.mu.Unlock()
+
+	if stopC == nil {
+		return
+	}
+	close(stopC)
+	<-doneC
+}
+
+// OnPosition registers handler fired when position of symbol changes. Empty symbol matches all symbols.
+func (t *PositionTracker) OnPosition(symbol string, handler PositionHandler) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.subscriptions = append(t.subscriptions, positionSubscription{symbol: symbol, handler: handler})
+}
+
+// Position returns open position of symbol and side, use PositionSideTypeBoth in one-way mode
+func (t *PositionTracker) Position(symbol string, side PositionSideType) (TrackedPosition, bool) {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+
+	p, ok := t.positions[positionKey{symbol: symbol, side: side}]
+	return p, ok
+}
+
+// Positions returns all open positions
+func (t *PositionTracker) Positions() []TrackedPosition {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+
+	res := make([]TrackedPosition, 0, len(t.positions))
+	for _, p := range t.positions {
+		res = append(res, p)
+	}
+	return res
+}
+
+// HandleUserDataEvent applies ACCOUNT_UPDATE event, other events are ignored.
+// Pass it the events of the user data stream.
+func (t *PositionTracker) HandleUserDataEvent(event *WsUserDataEvent) {
+	if event.Event != UserDataEventTypeAccountUpdate {
+		return
+	}
+
+	positions := make([]TrackedPosition, 0, len(event.AccountUpdate.Positions))
+	for _, wp := range event.AccountUpdate.Positions {
+		p, err := newTrackedPositionFromEvent(wp, event.TransactionTime)
+		if err != nil {
+			t.handleError(err)
+			continue
+		}
+		positions = append(positions, p)
+	}
+
+	t.mu.Lock()
+	var changed []TrackedPosition
+	for _, p := range positions {
+		key := positionKey{symbol: p.Symbol, side: p.Side}
+		if old, ok := t.positions[key]; ok && old.UpdateTime > p.UpdateTime {
+			continue
+		}
+		if t.set(key, p) {
+			changed = append(changed, p)
+		}
+	}
+	t.mu.Unlock()
+
+	t.notify(changed)
+}
+
+// Reconcile replaces tracked positions with 'positionRisk' snapshot. Positions changed by events
+// newer than the snapshot request are kept.
+func (t *PositionTracker) Reconcile(ctx context.Context) error {
+	requestTime := currentTimestamp() - t.c.TimeOffset
+	risks, err := t.c.NewGetPositionRiskService().Do(ctx)
+	if err != nil {
+		return err
+	}
+
+	snapshot := make(map[positionKey]TrackedPosition, len(risks))
+	for _, r := range risks {
+		p, err := newTrackedPositionFromRisk(r)
+		if err != nil {
+			return err
+		}
+		if p.Amount == 0 {
+			continue
+		}
+		snapshot[positionKey{symbol: p.Symbol, side: p.Side}] = p
+	}
+
+	t.mu.Lock()
+	var changed []TrackedPosition
+	for key, old := range t.positions {
+		if _, ok := snapshot[key]; !ok && old.UpdateTime < requestTime {
+			closed := TrackedPosition{Symbol: old.Symbol, Side: old.Side, UpdateTime: requestTime}
+			if t.set(key, closed) {
+				changed = append(changed, closed)
+			}
+		}
+	}
+	for key, p := range snapshot {
+		if old, ok := t.positions[key]; ok && old.UpdateTime >= requestTime {
+			continue
+		}
+		if t.set(key, p) {
+			changed = append(changed, p)
+		}
+	}
+	t.mu.Unlock()
+
+	t.notify(changed)
+	return nil
+}
+
+// set stores position, zero amount removes it. Returns whether amount or entry price changed.
+func (t *PositionTracker) set(key positionKey, p TrackedPosition) bool {
+	old, ok := t.positions[key]
+	if p.Amount == 0 {
+		delete(t.positions, key)
+		return ok
+	}
+	t.positions[key] = p
+	return !ok || old.Amount != p.Amount || old.EntryPrice != p.EntryPrice
+}
+
+func (t *PositionTracker) notify(changed []TrackedPosition) {
+	if len(changed) == 0 {
+		return
+	}
+
+	t.mu.RLock()
+	subscriptions := t.subscriptions
+	t.mu.RUnlock()
+
+	for _, p := range changed {
+		for _, s := range subscriptions {
+			if s.symbol == "" || s.symbol == p.Symbol {
+				s.handler(p)
+			}
+		}
+	}
+}
+
+func (t *PositionTracker) handleError(err error) {
+	if t.errHandler != nil {
+		t.errHandler(err)
+	}
+}
+
+func newTrackedPositionFromEvent(wp WsPosition, updateTime int64) (p TrackedPosition, err error) {
+	p = TrackedPosition{
+		Symbol:     wp.Symbol,
+		Side:       wp.Side,
+		UpdateTime: updateTime,
+	}
+	if p.Amount, err = parseOptionalFloat(wp.Amount); err != nil {
+		return p, err
+	}
+	if p.EntryPrice, err = parseOptionalFloat(wp.EntryPrice); err != nil {
+		return p, err
+	}
+	if p.MarkPrice, err = parseOptionalFloat(wp.MarkPrice); err != nil {
+		return p, err
+	}
+	if p.UnrealizedPnL, err = parseOptionalFloat(wp.UnrealizedPnL); err != nil {
+		return p, err
+	}
+	return p, nil
+}
+
+func newTrackedPositionFromRisk(r *PositionRisk) (p TrackedPosition, err error) {
+	p = TrackedPosition{
+		Symbol:     r.Symbol,
+		Side:       PositionSideType(r.PositionSide),
+		UpdateTime: r.UpdateTime,
+	}
+	if p.Amount, err = parseOptionalFloat(r.PositionAmt); err != nil {
+		return p, err
+	}
+	if p.EntryPrice, err = parseOptionalFloat(r.EntryPrice); err != nil {
+		return p, err
+	}
+	if p.MarkPrice, err = parseOptionalFloat(r.MarkPrice); err != nil {
+		return p, err
+	}
+	if p.UnrealizedPnL, err = parseOptionalFloat(r.UnRealizedProfit); err != nil {
+		return p, err
+	}
+	return p, nil
+}
@@ -0,0 +1,112 @@
+package futures
+
+import (
+	"net/http"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPositionTracker(t *testing.T) {
+	assert := assert.New(t)
+
+	var (
+		mu       sync.Mutex
+		snapshot = `[
+			{"symbol":"BTCUSDT","positionSide":"BOTH","positionAmt":"0.5","entryPrice":"60000.0","markPrice":"61000.0","unRealizedProfit":"500.0","updateTime":1728972000000},
+			{"symbol":"ETHUSDT","positionSide":"BOTH","positionAmt":"0.000","entryPrice":"0.0","markPrice":"2500.0","unRealizedProfit":"0.0","updateTime":0}
+		]`
+	)
+	c := NewClient("apiKey", "secretKey")
+	c.do = func(req *http.Request) (*http.Response, error) {
+		assert.Equal("/fapi/v2/positionRisk", req.URL.Path)
+		mu.Lock()
+		defer mu.Unlock()
+		return newHTTPResponse([]byte(snapshot), http.StatusOK), nil
+	}
+
+	var changes []TrackedPosition
+	tracker := NewPositionTracker(c, time.Hour, func(err error) {
+		t.Error(err)
+	})
+	tracker.OnPosition("BTCUSDT", func(position TrackedPosition) {
+		changes = append(changes, position)
+	})
+	assert.NoError(tracker.Start(newContext()))
+	defer tracker.Stop()
+
+	btc := TrackedPosition{
+		Symbol:        "BTCUSDT",
+		Side:          PositionSideTypeBoth,
+		Amount:        0.5,
+		EntryPrice:    60000,
+		MarkPrice:     61000,
+		UnrealizedPnL: 500,
+		UpdateTime:    1728972000000,
+	}
+	assert.Equal([]TrackedPosition{btc}, tracker.Positions())
+	assert.Equal([]TrackedPosition{btc}, changes)
+	_, ok := tracker.Position("ETHUSDT", PositionSideTypeBoth)
+	assert.False(ok)
+
+	// event newer than the next snapshot request is kept by reconciliation
+	future := time.Now().Add(time.Hour).UnixMilli()
+	tracker.HandleUserDataEvent(&WsUserDataEvent{
+		Event:           UserDataEventTypeAccountUpdate,
+		TransactionTime: future,
+		WsUserDataAccountUpdate: WsUserDataAccountUpdate{AccountUpdate: WsAccountUpdate{
+			Reason: UserDataEventReasonTypeOrder,
+			Positions: []WsPosition{
+				{Symbol: "BTCUSDT", Side: PositionSideTypeBoth, Amount: "1.0", EntryPrice: "60500.0", UnrealizedPnL: "500.0"},
+			},
+		}},
+	})
+	assert.NoError(tracker.Reconcile(newContext()))
+	p, ok := tracker.Position("BTCUSDT", PositionSideTypeBoth)
+	assert.True(ok)
+	assert.Equal(1.0, p.Amount)
+	assert.Equal(60500.0, p.EntryPrice)
+	assert.Equal(future, p.UpdateTime)
+	assert.Len(changes, 2)
+
+	// older event is ignored
+	tracker.HandleUserDataEvent(&WsUserDataEvent{
+		Event:           UserDataEventTypeAccountUpdate,
+		TransactionTime: future - 1,
+		WsUserDataAccountUpdate: WsUserDataAccountUpdate{AccountUpdate: WsAccountUpdate{
+			Positions: []WsPosition{{Symbol: "BTCUSDT", Side: PositionSideTypeBoth, Amount: "0"}},
+		}},
+	})
+	_, ok = tracker.Position("BTCUSDT", PositionSideTypeBoth)
+	assert.True(ok)
+	assert.Len(changes, 2)
+}
+
+func TestPositionTrackerReconcileDrift(t *testing.T) {
+	assert := assert.New(t)
+
+	snapshot := `[{"symbol":"BTCUSDT","positionSide":"LONG","positionAmt":"0.5","entryPrice":"60000.0","updateTime":1728972000000}]`
+	c := NewClient("apiKey", "secretKey")
+	c.do = func(req *http.Request) (*http.Response, error) {
+		return newHTTPResponse([]byte(snapshot), http.StatusOK), nil
+	}
+
+	var changes []TrackedPosition
+	tracker := NewPositionTracker(c, time.Hour, nil)
+	tracker.OnPosition("", func(position TrackedPosition) {
+		changes = append(changes, position)
+	})
+	assert.NoError(tracker.Reconcile(newContext()))
+	assert.Len(changes, 1)
+
+	// position closed while events were missed
+	snapshot = `[{"symbol":"BTCUSDT","positionSide":"LONG","positionAmt":"0.0","entryPrice":"0.0","updateTime":1728972100000}]`
+	assert.NoError(tracker.Reconcile(newContext()))
+	assert.Empty(tracker.Positions())
+	assert.Len(changes, 2)
+	assert.Equal("BTCUSDT", changes[1].Symbol)
+	assert.Equal(PositionSideTypeLong, changes[1].Side)
+	assert.Zero(changes[1].Amount)
+}
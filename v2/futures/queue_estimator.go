@@ -0,0 +1,233 @@
+package futures
+
+import (
+	"strconv"
+	"sync"
+
+	"github.com/adshao/go-binance/v2/common"
+)
+
+// QueueEstimator estimates the quantity resting ahead of live orders in the queue of their price
+// level from the diff depth and aggregate trade streams of their symbols, e.g. to decide whether
+// an order is worth keeping or should be replaced. Pass it the events of the streams, and set it
+// as QueueEstimator of an OpenOrderTracker to follow the orders of the tracker.
+//
+// An order starts behind the size displayed at its price when it is first seen. Trades at its
+// price on its side consume the queue ahead of it, trades through its price put it first.
+// Decreases of the level not explained by trades are cancels, taken from ahead of the order in
+// proportion of the quantity ahead of it, while increases join the queue behind it.
+type QueueEstimator struct {
+	errHandler ErrHandler
+
+	mu     sync.Mutex
+	books  map[string]*queueBook
+	orders map[int64]*queuedOrder
+}
+
+// queueBook define the levels of a symbol reported by the depth stream
+type queueBook struct {
+	bids map[float64]*queueLevel
+	asks map[float64]*queueLevel
+}
+
+// queueLevel define a price level and the quantity traded at it since its last depth update
+type queueLevel struct {
+	size       float64
+	updateTime int64
+	traded     float64
+}
+
+// queuedOrder define a live order followed by QueueEstimator
+type queuedOrder struct {
+	symbol     string
+	side       SideType
+	price      float64
+	remaining  float64
+	updateTime int64
+	// ahead is the quantity estimated ahead of the order, known once its level was reported
+	ahead float64
+	known bool
+}
+
+// NewQueueEstimator init QueueEstimator
+func NewQueueEstimator(errHandler ErrHandler) *QueueEstimator {
+	return &QueueEstimator{
+		errHandler: errHandler,
+		books:      make(map[string]*queueBook),
+		orders:     make(map[int64]*queuedOrder),
+	}
+}
+
+// Ahead returns the quantity estimated ahead of order, false if the order isn't followed or its
+// level wasn't reported yet
+func (e *QueueEstimator) Ahead(orderID int64) (float64, bool) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	o, ok := e.orders[orderID]
+	if !ok || !o.known {
+		return 0, false
+	}
+	return o.ahead, true
+}
+
+// HandleDepthEvent applies event of the diff depth stream of a symbol
+func (e *QueueEstimator) HandleDepthEvent(event *WsDepthEvent) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	book := e.book(event.Symbol)
+	for _, bid := range event.Bids {
+		if err := e.updateLevel(event.Symbol, SideTypeBuy, book.bids, bid, event.TransactionTime); err != nil {
+			e.handleError(err)
+		}
+	}
+	for _, ask := range event.Asks {
+		if err := e.updateLevel(event.Symbol, SideTypeSell, book.asks, ask, event.TransactionTime); err != nil {
+			e.handleError(err)
+		}
+	}
+}
+
+// HandleAggTradeEvent applies event of the aggregate trade stream of a symbol
+func (e *QueueEstimator) HandleAggTradeEvent(event *WsAggTradeEvent) {
+	price, err := strconv.ParseFloat(event.Price, 64)
+	if err != nil {
+		e.handleError(err)
+		return
+	}
+	qty, err := strconv.ParseFloat(event.Quantity, 64)
+	if err != nil {
+		e.handleError(err)
+		return
+	}
+	// buyer is the maker means the taker sold into the bids
+	side := SideTypeSell
+	if event.Maker {
+		side = SideTypeBuy
+	}
+
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	if level, ok := e.levels(event.Symbol, side)[price]; ok {
+		level.traded += qty
+	}
+	for _, o := range e.orders {
+		if o.symbol != event.Symbol || o.side != side || !o.known {
+			continue
+		}
+		switch {
+		case price == o.price:
+			o.ahead = max(o.ahead-qty, 0)
+		case side == SideTypeBuy && price < o.price, side == SideTypeSell && price > o.price:
+			o.ahead = 0
+		}
+	}
+}
+
+// track follows order o, or applies its change
+func (e *QueueEstimator) track(orderID int64, o TrackedOrder) {
+	remaining := o.OrigQuantity - o.ExecutedQuantity
+
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	if old, ok := e.orders[orderID]; ok && old.price == o.Price && old.side == o.Side {
+		// a fill means the order reached the front of the queue
+		if remaining < old.remaining {
+			old.ahead = 0
+		}
+		old.remaining = remaining
+		return
+	}
+
+	// a new price loses priority, the order queues again
+	q := &queuedOrder{
+		symbol:     o.Symbol,
+		side:       o.Side,
+		price:      o.Price,
+		remaining:  remaining,
+		updateTime: o.UpdateTime,
+	}
+	if level, ok := e.levels(o.Symbol, o.Side)[o.Price]; ok {
+		q.ahead, q.known = initialAhead(level.size, level.updateTime, q), true
+	}
+	e.orders[orderID] = q
+}
+
+// untrack stops following order
+func (e *QueueEstimator) untrack(orderID int64) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	delete(e.orders, orderID)
+}
+
+func (e *QueueEstimator) updateLevel(symbol string, side SideType, levels map[float64]*queueLevel, pl common.PriceLevel, updateTime int64) error {
+	price, size, err := pl.Parse()
+	if err != nil {
+		return err
+	}
+
+	old, ok := levels[price]
+	if size == 0 {
+		delete(levels, price)
+	} else {
+		levels[price] = &queueLevel{size: size, updateTime: updateTime}
+	}
+
+	for _, o := range e.orders {
+		if o.symbol != symbol || o.side != side || o.price != price {
+			continue
+		}
+		if !o.known || !ok {
+			o.ahead, o.known = initialAhead(size, updateTime, o), true
+			continue
+		}
+		if cancelled := old.size - size - old.traded; cancelled > 0 && old.size > 0 {
+			o.ahead -= cancelled * o.ahead / old.size
+		}
+		// the level includes the order once updated after it
+		limit := size
+		if updateTime >= o.updateTime {
+			limit = size - o.remaining
+		}
+		o.ahead = max(min(o.ahead, limit), 0)
+	}
+	return nil
+}
+
+// initialAhead returns the quantity ahead of order o joining a level of size last updated at
+// updateTime, which includes the order if updated after it
+func initialAhead(size float64, updateTime int64, o *queuedOrder) float64 {
+	if updateTime >= o.updateTime {
+		return max(size-o.remaining, 0)
+	}
+	return size
+}
+
+func (e *QueueEstimator) book(symbol string) *queueBook {
+	book, ok := e.books[symbol]
+	if !ok {
+		book = &queueBook{
+			bids: make(map[float64]*queueLevel),
+			asks: make(map[float64]*queueLevel),
+		}
+		e.books[symbol] = book
+	}
+	return book
+}
+
+func (e *QueueEstimator) levels(symbol string, side SideType) map[float64]*queueLevel {
+	if side == SideTypeBuy {
+		return e.book(symbol).bids
+	}
+	return e.book(symbol).asks
+}
+
+func (e *QueueEstimator) handleError(err error) {
+	if e.errHandler != nil {
+		e.errHandler(err)
+	}
+}
@@ -0,0 +1,82 @@
+package futures
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func queueDepthEvent(time int64, bids ...Bid) *WsDepthEvent {
+	return &WsDepthEvent{Symbol: "BTCUSDT", TransactionTime: time, Bids: bids}
+}
+
+func assertQueueAhead(t *testing.T, tracker *OpenOrderTracker, orderID int64, expected float64) {
+	ahead, ok := tracker.QueueAhead(orderID)
+	if assert.True(t, ok) {
+		assert.InDelta(t, expected, ahead, 1e-9)
+	}
+}
+
+func TestQueueEstimator(t *testing.T) {
+	queue := NewQueueEstimator(func(err error) {
+		t.Error(err)
+	})
+	tracker := NewOpenOrderTracker(NewClient("apiKey", "secretKey"), 0, nil).QueueEstimator(queue)
+
+	queue.HandleDepthEvent(queueDepthEvent(1000, Bid{Price: "100.0", Quantity: "5"}))
+	tracker.TrackCreated(&CreateOrderResponse{
+		Symbol: "BTCUSDT", OrderID: 1, Price: "100", OrigQuantity: "1",
+		Status: OrderStatusTypeNew, Type: OrderTypeLimit, Side: SideTypeBuy, UpdateTime: 2000,
+	})
+	assertQueueAhead(t, tracker, 1, 5)
+
+	// the order joins behind the level, which then includes it
+	queue.HandleDepthEvent(queueDepthEvent(2001, Bid{Price: "100", Quantity: "6"}))
+	assertQueueAhead(t, tracker, 1, 5)
+
+	// sells at the price consume the queue
+	queue.HandleAggTradeEvent(&WsAggTradeEvent{Symbol: "BTCUSDT", Price: "100", Quantity: "2", Maker: true})
+	assertQueueAhead(t, tracker, 1, 3)
+	// buys and other symbols don't
+	queue.HandleAggTradeEvent(&WsAggTradeEvent{Symbol: "BTCUSDT", Price: "100", Quantity: "2"})
+	queue.HandleAggTradeEvent(&WsAggTradeEvent{Symbol: "ETHUSDT", Price: "100", Quantity: "2", Maker: true})
+	assertQueueAhead(t, tracker, 1, 3)
+
+	// the level lost 3, of which 2 were traded: 1 was cancelled, half of it ahead of the order
+	queue.HandleDepthEvent(queueDepthEvent(2002, Bid{Price: "100", Quantity: "3"}))
+	assertQueueAhead(t, tracker, 1, 2)
+
+	// a partial fill puts the order first
+	tracker.Track(&Order{
+		Symbol: "BTCUSDT", OrderID: 1, Price: "100", OrigQuantity: "1", ExecutedQuantity: "0.5",
+		Status: OrderStatusTypePartiallyFilled, Type: OrderTypeLimit, Side: SideTypeBuy, UpdateTime: 2003,
+	})
+	assertQueueAhead(t, tracker, 1, 0)
+
+	tracker.Track(&Order{
+		Symbol: "BTCUSDT", OrderID: 1, Price: "100", OrigQuantity: "1", ExecutedQuantity: "1",
+		Status: OrderStatusTypeFilled, Type: OrderTypeLimit, Side: SideTypeBuy, UpdateTime: 2004,
+	})
+	_, ok := tracker.QueueAhead(1)
+	assert.False(t, ok)
+}
+
+func TestQueueEstimatorUnknownLevel(t *testing.T) {
+	queue := NewQueueEstimator(nil)
+	tracker := NewOpenOrderTracker(NewClient("apiKey", "secretKey"), 0, nil).QueueEstimator(queue)
+
+	tracker.TrackCreated(&CreateOrderResponse{
+		Symbol: "BTCUSDT", OrderID: 2, Price: "99", OrigQuantity: "1",
+		Status: OrderStatusTypeNew, Type: OrderTypeLimit, Side: SideTypeBuy, UpdateTime: 2000,
+	})
+	_, ok := tracker.QueueAhead(2)
+	assert.False(t, ok)
+
+	// the first update of the level includes the order
+	queue.HandleDepthEvent(queueDepthEvent(2001, Bid{Price: "99", Quantity: "4"}))
+	assertQueueAhead(t, tracker, 2, 3)
+
+	// trades through the price put the order first
+	queue.HandleAggTradeEvent(&WsAggTradeEvent{Symbol: "BTCUSDT", Price: "98.9", Quantity: "0.1", Maker: true})
+	assertQueueAhead(t, tracker, 2, 0)
+}
@@ -0,0 +1,105 @@
+package futures
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/adshao/go-binance/v2/common"
+)
+
+// RateLimitStore counts what the client-side rate limiters let through within fixed windows:
+// orders of the account for OrderLimiter, orders of each symbol for SymbolThrottle and
+// websocket API requests for the rate limit of ClientWs. The limiters count in memory by
+// default; implement it on top of a shared store, e.g. Redis INCRBY on a key expiring with the
+// window, so that processes using the same API key share the limits.
+type RateLimitStore interface {
+	// Add adds n to the count of key within the window starting at window and returns the
+	// count after adding. n is negative when a limiter gives back what it took over the limit.
+	// Counts of earlier windows may be dropped.
+	Add(ctx context.Context, key string, window time.Time, n int) (int, error)
+}
+
+// MemoryRateLimitStore is the RateLimitStore of a single process, kept in memory. The zero
+// value is ready to use.
+type MemoryRateLimitStore struct {
+	mu     sync.Mutex
+	counts map[string]windowCount
+}
+
+// windowCount define the count of a key within the window starting at start
+type windowCount struct {
+	start time.Time
+	count int
+}
+
+// NewMemoryRateLimitStore init MemoryRateLimitStore
+func NewMemoryRateLimitStore() *MemoryRateLimitStore {
+	return &MemoryRateLimitStore{}
+}
+
+// Add implements RateLimitStore
+func (m *MemoryRateLimitStore) Add(_ context.Context, key string, window time.Time, n int) (int, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if m.counts == nil {
+		m.counts = make(map[string]windowCount)
+	}
+	c := m.counts[key]
+	switch {
+	case window.Before(c.start):
+		return 0, nil
+	case window.After(c.start):
+		c = windowCount{start: window}
+	}
+	c.count += n
+	m.counts[key] = c
+	return c.count, nil
+}
+
+// windowCounter counts takes of keys within fixed windows in store, or in local if store is
+// not set or fails
+type windowCounter struct {
+	store   RateLimitStore
+	onError ErrHandler
+	local   *MemoryRateLimitStore
+}
+
+// take adds n to the count of key within the window starting at start if the count stays
+// within limit, reporting whether it did
+func (c windowCounter) take(ctx context.Context, key string, start time.Time, n, limit int) bool {
+	if c.add(ctx, key, start, n) <= limit {
+		return true
+	}
+	c.add(ctx, key, start, -n)
+	return false
+}
+
+// add adds n to the count of key within the window starting at start and returns the count
+func (c windowCounter) add(ctx context.Context, key string, start time.Time, n int) int {
+	if c.store != nil {
+		count, err := c.store.Add(ctx, key, start, n)
+		if err == nil {
+			return count
+		}
+		c.error(err)
+	}
+	count, _ := c.local.Add(ctx, key, start, n)
+	return count
+}
+
+func (c windowCounter) error(err error) {
+	if c.onError != nil {
+		c.onError(fmt.Errorf("rate limit store: %w", err))
+	}
+}
+
+// orSystemClock returns clock, the system clock if it is not set
+func orSystemClock(clock common.Clock) common.Clock {
+	if clock == nil {
+		return common.SystemClock
+	}
+	return clock
+}
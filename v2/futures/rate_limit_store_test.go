@@ -0,0 +1,72 @@
+package futures
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// failingRateLimitStore is a RateLimitStore which is unreachable
+type failingRateLimitStore struct{}
+
+func (failingRateLimitStore) Add(context.Context, string, time.Time, int) (int, error) {
+	return 0, errors.New("connection refused")
+}
+
+func TestMemoryRateLimitStore(t *testing.T) {
+	var store MemoryRateLimitStore
+	ctx := context.Background()
+	window := time.Unix(6000, 0)
+
+	count, err := store.Add(ctx, "a", window, 2)
+	require.NoError(t, err)
+	assert.Equal(t, 2, count)
+	count, _ = store.Add(ctx, "a", window, 1)
+	assert.Equal(t, 3, count)
+	count, _ = store.Add(ctx, "b", window, 1)
+	assert.Equal(t, 1, count)
+
+	// earlier windows are dropped
+	count, _ = store.Add(ctx, "a", window.Add(-time.Minute), 1)
+	assert.Equal(t, 0, count)
+	count, _ = store.Add(ctx, "a", window.Add(time.Minute), 1)
+	assert.Equal(t, 1, count)
+	count, _ = store.Add(ctx, "a", window, 1)
+	assert.Equal(t, 0, count)
+}
+
+func TestWindowCounter(t *testing.T) {
+	store := NewMemoryRateLimitStore()
+	counter := windowCounter{store: store, local: NewMemoryRateLimitStore()}
+	ctx := context.Background()
+	window := time.Unix(6000, 0)
+
+	assert.True(t, counter.take(ctx, "a", window, 1, 2))
+	assert.True(t, counter.take(ctx, "a", window, 1, 2))
+	assert.False(t, counter.take(ctx, "a", window, 1, 2))
+	// what was taken over the limit is given back
+	count, _ := store.Add(ctx, "a", window, 0)
+	assert.Equal(t, 2, count)
+	count, _ = counter.local.Add(ctx, "a", window, 0)
+	assert.Equal(t, 0, count)
+}
+
+func TestWindowCounterStoreError(t *testing.T) {
+	var errs []error
+	counter := windowCounter{
+		store:   failingRateLimitStore{},
+		onError: func(err error) { errs = append(errs, err) },
+		local:   NewMemoryRateLimitStore(),
+	}
+	ctx := context.Background()
+	window := time.Unix(6000, 0)
+
+	// takes are counted in memory
+	assert.True(t, counter.take(ctx, "a", window, 1, 1))
+	assert.False(t, counter.take(ctx, "a", window, 1, 1))
+	assert.Len(t, errs, 3)
+}
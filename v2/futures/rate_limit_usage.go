@@ -0,0 +1,108 @@
+package futures
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	usedWeightHeaderPrefix = "X-Mbx-Used-Weight-"
+	orderCountHeaderPrefix = "X-Mbx-Order-Count-"
+)
+
+// RateLimitUsage define usage of rate limits reported by X-MBX-USED-WEIGHT-* and
+// X-MBX-ORDER-COUNT-* headers of REST responses
+type RateLimitUsage struct {
+	// UsedWeight maps interval, e.g. "1m", to request weight of the IP used within it
+	UsedWeight map[string]int
+	// OrderCount maps interval, e.g. "10s" or "1m", to orders of the account placed within it
+	OrderCount map[string]int
+	// Time the usage was received
+	Time time.Time
+}
+
+// WithRateLimitUsage makes the request store usage reported by its response into usage
+func WithRateLimitUsage(usage *RateLimitUsage) RequestOption {
+	return func(r *request) {
+		r.rateLimitUsage = usage
+	}
+}
+
+// parseRateLimitUsage returns usage reported by header, maps are nil if it has none
+func parseRateLimitUsage(header http.Header, now time.Time) RateLimitUsage {
+	usage := RateLimitUsage{Time: now}
+	for key, values := range header {
+		if len(values) == 0 {
+			continue
+		}
+		var m *map[string]int
+		var interval string
+		switch {
+		case strings.HasPrefix(key, usedWeightHeaderPrefix):
+			m, interval = &usage.UsedWeight, key[len(usedWeightHeaderPrefix):]
+		case strings.HasPrefix(key, orderCountHeaderPrefix):
+			m, interval = &usage.OrderCount, key[len(orderCountHeaderPrefix):]
+		default:
+			continue
+		}
+		value, err := strconv.Atoi(values[0])
+		if err != nil {
+			continue
+		}
+		if *m == nil {
+			*m = make(map[string]int)
+		}
+		(*m)[strings.ToLower(interval)] = value
+	}
+	return usage
+}
+
+// rateLimitGauge keeps the latest usage reported of each rate limit
+type rateLimitGauge struct {
+	mu    sync.Mutex
+	usage RateLimitUsage
+}
+
+func (g *rateLimitGauge) update(usage RateLimitUsage) {
+	if usage.UsedWeight == nil && usage.OrderCount == nil {
+		return
+	}
+
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	g.usage.UsedWeight = mergeUsage(g.usage.UsedWeight, usage.UsedWeight)
+	g.usage.OrderCount = mergeUsage(g.usage.OrderCount, usage.OrderCount)
+	g.usage.Time = usage.Time
+}
+
+func (g *rateLimitGauge) get() RateLimitUsage {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	return RateLimitUsage{
+		UsedWeight: mergeUsage(nil, g.usage.UsedWeight),
+		OrderCount: mergeUsage(nil, g.usage.OrderCount),
+		Time:       g.usage.Time,
+	}
+}
+
+// mergeUsage sets values of src into dst, allocating it if needed
+func mergeUsage(dst, src map[string]int) map[string]int {
+	for interval, value := range src {
+		if dst == nil {
+			dst = make(map[string]int, len(src))
+		}
+		dst[interval] = value
+	}
+	return dst
+}
+
+// RateLimitUsage returns the latest usage of each rate limit reported by responses to the
+// client. Usage of an interval is not reset once it elapsed, compare Time to tell its age.
+func (c *Client) RateLimitUsage() RateLimitUsage {
+	return c.rateLimits.get()
+}
@@ -0,0 +1,66 @@
+package futures
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseRateLimitUsage(t *testing.T) {
+	now := time.Unix(1000, 0)
+	header := http.Header{}
+	header.Set("X-MBX-USED-WEIGHT-1M", "25")
+	header.Set("X-MBX-ORDER-COUNT-10S", "2")
+	header.Set("X-MBX-ORDER-COUNT-1M", "7")
+	header.Set("X-MBX-ORDER-COUNT-1D", "invalid")
+	header.Set("Content-Type", "application/json")
+
+	usage := parseRateLimitUsage(header, now)
+	assert.Equal(t, RateLimitUsage{
+		UsedWeight: map[string]int{"1m": 25},
+		OrderCount: map[string]int{"10s": 2, "1m": 7},
+		Time:       now,
+	}, usage)
+
+	assert.Equal(t, RateLimitUsage{Time: now}, parseRateLimitUsage(http.Header{}, now))
+}
+
+func TestClientRateLimitUsage(t *testing.T) {
+	c := NewClient("apiKey", "secretKey")
+	var header http.Header
+	c.do = func(req *http.Request) (*http.Response, error) {
+		res := newHTTPResponse([]byte(`{}`), http.StatusOK)
+		res.Header = header
+		return res, nil
+	}
+	assert.Empty(t, c.RateLimitUsage().UsedWeight)
+
+	header = http.Header{}
+	header.Set("X-MBX-USED-WEIGHT-1M", "10")
+	header.Set("X-MBX-ORDER-COUNT-10S", "1")
+	var usage RateLimitUsage
+	_, err := c.NewCreateOrderService().Symbol("BTCUSDT").Side(SideTypeBuy).
+		Type(OrderTypeMarket).Quantity("0.1").Do(newContext(), WithRateLimitUsage(&usage))
+	require.NoError(t, err)
+	assert.Equal(t, map[string]int{"1m": 10}, usage.UsedWeight)
+	assert.Equal(t, map[string]int{"10s": 1}, usage.OrderCount)
+
+	// the gauge keeps order counts reported by an earlier response
+	header = http.Header{}
+	header.Set("X-MBX-USED-WEIGHT-1M", "11")
+	_, err = c.NewExchangeInfoService().Do(newContext(), WithRateLimitUsage(&usage))
+	require.NoError(t, err)
+	assert.Nil(t, usage.OrderCount)
+
+	gauge := c.RateLimitUsage()
+	assert.Equal(t, map[string]int{"1m": 11}, gauge.UsedWeight)
+	assert.Equal(t, map[string]int{"10s": 1}, gauge.OrderCount)
+	assert.False(t, gauge.Time.IsZero())
+
+	// the returned maps are copies
+	gauge.UsedWeight["1m"] = 0
+	assert.Equal(t, 11, c.RateLimitUsage().UsedWeight["1m"])
+}
@@ -0,0 +1,391 @@
+package futures
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/adshao/go-binance/v2/common"
+)
+
+// RecorderSchemaVersion is the version of the recorded file layout, it is part of every file name
+// and must be increased whenever the columns of a stream change
+const RecorderSchemaVersion = 1
+
+// RecordStreamType define type of recorded stream
+type RecordStreamType string
+
+// Recorded streams
+const (
+	RecordStreamTypeDepth      RecordStreamType = "depth"
+	RecordStreamTypeAggTrade   RecordStreamType = "aggTrade"
+	RecordStreamTypeBookTicker RecordStreamType = "bookTicker"
+	RecordStreamTypeMarkPrice  RecordStreamType = "markPrice"
+)
+
+// recordHeaders define columns of every stream, the first column is always
+// the local receive time in microseconds
+var recordHeaders = map[RecordStreamType][]string{
+	RecordStreamTypeDepth: {
+		"recv_time_us", "event_time", "transaction_time", "symbol",
+		"first_update_id", "last_update_id", "prev_last_update_id", "bids", "asks",
+	},
+	RecordStreamTypeAggTrade: {
+		"recv_time_us", "event_time", "symbol", "agg_trade_id", "price", "quantity",
+		"first_trade_id", "last_trade_id", "trade_time", "maker",
+	},
+	RecordStreamTypeBookTicker: {
+		"recv_time_us", "event_time", "transaction_time", "symbol", "update_id",
+		"bid_price", "bid_qty", "ask_price", "ask_qty",
+	},
+	RecordStreamTypeMarkPrice: {
+		"recv_time_us", "event_time", "symbol", "mark_price", "index_price",
+		"estimated_settle_price", "funding_rate", "next_funding_time",
+	},
+}
+
+// RecordEncoder encodes records of a stream into a file format
+type RecordEncoder interface {
+	// Extension returns file extension without dot
+	Extension() string
+	// NewWriter returns writer of records into w
+	NewWriter(w io.Writer) RecordWriter
+}
+
+// RecordWriter writes records into a file
+type RecordWriter interface {
+	Write(record []string) error
+	Flush() error
+	// Close flushes the records and ends the file, the underlying writer is left open
+	Close() error
+}
+
+// CSVRecordEncoder encodes records as CSV, the first row is the header
+type CSVRecordEncoder struct{}
+
+// Extension returns file extension
+func (CSVRecordEncoder) Extension() string {
+	return "csv"
+}
+
+// NewWriter returns CSV writer
+func (CSVRecordEncoder) NewWriter(w io.Writer) RecordWriter {
+	return &csvRecordWriter{w: csv.NewWriter(w)}
+}
+
+type csvRecordWriter struct {
+	w *csv.Writer
+}
+
+func (c *csvRecordWriter) Write(record []string) error {
+	return c.w.Write(record)
+}
+
+func (c *csvRecordWriter) Flush() error {
+	c.w.Flush()
+	return c.w.Error()
+}
+
+func (c *csvRecordWriter) Close() error {
+	return c.Flush()
+}
+
+// RecorderConfig define configuration of MarketDataRecorder
+type RecorderConfig struct {
+	// Dir is the output directory, created if missing
+	Dir string
+	// RotateInterval starts a new file once the current one is older, 0 disables time based rotation
+	RotateInterval time.Duration
+	// MaxFileSize starts a new file once the records written into the current one are larger in
+	// bytes, as CSV text before any compression of the encoder. 0 disables size based rotation.
+	MaxFileSize int64
+	// Encoder defaults to CSVRecordEncoder, parquet.RecordEncoder of futures/recorder/parquet
+	// writes Parquet files
+	Encoder RecordEncoder
+}
+
+// MarketDataRecorder persists depth diffs, aggregate trades, book tickers and mark prices
+// into rotating files, one file set per stream type
+type MarketDataRecorder struct {
+	cfg        RecorderConfig
+	errHandler ErrHandler
+	now        func() time.Time
+
+	mu    sync.Mutex
+	files map[RecordStreamType]*rotatingRecordFile
+}
+
+// NewMarketDataRecorder init MarketDataRecorder
+func NewMarketDataRecorder(cfg RecorderConfig) (*MarketDataRecorder, error) {
+	if cfg.Encoder == nil {
+		cfg.Encoder = CSVRecordEncoder{}
+	}
+	if err := os.MkdirAll(cfg.Dir, 0o755); err != nil {
+		return nil, err
+	}
+	return &MarketDataRecorder{
+		cfg:   cfg,
+		now:   time.Now,
+		files: make(map[RecordStreamType]*rotatingRecordFile),
+	}, nil
+}
+
+// ErrHandler set handler for write errors of wrapped handlers
+func (r *MarketDataRecorder) ErrHandler(errHandler ErrHandler) *MarketDataRecorder {
+	r.errHandler = errHandler
+	return r
+}
+
+// RecordDepth records depth event
+func (r *MarketDataRecorder) RecordDepth(event *WsDepthEvent) error {
+	return r.write(RecordStreamTypeDepth, []string{
+		r.recvTime(),
+		formatInt(event.Time),
+		formatInt(event.TransactionTime),
+		event.Symbol,
+		formatInt(event.FirstUpdateID),
+		formatInt(event.LastUpdateID),
+		formatInt(event.PrevLastUpdateID),
+		formatPriceLevels(event.Bids),
+		formatPriceLevels(event.Asks),
+	})
+}
+
+// RecordAggTrade records aggregate trade event
+func (r *MarketDataRecorder) RecordAggTrade(event *WsAggTradeEvent) error {
+	return r.write(RecordStreamTypeAggTrade, []string{
+		r.recvTime(),
+		formatInt(event.Time),
+		event.Symbol,
+		formatInt(event.AggregateTradeID),
+		event.Price,
+		event.Quantity,
+		formatInt(event.FirstTradeID),
+		formatInt(event.LastTradeID),
+		formatInt(event.TradeTime),
+		strconv.FormatBool(event.Maker),
+	})
+}
+
+// RecordBookTicker records book ticker event
+func (r *MarketDataRecorder) RecordBookTicker(event *WsBookTickerEvent) error {
+	return r.write(RecordStreamTypeBookTicker, []string{
+		r.recvTime(),
+		formatInt(event.Time),
+		formatInt(event.TransactionTime),
+		event.Symbol,
+		formatInt(event.UpdateID),
+		event.BestBidPrice,
+		event.BestBidQty,
+		event.BestAskPrice,
+		event.BestAskQty,
+	})
+}
+
+// RecordMarkPrice records mark price event
+func (r *MarketDataRecorder) RecordMarkPrice(event *WsMarkPriceEvent) error {
+	return r.write(RecordStreamTypeMarkPrice, []string{
+		r.recvTime(),
+		formatInt(event.Time),
+		event.Symbol,
+		event.MarkPrice,
+		event.IndexPrice,
+		event.EstimatedSettlePrice,
+		event.FundingRate,
+		formatInt(event.NextFundingTime),
+	})
+}
+
+// DepthHandler wraps handler to record every event before handling it, handler may be nil
+func (r *MarketDataRecorder) DepthHandler(handler WsDepthHandler) WsDepthHandler {
+	return func(event *WsDepthEvent) {
+		r.handleError(r.RecordDepth(event))
+		if handler != nil {
+			handler(event)
+		}
+	}
+}
+
+// AggTradeHandler wraps handler to record every event before handling it, handler may be nil
+func (r *MarketDataRecorder) AggTradeHandler(handler WsAggTradeHandler) WsAggTradeHandler {
+	return func(event *WsAggTradeEvent) {
+		r.handleError(r.RecordAggTrade(event))
+		if handler != nil {
+			handler(event)
+		}
+	}
+}
+
+// BookTickerHandler wraps handler to record every event before handling it, handler may be nil
+func (r *MarketDataRecorder) BookTickerHandler(handler WsBookTickerHandler) WsBookTickerHandler {
+	return func(event *WsBookTickerEvent) {
+		r.handleError(r.RecordBookTicker(event))
+		if handler != nil {
+			handler(event)
+		}
+	}
+}
+
+// MarkPriceHandler wraps handler to record every event before handling it, handler may be nil
+func (r *MarketDataRecorder) MarkPriceHandler(handler WsMarkPriceHandler) WsMarkPriceHandler {
+	return func(event *WsMarkPriceEvent) {
+		r.handleError(r.RecordMarkPrice(event))
+		if handler != nil {
+			handler(event)
+		}
+	}
+}
+
+// AllMarkPriceHandler wraps handler to record every event before handling it, handler may be nil
+func (r *MarketDataRecorder) AllMarkPriceHandler(handler WsAllMarkPriceHandler) WsAllMarkPriceHandler {
+	return func(event WsAllMarkPriceEvent) {
+		for _, e := range event {
+			r.handleError(r.RecordMarkPrice(e))
+		}
+		if handler != nil {
+			handler(event)
+		}
+	}
+}
+
+// Flush flushes buffered records of all streams
+func (r *MarketDataRecorder) Flush() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for _, f := range r.files {
+		if err := f.flush(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Close flushes and closes all files
+func (r *MarketDataRecorder) Close() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	var firstErr error
+	for stream, f := range r.files {
+		if err := f.close(); err != nil && firstErr == nil {
+			firstErr = err
+		}
+		delete(r.files, stream)
+	}
+	return firstErr
+}
+
+func (r *MarketDataRecorder) recvTime() string {
+	return formatInt(r.now().UnixMicro())
+}
+
+func (r *MarketDataRecorder) write(stream RecordStreamType, record []string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	now := r.now()
+	f, ok := r.files[stream]
+	if ok && f.shouldRotate(now, r.cfg) {
+		if err := f.close(); err != nil {
+			return err
+		}
+		ok = false
+	}
+	if !ok {
+		var err error
+		f, err = openRotatingRecordFile(r.cfg, stream, now)
+		if err != nil {
+			return err
+		}
+		r.files[stream] = f
+	}
+	return f.write(record)
+}
+
+func (r *MarketDataRecorder) handleError(err error) {
+	if err != nil && r.errHandler != nil {
+		r.errHandler(err)
+	}
+}
+
+// recordFileName returns file name of stream recording started at t
+func recordFileName(stream RecordStreamType, t time.Time, extension string) string {
+	return fmt.Sprintf("%s_v%d_%s.%s", stream, RecorderSchemaVersion, t.UTC().Format("20060102T150405.000000"), extension)
+}
+
+type rotatingRecordFile struct {
+	file     *os.File
+	writer   RecordWriter
+	openedAt time.Time
+	// size is the size of the records written, as CSV text
+	size int64
+}
+
+func openRotatingRecordFile(cfg RecorderConfig, stream RecordStreamType, now time.Time) (*rotatingRecordFile, error) {
+	name := filepath.Join(cfg.Dir, recordFileName(stream, now, cfg.Encoder.Extension()))
+	file, err := os.OpenFile(name, os.O_CREATE|os.O_WRONLY|os.O_EXCL, 0o644)
+	if err != nil {
+		return nil, err
+	}
+	f := &rotatingRecordFile{
+		file:     file,
+		writer:   cfg.Encoder.NewWriter(file),
+		openedAt: now,
+	}
+	if err := f.write(recordHeaders[stream]); err != nil {
+		file.Close()
+		return nil, err
+	}
+	return f, nil
+}
+
+func (f *rotatingRecordFile) shouldRotate(now time.Time, cfg RecorderConfig) bool {
+	if cfg.RotateInterval > 0 && now.Sub(f.openedAt) >= cfg.RotateInterval {
+		return true
+	}
+	return cfg.MaxFileSize > 0 && f.size >= cfg.MaxFileSize
+}
+
+func (f *rotatingRecordFile) write(record []string) error {
+	if err := f.writer.Write(record); err != nil {
+		return err
+	}
+	// fields, their separators and the line end
+	f.size += int64(len(record))
+	for _, field := range record {
+		f.size += int64(len(field))
+	}
+	return nil
+}
+
+func (f *rotatingRecordFile) flush() error {
+	return f.writer.Flush()
+}
+
+func (f *rotatingRecordFile) close() error {
+	if err := f.writer.Close(); err != nil {
+		f.file.Close()
+		return err
+	}
+	return f.file.Close()
+}
+
+func formatInt(v int64) string {
+	return strconv.FormatInt(v, 10)
+}
+
+// formatPriceLevels formats levels as 'price:quantity' pairs separated by '|'
+func formatPriceLevels(levels []common.PriceLevel) string {
+	parts := make([]string, len(levels))
+	for i, l := range levels {
+		parts[i] = l.Price + ":" + l.Quantity
+	}
+	return strings.Join(parts, "|")
+}
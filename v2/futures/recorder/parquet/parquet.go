@@ -0,0 +1,219 @@
+// Package parquet implements futures.RecordEncoder and futures.RecordDecoder of Parquet files, so
+// MarketDataRecorder and MarketDataReplayer use it without the futures package depending on
+// parquet-go.
+package parquet
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/adshao/go-binance/v2/futures"
+	"github.com/parquet-go/parquet-go"
+)
+
+// parquetColumnsKey is the key of the file metadata holding the recorded columns in order
+const parquetColumnsKey = "columns"
+
+// RecordEncoder encodes records as Parquet with a column per field: times and ids are
+// INT64, flags BOOLEAN and the others, prices included, strings so they keep their precision.
+// It decodes the files it wrote for MarketDataReplayer.
+type RecordEncoder struct{}
+
+// Extension returns file extension
+func (RecordEncoder) Extension() string {
+	return "parquet"
+}
+
+// NewWriter returns Parquet writer, its first record is the header
+func (RecordEncoder) NewWriter(w io.Writer) futures.RecordWriter {
+	return &parquetRecordWriter{w: w}
+}
+
+// NewReader returns Parquet reader, its first record is the header. r is read at once unless it
+// is an io.ReaderAt and an io.Seeker, as files are.
+func (RecordEncoder) NewReader(r io.Reader) futures.RecordReader {
+	reader := &parquetRecordReader{rows: make([]parquet.Row, 1)}
+	reader.err = reader.open(r)
+	return reader
+}
+
+// parquetColumnNode returns the Parquet node of the recorded column name
+func parquetColumnNode(name string) parquet.Node {
+	switch {
+	case strings.HasSuffix(name, "_time"), strings.HasSuffix(name, "_us"), strings.HasSuffix(name, "_id"):
+		return parquet.Int(64)
+	case name == "maker":
+		return parquet.Leaf(parquet.BooleanType)
+	}
+	return parquet.String()
+}
+
+type parquetRecordWriter struct {
+	w      io.Writer
+	writer *parquet.Writer
+	// columns are the indexes in records of the columns of the schema, which sorts them by name
+	columns []int
+	kinds   []parquet.Kind
+	row     parquet.Row
+}
+
+func (p *parquetRecordWriter) Write(record []string) error {
+	if p.writer == nil {
+		p.open(record)
+		return nil
+	}
+	if len(record) != len(p.columns) {
+		return fmt.Errorf("parquet record: %d fields, expected %d", len(record), len(p.columns))
+	}
+	p.row = p.row[:0]
+	for i, index := range p.columns {
+		v, err := parquetValue(p.kinds[i], record[index])
+		if err != nil {
+			return err
+		}
+		p.row = append(p.row, v.Level(0, 0, i))
+	}
+	_, err := p.writer.WriteRows([]parquet.Row{p.row})
+	return err
+}
+
+// open starts the file with the columns of header
+func (p *parquetRecordWriter) open(header []string) {
+	group := make(parquet.Group, len(header))
+	indexes := make(map[string]int, len(header))
+	for i, name := range header {
+		group[name] = parquetColumnNode(name)
+		indexes[name] = i
+	}
+	schema := parquet.NewSchema("record", group)
+	for _, field := range schema.Fields() {
+		p.columns = append(p.columns, indexes[field.Name()])
+		p.kinds = append(p.kinds, field.Type().Kind())
+	}
+	p.writer = parquet.NewWriter(p.w, schema, parquet.KeyValueMetadata(parquetColumnsKey, strings.Join(header, ",")))
+}
+
+func (p *parquetRecordWriter) Flush() error {
+	if p.writer == nil {
+		return nil
+	}
+	return p.writer.Flush()
+}
+
+func (p *parquetRecordWriter) Close() error {
+	if p.writer == nil {
+		return nil
+	}
+	return p.writer.Close()
+}
+
+// parquetValue returns the value of kind written as s
+func parquetValue(kind parquet.Kind, s string) (parquet.Value, error) {
+	switch kind {
+	case parquet.Int64:
+		v, err := strconv.ParseInt(s, 10, 64)
+		if err != nil {
+			return parquet.Value{}, err
+		}
+		return parquet.Int64Value(v), nil
+	case parquet.Boolean:
+		v, err := strconv.ParseBool(s)
+		if err != nil {
+			return parquet.Value{}, err
+		}
+		return parquet.BooleanValue(v), nil
+	}
+	return parquet.ByteArrayValue([]byte(s)), nil
+}
+
+// formatParquetValue returns v as written in records
+func formatParquetValue(v parquet.Value) string {
+	switch v.Kind() {
+	case parquet.Int64:
+		return strconv.FormatInt(v.Int64(), 10)
+	case parquet.Boolean:
+		return strconv.FormatBool(v.Boolean())
+	}
+	return string(v.ByteArray())
+}
+
+type parquetRecordReader struct {
+	reader *parquet.Reader
+	header []string
+	// columns are the indexes in rows of the columns of header
+	columns []int
+	rows    []parquet.Row
+	started bool
+	err     error
+}
+
+func (p *parquetRecordReader) open(r io.Reader) error {
+	input, ok := r.(interface {
+		io.ReaderAt
+		io.Seeker
+	})
+	var size int64
+	if ok {
+		var err error
+		if size, err = input.Seek(0, io.SeekEnd); err != nil {
+			return err
+		}
+	} else {
+		data, err := io.ReadAll(r)
+		if err != nil {
+			return err
+		}
+		input, size = bytes.NewReader(data), int64(len(data))
+	}
+	file, err := parquet.OpenFile(input, size)
+	if err != nil {
+		return err
+	}
+	columns, ok := file.Lookup(parquetColumnsKey)
+	if !ok {
+		return fmt.Errorf("parquet record: file without %s metadata", parquetColumnsKey)
+	}
+	p.header = strings.Split(columns, ",")
+
+	indexes := make(map[string]int)
+	for i, field := range file.Schema().Fields() {
+		indexes[field.Name()] = i
+	}
+	for _, name := range p.header {
+		index, ok := indexes[name]
+		if !ok {
+			return fmt.Errorf("parquet record: file without column %s", name)
+		}
+		p.columns = append(p.columns, index)
+	}
+	p.reader = parquet.NewReader(file)
+	return nil
+}
+
+func (p *parquetRecordReader) Read() ([]string, error) {
+	if p.err != nil {
+		return nil, p.err
+	}
+	if !p.started {
+		p.started = true
+		return p.header, nil
+	}
+	for {
+		n, err := p.reader.ReadRows(p.rows)
+		if n > 0 {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+	row := p.rows[0]
+	record := make([]string, len(p.columns))
+	for i, index := range p.columns {
+		record[i] = formatParquetValue(row[index])
+	}
+	return record, nil
+}
@@ -0,0 +1,96 @@
+package parquet
+
+import (
+	"context"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"testing"
+
+	"github.com/adshao/go-binance/v2/futures"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRecordEncoder(t *testing.T) {
+	r := require.New(t)
+	dir := t.TempDir()
+
+	rec, err := futures.NewMarketDataRecorder(futures.RecorderConfig{Dir: dir, Encoder: RecordEncoder{}, MaxFileSize: 200})
+	r.NoError(err)
+	event := &futures.WsAggTradeEvent{Time: 1, Symbol: "BTCUSDT", AggregateTradeID: 2, Price: "100.10", Quantity: "1", Maker: true}
+	for i := 0; i < 3; i++ {
+		r.NoError(rec.RecordAggTrade(event))
+	}
+	r.NoError(rec.Close())
+
+	files, err := filepath.Glob(filepath.Join(dir, "aggTrade_v1_*.parquet"))
+	r.NoError(err)
+	sort.Strings(files)
+	// the header and two records of about 60 bytes fill a file
+	r.Len(files, 2)
+
+	var records [][]string
+	for _, name := range files {
+		f, err := os.Open(name)
+		r.NoError(err)
+		reader := RecordEncoder{}.NewReader(f)
+		for {
+			record, err := reader.Read()
+			if err == io.EOF {
+				break
+			}
+			r.NoError(err)
+			records = append(records, record)
+		}
+		f.Close()
+	}
+	header := []string{
+		"recv_time_us", "event_time", "symbol", "agg_trade_id", "price", "quantity",
+		"first_trade_id", "last_trade_id", "trade_time", "maker",
+	}
+	r.Len(records, 5)
+	r.Equal(header, records[0])
+	// the receive time is the first column
+	r.Equal([]string{"1", "BTCUSDT", "2", "100.10", "1", "0", "0", "0", "true"}, records[1][1:])
+	r.Equal(header, records[3])
+}
+
+func TestReplay(t *testing.T) {
+	assert := assert.New(t)
+	dir := t.TempDir()
+
+	rec, err := futures.NewMarketDataRecorder(futures.RecorderConfig{Dir: dir, Encoder: RecordEncoder{}})
+	assert.NoError(err)
+	assert.NoError(rec.RecordBookTicker(&futures.WsBookTickerEvent{
+		Time: 1, Symbol: "BTCUSDT", UpdateID: 10, BestBidPrice: "99", BestBidQty: "1", BestAskPrice: "101", BestAskQty: "2",
+	}))
+	assert.NoError(rec.RecordDepth(&futures.WsDepthEvent{
+		Time: 2, TransactionTime: 3, Symbol: "BTCUSDT", FirstUpdateID: 4, LastUpdateID: 5, PrevLastUpdateID: 6,
+		Bids: []futures.Bid{{Price: "100", Quantity: "1"}, {Price: "99", Quantity: "2"}},
+		Asks: []futures.Ask{},
+	}))
+	assert.NoError(rec.RecordMarkPrice(&futures.WsMarkPriceEvent{
+		Time: 5, Symbol: "BTCUSDT", MarkPrice: "100", IndexPrice: "100.1", EstimatedSettlePrice: "100", FundingRate: "0.0001", NextFundingTime: 6,
+	}))
+	assert.NoError(rec.Close())
+
+	var got []string
+	replayer := futures.NewMarketDataReplayer(futures.ReplayConfig{Dir: dir, Decoder: RecordEncoder{}})
+	replayer.OnBookTicker(func(event *futures.WsBookTickerEvent) {
+		got = append(got, "bookTicker "+event.Symbol)
+		assert.Equal("101", event.BestAskPrice)
+	}).OnDepth(func(event *futures.WsDepthEvent) {
+		got = append(got, "depth "+event.Symbol)
+		assert.Equal([]futures.Bid{{Price: "100", Quantity: "1"}, {Price: "99", Quantity: "2"}}, event.Bids)
+	}).OnMarkPrice(func(event *futures.WsMarkPriceEvent) {
+		got = append(got, "markPrice "+event.Symbol)
+		assert.Equal("100.1", event.IndexPrice)
+		assert.Equal(int64(6), event.NextFundingTime)
+	})
+
+	assert.NoError(replayer.Run(context.Background()))
+	// records received in the same microsecond may be replayed in any order
+	assert.ElementsMatch([]string{"bookTicker BTCUSDT", "depth BTCUSDT", "markPrice BTCUSDT"}, got)
+}
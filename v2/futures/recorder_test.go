@@ -0,0 +1,143 @@
+package futures
+
+import (
+	"encoding/csv"
+	"os"
+	"path/filepath"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type recorderTestSuite struct {
+	baseTestSuite
+	dir string
+}
+
+func TestRecorder(t *testing.T) {
+	suite.Run(t, new(recorderTestSuite))
+}
+
+func (s *recorderTestSuite) SetupTest() {
+	s.baseTestSuite.SetupTest()
+	s.dir = s.T().TempDir()
+}
+
+func (s *recorderTestSuite) readRecords(pattern string) [][][]string {
+	files, err := filepath.Glob(filepath.Join(s.dir, pattern))
+	s.r().NoError(err)
+	sort.Strings(files)
+
+	res := make([][][]string, 0, len(files))
+	for _, name := range files {
+		f, err := os.Open(name)
+		s.r().NoError(err)
+		records, err := csv.NewReader(f).ReadAll()
+		f.Close()
+		s.r().NoError(err)
+		res = append(res, records)
+	}
+	return res
+}
+
+func (s *recorderTestSuite) TestRecord() {
+	rec, err := NewMarketDataRecorder(RecorderConfig{Dir: s.dir})
+	s.r().NoError(err)
+	now := time.UnixMicro(1700000000000001)
+	rec.now = func() time.Time { return now }
+
+	var handled int
+	handler := rec.DepthHandler(func(event *WsDepthEvent) {
+		handled++
+	})
+	handler(&WsDepthEvent{
+		Time: 1, TransactionTime: 2, Symbol: "BTCUSDT", FirstUpdateID: 3, LastUpdateID: 4, PrevLastUpdateID: 5,
+		Bids: []Bid{{Price: "100", Quantity: "1"}, {Price: "99", Quantity: "2"}},
+		Asks: []Ask{{Price: "101", Quantity: "0"}},
+	})
+	s.r().Equal(1, handled)
+	s.r().NoError(rec.RecordBookTicker(&WsBookTickerEvent{
+		Time: 1, TransactionTime: 2, Symbol: "BTCUSDT", UpdateID: 3,
+		BestBidPrice: "100", BestBidQty: "1", BestAskPrice: "101", BestAskQty: "2",
+	}))
+	rec.AllMarkPriceHandler(nil)(WsAllMarkPriceEvent{{Time: 1, Symbol: "BTCUSDT", MarkPrice: "100", FundingRate: "0.0001", NextFundingTime: 2}})
+	s.r().NoError(rec.RecordAggTrade(&WsAggTradeEvent{Time: 1, Symbol: "BTCUSDT", AggregateTradeID: 2, Price: "100", Quantity: "1", Maker: true}))
+	s.r().NoError(rec.Close())
+
+	depth := s.readRecords("depth_v1_*.csv")
+	s.r().Len(depth, 1)
+	s.r().Equal([][]string{
+		recordHeaders[RecordStreamTypeDepth],
+		{"1700000000000001", "1", "2", "BTCUSDT", "3", "4", "5", "100:1|99:2", "101:0"},
+	}, depth[0])
+
+	bookTicker := s.readRecords("bookTicker_v1_*.csv")
+	s.r().Len(bookTicker, 1)
+	s.r().Equal([]string{"1700000000000001", "1", "2", "BTCUSDT", "3", "100", "1", "101", "2"}, bookTicker[0][1])
+
+	markPrice := s.readRecords("markPrice_v1_*.csv")
+	s.r().Len(markPrice, 1)
+	s.r().Equal([]string{"1700000000000001", "1", "BTCUSDT", "100", "", "", "0.0001", "2"}, markPrice[0][1])
+
+	aggTrade := s.readRecords("aggTrade_v1_*.csv")
+	s.r().Len(aggTrade, 1)
+	s.r().Equal([]string{"1700000000000001", "1", "BTCUSDT", "2", "100", "1", "0", "0", "0", "true"}, aggTrade[0][1])
+}
+
+func (s *recorderTestSuite) TestRotate() {
+	rec, err := NewMarketDataRecorder(RecorderConfig{Dir: s.dir, RotateInterval: time.Hour})
+	s.r().NoError(err)
+	now := time.Unix(1700000000, 0)
+	rec.now = func() time.Time { return now }
+
+	event := &WsBookTickerEvent{Symbol: "BTCUSDT"}
+	s.r().NoError(rec.RecordBookTicker(event))
+	now = now.Add(30 * time.Minute)
+	s.r().NoError(rec.RecordBookTicker(event))
+	now = now.Add(30 * time.Minute)
+	s.r().NoError(rec.RecordBookTicker(event))
+	s.r().NoError(rec.Close())
+
+	files := s.readRecords("bookTicker_*.csv")
+	s.r().Len(files, 2)
+	s.r().Len(files[0], 3)
+	s.r().Len(files[1], 2)
+}
+
+func (s *recorderTestSuite) TestRotateBySize() {
+	rec, err := NewMarketDataRecorder(RecorderConfig{Dir: s.dir, MaxFileSize: 1})
+	s.r().NoError(err)
+	now := time.Unix(1700000000, 0)
+	rec.now = func() time.Time {
+		now = now.Add(time.Microsecond)
+		return now
+	}
+
+	for i := 0; i < 3; i++ {
+		s.r().NoError(rec.RecordAggTrade(&WsAggTradeEvent{Symbol: "BTCUSDT"}))
+	}
+	s.r().NoError(rec.Close())
+	s.r().Len(s.readRecords("aggTrade_*.csv"), 3)
+}
+
+func (s *recorderTestSuite) TestRotateBySizeBuffered() {
+	rec, err := NewMarketDataRecorder(RecorderConfig{Dir: s.dir, MaxFileSize: 1 << 20})
+	s.r().NoError(err)
+
+	// records stay buffered while the file is below the size
+	s.r().NoError(rec.RecordAggTrade(&WsAggTradeEvent{Symbol: "BTCUSDT"}))
+	files, err := filepath.Glob(filepath.Join(s.dir, "aggTrade_*.csv"))
+	s.r().NoError(err)
+	s.r().Len(files, 1)
+	info, err := os.Stat(files[0])
+	s.r().NoError(err)
+	s.r().Zero(info.Size())
+
+	s.r().NoError(rec.Flush())
+	info, err = os.Stat(files[0])
+	s.r().NoError(err)
+	s.r().NotZero(info.Size())
+	s.r().NoError(rec.Close())
+}
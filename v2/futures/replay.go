@@ -0,0 +1,448 @@
+package futures
+
+import (
+	"context"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+var ErrReplaySchemaMismatch = errors.New("replay: recorded file does not match the recorder schema")
+
+// RecordDecoder decodes records of a stream written by the matching RecordEncoder
+type RecordDecoder interface {
+	// Extension returns file extension without dot
+	Extension() string
+	// NewReader returns reader of records from r
+	NewReader(r io.Reader) RecordReader
+}
+
+// RecordReader reads records from a file, Read returns io.EOF after the last record
+type RecordReader interface {
+	Read() ([]string, error)
+}
+
+// NewReader returns CSV reader
+func (CSVRecordEncoder) NewReader(r io.Reader) RecordReader {
+	return csv.NewReader(r)
+}
+
+// ReplayConfig define configuration of MarketDataReplayer
+type ReplayConfig struct {
+	// Dir is the directory written by MarketDataRecorder
+	Dir string
+	// Decoder defaults to CSVRecordEncoder
+	Decoder RecordDecoder
+	// Symbols restricts replayed records to the symbols, all symbols if empty
+	Symbols []string
+	// StartTime and EndTime restrict replayed records by receive time, zero values are unbounded
+	StartTime time.Time
+	EndTime   time.Time
+	// Speed scales the recorded gaps between records, 1 replays in real time, 10 ten times
+	// faster, 0 replays as fast as possible
+	Speed float64
+}
+
+// MarketDataReplayer replays files recorded by MarketDataRecorder into the same handlers the
+// websocket streams drive, in receive time order across streams, so strategy code runs unchanged
+// in backtests. Order execution and user data are simulated by feeding the replayed book tickers
+// or depth events to a simulator, e.g. algo.PaperPlacer, whose execution reports go to the
+// strategy's user data handler.
+type MarketDataReplayer struct {
+	cfg ReplayConfig
+
+	depth      WsDepthHandler
+	aggTrade   WsAggTradeHandler
+	bookTicker WsBookTickerHandler
+	markPrice  WsMarkPriceHandler
+
+	mu  sync.Mutex
+	now time.Time
+}
+
+// NewMarketDataReplayer init MarketDataReplayer
+func NewMarketDataReplayer(cfg ReplayConfig) *MarketDataReplayer {
+	if cfg.Decoder == nil {
+		cfg.Decoder = CSVRecordEncoder{}
+	}
+	return &MarketDataReplayer{cfg: cfg}
+}
+
+// OnDepth set handler of depth events, streams without handler are not read
+func (r *MarketDataReplayer) OnDepth(handler WsDepthHandler) *MarketDataReplayer {
+	r.depth = handler
+	return r
+}
+
+// OnAggTrade set handler of aggregate trade events
+func (r *MarketDataReplayer) OnAggTrade(handler WsAggTradeHandler) *MarketDataReplayer {
+	r.aggTrade = handler
+	return r
+}
+
+// OnBookTicker set handler of book ticker events
+func (r *MarketDataReplayer) OnBookTicker(handler WsBookTickerHandler) *MarketDataReplayer {
+	r.bookTicker = handler
+	return r
+}
+
+// OnMarkPrice set handler of mark price events
+func (r *MarketDataReplayer) OnMarkPrice(handler WsMarkPriceHandler) *MarketDataReplayer {
+	r.markPrice = handler
+	return r
+}
+
+// Now returns receive time of the record being replayed, strategies should use it instead of
+// the wall clock in backtests
+func (r *MarketDataReplayer) Now() time.Time {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return r.now
+}
+
+// Run replays all records then returns, or returns the error of ctx once it is done
+func (r *MarketDataReplayer) Run(ctx context.Context) error {
+	cursors, err := r.openCursors()
+	if err != nil {
+		return err
+	}
+	defer func() {
+		for _, c := range cursors {
+			c.close()
+		}
+	}()
+
+	var firstRecv time.Time
+	var wallStart time.Time
+	for {
+		var next *replayCursor
+		for _, c := range cursors {
+			if c.record != nil && (next == nil || c.recv.Before(next.recv)) {
+				next = c
+			}
+		}
+		if next == nil {
+			return nil
+		}
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		if r.cfg.Speed > 0 {
+			if firstRecv.IsZero() {
+				firstRecv, wallStart = next.recv, time.Now()
+			}
+			target := wallStart.Add(time.Duration(float64(next.recv.Sub(firstRecv)) / r.cfg.Speed))
+			if err := sleepUntil(ctx, target); err != nil {
+				return err
+			}
+		}
+
+		r.mu.Lock()
+		r.now = next.recv
+		r.mu.Unlock()
+		if err := r.dispatch(next.stream, next.record); err != nil {
+			return fmt.Errorf("replay: %s: %w", next.name, err)
+		}
+		if err := next.advance(); err != nil {
+			return err
+		}
+	}
+}
+
+// openCursors opens the files of the streams having handlers, positioned on their first record
+func (r *MarketDataReplayer) openCursors() ([]*replayCursor, error) {
+	entries, err := os.ReadDir(r.cfg.Dir)
+	if err != nil {
+		return nil, err
+	}
+	files := make(map[RecordStreamType][]string)
+	for _, e := range entries {
+		stream, version, ok := parseRecordFileName(e.Name(), r.cfg.Decoder.Extension())
+		if !ok || !r.handles(stream) {
+			continue
+		}
+		if version != RecorderSchemaVersion {
+			return nil, fmt.Errorf("%w: %s has schema v%d", ErrReplaySchemaMismatch, e.Name(), version)
+		}
+		files[stream] = append(files[stream], filepath.Join(r.cfg.Dir, e.Name()))
+	}
+
+	symbols := make(map[string]bool, len(r.cfg.Symbols))
+	for _, s := range r.cfg.Symbols {
+		symbols[s] = true
+	}
+	var cursors []*replayCursor
+	for stream, names := range files {
+		// file names sort by the time recording started
+		sort.Strings(names)
+		c := &replayCursor{
+			stream:  stream,
+			names:   names,
+			decoder: r.cfg.Decoder,
+			symbols: symbols,
+			start:   r.cfg.StartTime,
+			end:     r.cfg.EndTime,
+		}
+		cursors = append(cursors, c)
+		if err := c.advance(); err != nil {
+			for _, c := range cursors {
+				c.close()
+			}
+			return nil, err
+		}
+	}
+	return cursors, nil
+}
+
+func (r *MarketDataReplayer) handles(stream RecordStreamType) bool {
+	switch stream {
+	case RecordStreamTypeDepth:
+		return r.depth != nil
+	case RecordStreamTypeAggTrade:
+		return r.aggTrade != nil
+	case RecordStreamTypeBookTicker:
+		return r.bookTicker != nil
+	case RecordStreamTypeMarkPrice:
+		return r.markPrice != nil
+	}
+	return false
+}
+
+// dispatch decodes record of stream into its event and calls the handler
+func (r *MarketDataReplayer) dispatch(stream RecordStreamType, record []string) error {
+	p := &recordParser{record: record}
+	switch stream {
+	case RecordStreamTypeDepth:
+		event := &WsDepthEvent{
+			Event:            "depthUpdate",
+			Time:             p.int(1),
+			TransactionTime:  p.int(2),
+			Symbol:           record[3],
+			FirstUpdateID:    p.int(4),
+			LastUpdateID:     p.int(5),
+			PrevLastUpdateID: p.int(6),
+			Bids:             parsePriceLevels(record[7]),
+			Asks:             parsePriceLevels(record[8]),
+		}
+		if p.err == nil {
+			r.depth(event)
+		}
+	case RecordStreamTypeAggTrade:
+		event := &WsAggTradeEvent{
+			Event:            "aggTrade",
+			Time:             p.int(1),
+			Symbol:           record[2],
+			AggregateTradeID: p.int(3),
+			Price:            record[4],
+			Quantity:         record[5],
+			FirstTradeID:     p.int(6),
+			LastTradeID:      p.int(7),
+			TradeTime:        p.int(8),
+			Maker:            record[9] == "true",
+		}
+		if p.err == nil {
+			r.aggTrade(event)
+		}
+	case RecordStreamTypeBookTicker:
+		event := &WsBookTickerEvent{
+			Event:           "bookTicker",
+			Time:            p.int(1),
+			TransactionTime: p.int(2),
+			Symbol:          record[3],
+			UpdateID:        p.int(4),
+			BestBidPrice:    record[5],
+			BestBidQty:      record[6],
+			BestAskPrice:    record[7],
+			BestAskQty:      record[8],
+		}
+		if p.err == nil {
+			r.bookTicker(event)
+		}
+	case RecordStreamTypeMarkPrice:
+		event := &WsMarkPriceEvent{
+			Event:                "markPriceUpdate",
+			Time:                 p.int(1),
+			Symbol:               record[2],
+			MarkPrice:            record[3],
+			IndexPrice:           record[4],
+			EstimatedSettlePrice: record[5],
+			FundingRate:          record[6],
+			NextFundingTime:      p.int(7),
+		}
+		if p.err == nil {
+			r.markPrice(event)
+		}
+	}
+	return p.err
+}
+
+// replayCursor reads the records of a stream across its files in order
+type replayCursor struct {
+	stream  RecordStreamType
+	names   []string
+	decoder RecordDecoder
+	symbols map[string]bool
+	start   time.Time
+	end     time.Time
+
+	name        string
+	file        *os.File
+	reader      RecordReader
+	symbolIndex int
+	// record is the current record received at recv, nil once all files are read
+	record []string
+	recv   time.Time
+}
+
+// advance moves to the next record matching the filters
+func (c *replayCursor) advance() error {
+	for {
+		if c.reader == nil {
+			if len(c.names) == 0 {
+				c.record = nil
+				return nil
+			}
+			if err := c.open(c.names[0]); err != nil {
+				return err
+			}
+			c.names = c.names[1:]
+		}
+		record, err := c.reader.Read()
+		if err == io.EOF {
+			c.close()
+			continue
+		}
+		if err != nil {
+			return fmt.Errorf("replay: %s: %w", c.name, err)
+		}
+		if len(record) != len(recordHeaders[c.stream]) {
+			return fmt.Errorf("%w: %s has a record of %d columns", ErrReplaySchemaMismatch, c.name, len(record))
+		}
+		recvUs, err := strconv.ParseInt(record[0], 10, 64)
+		if err != nil {
+			return fmt.Errorf("replay: %s: %w", c.name, err)
+		}
+		recv := time.UnixMicro(recvUs)
+		if !c.start.IsZero() && recv.Before(c.start) {
+			continue
+		}
+		if !c.end.IsZero() && recv.After(c.end) {
+			// records are in receive order, the rest of the stream is out of range
+			c.close()
+			c.names = nil
+			c.record = nil
+			return nil
+		}
+		if len(c.symbols) > 0 && !c.symbols[record[c.symbolIndex]] {
+			continue
+		}
+		c.record, c.recv = record, recv
+		return nil
+	}
+}
+
+// open opens file name and checks its header against the recorder schema
+func (c *replayCursor) open(name string) error {
+	file, err := os.Open(name)
+	if err != nil {
+		return err
+	}
+	reader := c.decoder.NewReader(file)
+	header, err := reader.Read()
+	if err != nil {
+		file.Close()
+		return fmt.Errorf("replay: %s: %w", name, err)
+	}
+	if strings.Join(header, ",") != strings.Join(recordHeaders[c.stream], ",") {
+		file.Close()
+		return fmt.Errorf("%w: %s has header %v", ErrReplaySchemaMismatch, name, header)
+	}
+	c.name, c.file, c.reader = name, file, reader
+	for i, column := range header {
+		if column == "symbol" {
+			c.symbolIndex = i
+		}
+	}
+	return nil
+}
+
+func (c *replayCursor) close() {
+	if c.file != nil {
+		c.file.Close()
+	}
+	c.file, c.reader = nil, nil
+}
+
+// parseRecordFileName parses stream and schema version of a file named by recordFileName
+func parseRecordFileName(name, extension string) (RecordStreamType, int, bool) {
+	if !strings.HasSuffix(name, "."+extension) {
+		return "", 0, false
+	}
+	parts := strings.SplitN(strings.TrimSuffix(name, "."+extension), "_", 3)
+	if len(parts) != 3 || !strings.HasPrefix(parts[1], "v") {
+		return "", 0, false
+	}
+	stream := RecordStreamType(parts[0])
+	if _, ok := recordHeaders[stream]; !ok {
+		return "", 0, false
+	}
+	version, err := strconv.Atoi(parts[1][1:])
+	if err != nil {
+		return "", 0, false
+	}
+	return stream, version, true
+}
+
+// parsePriceLevels parses levels formatted by formatPriceLevels
+func parsePriceLevels(s string) []Bid {
+	if s == "" {
+		return []Bid{}
+	}
+	parts := strings.Split(s, "|")
+	levels := make([]Bid, 0, len(parts))
+	for _, part := range parts {
+		price, quantity, _ := strings.Cut(part, ":")
+		levels = append(levels, Bid{Price: price, Quantity: quantity})
+	}
+	return levels
+}
+
+// recordParser parses int columns of a record, keeping the first error
+type recordParser struct {
+	record []string
+	err    error
+}
+
+func (p *recordParser) int(i int) int64 {
+	v, err := strconv.ParseInt(p.record[i], 10, 64)
+	if err != nil && p.err == nil {
+		p.err = err
+	}
+	return v
+}
+
+// sleepUntil waits until t or ctx is done
+func sleepUntil(ctx context.Context, t time.Time) error {
+	d := time.Until(t)
+	if d <= 0 {
+		return nil
+	}
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
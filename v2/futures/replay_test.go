@@ -0,0 +1,136 @@
+package futures
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type replayTestSuite struct {
+	baseTestSuite
+	dir string
+}
+
+func TestReplay(t *testing.T) {
+	suite.Run(t, new(replayTestSuite))
+}
+
+func (s *replayTestSuite) SetupTest() {
+	s.baseTestSuite.SetupTest()
+	s.dir = s.T().TempDir()
+}
+
+// record writes a depth event at 1ms, book tickers at 0ms and 2ms and a mark price at 3ms
+func (s *replayTestSuite) record() {
+	rec, err := NewMarketDataRecorder(RecorderConfig{Dir: s.dir})
+	s.r().NoError(err)
+	start := time.UnixMicro(1700000000000000)
+	now := start
+	rec.now = func() time.Time { return now }
+
+	s.r().NoError(rec.RecordBookTicker(&WsBookTickerEvent{
+		Time: 1, Symbol: "BTCUSDT", UpdateID: 10, BestBidPrice: "99", BestBidQty: "1", BestAskPrice: "101", BestAskQty: "2",
+	}))
+	now = start.Add(time.Millisecond)
+	s.r().NoError(rec.RecordDepth(&WsDepthEvent{
+		Time: 2, TransactionTime: 3, Symbol: "BTCUSDT", FirstUpdateID: 4, LastUpdateID: 5, PrevLastUpdateID: 6,
+		Bids: []Bid{{Price: "100", Quantity: "1"}, {Price: "99", Quantity: "2"}},
+		Asks: []Ask{},
+	}))
+	now = start.Add(2 * time.Millisecond)
+	s.r().NoError(rec.RecordBookTicker(&WsBookTickerEvent{
+		Time: 4, Symbol: "ETHUSDT", UpdateID: 11, BestBidPrice: "9", BestBidQty: "1", BestAskPrice: "10", BestAskQty: "2",
+	}))
+	now = start.Add(3 * time.Millisecond)
+	s.r().NoError(rec.RecordMarkPrice(&WsMarkPriceEvent{
+		Time: 5, Symbol: "BTCUSDT", MarkPrice: "100", IndexPrice: "100.1", EstimatedSettlePrice: "100", FundingRate: "0.0001", NextFundingTime: 6,
+	}))
+	s.r().NoError(rec.Close())
+}
+
+func (s *replayTestSuite) TestReplayOrder() {
+	s.record()
+
+	var got []string
+	replayer := NewMarketDataReplayer(ReplayConfig{Dir: s.dir})
+	replayer.OnBookTicker(func(event *WsBookTickerEvent) {
+		got = append(got, "bookTicker "+event.Symbol)
+		if event.Symbol == "BTCUSDT" {
+			s.r().Equal(&WsBookTickerEvent{
+				Event: "bookTicker", Time: 1, Symbol: "BTCUSDT", UpdateID: 10,
+				BestBidPrice: "99", BestBidQty: "1", BestAskPrice: "101", BestAskQty: "2",
+			}, event)
+			s.r().Equal(time.UnixMicro(1700000000000000), replayer.Now())
+		}
+	}).OnDepth(func(event *WsDepthEvent) {
+		got = append(got, "depth "+event.Symbol)
+		s.r().Equal(&WsDepthEvent{
+			Event: "depthUpdate", Time: 2, TransactionTime: 3, Symbol: "BTCUSDT", FirstUpdateID: 4, LastUpdateID: 5, PrevLastUpdateID: 6,
+			Bids: []Bid{{Price: "100", Quantity: "1"}, {Price: "99", Quantity: "2"}},
+			Asks: []Ask{},
+		}, event)
+	}).OnMarkPrice(func(event *WsMarkPriceEvent) {
+		got = append(got, "markPrice "+event.Symbol)
+		s.r().Equal("0.0001", event.FundingRate)
+		s.r().Equal(int64(6), event.NextFundingTime)
+	})
+
+	s.r().NoError(replayer.Run(context.Background()))
+	s.r().Equal([]string{"bookTicker BTCUSDT", "depth BTCUSDT", "bookTicker ETHUSDT", "markPrice BTCUSDT"}, got)
+}
+
+func (s *replayTestSuite) TestReplayFilters() {
+	s.record()
+
+	var got []string
+	replayer := NewMarketDataReplayer(ReplayConfig{
+		Dir:       s.dir,
+		Symbols:   []string{"BTCUSDT"},
+		StartTime: time.UnixMicro(1700000000000500),
+		EndTime:   time.UnixMicro(1700000000002500),
+	})
+	replayer.OnBookTicker(func(event *WsBookTickerEvent) {
+		got = append(got, "bookTicker "+event.Symbol)
+	}).OnDepth(func(event *WsDepthEvent) {
+		got = append(got, "depth "+event.Symbol)
+	}).OnMarkPrice(func(event *WsMarkPriceEvent) {
+		got = append(got, "markPrice "+event.Symbol)
+	})
+
+	s.r().NoError(replayer.Run(context.Background()))
+	s.r().Equal([]string{"depth BTCUSDT"}, got)
+}
+
+func (s *replayTestSuite) TestReplaySpeed() {
+	s.record()
+
+	var count int
+	replayer := NewMarketDataReplayer(ReplayConfig{Dir: s.dir, Speed: 0.1})
+	replayer.OnBookTicker(func(event *WsBookTickerEvent) {
+		count++
+	})
+	start := time.Now()
+	s.r().NoError(replayer.Run(context.Background()))
+	// the 2ms between the book tickers take 20ms
+	s.r().GreaterOrEqual(time.Since(start), 20*time.Millisecond)
+	s.r().Equal(2, count)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	s.r().ErrorIs(replayer.Run(ctx), context.Canceled)
+}
+
+func (s *replayTestSuite) TestReplaySchemaMismatch() {
+	name := filepath.Join(s.dir, "bookTicker_v0_20231114T221320.000000.csv")
+	s.r().NoError(os.WriteFile(name, []byte("recv_time_us\n"), 0o644))
+
+	replayer := NewMarketDataReplayer(ReplayConfig{Dir: s.dir}).OnBookTicker(func(event *WsBookTickerEvent) {})
+	s.r().ErrorIs(replayer.Run(context.Background()), ErrReplaySchemaMismatch)
+
+	s.r().NoError(os.Rename(name, filepath.Join(s.dir, "bookTicker_v1_20231114T221320.000000.csv")))
+	s.r().ErrorIs(replayer.Run(context.Background()), ErrReplaySchemaMismatch)
+}
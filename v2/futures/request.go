@@ -28,6 +28,11 @@ type request struct {
 	header     http.Header
 	body       io.Reader
 	fullURL    string
+	priority   *RequestPriority
+	// rateLimitUsage receives usage reported by the response, if set
+	rateLimitUsage *RateLimitUsage
+	// retry overrides whether RetryPolicy may send the request again, if set
+	retry *bool
 }
 
 // setParam set param with key/value to query string
@@ -0,0 +1,116 @@
+package futures
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/jpillora/backoff"
+)
+
+// retrySafeRequests are the non GET requests which may be sent again: canceling twice only
+// fails the second time, and the others set a value or refresh a timer
+var retrySafeRequests = map[string]bool{
+	"DELETE /fapi/v1/order":            true,
+	"DELETE /fapi/v1/batchOrders":      true,
+	"DELETE /fapi/v1/allOpenOrders":    true,
+	"POST /fapi/v1/countdownCancelAll": true,
+	"POST /fapi/v1/order/test":         true,
+	"POST /fapi/v1/leverage":           true,
+	"POST /fapi/v1/marginType":         true,
+	"POST /fapi/v1/positionSide/dual":  true,
+	"POST /fapi/v1/multiAssetsMargin":  true,
+	"PUT /fapi/v1/listenKey":           true,
+	"DELETE /fapi/v1/listenKey":        true,
+}
+
+// WithRetry makes the request be retried by RetryPolicy of the client or not, overriding
+// whether it is considered safe to send again. E.g. orders with newClientOrderId set may be
+// retried since a duplicate is rejected.
+func WithRetry(retry bool) RequestOption {
+	return func(r *request) {
+		r.retry = &retry
+	}
+}
+
+// retrySafe reports whether r may be sent again after a failure
+func retrySafe(r *request) bool {
+	if r.retry != nil {
+		return *r.retry
+	}
+	return r.method == http.MethodGet || retrySafeRequests[r.method+" "+r.endpoint]
+}
+
+// RetryPolicy define retries of REST requests failing transiently: network errors, server
+// errors, rate limits and the transient error codes. Only queries and requests which are safe
+// to send again, such as cancels, are retried unless set otherwise by WithRetry.
+type RetryPolicy struct {
+	// MaxAttempts is the number of attempts including the first one
+	MaxAttempts int
+	// MinBackoff and MaxBackoff bound the exponential delay between attempts
+	MinBackoff time.Duration
+	MaxBackoff time.Duration
+	// Jitter randomizes delays so clients don't retry at the same time
+	Jitter bool
+	// MaxRetryAfter is the longest Retry-After of 429 and 418 responses waited for, the request
+	// fails at once if the server asks to wait longer
+	MaxRetryAfter time.Duration
+}
+
+// NewRetryPolicy init RetryPolicy making up to 3 attempts
+func NewRetryPolicy() *RetryPolicy {
+	return &RetryPolicy{
+		MaxAttempts:   3,
+		MinBackoff:    100 * time.Millisecond,
+		MaxBackoff:    5 * time.Second,
+		Jitter:        true,
+		MaxRetryAfter: 10 * time.Second,
+	}
+}
+
+func (p *RetryPolicy) backoff() *backoff.Backoff {
+	return &backoff.Backoff{
+		Min:    p.MinBackoff,
+		Max:    p.MaxBackoff,
+		Factor: 2,
+		Jitter: p.Jitter,
+	}
+}
+
+// retryDelay returns the delay before retrying request which failed with res and err, false if
+// it must not be retried
+func (p *RetryPolicy) retryDelay(b *backoff.Backoff, res apiResponse, err error) (time.Duration, bool) {
+	if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return 0, false
+	}
+	switch {
+	case res.status == http.StatusTooManyRequests || res.status == http.StatusTeapot:
+		delay := b.Duration()
+		if retryAfter, ok := parseRetryAfter(res.header.Get("Retry-After")); ok {
+			if retryAfter > p.MaxRetryAfter {
+				return 0, false
+			}
+			delay = retryAfter
+		}
+		return delay, true
+	case res.status >= http.StatusInternalServerError, isTransientError(err):
+		return b.Duration(), true
+	}
+	return 0, false
+}
+
+// parseRetryAfter parses Retry-After header given in seconds or as HTTP date
+func parseRetryAfter(value string) (time.Duration, bool) {
+	if value == "" {
+		return 0, false
+	}
+	if seconds, err := strconv.Atoi(value); err == nil {
+		return time.Duration(seconds) * time.Second, true
+	}
+	if t, err := http.ParseTime(value); err == nil {
+		return max(time.Until(t), 0), true
+	}
+	return 0, false
+}
@@ -0,0 +1,166 @@
+package futures
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/adshao/go-binance/v2/common"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRetrySafe(t *testing.T) {
+	assert.True(t, retrySafe(&request{method: http.MethodGet, endpoint: "/fapi/v1/order"}))
+	assert.True(t, retrySafe(&request{method: http.MethodDelete, endpoint: "/fapi/v1/order"}))
+	assert.True(t, retrySafe(&request{method: http.MethodPut, endpoint: "/fapi/v1/listenKey"}))
+	assert.False(t, retrySafe(&request{method: http.MethodPost, endpoint: "/fapi/v1/order"}))
+	assert.False(t, retrySafe(&request{method: http.MethodPost, endpoint: "/fapi/v1/batchOrders"}))
+
+	r := &request{method: http.MethodPost, endpoint: "/fapi/v1/order"}
+	WithRetry(true)(r)
+	assert.True(t, retrySafe(r))
+	r = &request{method: http.MethodGet, endpoint: "/fapi/v1/order"}
+	WithRetry(false)(r)
+	assert.False(t, retrySafe(r))
+}
+
+func TestParseRetryAfter(t *testing.T) {
+	d, ok := parseRetryAfter("3")
+	assert.True(t, ok)
+	assert.Equal(t, 3*time.Second, d)
+
+	d, ok = parseRetryAfter(time.Now().Add(-time.Minute).UTC().Format(http.TimeFormat))
+	assert.True(t, ok)
+	assert.Equal(t, time.Duration(0), d)
+
+	_, ok = parseRetryAfter("")
+	assert.False(t, ok)
+	_, ok = parseRetryAfter("soon")
+	assert.False(t, ok)
+}
+
+// scriptedResponse define a response returned by scriptedDo
+type scriptedResponse struct {
+	status     int
+	body       string
+	retryAfter string
+	err        error
+}
+
+// scriptedDo returns the scripted responses in turn and records the requests
+func scriptedDo(c *Client, responses ...scriptedResponse) *[]*http.Request {
+	var requests []*http.Request
+	c.do = func(req *http.Request) (*http.Response, error) {
+		requests = append(requests, req)
+		res := responses[0]
+		if len(responses) > 1 {
+			responses = responses[1:]
+		}
+		if res.err != nil {
+			return nil, res.err
+		}
+		header := http.Header{}
+		if res.retryAfter != "" {
+			header.Set("Retry-After", res.retryAfter)
+		}
+		return &http.Response{StatusCode: res.status, Header: header, Body: io.NopCloser(strings.NewReader(res.body))}, nil
+	}
+	return &requests
+}
+
+func newRetryTestClient() *Client {
+	c := NewClient("apiKey", "secretKey")
+	c.RetryPolicy = NewRetryPolicy()
+	c.RetryPolicy.MinBackoff = time.Millisecond
+	c.RetryPolicy.MaxBackoff = time.Millisecond
+	return c
+}
+
+func TestClientRetry(t *testing.T) {
+	c := newRetryTestClient()
+	requests := scriptedDo(c,
+		scriptedResponse{err: errors.New("connection reset")},
+		scriptedResponse{status: http.StatusServiceUnavailable, body: `{"code":-1008,"msg":"Server is currently overloaded"}`},
+		scriptedResponse{status: http.StatusOK, body: `{"serverTime":1499827319559}`},
+	)
+	serverTime, err := c.NewServerTimeService().Do(newContext())
+	require.NoError(t, err)
+	assert.Equal(t, int64(1499827319559), serverTime)
+	assert.Len(t, *requests, 3)
+
+	// attempts are exhausted
+	requests = scriptedDo(c, scriptedResponse{status: http.StatusBadGateway})
+	_, err = c.NewServerTimeService().Do(newContext())
+	assert.Error(t, err)
+	assert.Len(t, *requests, 3)
+
+	// errors of the request itself are not retried
+	requests = scriptedDo(c, scriptedResponse{status: http.StatusBadRequest, body: `{"code":-1121,"msg":"Invalid symbol."}`})
+	_, err = c.NewServerTimeService().Do(newContext())
+	assert.True(t, common.IsAPIErrorCode(err, -1121))
+	assert.Len(t, *requests, 1)
+}
+
+func TestClientRetryOrders(t *testing.T) {
+	c := newRetryTestClient()
+	failure := scriptedResponse{status: http.StatusServiceUnavailable, body: `{"code":-1001,"msg":"Internal error"}`}
+
+	requests := scriptedDo(c, failure)
+	_, err := c.NewCreateOrderService().Symbol("BTCUSDT").Side(SideTypeBuy).
+		Type(OrderTypeMarket).Quantity("0.1").Do(newContext())
+	assert.Error(t, err)
+	assert.Len(t, *requests, 1)
+
+	// cancels are retried, signed again
+	requests = scriptedDo(c, failure, scriptedResponse{status: http.StatusOK, body: `{"orderId":1}`})
+	res, err := c.NewCancelOrderService().Symbol("BTCUSDT").OrderID(1).Do(newContext())
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), res.OrderID)
+	require.Len(t, *requests, 2)
+	assert.Equal(t, 1, strings.Count((*requests)[1].URL.RawQuery, "signature="))
+	assert.Equal(t, 1, strings.Count((*requests)[1].URL.RawQuery, "timestamp="))
+
+	// orders may be retried explicitly
+	requests = scriptedDo(c, failure, scriptedResponse{status: http.StatusOK, body: `{"orderId":2}`})
+	order, err := c.NewCreateOrderService().Symbol("BTCUSDT").Side(SideTypeBuy).Type(OrderTypeMarket).
+		Quantity("0.1").NewClientOrderID("id").Do(newContext(), WithRetry(true))
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), order.OrderID)
+	require.Len(t, *requests, 2)
+	body, err := io.ReadAll((*requests)[1].Body)
+	require.NoError(t, err)
+	assert.Contains(t, string(body), "newClientOrderId=id")
+}
+
+func TestClientRetryAfter(t *testing.T) {
+	c := newRetryTestClient()
+	requests := scriptedDo(c,
+		scriptedResponse{status: http.StatusTooManyRequests, retryAfter: "0", body: `{"code":-1003,"msg":"Too many requests"}`},
+		scriptedResponse{status: http.StatusOK, body: `{"serverTime":1}`},
+	)
+	_, err := c.NewServerTimeService().Do(newContext())
+	require.NoError(t, err)
+	assert.Len(t, *requests, 2)
+
+	// a ban longer than MaxRetryAfter is not waited for
+	requests = scriptedDo(c, scriptedResponse{status: http.StatusTeapot, retryAfter: "600", body: `{"code":-1003,"msg":"Way too many requests"}`})
+	_, err = c.NewServerTimeService().Do(newContext())
+	assert.True(t, common.IsAPIErrorCode(err, -1003))
+	assert.Len(t, *requests, 1)
+}
+
+func TestClientRetryCanceled(t *testing.T) {
+	c := newRetryTestClient()
+	c.RetryPolicy.MinBackoff, c.RetryPolicy.MaxBackoff = time.Hour, time.Hour
+	scriptedDo(c, scriptedResponse{status: http.StatusServiceUnavailable})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	_, err := c.NewServerTimeService().Do(ctx)
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+}
@@ -0,0 +1,211 @@
+package futures
+
+import (
+	"fmt"
+	"math"
+)
+
+// RiskRule define pre-trade risk rule
+type RiskRule string
+
+// Pre-trade risk rules
+const (
+	RiskRuleOrderNotional RiskRule = "ORDER_NOTIONAL"
+	RiskRulePosition      RiskRule = "POSITION"
+	RiskRuleOpenOrders    RiskRule = "OPEN_ORDERS"
+	RiskRulePriceBand     RiskRule = "PRICE_BAND"
+	// RiskRuleMarkPrice rejects orders a rule needs mark price for while it is unknown
+	RiskRuleMarkPrice RiskRule = "MARK_PRICE"
+)
+
+// RiskError is returned when an order is rejected locally by a risk rule
+type RiskError struct {
+	Rule   RiskRule
+	Symbol string
+	Value  float64
+	Limit  float64
+}
+
+func (e *RiskError) Error() string {
+	if e.Rule == RiskRuleMarkPrice {
+		return fmt.Sprintf("risk check: mark price of %s is unknown", e.Symbol)
+	}
+	return fmt.Sprintf("risk check: %s of %s is %v, limit %v", e.Rule, e.Symbol, e.Value, e.Limit)
+}
+
+// RiskOrder define order checked before it is sent, Price is zero for market orders
+type RiskOrder struct {
+	Symbol        string
+	Side          SideType
+	PositionSide  PositionSideType
+	Type          OrderType
+	Quantity      float64
+	Price         float64
+	ReduceOnly    bool
+	ClosePosition bool
+	// Amend is set for an order modifying a live order, which is not counted against MaxOpenOrders
+	Amend bool
+}
+
+// OrderRiskChecker checks orders before they are sent, returning error rejects the order.
+// Set it as RiskChecker of Client and ClientWs to check orders placed through them.
+type OrderRiskChecker interface {
+	CheckOrder(order RiskOrder) error
+}
+
+// MarkPriceSource provides mark price of symbols, implemented by MarkPriceAggregator
+type MarkPriceSource interface {
+	MarkPrice(symbol string) (float64, bool)
+}
+
+// PositionSource provides open positions, implemented by PositionTracker
+type PositionSource interface {
+	Positions() []TrackedPosition
+}
+
+// OpenOrderSource provides live orders of a symbol, implemented by OpenOrderTracker
+type OpenOrderSource interface {
+	Orders(symbol string) []TrackedOrder
+}
+
+var (
+	_ MarkPriceSource  = (*MarkPriceAggregator)(nil)
+	_ PositionSource   = (*PositionTracker)(nil)
+	_ OpenOrderSource  = (*OpenOrderTracker)(nil)
+	_ OrderRiskChecker = (*RiskChecker)(nil)
+)
+
+// RiskLimits define pre-trade limits, zero value of a limit disables it
+type RiskLimits struct {
+	// MaxOrderNotional is the largest order quantity times price, mark price for market orders
+	MaxOrderNotional float64
+	// MaxPosition is the largest absolute net position of a symbol in base asset the order may result in
+	MaxPosition float64
+	// MaxOpenOrders is the largest number of live orders of a symbol
+	MaxOpenOrders int
+	// MaxPriceDeviation is the largest relative deviation of order price from mark price, e.g. 0.05
+	MaxPriceDeviation float64
+}
+
+// RiskChecker enforces RiskLimits using mark prices, positions and live orders of the sources.
+// Rules whose source is nil are skipped. Reduce only and close position orders are not limited
+// by notional and position.
+type RiskChecker struct {
+	Limits RiskLimits
+	// SymbolLimits override Limits for single symbols
+	SymbolLimits map[string]RiskLimits
+
+	MarkPrices MarkPriceSource
+	Positions  PositionSource
+	OpenOrders OpenOrderSource
+}
+
+// CheckOrder implements OrderRiskChecker, returns *RiskError if order breaks a limit
+func (c *RiskChecker) CheckOrder(order RiskOrder) error {
+	limits := c.Limits
+	if l, ok := c.SymbolLimits[order.Symbol]; ok {
+		limits = l
+	}
+
+	if limits.MaxOpenOrders > 0 && c.OpenOrders != nil && !order.Amend {
+		if n := len(c.OpenOrders.Orders(order.Symbol)); n >= limits.MaxOpenOrders {
+			return &RiskError{Rule: RiskRuleOpenOrders, Symbol: order.Symbol, Value: float64(n + 1), Limit: float64(limits.MaxOpenOrders)}
+		}
+	}
+
+	var markPrice float64
+	needMarkPrice := limits.MaxPriceDeviation > 0 && order.Price > 0 ||
+		limits.MaxOrderNotional > 0 && order.Price == 0 && !order.ReduceOnly && !order.ClosePosition
+	if needMarkPrice {
+		var ok bool
+		if c.MarkPrices != nil {
+			markPrice, ok = c.MarkPrices.MarkPrice(order.Symbol)
+		}
+		if !ok || markPrice <= 0 {
+			return &RiskError{Rule: RiskRuleMarkPrice, Symbol: order.Symbol}
+		}
+	}
+
+	if limits.MaxPriceDeviation > 0 && order.Price > 0 {
+		deviation := math.Abs(order.Price-markPrice) / markPrice
+		if deviation > limits.MaxPriceDeviation {
+			return &RiskError{Rule: RiskRulePriceBand, Symbol: order.Symbol, Value: deviation, Limit: limits.MaxPriceDeviation}
+		}
+	}
+
+	if order.ReduceOnly || order.ClosePosition {
+		return nil
+	}
+
+	if limits.MaxOrderNotional > 0 {
+		price := order.Price
+		if price == 0 {
+			price = markPrice
+		}
+		if notional := order.Quantity * price; notional > limits.MaxOrderNotional {
+			return &RiskError{Rule: RiskRuleOrderNotional, Symbol: order.Symbol, Value: notional, Limit: limits.MaxOrderNotional}
+		}
+	}
+
+	if limits.MaxPosition > 0 && c.Positions != nil {
+		var current float64
+		for _, p := range c.Positions.Positions() {
+			if p.Symbol == order.Symbol {
+				current += p.Amount
+			}
+		}
+		after := current + order.Quantity
+		if order.Side == SideTypeSell {
+			after = current - order.Quantity
+		}
+		if math.Abs(after) > limits.MaxPosition && math.Abs(after) > math.Abs(current) {
+			return &RiskError{Rule: RiskRulePosition, Symbol: order.Symbol, Value: math.Abs(after), Limit: limits.MaxPosition}
+		}
+	}
+	return nil
+}
+
+// checkOrderRisk checks order params with checker, if any
+func checkOrderRisk(checker OrderRiskChecker, m params) error {
+	if checker == nil {
+		return nil
+	}
+	order, err := newRiskOrder(m)
+	if err != nil {
+		return err
+	}
+	return checker.CheckOrder(order)
+}
+
+// checkModifyRisk checks the LIMIT order as amended by params m with checker, if any
+func checkModifyRisk(checker OrderRiskChecker, m params) error {
+	if checker == nil {
+		return nil
+	}
+	order, err := newRiskOrder(m)
+	if err != nil {
+		return err
+	}
+	order.Type = OrderTypeLimit
+	order.Amend = true
+	return checker.CheckOrder(order)
+}
+
+func newRiskOrder(m params) (order RiskOrder, err error) {
+	order.Symbol, _ = m["symbol"].(string)
+	order.Side, _ = m["side"].(SideType)
+	order.PositionSide, _ = m["positionSide"].(PositionSideType)
+	order.Type, _ = m["type"].(OrderType)
+	order.ReduceOnly, _ = m["reduceOnly"].(bool)
+	order.ClosePosition, _ = m["closePosition"].(bool)
+
+	quantity, _ := m["quantity"].(string)
+	if order.Quantity, err = parseOptionalFloat(quantity); err != nil {
+		return order, err
+	}
+	price, _ := m["price"].(string)
+	if order.Price, err = parseOptionalFloat(price); err != nil {
+		return order, err
+	}
+	return order, nil
+}
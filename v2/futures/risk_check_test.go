@@ -0,0 +1,132 @@
+package futures
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type fakeMarkPrices map[string]float64
+
+func (f fakeMarkPrices) MarkPrice(symbol string) (float64, bool) {
+	p, ok := f[symbol]
+	return p, ok
+}
+
+type fakePositions []TrackedPosition
+
+func (f fakePositions) Positions() []TrackedPosition {
+	return f
+}
+
+type fakeOpenOrders []TrackedOrder
+
+func (f fakeOpenOrders) Orders(symbol string) []TrackedOrder {
+	var res []TrackedOrder
+	for _, o := range f {
+		if o.Symbol == symbol {
+			res = append(res, o)
+		}
+	}
+	return res
+}
+
+func assertRiskRule(t *testing.T, rule RiskRule, err error) {
+	var riskErr *RiskError
+	if assert.True(t, errors.As(err, &riskErr), "expected RiskError, got %v", err) {
+		assert.Equal(t, rule, riskErr.Rule)
+	}
+}
+
+func TestRiskChecker(t *testing.T) {
+	checker := &RiskChecker{
+		Limits: RiskLimits{
+			MaxOrderNotional:  10000,
+			MaxPosition:       0.5,
+			MaxOpenOrders:     2,
+			MaxPriceDeviation: 0.05,
+		},
+		SymbolLimits: map[string]RiskLimits{
+			"ETHUSDT": {MaxOrderNotional: 1000},
+		},
+		MarkPrices: fakeMarkPrices{"BTCUSDT": 60000, "ETHUSDT": 2500},
+		Positions:  fakePositions{{Symbol: "BTCUSDT", Side: PositionSideTypeBoth, Amount: 0.4}},
+		OpenOrders: fakeOpenOrders{{Symbol: "XRPUSDT", OrderID: 1}, {Symbol: "XRPUSDT", OrderID: 2}},
+	}
+
+	assert.NoError(t, checker.CheckOrder(RiskOrder{Symbol: "BTCUSDT", Side: SideTypeBuy, Quantity: 0.1, Price: 61000}))
+	assertRiskRule(t, RiskRulePriceBand, checker.CheckOrder(RiskOrder{Symbol: "BTCUSDT", Side: SideTypeBuy, Quantity: 0.1, Price: 66000}))
+	assertRiskRule(t, RiskRuleOrderNotional, checker.CheckOrder(RiskOrder{Symbol: "BTCUSDT", Side: SideTypeSell, Quantity: 0.2}))
+	assertRiskRule(t, RiskRulePosition, checker.CheckOrder(RiskOrder{Symbol: "BTCUSDT", Side: SideTypeBuy, Quantity: 0.15, Price: 60000}))
+	assert.NoError(t, checker.CheckOrder(RiskOrder{Symbol: "BTCUSDT", Side: SideTypeSell, Quantity: 0.15, Price: 60000}))
+	assert.NoError(t, checker.CheckOrder(RiskOrder{Symbol: "BTCUSDT", Side: SideTypeBuy, Quantity: 1, ReduceOnly: true}))
+	assertRiskRule(t, RiskRuleOpenOrders, checker.CheckOrder(RiskOrder{Symbol: "XRPUSDT", Side: SideTypeBuy, Quantity: 1, Price: 0.5}))
+	// amending a live order doesn't add one
+	assert.NoError(t, checker.CheckOrder(RiskOrder{Symbol: "XRPUSDT", Side: SideTypeBuy, Type: OrderTypeLimit, Quantity: 1, ReduceOnly: true, Amend: true}))
+	assertRiskRule(t, RiskRuleMarkPrice, checker.CheckOrder(RiskOrder{Symbol: "SOLUSDT", Side: SideTypeBuy, Quantity: 1}))
+
+	// symbol limits override default ones
+	assertRiskRule(t, RiskRuleOrderNotional, checker.CheckOrder(RiskOrder{Symbol: "ETHUSDT", Side: SideTypeBuy, Quantity: 1}))
+	assert.NoError(t, checker.CheckOrder(RiskOrder{Symbol: "ETHUSDT", Side: SideTypeBuy, Quantity: 0.1, Price: 3000}))
+}
+
+func TestCreateOrderRiskCheck(t *testing.T) {
+	c := NewClient("apiKey", "secretKey")
+	c.RiskChecker = &RiskChecker{
+		Limits:     RiskLimits{MaxOrderNotional: 1000},
+		MarkPrices: fakeMarkPrices{"BTCUSDT": 60000},
+	}
+	c.do = func(req *http.Request) (*http.Response, error) {
+		t.Fatal("order rejected by risk check must not be sent")
+		return nil, nil
+	}
+
+	_, err := c.NewCreateOrderService().Symbol("BTCUSDT").Side(SideTypeBuy).
+		Type(OrderTypeLimit).TimeInForce(TimeInForceTypeGTC).Quantity("0.1").Price("60000").
+		Do(newContext())
+	assertRiskRule(t, RiskRuleOrderNotional, err)
+}
+
+func TestCreateBatchOrdersRiskCheck(t *testing.T) {
+	c := NewClient("apiKey", "secretKey")
+	c.RiskChecker = &RiskChecker{
+		Limits:     RiskLimits{MaxOrderNotional: 1000},
+		MarkPrices: fakeMarkPrices{"BTCUSDT": 60000},
+	}
+	c.do = func(req *http.Request) (*http.Response, error) {
+		t.Fatal("batch with an order rejected by risk check must not be sent")
+		return nil, nil
+	}
+
+	_, err := c.NewCreateBatchOrdersService().OrderList([]*CreateOrderService{
+		c.NewCreateOrderService().Symbol("BTCUSDT").Side(SideTypeBuy).
+			Type(OrderTypeLimit).TimeInForce(TimeInForceTypeGTC).Quantity("0.001").Price("60000"),
+		c.NewCreateOrderService().Symbol("BTCUSDT").Side(SideTypeBuy).
+			Type(OrderTypeLimit).TimeInForce(TimeInForceTypeGTC).Quantity("0.1").Price("60000"),
+	}).Do(newContext())
+	assertRiskRule(t, RiskRuleOrderNotional, err)
+}
+
+func TestModifyOrderRiskCheck(t *testing.T) {
+	c := NewClient("apiKey", "secretKey")
+	c.RiskChecker = &RiskChecker{
+		Limits:     RiskLimits{MaxOrderNotional: 1000},
+		MarkPrices: fakeMarkPrices{"BTCUSDT": 60000},
+	}
+	c.do = func(req *http.Request) (*http.Response, error) {
+		t.Fatal("modification rejected by risk check must not be sent")
+		return nil, nil
+	}
+
+	_, err := c.NewModifyOrderService().Symbol("BTCUSDT").Side(SideTypeBuy).OrderID(1).
+		Quantity("0.1").Price("60000").Do(newContext())
+	assertRiskRule(t, RiskRuleOrderNotional, err)
+
+	_, err = c.NewModifyBatchOrdersService().OrderList([]*ModifyOrderService{
+		c.NewModifyOrderService().Symbol("BTCUSDT").Side(SideTypeBuy).OrderID(1).Quantity("0.001").Price("60000"),
+		c.NewModifyOrderService().Symbol("BTCUSDT").Side(SideTypeBuy).OrderID(2).Quantity("0.1").Price("60000"),
+	}).Do(newContext())
+	assertRiskRule(t, RiskRuleOrderNotional, err)
+}
@@ -0,0 +1,69 @@
+package futures
+
+import "time"
+
+type rollingSample struct {
+	time   time.Time
+	values []float64
+}
+
+// rollingWindow keeps running sums of samples added within the last window duration
+type rollingWindow struct {
+	window  time.Duration
+	samples []rollingSample
+	// head is the index of the oldest sample within window, samples before it are dropped
+	// once they are half of the slice so pruning doesn't copy the samples on every add
+	head int
+	sums []float64
+}
+
+func newRollingWindow(window time.Duration, size int) *rollingWindow {
+	return &rollingWindow{
+		window: window,
+		sums:   make([]float64, size),
+	}
+}
+
+// add appends a sample, values must have the window size
+func (w *rollingWindow) add(t time.Time, values ...float64) {
+	w.samples = append(w.samples, rollingSample{time: t, values: values})
+	for i, v := range values {
+		w.sums[i] += v
+	}
+	w.prune(t)
+}
+
+// prune removes samples older than window relative to now
+func (w *rollingWindow) prune(now time.Time) {
+	cutoff := now.Add(-w.window)
+	head := w.head
+	for w.head < len(w.samples) && !w.samples[w.head].time.After(cutoff) {
+		for i, v := range w.samples[w.head].values {
+			w.sums[i] -= v
+		}
+		w.head++
+	}
+	if w.head == head {
+		return
+	}
+	if w.head == len(w.samples) {
+		clear(w.samples)
+		w.samples, w.head = w.samples[:0], 0
+		// avoid accumulating float rounding errors
+		clear(w.sums)
+		return
+	}
+	if w.head >= len(w.samples)/2 {
+		n := copy(w.samples, w.samples[w.head:])
+		clear(w.samples[n:])
+		w.samples, w.head = w.samples[:n], 0
+	}
+}
+
+// snapshot returns the sums and the number of samples within window relative to now
+func (w *rollingWindow) snapshot(now time.Time) ([]float64, int) {
+	w.prune(now)
+	sums := make([]float64, len(w.sums))
+	copy(sums, w.sums)
+	return sums, len(w.samples) - w.head
+}
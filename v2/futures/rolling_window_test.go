@@ -0,0 +1,33 @@
+package futures
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRollingWindow(t *testing.T) {
+	assert := assert.New(t)
+
+	w := newRollingWindow(10*time.Second, 2)
+	start := time.Unix(0, 0)
+	for i := 0; i < 100; i++ {
+		w.add(start.Add(time.Duration(i)*time.Second), 1, float64(i))
+		// pruned samples are dropped from time to time, not on every add
+		assert.LessOrEqual(len(w.samples), 20)
+	}
+	sums, n := w.snapshot(start.Add(99 * time.Second))
+	assert.Equal(10, n)
+	assert.Equal([]float64{10, 90 + 91 + 92 + 93 + 94 + 95 + 96 + 97 + 98 + 99}, sums)
+
+	sums, n = w.snapshot(start.Add(time.Hour))
+	assert.Zero(n)
+	assert.Equal([]float64{0, 0}, sums)
+	assert.Empty(w.samples)
+
+	w.add(start.Add(time.Hour), 2, 3)
+	sums, n = w.snapshot(start.Add(time.Hour))
+	assert.Equal(1, n)
+	assert.Equal([]float64{2, 3}, sums)
+}
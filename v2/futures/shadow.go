@@ -0,0 +1,254 @@
+package futures
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/adshao/go-binance/v2/common"
+)
+
+const defaultShadowTimeout = 10 * time.Second
+
+// ShadowDivergence define a difference between the primary and the shadow result of an order
+type ShadowDivergence struct {
+	// Field is a response field, "accepted" or "errorCode"
+	Field   string
+	Primary string
+	Shadow  string
+}
+
+// ShadowReport define comparison of an order sent over the websocket API and its REST test
+// mirror
+type ShadowReport struct {
+	Symbol        string
+	ClientOrderID string
+	// PrimaryLatency and ShadowLatency are measured locally from send to response
+	PrimaryLatency time.Duration
+	ShadowLatency  time.Duration
+	PrimaryErr     error
+	ShadowErr      error
+	// Divergences is empty when both paths agree
+	Divergences []ShadowDivergence
+}
+
+// Divergent reports whether the paths disagreed
+func (r *ShadowReport) Divergent() bool {
+	return len(r.Divergences) > 0
+}
+
+// ShadowReportHandler handle ShadowReport
+type ShadowReportHandler func(report *ShadowReport)
+
+// ShadowStats define aggregated comparisons of a ShadowComparator
+type ShadowStats struct {
+	Orders    int
+	Divergent int
+	// PrimaryLatency and ShadowLatency are mean latencies
+	PrimaryLatency time.Duration
+	ShadowLatency  time.Duration
+}
+
+// ShadowComparator places orders over the websocket API and mirrors each of them as a REST
+// 'order/test' request, then compares latency, acceptance and the fields both responses hold,
+// e.g. to validate the websocket path before switching a strategy to it. The futures websocket
+// API has no test method, so the REST path is always the shadow. The shadow request runs
+// concurrently and never delays or fails the primary order.
+type ShadowComparator struct {
+	ws      *OrderPlaceWsService
+	rest    *Client
+	timeout time.Duration
+
+	mu       sync.Mutex
+	handlers []ShadowReportHandler
+	stats    ShadowStats
+	primary  time.Duration
+	shadow   time.Duration
+	wg       sync.WaitGroup
+}
+
+// NewShadowComparator init ShadowComparator placing orders with ws and mirroring them with rest
+func NewShadowComparator(ws *ClientWs, rest *Client) *ShadowComparator {
+	return &ShadowComparator{
+		ws:      ws.NewOrderPlaceWsService(),
+		rest:    rest,
+		timeout: defaultShadowTimeout,
+	}
+}
+
+// Timeout set timeout of shadow requests, default 10s
+func (s *ShadowComparator) Timeout(timeout time.Duration) *ShadowComparator {
+	s.timeout = timeout
+	return s
+}
+
+// OnReport registers handler called with the report of every order
+func (s *ShadowComparator) OnReport(handler ShadowReportHandler) *ShadowComparator {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.handlers = append(s.handlers, handler)
+	return s
+}
+
+// PlaceOrder sends req over the websocket API and its mirror over REST, the result is the one of
+// the websocket API
+func (s *ShadowComparator) PlaceOrder(ctx context.Context, req *OrderPlaceWsRequest) (*CreateOrderResponse, error) {
+	type result struct {
+		res     *CreateOrderResponse
+		err     error
+		latency time.Duration
+	}
+	shadowC := make(chan result, 1)
+	s.wg.Add(1)
+	go func() {
+		ctx, cancel := context.WithTimeout(context.Background(), s.timeout)
+		defer cancel()
+		start := time.Now()
+		res, err := req.createOrderService(s.rest).Test(ctx)
+		shadowC <- result{res: res, err: err, latency: time.Since(start)}
+	}()
+
+	start := time.Now()
+	res, err := s.ws.Do(ctx, req)
+	latency := time.Since(start)
+
+	go func() {
+		defer s.wg.Done()
+		shadow := <-shadowC
+		report := &ShadowReport{
+			Symbol:         req.symbol,
+			PrimaryLatency: latency,
+			ShadowLatency:  shadow.latency,
+			PrimaryErr:     err,
+			ShadowErr:      shadow.err,
+		}
+		if req.newClientOrderID != nil {
+			report.ClientOrderID = *req.newClientOrderID
+		}
+		report.Divergences = compareShadowResults(res, err, shadow.res, shadow.err)
+		s.report(report)
+	}()
+	return res, err
+}
+
+// Wait waits until the reports of all placed orders were handled
+func (s *ShadowComparator) Wait() {
+	s.wg.Wait()
+}
+
+// Stats returns aggregated comparisons
+func (s *ShadowComparator) Stats() ShadowStats {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	stats := s.stats
+	if stats.Orders > 0 {
+		stats.PrimaryLatency = s.primary / time.Duration(stats.Orders)
+		stats.ShadowLatency = s.shadow / time.Duration(stats.Orders)
+	}
+	return stats
+}
+
+func (s *ShadowComparator) report(report *ShadowReport) {
+	s.mu.Lock()
+	s.stats.Orders++
+	if report.Divergent() {
+		s.stats.Divergent++
+	}
+	s.primary += report.PrimaryLatency
+	s.shadow += report.ShadowLatency
+	handlers := s.handlers
+	s.mu.Unlock()
+
+	for _, handler := range handlers {
+		handler(report)
+	}
+}
+
+// compareShadowResults compares acceptance and the order fields set in both responses, the
+// test endpoint may leave fields empty
+func compareShadowResults(primary *CreateOrderResponse, primaryErr error, shadow *CreateOrderResponse, shadowErr error) []ShadowDivergence {
+	var divergences []ShadowDivergence
+	if (primaryErr == nil) != (shadowErr == nil) {
+		return append(divergences, ShadowDivergence{
+			Field:   "accepted",
+			Primary: strconv.FormatBool(primaryErr == nil),
+			Shadow:  strconv.FormatBool(shadowErr == nil),
+		})
+	}
+	if primaryErr != nil {
+		primaryCode, shadowCode := shadowErrorCode(primaryErr), shadowErrorCode(shadowErr)
+		if primaryCode != shadowCode {
+			divergences = append(divergences, ShadowDivergence{Field: "errorCode", Primary: primaryCode, Shadow: shadowCode})
+		}
+		return divergences
+	}
+	if primary == nil || shadow == nil {
+		return divergences
+	}
+
+	fields := []struct {
+		name            string
+		primary, shadow string
+	}{
+		{"symbol", primary.Symbol, shadow.Symbol},
+		{"side", string(primary.Side), string(shadow.Side)},
+		{"type", string(primary.Type), string(shadow.Type)},
+		{"timeInForce", string(primary.TimeInForce), string(shadow.TimeInForce)},
+		{"positionSide", string(primary.PositionSide), string(shadow.PositionSide)},
+		{"price", primary.Price, shadow.Price},
+		{"origQty", primary.OrigQuantity, shadow.OrigQuantity},
+		{"stopPrice", primary.StopPrice, shadow.StopPrice},
+	}
+	for _, f := range fields {
+		if f.primary == "" || f.shadow == "" || equalDecimalStrings(f.primary, f.shadow) {
+			continue
+		}
+		divergences = append(divergences, ShadowDivergence{Field: f.name, Primary: f.primary, Shadow: f.shadow})
+	}
+	return divergences
+}
+
+// equalDecimalStrings compares a and b as numbers if both are numbers, "1.0" equals "1"
+func equalDecimalStrings(a, b string) bool {
+	if a == b {
+		return true
+	}
+	x, errX := strconv.ParseFloat(a, 64)
+	y, errY := strconv.ParseFloat(b, 64)
+	return errX == nil && errY == nil && x == y
+}
+
+func shadowErrorCode(err error) string {
+	var apiErr *common.APIError
+	if errors.As(err, &apiErr) {
+		return strconv.FormatInt(apiErr.Code, 10)
+	}
+	return err.Error()
+}
+
+// createOrderService returns CreateOrderService of c sending the same order as s
+func (s *OrderPlaceWsRequest) createOrderService(c *Client) *CreateOrderService {
+	return &CreateOrderService{
+		c:                c,
+		symbol:           s.symbol,
+		side:             s.side,
+		positionSide:     s.positionSide,
+		orderType:        s.orderType,
+		timeInForce:      s.timeInForce,
+		quantity:         s.quantity,
+		reduceOnly:       s.reduceOnly,
+		price:            s.price,
+		newClientOrderID: s.newClientOrderID,
+		stopPrice:        s.stopPrice,
+		workingType:      s.workingType,
+		activationPrice:  s.activationPrice,
+		callbackRate:     s.callbackRate,
+		priceProtect:     s.priceProtect,
+		newOrderRespType: s.newOrderRespType,
+		closePosition:    s.closePosition,
+	}
+}
@@ -0,0 +1,85 @@
+package futures
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type shadowTestSuite struct {
+	baseWsApiTestSuite
+}
+
+func TestShadowComparator(t *testing.T) {
+	suite.Run(t, new(shadowTestSuite))
+}
+
+func (s *shadowTestSuite) newComparator(reply string, code int) (*ShadowComparator, *[]*ShadowReport, *[]string) {
+	var paths []string
+	rest := NewClient("apiKey", "secretKey")
+	rest.do = func(req *http.Request) (*http.Response, error) {
+		paths = append(paths, req.URL.Path)
+		return newHTTPResponse([]byte(reply), code), nil
+	}
+	var reports []*ShadowReport
+	comparator := NewShadowComparator(s.wsClient, rest).OnReport(func(report *ShadowReport) {
+		reports = append(reports, report)
+	})
+	return comparator, &reports, &paths
+}
+
+func (s *shadowTestSuite) newRequest() *OrderPlaceWsRequest {
+	return NewOrderPlaceWsRequest().Symbol("BTCUSDT").Side(SideTypeBuy).Type(OrderTypeLimit).
+		TimeInForce(TimeInForceTypeGTC).Quantity("1").Price("100").NewClientOrderID("c1")
+}
+
+func (s *shadowTestSuite) TestAgree() {
+	s.respond(WsApiMethodOrderPlace, `{"orderId":1,"symbol":"BTCUSDT","side":"BUY","type":"LIMIT","price":"100.00","origQty":"1","status":"NEW"}`)
+	comparator, reports, paths := s.newComparator(`{"symbol":"BTCUSDT","price":"100","origQty":"1.000"}`, http.StatusOK)
+
+	res, err := comparator.PlaceOrder(newContext(), s.newRequest())
+	s.r().NoError(err)
+	s.r().Equal(int64(1), res.OrderID)
+	comparator.Wait()
+
+	s.r().Equal([]string{"/fapi/v1/order/test"}, *paths)
+	s.r().Len(*reports, 1)
+	report := (*reports)[0]
+	s.r().False(report.Divergent())
+	s.r().Equal("BTCUSDT", report.Symbol)
+	s.r().Equal("c1", report.ClientOrderID)
+	s.r().Positive(report.PrimaryLatency)
+	s.r().Positive(report.ShadowLatency)
+
+	stats := comparator.Stats()
+	s.r().Equal(1, stats.Orders)
+	s.r().Equal(0, stats.Divergent)
+	s.r().Equal(report.PrimaryLatency, stats.PrimaryLatency)
+}
+
+func (s *shadowTestSuite) TestDiverge() {
+	s.respond(WsApiMethodOrderPlace, `{"orderId":1,"symbol":"BTCUSDT","price":"100","origQty":"1","status":"NEW"}`)
+	comparator, reports, _ := s.newComparator(`{"symbol":"BTCUSDT","price":"101","origQty":"1"}`, http.StatusOK)
+	_, err := comparator.PlaceOrder(newContext(), s.newRequest())
+	s.r().NoError(err)
+	comparator.Wait()
+	s.r().Equal([]ShadowDivergence{{Field: "price", Primary: "100", Shadow: "101"}}, (*reports)[0].Divergences)
+
+	comparator, reports, _ = s.newComparator(`{"code":-4003,"msg":"Quantity less than zero."}`, http.StatusBadRequest)
+	_, err = comparator.PlaceOrder(newContext(), s.newRequest())
+	s.r().NoError(err)
+	comparator.Wait()
+	report := (*reports)[0]
+	s.r().Equal([]ShadowDivergence{{Field: "accepted", Primary: "true", Shadow: "false"}}, report.Divergences)
+	s.r().Error(report.ShadowErr)
+	s.r().Equal(1, comparator.Stats().Divergent)
+
+	s.mu.Lock()
+	s.responses[WsApiMethodOrderPlace] = `{"id":"{{id}}","status":400,"error":{"code":-1111,"msg":"Precision is over the maximum defined for this asset."}}`
+	s.mu.Unlock()
+	_, err = comparator.PlaceOrder(newContext(), s.newRequest())
+	s.r().Error(err)
+	comparator.Wait()
+	s.r().Equal([]ShadowDivergence{{Field: "errorCode", Primary: "-1111", Shadow: "-4003"}}, (*reports)[1].Divergences)
+}
@@ -0,0 +1,73 @@
+package futures
+
+import (
+	"context"
+	"strconv"
+	"strings"
+
+	"github.com/adshao/go-binance/v2/common"
+)
+
+// API error codes returned when a setting already has the requested value
+const (
+	errCodeNoNeedToChangeMarginType   = -4046
+	errCodeNoNeedToChangePositionSide = -4059
+)
+
+// SymbolConfig define desired trading settings of a symbol, zero value of a setting keeps it as is
+type SymbolConfig struct {
+	Leverage   int
+	MarginType MarginType
+	// DualSidePosition is the account wide position mode: true - Hedge Mode, false - One-way Mode
+	DualSidePosition *bool
+}
+
+// EnsureSymbolConfig reads current settings of symbol and only sends the change requests of
+// settings that differ, "no need to change" errors are ignored
+func (c *Client) EnsureSymbolConfig(ctx context.Context, symbol string, config SymbolConfig, opts ...RequestOption) error {
+	if config.DualSidePosition != nil {
+		mode, err := c.NewGetPositionModeService().Do(ctx, opts...)
+		if err != nil {
+			return err
+		}
+		if mode.DualSidePosition != *config.DualSidePosition {
+			err = c.NewChangePositionModeService().DualSide(*config.DualSidePosition).Do(ctx, opts...)
+			if err != nil && !common.IsAPIErrorCode(err, errCodeNoNeedToChangePositionSide) {
+				return err
+			}
+		}
+	}
+
+	if config.Leverage == 0 && config.MarginType == "" {
+		return nil
+	}
+	positions, err := c.NewGetPositionRiskService().Symbol(symbol).Do(ctx, opts...)
+	if err != nil {
+		return err
+	}
+	var (
+		leverage   int
+		marginType MarginType
+	)
+	if len(positions) > 0 {
+		leverage, _ = strconv.Atoi(positions[0].Leverage)
+		marginType = MarginTypeCrossed
+		if strings.EqualFold(positions[0].MarginType, string(MarginTypeIsolated)) {
+			marginType = MarginTypeIsolated
+		}
+	}
+
+	if config.MarginType != "" && config.MarginType != marginType {
+		err = c.NewChangeMarginTypeService().Symbol(symbol).MarginType(config.MarginType).Do(ctx, opts...)
+		if err != nil && !common.IsAPIErrorCode(err, errCodeNoNeedToChangeMarginType) {
+			return err
+		}
+	}
+	if config.Leverage != 0 && config.Leverage != leverage {
+		_, err = c.NewChangeLeverageService().Symbol(symbol).Leverage(config.Leverage).Do(ctx, opts...)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,46 @@
+package futures
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEnsureSymbolConfig(t *testing.T) {
+	assert := assert.New(t)
+
+	var calls []string
+	c := NewClient("apiKey", "secretKey")
+	c.do = func(req *http.Request) (*http.Response, error) {
+		calls = append(calls, req.Method+" "+req.URL.Path)
+		switch req.Method + " " + req.URL.Path {
+		case "GET /fapi/v1/positionSide/dual":
+			return newHTTPResponse([]byte(`{"dualSidePosition":true}`), http.StatusOK), nil
+		case "GET /fapi/v2/positionRisk":
+			return newHTTPResponse([]byte(`[{"symbol":"BTCUSDT","leverage":"10","marginType":"cross"}]`), http.StatusOK), nil
+		case "POST /fapi/v1/marginType":
+			return newHTTPResponse([]byte(`{"code":-4046,"msg":"No need to change margin type."}`), http.StatusBadRequest), nil
+		case "POST /fapi/v1/leverage":
+			return newHTTPResponse([]byte(`{"leverage":20,"maxNotionalValue":"1000000","symbol":"BTCUSDT"}`), http.StatusOK), nil
+		}
+		return newHTTPResponse([]byte(`{}`), http.StatusOK), nil
+	}
+
+	dualSide := true
+	err := c.EnsureSymbolConfig(newContext(), "BTCUSDT", SymbolConfig{
+		Leverage:         10,
+		MarginType:       MarginTypeCrossed,
+		DualSidePosition: &dualSide,
+	})
+	assert.NoError(err)
+	assert.Equal([]string{"GET /fapi/v1/positionSide/dual", "GET /fapi/v2/positionRisk"}, calls, "nothing to change")
+
+	calls = nil
+	err = c.EnsureSymbolConfig(newContext(), "BTCUSDT", SymbolConfig{
+		Leverage:   20,
+		MarginType: MarginTypeIsolated,
+	})
+	assert.NoError(err, "no need to change error is ignored")
+	assert.Equal([]string{"GET /fapi/v2/positionRisk", "POST /fapi/v1/marginType", "POST /fapi/v1/leverage"}, calls)
+}
@@ -0,0 +1,233 @@
+package futures
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/adshao/go-binance/v2/common"
+)
+
+var ErrSymbolThrottled = errors.New("symbol throttle: order rate of symbol exceeded")
+
+// SymbolThrottleStats define counts of orders of a symbol passed through SymbolThrottle
+type SymbolThrottleStats struct {
+	// Allowed counts the orders let through, including the delayed ones
+	Allowed int64
+	// Delayed counts the orders which waited for capacity
+	Delayed int64
+	// Rejected counts the orders which failed with ErrSymbolThrottled
+	Rejected int64
+}
+
+// SymbolThrottle limits the rate of orders placed per symbol, so a runaway strategy on one
+// symbol can't use up the order rate limit shared with the other symbols of the client. Every
+// symbol has its own bucket of Burst orders refilled at Rate orders per second, orders of a
+// symbol waiting for capacity are let through in turn. Set it as SymbolThrottle of Client and
+// ClientWs, several clients may share one.
+//
+// Processes placing orders with the same API key share the limit through Store, which counts
+// the orders of every symbol within fixed windows, as long as refilling Burst orders takes,
+// instead of a bucket.
+type SymbolThrottle struct {
+	// Rate is the number of orders per second a symbol may place on average
+	Rate float64
+	// Burst is the number of orders a symbol may place at once
+	Burst int
+	// MaxDelay bounds waiting for capacity, orders which would wait longer fail with
+	// ErrSymbolThrottled. Orders fail at once if it is 0.
+	MaxDelay time.Duration
+	// Store, if set, counts orders of every symbol shared with other processes, e.g. through
+	// Redis. Orders are counted in memory while it fails.
+	Store RateLimitStore
+	// OnError is called when Store fails
+	OnError ErrHandler
+
+	clock   common.Clock
+	mu      sync.Mutex
+	symbols map[string]*symbolBucket
+	local   MemoryRateLimitStore
+}
+
+// symbolBucket define the capacity left to a symbol
+type symbolBucket struct {
+	tokens float64
+	last   time.Time
+	stats  SymbolThrottleStats
+}
+
+// NewSymbolThrottle init SymbolThrottle letting every symbol place rate orders per second,
+// burst at once
+func NewSymbolThrottle(rate float64, burst int) *SymbolThrottle {
+	return &SymbolThrottle{
+		Rate:  rate,
+		Burst: burst,
+		clock: common.SystemClock,
+	}
+}
+
+// Stats returns counts of orders of every symbol seen
+func (t *SymbolThrottle) Stats() map[string]SymbolThrottleStats {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	stats := make(map[string]SymbolThrottleStats, len(t.symbols))
+	for symbol, b := range t.symbols {
+		stats[symbol] = b.stats
+	}
+	return stats
+}
+
+// bucket returns the bucket of symbol, which is full when first seen at now
+func (t *SymbolThrottle) bucket(symbol string, now time.Time) *symbolBucket {
+	if t.symbols == nil {
+		t.symbols = make(map[string]*symbolBucket)
+	}
+	b, ok := t.symbols[symbol]
+	if !ok {
+		b = &symbolBucket{tokens: float64(t.Burst), last: now}
+		t.symbols[symbol] = b
+	}
+	return b
+}
+
+// wait blocks until an order of symbol may be placed and returns the function giving back
+// its capacity if the order is not placed after all
+func (t *SymbolThrottle) wait(ctx context.Context, symbol string) (func(), error) {
+	if t.Store != nil {
+		return t.waitWindow(ctx, symbol)
+	}
+	clock := orSystemClock(t.clock)
+
+	t.mu.Lock()
+	now := clock.Now()
+	b := t.bucket(symbol, now)
+	b.tokens = min(b.tokens+now.Sub(b.last).Seconds()*t.Rate, float64(t.Burst))
+	b.last = now
+
+	// the order reserves a token, waiting for the deficit to be refilled
+	var delay time.Duration
+	if b.tokens < 1 {
+		delay = time.Duration((1 - b.tokens) / t.Rate * float64(time.Second))
+		if t.Rate <= 0 || delay > t.MaxDelay {
+			b.stats.Rejected++
+			t.mu.Unlock()
+			return nil, ErrSymbolThrottled
+		}
+		b.stats.Delayed++
+	}
+	b.tokens--
+	b.stats.Allowed++
+	t.mu.Unlock()
+
+	giveBack := func() {
+		t.mu.Lock()
+		b.tokens++
+		b.stats.Allowed--
+		t.mu.Unlock()
+	}
+	if delay == 0 {
+		return giveBack, nil
+	}
+	timer := clock.NewTimer(delay)
+	select {
+	case <-timer.C():
+		return giveBack, nil
+	case <-ctx.Done():
+		timer.Stop()
+		giveBack()
+		return nil, ctx.Err()
+	}
+}
+
+// waitWindow blocks until an order of symbol fits into the current window counted by Store
+func (t *SymbolThrottle) waitWindow(ctx context.Context, symbol string) (func(), error) {
+	clock := orSystemClock(t.clock)
+	counter := windowCounter{store: t.Store, onError: t.OnError, local: &t.local}
+	key := symbolThrottleKey(symbol)
+	now := clock.Now()
+	deadline := now.Add(t.MaxDelay)
+	delayed := false
+	for {
+		var window time.Duration
+		if t.Rate > 0 {
+			window = time.Duration(float64(t.Burst) / t.Rate * float64(time.Second))
+		}
+		if window <= 0 {
+			t.count(symbol, now, func(stats *SymbolThrottleStats) { stats.Rejected++ })
+			return nil, ErrSymbolThrottled
+		}
+		start := now.Truncate(window)
+		if counter.take(ctx, key, start, 1, t.Burst) {
+			t.count(symbol, now, func(stats *SymbolThrottleStats) {
+				stats.Allowed++
+				if delayed {
+					stats.Delayed++
+				}
+			})
+			return func() {
+				counter.add(context.Background(), key, start, -1)
+				t.count(symbol, now, func(stats *SymbolThrottleStats) { stats.Allowed-- })
+			}, nil
+		}
+		next := start.Add(window)
+		if next.After(deadline) {
+			t.count(symbol, now, func(stats *SymbolThrottleStats) { stats.Rejected++ })
+			return nil, ErrSymbolThrottled
+		}
+		delayed = true
+		timer := clock.NewTimer(next.Sub(now))
+		select {
+		case <-timer.C():
+		case <-ctx.Done():
+			timer.Stop()
+			return nil, ctx.Err()
+		}
+		now = clock.Now()
+	}
+}
+
+// count updates stats of symbol
+func (t *SymbolThrottle) count(symbol string, now time.Time, update func(stats *SymbolThrottleStats)) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	update(&t.bucket(symbol, now).stats)
+}
+
+// symbolThrottleKey returns the RateLimitStore key of orders of symbol
+func symbolThrottleKey(symbol string) string {
+	return "symbol-throttle:" + symbol
+}
+
+// throttleOrders waits until throttle and limiter, if any, let an order of each of symbols
+// through. If one of them is held back, the capacity taken for the others is given back.
+func throttleOrders(ctx context.Context, limiter *OrderLimiter, throttle *SymbolThrottle, symbols ...string) error {
+	var giveBacks []func()
+	for _, symbol := range symbols {
+		if throttle != nil {
+			giveBack, err := throttle.wait(ctx, symbol)
+			if err != nil {
+				giveBackAll(giveBacks)
+				return err
+			}
+			giveBacks = append(giveBacks, giveBack)
+		}
+		if limiter != nil {
+			giveBack, err := limiter.wait(ctx)
+			if err != nil {
+				giveBackAll(giveBacks)
+				return err
+			}
+			giveBacks = append(giveBacks, giveBack)
+		}
+	}
+	return nil
+}
+
+func giveBackAll(giveBacks []func()) {
+	for _, giveBack := range giveBacks {
+		giveBack()
+	}
+}
@@ -0,0 +1,212 @@
+package futures
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/adshao/go-binance/v2/common"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestSymbolThrottle(rate float64, burst int) (*SymbolThrottle, *common.FakeClock) {
+	clock := common.NewFakeClock(time.Unix(6000, 0))
+	t := NewSymbolThrottle(rate, burst)
+	t.clock = clock
+	return t, clock
+}
+
+// waitSymbol waits for throttle to let an order of symbol through, keeping its capacity
+func waitSymbol(ctx context.Context, throttle *SymbolThrottle, symbol string) error {
+	_, err := throttle.wait(ctx, symbol)
+	return err
+}
+
+func TestSymbolThrottle(t *testing.T) {
+	throttle, clock := newTestSymbolThrottle(1, 2)
+	ctx := context.Background()
+
+	assert.NoError(t, waitSymbol(ctx, throttle, "BTCUSDT"))
+	assert.NoError(t, waitSymbol(ctx, throttle, "BTCUSDT"))
+	assert.ErrorIs(t, waitSymbol(ctx, throttle, "BTCUSDT"), ErrSymbolThrottled)
+	// other symbols are not held back
+	assert.NoError(t, waitSymbol(ctx, throttle, "ETHUSDT"))
+
+	clock.Advance(time.Second)
+	assert.NoError(t, waitSymbol(ctx, throttle, "BTCUSDT"))
+	assert.ErrorIs(t, waitSymbol(ctx, throttle, "BTCUSDT"), ErrSymbolThrottled)
+
+	assert.Equal(t, map[string]SymbolThrottleStats{
+		"BTCUSDT": {Allowed: 3, Rejected: 2},
+		"ETHUSDT": {Allowed: 1},
+	}, throttle.Stats())
+}
+
+func TestSymbolThrottleDelay(t *testing.T) {
+	throttle, clock := newTestSymbolThrottle(2, 1)
+	throttle.MaxDelay = time.Second
+	ctx := context.Background()
+	require.NoError(t, waitSymbol(ctx, throttle, "BTCUSDT"))
+
+	// waiting orders reserve capacity in turn
+	errC := make(chan error, 2)
+	for i := 0; i < 2; i++ {
+		go func() {
+			errC <- waitSymbol(ctx, throttle, "BTCUSDT")
+		}()
+	}
+	clock.BlockUntil(2)
+	assert.ErrorIs(t, waitSymbol(ctx, throttle, "BTCUSDT"), ErrSymbolThrottled)
+
+	clock.Advance(500 * time.Millisecond)
+	assert.NoError(t, <-errC)
+	clock.Advance(500 * time.Millisecond)
+	assert.NoError(t, <-errC)
+	assert.Equal(t, SymbolThrottleStats{Allowed: 3, Delayed: 2, Rejected: 1}, throttle.Stats()["BTCUSDT"])
+
+	// canceled orders give their capacity back
+	ctx, cancel := context.WithCancel(context.Background())
+	go func() {
+		errC <- waitSymbol(ctx, throttle, "BTCUSDT")
+	}()
+	clock.BlockUntil(1)
+	cancel()
+	assert.ErrorIs(t, <-errC, context.Canceled)
+	clock.Advance(500 * time.Millisecond)
+	assert.Equal(t, SymbolThrottleStats{Allowed: 3, Delayed: 3, Rejected: 1}, throttle.Stats()["BTCUSDT"])
+	assert.NoError(t, waitSymbol(context.Background(), throttle, "BTCUSDT"))
+}
+
+func TestClientSymbolThrottle(t *testing.T) {
+	c := NewClient("apiKey", "secretKey")
+	c.SymbolThrottle, _ = newTestSymbolThrottle(1, 1)
+	requests := 0
+	c.do = func(req *http.Request) (*http.Response, error) {
+		requests++
+		return newHTTPResponse([]byte(`{"orderId":1}`), http.StatusOK), nil
+	}
+	placeOrder := func(symbol string) error {
+		_, err := c.NewCreateOrderService().Symbol(symbol).Side(SideTypeBuy).
+			Type(OrderTypeMarket).Quantity("0.1").Do(newContext())
+		return err
+	}
+
+	assert.NoError(t, placeOrder("BTCUSDT"))
+	assert.ErrorIs(t, placeOrder("BTCUSDT"), ErrSymbolThrottled)
+	assert.NoError(t, placeOrder("ETHUSDT"))
+	assert.Equal(t, 2, requests)
+}
+
+func TestModifyOrderSymbolThrottle(t *testing.T) {
+	c := NewClient("apiKey", "secretKey")
+	c.SymbolThrottle, _ = newTestSymbolThrottle(1, 2)
+	requests := 0
+	c.do = func(req *http.Request) (*http.Response, error) {
+		requests++
+		if req.URL.Path == "/fapi/v1/batchOrders" {
+			return newHTTPResponse([]byte(`[{"orderId":1},{"orderId":2}]`), http.StatusOK), nil
+		}
+		return newHTTPResponse([]byte(`{"orderId":1}`), http.StatusOK), nil
+	}
+	modify := func(orderID int64) *ModifyOrderService {
+		return c.NewModifyOrderService().Symbol("BTCUSDT").Side(SideTypeBuy).OrderID(orderID).
+			Quantity("0.1").Price("60000")
+	}
+
+	_, err := c.NewModifyBatchOrdersService().OrderList([]*ModifyOrderService{modify(1), modify(2)}).Do(newContext())
+	assert.NoError(t, err)
+	_, err = modify(1).Do(newContext())
+	assert.ErrorIs(t, err, ErrSymbolThrottled)
+	assert.Equal(t, 1, requests)
+}
+
+func TestSymbolThrottleStore(t *testing.T) {
+	store := NewMemoryRateLimitStore()
+	t1, clock := newTestSymbolThrottle(1, 2)
+	t2, _ := newTestSymbolThrottle(1, 2)
+	t2.clock = clock
+	t1.Store, t2.Store = store, store
+	ctx := context.Background()
+
+	// orders of a symbol in one process hold back the other, within windows of 2 seconds
+	assert.NoError(t, waitSymbol(ctx, t1, "BTCUSDT"))
+	assert.NoError(t, waitSymbol(ctx, t2, "BTCUSDT"))
+	assert.ErrorIs(t, waitSymbol(ctx, t1, "BTCUSDT"), ErrSymbolThrottled)
+	assert.NoError(t, waitSymbol(ctx, t2, "ETHUSDT"))
+
+	t1.MaxDelay = 2 * time.Second
+	errC := make(chan error, 1)
+	go func() {
+		errC <- waitSymbol(ctx, t1, "BTCUSDT")
+	}()
+	clock.BlockUntil(1)
+	clock.Advance(2 * time.Second)
+	assert.NoError(t, <-errC)
+
+	assert.Equal(t, map[string]SymbolThrottleStats{
+		"BTCUSDT": {Allowed: 2, Delayed: 1, Rejected: 1},
+	}, t1.Stats())
+}
+
+func TestSymbolThrottleZeroValue(t *testing.T) {
+	throttle := &SymbolThrottle{}
+	assert.ErrorIs(t, waitSymbol(context.Background(), throttle, "BTCUSDT"), ErrSymbolThrottled)
+	throttle.Store = NewMemoryRateLimitStore()
+	assert.ErrorIs(t, waitSymbol(context.Background(), throttle, "BTCUSDT"), ErrSymbolThrottled)
+}
+
+func TestThrottleOrdersSent(t *testing.T) {
+	c := NewClient("apiKey", "secretKey")
+	c.SymbolThrottle, _ = newTestSymbolThrottle(1, 1)
+	c.OrderLimiter, _ = newTestOrderLimiter(OrderLimit{Interval: time.Minute, Limit: 1})
+	c.RiskChecker = &RiskChecker{
+		Limits:     RiskLimits{MaxOrderNotional: 1000},
+		MarkPrices: fakeMarkPrices{"BTCUSDT": 60000},
+	}
+	c.CircuitBreaker = NewCircuitBreaker(1, time.Hour)
+	var paths []string
+	c.do = func(req *http.Request) (*http.Response, error) {
+		paths = append(paths, req.URL.Path)
+		return newHTTPResponse([]byte(`{"orderId":1}`), http.StatusOK), nil
+	}
+	order := func(quantity string) *CreateOrderService {
+		return c.NewCreateOrderService().Symbol("BTCUSDT").Side(SideTypeBuy).
+			Type(OrderTypeLimit).TimeInForce(TimeInForceTypeGTC).Quantity(quantity).Price("60000")
+	}
+	batch := func(orders ...*CreateOrderService) error {
+		_, err := c.NewCreateBatchOrdersService().OrderList(orders).Do(newContext())
+		return err
+	}
+
+	// test orders take nothing
+	_, err := order("0.01").Test(newContext())
+	require.NoError(t, err)
+	// nor do batches with an order rejected by the risk check
+	assertRiskRule(t, RiskRuleOrderNotional, batch(order("0.01"), order("0.1")))
+	// nor batches held back, the capacity taken for their first orders is given back
+	assert.ErrorIs(t, batch(order("0.01"), order("0.01")), ErrSymbolThrottled)
+	// nor orders rejected by the breaker
+	require.NoError(t, c.CircuitBreaker.allow())
+	c.CircuitBreaker.done(&common.APIError{Code: -2019})
+	_, err = order("0.01").Do(newContext())
+	assert.ErrorIs(t, err, ErrCircuitOpen)
+
+	c.CircuitBreaker.Reset()
+	_, err = order("0.01").Do(newContext())
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"/fapi/v1/order/test", "/fapi/v1/order"}, paths)
+	assert.Equal(t, SymbolThrottleStats{Allowed: 1, Rejected: 1}, c.SymbolThrottle.Stats()["BTCUSDT"])
+}
+
+func TestThrottleOrdersGiveBack(t *testing.T) {
+	throttle, _ := newTestSymbolThrottle(1, 2)
+	limiter, _ := newTestOrderLimiter(OrderLimit{Interval: time.Minute, Limit: 2})
+	ctx := context.Background()
+
+	// the limiter holds back the third order, the throttle gets back what it gave the others
+	assert.ErrorIs(t, throttleOrders(ctx, limiter, throttle, "BTCUSDT", "ETHUSDT", "BTCUSDT"), ErrOrderRateLimited)
+	assert.NoError(t, throttleOrders(ctx, limiter, throttle, "BTCUSDT", "BTCUSDT"))
+	assert.ErrorIs(t, waitSymbol(ctx, throttle, "BTCUSDT"), ErrSymbolThrottled)
+}
@@ -2,7 +2,6 @@ package futures
 
 import (
 	"context"
-	"encoding/json"
 	"net/http"
 
 	"github.com/adshao/go-binance/v2/common"
@@ -35,7 +34,7 @@ func (s *ListBookTickersService) Do(ctx context.Context, opts ...RequestOption)
 		return []*BookTicker{}, err
 	}
 	res = make([]*BookTicker, 0)
-	err = json.Unmarshal(data, &res)
+	err = unmarshalResponse(data, &res)
 	if err != nil {
 		return []*BookTicker{}, err
 	}
@@ -49,6 +48,7 @@ type BookTicker struct {
 	BidQuantity string `json:"bidQty"`
 	AskPrice    string `json:"askPrice"`
 	AskQuantity string `json:"askQty"`
+	Time        int64  `json:"time"`
 }
 
 // ListPricesService list latest price for a symbol or symbols
@@ -78,7 +78,7 @@ func (s *ListPricesService) Do(ctx context.Context, opts ...RequestOption) (res
 	}
 	data = common.ToJSONList(data)
 	res = make([]*SymbolPrice, 0)
-	err = json.Unmarshal(data, &res)
+	err = unmarshalResponse(data, &res)
 	if err != nil {
 		return []*SymbolPrice{}, err
 	}
@@ -89,6 +89,7 @@ func (s *ListPricesService) Do(ctx context.Context, opts ...RequestOption) (res
 type SymbolPrice struct {
 	Symbol string `json:"symbol"`
 	Price  string `json:"price"`
+	Time   int64  `json:"time"`
 }
 
 // ListPriceChangeStatsService show stats of price change in last 24 hours for all symbols
@@ -118,7 +119,7 @@ func (s *ListPriceChangeStatsService) Do(ctx context.Context, opts ...RequestOpt
 	}
 	data = common.ToJSONList(data)
 	res = make([]*PriceChangeStats, 0)
-	err = json.Unmarshal(data, &res)
+	err = unmarshalResponse(data, &res)
 	if err != nil {
 		return nil, err
 	}
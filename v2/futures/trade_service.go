@@ -2,7 +2,6 @@ package futures
 
 import (
 	"context"
-	"encoding/json"
 	"net/http"
 )
 
@@ -52,7 +51,7 @@ func (s *HistoricalTradesService) Do(ctx context.Context, opts ...RequestOption)
 		return
 	}
 	res = make([]*Trade, 0)
-	err = json.Unmarshal(data, &res)
+	err = unmarshalResponse(data, &res)
 	if err != nil {
 		return
 	}
@@ -149,7 +148,7 @@ func (s *AggTradesService) Do(ctx context.Context, opts ...RequestOption) (res [
 		return []*AggTrade{}, err
 	}
 	res = make([]*AggTrade, 0)
-	err = json.Unmarshal(data, &res)
+	err = unmarshalResponse(data, &res)
 	if err != nil {
 		return []*AggTrade{}, err
 	}
@@ -201,7 +200,7 @@ func (s *RecentTradesService) Do(ctx context.Context, opts ...RequestOption) (re
 		return []*Trade{}, err
 	}
 	res = make([]*Trade, 0)
-	err = json.Unmarshal(data, &res)
+	err = unmarshalResponse(data, &res)
 	if err != nil {
 		return []*Trade{}, err
 	}
@@ -283,7 +282,7 @@ func (s *ListAccountTradeService) Do(ctx context.Context, opts ...RequestOption)
 		return []*AccountTrade{}, err
 	}
 	res = make([]*AccountTrade, 0)
-	err = json.Unmarshal(data, &res)
+	err = unmarshalResponse(data, &res)
 	if err != nil {
 		return []*AccountTrade{}, err
 	}
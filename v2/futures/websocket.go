@@ -1,9 +1,14 @@
 package futures
 
 import (
+	"context"
+	"crypto/tls"
+	"net"
 	"net/http"
+	"sync/atomic"
 	"time"
 
+	"github.com/adshao/go-binance/v2/common"
 	"github.com/gorilla/websocket"
 )
 
@@ -16,22 +21,105 @@ type ErrHandler func(err error)
 // WsConfig webservice configuration
 type WsConfig struct {
 	Endpoint string
+	Socket   WsSocketConfig
+	// Compression negotiates permessage-deflate with the server
+	Compression bool
 }
 
 func newWsConfig(endpoint string) *WsConfig {
 	return &WsConfig{
-		Endpoint: endpoint,
+		Endpoint:    endpoint,
+		Socket:      WsSocketOptions,
+		Compression: WsStreamCompression,
 	}
 }
 
-var wsServe = func(cfg *WsConfig, handler WsHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error) {
-	Dialer := websocket.Dialer{
+// WsSocketConfig define tuning of the sockets of websocket connections. The zero value keeps
+// the defaults of Go and of the OS.
+type WsSocketConfig struct {
+	// EnableNagle makes the OS delay small writes to coalesce them, Go disables it by default
+	// which suits latency sensitive order requests
+	EnableNagle bool
+	// ReadBufferSize and WriteBufferSize set the size of the OS receive and send buffers of the
+	// socket, 0 keeps the OS default
+	ReadBufferSize  int
+	WriteBufferSize int
+	// WriteTimeout is the deadline of writing a websocket API request, 0 for none. The
+	// connection is reconnected after a write times out since a frame may have been partially
+	// written.
+	WriteTimeout time.Duration
+	// TLSSessionCache, e.g. tls.NewLRUClientSessionCache(0), lets reconnects resume the TLS
+	// session instead of a full handshake
+	TLSSessionCache tls.ClientSessionCache
+	// LocalAddr, if set, is the local address connections are dialed from, e.g. to pick the
+	// egress IP of a host with several
+	LocalAddr net.Addr
+}
+
+// dial dials a TCP connection tuned by c
+func (c WsSocketConfig) dial(ctx context.Context, network, addr string) (net.Conn, error) {
+	conn, err := (&net.Dialer{LocalAddr: c.LocalAddr}).DialContext(ctx, network, addr)
+	if err != nil {
+		return nil, err
+	}
+	tcpConn, ok := conn.(*net.TCPConn)
+	if !ok {
+		return conn, nil
+	}
+	if err := c.tune(tcpConn); err != nil {
+		conn.Close()
+		return nil, err
+	}
+	return conn, nil
+}
+
+func (c WsSocketConfig) tune(conn *net.TCPConn) error {
+	if c.EnableNagle {
+		if err := conn.SetNoDelay(false); err != nil {
+			return err
+		}
+	}
+	if c.ReadBufferSize > 0 {
+		if err := conn.SetReadBuffer(c.ReadBufferSize); err != nil {
+			return err
+		}
+	}
+	if c.WriteBufferSize > 0 {
+		if err := conn.SetWriteBuffer(c.WriteBufferSize); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// newWsDialer init websocket.Dialer of cfg, connections of the websocket API are dialed to be
+// coalesced by the pipelined writer
+func newWsDialer(cfg *WsConfig, coalescing bool) *websocket.Dialer {
+	socket := cfg.Socket
+	dial := socket.dial
+	if coalescing {
+		dial = func(ctx context.Context, network, addr string) (net.Conn, error) {
+			conn, err := socket.dial(ctx, network, addr)
+			if err != nil {
+				return nil, err
+			}
+			return &coalescingConn{Conn: conn}, nil
+		}
+	}
+	dialer := &websocket.Dialer{
+		NetDialContext:    dial,
 		Proxy:             http.ProxyFromEnvironment,
 		HandshakeTimeout:  45 * time.Second,
-		EnableCompression: false,
+		EnableCompression: cfg.Compression,
 	}
+	if socket.TLSSessionCache != nil {
+		dialer.TLSClientConfig = &tls.Config{ClientSessionCache: socket.TLSSessionCache}
+	}
+	return dialer
+}
 
-	c, _, err := Dialer.Dial(cfg.Endpoint, nil)
+var wsServe = func(cfg *WsConfig, handler WsHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error) {
+	c, _, err := newWsDialer(cfg, false).Dial(cfg.Endpoint, nil)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -44,7 +132,7 @@ var wsServe = func(cfg *WsConfig, handler WsHandler, errHandler ErrHandler) (don
 		// closed by the client.
 		defer close(doneC)
 		if WebsocketKeepalive {
-			keepAlive(c, WebsocketTimeout)
+			keepAlive(c, WebsocketTimeout, common.SystemClock)
 		}
 		// Wait for the stopC channel to be closed.  We do that in a
 		// separate goroutine because ReadMessage is a blocking
@@ -72,12 +160,13 @@ var wsServe = func(cfg *WsConfig, handler WsHandler, errHandler ErrHandler) (don
 	return
 }
 
-func keepAlive(c *websocket.Conn, timeout time.Duration) {
-	ticker := time.NewTicker(timeout)
+func keepAlive(c *websocket.Conn, timeout time.Duration, clock common.Clock) {
+	ticker := clock.NewTicker(timeout)
 
-	lastResponse := time.Now()
+	var lastResponse atomic.Int64
+	lastResponse.Store(clock.Now().UnixNano())
 	c.SetPongHandler(func(msg string) error {
-		lastResponse = time.Now()
+		lastResponse.Store(clock.Now().UnixNano())
 		return nil
 	})
 
@@ -89,8 +178,8 @@ func keepAlive(c *websocket.Conn, timeout time.Duration) {
 			if err != nil {
 				return
 			}
-			<-ticker.C
-			if time.Since(lastResponse) > timeout {
+			<-ticker.C()
+			if clock.Now().Sub(time.Unix(0, lastResponse.Load())) > timeout {
 				c.Close()
 				return
 			}
@@ -99,19 +188,13 @@ func keepAlive(c *websocket.Conn, timeout time.Duration) {
 }
 
 var WsGetReadWriteConnection = func(cfg *WsConfig) (*websocket.Conn, error) {
-	Dialer := websocket.Dialer{
-		Proxy:             http.ProxyFromEnvironment,
-		HandshakeTimeout:  45 * time.Second,
-		EnableCompression: false,
-	}
-
-	c, _, err := Dialer.Dial(cfg.Endpoint, nil)
+	c, _, err := newWsDialer(cfg, true).Dial(cfg.Endpoint, nil)
 	if err != nil {
 		return nil, err
 	}
 
 	if WebsocketKeepalive {
-		keepAlive(c, WebsocketTimeoutReadWriteConnection)
+		keepAlive(c, WebsocketTimeoutReadWriteConnection, WsApiClock)
 	}
 
 	return c, nil
@@ -7,6 +7,7 @@ import (
 	"strings"
 	"time"
 
+	"github.com/adshao/go-binance/v2/common"
 	"github.com/bitly/go-simplejson"
 	"github.com/gorilla/websocket"
 )
@@ -33,6 +34,34 @@ var (
 	// WebsocketTimeoutReadWriteConnection is an interval for sending ping/pong messages if WebsocketKeepalive is enabled
 	// using for websocket API (read/write)
 	WebsocketTimeoutReadWriteConnection = time.Second * 10
+	// WsApiClock drives reconnect backoff, keepalives and timestamps of websocket API clients
+	// created afterwards, tests may replace it with a common.FakeClock
+	WsApiClock common.Clock = common.SystemClock
+	// StrictDecoding makes REST and websocket responses fail with *common.SchemaError on unknown
+	// fields or missing fields not tagged omitempty instead of zeroing them, to catch API changes
+	// in staging. Responses and events with custom decoding are not checked. Responses of
+	// requests placing, modifying or canceling orders are decoded anyway, their schema errors
+	// go to StrictDecodingErrHandler.
+	StrictDecoding = false
+	// StrictDecodingErrHandler receives schema errors of order responses under StrictDecoding
+	StrictDecodingErrHandler ErrHandler
+	// JSONCodec decodes responses and stream events, e.g. common.JsoniterJSONCodec or
+	// common.SonicJSONCodec of the sonic build tag at high stream rates. Websocket API requests
+	// are encoded directly, only param values of other than basic types go through it. Set it
+	// before creating clients or serving streams.
+	JSONCodec common.JSONCodec = common.StdJSONCodec
+	// WsSocketOptions tunes sockets of stream and websocket API connections dialed afterwards
+	WsSocketOptions WsSocketConfig
+	// WsStreamCompression negotiates permessage-deflate on stream connections, which saves
+	// bandwidth of high volume market data at the cost of CPU
+	WsStreamCompression = false
+	// WsApiCompression negotiates permessage-deflate on websocket API connections, better left off
+	// for order entry where the latency of compressing matters more than the bandwidth
+	WsApiCompression = false
+	// WsApiEndpointSelector, if set, picks the endpoint websocket API clients connect and
+	// reconnect to instead of the main or testnet one, e.g. an EndpointSelector probing with
+	// ProbeWsEndpoint
+	WsApiEndpointSelector *EndpointSelector
 )
 
 func getWsProxyUrl() *string {
@@ -85,7 +114,7 @@ func WsAggTradeServe(symbol string, handler WsAggTradeHandler, errHandler ErrHan
 	cfg := newWsConfig(endpoint)
 	wsHandler := func(message []byte) {
 		event := new(WsAggTradeEvent)
-		err := json.Unmarshal(message, &event)
+		err := unmarshalResponse(message, &event)
 		if err != nil {
 			errHandler(err)
 			return
@@ -115,10 +144,10 @@ func WsCombinedAggTradeServe(symbols []string, handler WsAggTradeHandler, errHan
 
 		symbol := strings.Split(stream, "@")[0]
 
-		jsonData, _ := json.Marshal(data)
+		jsonData, _ := JSONCodec.Marshal(data)
 
 		event := new(WsAggTradeEvent)
-		err = json.Unmarshal(jsonData, event)
+		err = unmarshalResponse(jsonData, event)
 		if err != nil {
 			errHandler(err)
 			return
@@ -149,7 +178,7 @@ func wsMarkPriceServe(endpoint string, handler WsMarkPriceHandler, errHandler Er
 	cfg := newWsConfig(endpoint)
 	wsHandler := func(message []byte) {
 		event := new(WsMarkPriceEvent)
-		err := json.Unmarshal(message, &event)
+		err := unmarshalResponse(message, &event)
 		if err != nil {
 			errHandler(err)
 			return
@@ -190,10 +219,10 @@ func wsCombinedMarkPriceServe(endpoint string, handler WsMarkPriceHandler, errHa
 		}
 
 		data := j.Get("data").MustMap()
-		jsonData, _ := json.Marshal(data)
+		jsonData, _ := JSONCodec.Marshal(data)
 
 		event := new(WsMarkPriceEvent)
-		err = json.Unmarshal(jsonData, event)
+		err = unmarshalResponse(jsonData, event)
 		if err != nil {
 			errHandler(err)
 			return
@@ -248,7 +277,7 @@ func wsAllMarkPriceServe(endpoint string, handler WsAllMarkPriceHandler, errHand
 	cfg := newWsConfig(endpoint)
 	wsHandler := func(message []byte) {
 		var event WsAllMarkPriceEvent
-		err := json.Unmarshal(message, &event)
+		err := unmarshalResponse(message, &event)
 		if err != nil {
 			errHandler(err)
 			return
@@ -316,7 +345,7 @@ func WsKlineServe(symbol string, interval string, handler WsKlineHandler, errHan
 	cfg := newWsConfig(endpoint)
 	wsHandler := func(message []byte) {
 		event := new(WsKlineEvent)
-		err := json.Unmarshal(message, event)
+		err := unmarshalResponse(message, event)
 		if err != nil {
 			errHandler(err)
 			return
@@ -346,10 +375,10 @@ func WsCombinedKlineServe(symbolIntervalPair map[string]string, handler WsKlineH
 
 		symbol := strings.Split(stream, "@")[0]
 
-		jsonData, _ := json.Marshal(data)
+		jsonData, _ := JSONCodec.Marshal(data)
 
 		event := new(WsKlineEvent)
-		err = json.Unmarshal(jsonData, event)
+		err = unmarshalResponse(jsonData, event)
 		if err != nil {
 			errHandler(err)
 			return
@@ -407,7 +436,7 @@ func WsContinuousKlineServe(subscribeArgs *WsContinuousKlineSubscribeArgs, handl
 	cfg := newWsConfig(endpoint)
 	wsHandler := func(message []byte) {
 		event := new(WsContinuousKlineEvent)
-		err := json.Unmarshal(message, event)
+		err := unmarshalResponse(message, event)
 		if err != nil {
 			errHandler(err)
 			return
@@ -436,10 +465,10 @@ func WsCombinedContinuousKlineServe(subscribeArgsList []*WsContinuousKlineSubscr
 
 		data := j.Get("data").MustMap()
 
-		jsonData, _ := json.Marshal(data)
+		jsonData, _ := JSONCodec.Marshal(data)
 
 		event := new(WsContinuousKlineEvent)
-		err = json.Unmarshal(jsonData, event)
+		err = unmarshalResponse(jsonData, event)
 		if err != nil {
 			errHandler(err)
 			return
@@ -472,7 +501,7 @@ func WsMiniMarketTickerServe(symbol string, handler WsMiniMarketTickerHandler, e
 	cfg := newWsConfig(endpoint)
 	wsHandler := func(message []byte) {
 		event := new(WsMiniMarketTickerEvent)
-		err := json.Unmarshal(message, &event)
+		err := unmarshalResponse(message, &event)
 		if err != nil {
 			errHandler(err)
 			return
@@ -494,7 +523,7 @@ func WsAllMiniMarketTickerServe(handler WsAllMiniMarketTickerHandler, errHandler
 	cfg := newWsConfig(endpoint)
 	wsHandler := func(message []byte) {
 		var event WsAllMiniMarketTickerEvent
-		err := json.Unmarshal(message, &event)
+		err := unmarshalResponse(message, &event)
 		if err != nil {
 			errHandler(err)
 			return
@@ -535,7 +564,7 @@ func WsMarketTickerServe(symbol string, handler WsMarketTickerHandler, errHandle
 	cfg := newWsConfig(endpoint)
 	wsHandler := func(message []byte) {
 		event := new(WsMarketTickerEvent)
-		err := json.Unmarshal(message, &event)
+		err := unmarshalResponse(message, &event)
 		if err != nil {
 			errHandler(err)
 			return
@@ -557,7 +586,7 @@ func WsAllMarketTickerServe(handler WsAllMarketTickerHandler, errHandler ErrHand
 	cfg := newWsConfig(endpoint)
 	wsHandler := func(message []byte) {
 		var event WsAllMarketTickerEvent
-		err := json.Unmarshal(message, &event)
+		err := unmarshalResponse(message, &event)
 		if err != nil {
 			errHandler(err)
 			return
@@ -594,7 +623,7 @@ func WsBookTickerServe(symbol string, handler WsBookTickerHandler, errHandler Er
 	cfg := newWsConfig(endpoint)
 	wsHandler := func(message []byte) {
 		event := new(WsBookTickerEvent)
-		err := json.Unmarshal(message, &event)
+		err := unmarshalResponse(message, &event)
 		if err != nil {
 			errHandler(err)
 			return
@@ -613,7 +642,7 @@ func WsCombinedBookTickerServe(symbols []string, handler WsBookTickerHandler, er
 	cfg := newWsConfig(endpoint)
 	wsHandler := func(message []byte) {
 		event := new(WsCombinedBookTickerEvent)
-		err := json.Unmarshal(message, event)
+		err := unmarshalResponse(message, event)
 		if err != nil {
 			errHandler(err)
 			return
@@ -629,7 +658,7 @@ func WsAllBookTickerServe(handler WsBookTickerHandler, errHandler ErrHandler) (d
 	cfg := newWsConfig(endpoint)
 	wsHandler := func(message []byte) {
 		event := new(WsBookTickerEvent)
-		err := json.Unmarshal(message, &event)
+		err := unmarshalResponse(message, &event)
 		if err != nil {
 			errHandler(err)
 			return
@@ -670,7 +699,7 @@ func WsLiquidationOrderServe(symbol string, handler WsLiquidationOrderHandler, e
 	cfg := newWsConfig(endpoint)
 	wsHandler := func(message []byte) {
 		event := new(WsLiquidationOrderEvent)
-		err := json.Unmarshal(message, &event)
+		err := unmarshalResponse(message, &event)
 		if err != nil {
 			errHandler(err)
 			return
@@ -686,7 +715,7 @@ func WsAllLiquidationOrderServe(handler WsLiquidationOrderHandler, errHandler Er
 	cfg := newWsConfig(endpoint)
 	wsHandler := func(message []byte) {
 		event := new(WsLiquidationOrderEvent)
-		err := json.Unmarshal(message, &event)
+		err := unmarshalResponse(message, &event)
 		if err != nil {
 			errHandler(err)
 			return
@@ -913,7 +942,7 @@ func WsBLVTInfoServe(name string, handler WsBLVTInfoHandler, errHandler ErrHandl
 	cfg := newWsConfig(endpoint)
 	wsHandler := func(message []byte) {
 		event := new(WsBLVTInfoEvent)
-		err := json.Unmarshal(message, &event)
+		err := unmarshalResponse(message, &event)
 		if err != nil {
 			errHandler(err)
 			return
@@ -956,7 +985,7 @@ func WsBLVTKlineServe(name string, interval string, handler WsBLVTKlineHandler,
 	cfg := newWsConfig(endpoint)
 	wsHandler := func(message []byte) {
 		event := new(WsBLVTKlineEvent)
-		err := json.Unmarshal(message, event)
+		err := unmarshalResponse(message, event)
 		if err != nil {
 			errHandler(err)
 			return
@@ -991,7 +1020,7 @@ func WsCompositiveIndexServe(symbol string, handler WsCompositeIndexHandler, err
 	cfg := newWsConfig(endpoint)
 	wsHandler := func(message []byte) {
 		event := new(WsCompositeIndexEvent)
-		err := json.Unmarshal(message, event)
+		err := unmarshalResponse(message, event)
 		if err != nil {
 			errHandler(err)
 			return
@@ -1095,7 +1124,7 @@ func (e *WsUserDataEvent) UnmarshalJSON(data []byte) error {
 		// noting
 	default:
 		if v, ok := eventMaps[e.Event]; ok {
-			if err := json.Unmarshal(data, v); err != nil {
+			if err := JSONCodec.Unmarshal(data, v); err != nil {
 				return err
 			}
 		} else {
@@ -1183,11 +1212,18 @@ type WsUserDataHandler func(event *WsUserDataEvent)
 
 // WsUserDataServe serve user data handler with listen key
 func WsUserDataServe(listenKey string, handler WsUserDataHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error) {
+	return WsUserDataServeWithSocket(listenKey, WsSocketOptions, handler, errHandler)
+}
+
+// WsUserDataServeWithSocket serve user data handler with listen key, dialing the connection
+// with socket instead of WsSocketOptions
+func WsUserDataServeWithSocket(listenKey string, socket WsSocketConfig, handler WsUserDataHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error) {
 	endpoint := fmt.Sprintf("%s/%s", getWsEndpoint(), listenKey)
 	cfg := newWsConfig(endpoint)
+	cfg.Socket = socket
 	wsHandler := func(message []byte) {
 		event := new(WsUserDataEvent)
-		err := json.Unmarshal(message, event)
+		err := unmarshalResponse(message, event)
 		if err != nil {
 			errHandler(err)
 			return
@@ -1200,6 +1236,7 @@ func WsUserDataServe(listenKey string, handler WsUserDataHandler, errHandler Err
 // WsApiInitReadWriteConn create and serve connection
 func WsApiInitReadWriteConn() (*websocket.Conn, error) {
 	cfg := newWsConfig(getWsApiEndpoint())
+	cfg.Compression = WsApiCompression
 	conn, err := WsGetReadWriteConnection(cfg)
 	if err != nil {
 		return nil, err
@@ -1208,8 +1245,12 @@ func WsApiInitReadWriteConn() (*websocket.Conn, error) {
 	return conn, err
 }
 
-// getWsApiEndpoint return the base endpoint of the API WS according the UseTestnet flag
+// getWsApiEndpoint return the base endpoint of the API WS according WsApiEndpointSelector and
+// the UseTestnet flag
 func getWsApiEndpoint() string {
+	if WsApiEndpointSelector != nil {
+		return WsApiEndpointSelector.Endpoint()
+	}
 	if UseTestnet {
 		return BaseWsApiTestnetURL
 	}
@@ -8,6 +8,8 @@ import (
 	"time"
 
 	"github.com/stretchr/testify/suite"
+
+	"github.com/adshao/go-binance/v2/common"
 )
 
 type websocketServiceTestSuite struct {
@@ -54,6 +56,37 @@ func (s *websocketServiceTestSuite) assertWsServe(count ...int) {
 	s.r().Equal(e, s.serveCount)
 }
 
+// countingJSONCodec counts calls of the codec it wraps
+type countingJSONCodec struct {
+	common.JSONCodec
+	unmarshals int
+}
+
+func (c *countingJSONCodec) Unmarshal(data []byte, v interface{}) error {
+	c.unmarshals++
+	return c.JSONCodec.Unmarshal(data, v)
+}
+
+func (s *websocketServiceTestSuite) TestJSONCodec() {
+	codec := &countingJSONCodec{JSONCodec: common.JsoniterJSONCodec}
+	JSONCodec = codec
+	defer func() { JSONCodec = common.StdJSONCodec }()
+
+	s.mockWsServe([]byte(`{"e":"aggTrade","E":123456789,"s":"BTCUSDT","a":5933014,"p":"0.001"}`), nil)
+	defer s.assertWsServe()
+
+	var event *WsAggTradeEvent
+	_, _, err := WsAggTradeServe("BTCUSDT", func(e *WsAggTradeEvent) {
+		event = e
+	}, func(err error) {
+		s.r().FailNow(err.Error())
+	})
+	s.r().NoError(err)
+	s.r().Equal(1, codec.unmarshals)
+	s.r().Equal(int64(5933014), event.AggregateTradeID)
+	s.r().Equal("0.001", event.Price)
+}
+
 func (s *websocketServiceTestSuite) TestAggTradeServe() {
 	data := []byte(`{
 		"e": "aggTrade",
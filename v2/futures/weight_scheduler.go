@@ -0,0 +1,180 @@
+package futures
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/adshao/go-binance/v2/common"
+)
+
+const (
+	weightSchedulerDefaultLimit           = 2400
+	weightSchedulerDefaultLowThreshold    = 0.7
+	weightSchedulerDefaultNormalThreshold = 0.9
+	usedWeightHeader                      = "X-Mbx-Used-Weight-1m"
+)
+
+var ErrWeightHeadroom = errors.New("weight scheduler: not enough request weight headroom")
+
+// RequestPriority define the class of a REST request for the WeightScheduler
+type RequestPriority int
+
+const (
+	// RequestPriorityLow is the default of exchange info and of history pulls
+	RequestPriorityLow RequestPriority = iota
+	RequestPriorityNormal
+	// RequestPriorityHigh is the default of order requests, they are never held back
+	RequestPriorityHigh
+)
+
+// lowPriorityEndpoints are the REST endpoints whose requests are RequestPriorityLow by default
+var lowPriorityEndpoints = map[string]bool{
+	"/fapi/v1/exchangeInfo": true,
+}
+
+// WithRequestPriority set priority of the request for the WeightScheduler of the client
+func WithRequestPriority(priority RequestPriority) RequestOption {
+	return func(r *request) {
+		r.priority = &priority
+	}
+}
+
+// requestPriority returns priority of r, set by WithRequestPriority or the default of its endpoint
+func requestPriority(r *request) RequestPriority {
+	switch {
+	case r.priority != nil:
+		return *r.priority
+	case r.method != http.MethodGet && orderEndpoints[r.endpoint]:
+		return RequestPriorityHigh
+	case lowPriorityEndpoints[r.endpoint]:
+		return RequestPriorityLow
+	}
+	return RequestPriorityNormal
+}
+
+// WeightScheduler tracks the request weight of the IP consumed within the current minute, as
+// reported by the X-MBX-USED-WEIGHT-1M header of responses, and holds back REST requests of
+// lower priority when headroom is low, so that order requests don't get the IP banned. Set it
+// as WeightScheduler of Client, clients sharing an IP should share one. The header reports the
+// weight used by the whole IP, so processes sharing it hold back on the same usage. The zero
+// value uses the default limit and thresholds.
+type WeightScheduler struct {
+	// Limit is the request weight allowed per minute, the default if 0
+	Limit int
+	// LowThreshold and NormalThreshold are the fractions of Limit above which requests of low
+	// and normal priority wait for the next minute, the defaults if 0
+	LowThreshold    float64
+	NormalThreshold float64
+	// MaxDelay bounds waiting for the next minute, requests which would wait longer fail with
+	// ErrWeightHeadroom. Requests fail at once if it is 0.
+	MaxDelay time.Duration
+
+	clock       common.Clock
+	mu          sync.Mutex
+	windowStart time.Time
+	used        int
+}
+
+// NewWeightScheduler init WeightScheduler with default limit and thresholds, waiting up to a
+// minute for headroom
+func NewWeightScheduler() *WeightScheduler {
+	return &WeightScheduler{
+		Limit:           weightSchedulerDefaultLimit,
+		LowThreshold:    weightSchedulerDefaultLowThreshold,
+		NormalThreshold: weightSchedulerDefaultNormalThreshold,
+		MaxDelay:        time.Minute,
+		clock:           common.SystemClock,
+	}
+}
+
+// UsedWeight returns the weight consumed within the current minute as last reported
+func (s *WeightScheduler) UsedWeight() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.roll(orSystemClock(s.clock).Now())
+	return s.used
+}
+
+func (s *WeightScheduler) limit() int {
+	if s.Limit <= 0 {
+		return weightSchedulerDefaultLimit
+	}
+	return s.Limit
+}
+
+// threshold returns the used weight above which requests of priority wait
+func (s *WeightScheduler) threshold(priority RequestPriority) float64 {
+	fraction, fallback := s.LowThreshold, weightSchedulerDefaultLowThreshold
+	if priority == RequestPriorityNormal {
+		fraction, fallback = s.NormalThreshold, weightSchedulerDefaultNormalThreshold
+	}
+	if fraction <= 0 {
+		fraction = fallback
+	}
+	return fraction * float64(s.limit())
+}
+
+// wait blocks until a request of priority may be sent
+func (s *WeightScheduler) wait(ctx context.Context, priority RequestPriority) error {
+	if priority >= RequestPriorityHigh {
+		return nil
+	}
+	threshold := s.threshold(priority)
+
+	for {
+		s.mu.Lock()
+		now := orSystemClock(s.clock).Now()
+		s.roll(now)
+		if float64(s.used) < threshold {
+			s.mu.Unlock()
+			return nil
+		}
+		delay := s.windowStart.Add(time.Minute).Sub(now)
+		s.mu.Unlock()
+
+		if delay > s.MaxDelay {
+			return ErrWeightHeadroom
+		}
+		timer := orSystemClock(s.clock).NewTimer(delay)
+		select {
+		case <-timer.C():
+		case <-ctx.Done():
+			timer.Stop()
+			return ctx.Err()
+		}
+	}
+}
+
+// observe records the weight reported by header of a response with status
+func (s *WeightScheduler) observe(status int, header http.Header) {
+	used, err := strconv.Atoi(header.Get(usedWeightHeader))
+	if err != nil && status != http.StatusTooManyRequests && status != http.StatusTeapot {
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.roll(orSystemClock(s.clock).Now())
+	if err == nil {
+		s.used = used
+	}
+	// the limit was hit, nothing else is sent within the minute
+	if status == http.StatusTooManyRequests || status == http.StatusTeapot {
+		s.used = max(s.used, s.limit())
+	}
+}
+
+// roll resets the used weight once a new minute started
+func (s *WeightScheduler) roll(now time.Time) {
+	start := now.Truncate(time.Minute)
+	if !start.Equal(s.windowStart) {
+		s.windowStart = start
+		s.used = 0
+	}
+}
@@ -0,0 +1,127 @@
+package futures
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/adshao/go-binance/v2/common"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func usedWeight(weight string) http.Header {
+	header := http.Header{}
+	header.Set("X-MBX-USED-WEIGHT-1M", weight)
+	return header
+}
+
+func newTestWeightScheduler() (*WeightScheduler, *common.FakeClock) {
+	clock := common.NewFakeClock(time.Unix(6000, 0).Add(10 * time.Second))
+	s := NewWeightScheduler()
+	s.clock = clock
+	return s, clock
+}
+
+func TestRequestPriority(t *testing.T) {
+	assert.Equal(t, RequestPriorityHigh, requestPriority(&request{method: http.MethodPost, endpoint: "/fapi/v1/order"}))
+	assert.Equal(t, RequestPriorityNormal, requestPriority(&request{method: http.MethodGet, endpoint: "/fapi/v1/order"}))
+	assert.Equal(t, RequestPriorityLow, requestPriority(&request{method: http.MethodGet, endpoint: "/fapi/v1/exchangeInfo"}))
+	assert.Equal(t, RequestPriorityNormal, requestPriority(&request{method: http.MethodGet, endpoint: "/fapi/v1/klines"}))
+
+	r := &request{method: http.MethodGet, endpoint: "/fapi/v1/exchangeInfo"}
+	WithRequestPriority(RequestPriorityHigh)(r)
+	assert.Equal(t, RequestPriorityHigh, requestPriority(r))
+}
+
+func TestWeightScheduler(t *testing.T) {
+	s, clock := newTestWeightScheduler()
+	s.MaxDelay = 0
+	ctx := context.Background()
+
+	assert.NoError(t, s.wait(ctx, RequestPriorityLow))
+	s.observe(http.StatusOK, usedWeight("1700"))
+	assert.Equal(t, 1700, s.UsedWeight())
+	assert.ErrorIs(t, s.wait(ctx, RequestPriorityLow), ErrWeightHeadroom)
+	assert.NoError(t, s.wait(ctx, RequestPriorityNormal))
+
+	s.observe(http.StatusOK, usedWeight("2200"))
+	assert.ErrorIs(t, s.wait(ctx, RequestPriorityNormal), ErrWeightHeadroom)
+	assert.NoError(t, s.wait(ctx, RequestPriorityHigh))
+
+	// the weight is reset every minute
+	clock.Advance(50 * time.Second)
+	assert.Equal(t, 0, s.UsedWeight())
+	assert.NoError(t, s.wait(ctx, RequestPriorityLow))
+
+	// hitting the limit holds back everything but orders for the rest of the minute
+	s.observe(http.StatusTooManyRequests, http.Header{})
+	assert.Equal(t, s.Limit, s.UsedWeight())
+	assert.ErrorIs(t, s.wait(ctx, RequestPriorityNormal), ErrWeightHeadroom)
+
+	// responses without the header are ignored
+	s.observe(http.StatusOK, http.Header{})
+	assert.Equal(t, s.Limit, s.UsedWeight())
+}
+
+func TestWeightSchedulerDelay(t *testing.T) {
+	s, clock := newTestWeightScheduler()
+	s.observe(http.StatusOK, usedWeight("2000"))
+
+	errC := make(chan error, 1)
+	go func() {
+		errC <- s.wait(context.Background(), RequestPriorityLow)
+	}()
+	clock.BlockUntil(1)
+	clock.Advance(50 * time.Second)
+	assert.NoError(t, <-errC)
+
+	s.observe(http.StatusOK, usedWeight("2000"))
+	ctx, cancel := context.WithCancel(context.Background())
+	go func() {
+		errC <- s.wait(ctx, RequestPriorityLow)
+	}()
+	clock.BlockUntil(1)
+	cancel()
+	assert.ErrorIs(t, <-errC, context.Canceled)
+}
+
+func TestWeightSchedulerZeroValue(t *testing.T) {
+	s := &WeightScheduler{}
+	ctx := context.Background()
+
+	assert.NoError(t, s.wait(ctx, RequestPriorityLow))
+	s.observe(http.StatusOK, usedWeight("1800"))
+	assert.Equal(t, 1800, s.UsedWeight())
+	// the default thresholds apply
+	assert.ErrorIs(t, s.wait(ctx, RequestPriorityLow), ErrWeightHeadroom)
+	assert.NoError(t, s.wait(ctx, RequestPriorityNormal))
+
+	s.observe(http.StatusTeapot, http.Header{})
+	assert.Equal(t, weightSchedulerDefaultLimit, s.UsedWeight())
+}
+
+func TestClientWeightScheduler(t *testing.T) {
+	c := NewClient("apiKey", "secretKey")
+	c.WeightScheduler, _ = newTestWeightScheduler()
+	c.WeightScheduler.MaxDelay = 0
+	requests := 0
+	c.do = func(req *http.Request) (*http.Response, error) {
+		requests++
+		res := newHTTPResponse([]byte(`{}`), http.StatusOK)
+		res.Header = usedWeight("2300")
+		return res, nil
+	}
+
+	_, err := c.NewExchangeInfoService().Do(newContext())
+	require.NoError(t, err)
+	assert.Equal(t, 2300, c.WeightScheduler.UsedWeight())
+
+	_, err = c.NewExchangeInfoService().Do(newContext())
+	assert.ErrorIs(t, err, ErrWeightHeadroom)
+	_, err = c.NewCreateOrderService().Symbol("BTCUSDT").Side(SideTypeBuy).
+		Type(OrderTypeMarket).Quantity("0.1").Do(newContext())
+	assert.NoError(t, err)
+	assert.Equal(t, 2, requests)
+}
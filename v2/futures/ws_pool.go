@@ -0,0 +1,113 @@
+package futures
+
+import (
+	"errors"
+	"sync/atomic"
+)
+
+var ErrWsPoolEmpty = errors.New("ws pool: size must be positive")
+
+// WsPoolStrategy define how WsPool picks the connection of a request
+type WsPoolStrategy int
+
+const (
+	// WsPoolRoundRobin uses the connections in turn
+	WsPoolRoundRobin WsPoolStrategy = iota
+	// WsPoolLeastPending uses the connection waiting for the fewest responses
+	WsPoolLeastPending
+)
+
+// WsPool keeps several websocket API connections with the same credentials and spreads order
+// requests over them, so a slow response or a reconnect of one connection doesn't hold up the
+// others. Connections being reconnected are skipped while another one is up.
+type WsPool struct {
+	clients  []*ClientWs
+	strategy WsPoolStrategy
+	next     atomic.Uint64
+}
+
+// NewWsPool connects size websocket API clients of apiKey and secretKey created with opts
+func NewWsPool(apiKey, secretKey string, size int, strategy WsPoolStrategy, opts ...ClientWsOption) (*WsPool, error) {
+	if size <= 0 {
+		return nil, ErrWsPoolEmpty
+	}
+	clients := make([]*ClientWs, 0, size)
+	for i := 0; i < size; i++ {
+		client, err := NewClientWs(apiKey, secretKey, opts...)
+		if err != nil {
+			for _, client := range clients {
+				_ = client.Close()
+			}
+			return nil, err
+		}
+		clients = append(clients, client)
+	}
+	return &WsPool{clients: clients, strategy: strategy}, nil
+}
+
+// Clients returns the clients of the pool, e.g. to set their handlers or enable pipelining
+func (p *WsPool) Clients() []*ClientWs {
+	return p.clients
+}
+
+// Client returns the client the next request should be sent with
+func (p *WsPool) Client() *ClientWs {
+	if p.strategy == WsPoolLeastPending {
+		return p.leastPending()
+	}
+	return p.roundRobin()
+}
+
+func (p *WsPool) roundRobin() *ClientWs {
+	n := uint64(len(p.clients))
+	start := p.next.Add(1) - 1
+	for i := uint64(0); i < n; i++ {
+		client := p.clients[(start+i)%n]
+		if !client.reconnecting.Load() {
+			return client
+		}
+	}
+	return p.clients[start%n]
+}
+
+func (p *WsPool) leastPending() *ClientWs {
+	var best *ClientWs
+	bestPending := 0
+	for _, client := range p.clients {
+		if client.reconnecting.Load() {
+			continue
+		}
+		pending := client.pending.len()
+		if best == nil || pending < bestPending {
+			best, bestPending = client, pending
+		}
+	}
+	if best == nil {
+		return p.roundRobin()
+	}
+	return best
+}
+
+// GetReconnectCount returns sum of reconnect attempts by clients of the pool
+func (p *WsPool) GetReconnectCount() int64 {
+	var count int64
+	for _, client := range p.clients {
+		count += client.GetReconnectCount()
+	}
+	return count
+}
+
+// NewOrderPlaceWsService init OrderPlaceWsService sending every request over a connection of the pool
+func (p *WsPool) NewOrderPlaceWsService() *OrderPlaceWsService {
+	return &OrderPlaceWsService{pool: p}
+}
+
+// NewOrderCancelWsService init OrderCancelWsService sending every request over a connection of the pool
+func (p *WsPool) NewOrderCancelWsService() *OrderCancelWsService {
+	return &OrderCancelWsService{pool: p}
+}
+
+// NewOrderModifyWsService init OrderModifyWsService sending every request over a connection of the pool
+func (p *WsPool) NewOrderModifyWsService() *OrderModifyWsService {
+	return &OrderModifyWsService{pool: p}
+}
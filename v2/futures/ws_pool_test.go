@@ -0,0 +1,124 @@
+package futures
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"github.com/stretchr/testify/suite"
+)
+
+type wsPoolTestSuite struct {
+	baseWsApiTestSuite
+}
+
+func TestWsPool(t *testing.T) {
+	suite.Run(t, new(wsPoolTestSuite))
+}
+
+func (s *wsPoolTestSuite) TestNewWsPool() {
+	_, err := NewWsPool(s.apiKey, s.secretKey, 0, WsPoolRoundRobin)
+	s.r().ErrorIs(err, ErrWsPoolEmpty)
+
+	pool, err := NewWsPool(s.apiKey, s.secretKey, 3, WsPoolRoundRobin)
+	s.r().NoError(err)
+	s.Len(pool.Clients(), 3)
+}
+
+func (s *wsPoolTestSuite) TestNewWsPoolDialError() {
+	dial := WsGetReadWriteConnection
+	var conns []*websocket.Conn
+	WsGetReadWriteConnection = func(cfg *WsConfig) (*websocket.Conn, error) {
+		if len(conns) == 2 {
+			return nil, errors.New("connection refused")
+		}
+		conn, err := dial(cfg)
+		conns = append(conns, conn)
+		return conn, err
+	}
+
+	_, err := NewWsPool(s.apiKey, s.secretKey, 3, WsPoolRoundRobin)
+	s.r().Error(err)
+	// the connections already dialed are closed
+	s.r().Len(conns, 2)
+	for _, conn := range conns {
+		s.Error(conn.WriteMessage(websocket.TextMessage, []byte("{}")))
+	}
+}
+
+func (s *wsPoolTestSuite) TestRoundRobin() {
+	pool, err := NewWsPool(s.apiKey, s.secretKey, 3, WsPoolRoundRobin)
+	s.r().NoError(err)
+	clients := pool.Clients()
+
+	s.Equal([]*ClientWs{clients[0], clients[1], clients[2], clients[0]},
+		[]*ClientWs{pool.Client(), pool.Client(), pool.Client(), pool.Client()})
+
+	// a reconnecting connection is skipped
+	clients[1].reconnecting.Store(true)
+	s.Equal([]*ClientWs{clients[2], clients[2], clients[0]},
+		[]*ClientWs{pool.Client(), pool.Client(), pool.Client()})
+
+	// all reconnecting, the next one is used anyway
+	clients[0].reconnecting.Store(true)
+	clients[2].reconnecting.Store(true)
+	s.NotNil(pool.Client())
+}
+
+func (s *wsPoolTestSuite) TestLeastPending() {
+	pool, err := NewWsPool(s.apiKey, s.secretKey, 3, WsPoolLeastPending)
+	s.r().NoError(err)
+	clients := pool.Clients()
+
+	clients[0].pending.add("a")
+	clients[1].pending.add("b")
+	clients[1].pending.add("c")
+	s.Equal(clients[2], pool.Client())
+
+	clients[2].pending.add("d")
+	clients[2].pending.add("e")
+	s.Equal(clients[0], pool.Client())
+
+	clients[0].reconnecting.Store(true)
+	s.Equal(clients[1], pool.Client())
+}
+
+func (s *wsPoolTestSuite) TestLeastPendingTimedOut() {
+	pool, err := NewWsPool(s.apiKey, s.secretKey, 2, WsPoolLeastPending)
+	s.r().NoError(err)
+	clients := pool.Clients()
+
+	// the request is not answered, it is no longer pending once it timed out
+	s.mu.Lock()
+	s.responses[WsApiMethodOrderCancel] = `{"id":"other","status":200,"result":{}}`
+	s.mu.Unlock()
+	ctx, cancel := context.WithTimeout(newContext(), 10*time.Millisecond)
+	defer cancel()
+	_, err = clients[0].NewOrderCancelWsService().Do(ctx, NewCancelOrderRequest().Symbol("BTCUSDT").OrderID(1))
+	s.r().ErrorIs(err, context.DeadlineExceeded)
+	s.Equal(0, clients[0].pending.len())
+
+	clients[1].pending.add("a")
+	s.Equal(clients[0], pool.Client())
+}
+
+func (s *wsPoolTestSuite) TestOrderServices() {
+	pool, err := NewWsPool(s.apiKey, s.secretKey, 2, WsPoolRoundRobin)
+	s.r().NoError(err)
+	s.respond(WsApiMethodOrderPlace, `{"orderId":1,"symbol":"BTCUSDT","status":"NEW"}`)
+	s.respond(WsApiMethodOrderCancel, `{"orderId":1,"symbol":"BTCUSDT","status":"CANCELED"}`)
+
+	place := pool.NewOrderPlaceWsService()
+	for i := 0; i < 2; i++ {
+		res, err := place.Do(newContext(), NewOrderPlaceWsRequest().
+			Symbol("BTCUSDT").Side(SideTypeBuy).Type(OrderTypeMarket).Quantity("1"))
+		s.r().NoError(err)
+		s.Equal(int64(1), res.OrderID)
+	}
+	res, err := pool.NewOrderCancelWsService().Do(newContext(), NewCancelOrderRequest().Symbol("BTCUSDT").OrderID(1))
+	s.r().NoError(err)
+	s.Equal(OrderStatusTypeCanceled, res.Status)
+	s.Equal(int64(0), place.GetReconnectCount())
+}
@@ -0,0 +1,307 @@
+// Package wstest provides a harness driving futures.ClientWs against a local websocket API
+// server with scripted responses and a fake clock, so reconnects, backoff, keepalives and
+// timeouts are tested without real sleeps.
+package wstest
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/adshao/go-binance/v2/common"
+	"github.com/adshao/go-binance/v2/futures"
+	"github.com/gorilla/websocket"
+)
+
+var ErrDialFailed = errors.New("wstest: scripted dial failure")
+
+// ResponseFunc returns raw response message to req, an empty message sends no response
+type ResponseFunc func(req futures.WsApiRequest) string
+
+// Config define configuration of Harness
+type Config struct {
+	// Start is the initial fake time, the current time if zero
+	Start time.Time
+	// Keepalive enables ping/pong keepalive of the client connections with timeout
+	Keepalive        bool
+	KeepaliveTimeout time.Duration
+}
+
+// Harness serves the websocket API of a futures.ClientWs from a local server and drives its
+// timers with Clock. It replaces futures.WsGetReadWriteConnection, futures.WsApiClock and
+// futures.WebsocketKeepalive until the test ends, so tests using it must not run in parallel.
+// Requests of methods without a scripted response are answered with a -1102 error.
+type Harness struct {
+	Clock  *common.FakeClock
+	Client *futures.ClientWs
+
+	t        testing.TB
+	server   *httptest.Server
+	mu       sync.Mutex
+	scripts  map[futures.WsApiMethodType]ResponseFunc
+	requests []futures.WsApiRequest
+	conns    []*websocket.Conn
+	dials    int
+	failures int
+	pings    bool
+	// closed fails dials once the test ended, parked is set while the client waits to redial
+	closed     bool
+	parked     bool
+	closedDial chan struct{}
+	accepted   chan struct{}
+}
+
+// NewHarness starts the server and connects Client to it, everything is torn down when the
+// test ends
+func NewHarness(t testing.TB, cfg Config) *Harness {
+	t.Helper()
+	if cfg.Start.IsZero() {
+		cfg.Start = time.Now()
+	}
+	if cfg.KeepaliveTimeout == 0 {
+		cfg.KeepaliveTimeout = futures.WebsocketTimeoutReadWriteConnection
+	}
+	h := &Harness{
+		Clock:      common.NewFakeClock(cfg.Start),
+		t:          t,
+		scripts:    make(map[futures.WsApiMethodType]ResponseFunc),
+		pings:      true,
+		closedDial: make(chan struct{}),
+		accepted:   make(chan struct{}),
+	}
+	h.server = httptest.NewServer(http.HandlerFunc(h.serve))
+
+	origGetConn, origClock := futures.WsGetReadWriteConnection, futures.WsApiClock
+	origKeepalive, origTimeout := futures.WebsocketKeepalive, futures.WebsocketTimeoutReadWriteConnection
+	endpoint := "ws" + strings.TrimPrefix(h.server.URL, "http")
+	futures.WsApiClock = h.Clock
+	futures.WebsocketKeepalive = cfg.Keepalive
+	futures.WebsocketTimeoutReadWriteConnection = cfg.KeepaliveTimeout
+	futures.WsGetReadWriteConnection = func(wsCfg *futures.WsConfig) (*websocket.Conn, error) {
+		h.mu.Lock()
+		if h.closed {
+			if !h.parked {
+				h.parked = true
+				close(h.closedDial)
+			}
+			h.mu.Unlock()
+			return nil, ErrDialFailed
+		}
+		h.dials++
+		h.parked = h.failures > 0
+		if h.parked {
+			h.failures--
+			h.mu.Unlock()
+			return nil, ErrDialFailed
+		}
+		h.mu.Unlock()
+		// the original dialer sets up the keepalive with the fake clock
+		dialCfg := *wsCfg
+		dialCfg.Endpoint = endpoint
+		conn, err := origGetConn(&dialCfg)
+		if err == nil {
+			// the connection can be dropped or pushed to once the dial returned
+			<-h.accepted
+		}
+		return conn, err
+	}
+	t.Cleanup(func() {
+		h.close()
+		futures.WsGetReadWriteConnection, futures.WsApiClock = origGetConn, origClock
+		futures.WebsocketKeepalive, futures.WebsocketTimeoutReadWriteConnection = origKeepalive, origTimeout
+		h.server.Close()
+	})
+
+	client, err := futures.NewClientWs("apiKey", "secretKey")
+	if err != nil {
+		t.Fatalf("wstest: unable to connect client: %v", err)
+	}
+	h.Client = client
+	return h
+}
+
+// Respond scripts result returned to requests of method with status 200
+func (h *Harness) Respond(method futures.WsApiMethodType, result string) {
+	h.Handle(method, func(req futures.WsApiRequest) string {
+		return fmt.Sprintf(`{"id":%q,"status":200,"result":%s}`, req.Id, result)
+	})
+}
+
+// RespondError scripts API error returned to requests of method
+func (h *Harness) RespondError(method futures.WsApiMethodType, status int, code int64, msg string) {
+	h.Handle(method, func(req futures.WsApiRequest) string {
+		return fmt.Sprintf(`{"id":%q,"status":%d,"error":{"code":%d,"msg":%q}}`, req.Id, status, code, msg)
+	})
+}
+
+// Hold leaves requests of method unanswered, e.g. to test timeouts
+func (h *Harness) Hold(method futures.WsApiMethodType) {
+	h.Handle(method, func(req futures.WsApiRequest) string {
+		return ""
+	})
+}
+
+// Handle scripts requests of method with f
+func (h *Harness) Handle(method futures.WsApiMethodType, f ResponseFunc) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	h.scripts[method] = f
+}
+
+// Requests returns requests received so far
+func (h *Harness) Requests() []futures.WsApiRequest {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	return append([]futures.WsApiRequest(nil), h.requests...)
+}
+
+// WaitRequests waits until n requests were received, failing the test after timeout of real time
+func (h *Harness) WaitRequests(n int, timeout time.Duration) []futures.WsApiRequest {
+	h.t.Helper()
+	deadline := time.Now().Add(timeout)
+	for {
+		if requests := h.Requests(); len(requests) >= n {
+			return requests
+		}
+		if time.Now().After(deadline) {
+			h.t.Fatalf("wstest: %d requests received, waiting for %d", len(h.Requests()), n)
+		}
+		time.Sleep(time.Millisecond)
+	}
+}
+
+// Push sends message to the client on the current connections, e.g. an event
+func (h *Harness) Push(message string) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	for _, conn := range h.conns {
+		_ = conn.WriteMessage(websocket.TextMessage, []byte(message))
+	}
+}
+
+// Drop closes the current connections from the server side, the client then reconnects
+func (h *Harness) Drop() {
+	h.mu.Lock()
+	conns := h.conns
+	h.conns = nil
+	h.mu.Unlock()
+
+	for _, conn := range conns {
+		conn.Close()
+	}
+}
+
+// FailDials makes the next n connection attempts of the client fail
+func (h *Harness) FailDials(n int) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	h.failures = n
+}
+
+// Dials returns the number of connection attempts of the client, including the first one
+func (h *Harness) Dials() int {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	return h.dials
+}
+
+// WaitDials waits until n connection attempts were made, failing the test after timeout of real time
+func (h *Harness) WaitDials(n int, timeout time.Duration) {
+	h.t.Helper()
+	deadline := time.Now().Add(timeout)
+	for h.Dials() < n {
+		if time.Now().After(deadline) {
+			h.t.Fatalf("wstest: %d dials made, waiting for %d", h.Dials(), n)
+		}
+		time.Sleep(time.Millisecond)
+	}
+}
+
+// IgnorePings stops answering pings on connections accepted afterwards, so the keepalive of
+// the client times out
+func (h *Harness) IgnorePings() {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	h.pings = false
+}
+
+// close drops the connections and waits until the client is parked on a failed dial, waiting
+// for a fake timer which is never advanced again. ClientWs has no Close, so this is what keeps
+// it from dialing with the restored globals or the next test's harness.
+func (h *Harness) close() {
+	h.mu.Lock()
+	h.closed = true
+	parked := h.parked || h.Client == nil
+	h.mu.Unlock()
+	if parked {
+		return
+	}
+	deadline := time.Now().Add(5 * time.Second)
+	for time.Now().Before(deadline) {
+		h.Drop()
+		select {
+		case <-h.closedDial:
+			return
+		case <-time.After(time.Millisecond):
+		}
+	}
+}
+
+func (h *Harness) serve(w http.ResponseWriter, r *http.Request) {
+	upgrader := websocket.Upgrader{}
+	conn, err := upgrader.Upgrade(w, r, nil)
+	if err != nil {
+		return
+	}
+	defer conn.Close()
+
+	h.mu.Lock()
+	h.conns = append(h.conns, conn)
+	if !h.pings {
+		conn.SetPingHandler(func(string) error { return nil })
+	}
+	h.mu.Unlock()
+	h.accepted <- struct{}{}
+
+	for {
+		_, message, err := conn.ReadMessage()
+		if err != nil {
+			return
+		}
+		req := futures.WsApiRequest{}
+		if err := json.Unmarshal(message, &req); err != nil {
+			return
+		}
+		h.mu.Lock()
+		h.requests = append(h.requests, req)
+		script, ok := h.scripts[req.Method]
+		h.mu.Unlock()
+
+		response := fmt.Sprintf(`{"id":%q,"status":400,"error":{"code":-1102,"msg":"unexpected method"}}`, req.Id)
+		if ok {
+			response = script(req)
+		}
+		if response == "" {
+			continue
+		}
+		// writes are serialized with Push
+		h.mu.Lock()
+		err = conn.WriteMessage(websocket.TextMessage, []byte(response))
+		h.mu.Unlock()
+		if err != nil {
+			return
+		}
+	}
+}
@@ -0,0 +1,104 @@
+package wstest
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/adshao/go-binance/v2/common"
+	"github.com/adshao/go-binance/v2/futures"
+	"github.com/stretchr/testify/assert"
+)
+
+const waitTimeout = 5 * time.Second
+
+func newOrderRequest() *futures.OrderPlaceWsRequest {
+	return futures.NewOrderPlaceWsRequest().Symbol("BTCUSDT").Side(futures.SideTypeBuy).
+		Type(futures.OrderTypeLimit).TimeInForce(futures.TimeInForceTypeGTC).Quantity("1").Price("100")
+}
+
+func TestHarnessScriptedResponses(t *testing.T) {
+	assert := assert.New(t)
+	h := NewHarness(t, Config{Start: time.Unix(1700000000, 0)})
+
+	h.Respond(futures.WsApiMethodOrderPlace, `{"orderId":1,"symbol":"BTCUSDT","status":"NEW"}`)
+	res, err := h.Client.NewOrderPlaceWsService().Do(context.Background(), newOrderRequest())
+	assert.NoError(err)
+	assert.Equal(int64(1), res.OrderID)
+	assert.Equal("BTCUSDT", h.Requests()[0].Params["symbol"])
+
+	h.RespondError(futures.WsApiMethodOrderPlace, 400, -2019, "Margin is insufficient.")
+	_, err = h.Client.NewOrderPlaceWsService().Do(context.Background(), newOrderRequest())
+	assert.True(common.IsAPIErrorCode(err, -2019))
+
+	pushed := make(chan string, 1)
+	h.Client.OnUnhandledMessage(func(message []byte) {
+		pushed <- string(message)
+	})
+	h.Push(`{"e":"serverShutdown"}`)
+	assert.Equal(`{"e":"serverShutdown"}`, <-pushed)
+	assert.Equal(time.Unix(1700000000, 0), h.Client.LastMessageAt())
+
+	h.Clock.Advance(time.Hour)
+	assert.Equal(time.Hour, h.Client.ConnectionAge())
+}
+
+func TestHarnessTimeout(t *testing.T) {
+	assert := assert.New(t)
+	h := NewHarness(t, Config{})
+
+	h.Hold(futures.WsApiMethodOrderPlace)
+	ctx, cancel := h.Clock.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	errC := make(chan error, 1)
+	go func() {
+		_, err := h.Client.NewOrderPlaceWsService().Do(ctx, newOrderRequest())
+		errC <- err
+	}()
+	h.WaitRequests(1, waitTimeout)
+	h.Clock.Advance(5 * time.Second)
+	assert.ErrorIs(<-errC, context.DeadlineExceeded)
+}
+
+func TestHarnessReconnectBackoff(t *testing.T) {
+	assert := assert.New(t)
+	h := NewHarness(t, Config{})
+
+	h.FailDials(2)
+	h.Drop()
+	h.WaitDials(2, waitTimeout)
+	assert.ErrorIs(h.Client.Healthy(context.Background()), futures.ErrWsReconnecting)
+
+	// the first retry waits 100ms, the second 180ms
+	h.Clock.BlockUntil(1)
+	h.Clock.Advance(100 * time.Millisecond)
+	h.WaitDials(3, waitTimeout)
+	h.Clock.BlockUntil(1)
+	h.Clock.Advance(179 * time.Millisecond)
+	time.Sleep(20 * time.Millisecond)
+	assert.Equal(3, h.Dials())
+	h.Clock.Advance(time.Millisecond)
+	h.WaitDials(4, waitTimeout)
+
+	h.Respond(futures.WsApiMethodOrderPlace, `{"orderId":2}`)
+	assert.Eventually(func() bool {
+		return h.Client.Healthy(context.Background()) == nil
+	}, waitTimeout, time.Millisecond)
+	res, err := h.Client.NewOrderPlaceWsService().Do(context.Background(), newOrderRequest())
+	assert.NoError(err)
+	assert.Equal(int64(2), res.OrderID)
+	assert.Equal(int64(3), h.Client.GetReconnectCount())
+}
+
+func TestHarnessKeepalive(t *testing.T) {
+	h := NewHarness(t, Config{Keepalive: true, KeepaliveTimeout: 10 * time.Second})
+
+	h.IgnorePings()
+	h.Drop()
+	h.WaitDials(2, waitTimeout)
+
+	// tickers of the dropped and of the new connection
+	h.Clock.BlockUntil(2)
+	h.Clock.Advance(10*time.Second + time.Millisecond)
+	h.WaitDials(3, waitTimeout)
+}
@@ -4,6 +4,8 @@ import (
 	"context"
 	"fmt"
 	"net/http"
+
+	"github.com/bitly/go-simplejson"
 )
 
 // KlinesService list klines
@@ -71,13 +73,17 @@ func (s *KlinesService) Do(ctx context.Context, opts ...RequestOption) (res []*K
 	if err != nil {
 		return []*Kline{}, err
 	}
+	return newKlines(j)
+}
+
+// newKlines parses klines shared by REST and websocket API
+func newKlines(j *simplejson.Json) ([]*Kline, error) {
 	num := len(j.MustArray())
-	res = make([]*Kline, num)
+	res := make([]*Kline, num)
 	for i := 0; i < num; i++ {
 		item := j.GetIndex(i)
 		if len(item.MustArray()) < 11 {
-			err = fmt.Errorf("invalid kline response")
-			return []*Kline{}, err
+			return []*Kline{}, fmt.Errorf("invalid kline response")
 		}
 		res[i] = &Kline{
 			OpenTime:                 item.GetIndex(0).MustInt64(),
@@ -0,0 +1,285 @@
+package binance
+
+import (
+	"context"
+	stdjson "encoding/json"
+
+	"github.com/adshao/go-binance/v2/common"
+)
+
+const (
+	WsApiMethodDepth       WsApiMethodType = "depth"
+	WsApiMethodTickerPrice WsApiMethodType = "ticker.price"
+	WsApiMethodTickerBook  WsApiMethodType = "ticker.book"
+	WsApiMethodKlines      WsApiMethodType = "klines"
+)
+
+// DepthWsRequest parameters for 'depth' websocket API
+type DepthWsRequest struct {
+	symbol string
+	limit  *int
+}
+
+// NewDepthWsRequest init DepthWsRequest
+func NewDepthWsRequest() *DepthWsRequest {
+	return &DepthWsRequest{}
+}
+
+// Symbol set symbol
+func (s *DepthWsRequest) Symbol(symbol string) *DepthWsRequest {
+	s.symbol = symbol
+	return s
+}
+
+// Limit set limit
+func (s *DepthWsRequest) Limit(limit int) *DepthWsRequest {
+	s.limit = &limit
+	return s
+}
+
+// buildParams builds params
+func (s *DepthWsRequest) buildParams() params {
+	m := params{
+		"symbol": s.symbol,
+	}
+	if s.limit != nil {
+		m["limit"] = *s.limit
+	}
+	return m
+}
+
+// DepthWsService order book snapshot over websocket API
+type DepthWsService struct {
+	c *ClientWs
+}
+
+// NewDepthWsService init DepthWsService sharing the client connection
+func (c *ClientWs) NewDepthWsService() *DepthWsService {
+	return &DepthWsService{c: c}
+}
+
+// Do - sends 'depth' request
+func (s *DepthWsService) Do(ctx context.Context, req *DepthWsRequest) (*DepthResponse, error) {
+	rawResp, err := s.c.doRequest(ctx, WsApiMethodDepth, req.buildParams(), false)
+	if err != nil {
+		return nil, err
+	}
+
+	j, err := newJSON(rawResp)
+	if err != nil {
+		return nil, err
+	}
+	return newDepthResponse(j.Get("result")), nil
+}
+
+// TickerPriceWsRequest parameters for 'ticker.price' websocket API
+type TickerPriceWsRequest struct {
+	symbol *string
+}
+
+// NewTickerPriceWsRequest init TickerPriceWsRequest
+func NewTickerPriceWsRequest() *TickerPriceWsRequest {
+	return &TickerPriceWsRequest{}
+}
+
+// Symbol set symbol, prices of all symbols are returned if not set
+func (s *TickerPriceWsRequest) Symbol(symbol string) *TickerPriceWsRequest {
+	s.symbol = &symbol
+	return s
+}
+
+// buildParams builds params
+func (s *TickerPriceWsRequest) buildParams() params {
+	m := params{}
+	if s.symbol != nil {
+		m["symbol"] = *s.symbol
+	}
+	return m
+}
+
+// TickerPriceWsResponse define 'ticker.price' websocket API response
+type TickerPriceWsResponse struct {
+	Id     string             `json:"id"`
+	Status int                `json:"status"`
+	Result stdjson.RawMessage `json:"result"`
+
+	// error response
+	Error *common.APIError `json:"error,omitempty"`
+}
+
+// TickerPriceWsService latest price over websocket API
+type TickerPriceWsService struct {
+	c *ClientWs
+}
+
+// NewTickerPriceWsService init TickerPriceWsService sharing the client connection
+func (c *ClientWs) NewTickerPriceWsService() *TickerPriceWsService {
+	return &TickerPriceWsService{c: c}
+}
+
+// Do - sends 'ticker.price' request
+func (s *TickerPriceWsService) Do(ctx context.Context, req *TickerPriceWsRequest) ([]*SymbolPrice, error) {
+	rawResp, err := s.c.doRequest(ctx, WsApiMethodTickerPrice, req.buildParams(), false)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := TickerPriceWsResponse{}
+	if err := json.Unmarshal(rawResp, &resp); err != nil {
+		return nil, err
+	}
+	res := make([]*SymbolPrice, 0)
+	if err := json.Unmarshal(common.ToJSONList(resp.Result), &res); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// TickerBookWsRequest parameters for 'ticker.book' websocket API
+type TickerBookWsRequest struct {
+	symbol *string
+}
+
+// NewTickerBookWsRequest init TickerBookWsRequest
+func NewTickerBookWsRequest() *TickerBookWsRequest {
+	return &TickerBookWsRequest{}
+}
+
+// Symbol set symbol, book tickers of all symbols are returned if not set
+func (s *TickerBookWsRequest) Symbol(symbol string) *TickerBookWsRequest {
+	s.symbol = &symbol
+	return s
+}
+
+// buildParams builds params
+func (s *TickerBookWsRequest) buildParams() params {
+	m := params{}
+	if s.symbol != nil {
+		m["symbol"] = *s.symbol
+	}
+	return m
+}
+
+// TickerBookWsResponse define 'ticker.book' websocket API response
+type TickerBookWsResponse struct {
+	Id     string             `json:"id"`
+	Status int                `json:"status"`
+	Result stdjson.RawMessage `json:"result"`
+
+	// error response
+	Error *common.APIError `json:"error,omitempty"`
+}
+
+// TickerBookWsService best price/qty on the order book over websocket API
+type TickerBookWsService struct {
+	c *ClientWs
+}
+
+// NewTickerBookWsService init TickerBookWsService sharing the client connection
+func (c *ClientWs) NewTickerBookWsService() *TickerBookWsService {
+	return &TickerBookWsService{c: c}
+}
+
+// Do - sends 'ticker.book' request
+func (s *TickerBookWsService) Do(ctx context.Context, req *TickerBookWsRequest) ([]*BookTicker, error) {
+	rawResp, err := s.c.doRequest(ctx, WsApiMethodTickerBook, req.buildParams(), false)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := TickerBookWsResponse{}
+	if err := json.Unmarshal(rawResp, &resp); err != nil {
+		return nil, err
+	}
+	res := make([]*BookTicker, 0)
+	if err := json.Unmarshal(common.ToJSONList(resp.Result), &res); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// KlinesWsRequest parameters for 'klines' websocket API
+type KlinesWsRequest struct {
+	symbol    string
+	interval  string
+	limit     *int
+	startTime *int64
+	endTime   *int64
+}
+
+// NewKlinesWsRequest init KlinesWsRequest
+func NewKlinesWsRequest() *KlinesWsRequest {
+	return &KlinesWsRequest{}
+}
+
+// Symbol set symbol
+func (s *KlinesWsRequest) Symbol(symbol string) *KlinesWsRequest {
+	s.symbol = symbol
+	return s
+}
+
+// Interval set interval
+func (s *KlinesWsRequest) Interval(interval string) *KlinesWsRequest {
+	s.interval = interval
+	return s
+}
+
+// Limit set limit
+func (s *KlinesWsRequest) Limit(limit int) *KlinesWsRequest {
+	s.limit = &limit
+	return s
+}
+
+// StartTime set startTime
+func (s *KlinesWsRequest) StartTime(startTime int64) *KlinesWsRequest {
+	s.startTime = &startTime
+	return s
+}
+
+// EndTime set endTime
+func (s *KlinesWsRequest) EndTime(endTime int64) *KlinesWsRequest {
+	s.endTime = &endTime
+	return s
+}
+
+// buildParams builds params
+func (s *KlinesWsRequest) buildParams() params {
+	m := params{
+		"symbol":   s.symbol,
+		"interval": s.interval,
+	}
+	if s.limit != nil {
+		m["limit"] = *s.limit
+	}
+	if s.startTime != nil {
+		m["startTime"] = *s.startTime
+	}
+	if s.endTime != nil {
+		m["endTime"] = *s.endTime
+	}
+	return m
+}
+
+// KlinesWsService klines over websocket API
+type KlinesWsService struct {
+	c *ClientWs
+}
+
+// NewKlinesWsService init KlinesWsService sharing the client connection
+func (c *ClientWs) NewKlinesWsService() *KlinesWsService {
+	return &KlinesWsService{c: c}
+}
+
+// Do - sends 'klines' request
+func (s *KlinesWsService) Do(ctx context.Context, req *KlinesWsRequest) ([]*Kline, error) {
+	rawResp, err := s.c.doRequest(ctx, WsApiMethodKlines, req.buildParams(), false)
+	if err != nil {
+		return nil, err
+	}
+
+	j, err := newJSON(rawResp)
+	if err != nil {
+		return nil, err
+	}
+	return newKlines(j.Get("result"))
+}
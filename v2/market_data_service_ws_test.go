@@ -0,0 +1,99 @@
+package binance
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+
+	"github.com/adshao/go-binance/v2/common"
+)
+
+type marketDataWsServiceTestSuite struct {
+	baseWsApiTestSuite
+}
+
+func TestMarketDataWsService(t *testing.T) {
+	suite.Run(t, new(marketDataWsServiceTestSuite))
+}
+
+func (s *marketDataWsServiceTestSuite) TestDepth() {
+	s.respond(WsApiMethodDepth, `{
+		"lastUpdateId": 2731179239,
+		"bids": [["0.01379900", "3.43200000"]],
+		"asks": [["0.01380000", "5.91700000"]]
+	}`)
+
+	res, err := s.wsClient.NewDepthWsService().Do(newContext(), NewDepthWsRequest().Symbol("BNBBTC").Limit(5))
+	r := s.r()
+	r.NoError(err)
+	r.Equal(&DepthResponse{
+		LastUpdateID: 2731179239,
+		Bids:         []Bid{{Price: "0.01379900", Quantity: "3.43200000"}},
+		Asks:         []Ask{{Price: "0.01380000", Quantity: "5.91700000"}},
+	}, res)
+	r.Equal(params{"symbol": "BNBBTC", "limit": float64(5)}, s.lastRequest().Params)
+}
+
+func (s *marketDataWsServiceTestSuite) TestTickerPrice() {
+	s.respond(WsApiMethodTickerPrice, `{"symbol":"BNBBTC","price":"0.01361900"}`)
+
+	res, err := s.wsClient.NewTickerPriceWsService().Do(newContext(), NewTickerPriceWsRequest().Symbol("BNBBTC"))
+	r := s.r()
+	r.NoError(err)
+	r.Equal([]*SymbolPrice{{Symbol: "BNBBTC", Price: "0.01361900"}}, res)
+}
+
+func (s *marketDataWsServiceTestSuite) TestTickerBook() {
+	s.respond(WsApiMethodTickerBook, `[
+		{"symbol":"BNBBTC","bidPrice":"0.01358000","bidQty":"12.53400000","askPrice":"0.01358100","askQty":"17.83700000"},
+		{"symbol":"BTCUSDT","bidPrice":"23980.49000000","bidQty":"0.01000000","askPrice":"23981.31000000","askQty":"0.01512000"}
+	]`)
+
+	res, err := s.wsClient.NewTickerBookWsService().Do(newContext(), NewTickerBookWsRequest())
+	r := s.r()
+	r.NoError(err)
+	r.Len(res, 2)
+	r.Equal(&BookTicker{
+		Symbol:      "BTCUSDT",
+		BidPrice:    "23980.49000000",
+		BidQuantity: "0.01000000",
+		AskPrice:    "23981.31000000",
+		AskQuantity: "0.01512000",
+	}, res[1])
+	r.Empty(s.lastRequest().Params)
+}
+
+func (s *marketDataWsServiceTestSuite) TestKlines() {
+	s.respond(WsApiMethodKlines, `[
+		[1655971200000,"0.01086000","0.01086600","0.01083600","0.01083800","2290.53800000",1655974799999,"24.85074442",2283,"1171.64000000","12.71225884","0"]
+	]`)
+
+	res, err := s.wsClient.NewKlinesWsService().Do(newContext(), NewKlinesWsRequest().
+		Symbol("BNBBTC").Interval("1h").StartTime(1655969280000).Limit(1))
+	r := s.r()
+	r.NoError(err)
+	r.Equal([]*Kline{{
+		OpenTime:                 1655971200000,
+		Open:                     "0.01086000",
+		High:                     "0.01086600",
+		Low:                      "0.01083600",
+		Close:                    "0.01083800",
+		Volume:                   "2290.53800000",
+		CloseTime:                1655974799999,
+		QuoteAssetVolume:         "24.85074442",
+		TradeNum:                 2283,
+		TakerBuyBaseAssetVolume:  "1171.64000000",
+		TakerBuyQuoteAssetVolume: "12.71225884",
+	}}, res)
+	r.Equal(params{
+		"symbol":    "BNBBTC",
+		"interval":  "1h",
+		"startTime": float64(1655969280000),
+		"limit":     float64(1),
+	}, s.lastRequest().Params)
+}
+
+func (s *marketDataWsServiceTestSuite) TestError() {
+	_, err := s.wsClient.NewKlinesWsService().Do(newContext(), NewKlinesWsRequest().Symbol("BNBBTC").Interval("1h"))
+	s.r().Equal(&common.APIError{Code: -1102, Message: "unexpected method"}, err)
+}
@@ -0,0 +1,88 @@
+package options
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+)
+
+// ExchangeInfoService exchange info service
+type ExchangeInfoService struct {
+	c *Client
+}
+
+// Do send request
+func (s *ExchangeInfoService) Do(ctx context.Context, opts ...RequestOption) (res *ExchangeInfo, err error) {
+	r := &request{
+		method:   http.MethodGet,
+		endpoint: "/eapi/v1/exchangeInfo",
+		secType:  secTypeNone,
+	}
+	data, err := s.c.callAPI(ctx, r, opts...)
+	if err != nil {
+		return nil, err
+	}
+	res = new(ExchangeInfo)
+	err = json.Unmarshal(data, res)
+	if err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// ExchangeInfo exchange info
+type ExchangeInfo struct {
+	Timezone        string           `json:"timezone"`
+	ServerTime      int64            `json:"serverTime"`
+	OptionContracts []OptionContract `json:"optionContracts"`
+	OptionAssets    []OptionAsset    `json:"optionAssets"`
+	OptionSymbols   []OptionSymbol   `json:"optionSymbols"`
+	RateLimits      []RateLimit      `json:"rateLimits"`
+}
+
+// OptionContract define underlying contract
+type OptionContract struct {
+	ID          int64  `json:"id"`
+	BaseAsset   string `json:"baseAsset"`
+	QuoteAsset  string `json:"quoteAsset"`
+	Underlying  string `json:"underlying"`
+	SettleAsset string `json:"settleAsset"`
+}
+
+// OptionAsset define option asset
+type OptionAsset struct {
+	ID   int64  `json:"id"`
+	Name string `json:"name"`
+}
+
+// OptionSymbol define option trading symbol
+type OptionSymbol struct {
+	ContractID           int64                    `json:"contractId"`
+	ExpiryDate           int64                    `json:"expiryDate"`
+	Filters              []map[string]interface{} `json:"filters"`
+	ID                   int64                    `json:"id"`
+	Symbol               string                   `json:"symbol"`
+	Side                 OptionSideType           `json:"side"`
+	StrikePrice          string                   `json:"strikePrice"`
+	Underlying           string                   `json:"underlying"`
+	Unit                 int64                    `json:"unit"`
+	MakerFeeRate         string                   `json:"makerFeeRate"`
+	TakerFeeRate         string                   `json:"takerFeeRate"`
+	MinQty               string                   `json:"minQty"`
+	MaxQty               string                   `json:"maxQty"`
+	InitialMargin        string                   `json:"initialMargin"`
+	MaintenanceMargin    string                   `json:"maintenanceMargin"`
+	MinInitialMargin     string                   `json:"minInitialMargin"`
+	MinMaintenanceMargin string                   `json:"minMaintenanceMargin"`
+	PriceScale           int                      `json:"priceScale"`
+	QuantityScale        int                      `json:"quantityScale"`
+	QuoteAsset           string                   `json:"quoteAsset"`
+}
+
+// RateLimit struct
+type RateLimit struct {
+	RateLimitType string `json:"rateLimitType"`
+	Interval      string `json:"interval"`
+	IntervalNum   int64  `json:"intervalNum"`
+	Limit         int64  `json:"limit"`
+}
@@ -0,0 +1,71 @@
+package options
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type exchangeInfoServiceTestSuite struct {
+	baseTestSuite
+}
+
+func TestExchangeInfoService(t *testing.T) {
+	suite.Run(t, new(exchangeInfoServiceTestSuite))
+}
+
+func (s *exchangeInfoServiceTestSuite) TestExchangeInfo() {
+	data := []byte(`{
+		"timezone": "UTC",
+		"serverTime": 1592387337630,
+		"optionContracts": [
+			{"id": 1, "baseAsset": "BTC", "quoteAsset": "USDT", "underlying": "BTCUSDT", "settleAsset": "USDT"}
+		],
+		"optionAssets": [{"id": 1, "name": "USDT"}],
+		"optionSymbols": [{
+			"contractId": 2,
+			"expiryDate": 1660521600000,
+			"filters": [{"filterType": "PRICE_FILTER", "minPrice": "0.02", "maxPrice": "80000.01", "tickSize": "0.01"}],
+			"id": 17,
+			"symbol": "BTC-220815-50000-C",
+			"side": "CALL",
+			"strikePrice": "50000",
+			"underlying": "BTCUSDT",
+			"unit": 1,
+			"makerFeeRate": "0.0002",
+			"takerFeeRate": "0.0002",
+			"minQty": "0.01",
+			"maxQty": "100",
+			"initialMargin": "0.15",
+			"maintenanceMargin": "0.075",
+			"minInitialMargin": "0.1",
+			"minMaintenanceMargin": "0.05",
+			"priceScale": 2,
+			"quantityScale": 2,
+			"quoteAsset": "USDT"
+		}],
+		"rateLimits": [{"rateLimitType": "REQUEST_WEIGHT", "interval": "MINUTE", "intervalNum": 1, "limit": 2400}]
+	}`)
+	s.mockDo(data, nil)
+	defer s.assertDo()
+	s.assertReq(func(r *request) {
+		e := newRequest()
+		s.assertRequestEqual(e, r)
+	})
+
+	res, err := s.client.NewExchangeInfoService().Do(newContext())
+	r := s.r()
+	r.NoError(err)
+	r.Equal("UTC", res.Timezone)
+	r.Equal([]OptionContract{{ID: 1, BaseAsset: "BTC", QuoteAsset: "USDT", Underlying: "BTCUSDT", SettleAsset: "USDT"}}, res.OptionContracts)
+	r.Equal([]OptionAsset{{ID: 1, Name: "USDT"}}, res.OptionAssets)
+	r.Len(res.OptionSymbols, 1)
+	sym := res.OptionSymbols[0]
+	r.Equal("BTC-220815-50000-C", sym.Symbol)
+	r.Equal(OptionSideTypeCall, sym.Side)
+	r.Equal("50000", sym.StrikePrice)
+	r.Equal(int64(1660521600000), sym.ExpiryDate)
+	r.Equal(2, sym.PriceScale)
+	r.Equal("0.01", sym.Filters[0]["tickSize"])
+	r.Equal(int64(2400), res.RateLimits[0].Limit)
+}
@@ -0,0 +1,57 @@
+package options
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+)
+
+// MarkPriceService get option mark price and greeks
+type MarkPriceService struct {
+	c      *Client
+	symbol *string
+}
+
+// Symbol set symbol
+func (s *MarkPriceService) Symbol(symbol string) *MarkPriceService {
+	s.symbol = &symbol
+	return s
+}
+
+// Do send request
+func (s *MarkPriceService) Do(ctx context.Context, opts ...RequestOption) (res []*MarkPrice, err error) {
+	r := &request{
+		method:   http.MethodGet,
+		endpoint: "/eapi/v1/mark",
+		secType:  secTypeNone,
+	}
+	if s.symbol != nil {
+		r.setParam("symbol", *s.symbol)
+	}
+	data, err := s.c.callAPI(ctx, r, opts...)
+	if err != nil {
+		return []*MarkPrice{}, err
+	}
+	res = make([]*MarkPrice, 0)
+	err = json.Unmarshal(data, &res)
+	if err != nil {
+		return []*MarkPrice{}, err
+	}
+	return res, nil
+}
+
+// MarkPrice define option mark price, implied volatility and greeks
+type MarkPrice struct {
+	Symbol           string `json:"symbol"`
+	MarkPrice        string `json:"markPrice"`
+	BidIV            string `json:"bidIV"`
+	AskIV            string `json:"askIV"`
+	MarkIV           string `json:"markIV"`
+	Delta            string `json:"delta"`
+	Theta            string `json:"theta"`
+	Gamma            string `json:"gamma"`
+	Vega             string `json:"vega"`
+	HighPriceLimit   string `json:"highPriceLimit"`
+	LowPriceLimit    string `json:"lowPriceLimit"`
+	RiskFreeInterest string `json:"riskFreeInterest"`
+}
@@ -0,0 +1,213 @@
+package options
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+)
+
+// CreateOrderService create option order
+type CreateOrderService struct {
+	c                *Client
+	symbol           string
+	side             SideType
+	orderType        OrderType
+	quantity         string
+	price            *string
+	timeInForce      *TimeInForceType
+	reduceOnly       *bool
+	postOnly         *bool
+	newOrderRespType *NewOrderRespType
+	clientOrderID    *string
+	isMmp            *bool
+}
+
+// Symbol set symbol
+func (s *CreateOrderService) Symbol(symbol string) *CreateOrderService {
+	s.symbol = symbol
+	return s
+}
+
+// Side set side
+func (s *CreateOrderService) Side(side SideType) *CreateOrderService {
+	s.side = side
+	return s
+}
+
+// Type set type
+func (s *CreateOrderService) Type(orderType OrderType) *CreateOrderService {
+	s.orderType = orderType
+	return s
+}
+
+// Quantity set quantity
+func (s *CreateOrderService) Quantity(quantity string) *CreateOrderService {
+	s.quantity = quantity
+	return s
+}
+
+// Price set price
+func (s *CreateOrderService) Price(price string) *CreateOrderService {
+	s.price = &price
+	return s
+}
+
+// TimeInForce set timeInForce
+func (s *CreateOrderService) TimeInForce(timeInForce TimeInForceType) *CreateOrderService {
+	s.timeInForce = &timeInForce
+	return s
+}
+
+// ReduceOnly set reduceOnly
+func (s *CreateOrderService) ReduceOnly(reduceOnly bool) *CreateOrderService {
+	s.reduceOnly = &reduceOnly
+	return s
+}
+
+// PostOnly set postOnly
+func (s *CreateOrderService) PostOnly(postOnly bool) *CreateOrderService {
+	s.postOnly = &postOnly
+	return s
+}
+
+// NewOrderRespType set newOrderRespType
+func (s *CreateOrderService) NewOrderRespType(newOrderRespType NewOrderRespType) *CreateOrderService {
+	s.newOrderRespType = &newOrderRespType
+	return s
+}
+
+// ClientOrderID set clientOrderId
+func (s *CreateOrderService) ClientOrderID(clientOrderID string) *CreateOrderService {
+	s.clientOrderID = &clientOrderID
+	return s
+}
+
+// IsMmp set isMmp, marks the order as a market maker protection order
+func (s *CreateOrderService) IsMmp(isMmp bool) *CreateOrderService {
+	s.isMmp = &isMmp
+	return s
+}
+
+// Do send request
+func (s *CreateOrderService) Do(ctx context.Context, opts ...RequestOption) (res *Order, err error) {
+	r := &request{
+		method:   http.MethodPost,
+		endpoint: "/eapi/v1/order",
+		secType:  secTypeSigned,
+	}
+	m := params{
+		"symbol":   s.symbol,
+		"side":     s.side,
+		"type":     s.orderType,
+		"quantity": s.quantity,
+	}
+	if s.price != nil {
+		m["price"] = *s.price
+	}
+	if s.timeInForce != nil {
+		m["timeInForce"] = *s.timeInForce
+	}
+	if s.reduceOnly != nil {
+		m["reduceOnly"] = *s.reduceOnly
+	}
+	if s.postOnly != nil {
+		m["postOnly"] = *s.postOnly
+	}
+	if s.newOrderRespType != nil {
+		m["newOrderRespType"] = *s.newOrderRespType
+	}
+	if s.clientOrderID != nil {
+		m["clientOrderId"] = *s.clientOrderID
+	}
+	if s.isMmp != nil {
+		m["isMmp"] = *s.isMmp
+	}
+	r.setFormParams(m)
+	data, err := s.c.callAPI(ctx, r, opts...)
+	if err != nil {
+		return nil, err
+	}
+	res = new(Order)
+	err = json.Unmarshal(data, res)
+	if err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// CancelOrderService cancel an active option order
+type CancelOrderService struct {
+	c             *Client
+	symbol        string
+	orderID       *int64
+	clientOrderID *string
+}
+
+// Symbol set symbol
+func (s *CancelOrderService) Symbol(symbol string) *CancelOrderService {
+	s.symbol = symbol
+	return s
+}
+
+// OrderID set orderID
+func (s *CancelOrderService) OrderID(orderID int64) *CancelOrderService {
+	s.orderID = &orderID
+	return s
+}
+
+// ClientOrderID set clientOrderId
+func (s *CancelOrderService) ClientOrderID(clientOrderID string) *CancelOrderService {
+	s.clientOrderID = &clientOrderID
+	return s
+}
+
+// Do send request
+func (s *CancelOrderService) Do(ctx context.Context, opts ...RequestOption) (res *Order, err error) {
+	r := &request{
+		method:   http.MethodDelete,
+		endpoint: "/eapi/v1/order",
+		secType:  secTypeSigned,
+	}
+	r.setFormParam("symbol", s.symbol)
+	if s.orderID != nil {
+		r.setFormParam("orderId", *s.orderID)
+	}
+	if s.clientOrderID != nil {
+		r.setFormParam("clientOrderId", *s.clientOrderID)
+	}
+	data, err := s.c.callAPI(ctx, r, opts...)
+	if err != nil {
+		return nil, err
+	}
+	res = new(Order)
+	err = json.Unmarshal(data, res)
+	if err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// Order define option order info
+type Order struct {
+	OrderID       int64           `json:"orderId"`
+	Symbol        string          `json:"symbol"`
+	Price         string          `json:"price"`
+	Quantity      string          `json:"quantity"`
+	ExecutedQty   string          `json:"executedQty"`
+	Fee           string          `json:"fee"`
+	Side          SideType        `json:"side"`
+	Type          OrderType       `json:"type"`
+	TimeInForce   TimeInForceType `json:"timeInForce"`
+	ReduceOnly    bool            `json:"reduceOnly"`
+	PostOnly      bool            `json:"postOnly"`
+	CreateTime    int64           `json:"createTime"`
+	UpdateTime    int64           `json:"updateTime"`
+	Status        OrderStatusType `json:"status"`
+	AvgPrice      string          `json:"avgPrice"`
+	ClientOrderID string          `json:"clientOrderId"`
+	PriceScale    int             `json:"priceScale"`
+	QuantityScale int             `json:"quantityScale"`
+	OptionSide    OptionSideType  `json:"optionSide"`
+	QuoteAsset    string          `json:"quoteAsset"`
+	Mmp           bool            `json:"mmp"`
+}
@@ -0,0 +1,108 @@
+package options
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type orderServiceTestSuite struct {
+	baseTestSuite
+}
+
+func TestOrderService(t *testing.T) {
+	suite.Run(t, new(orderServiceTestSuite))
+}
+
+func (s *orderServiceTestSuite) TestCreateOrder() {
+	data := []byte(`{
+		"orderId": 4611875134427365377,
+		"symbol": "BTC-200730-9000-C",
+		"price": "100",
+		"quantity": "1",
+		"executedQty": "0",
+		"fee": "0",
+		"side": "BUY",
+		"type": "LIMIT",
+		"timeInForce": "GTC",
+		"reduceOnly": false,
+		"postOnly": true,
+		"createTime": 1592465880683,
+		"updateTime": 1566818724722,
+		"status": "ACCEPTED",
+		"avgPrice": "0",
+		"clientOrderId": "myOrder",
+		"priceScale": 2,
+		"quantityScale": 2,
+		"optionSide": "CALL",
+		"quoteAsset": "USDT",
+		"mmp": false
+	}`)
+	s.mockDo(data, nil)
+	defer s.assertDo()
+	s.assertReq(func(r *request) {
+		e := newSignedRequest().setFormParams(params{
+			"symbol":        "BTC-200730-9000-C",
+			"side":          SideTypeBuy,
+			"type":          OrderTypeLimit,
+			"quantity":      "1",
+			"price":         "100",
+			"timeInForce":   TimeInForceTypeGTC,
+			"postOnly":      true,
+			"clientOrderId": "myOrder",
+		})
+		s.assertRequestEqual(e, r)
+	})
+
+	res, err := s.client.NewCreateOrderService().Symbol("BTC-200730-9000-C").Side(SideTypeBuy).
+		Type(OrderTypeLimit).Quantity("1").Price("100").TimeInForce(TimeInForceTypeGTC).
+		PostOnly(true).ClientOrderID("myOrder").Do(newContext())
+	r := s.r()
+	r.NoError(err)
+	r.Equal(&Order{
+		OrderID:       4611875134427365377,
+		Symbol:        "BTC-200730-9000-C",
+		Price:         "100",
+		Quantity:      "1",
+		ExecutedQty:   "0",
+		Fee:           "0",
+		Side:          SideTypeBuy,
+		Type:          OrderTypeLimit,
+		TimeInForce:   TimeInForceTypeGTC,
+		PostOnly:      true,
+		CreateTime:    1592465880683,
+		UpdateTime:    1566818724722,
+		Status:        OrderStatusTypeAccepted,
+		AvgPrice:      "0",
+		ClientOrderID: "myOrder",
+		PriceScale:    2,
+		QuantityScale: 2,
+		OptionSide:    OptionSideTypeCall,
+		QuoteAsset:    "USDT",
+	}, res)
+}
+
+func (s *orderServiceTestSuite) TestCancelOrder() {
+	data := []byte(`{
+		"orderId": 4611875134427365377,
+		"symbol": "BTC-200730-9000-C",
+		"status": "CANCELLED",
+		"clientOrderId": "myOrder"
+	}`)
+	s.mockDo(data, nil)
+	defer s.assertDo()
+	s.assertReq(func(r *request) {
+		e := newSignedRequest().setFormParams(params{
+			"symbol":  "BTC-200730-9000-C",
+			"orderId": 4611875134427365377,
+		})
+		s.assertRequestEqual(e, r)
+	})
+
+	res, err := s.client.NewCancelOrderService().Symbol("BTC-200730-9000-C").
+		OrderID(4611875134427365377).Do(newContext())
+	r := s.r()
+	r.NoError(err)
+	r.Equal(OrderStatusTypeCancelled, res.Status)
+	r.Equal("myOrder", res.ClientOrderID)
+}
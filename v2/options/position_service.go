@@ -0,0 +1,61 @@
+package options
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+)
+
+// GetPositionService get option positions
+type GetPositionService struct {
+	c      *Client
+	symbol *string
+}
+
+// Symbol set symbol
+func (s *GetPositionService) Symbol(symbol string) *GetPositionService {
+	s.symbol = &symbol
+	return s
+}
+
+// Do send request
+func (s *GetPositionService) Do(ctx context.Context, opts ...RequestOption) (res []*Position, err error) {
+	r := &request{
+		method:   http.MethodGet,
+		endpoint: "/eapi/v1/position",
+		secType:  secTypeSigned,
+	}
+	if s.symbol != nil {
+		r.setParam("symbol", *s.symbol)
+	}
+	data, err := s.c.callAPI(ctx, r, opts...)
+	if err != nil {
+		return []*Position{}, err
+	}
+	res = make([]*Position, 0)
+	err = json.Unmarshal(data, &res)
+	if err != nil {
+		return []*Position{}, err
+	}
+	return res, nil
+}
+
+// Position define option position info
+type Position struct {
+	EntryPrice    string           `json:"entryPrice"`
+	Symbol        string           `json:"symbol"`
+	Side          PositionSideType `json:"side"`
+	Quantity      string           `json:"quantity"`
+	ReducibleQty  string           `json:"reducibleQty"`
+	MarkValue     string           `json:"markValue"`
+	Ror           string           `json:"ror"`
+	UnrealizedPNL string           `json:"unrealizedPNL"`
+	MarkPrice     string           `json:"markPrice"`
+	StrikePrice   string           `json:"strikePrice"`
+	PositionCost  string           `json:"positionCost"`
+	ExpiryDate    int64            `json:"expiryDate"`
+	PriceScale    int              `json:"priceScale"`
+	QuantityScale int              `json:"quantityScale"`
+	OptionSide    OptionSideType   `json:"optionSide"`
+	QuoteAsset    string           `json:"quoteAsset"`
+}
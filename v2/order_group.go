@@ -0,0 +1,111 @@
+package binance
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/adshao/go-binance/v2/common"
+	"github.com/adshao/go-binance/v2/futures"
+)
+
+// unwindTimeout bounds unwinding of the legs of a failed OrderGroup
+const unwindTimeout = 30 * time.Second
+
+// OrderLeg define order of an OrderGroup
+type OrderLeg interface {
+	// Place sends the order and returns once it is acknowledged
+	Place(ctx context.Context) error
+	// Unwind cancels the order if open and reverses its executed quantity
+	Unwind(ctx context.Context) error
+}
+
+var (
+	_ OrderLeg = (*SpotOrderLeg)(nil)
+	_ OrderLeg = (*futures.OrderLeg)(nil)
+)
+
+// OrderGroupResult define outcome of each leg of an OrderGroup
+type OrderGroupResult struct {
+	// PlaceErrs holds the placement error of each leg, nil for acknowledged legs
+	PlaceErrs []error
+	// Unwound is set for legs unwound because another leg failed
+	Unwound []bool
+	// UnwindErrs holds the unwind error of each unwound leg
+	UnwindErrs []error
+}
+
+// OrderGroup places orders concurrently, e.g. a spot leg and a perp leg, and unwinds the
+// legs placed if any leg fails or is not acknowledged within the timeout. Legs rejected by
+// the exchange are not unwound, legs whose outcome is unknown are, including those the
+// exchange reports with unknown execution status.
+type OrderGroup struct {
+	legs    []OrderLeg
+	timeout time.Duration
+}
+
+// NewOrderGroup init OrderGroup of legs
+func NewOrderGroup(legs ...OrderLeg) *OrderGroup {
+	return &OrderGroup{legs: legs}
+}
+
+// Timeout set deadline of all legs to be acknowledged
+func (g *OrderGroup) Timeout(timeout time.Duration) *OrderGroup {
+	g.timeout = timeout
+	return g
+}
+
+// Do places the legs. If a leg fails, the others are unwound and the error of the first
+// failed leg is returned, see OrderGroupResult for the outcome of each leg.
+func (g *OrderGroup) Do(ctx context.Context) (*OrderGroupResult, error) {
+	res := &OrderGroupResult{
+		PlaceErrs:  make([]error, len(g.legs)),
+		Unwound:    make([]bool, len(g.legs)),
+		UnwindErrs: make([]error, len(g.legs)),
+	}
+
+	placeCtx := ctx
+	if g.timeout > 0 {
+		var cancel context.CancelFunc
+		placeCtx, cancel = context.WithTimeout(ctx, g.timeout)
+		defer cancel()
+	}
+	var wg sync.WaitGroup
+	for i, leg := range g.legs {
+		wg.Add(1)
+		go func(i int, leg OrderLeg) {
+			defer wg.Done()
+			res.PlaceErrs[i] = leg.Place(placeCtx)
+		}(i, leg)
+	}
+	wg.Wait()
+
+	var err error
+	for i, placeErr := range res.PlaceErrs {
+		if placeErr != nil {
+			err = fmt.Errorf("order group: leg %d: %w", i, placeErr)
+			break
+		}
+	}
+	if err == nil {
+		return res, nil
+	}
+
+	unwindCtx, cancel := context.WithTimeout(context.Background(), unwindTimeout)
+	defer cancel()
+	for i, leg := range g.legs {
+		placeErr := res.PlaceErrs[i]
+		if common.IsAPIError(placeErr) && !common.IsAPIErrorCode(placeErr, errCodeUnknownStatus) {
+			continue
+		}
+		wg.Add(1)
+		go func(i int, leg OrderLeg) {
+			defer wg.Done()
+			res.Unwound[i] = true
+			res.UnwindErrs[i] = leg.Unwind(unwindCtx)
+		}(i, leg)
+	}
+	wg.Wait()
+	return res, err
+}
@@ -0,0 +1,118 @@
+package binance
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/adshao/go-binance/v2/common"
+	"github.com/stretchr/testify/assert"
+)
+
+type testOrderLeg struct {
+	placeErr error
+	delay    time.Duration
+	unwound  bool
+}
+
+func (l *testOrderLeg) Place(ctx context.Context) error {
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-time.After(l.delay):
+	}
+	return l.placeErr
+}
+
+func (l *testOrderLeg) Unwind(ctx context.Context) error {
+	l.unwound = true
+	return nil
+}
+
+func TestOrderGroup(t *testing.T) {
+	assert := assert.New(t)
+
+	spot, perp := &testOrderLeg{}, &testOrderLeg{}
+	res, err := NewOrderGroup(spot, perp).Do(newContext())
+	assert.NoError(err)
+	assert.Equal([]error{nil, nil}, res.PlaceErrs)
+	assert.False(spot.unwound || perp.unwound)
+
+	rejected := &common.APIError{Code: -2010, Message: "Account has insufficient balance for requested action."}
+	spot, perp = &testOrderLeg{}, &testOrderLeg{placeErr: rejected}
+	res, err = NewOrderGroup(spot, perp).Do(newContext())
+	assert.ErrorIs(err, rejected)
+	assert.Equal([]bool{true, false}, res.Unwound)
+	assert.True(spot.unwound)
+	assert.False(perp.unwound, "rejected leg is not unwound")
+
+	unknown := &common.APIError{Code: -1007, Message: "Timeout waiting for response from backend server. Send status unknown; execution status unknown."}
+	spot, perp = &testOrderLeg{placeErr: unknown}, &testOrderLeg{placeErr: rejected}
+	res, err = NewOrderGroup(spot, perp).Do(newContext())
+	assert.ErrorIs(err, unknown)
+	assert.Equal([]bool{true, false}, res.Unwound, "leg of unknown execution status is unwound")
+
+	spot, perp = &testOrderLeg{}, &testOrderLeg{delay: time.Second}
+	res, err = NewOrderGroup(spot, perp).Timeout(10 * time.Millisecond).Do(newContext())
+	assert.ErrorIs(err, context.DeadlineExceeded)
+	assert.Equal([]bool{true, true}, res.Unwound, "leg not acknowledged in time is unwound")
+}
+
+func TestSpotOrderLegUnwind(t *testing.T) {
+	assert := assert.New(t)
+
+	var orders []url.Values
+	canceled := false
+	c := NewClient("apiKey", "secretKey")
+	c.do = func(req *http.Request) (*http.Response, error) {
+		body, _ := io.ReadAll(req.Body)
+		form, _ := url.ParseQuery(string(body))
+		switch req.Method {
+		case http.MethodPost:
+			orders = append(orders, form)
+			if len(orders) == 1 {
+				return nil, errors.New("timeout")
+			}
+			return newHTTPResponse([]byte(`{"status":"FILLED","executedQty":"0.4"}`), http.StatusOK), nil
+		case http.MethodDelete:
+			canceled = true
+			return newHTTPResponse([]byte(`{}`), http.StatusOK), nil
+		case http.MethodGet:
+			switch req.URL.Path {
+			case "/api/v3/exchangeInfo":
+				return newHTTPResponse([]byte(`{"symbols":[{"symbol":"BTCUSDT","baseAsset":"BTC","baseAssetPrecision":8,
+					"filters":[{"filterType":"LOT_SIZE","minQty":"0.00010000","maxQty":"9000.00000000","stepSize":"0.00010000"}]}]}`), http.StatusOK), nil
+			case "/api/v3/myTrades":
+				// commission paid in base asset was not bought
+				return newHTTPResponse([]byte(`[{"orderId":7,"qty":"0.3","commission":"0.0003","commissionAsset":"BTC"},
+					{"orderId":7,"qty":"0.1","commission":"0.00001","commissionAsset":"BNB"}]`), http.StatusOK), nil
+			}
+			status := "PARTIALLY_FILLED"
+			if canceled {
+				status = "CANCELED"
+			}
+			return newHTTPResponse([]byte(fmt.Sprintf(`{"symbol":"BTCUSDT","orderId":7,"status":"%s","executedQty":"0.4"}`, status)), http.StatusOK), nil
+		}
+		return newHTTPResponse([]byte(`{}`), http.StatusNotFound), nil
+	}
+
+	leg := c.NewSpotOrderLeg(c.NewCreateOrderService().Symbol("BTCUSDT").Side(SideTypeBuy).
+		Type(OrderTypeLimit).TimeInForce(TimeInForceTypeGTC).Quantity("1").Price("100"))
+	assert.Error(leg.Place(newContext()))
+	assert.Nil(leg.Order())
+
+	assert.NoError(leg.Unwind(newContext()))
+	assert.True(canceled)
+	if assert.Len(orders, 2) {
+		assert.Equal(leg.ClientOrderID(), orders[0].Get("newClientOrderId"))
+		assert.Equal("SELL", orders[1].Get("side"))
+		assert.Equal("MARKET", orders[1].Get("type"))
+		assert.Equal("0.3997", orders[1].Get("quantity"))
+	}
+	assert.Equal("0.4", leg.UnwindOrder().ExecutedQuantity)
+}
@@ -0,0 +1,147 @@
+package binance
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/adshao/go-binance/v2/common"
+)
+
+// API error codes of orders that are unknown to the exchange
+const (
+	errCodeUnknownOrder      = -2011
+	errCodeOrderDoesNotExist = -2013
+)
+
+// errCodeUnknownStatus is returned when the order was sent but its execution status is unknown
+const errCodeUnknownStatus = -1007
+
+// SpotOrderLeg wraps a spot order so it can be unwound after it was placed, e.g. as leg of an OrderGroup
+type SpotOrderLeg struct {
+	c             *Client
+	order         *CreateOrderService
+	clientOrderID string
+	res           *CreateOrderResponse
+	unwind        *CreateOrderResponse
+}
+
+// NewSpotOrderLeg init SpotOrderLeg of order, a client order id is set if order has none
+func (c *Client) NewSpotOrderLeg(order *CreateOrderService) *SpotOrderLeg {
+	l := &SpotOrderLeg{c: c, order: order}
+	if order.newClientOrderID != nil {
+		l.clientOrderID = *order.newClientOrderID
+	} else {
+		l.clientOrderID = "leg" + strconv.FormatInt(time.Now().UnixNano(), 36)
+		order.NewClientOrderID(l.clientOrderID)
+	}
+	return l
+}
+
+// Place sends the order
+func (l *SpotOrderLeg) Place(ctx context.Context) (err error) {
+	l.res, err = l.order.Do(ctx)
+	return err
+}
+
+// Unwind cancels the order if it is still open and sells back or buys back its executed
+// quantity with a market order, less the commission paid in base asset for buy orders. Orders
+// that were never placed are ignored.
+func (l *SpotOrderLeg) Unwind(ctx context.Context) error {
+	order, err := l.c.NewGetOrderService().Symbol(l.order.symbol).OrigClientOrderID(l.clientOrderID).Do(ctx)
+	if common.IsAPIErrorCode(err, errCodeOrderDoesNotExist) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	if order.Status == OrderStatusTypeNew || order.Status == OrderStatusTypePartiallyFilled {
+		_, err = l.c.NewCancelOrderService().Symbol(l.order.symbol).OrigClientOrderID(l.clientOrderID).Do(ctx)
+		if err != nil && !common.IsAPIErrorCode(err, errCodeUnknownOrder) {
+			return err
+		}
+		if order, err = l.c.NewGetOrderService().Symbol(l.order.symbol).OrigClientOrderID(l.clientOrderID).Do(ctx); err != nil {
+			return err
+		}
+	}
+	executed, err := strconv.ParseFloat(order.ExecutedQuantity, 64)
+	if err != nil || executed == 0 {
+		return err
+	}
+
+	side, quantity := SideTypeSell, order.ExecutedQuantity
+	if l.order.side == SideTypeSell {
+		side = SideTypeBuy
+	} else if quantity, err = l.netQuantity(ctx, order.OrderID, executed); err != nil {
+		return err
+	}
+	if q, err := strconv.ParseFloat(quantity, 64); err != nil || q == 0 {
+		return err
+	}
+	l.unwind, err = l.c.NewCreateOrderService().Symbol(l.order.symbol).Side(side).Type(OrderTypeMarket).
+		Quantity(quantity).NewOrderRespType(NewOrderRespTypeRESULT).Do(ctx)
+	return err
+}
+
+// netQuantity returns executed quantity of bought order less the commission paid in base
+// asset, rounded down to the lot size of the symbol
+func (l *SpotOrderLeg) netQuantity(ctx context.Context, orderID int64, executed float64) (string, error) {
+	info, err := l.c.NewExchangeInfoService().Symbol(l.order.symbol).Do(ctx)
+	if err != nil {
+		return "", err
+	}
+	var symbol *Symbol
+	for i := range info.Symbols {
+		if info.Symbols[i].Symbol == l.order.symbol {
+			symbol = &info.Symbols[i]
+		}
+	}
+	if symbol == nil {
+		return "", fmt.Errorf("symbol %s not found", l.order.symbol)
+	}
+	trades, err := l.c.NewListTradesService().Symbol(l.order.symbol).OrderId(orderID).Do(ctx)
+	if err != nil {
+		return "", err
+	}
+	var commission float64
+	for _, trade := range trades {
+		if trade.CommissionAsset != symbol.BaseAsset {
+			continue
+		}
+		v, err := strconv.ParseFloat(trade.Commission, 64)
+		if err != nil {
+			return "", err
+		}
+		commission += v
+	}
+	net := executed - commission
+	if lot := symbol.LotSizeFilter(); lot != nil {
+		step, err := strconv.ParseFloat(lot.StepSize, 64)
+		if err != nil {
+			return "", err
+		}
+		if step > 0 {
+			net = common.AmountToLotSize(step, symbol.BaseAssetPrecision, net)
+		}
+	}
+	if net < 0 {
+		net = 0
+	}
+	return strconv.FormatFloat(net, 'f', -1, 64), nil
+}
+
+// ClientOrderID returns client order id of the order
+func (l *SpotOrderLeg) ClientOrderID() string {
+	return l.clientOrderID
+}
+
+// Order returns response of the placed order, nil if placing failed
+func (l *SpotOrderLeg) Order() *CreateOrderResponse {
+	return l.res
+}
+
+// UnwindOrder returns response of the order reversing the executed quantity, nil if none was sent
+func (l *SpotOrderLeg) UnwindOrder() *CreateOrderResponse {
+	return l.unwind
+}
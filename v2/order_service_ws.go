@@ -0,0 +1,339 @@
+package binance
+
+import (
+	"context"
+
+	"github.com/adshao/go-binance/v2/common"
+)
+
+const (
+	WsApiMethodOrderTest  WsApiMethodType = "order.test"
+	WsApiMethodOrderPlace WsApiMethodType = "order.place"
+)
+
+// OrderTestWsRequest parameters for 'order.test' websocket API
+type OrderTestWsRequest struct {
+	symbol                 string
+	side                   SideType
+	orderType              OrderType
+	timeInForce            *TimeInForceType
+	quantity               *string
+	quoteOrderQuantity     *string
+	price                  *string
+	newClientOrderID       *string
+	stopPrice              *string
+	computeCommissionRates *bool
+}
+
+// NewOrderTestWsRequest init OrderTestWsRequest
+func NewOrderTestWsRequest() *OrderTestWsRequest {
+	return &OrderTestWsRequest{}
+}
+
+// Symbol set symbol
+func (s *OrderTestWsRequest) Symbol(symbol string) *OrderTestWsRequest {
+	s.symbol = symbol
+	return s
+}
+
+// Side set side
+func (s *OrderTestWsRequest) Side(side SideType) *OrderTestWsRequest {
+	s.side = side
+	return s
+}
+
+// Type set type
+func (s *OrderTestWsRequest) Type(orderType OrderType) *OrderTestWsRequest {
+	s.orderType = orderType
+	return s
+}
+
+// TimeInForce set timeInForce
+func (s *OrderTestWsRequest) TimeInForce(timeInForce TimeInForceType) *OrderTestWsRequest {
+	s.timeInForce = &timeInForce
+	return s
+}
+
+// Quantity set quantity
+func (s *OrderTestWsRequest) Quantity(quantity string) *OrderTestWsRequest {
+	s.quantity = &quantity
+	return s
+}
+
+// QuoteOrderQty set quoteOrderQty
+func (s *OrderTestWsRequest) QuoteOrderQty(quoteOrderQty string) *OrderTestWsRequest {
+	s.quoteOrderQuantity = &quoteOrderQty
+	return s
+}
+
+// Price set price
+func (s *OrderTestWsRequest) Price(price string) *OrderTestWsRequest {
+	s.price = &price
+	return s
+}
+
+// NewClientOrderID set newClientOrderId
+func (s *OrderTestWsRequest) NewClientOrderID(newClientOrderID string) *OrderTestWsRequest {
+	s.newClientOrderID = &newClientOrderID
+	return s
+}
+
+// StopPrice set stopPrice
+func (s *OrderTestWsRequest) StopPrice(stopPrice string) *OrderTestWsRequest {
+	s.stopPrice = &stopPrice
+	return s
+}
+
+// ComputeCommissionRates set computeCommissionRates, the response then holds the commission
+// rates the order would pay
+func (s *OrderTestWsRequest) ComputeCommissionRates(computeCommissionRates bool) *OrderTestWsRequest {
+	s.computeCommissionRates = &computeCommissionRates
+	return s
+}
+
+// buildParams builds params
+func (s *OrderTestWsRequest) buildParams() params {
+	m := params{
+		"symbol": s.symbol,
+		"side":   s.side,
+		"type":   s.orderType,
+	}
+	if s.timeInForce != nil {
+		m["timeInForce"] = *s.timeInForce
+	}
+	if s.quantity != nil {
+		m["quantity"] = *s.quantity
+	}
+	if s.quoteOrderQuantity != nil {
+		m["quoteOrderQty"] = *s.quoteOrderQuantity
+	}
+	if s.price != nil {
+		m["price"] = *s.price
+	}
+	if s.newClientOrderID != nil {
+		m["newClientOrderId"] = *s.newClientOrderID
+	}
+	if s.stopPrice != nil {
+		m["stopPrice"] = *s.stopPrice
+	}
+	if s.computeCommissionRates != nil {
+		m["computeCommissionRates"] = *s.computeCommissionRates
+	}
+	return m
+}
+
+// OrderTestCommissionRates define commission rates returned by 'order.test'
+type OrderTestCommissionRates struct {
+	Maker string `json:"maker"`
+	Taker string `json:"taker"`
+}
+
+// OrderTestDiscount define commission discount returned by 'order.test'
+type OrderTestDiscount struct {
+	EnabledForAccount bool   `json:"enabledForAccount"`
+	EnabledForSymbol  bool   `json:"enabledForSymbol"`
+	DiscountAsset     string `json:"discountAsset"`
+	Discount          string `json:"discount"`
+}
+
+// OrderTestResult define result of 'order.test', empty unless commission rates were requested
+type OrderTestResult struct {
+	StandardCommissionForOrder OrderTestCommissionRates `json:"standardCommissionForOrder"`
+	TaxCommissionForOrder      OrderTestCommissionRates `json:"taxCommissionForOrder"`
+	Discount                   OrderTestDiscount        `json:"discount"`
+}
+
+// OrderTestWsResponse define 'order.test' websocket API response
+type OrderTestWsResponse struct {
+	Id     string           `json:"id"`
+	Status int              `json:"status"`
+	Result *OrderTestResult `json:"result"`
+
+	// error response
+	Error *common.APIError `json:"error,omitempty"`
+}
+
+// OrderTestWsService validates a new order without sending it to the matching engine
+type OrderTestWsService struct {
+	c *ClientWs
+}
+
+// NewOrderTestWsService init OrderTestWsService sharing the client connection
+func (c *ClientWs) NewOrderTestWsService() *OrderTestWsService {
+	return &OrderTestWsService{c: c}
+}
+
+// Do - sends 'order.test' request
+func (s *OrderTestWsService) Do(ctx context.Context, req *OrderTestWsRequest) (*OrderTestResult, error) {
+	rawResp, err := s.c.doRequest(ctx, WsApiMethodOrderTest, req.buildParams(), true)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := OrderTestWsResponse{}
+	if err := json.Unmarshal(rawResp, &resp); err != nil {
+		return nil, err
+	}
+
+	return resp.Result, nil
+}
+
+// TestOrder returns req as a common.TestOrder tested with 'order.test'
+func (s *OrderTestWsService) TestOrder(req *OrderTestWsRequest) common.TestOrder {
+	return &wsTestOrder{s: s, req: req}
+}
+
+type wsTestOrder struct {
+	s   *OrderTestWsService
+	req *OrderTestWsRequest
+}
+
+func (o *wsTestOrder) Test(ctx context.Context) error {
+	_, err := o.s.Do(ctx, o.req)
+	return err
+}
+
+// OrderPlaceWsRequest parameters for 'order.place' websocket API
+type OrderPlaceWsRequest struct {
+	symbol             string
+	side               SideType
+	orderType          OrderType
+	timeInForce        *TimeInForceType
+	quantity           *string
+	quoteOrderQuantity *string
+	price              *string
+	newClientOrderID   *string
+	stopPrice          *string
+	newOrderRespType   *NewOrderRespType
+}
+
+// NewOrderPlaceWsRequest init OrderPlaceWsRequest
+func NewOrderPlaceWsRequest() *OrderPlaceWsRequest {
+	return &OrderPlaceWsRequest{}
+}
+
+// Symbol set symbol
+func (s *OrderPlaceWsRequest) Symbol(symbol string) *OrderPlaceWsRequest {
+	s.symbol = symbol
+	return s
+}
+
+// Side set side
+func (s *OrderPlaceWsRequest) Side(side SideType) *OrderPlaceWsRequest {
+	s.side = side
+	return s
+}
+
+// Type set type
+func (s *OrderPlaceWsRequest) Type(orderType OrderType) *OrderPlaceWsRequest {
+	s.orderType = orderType
+	return s
+}
+
+// TimeInForce set timeInForce
+func (s *OrderPlaceWsRequest) TimeInForce(timeInForce TimeInForceType) *OrderPlaceWsRequest {
+	s.timeInForce = &timeInForce
+	return s
+}
+
+// Quantity set quantity
+func (s *OrderPlaceWsRequest) Quantity(quantity string) *OrderPlaceWsRequest {
+	s.quantity = &quantity
+	return s
+}
+
+// QuoteOrderQty set quoteOrderQty
+func (s *OrderPlaceWsRequest) QuoteOrderQty(quoteOrderQty string) *OrderPlaceWsRequest {
+	s.quoteOrderQuantity = &quoteOrderQty
+	return s
+}
+
+// Price set price
+func (s *OrderPlaceWsRequest) Price(price string) *OrderPlaceWsRequest {
+	s.price = &price
+	return s
+}
+
+// NewClientOrderID set newClientOrderId
+func (s *OrderPlaceWsRequest) NewClientOrderID(newClientOrderID string) *OrderPlaceWsRequest {
+	s.newClientOrderID = &newClientOrderID
+	return s
+}
+
+// StopPrice set stopPrice
+func (s *OrderPlaceWsRequest) StopPrice(stopPrice string) *OrderPlaceWsRequest {
+	s.stopPrice = &stopPrice
+	return s
+}
+
+// NewOrderRespType set newOrderRespType
+func (s *OrderPlaceWsRequest) NewOrderRespType(newOrderRespType NewOrderRespType) *OrderPlaceWsRequest {
+	s.newOrderRespType = &newOrderRespType
+	return s
+}
+
+// buildParams builds params
+func (s *OrderPlaceWsRequest) buildParams() params {
+	m := params{
+		"symbol": s.symbol,
+		"side":   s.side,
+		"type":   s.orderType,
+	}
+	if s.timeInForce != nil {
+		m["timeInForce"] = *s.timeInForce
+	}
+	if s.quantity != nil {
+		m["quantity"] = *s.quantity
+	}
+	if s.quoteOrderQuantity != nil {
+		m["quoteOrderQty"] = *s.quoteOrderQuantity
+	}
+	if s.price != nil {
+		m["price"] = *s.price
+	}
+	if s.newClientOrderID != nil {
+		m["newClientOrderId"] = *s.newClientOrderID
+	}
+	if s.stopPrice != nil {
+		m["stopPrice"] = *s.stopPrice
+	}
+	if s.newOrderRespType != nil {
+		m["newOrderRespType"] = *s.newOrderRespType
+	}
+	return m
+}
+
+// OrderPlaceWsResponse define 'order.place' websocket API response
+type OrderPlaceWsResponse struct {
+	Id     string               `json:"id"`
+	Status int                  `json:"status"`
+	Result *CreateOrderResponse `json:"result"`
+
+	// error response
+	Error *common.APIError `json:"error,omitempty"`
+}
+
+// OrderPlaceWsService creates order
+type OrderPlaceWsService struct {
+	c *ClientWs
+}
+
+// NewOrderPlaceWsService init OrderPlaceWsService sharing the client connection
+func (c *ClientWs) NewOrderPlaceWsService() *OrderPlaceWsService {
+	return &OrderPlaceWsService{c: c}
+}
+
+// Do - sends 'order.place' request
+func (s *OrderPlaceWsService) Do(ctx context.Context, req *OrderPlaceWsRequest) (*CreateOrderResponse, error) {
+	rawResp, err := s.c.doRequest(ctx, WsApiMethodOrderPlace, req.buildParams(), true)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := OrderPlaceWsResponse{}
+	if err := json.Unmarshal(rawResp, &resp); err != nil {
+		return nil, err
+	}
+
+	return resp.Result, nil
+}
@@ -0,0 +1,89 @@
+package binance
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+
+	"github.com/adshao/go-binance/v2/common"
+)
+
+type orderWsServiceTestSuite struct {
+	baseWsApiTestSuite
+}
+
+func TestOrderWsService(t *testing.T) {
+	suite.Run(t, new(orderWsServiceTestSuite))
+}
+
+func (s *orderWsServiceTestSuite) TestOrderTest() {
+	s.respond(WsApiMethodOrderTest, `{
+		"standardCommissionForOrder": {"maker": "0.00000112", "taker": "0.00000114"},
+		"taxCommissionForOrder": {"maker": "0.00000000", "taker": "0.00000000"},
+		"discount": {"enabledForAccount": true, "enabledForSymbol": true, "discountAsset": "BNB", "discount": "0.25000000"}
+	}`)
+
+	req := NewOrderTestWsRequest().Symbol("BTCUSDT").Side(SideTypeBuy).Type(OrderTypeLimit).
+		TimeInForce(TimeInForceTypeGTC).Quantity("0.1").Price("50000").ComputeCommissionRates(true)
+	res, err := s.wsClient.NewOrderTestWsService().Do(newContext(), req)
+	r := s.r()
+	r.NoError(err)
+	r.Equal("0.00000112", res.StandardCommissionForOrder.Maker)
+	r.Equal("BNB", res.Discount.DiscountAsset)
+	r.True(res.Discount.EnabledForSymbol)
+
+	p := s.lastRequest().Params
+	r.Equal("BTCUSDT", p["symbol"])
+	r.Equal("LIMIT", p["type"])
+	r.Equal("0.1", p["quantity"])
+	r.Equal("50000", p["price"])
+	r.Equal(true, p["computeCommissionRates"])
+	r.Equal(s.apiKey, p[apiKey])
+	r.NotEmpty(p[signatureKey])
+}
+
+func (s *orderWsServiceTestSuite) TestTestOrder() {
+	s.respond(WsApiMethodOrderTest, `{}`)
+	order := s.wsClient.NewOrderTestWsService().TestOrder(NewOrderTestWsRequest().Symbol("BTCUSDT").
+		Side(SideTypeSell).Type(OrderTypeMarket).Quantity("0.1"))
+	s.r().NoError(order.Test(newContext()))
+
+	s.mu.Lock()
+	delete(s.responses, WsApiMethodOrderTest)
+	s.mu.Unlock()
+	err := order.Test(newContext())
+	s.r().True(common.IsAPIErrorCode(err, -1102))
+}
+
+func (s *orderWsServiceTestSuite) TestOrderPlace() {
+	s.respond(WsApiMethodOrderPlace, `{
+		"symbol": "BTCUSDT",
+		"orderId": 12569099453,
+		"clientOrderId": "4d96324ff9d44481926157ec08158a40",
+		"transactTime": 1660801715639,
+		"price": "23416.10000000",
+		"origQty": "0.00847000",
+		"executedQty": "0.00000000",
+		"status": "EXPIRED",
+		"timeInForce": "IOC",
+		"type": "LIMIT",
+		"side": "BUY"
+	}`)
+
+	req := NewOrderPlaceWsRequest().Symbol("BTCUSDT").Side(SideTypeBuy).Type(OrderTypeLimit).
+		TimeInForce(TimeInForceTypeIOC).Quantity("0.00847").Price("23416.1").NewOrderRespType(NewOrderRespTypeRESULT)
+	res, err := s.wsClient.NewOrderPlaceWsService().Do(newContext(), req)
+	r := s.r()
+	r.NoError(err)
+	r.Equal(int64(12569099453), res.OrderID)
+	r.Equal(int64(1660801715639), res.TransactTime)
+	r.Equal(OrderStatusTypeExpired, res.Status)
+
+	p := s.lastRequest().Params
+	r.Equal("BTCUSDT", p["symbol"])
+	r.Equal("IOC", p["timeInForce"])
+	r.Equal("0.00847", p["quantity"])
+	r.Equal("23416.1", p["price"])
+	r.Equal("RESULT", p["newOrderRespType"])
+	r.NotEmpty(p[signatureKey])
+}
@@ -0,0 +1,123 @@
+package portfoliomargin
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+)
+
+type CancelUMOrderService struct {
+	c                 *Client
+	symbol            string
+	orderID           *int64
+	origClientOrderID *string
+}
+
+func (s *CancelUMOrderService) Symbol(symbol string) *CancelUMOrderService {
+	s.symbol = symbol
+	return s
+}
+
+func (s *CancelUMOrderService) OrderID(orderID int64) *CancelUMOrderService {
+	s.orderID = &orderID
+	return s
+}
+
+func (s *CancelUMOrderService) OrigClientOrderID(origClientOrderID string) *CancelUMOrderService {
+	s.origClientOrderID = &origClientOrderID
+	return s
+}
+
+// Do send request
+func (s *CancelUMOrderService) Do(ctx context.Context, opts ...RequestOption) (res *UMOrder, err error) {
+	r := &request{
+		method:   http.MethodDelete,
+		endpoint: "/papi/v1/um/order",
+		secType:  secTypeSigned,
+	}
+	r.setFormParam("symbol", s.symbol)
+	if s.orderID != nil {
+		r.setFormParam("orderId", *s.orderID)
+	}
+	if s.origClientOrderID != nil {
+		r.setFormParam("origClientOrderId", *s.origClientOrderID)
+	}
+	data, _, err := s.c.callAPI(ctx, r, opts...)
+	if err != nil {
+		return nil, err
+	}
+	res = new(UMOrder)
+	err = json.Unmarshal(data, res)
+	if err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+type CancelCMOrderService struct {
+	c                 *Client
+	symbol            string
+	orderID           *int64
+	origClientOrderID *string
+}
+
+func (s *CancelCMOrderService) Symbol(symbol string) *CancelCMOrderService {
+	s.symbol = symbol
+	return s
+}
+
+func (s *CancelCMOrderService) OrderID(orderID int64) *CancelCMOrderService {
+	s.orderID = &orderID
+	return s
+}
+
+func (s *CancelCMOrderService) OrigClientOrderID(origClientOrderID string) *CancelCMOrderService {
+	s.origClientOrderID = &origClientOrderID
+	return s
+}
+
+// Do send request
+func (s *CancelCMOrderService) Do(ctx context.Context, opts ...RequestOption) (res *CMOrder, err error) {
+	r := &request{
+		method:   http.MethodDelete,
+		endpoint: "/papi/v1/cm/order",
+		secType:  secTypeSigned,
+	}
+	r.setFormParam("symbol", s.symbol)
+	if s.orderID != nil {
+		r.setFormParam("orderId", *s.orderID)
+	}
+	if s.origClientOrderID != nil {
+		r.setFormParam("origClientOrderId", *s.origClientOrderID)
+	}
+	data, _, err := s.c.callAPI(ctx, r, opts...)
+	if err != nil {
+		return nil, err
+	}
+	res = new(CMOrder)
+	err = json.Unmarshal(data, res)
+	if err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+type CMOrder struct {
+	ClientOrderID string           `json:"clientOrderId"`
+	CumQty        string           `json:"cumQty"`
+	CumBase       string           `json:"cumBase"`
+	ExecutedQty   string           `json:"executedQty"`
+	OrderID       int64            `json:"orderId"`
+	AvgPrice      string           `json:"avgPrice"`
+	OrigQty       string           `json:"origQty"`
+	Price         string           `json:"price"`
+	ReduceOnly    bool             `json:"reduceOnly"`
+	Side          SideType         `json:"side"`
+	PositionSide  PositionSideType `json:"positionSide"`
+	Status        OrderStatusType  `json:"status"`
+	Symbol        string           `json:"symbol"`
+	Pair          string           `json:"pair"`
+	TimeInForce   TimeInForceType  `json:"timeInForce"`
+	Type          OrderType        `json:"type"`
+	UpdateTime    int64            `json:"updateTime"`
+}
@@ -1,5 +1,15 @@
 package portfoliomargin
 
+// NewCancelCMOrderService cancel coin margin order
+func (c *Client) NewCancelCMOrderService() *CancelCMOrderService {
+	return &CancelCMOrderService{c: c}
+}
+
+// NewCancelUMOrderService cancel usd margin order
+func (c *Client) NewCancelUMOrderService() *CancelUMOrderService {
+	return &CancelUMOrderService{c: c}
+}
+
 // NewChangeUMInitialLeverageService
 func (c *Client) NewChangeUMInitialLeverageService() *ChangeUMInitialLeverageService {
 	return &ChangeUMInitialLeverageService{c: c}
@@ -40,6 +50,11 @@ func (c *Client) NewGetAllUMOrdersService() *GetAllUMOrdersService {
 	return &GetAllUMOrdersService{c: c}
 }
 
+// NewGetCMPositionRiskService
+func (c *Client) NewGetCMPositionRiskService() *GetCMPositionRiskService {
+	return &GetCMPositionRiskService{c: c}
+}
+
 // NewGetNegativeBalanceInterestHistoryService
 func (c *Client) NewGetNegativeBalanceInterestHistoryService() *GetNegativeBalanceInterestHistoryService {
 	return &GetNegativeBalanceInterestHistoryService{c: c}
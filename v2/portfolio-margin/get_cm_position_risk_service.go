@@ -0,0 +1,63 @@
+package portfoliomargin
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+)
+
+type GetCMPositionRiskService struct {
+	c           *Client
+	marginAsset *string
+	pair        *string
+}
+
+func (s *GetCMPositionRiskService) MarginAsset(marginAsset string) *GetCMPositionRiskService {
+	s.marginAsset = &marginAsset
+	return s
+}
+
+func (s *GetCMPositionRiskService) Pair(pair string) *GetCMPositionRiskService {
+	s.pair = &pair
+	return s
+}
+
+// Do sends the request to get the coin margined positions
+func (s *GetCMPositionRiskService) Do(ctx context.Context, opts ...RequestOption) (res []CMPositionRisk, err error) {
+	r := &request{
+		method:   http.MethodGet,
+		endpoint: "/papi/v1/cm/positionRisk",
+		secType:  secTypeSigned,
+	}
+	if s.marginAsset != nil {
+		r.setParam("marginAsset", *s.marginAsset)
+	}
+	if s.pair != nil {
+		r.setParam("pair", *s.pair)
+	}
+
+	data, _, err := s.c.callAPI(ctx, r, opts...)
+	if err != nil {
+		return nil, err
+	}
+	res = make([]CMPositionRisk, 0)
+	err = json.Unmarshal(data, &res)
+	if err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+type CMPositionRisk struct {
+	Symbol           string `json:"symbol"`
+	PositionAmt      string `json:"positionAmt"`
+	EntryPrice       string `json:"entryPrice"`
+	MarkPrice        string `json:"markPrice"`
+	UnRealizedProfit string `json:"unRealizedProfit"`
+	LiquidationPrice string `json:"liquidationPrice"`
+	Leverage         string `json:"leverage"`
+	PositionSide     string `json:"positionSide"`
+	UpdateTime       int64  `json:"updateTime"`
+	MaxQty           string `json:"maxQty"`
+	NotionalValue    string `json:"notionalValue"`
+}
@@ -31,6 +31,17 @@ func (s *serverServiceTestSuite) TestPing() {
 	s.r().NoError(err)
 }
 
+func (s *serverServiceTestSuite) TestHealthy() {
+	s.mockDo([]byte(`{}`), nil)
+	defer s.assertDo()
+
+	r := s.r()
+	r.NoError(s.client.Healthy(newContext()))
+
+	s.client.TimeOffset = common.MaxTimeOffset + 1
+	r.ErrorIs(s.client.Healthy(newContext()), common.ErrTimeOffsetTooLarge)
+}
+
 func (s *serverServiceTestSuite) TestServerTime() {
 	data := []byte(`{
         "serverTime": 1499827319559
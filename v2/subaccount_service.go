@@ -561,3 +561,112 @@ type SubAccountFuturesAccountAsset struct {
 	UnrealizedProfit       string `json:"unrealizedProfit"`
 	WalletBalance          string `json:"walletBalance"`
 }
+
+// SubAccountFuturesPositionRiskService Get Futures Position-Risk of Sub-account (For Master Account)
+// https://binance-docs.github.io/apidocs/spot/en/#get-futures-position-risk-of-sub-account-for-master-account
+type SubAccountFuturesPositionRiskService struct {
+	c     *Client
+	email string
+}
+
+func (s *SubAccountFuturesPositionRiskService) Email(v string) *SubAccountFuturesPositionRiskService {
+	s.email = v
+	return s
+}
+
+func (s *SubAccountFuturesPositionRiskService) Do(ctx context.Context, opts ...RequestOption) (res []*SubAccountFuturesPositionRisk, err error) {
+	r := &request{
+		method:   "GET",
+		endpoint: "/sapi/v1/sub-account/futures/positionRisk",
+		secType:  secTypeSigned,
+	}
+	r.setParam("email", s.email)
+	data, err := s.c.callAPI(ctx, r, opts...)
+	if err != nil {
+		return nil, err
+	}
+	res = make([]*SubAccountFuturesPositionRisk, 0)
+	err = json.Unmarshal(data, &res)
+	if err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+type SubAccountFuturesPositionRisk struct {
+	EntryPrice       string `json:"entryPrice"`
+	Leverage         string `json:"leverage"`
+	MaxNotional      string `json:"maxNotional"`
+	LiquidationPrice string `json:"liquidationPrice"`
+	MarkPrice        string `json:"markPrice"`
+	PositionAmount   string `json:"positionAmount"`
+	Symbol           string `json:"symbol"`
+	UnrealizedProfit string `json:"unrealizedProfit"`
+}
+
+// SubAccountFuturesTransferService Futures Transfer between Sub-accounts (For Master Account)
+// https://binance-docs.github.io/apidocs/spot/en/#sub-account-futures-asset-transfer-for-master-account
+type SubAccountFuturesTransferService struct {
+	c           *Client
+	fromEmail   string
+	toEmail     string
+	futuresType int
+	asset       string
+	amount      string
+}
+
+func (s *SubAccountFuturesTransferService) FromEmail(v string) *SubAccountFuturesTransferService {
+	s.fromEmail = v
+	return s
+}
+
+func (s *SubAccountFuturesTransferService) ToEmail(v string) *SubAccountFuturesTransferService {
+	s.toEmail = v
+	return s
+}
+
+// FuturesType 1:USDT-margined Futures, 2: Coin-margined Futures
+func (s *SubAccountFuturesTransferService) FuturesType(v int) *SubAccountFuturesTransferService {
+	s.futuresType = v
+	return s
+}
+
+func (s *SubAccountFuturesTransferService) Asset(v string) *SubAccountFuturesTransferService {
+	s.asset = v
+	return s
+}
+
+func (s *SubAccountFuturesTransferService) Amount(v string) *SubAccountFuturesTransferService {
+	s.amount = v
+	return s
+}
+
+func (s *SubAccountFuturesTransferService) Do(ctx context.Context, opts ...RequestOption) (res *SubAccountFuturesTransferResponse, err error) {
+	r := &request{
+		method:   "POST",
+		endpoint: "/sapi/v1/sub-account/futures/internalTransfer",
+		secType:  secTypeSigned,
+	}
+	r.setParams(params{
+		"fromEmail":   s.fromEmail,
+		"toEmail":     s.toEmail,
+		"futuresType": s.futuresType,
+		"asset":       s.asset,
+		"amount":      s.amount,
+	})
+	data, err := s.c.callAPI(ctx, r, opts...)
+	if err != nil {
+		return nil, err
+	}
+	res = new(SubAccountFuturesTransferResponse)
+	err = json.Unmarshal(data, res)
+	if err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+type SubAccountFuturesTransferResponse struct {
+	Success bool   `json:"success"`
+	TxnID   string `json:"txnId"`
+}
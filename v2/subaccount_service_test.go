@@ -301,3 +301,66 @@ func (s *subAccountServiceTestSuite) assertAccountFuturesAssetsEqual(e, a *SubAc
 	r.Equal(e.UnrealizedProfit, a.UnrealizedProfit, "UnrealizedProfit")
 	r.Equal(e.WalletBalance, a.WalletBalance, "WalletBalance")
 }
+
+func (s *subAccountServiceTestSuite) TestSubAccountFuturesPositionRiskService() {
+	data := []byte(`[
+		{
+			"entryPrice": "9975.12000",
+			"leverage": "50",
+			"maxNotional": "1000000",
+			"liquidationPrice": "7963.54",
+			"markPrice": "9973.50770517",
+			"positionAmount": "0.010",
+			"symbol": "BTCUSDT",
+			"unrealizedProfit": "-0.01612295"
+		}
+	]`)
+	s.mockDo(data, nil)
+	defer s.assertDo()
+
+	email := "abc@test.com"
+	s.assertReq(func(r *request) {
+		e := newSignedRequest().setParams(params{
+			"email": email,
+		})
+		s.assertRequestEqual(e, r)
+	})
+
+	res, err := s.client.NewSubAccountFuturesPositionRiskService().Email(email).Do(newContext())
+	r := s.r()
+	r.NoError(err)
+	r.Equal([]*SubAccountFuturesPositionRisk{{
+		EntryPrice:       "9975.12000",
+		Leverage:         "50",
+		MaxNotional:      "1000000",
+		LiquidationPrice: "7963.54",
+		MarkPrice:        "9973.50770517",
+		PositionAmount:   "0.010",
+		Symbol:           "BTCUSDT",
+		UnrealizedProfit: "-0.01612295",
+	}}, res)
+}
+
+func (s *subAccountServiceTestSuite) TestSubAccountFuturesTransferService() {
+	data := []byte(`{"success": true, "txnId": "2934662589"}`)
+	s.mockDo(data, nil)
+	defer s.assertDo()
+
+	s.assertReq(func(r *request) {
+		e := newSignedRequest().setParams(params{
+			"fromEmail":   "a@test.com",
+			"toEmail":     "b@test.com",
+			"futuresType": 1,
+			"asset":       "USDT",
+			"amount":      "100",
+		})
+		s.assertRequestEqual(e, r)
+	})
+
+	res, err := s.client.NewSubAccountFuturesTransferService().FromEmail("a@test.com").ToEmail("b@test.com").
+		FuturesType(1).Asset("USDT").Amount("100").Do(newContext())
+	r := s.r()
+	r.NoError(err)
+	r.True(res.Success)
+	r.Equal("2934662589", res.TxnID)
+}
@@ -0,0 +1,199 @@
+package binance
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/adshao/go-binance/v2/futures"
+)
+
+// Universal transfer types between the spot and USDⓈ-M futures wallets
+const (
+	UniversalTransferTypeMainUMFuture = "MAIN_UMFUTURE"
+	UniversalTransferTypeUMFutureMain = "UMFUTURE_MAIN"
+)
+
+const (
+	defaultTransferPollInterval = 500 * time.Millisecond
+	defaultTransferTimeout      = 30 * time.Second
+	// transferBalanceEpsilon absorbs float rounding when comparing balances
+	transferBalanceEpsilon = 1e-8
+)
+
+var ErrUnsupportedTransferType = errors.New("transfer and trade: unsupported transfer type")
+
+// TransferStage define stage of a TransferAndTradeService
+type TransferStage string
+
+// Stages of a TransferAndTradeService, in order
+const (
+	TransferStageTransfer TransferStage = "TRANSFER"
+	TransferStageBalance  TransferStage = "BALANCE"
+	TransferStageOrder    TransferStage = "ORDER"
+)
+
+// TransferAndTradeError define error of a TransferAndTradeService and the stage it failed at.
+// TranID is set once the transfer was accepted, the funds may then sit in the target wallet.
+type TransferAndTradeError struct {
+	Stage  TransferStage
+	TranID int64
+	Err    error
+}
+
+func (e *TransferAndTradeError) Error() string {
+	return fmt.Sprintf("transfer and trade: %s: %v", e.Stage, e.Err)
+}
+
+func (e *TransferAndTradeError) Unwrap() error {
+	return e.Err
+}
+
+// TransferAndTradeService moves an asset between the spot and USDⓈ-M futures wallets, waits until
+// the balance of the target wallet reflects the transfer, then places an order funded by it, e.g.
+// moves USDT to the futures wallet then places a perp order. Any failure is returned as a
+// *TransferAndTradeError.
+type TransferAndTradeService struct {
+	c            *Client
+	fc           *futures.Client
+	transferType string
+	asset        string
+	amount       float64
+	order        OrderLeg
+	pollInterval time.Duration
+	timeout      time.Duration
+}
+
+// NewTransferAndTradeService init TransferAndTradeService transferring with c and reading futures
+// balances with fc
+func NewTransferAndTradeService(c *Client, fc *futures.Client) *TransferAndTradeService {
+	return &TransferAndTradeService{
+		c:            c,
+		fc:           fc,
+		pollInterval: defaultTransferPollInterval,
+		timeout:      defaultTransferTimeout,
+	}
+}
+
+// Type set transfer type, UniversalTransferTypeMainUMFuture or UniversalTransferTypeUMFutureMain
+func (s *TransferAndTradeService) Type(transferType string) *TransferAndTradeService {
+	s.transferType = transferType
+	return s
+}
+
+// Asset set asset
+func (s *TransferAndTradeService) Asset(asset string) *TransferAndTradeService {
+	s.asset = asset
+	return s
+}
+
+// Amount set amount
+func (s *TransferAndTradeService) Amount(amount float64) *TransferAndTradeService {
+	s.amount = amount
+	return s
+}
+
+// Order set order placed once the transfer landed, e.g. a futures.OrderLeg or a SpotOrderLeg
+func (s *TransferAndTradeService) Order(order OrderLeg) *TransferAndTradeService {
+	s.order = order
+	return s
+}
+
+// PollInterval set interval between balance reads, default 500ms
+func (s *TransferAndTradeService) PollInterval(interval time.Duration) *TransferAndTradeService {
+	s.pollInterval = interval
+	return s
+}
+
+// Timeout set how long to wait for the transfer to land, default 30s
+func (s *TransferAndTradeService) Timeout(timeout time.Duration) *TransferAndTradeService {
+	s.timeout = timeout
+	return s
+}
+
+// Do sends the transfer then the order
+func (s *TransferAndTradeService) Do(ctx context.Context) (*CreateUserUniversalTransferResponse, error) {
+	var balance func(ctx context.Context) (float64, error)
+	switch s.transferType {
+	case UniversalTransferTypeMainUMFuture:
+		balance = s.futuresBalance
+	case UniversalTransferTypeUMFutureMain:
+		balance = s.spotBalance
+	default:
+		return nil, &TransferAndTradeError{Stage: TransferStageTransfer, Err: ErrUnsupportedTransferType}
+	}
+
+	before, err := balance(ctx)
+	if err != nil {
+		return nil, &TransferAndTradeError{Stage: TransferStageTransfer, Err: err}
+	}
+	res, err := s.c.NewUserUniversalTransferService().Type(s.transferType).Asset(s.asset).Amount(s.amount).Do(ctx)
+	if err != nil {
+		return nil, &TransferAndTradeError{Stage: TransferStageTransfer, Err: err}
+	}
+
+	if err = s.waitBalance(ctx, balance, before+s.amount-transferBalanceEpsilon); err != nil {
+		return res, &TransferAndTradeError{Stage: TransferStageBalance, TranID: res.ID, Err: err}
+	}
+	if s.order == nil {
+		return res, nil
+	}
+	if err = s.order.Place(ctx); err != nil {
+		return res, &TransferAndTradeError{Stage: TransferStageOrder, TranID: res.ID, Err: err}
+	}
+	return res, nil
+}
+
+// waitBalance polls balance until it reaches target
+func (s *TransferAndTradeService) waitBalance(ctx context.Context, balance func(ctx context.Context) (float64, error), target float64) error {
+	ctx, cancel := context.WithTimeout(ctx, s.timeout)
+	defer cancel()
+	ticker := time.NewTicker(s.pollInterval)
+	defer ticker.Stop()
+	var lastErr error
+	for {
+		select {
+		case <-ctx.Done():
+			if lastErr != nil {
+				return lastErr
+			}
+			return ctx.Err()
+		case <-ticker.C:
+		}
+		v, err := balance(ctx)
+		lastErr = err
+		if err == nil && v >= target {
+			return nil
+		}
+	}
+}
+
+// futuresBalance returns wallet balance of asset in the futures wallet
+func (s *TransferAndTradeService) futuresBalance(ctx context.Context) (float64, error) {
+	balances, err := s.fc.NewGetBalanceService().Do(ctx)
+	if err != nil {
+		return 0, err
+	}
+	for _, b := range balances {
+		if b.Asset == s.asset {
+			return strconv.ParseFloat(b.Balance, 64)
+		}
+	}
+	return 0, nil
+}
+
+// spotBalance returns free balance of asset in the spot wallet
+func (s *TransferAndTradeService) spotBalance(ctx context.Context) (float64, error) {
+	account, err := s.c.NewGetAccountService().Do(ctx)
+	if err != nil {
+		return 0, err
+	}
+	for _, b := range account.Balances {
+		if b.Asset == s.asset {
+			return strconv.ParseFloat(b.Free, 64)
+		}
+	}
+	return 0, nil
+}
@@ -0,0 +1,79 @@
+package binance
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/adshao/go-binance/v2/futures"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTransferAndTradeService(t *testing.T) {
+	assert := assert.New(t)
+
+	var (
+		transfer     url.Values
+		balanceReads int
+		landed       = 2
+	)
+	do := func(req *http.Request) (*http.Response, error) {
+		body, _ := io.ReadAll(req.Body)
+		switch req.URL.Path {
+		case "/sapi/v1/asset/transfer":
+			transfer, _ = url.ParseQuery(string(body))
+			for k, v := range req.URL.Query() {
+				transfer[k] = v
+			}
+			return newHTTPResponse([]byte(`{"tranId":13526853623}`), http.StatusOK), nil
+		case "/fapi/v2/balance":
+			balanceReads++
+			balance := "10"
+			if balanceReads > landed {
+				balance = "110"
+			}
+			return newHTTPResponse([]byte(`[{"asset":"BNB","balance":"5"},{"asset":"USDT","balance":"`+balance+`"}]`), http.StatusOK), nil
+		}
+		return newHTTPResponse([]byte(`{}`), http.StatusNotFound), nil
+	}
+	c := NewClient("apiKey", "secretKey")
+	c.do = do
+	fc := futures.NewClient("apiKey", "secretKey")
+	fc.HTTPClient = &http.Client{Transport: roundTripFunc(do)}
+
+	order := &testOrderLeg{}
+	newService := func() *TransferAndTradeService {
+		return NewTransferAndTradeService(c, fc).Type(UniversalTransferTypeMainUMFuture).Asset("USDT").Amount(100).
+			Order(order).PollInterval(time.Millisecond)
+	}
+	res, err := newService().Do(newContext())
+	assert.NoError(err)
+	assert.Equal(int64(13526853623), res.ID)
+	assert.Equal("MAIN_UMFUTURE", transfer.Get("type"))
+	assert.Equal("100", transfer.Get("amount"))
+	assert.Equal(3, balanceReads, "order placed once the transfer landed")
+
+	balanceReads, landed = 0, 1000
+	_, err = newService().Timeout(20 * time.Millisecond).Do(newContext())
+	var transferErr *TransferAndTradeError
+	if assert.ErrorAs(err, &transferErr) {
+		assert.Equal(TransferStageBalance, transferErr.Stage)
+		assert.Equal(int64(13526853623), transferErr.TranID)
+	}
+	assert.ErrorIs(err, context.DeadlineExceeded)
+
+	placeErr := errors.New("insufficient margin")
+	balanceReads, landed, order.placeErr = 0, 1, placeErr
+	_, err = newService().Do(newContext())
+	if assert.ErrorAs(err, &transferErr) {
+		assert.Equal(TransferStageOrder, transferErr.Stage)
+	}
+	assert.ErrorIs(err, placeErr)
+
+	_, err = newService().Type("MAIN_MARGIN").Do(newContext())
+	assert.ErrorIs(err, ErrUnsupportedTransferType)
+}
@@ -0,0 +1,175 @@
+package binance
+
+import (
+	"context"
+	stdjson "encoding/json"
+	"time"
+
+	"github.com/adshao/go-binance/v2/common"
+)
+
+const (
+	WsApiMethodUserDataStreamSubscribe   WsApiMethodType = "userDataStream.subscribe.signature"
+	WsApiMethodUserDataStreamUnsubscribe WsApiMethodType = "userDataStream.unsubscribe"
+
+	// userDataResubscribeTimeout bounds resubscribing to user data stream after reconnect
+	userDataResubscribeTimeout = 10 * time.Second
+)
+
+// userDataSubscription state of user data stream subscribed on websocket API connection
+type userDataSubscription struct {
+	id         int64
+	handler    WsUserDataHandler
+	errHandler ErrHandler
+}
+
+// UserDataStreamSubscribeWsResponse define 'userDataStream.subscribe.signature' websocket API response
+type UserDataStreamSubscribeWsResponse struct {
+	Id     string                          `json:"id"`
+	Status int                             `json:"status"`
+	Result UserDataStreamSubscribeWsResult `json:"result"`
+
+	// error response
+	Error *common.APIError `json:"error,omitempty"`
+}
+
+// UserDataStreamSubscribeWsResult define result of 'userDataStream.subscribe.signature'
+type UserDataStreamSubscribeWsResult struct {
+	SubscriptionID int64 `json:"subscriptionId"`
+}
+
+// UserDataStreamSubscribeWsService subscribes to account and order events of the client API key
+// on the websocket API connection, events are pushed alongside request responses
+type UserDataStreamSubscribeWsService struct {
+	c *ClientWs
+}
+
+// NewUserDataStreamSubscribeWsService init UserDataStreamSubscribeWsService sharing the client connection
+func (c *ClientWs) NewUserDataStreamSubscribeWsService() *UserDataStreamSubscribeWsService {
+	return &UserDataStreamSubscribeWsService{c: c}
+}
+
+// Do - sends 'userDataStream.subscribe.signature' request and routes pushed events to handler.
+// Errors parsing events, and failures to subscribe again after reconnect, go to errHandler.
+// Returns subscription id.
+func (s *UserDataStreamSubscribeWsService) Do(ctx context.Context, handler WsUserDataHandler, errHandler ErrHandler) (int64, error) {
+	// register handler first, events may arrive right after the response
+	sub := &userDataSubscription{handler: handler, errHandler: errHandler}
+	s.c.setUserDataSubscription(sub)
+
+	id, err := s.c.subscribeUserDataStream(ctx, sub)
+	if err != nil {
+		s.c.setUserDataSubscription(nil)
+		return 0, err
+	}
+	return id, nil
+}
+
+// UserDataStreamUnsubscribeWsResponse define 'userDataStream.unsubscribe' websocket API response
+type UserDataStreamUnsubscribeWsResponse struct {
+	Id     string             `json:"id"`
+	Status int                `json:"status"`
+	Result stdjson.RawMessage `json:"result"`
+
+	// error response
+	Error *common.APIError `json:"error,omitempty"`
+}
+
+// UserDataStreamUnsubscribeWsService stops user data stream started by UserDataStreamSubscribeWsService
+type UserDataStreamUnsubscribeWsService struct {
+	c *ClientWs
+}
+
+// NewUserDataStreamUnsubscribeWsService init UserDataStreamUnsubscribeWsService sharing the client connection
+func (c *ClientWs) NewUserDataStreamUnsubscribeWsService() *UserDataStreamUnsubscribeWsService {
+	return &UserDataStreamUnsubscribeWsService{c: c}
+}
+
+// Do - sends 'userDataStream.unsubscribe' request, events are not routed to handler afterwards
+func (s *UserDataStreamUnsubscribeWsService) Do(ctx context.Context) error {
+	m := params{}
+	if sub := s.c.getUserDataSubscription(); sub != nil {
+		m["subscriptionId"] = sub.id
+	}
+	s.c.setUserDataSubscription(nil)
+
+	_, err := s.c.doRequest(ctx, WsApiMethodUserDataStreamUnsubscribe, m, false)
+	return err
+}
+
+func (c *ClientWs) setUserDataSubscription(sub *userDataSubscription) {
+	c.userDataMu.Lock()
+	defer c.userDataMu.Unlock()
+
+	c.userData = sub
+}
+
+func (c *ClientWs) getUserDataSubscription() *userDataSubscription {
+	c.userDataMu.Lock()
+	defer c.userDataMu.Unlock()
+
+	return c.userData
+}
+
+// subscribeUserDataStream sends subscribe request and stores subscription id into sub
+func (c *ClientWs) subscribeUserDataStream(ctx context.Context, sub *userDataSubscription) (int64, error) {
+	rawResp, err := c.doRequest(ctx, WsApiMethodUserDataStreamSubscribe, params{}, true)
+	if err != nil {
+		return 0, err
+	}
+
+	resp := UserDataStreamSubscribeWsResponse{}
+	if err := json.Unmarshal(rawResp, &resp); err != nil {
+		return 0, err
+	}
+
+	c.userDataMu.Lock()
+	sub.id = resp.Result.SubscriptionID
+	c.userDataMu.Unlock()
+	return resp.Result.SubscriptionID, nil
+}
+
+// resubscribeUserDataStream subscribes again after reconnect since subscriptions don't outlive the connection
+func (c *ClientWs) resubscribeUserDataStream() {
+	sub := c.getUserDataSubscription()
+	if sub == nil {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), userDataResubscribeTimeout)
+	defer cancel()
+
+	if _, err := c.subscribeUserDataStream(ctx, sub); err != nil {
+		c.debug("user data: unable to subscribe after reconnect '%v'", err)
+		if sub.errHandler != nil {
+			sub.errHandler(err)
+		}
+	}
+}
+
+// handleUserDataEvent passes user data event message to the subscription handler,
+// returns false if message is not a user data event or there is no subscription
+func (c *ClientWs) handleUserDataEvent(message []byte) bool {
+	msg := struct {
+		SubscriptionID int64              `json:"subscriptionId"`
+		Event          stdjson.RawMessage `json:"event"`
+	}{}
+	if err := json.Unmarshal(message, &msg); err != nil || len(msg.Event) == 0 {
+		return false
+	}
+
+	sub := c.getUserDataSubscription()
+	if sub == nil {
+		return false
+	}
+
+	event, err := parseUserDataEvent(msg.Event)
+	if err != nil {
+		if sub.errHandler != nil {
+			sub.errHandler(err)
+		}
+		return true
+	}
+	sub.handler(event)
+	return true
+}
@@ -0,0 +1,81 @@
+package binance
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type userStreamWsServiceTestSuite struct {
+	baseWsApiTestSuite
+}
+
+func TestUserStreamWsService(t *testing.T) {
+	suite.Run(t, new(userStreamWsServiceTestSuite))
+}
+
+func (s *userStreamWsServiceTestSuite) TestSubscribe() {
+	s.respond(WsApiMethodUserDataStreamSubscribe, `{"subscriptionId": 0}`)
+	s.pushAfter(WsApiMethodUserDataStreamSubscribe,
+		`{"subscriptionId":0,"event":{"e":"outboundAccountPosition","E":1728972148778,"u":1728972148778,"B":[{"a":"BTC","f":"11818.00000000","l":"182.00000000"}]}}`,
+		`{"subscriptionId":0,"event":{"e":"executionReport","E":1499405658658,"s":"ETHBTC","c":"mUvoqJxFIILMdfAW5iGSOW","S":"BUY","o":"LIMIT","f":"GTC","q":"1.00000000","p":"0.10264410","x":"NEW","X":"NEW","i":4293153,"T":1499405658657,"t":-1}}`,
+	)
+
+	events := make(chan *WsUserDataEvent, 2)
+	id, err := s.wsClient.NewUserDataStreamSubscribeWsService().Do(newContext(), func(event *WsUserDataEvent) {
+		events <- event
+	}, func(err error) {
+		s.T().Error(err)
+	})
+	r := s.r()
+	r.NoError(err)
+	r.Equal(int64(0), id)
+
+	p := s.lastRequest().Params
+	r.Equal(s.apiKey, p[apiKey])
+	r.NotEmpty(p[signatureKey])
+
+	event := s.nextEvent(events)
+	r.Equal(UserDataEventTypeOutboundAccountPosition, event.Event)
+	r.Equal([]WsAccountUpdate{{Asset: "BTC", Free: "11818.00000000", Locked: "182.00000000"}}, event.AccountUpdate.WsAccountUpdates)
+
+	event = s.nextEvent(events)
+	r.Equal(UserDataEventTypeExecutionReport, event.Event)
+	r.Equal("ETHBTC", event.OrderUpdate.Symbol)
+	r.Equal(int64(4293153), event.OrderUpdate.Id)
+	r.Equal(int64(1499405658657), event.OrderUpdate.TransactionTime)
+}
+
+func (s *userStreamWsServiceTestSuite) TestUnsubscribe() {
+	s.respond(WsApiMethodUserDataStreamSubscribe, `{"subscriptionId": 3}`)
+	s.respond(WsApiMethodUserDataStreamUnsubscribe, `{}`)
+
+	_, err := s.wsClient.NewUserDataStreamSubscribeWsService().Do(newContext(), func(event *WsUserDataEvent) {}, nil)
+	r := s.r()
+	r.NoError(err)
+
+	err = s.wsClient.NewUserDataStreamUnsubscribeWsService().Do(newContext())
+	r.NoError(err)
+	p := s.lastRequest().Params
+	r.Equal(float64(3), p["subscriptionId"])
+	r.NotContains(p, signatureKey)
+	r.Nil(s.wsClient.getUserDataSubscription())
+}
+
+func (s *userStreamWsServiceTestSuite) TestSubscribeError() {
+	_, err := s.wsClient.NewUserDataStreamSubscribeWsService().Do(newContext(), func(event *WsUserDataEvent) {}, nil)
+	r := s.r()
+	r.Error(err)
+	r.Nil(s.wsClient.getUserDataSubscription())
+}
+
+func (s *userStreamWsServiceTestSuite) nextEvent(events chan *WsUserDataEvent) *WsUserDataEvent {
+	select {
+	case event := <-events:
+		return event
+	case <-time.After(time.Second):
+		s.r().FailNow("user data event was not delivered")
+		return nil
+	}
+}
@@ -0,0 +1,293 @@
+// Package vision downloads and parses the public market data archives
+// published on https://data.binance.vision for spot and futures markets.
+package vision
+
+import (
+	"bufio"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// MarketType define market of an archive
+type MarketType string
+
+// PeriodType define period covered by an archive
+type PeriodType string
+
+// DataType define content of an archive
+type DataType string
+
+// Endpoints
+const (
+	baseMainURL = "https://data.binance.vision"
+)
+
+// Global enums
+const (
+	MarketTypeSpot    MarketType = "spot"
+	MarketTypeFutures MarketType = "futures/um"
+	MarketTypeCoin    MarketType = "futures/cm"
+
+	PeriodTypeDaily   PeriodType = "daily"
+	PeriodTypeMonthly PeriodType = "monthly"
+
+	DataTypeKlines      DataType = "klines"
+	DataTypeTrades      DataType = "trades"
+	DataTypeAggTrades   DataType = "aggTrades"
+	DataTypeFundingRate DataType = "fundingRate"
+)
+
+// ErrArchiveNotFound is returned when the archive is not published (yet)
+var ErrArchiveNotFound = errors.New("vision: archive not found")
+
+// ErrChecksumMismatch is returned when downloaded archive doesn't match its published checksum
+var ErrChecksumMismatch = errors.New("vision: checksum mismatch")
+
+// Archive define a single archive file
+type Archive struct {
+	Market   MarketType
+	Period   PeriodType
+	Data     DataType
+	Symbol   string
+	Interval string // klines only
+	Date     time.Time
+}
+
+// FileName returns archive file name like BTCUSDT-1m-2024-01-02.zip
+func (a Archive) FileName() string {
+	date := a.Date.UTC().Format("2006-01-02")
+	if a.Period == PeriodTypeMonthly {
+		date = a.Date.UTC().Format("2006-01")
+	}
+	kind := string(a.Data)
+	if a.Data == DataTypeKlines {
+		kind = a.Interval
+	}
+	return fmt.Sprintf("%s-%s-%s.zip", a.Symbol, kind, date)
+}
+
+// Path returns archive path relative to the data root
+func (a Archive) Path() string {
+	parts := []string{"data", string(a.Market), string(a.Period), string(a.Data), a.Symbol}
+	if a.Data == DataTypeKlines {
+		parts = append(parts, a.Interval)
+	}
+	parts = append(parts, a.FileName())
+	return path.Join(parts...)
+}
+
+// Client define data.binance.vision client storing archives under Dir
+// with the same layout as the remote
+type Client struct {
+	BaseURL    string
+	Dir        string
+	HTTPClient *http.Client
+	Debug      bool
+	Logger     *log.Logger
+}
+
+// NewClient init Client storing archives under dir
+func NewClient(dir string) *Client {
+	return &Client{
+		BaseURL:    baseMainURL,
+		Dir:        dir,
+		HTTPClient: http.DefaultClient,
+		Logger:     log.New(os.Stderr, "Binance-golang ", log.LstdFlags),
+	}
+}
+
+func (c *Client) debug(format string, v ...interface{}) {
+	if c.Debug {
+		c.Logger.Printf(format, v...)
+	}
+}
+
+// LocalPath returns local path of archive
+func (c *Client) LocalPath(a Archive) string {
+	return filepath.Join(c.Dir, filepath.FromSlash(a.Path()))
+}
+
+// Download downloads archive into the local directory and verifies its checksum.
+// Archive already downloaded and verified is not downloaded again.
+func (c *Client) Download(ctx context.Context, a Archive) (string, error) {
+	localPath := c.LocalPath(a)
+	checksumPath := localPath + ".CHECKSUM"
+
+	if expected, err := readChecksumFile(checksumPath); err == nil {
+		if actual, err := fileChecksum(localPath); err == nil && actual == expected {
+			c.debug("vision: %s is up to date", localPath)
+			return localPath, nil
+		}
+	}
+
+	checksum, err := c.get(ctx, a.Path()+".CHECKSUM")
+	if err != nil {
+		return "", err
+	}
+	expected, err := parseChecksum(checksum)
+	if err != nil {
+		return "", err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(localPath), 0o755); err != nil {
+		return "", err
+	}
+	if err := c.download(ctx, a.Path(), localPath, expected); err != nil {
+		return "", err
+	}
+	if err := writeFileAtomic(checksumPath, checksum); err != nil {
+		return "", err
+	}
+	c.debug("vision: downloaded %s", localPath)
+	return localPath, nil
+}
+
+// Sync downloads every archive of the template from start to end date inclusive, one per day or month
+// depending on the period. Archives not published yet are skipped. Returns local paths of all synced archives.
+func (c *Client) Sync(ctx context.Context, template Archive, start, end time.Time) ([]string, error) {
+	var paths []string
+	for _, date := range archiveDates(template.Period, start, end) {
+		a := template
+		a.Date = date
+		p, err := c.Download(ctx, a)
+		if errors.Is(err, ErrArchiveNotFound) {
+			c.debug("vision: %s not found, skipped", a.Path())
+			continue
+		}
+		if err != nil {
+			return paths, err
+		}
+		paths = append(paths, p)
+	}
+	return paths, nil
+}
+
+func (c *Client) get(ctx context.Context, p string) ([]byte, error) {
+	body, err := c.open(ctx, p)
+	if err != nil {
+		return nil, err
+	}
+	defer body.Close()
+	return io.ReadAll(body)
+}
+
+// download streams file p into a temporary file, hashing it on the way, and renames it to name
+// if its checksum is expected, so large archives are never held in memory
+func (c *Client) download(ctx context.Context, p, name, expected string) (err error) {
+	body, err := c.open(ctx, p)
+	if err != nil {
+		return err
+	}
+	defer body.Close()
+
+	tmp := name + ".tmp"
+	f, err := os.Create(tmp)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err != nil {
+			f.Close()
+			os.Remove(tmp)
+		}
+	}()
+	h := sha256.New()
+	if _, err = io.Copy(io.MultiWriter(f, h), body); err != nil {
+		return err
+	}
+	if hex.EncodeToString(h.Sum(nil)) != expected {
+		return ErrChecksumMismatch
+	}
+	if err = f.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp, name)
+}
+
+// open requests file p, the caller closes the returned body
+func (c *Client) open(ctx context.Context, p string) (io.ReadCloser, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, strings.TrimRight(c.BaseURL, "/")+"/"+p, nil)
+	if err != nil {
+		return nil, err
+	}
+	res, err := c.HTTPClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	if res.StatusCode == http.StatusNotFound {
+		res.Body.Close()
+		return nil, ErrArchiveNotFound
+	}
+	if res.StatusCode != http.StatusOK {
+		res.Body.Close()
+		return nil, fmt.Errorf("vision: unexpected status code %d for %s", res.StatusCode, p)
+	}
+	return res.Body, nil
+}
+
+// archiveDates returns dates of archives covering [start, end]
+func archiveDates(period PeriodType, start, end time.Time) []time.Time {
+	start, end = start.UTC(), end.UTC()
+	var dates []time.Time
+	if period == PeriodTypeMonthly {
+		for d := time.Date(start.Year(), start.Month(), 1, 0, 0, 0, 0, time.UTC); !d.After(end); d = d.AddDate(0, 1, 0) {
+			dates = append(dates, d)
+		}
+		return dates
+	}
+	for d := time.Date(start.Year(), start.Month(), start.Day(), 0, 0, 0, 0, time.UTC); !d.After(end); d = d.AddDate(0, 0, 1) {
+		dates = append(dates, d)
+	}
+	return dates
+}
+
+// parseChecksum parses checksum file content in 'sha256sum' format
+func parseChecksum(data []byte) (string, error) {
+	fields := strings.Fields(string(data))
+	if len(fields) == 0 || len(fields[0]) != sha256.Size*2 {
+		return "", fmt.Errorf("vision: invalid checksum file")
+	}
+	return strings.ToLower(fields[0]), nil
+}
+
+func readChecksumFile(name string) (string, error) {
+	data, err := os.ReadFile(name)
+	if err != nil {
+		return "", err
+	}
+	return parseChecksum(data)
+}
+
+func fileChecksum(name string) (string, error) {
+	f, err := os.Open(name)
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+
+	h := sha256.New()
+	if _, err := io.Copy(h, bufio.NewReader(f)); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
+
+// writeFileAtomic writes data into a temporary file and renames it to name
+func writeFileAtomic(name string, data []byte) error {
+	tmp := name + ".tmp"
+	if err := os.WriteFile(tmp, data, 0o644); err != nil {
+		return err
+	}
+	return os.Rename(tmp, name)
+}
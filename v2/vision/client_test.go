@@ -0,0 +1,178 @@
+package vision
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type clientTestSuite struct {
+	suite.Suite
+	server   *httptest.Server
+	files    map[string][]byte
+	requests []string
+	client   *Client
+}
+
+func TestClient(t *testing.T) {
+	suite.Run(t, new(clientTestSuite))
+}
+
+func (s *clientTestSuite) SetupTest() {
+	s.files = make(map[string][]byte)
+	s.requests = nil
+	s.server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		s.requests = append(s.requests, r.URL.Path)
+		data, ok := s.files[r.URL.Path]
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.Write(data)
+	}))
+	s.client = NewClient(s.T().TempDir())
+	s.client.BaseURL = s.server.URL
+}
+
+func (s *clientTestSuite) TearDownTest() {
+	s.server.Close()
+}
+
+func (s *clientTestSuite) publish(a Archive, content string) {
+	var buf bytes.Buffer
+	zw := zip.NewWriter(&buf)
+	w, err := zw.Create(a.FileName()[:len(a.FileName())-len(".zip")] + ".csv")
+	s.Require().NoError(err)
+	_, err = w.Write([]byte(content))
+	s.Require().NoError(err)
+	s.Require().NoError(zw.Close())
+
+	sum := sha256.Sum256(buf.Bytes())
+	s.files["/"+a.Path()] = buf.Bytes()
+	s.files["/"+a.Path()+".CHECKSUM"] = []byte(fmt.Sprintf("%s  %s\n", hex.EncodeToString(sum[:]), a.FileName()))
+}
+
+func (s *clientTestSuite) TestArchivePath() {
+	r := s.Require()
+	date := time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC)
+
+	r.Equal("data/futures/um/daily/klines/BTCUSDT/1m/BTCUSDT-1m-2024-01-02.zip", Archive{
+		Market: MarketTypeFutures, Period: PeriodTypeDaily, Data: DataTypeKlines, Symbol: "BTCUSDT", Interval: "1m", Date: date,
+	}.Path())
+	r.Equal("data/spot/monthly/trades/BTCUSDT/BTCUSDT-trades-2024-01.zip", Archive{
+		Market: MarketTypeSpot, Period: PeriodTypeMonthly, Data: DataTypeTrades, Symbol: "BTCUSDT", Date: date,
+	}.Path())
+	r.Equal("data/futures/um/monthly/fundingRate/BTCUSDT/BTCUSDT-fundingRate-2024-01.zip", Archive{
+		Market: MarketTypeFutures, Period: PeriodTypeMonthly, Data: DataTypeFundingRate, Symbol: "BTCUSDT", Date: date,
+	}.Path())
+}
+
+func (s *clientTestSuite) TestSyncKlines() {
+	r := s.Require()
+	template := Archive{Market: MarketTypeFutures, Period: PeriodTypeDaily, Data: DataTypeKlines, Symbol: "BTCUSDT", Interval: "1m"}
+	day1, day2 := template, template
+	day1.Date = time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	day2.Date = time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC)
+	s.publish(day1, "open_time,open,high,low,close,volume,close_time,quote_volume,count,taker_buy_volume,taker_buy_quote_volume,ignore\n"+
+		"1704067200000,42283.50,42298.50,42261.00,42297.80,178.328,1704067259999,7539034.58780,2108,106.813,4515775.71000,0\n")
+	s.publish(day2, "1704153600000,44183.40,44187.80,44150.20,44155.60,306.226,1704153659999,13525432.82850,3014,100.455,4437102.13040,0\n")
+
+	// third day is not published yet
+	paths, err := s.client.Sync(context.Background(), template, day1.Date, day2.Date.Add(24*time.Hour))
+	r.NoError(err)
+	r.Len(paths, 2)
+	r.Len(s.requests, 5)
+
+	klines, err := ParseKlines(paths[0])
+	r.NoError(err)
+	r.Equal([]*Kline{{
+		OpenTime:                 1704067200000,
+		Open:                     "42283.50",
+		High:                     "42298.50",
+		Low:                      "42261.00",
+		Close:                    "42297.80",
+		Volume:                   "178.328",
+		CloseTime:                1704067259999,
+		QuoteAssetVolume:         "7539034.58780",
+		TradeNum:                 2108,
+		TakerBuyBaseAssetVolume:  "106.813",
+		TakerBuyQuoteAssetVolume: "4515775.71000",
+	}}, klines)
+
+	klines, err = ParseKlines(paths[1])
+	r.NoError(err)
+	r.Len(klines, 1)
+
+	// synced archives are not downloaded again
+	s.requests = nil
+	paths, err = s.client.Sync(context.Background(), template, day1.Date, day2.Date)
+	r.NoError(err)
+	r.Len(paths, 2)
+	r.Empty(s.requests)
+}
+
+func (s *clientTestSuite) TestChecksumMismatch() {
+	r := s.Require()
+	a := Archive{Market: MarketTypeSpot, Period: PeriodTypeDaily, Data: DataTypeTrades, Symbol: "BTCUSDT", Date: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)}
+	s.publish(a, "1,42283.5,0.1,4228.35,1704067200000,true,true\n")
+	s.files["/"+a.Path()] = append(s.files["/"+a.Path()], 0)
+
+	_, err := s.client.Download(context.Background(), a)
+	r.Equal(ErrChecksumMismatch, err)
+	_, err = os.Stat(s.client.LocalPath(a))
+	r.True(os.IsNotExist(err))
+	_, err = os.Stat(s.client.LocalPath(a) + ".tmp")
+	r.True(os.IsNotExist(err), "partial download is removed")
+}
+
+func (s *clientTestSuite) TestParse() {
+	r := s.Require()
+	date := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	trades := Archive{Market: MarketTypeSpot, Period: PeriodTypeDaily, Data: DataTypeTrades, Symbol: "BTCUSDT", Date: date}
+	s.publish(trades, "1,42283.5,0.1,4228.35,1704067200000,True,True\n")
+	p, err := s.client.Download(context.Background(), trades)
+	r.NoError(err)
+	r.Equal(filepath.Join(s.client.Dir, "data", "spot", "daily", "trades", "BTCUSDT", "BTCUSDT-trades-2024-01-01.zip"), p)
+	parsedTrades, err := ParseTrades(p)
+	r.NoError(err)
+	r.Equal([]*Trade{{ID: 1, Price: "42283.5", Quantity: "0.1", QuoteQuantity: "4228.35", Time: 1704067200000, IsBuyerMaker: true}}, parsedTrades)
+
+	aggTrades := Archive{Market: MarketTypeFutures, Period: PeriodTypeDaily, Data: DataTypeAggTrades, Symbol: "BTCUSDT", Date: date}
+	s.publish(aggTrades, "agg_trade_id,price,quantity,first_trade_id,last_trade_id,transact_time,is_buyer_maker\n1,42283.5,0.1,10,12,1704067200000,false\n")
+	p, err = s.client.Download(context.Background(), aggTrades)
+	r.NoError(err)
+	parsedAggTrades, err := ParseAggTrades(p)
+	r.NoError(err)
+	r.Equal([]*AggTrade{{AggTradeID: 1, Price: "42283.5", Quantity: "0.1", FirstTradeID: 10, LastTradeID: 12, Timestamp: 1704067200000}}, parsedAggTrades)
+
+	funding := Archive{Market: MarketTypeFutures, Period: PeriodTypeMonthly, Data: DataTypeFundingRate, Symbol: "BTCUSDT", Date: date}
+	s.publish(funding, "calc_time,funding_interval_hours,last_funding_rate\n1704067200000,8,0.00037409\n")
+	p, err = s.client.Download(context.Background(), funding)
+	r.NoError(err)
+	rates, err := ParseFundingRates(p)
+	r.NoError(err)
+	r.Equal([]*FundingRate{{FundingTime: 1704067200000, FundingIntervalHours: 8, FundingRate: "0.00037409"}}, rates)
+
+	_, err = ParseFundingRates(s.client.LocalPath(trades))
+	r.Error(err)
+}
+
+func (s *clientTestSuite) TestArchiveDates() {
+	r := s.Require()
+	start := time.Date(2023, 12, 15, 10, 0, 0, 0, time.UTC)
+	end := time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC)
+	r.Len(archiveDates(PeriodTypeMonthly, start, end), 3)
+	r.Len(archiveDates(PeriodTypeDaily, start, end), 49)
+}
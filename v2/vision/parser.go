@@ -0,0 +1,218 @@
+package vision
+
+import (
+	"archive/zip"
+	"encoding/csv"
+	"fmt"
+	"io"
+	"strconv"
+)
+
+// Kline define a row of klines archive
+type Kline struct {
+	OpenTime                 int64
+	Open                     string
+	High                     string
+	Low                      string
+	Close                    string
+	Volume                   string
+	CloseTime                int64
+	QuoteAssetVolume         string
+	TradeNum                 int64
+	TakerBuyBaseAssetVolume  string
+	TakerBuyQuoteAssetVolume string
+}
+
+// Trade define a row of trades archive
+type Trade struct {
+	ID            int64
+	Price         string
+	Quantity      string
+	QuoteQuantity string
+	Time          int64
+	IsBuyerMaker  bool
+}
+
+// AggTrade define a row of aggTrades archive
+type AggTrade struct {
+	AggTradeID   int64
+	Price        string
+	Quantity     string
+	FirstTradeID int64
+	LastTradeID  int64
+	Timestamp    int64
+	IsBuyerMaker bool
+}
+
+// FundingRate define a row of fundingRate archive
+type FundingRate struct {
+	FundingTime          int64
+	FundingIntervalHours int64
+	FundingRate          string
+}
+
+// ParseKlines reads klines from archive file
+func ParseKlines(name string) ([]*Kline, error) {
+	var res []*Kline
+	err := readArchive(name, 11, func(row []string) (err error) {
+		k := &Kline{
+			Open:                     row[1],
+			High:                     row[2],
+			Low:                      row[3],
+			Close:                    row[4],
+			Volume:                   row[5],
+			QuoteAssetVolume:         row[7],
+			TakerBuyBaseAssetVolume:  row[9],
+			TakerBuyQuoteAssetVolume: row[10],
+		}
+		if k.OpenTime, err = strconv.ParseInt(row[0], 10, 64); err != nil {
+			return err
+		}
+		if k.CloseTime, err = strconv.ParseInt(row[6], 10, 64); err != nil {
+			return err
+		}
+		if k.TradeNum, err = strconv.ParseInt(row[8], 10, 64); err != nil {
+			return err
+		}
+		res = append(res, k)
+		return nil
+	})
+	return res, err
+}
+
+// ParseTrades reads trades from archive file
+func ParseTrades(name string) ([]*Trade, error) {
+	var res []*Trade
+	err := readArchive(name, 6, func(row []string) (err error) {
+		t := &Trade{
+			Price:         row[1],
+			Quantity:      row[2],
+			QuoteQuantity: row[3],
+		}
+		if t.ID, err = strconv.ParseInt(row[0], 10, 64); err != nil {
+			return err
+		}
+		if t.Time, err = strconv.ParseInt(row[4], 10, 64); err != nil {
+			return err
+		}
+		if t.IsBuyerMaker, err = parseBool(row[5]); err != nil {
+			return err
+		}
+		res = append(res, t)
+		return nil
+	})
+	return res, err
+}
+
+// ParseAggTrades reads aggregate trades from archive file
+func ParseAggTrades(name string) ([]*AggTrade, error) {
+	var res []*AggTrade
+	err := readArchive(name, 7, func(row []string) (err error) {
+		t := &AggTrade{
+			Price:    row[1],
+			Quantity: row[2],
+		}
+		if t.AggTradeID, err = strconv.ParseInt(row[0], 10, 64); err != nil {
+			return err
+		}
+		if t.FirstTradeID, err = strconv.ParseInt(row[3], 10, 64); err != nil {
+			return err
+		}
+		if t.LastTradeID, err = strconv.ParseInt(row[4], 10, 64); err != nil {
+			return err
+		}
+		if t.Timestamp, err = strconv.ParseInt(row[5], 10, 64); err != nil {
+			return err
+		}
+		if t.IsBuyerMaker, err = parseBool(row[6]); err != nil {
+			return err
+		}
+		res = append(res, t)
+		return nil
+	})
+	return res, err
+}
+
+// ParseFundingRates reads funding rates from archive file
+func ParseFundingRates(name string) ([]*FundingRate, error) {
+	var res []*FundingRate
+	err := readArchive(name, 3, func(row []string) (err error) {
+		f := &FundingRate{
+			FundingRate: row[2],
+		}
+		if f.FundingTime, err = strconv.ParseInt(row[0], 10, 64); err != nil {
+			return err
+		}
+		if f.FundingIntervalHours, err = strconv.ParseInt(row[1], 10, 64); err != nil {
+			return err
+		}
+		res = append(res, f)
+		return nil
+	})
+	return res, err
+}
+
+// readArchive calls f with every data row of the CSV files inside zip archive,
+// header rows are skipped since only some archives have them
+func readArchive(name string, minColumns int, f func(row []string) error) error {
+	zr, err := zip.OpenReader(name)
+	if err != nil {
+		return err
+	}
+	defer zr.Close()
+
+	for _, file := range zr.File {
+		rc, err := file.Open()
+		if err != nil {
+			return err
+		}
+		err = readCSV(rc, minColumns, f)
+		rc.Close()
+		if err != nil {
+			return fmt.Errorf("vision: %s: %w", file.Name, err)
+		}
+	}
+	return nil
+}
+
+func readCSV(r io.Reader, minColumns int, f func(row []string) error) error {
+	cr := csv.NewReader(r)
+	cr.FieldsPerRecord = -1
+	cr.ReuseRecord = true
+	for line := 1; ; line++ {
+		row, err := cr.Read()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		if line == 1 && isHeader(row) {
+			continue
+		}
+		if len(row) < minColumns {
+			return fmt.Errorf("line %d: expected at least %d columns, got %d", line, minColumns, len(row))
+		}
+		if err := f(row); err != nil {
+			return fmt.Errorf("line %d: %w", line, err)
+		}
+	}
+}
+
+func isHeader(row []string) bool {
+	if len(row) == 0 {
+		return false
+	}
+	_, err := strconv.ParseFloat(row[0], 64)
+	return err != nil
+}
+
+func parseBool(s string) (bool, error) {
+	switch s {
+	case "true", "True", "TRUE":
+		return true, nil
+	case "false", "False", "FALSE":
+		return false, nil
+	}
+	return strconv.ParseBool(s)
+}
@@ -6,6 +6,8 @@ import (
 	"time"
 
 	stdjson "encoding/json"
+
+	"github.com/gorilla/websocket"
 )
 
 // Endpoints
@@ -14,6 +16,8 @@ const (
 	baseWsTestnetURL       = "wss://testnet.binance.vision/ws"
 	baseCombinedMainURL    = "wss://stream.binance.com:9443/stream?streams="
 	baseCombinedTestnetURL = "wss://testnet.binance.vision/stream?streams="
+	BaseWsApiMainURL       = "wss://ws-api.binance.com:443/ws-api/v3"
+	BaseWsApiTestnetURL    = "wss://testnet.binance.vision/ws-api/v3"
 )
 
 var (
@@ -21,6 +25,9 @@ var (
 	WebsocketTimeout = time.Second * 60
 	// WebsocketKeepalive enables sending ping/pong messages to check the connection stability
 	WebsocketKeepalive = false
+	// WebsocketTimeoutReadWriteConnection is an interval for sending ping/pong messages if WebsocketKeepalive is enabled
+	// using for websocket API (read/write)
+	WebsocketTimeoutReadWriteConnection = time.Second * 10
 )
 
 // getWsEndpoint return the base endpoint of the WS according the UseTestnet flag
@@ -39,6 +46,20 @@ func getCombinedEndpoint() string {
 	return baseCombinedMainURL
 }
 
+// getWsApiEndpoint return the base endpoint of the API WS according the UseTestnet flag
+func getWsApiEndpoint() string {
+	if UseTestnet {
+		return BaseWsApiTestnetURL
+	}
+	return BaseWsApiMainURL
+}
+
+// WsApiInitReadWriteConn create and serve connection
+func WsApiInitReadWriteConn() (*websocket.Conn, error) {
+	cfg := newWsConfig(getWsApiEndpoint())
+	return WsGetReadWriteConnection(cfg)
+}
+
 // WsPartialDepthEvent define websocket partial depth book event
 type WsPartialDepthEvent struct {
 	Symbol       string
@@ -569,56 +590,59 @@ func WsUserDataServe(listenKey string, handler WsUserDataHandler, errHandler Err
 	endpoint := fmt.Sprintf("%s/%s", getWsEndpoint(), listenKey)
 	cfg := newWsConfig(endpoint)
 	wsHandler := func(message []byte) {
-		j, err := newJSON(message)
+		event, err := parseUserDataEvent(message)
 		if err != nil {
 			errHandler(err)
 			return
 		}
+		handler(event)
+	}
+	return wsServe(cfg, wsHandler, errHandler)
+}
 
-		event := new(WsUserDataEvent)
+// parseUserDataEvent parses user data event message into WsUserDataEvent
+func parseUserDataEvent(message []byte) (*WsUserDataEvent, error) {
+	j, err := newJSON(message)
+	if err != nil {
+		return nil, err
+	}
+
+	event := new(WsUserDataEvent)
+
+	err = json.Unmarshal(message, event)
+	if err != nil {
+		return nil, err
+	}
 
-		err = json.Unmarshal(message, event)
+	switch UserDataEventType(j.Get("e").MustString()) {
+	case UserDataEventTypeOutboundAccountPosition:
+		err = json.Unmarshal(message, &event.AccountUpdate)
 		if err != nil {
-			errHandler(err)
-			return
+			return nil, err
 		}
-
-		switch UserDataEventType(j.Get("e").MustString()) {
-		case UserDataEventTypeOutboundAccountPosition:
-			err = json.Unmarshal(message, &event.AccountUpdate)
-			if err != nil {
-				errHandler(err)
-				return
-			}
-		case UserDataEventTypeBalanceUpdate:
-			err = json.Unmarshal(message, &event.BalanceUpdate)
-			if err != nil {
-				errHandler(err)
-				return
-			}
-		case UserDataEventTypeExecutionReport:
-			err = json.Unmarshal(message, &event.OrderUpdate)
-			if err != nil {
-				errHandler(err)
-				return
-			}
-			// Unmarshal has case sensitive problem
-			event.TransactionTime = j.Get("T").MustInt64()
-			event.OrderUpdate.TransactionTime = j.Get("T").MustInt64()
-			event.OrderUpdate.Id = j.Get("i").MustInt64()
-			event.OrderUpdate.TradeId = j.Get("t").MustInt64()
-			event.OrderUpdate.FeeAsset = j.Get("N").MustString()
-		case UserDataEventTypeListStatus:
-			err = json.Unmarshal(message, &event.OCOUpdate)
-			if err != nil {
-				errHandler(err)
-				return
-			}
+	case UserDataEventTypeBalanceUpdate:
+		err = json.Unmarshal(message, &event.BalanceUpdate)
+		if err != nil {
+			return nil, err
+		}
+	case UserDataEventTypeExecutionReport:
+		err = json.Unmarshal(message, &event.OrderUpdate)
+		if err != nil {
+			return nil, err
+		}
+		// Unmarshal has case sensitive problem
+		event.TransactionTime = j.Get("T").MustInt64()
+		event.OrderUpdate.TransactionTime = j.Get("T").MustInt64()
+		event.OrderUpdate.Id = j.Get("i").MustInt64()
+		event.OrderUpdate.TradeId = j.Get("t").MustInt64()
+		event.OrderUpdate.FeeAsset = j.Get("N").MustString()
+	case UserDataEventTypeListStatus:
+		err = json.Unmarshal(message, &event.OCOUpdate)
+		if err != nil {
+			return nil, err
 		}
-
-		handler(event)
 	}
-	return wsServe(cfg, wsHandler, errHandler)
+	return event, nil
 }
 
 // WsMarketStatHandler handle websocket that push single market statistics for 24hr